@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+)
+
+// NewConvertCommand creates the convert command, migrating a policy to another version
+func NewConvertCommand() *cobra.Command {
+	from := v1alpha1.SchemeGroupVersion.Version
+	to := v1alpha2.SchemeGroupVersion.Version
+	convertCmd := &cobra.Command{
+		Use:   "convert [policy-file]",
+		Short: "Convert a descheduler policy to another version",
+		Long: `Converts a v1alpha1 policy, read from the given file or from the standard input, to a v1alpha2 policy
+printed as YAML. Every enabled strategy is converted to a profile enabling the plugin of the same name along
+with the DefaultEvictor. The fields without a v1alpha2 equivalent are dropped and reported on the standard error.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if from != v1alpha1.SchemeGroupVersion.Version || to != v1alpha2.SchemeGroupVersion.Version {
+				return fmt.Errorf("converting a policy from %q to %q is not supported, only from %q to %q is", from, to, v1alpha1.SchemeGroupVersion.Version, v1alpha2.SchemeGroupVersion.Version)
+			}
+
+			var data []byte
+			var err error
+			if len(args) == 0 || args[0] == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("reading the policy: %w", err)
+			}
+
+			policy, err := v1alpha1.Decode(data)
+			if err != nil {
+				return err
+			}
+			converted, dropped, err := v1alpha1.ConvertToV1alpha2(policy)
+			if err != nil {
+				return err
+			}
+			out, err := encodePolicy(converted)
+			if err != nil {
+				return err
+			}
+			for _, field := range dropped {
+				fmt.Fprintf(cmd.ErrOrStderr(), "unconvertible field dropped: %s\n", field)
+			}
+			_, err = cmd.OutOrStdout().Write(out)
+			return err
+		},
+	}
+	convertCmd.Flags().StringVar(&from, "from", from, "Version of the policy to convert")
+	convertCmd.Flags().StringVar(&to, "to", to, "Version to convert the policy to")
+	return convertCmd
+}
+
+// encodePolicy encodes the policy as YAML without the unset fields, which the arguments of the plugins
+// and the plugin sets encode as null or as empty objects. Empty plugin arguments are kept.
+func encodePolicy(policy *v1alpha2.DeschedulerPolicy) ([]byte, error) {
+	raw, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("encoding the policy: %w", err)
+	}
+	var document interface{}
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return nil, fmt.Errorf("encoding the policy: %w", err)
+	}
+	return yaml.Marshal(pruneUnset(document))
+}
+
+func pruneUnset(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			field = pruneUnset(field)
+			if object, ok := field.(map[string]interface{}); field == nil || ok && len(object) == 0 && key != "args" {
+				delete(value, key)
+				continue
+			}
+			value[key] = field
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = pruneUnset(item)
+		}
+	}
+	return value
+}
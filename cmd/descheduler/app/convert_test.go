@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+)
+
+const v1alpha1Policy = `apiVersion: "descheduler/v1alpha1"
+kind: "DeschedulerPolicy"
+nodeSelector: "node=ready"
+evictLocalStoragePods: true
+strategies:
+  "RemoveDuplicates":
+    enabled: true
+    weight: 2
+  "LowNodeUtilization":
+    enabled: true
+    params:
+      nodeResourceUtilizationThresholds:
+        thresholds:
+          "cpu": 20
+        targetThresholds:
+          "cpu": 50
+  "PodLifeTime":
+    enabled: true
+    params:
+      podLifeTime:
+        maxPodLifeTimeSeconds: 86400
+      namespaces:
+        include: ["default"]
+      thresholdPriorityClassName: "system-cluster-critical"
+      nodeFit: true
+  "RemoveFailedPods":
+    enabled: false
+  "RemovePodsViolatingNodeTaints":
+    enabled: true
+    params:
+      podLifeTime:
+        maxPodLifeTimeSeconds: 60
+`
+
+func TestConvertCommand(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		policy            string
+		expectError       bool
+		expectProfiles    []string
+		expectUnconverted []string
+	}{
+		{
+			name:   "v1alpha1 policy is converted to v1alpha2",
+			policy: v1alpha1Policy,
+			expectProfiles: []string{
+				"strategy-LowNodeUtilization-profile",
+				"strategy-PodLifeTime-profile",
+				"strategy-RemoveDuplicates-profile",
+				"strategy-RemovePodsViolatingNodeTaints-profile",
+			},
+			expectUnconverted: []string{
+				"strategies.RemoveDuplicates.weight",
+				"strategies.RemovePodsViolatingNodeTaints.params.podLifeTime",
+			},
+		},
+		{
+			name:        "unsupported target version is rejected",
+			args:        []string{"--to", "v1alpha3"},
+			policy:      v1alpha1Policy,
+			expectError: true,
+		},
+		{
+			name:        "v1alpha2 policy is rejected",
+			policy:      "apiVersion: \"descheduler/v1alpha2\"\nkind: \"DeschedulerPolicy\"\n",
+			expectError: true,
+		},
+		{
+			name:        "unknown field is rejected",
+			policy:      v1alpha1Policy + "evictDaemonSetPods: true\n",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			cmd := NewConvertCommand()
+			cmd.SetIn(strings.NewReader(tc.policy))
+			cmd.SetOut(&out)
+			cmd.SetErr(&errOut)
+			cmd.SetArgs(tc.args)
+			err := cmd.Execute()
+			if tc.expectError != (err != nil) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+
+			for _, field := range tc.expectUnconverted {
+				if !strings.Contains(errOut.String(), field) {
+					t.Errorf("Expected %s to be reported as unconvertible, got:\n%s", field, errOut.String())
+				}
+			}
+			if lines := strings.Count(errOut.String(), "\n"); lines != len(tc.expectUnconverted) {
+				t.Errorf("Expected %d unconvertible fields, got:\n%s", len(tc.expectUnconverted), errOut.String())
+			}
+
+			// The converted policy must be accepted by the descheduler
+			if err := setupPlugins(nil); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			file := filepath.Join(t.TempDir(), "policy.yaml")
+			if err := os.WriteFile(file, out.Bytes(), 0o600); err != nil {
+				t.Fatalf("Unable to write the converted policy: %v", err)
+			}
+			policy, err := descheduler.LoadPolicyConfig(file, true, fakeclientset.NewSimpleClientset(), pluginregistry.PluginRegistry)
+			if err != nil {
+				t.Fatalf("Unable to load the converted policy: %v\n%s", err, out.String())
+			}
+			var profiles []string
+			for _, profile := range policy.Profiles {
+				profiles = append(profiles, profile.Name)
+			}
+			if strings.Join(profiles, ",") != strings.Join(tc.expectProfiles, ",") {
+				t.Errorf("Expected profiles %v, got %v", tc.expectProfiles, profiles)
+			}
+		})
+	}
+}
@@ -18,17 +18,20 @@ limitations under the License.
 package options
 
 import (
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apiserveroptions "k8s.io/apiserver/pkg/server/options"
 	clientset "k8s.io/client-go/kubernetes"
+	cliflag "k8s.io/component-base/cli/flag"
 	componentbaseconfig "k8s.io/component-base/config"
 	componentbaseoptions "k8s.io/component-base/config/options"
 	"sigs.k8s.io/descheduler/pkg/apis/componentconfig"
 	"sigs.k8s.io/descheduler/pkg/apis/componentconfig/v1alpha1"
 	deschedulerscheme "sigs.k8s.io/descheduler/pkg/descheduler/scheme"
+	"sigs.k8s.io/descheduler/pkg/features"
 	"sigs.k8s.io/descheduler/pkg/tracing"
 )
 
@@ -45,6 +48,30 @@ type DeschedulerServer struct {
 	SecureServing  *apiserveroptions.SecureServingOptionsWithLoopback
 	DisableMetrics bool
 	EnableHTTP2    bool
+	// CycleSummaryFormat is the format of the summary logged at the end of each descheduling cycle
+	CycleSummaryFormat string
+	// CycleSummaryDestinations lists in the cycle summary the pods the plugins tried to evict along with
+	// the nodes they are predicted to get scheduled onto
+	CycleSummaryDestinations bool
+	// InPlacePodVerticalScaling makes the requests of pods account for in-place resizes
+	InPlacePodVerticalScaling bool
+	// EventVerbosity tells which events are emitted
+	EventVerbosity string
+	// NamespaceEventVerbosity overrides the event verbosity of the events about objects of the given namespaces
+	NamespaceEventVerbosity map[string]string
+	// PolicyStrict fails the loading of the policy config file on the fields which are not part of
+	// the policy, instead of ignoring them. The unknown fields of the plugin arguments always fail.
+	PolicyStrict bool
+	// ServerSideDryRun validates the evictions against the API server in dry run mode
+	ServerSideDryRun bool
+	// DryRunPreviewAnnotations annotates the pods the descheduler would evict in dry run mode
+	DryRunPreviewAnnotations bool
+	// FeatureGates enables or disables the experimental features, taking precedence over the featureGates of the policy
+	FeatureGates map[string]bool
+	// InformerResyncPeriod is how often the informers resync their cache, taking precedence over the policy when set
+	InformerResyncPeriod time.Duration
+	// EvictionTimeout bounds each request to the eviction API, taking precedence over the policy when set
+	EvictionTimeout time.Duration
 }
 
 // NewDeschedulerServer creates a new DeschedulerServer with default parameters
@@ -60,6 +87,9 @@ func NewDeschedulerServer() (*DeschedulerServer, error) {
 	return &DeschedulerServer{
 		DeschedulerConfiguration: *cfg,
 		SecureServing:            secureServing,
+		CycleSummaryFormat:       "none",
+		EventVerbosity:           "per-eviction",
+		PolicyStrict:             true,
 	}, nil
 }
 
@@ -90,10 +120,15 @@ func (rs *DeschedulerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&rs.DeschedulingInterval, "descheduling-interval", rs.DeschedulingInterval, "Time interval between two consecutive descheduler executions. Setting this value instructs the descheduler to run in a continuous loop at the interval specified.")
 	fs.StringVar(&rs.ClientConnection.Kubeconfig, "kubeconfig", rs.ClientConnection.Kubeconfig, "File with kube configuration. Deprecated, use client-connection-kubeconfig instead.")
 	fs.StringVar(&rs.ClientConnection.Kubeconfig, "client-connection-kubeconfig", rs.ClientConnection.Kubeconfig, "File path to kube configuration for interacting with kubernetes apiserver.")
-	fs.Float32Var(&rs.ClientConnection.QPS, "client-connection-qps", rs.ClientConnection.QPS, "QPS to use for interacting with kubernetes apiserver.")
-	fs.Int32Var(&rs.ClientConnection.Burst, "client-connection-burst", rs.ClientConnection.Burst, "Burst to use for interacting with kubernetes apiserver.")
+	fs.Float32Var(&rs.ClientConnection.QPS, "client-connection-qps", rs.ClientConnection.QPS, "QPS to use for interacting with kubernetes apiserver, overriding the clientConnection.qps of the policy.")
+	fs.Int32Var(&rs.ClientConnection.Burst, "client-connection-burst", rs.ClientConnection.Burst, "Burst to use for interacting with kubernetes apiserver, overriding the clientConnection.burst of the policy.")
+	fs.DurationVar(&rs.InformerResyncPeriod, "informer-resync-period", rs.InformerResyncPeriod, "How often the informers resync their cache, overriding the informerResyncPeriod of the policy. Resyncs are disabled by default.")
+	fs.DurationVar(&rs.EvictionTimeout, "eviction-timeout", rs.EvictionTimeout, "Timeout of each request to the eviction API, overriding the evictionTimeout of the policy. Unbounded by default.")
 	fs.StringVar(&rs.PolicyConfigFile, "policy-config-file", rs.PolicyConfigFile, "File with descheduler policy configuration.")
+	fs.BoolVar(&rs.PolicyStrict, "policy-strict", rs.PolicyStrict, "Fail on the fields of the policy config file which are not part of the policy nor of the plugin arguments, reporting their path and line. When disabled, such fields of the policy are logged and ignored, the unknown fields of the plugin arguments still fail.")
 	fs.BoolVar(&rs.DryRun, "dry-run", rs.DryRun, "Execute descheduler in dry run mode.")
+	fs.BoolVar(&rs.ServerSideDryRun, "server-side-dry-run", rs.ServerSideDryRun, "In dry run mode, request the evictions from the API server with dryRun=All so admission webhooks and PodDisruptionBudgets validate them without evicting anything. Requires --dry-run.")
+	fs.BoolVar(&rs.DryRunPreviewAnnotations, "dry-run-preview-annotations", rs.DryRunPreviewAnnotations, "In dry run mode, annotate the pods the descheduler would evict with descheduler.io/would-evict=<strategy>, and remove the annotation once they would no longer be evicted. Requires --dry-run.")
 	fs.BoolVar(&rs.DisableMetrics, "disable-metrics", rs.DisableMetrics, "Disables metrics. The metrics are by default served through https://localhost:10258/metrics. Secure address, resp. port can be changed through --bind-address, resp. --secure-port flags.")
 	fs.StringVar(&rs.Tracing.CollectorEndpoint, "otel-collector-endpoint", "", "Set this flag to the OpenTelemetry Collector Service Address")
 	fs.StringVar(&rs.Tracing.TransportCert, "otel-transport-ca-cert", "", "Path of the CA Cert that can be used to generate the client Certificate for establishing secure connection to the OTEL in gRPC mode")
@@ -102,6 +137,15 @@ func (rs *DeschedulerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.Float64Var(&rs.Tracing.SampleRate, "otel-sample-rate", 1.0, "Sample rate to collect the Traces")
 	fs.BoolVar(&rs.Tracing.FallbackToNoOpProviderOnError, "otel-fallback-no-op-on-error", false, "Fallback to NoOp Tracer in case of error")
 	fs.BoolVar(&rs.EnableHTTP2, "enable-http2", false, "If http/2 should be enabled for the metrics and health check")
+	fs.StringVar(&rs.CycleSummaryFormat, "cycle-summary-format", rs.CycleSummaryFormat, "Format of the per plugin summary logged at the end of each descheduling cycle. One of: table, json, none.")
+	fs.BoolVar(&rs.CycleSummaryDestinations, "cycle-summary-destinations", rs.CycleSummaryDestinations, "List in the cycle summary and the status endpoint the pods the plugins tried to evict, along with the nodes they are predicted to get scheduled onto by a best effort fit simulation. Combined with --dry-run, it helps evaluating whether the evictions would improve the placement of the pods.")
+	fs.BoolVar(&rs.InPlacePodVerticalScaling, "in-place-pod-vertical-scaling", rs.InPlacePodVerticalScaling, "Use the resources allocated to the containers instead of the requests of their spec when computing the utilization of nodes and whether pods fit on them. Enable it on clusters with the InPlacePodVerticalScaling feature enabled.")
+
+	fs.StringVar(&rs.EventVerbosity, "event-verbosity", rs.EventVerbosity, "Events emitted by the descheduler. One of: per-eviction (an event for each evicted pod), per-cycle (warnings and an event summing up each descheduling cycle per namespace), errors-only (warnings only, e.g. failed evictions), none.")
+	fs.StringToStringVar(&rs.NamespaceEventVerbosity, "namespace-event-verbosity", rs.NamespaceEventVerbosity, "Event verbosity of the events about objects of the given namespaces, overriding --event-verbosity, e.g. kube-system=none,team-a=per-cycle.")
+
+	fs.Var(cliflag.NewMapStringBool(&rs.FeatureGates), "feature-gates", "A set of key=value pairs that describe feature gates for alpha/experimental features, overriding the featureGates of the policy. "+
+		"Options are:\n"+strings.Join(features.DefaultFeatureGate.KnownFeatures(), "\n"))
 
 	componentbaseoptions.BindLeaderElectionFlags(&rs.LeaderElection, fs)
 
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+)
+
+// NewPluginsCommand creates the plugins command, listing the out-of-tree plugins
+// registered by the given options as well
+func NewPluginsCommand(opts ...Option) *cobra.Command {
+	pluginsCmd := &cobra.Command{
+		Use:   "plugins",
+		Short: "Plugins of descheduler",
+		Long:  `Lists the plugins compiled into descheduler.`,
+	}
+	pluginsCmd.AddCommand(newPluginsDescribeCommand(opts))
+	return pluginsCmd
+}
+
+func newPluginsDescribeCommand(opts []Option) *cobra.Command {
+	describeCmd := &cobra.Command{
+		Use:   "describe [plugin...]",
+		Short: "Describe the plugins of descheduler",
+		Long: `Prints, as JSON, the description of the given plugins, or of all the plugins when none is given:
+their extension points, the JSON schema of their arguments and their default arguments.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := setupPlugins(opts); err != nil {
+				return err
+			}
+
+			var descriptions []pluginregistry.PluginDescription
+			if len(args) == 0 {
+				var err error
+				if descriptions, err = pluginregistry.PluginRegistry.Describe(); err != nil {
+					return err
+				}
+			}
+			for _, name := range args {
+				description, err := pluginregistry.PluginRegistry.DescribePlugin(name)
+				if err != nil {
+					return err
+				}
+				descriptions = append(descriptions, description)
+			}
+
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(descriptions)
+		},
+	}
+	return describeCmd
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+)
+
+// Option configures the plugins of the descheduler command, so out-of-tree plugins
+// can be compiled into a descheduler binary without forking it
+type Option func(registry pluginregistry.Registry) error
+
+// WithPlugin registers an out-of-tree plugin along with the type of its arguments,
+// their validation and their defaulting. The name must not collide with another plugin.
+func WithPlugin(
+	name string,
+	builder pluginregistry.PluginBuilder,
+	pluginType interface{},
+	exampleArg runtime.Object,
+	validator pluginregistry.PluginArgValidator,
+	defaulter pluginregistry.PluginArgDefaulter,
+) Option {
+	return func(registry pluginregistry.Registry) error {
+		if _, ok := registry[name]; ok {
+			return fmt.Errorf("plugin %q is already registered", name)
+		}
+		pluginregistry.Register(name, builder, pluginType, exampleArg, validator, defaulter, registry)
+		return nil
+	}
+}
+
+// setupPlugins registers the in-tree plugins followed by the out-of-tree ones
+func setupPlugins(opts []Option) error {
+	descheduler.SetupPlugins()
+	for _, opt := range opts {
+		if err := opt(pluginregistry.PluginRegistry); err != nil {
+			return fmt.Errorf("registering out-of-tree plugin: %w", err)
+		}
+	}
+	return nil
+}
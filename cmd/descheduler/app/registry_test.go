@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+func withPodLifeTime(name string) Option {
+	return WithPlugin(name, podlifetime.New, &podlifetime.PodLifeTime{}, &podlifetime.PodLifeTimeArgs{}, podlifetime.ValidatePodLifeTimeArgs, podlifetime.SetDefaults_PodLifeTimeArgs)
+}
+
+func TestWithPlugin(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		expectError bool
+	}{
+		{
+			name: "out-of-tree plugin is registered",
+			opts: []Option{withPodLifeTime("CustomPodLifeTime")},
+		},
+		{
+			name:        "name of an in-tree plugin is rejected",
+			opts:        []Option{withPodLifeTime(podlifetime.PluginName)},
+			expectError: true,
+		},
+		{
+			name:        "out-of-tree plugin registered twice is rejected",
+			opts:        []Option{withPodLifeTime("CustomPodLifeTime"), withPodLifeTime("CustomPodLifeTime")},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := setupPlugins(tc.opts)
+			if tc.expectError != (err != nil) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if err != nil {
+				return
+			}
+			if _, ok := pluginregistry.PluginRegistry["CustomPodLifeTime"]; !ok {
+				t.Errorf("Expected the out-of-tree plugin to be registered")
+			}
+			if _, ok := pluginregistry.PluginRegistry[podlifetime.PluginName]; !ok {
+				t.Errorf("Expected the in-tree plugins to be registered")
+			}
+		})
+	}
+}
+
+func TestPluginsCommandDescribesOutOfTreePlugins(t *testing.T) {
+	var out bytes.Buffer
+	cmd := NewPluginsCommand(withPodLifeTime("CustomPodLifeTime"))
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"describe", "CustomPodLifeTime"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var descriptions []pluginregistry.PluginDescription
+	if err := json.Unmarshal(out.Bytes(), &descriptions); err != nil {
+		t.Fatalf("Unable to decode the descriptions: %v", err)
+	}
+	if len(descriptions) != 1 || descriptions[0].Name != "CustomPodLifeTime" {
+		t.Fatalf("Unexpected descriptions: %v", descriptions)
+	}
+	if points := descriptions[0].ExtensionPoints; len(points) != 1 || points[0] != frameworktypes.DescheduleExtensionPoint {
+		t.Errorf("Unexpected extension points: %v", points)
+	}
+}
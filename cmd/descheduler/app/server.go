@@ -26,7 +26,11 @@ import (
 	"k8s.io/apiserver/pkg/server/healthz"
 
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/descheduler"
+	"sigs.k8s.io/descheduler/pkg/features"
+	"sigs.k8s.io/descheduler/pkg/framework/logging"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	"sigs.k8s.io/descheduler/pkg/tracing"
 
 	"github.com/spf13/cobra"
@@ -36,22 +40,20 @@ import (
 	apiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/apiserver/pkg/server/mux"
 	restclient "k8s.io/client-go/rest"
-	"k8s.io/component-base/featuregate"
 	"k8s.io/component-base/logs"
 	logsapi "k8s.io/component-base/logs/api/v1"
 	_ "k8s.io/component-base/logs/json/register"
-	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/klog/v2"
 )
 
 // NewDeschedulerCommand creates a *cobra.Command object with default parameters
-func NewDeschedulerCommand(out io.Writer) *cobra.Command {
+// and the out-of-tree plugins registered by the given options
+func NewDeschedulerCommand(out io.Writer, opts ...Option) *cobra.Command {
 	s, err := options.NewDeschedulerServer()
 	if err != nil {
 		klog.ErrorS(err, "unable to initialize server")
 	}
 
-	featureGate := featuregate.NewFeatureGate()
 	logConfig := logsapi.NewLoggingConfiguration()
 
 	cmd := &cobra.Command{
@@ -60,11 +62,14 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 		Long:  "The descheduler evicts pods which may be bound to less desired nodes",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			logs.InitLogs()
-			if logsapi.ValidateAndApply(logConfig, featureGate); err != nil {
+			// The logging gates are set through --feature-gates as well
+			if err := features.DefaultMutableFeatureGate.SetFromMap(s.FeatureGates); err != nil {
 				return err
 			}
-			descheduler.SetupPlugins()
-			return nil
+			if logsapi.ValidateAndApply(logConfig, features.DefaultMutableFeatureGate); err != nil {
+				return err
+			}
+			return setupPlugins(opts)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// loopbackClientConfig is a config for a privileged loopback connection
@@ -81,10 +86,19 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 
 			pathRecorderMux := mux.NewPathRecorderMux("descheduler")
 			if !s.DisableMetrics {
-				pathRecorderMux.Handle("/metrics", legacyregistry.HandlerWithReset())
+				// The exemplars linking the eviction counters to the traces are only served in the OpenMetrics format
+				pathRecorderMux.Handle("/metrics", metrics.HandlerWithReset(s.Tracing.CollectorEndpoint != ""))
 			}
 
+			pathRecorderMux.Handle("/plugins", pluginregistry.DescribeHandler(pluginregistry.PluginRegistry))
+			pathRecorderMux.Handle("/debug/plugins/v", logging.Handler(logging.DefaultLevels, func(name string) bool {
+				_, ok := pluginregistry.PluginRegistry[name]
+				return ok
+			}))
+
 			healthz.InstallHandler(pathRecorderMux, healthz.NamedCheck("Descheduler", healthz.PingHealthz.Check))
+			healthz.InstallReadyzHandler(pathRecorderMux, descheduler.DefaultStatus.ReadyzChecks()...)
+			pathRecorderMux.Handle("/statusz", descheduler.StatusHandler(descheduler.DefaultStatus))
 
 			stoppedCh, _, err := secureServing.Serve(pathRecorderMux, 0, ctx.Done())
 			if err != nil {
@@ -106,9 +120,10 @@ func NewDeschedulerCommand(out io.Writer) *cobra.Command {
 	}
 	cmd.SetOut(out)
 	flags := cmd.Flags()
+	// Added first so the logging gates are listed by --feature-gates
+	runtime.Must(logsapi.AddFeatureGates(features.DefaultMutableFeatureGate))
 	s.AddFlags(flags)
 
-	runtime.Must(logsapi.AddFeatureGates(featureGate))
 	logsapi.AddFlags(logConfig, flags)
 
 	return cmd
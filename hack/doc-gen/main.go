@@ -13,6 +13,8 @@ var docGenPath = "docs/cli"
 func main() {
 	cmd := app.NewDeschedulerCommand(os.Stdout)
 	cmd.AddCommand(app.NewVersionCommand())
+	cmd.AddCommand(app.NewPluginsCommand())
+	cmd.AddCommand(app.NewConvertCommand())
 	cmd.DisableAutoGenTag = true // Disable this so that the diff wont track it
 	if err := doc.GenMarkdownTree(cmd, docGenPath); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+// IncWithExemplar increments the counter, attaching the trace and span IDs of the span of the
+// context as an exemplar when the span is sampled, so a spike of the counter can be followed to
+// the traces of the descheduling cycle causing it. The counter is incremented without exemplar
+// otherwise, e.g. when the tracing is disabled.
+func IncWithExemplar(ctx context.Context, counter metrics.CounterMetric) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	adder, ok := counter.(prometheus.ExemplarAdder)
+	if !ok || !spanContext.IsSampled() {
+		counter.Inc()
+		return
+	}
+	adder.AddWithExemplar(1, prometheus.Labels{
+		"trace_id": spanContext.TraceID().String(),
+		"span_id":  spanContext.SpanID().String(),
+	})
+}
+
+// HandlerWithReset serves the registered metrics like legacyregistry.HandlerWithReset. With
+// openMetrics set, the metrics are served in the OpenMetrics format to the scrapers accepting
+// it, the only format exposing the exemplars.
+func HandlerWithReset(openMetrics bool) http.Handler {
+	if !openMetrics {
+		return legacyregistry.HandlerWithReset()
+	}
+	handler := metrics.HandlerFor(legacyregistry.DefaultGatherer, metrics.HandlerOpts{EnableOpenMetrics: true})
+	return promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			legacyregistry.Reset()
+			_, _ = io.WriteString(w, "metrics reset\n")
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+}
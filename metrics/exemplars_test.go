@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestIncWithExemplar(t *testing.T) {
+	traceID := trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	spanID := trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+
+	tests := []struct {
+		description      string
+		ctx              context.Context
+		expectedExemplar map[string]string
+	}{
+		{
+			description: "no span, no exemplar",
+			ctx:         context.Background(),
+		},
+		{
+			description: "span not sampled, no exemplar",
+			ctx: trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+			})),
+		},
+		{
+			description: "sampled span, exemplar",
+			ctx: trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			})),
+			expectedExemplar: map[string]string{
+				"trace_id": "0102030405060708090a0b0c0d0e0f10",
+				"span_id":  "0102030405060708",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test"})
+			IncWithExemplar(tc.ctx, counter)
+
+			var metric dto.Metric
+			if err := counter.Write(&metric); err != nil {
+				t.Fatalf("Unable to read the counter: %v", err)
+			}
+			if value := metric.GetCounter().GetValue(); value != 1 {
+				t.Errorf("Expected the counter to be incremented once, got %v", value)
+			}
+			exemplar := map[string]string{}
+			for _, label := range metric.GetCounter().GetExemplar().GetLabel() {
+				exemplar[label.GetName()] = label.GetValue()
+			}
+			if len(exemplar) != len(tc.expectedExemplar) {
+				t.Fatalf("Expected exemplar %v, got %v", tc.expectedExemplar, exemplar)
+			}
+			for name, value := range tc.expectedExemplar {
+				if exemplar[name] != value {
+					t.Errorf("Expected exemplar %v, got %v", tc.expectedExemplar, exemplar)
+				}
+			}
+		})
+	}
+}
@@ -17,8 +17,11 @@ limitations under the License.
 package metrics
 
 import (
+	"slices"
+	"strings"
 	"sync"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/component-base/metrics"
 	"k8s.io/component-base/metrics/legacyregistry"
 	"sigs.k8s.io/descheduler/pkg/version"
@@ -38,6 +41,118 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		}, []string{"result", "strategy", "profile", "namespace", "node"})
 
+	EvictionBudgetRemainingTotal = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "eviction_budget_remaining_total",
+			Help:           "Number of pods which can still be evicted in the current descheduling cycle before reaching maxNoOfPodsToEvictTotal",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	EvictionBudgetRemainingPerNode = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "eviction_budget_remaining_per_node",
+			Help:           "Number of pods which can still be evicted from a node in the current descheduling cycle before reaching maxNoOfPodsToEvictPerNode, by the node name. Only the nodes pods were evicted from are reported",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"node"})
+
+	EvictionBudgetRemainingPerNamespace = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "eviction_budget_remaining_per_namespace",
+			Help:           "Number of pods which can still be evicted from a namespace in the current descheduling cycle before reaching maxNoOfPodsToEvictPerNamespace, by the namespace. Only the namespaces pods were evicted from are reported",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"namespace"})
+
+	EvictionsRejectedByLimit = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "evictions_rejected_by_limit",
+			Help:           "Number of evictions rejected because an eviction limit was reached, by the limit (total, node or namespace), by the strategy, by the profile",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"limit", "strategy", "profile"})
+
+	EvictionThrottlingBackoff = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "eviction_throttling_backoff_seconds",
+			Help:           "Delay between two evictions while the API server keeps throttling them, zero when the evictions are not throttled",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	ThresholdWarnings = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "threshold_warnings",
+			Help:           "Number of pods or nodes crossing the warn threshold of a strategy without crossing its evict threshold, by the strategy, by the namespace, by the node name",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "namespace", "node"})
+
+	CyclesAborted = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "cycles_aborted",
+			Help:           "Number of descheduling cycles aborted by the cycle anomaly guard for evicting too many pods",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	APICalls = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "api_calls",
+			Help:           "Number of writes to the API server accompanying the evictions, i.e. the eviction requests, the events recorded and the eviction conditions applied, by the resource, by the verb",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"resource", "verb"})
+
+	APICallsCoalesced = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "api_calls_coalesced",
+			Help:           "Number of write calls to the API server saved by the eviction batching, by the resource",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"resource"})
+
+	PluginFailures = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "plugin_failures",
+			Help:           "Number of plugin runs which panicked or timed out, by the strategy, by the profile, by the reason",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "profile", "reason"})
+
+	PluginCircuitBreakerTrips = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "plugin_circuit_breaker_trips",
+			Help:           "Number of times a plugin got disabled for repeatedly panicking or timing out, by the strategy, by the profile",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "profile"})
+
+	CycleOverruns = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "cycle_overruns",
+			Help:           "Number of descheduling cycles which overran their deadline",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	PluginsSkippedAtCycleDeadline = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "plugins_skipped_at_cycle_deadline",
+			Help:           "Number of times a plugin got skipped as its descheduling cycle overran its deadline, by the strategy, by the profile",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"strategy", "profile"})
+
+	SingletonWorkloadProtections = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "singleton_workload_protections",
+			Help:           "Number of pods the DefaultEvictor found to be the only desired replica of their workload without PodDisruptionBudget, counted once per cycle, by the result (protected or overridden), by the namespace",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"result", "namespace"})
+
 	buildInfo = metrics.NewGauge(
 		&metrics.GaugeOpts{
 			Subsystem:      DeschedulerSubsystem,
@@ -68,12 +183,88 @@ var (
 
 	metricsList = []metrics.Registerable{
 		PodsEvicted,
+		EvictionBudgetRemainingTotal,
+		EvictionBudgetRemainingPerNode,
+		EvictionBudgetRemainingPerNamespace,
+		EvictionsRejectedByLimit,
+		EvictionThrottlingBackoff,
+		ThresholdWarnings,
+		CyclesAborted,
+		APICalls,
+		APICallsCoalesced,
+		PluginFailures,
+		PluginCircuitBreakerTrips,
+		CycleOverruns,
+		PluginsSkippedAtCycleDeadline,
+		SingletonWorkloadProtections,
 		buildInfo,
 		DeschedulerLoopDuration,
 		DeschedulerStrategyDuration,
 	}
 )
 
+// ProfileLabelName returns the name the profile label with the given key is reported
+// under by the profile labels metric, e.g. label_cost_center for cost-center
+func ProfileLabelName(key string) string {
+	return "label_" + strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+var (
+	profileLabelsMu    sync.Mutex
+	profileLabels      *metrics.GaugeVec
+	profileLabelsNames []string
+)
+
+// SetProfileLabels reports the labels of the profiles through the profile_labels metric, one
+// series per profile. The metric is meant to be joined with the metrics labeled by profile, e.g.
+// descheduler_pods_evicted * on(profile) group_left(label_team) descheduler_profile_labels.
+// The label names are the ones of the first call, the labels added later are not reported.
+func SetProfileLabels(labelsByProfile map[string]map[string]string) {
+	profileLabelsMu.Lock()
+	defer profileLabelsMu.Unlock()
+
+	if profileLabels == nil {
+		names := sets.New[string]()
+		for _, labels := range labelsByProfile {
+			for key := range labels {
+				names.Insert(ProfileLabelName(key))
+			}
+		}
+		if names.Len() == 0 {
+			return
+		}
+		profileLabelsNames = sets.List(names)
+		profileLabels = metrics.NewGaugeVec(
+			&metrics.GaugeOpts{
+				Subsystem:      DeschedulerSubsystem,
+				Name:           "profile_labels",
+				Help:           "Labels of the profiles, by the profile. Always 1",
+				StabilityLevel: metrics.ALPHA,
+			}, append([]string{"profile"}, profileLabelsNames...))
+		RegisterMetrics(profileLabels)
+	}
+
+	profileLabels.Reset()
+	for profile, labels := range labelsByProfile {
+		// The labels a profile does not set are reported empty
+		values := map[string]string{"profile": profile}
+		for _, name := range profileLabelsNames {
+			values[name] = ""
+		}
+		for key, value := range labels {
+			if name := ProfileLabelName(key); slices.Contains(profileLabelsNames, name) {
+				values[name] = value
+			}
+		}
+		profileLabels.With(values).Set(1)
+	}
+}
+
 var registerMetrics sync.Once
 
 // Register all metrics.
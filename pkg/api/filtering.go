@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// NodeRoleLabelPrefix prefixes the labels giving the roles of a node, e.g. node-role.kubernetes.io/control-plane
+	NodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	// LegacyNodeRoleLabel is the label giving the role of a node in some installers, e.g. kubernetes.io/role=master
+	LegacyNodeRoleLabel = "kubernetes.io/role"
+)
+
+// NodeMatcher tells whether a node is processed by a plugin from its labels, a labels.Selector
+// being a NodeMatcher
+type NodeMatcher interface {
+	Matches(labels.Labels) bool
+}
+
+// FilteringArgsGetter is implemented by the arguments of the plugins embedding FilteringArgs
+type FilteringArgsGetter interface {
+	GetFilteringArgs() *FilteringArgs
+}
+
+// EvictableNamespacesArgs is implemented by the arguments of the plugins restricting the namespaces of the
+// evicted pods with EvictableNamespaces, the namespaces of their FilteringArgs being rejected
+type EvictableNamespacesArgs interface {
+	GetEvictableNamespaces() *Namespaces
+	SetEvictableNamespaces(namespaces *Namespaces)
+}
+
+// GetFilteringArgs returns the filtering arguments, it is promoted to the arguments embedding them
+func (f *FilteringArgs) GetFilteringArgs() *FilteringArgs {
+	return f
+}
+
+// Validate checks at most one of the included and excluded namespaces is set and the selectors can be parsed
+func (f *FilteringArgs) Validate() error {
+	// At most one of include/exclude can be set
+	if f.Namespaces != nil && len(f.Namespaces.Include) > 0 && len(f.Namespaces.Exclude) > 0 {
+		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	}
+	if f.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(f.LabelSelector); err != nil {
+			return fmt.Errorf("failed to get label selectors from strategy's params: %+v", err)
+		}
+	}
+	if _, err := f.NodeLabelSelector(); err != nil {
+		return err
+	}
+	if f.NodeRoles != nil {
+		if len(f.NodeRoles.Include) > 0 && len(f.NodeRoles.Exclude) > 0 {
+			return fmt.Errorf("only one of Include/Exclude node roles can be set")
+		}
+		for _, role := range append(append([]string{}, f.NodeRoles.Include...), f.NodeRoles.Exclude...) {
+			if errs := validation.IsQualifiedName(NodeRoleLabelPrefix + role); len(errs) > 0 {
+				return fmt.Errorf("invalid node role %q: %v", role, errs[0])
+			}
+		}
+	}
+	return nil
+}
+
+// NodeLabelSelector parses the node selector, it returns nil when no node selector is set
+func (f *FilteringArgs) NodeLabelSelector() (labels.Selector, error) {
+	if f.NodeSelector == "" {
+		return nil, nil
+	}
+	selector, err := labels.Parse(f.NodeSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node selector %q: %v", f.NodeSelector, err)
+	}
+	return selector, nil
+}
+
+// NodeMatcher returns the matcher of the nodes of the node selector and the node roles,
+// it returns nil when the nodes are not filtered
+func (f *FilteringArgs) NodeMatcher() (NodeMatcher, error) {
+	selector, err := f.NodeLabelSelector()
+	if err != nil {
+		return nil, err
+	}
+	if f.NodeRoles == nil || (len(f.NodeRoles.Include) == 0 && len(f.NodeRoles.Exclude) == 0) {
+		if selector == nil {
+			return nil, nil
+		}
+		return selector, nil
+	}
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	return &nodeRolesMatcher{selector: selector, include: f.NodeRoles.Include, exclude: f.NodeRoles.Exclude}, nil
+}
+
+// nodeRolesMatcher matches the nodes of the selector having one of the included roles, or
+// none of the excluded ones
+type nodeRolesMatcher struct {
+	selector labels.Selector
+	include  []string
+	exclude  []string
+}
+
+func (m *nodeRolesMatcher) Matches(nodeLabels labels.Labels) bool {
+	if !m.selector.Matches(nodeLabels) {
+		return false
+	}
+	if len(m.include) > 0 {
+		return hasAnyNodeRole(nodeLabels, m.include)
+	}
+	return !hasAnyNodeRole(nodeLabels, m.exclude)
+}
+
+func hasAnyNodeRole(nodeLabels labels.Labels, roles []string) bool {
+	for _, role := range roles {
+		if nodeLabels.Has(NodeRoleLabelPrefix+role) || (nodeLabels.Has(LegacyNodeRoleLabel) && nodeLabels.Get(LegacyNodeRoleLabel) == role) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatinginterpodantiaffinity"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodeaffinity"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingtopologyspreadconstraint"
+)
+
+// strategyConversion converts a strategy to the plugin of the same name
+type strategyConversion struct {
+	// balance enables the plugin at the balance extension point instead of the deschedule one
+	balance bool
+	// params are the strategy specific parameters read by the conversion, besides the common ones
+	params []string
+	// args returns the arguments of the plugin, along with the parameters it could not convert
+	args func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string)
+}
+
+var strategyConversions = map[StrategyName]strategyConversion{
+	removeduplicates.PluginName: {
+		balance: true,
+		params:  []string{"removeDuplicates"},
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			args := &removeduplicates.RemoveDuplicatesArgs{FilteringArgs: filtering}
+			if params.RemoveDuplicates != nil {
+				args.ExcludeOwnerKinds = params.RemoveDuplicates.ExcludeOwnerKinds
+			}
+			return args, nil
+		},
+	},
+	nodeutilization.LowNodeUtilizationPluginName: {
+		balance: true,
+		params:  []string{"nodeResourceUtilizationThresholds"},
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			args := &nodeutilization.LowNodeUtilizationArgs{FilteringArgs: filtering}
+			if thresholds := params.NodeResourceUtilizationThresholds; thresholds != nil {
+				args.UseDeviationThresholds = thresholds.UseDeviationThresholds
+				args.Thresholds = convertResourceThresholds(thresholds.Thresholds)
+				args.TargetThresholds = convertResourceThresholds(thresholds.TargetThresholds)
+				args.NumberOfNodes = thresholds.NumberOfNodes
+			}
+			return args, nil
+		},
+	},
+	nodeutilization.HighNodeUtilizationPluginName: {
+		balance: true,
+		params:  []string{"nodeResourceUtilizationThresholds"},
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			args := &nodeutilization.HighNodeUtilizationArgs{FilteringArgs: filtering}
+			var dropped []string
+			if thresholds := params.NodeResourceUtilizationThresholds; thresholds != nil {
+				args.Thresholds = convertResourceThresholds(thresholds.Thresholds)
+				args.NumberOfNodes = thresholds.NumberOfNodes
+				if thresholds.UseDeviationThresholds {
+					dropped = append(dropped, "nodeResourceUtilizationThresholds.useDeviationThresholds")
+				}
+				if len(thresholds.TargetThresholds) > 0 {
+					dropped = append(dropped, "nodeResourceUtilizationThresholds.targetThresholds")
+				}
+			}
+			return args, dropped
+		},
+	},
+	removepodsviolatinginterpodantiaffinity.PluginName: {
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			return &removepodsviolatinginterpodantiaffinity.RemovePodsViolatingInterPodAntiAffinityArgs{FilteringArgs: filtering}, nil
+		},
+	},
+	removepodsviolatingnodeaffinity.PluginName: {
+		params: []string{"nodeAffinityType"},
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			return &removepodsviolatingnodeaffinity.RemovePodsViolatingNodeAffinityArgs{
+				FilteringArgs:    filtering,
+				NodeAffinityType: params.NodeAffinityType,
+			}, nil
+		},
+	},
+	removepodsviolatingnodetaints.PluginName: {
+		params: []string{"includePreferNoSchedule", "excludedTaints", "includedTaints"},
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			return &removepodsviolatingnodetaints.RemovePodsViolatingNodeTaintsArgs{
+				FilteringArgs:           filtering,
+				IncludePreferNoSchedule: params.IncludePreferNoSchedule,
+				ExcludedTaints:          params.ExcludedTaints,
+				IncludedTaints:          params.IncludedTaints,
+			}, nil
+		},
+	},
+	removepodshavingtoomanyrestarts.PluginName: {
+		params: []string{"podsHavingTooManyRestarts"},
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			args := &removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestartsArgs{FilteringArgs: filtering}
+			if restarts := params.PodsHavingTooManyRestarts; restarts != nil {
+				args.PodRestartThreshold = restarts.PodRestartThreshold
+				args.IncludingInitContainers = restarts.IncludingInitContainers
+			}
+			return args, nil
+		},
+	},
+	podlifetime.PluginName: {
+		params: []string{"podLifeTime"},
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			args := &podlifetime.PodLifeTimeArgs{FilteringArgs: filtering}
+			if lifeTime := params.PodLifeTime; lifeTime != nil {
+				args.MaxPodLifeTimeSeconds = lifeTime.MaxPodLifeTimeSeconds
+				// The deprecated podStatusPhases were merged into the states by the v1alpha1 policies
+				args.States = append(args.States, lifeTime.States...)
+				for _, phase := range lifeTime.PodStatusPhases {
+					if !contains(args.States, phase) {
+						args.States = append(args.States, phase)
+					}
+				}
+			}
+			return args, nil
+		},
+	},
+	removefailedpods.PluginName: {
+		params: []string{"failedPods"},
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			args := &removefailedpods.RemoveFailedPodsArgs{FilteringArgs: filtering}
+			if failedPods := params.FailedPods; failedPods != nil {
+				args.ExcludeOwnerKinds = failedPods.ExcludeOwnerKinds
+				args.MinPodLifetimeSeconds = failedPods.MinPodLifetimeSeconds
+				args.Reasons = failedPods.Reasons
+				args.IncludingInitContainers = failedPods.IncludingInitContainers
+			}
+			return args, nil
+		},
+	},
+	removepodsviolatingtopologyspreadconstraint.PluginName: {
+		balance: true,
+		params:  []string{"includeSoftConstraints"},
+		args: func(params *StrategyParameters, filtering api.FilteringArgs) (runtime.Object, []string) {
+			args := &removepodsviolatingtopologyspreadconstraint.RemovePodsViolatingTopologySpreadConstraintArgs{
+				FilteringArgs: filtering,
+				Constraints:   []v1.UnsatisfiableConstraintAction{v1.DoNotSchedule},
+			}
+			if params.IncludeSoftConstraints {
+				args.Constraints = append(args.Constraints, v1.ScheduleAnyway)
+			}
+			return args, nil
+		},
+	},
+}
+
+// Decode decodes a v1alpha1 policy from YAML or JSON, rejecting unknown fields
+func Decode(data []byte) (*DeschedulerPolicy, error) {
+	policy := &DeschedulerPolicy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("failed decoding descheduler's policy config: %v", err)
+	}
+	if policy.APIVersion != SchemeGroupVersion.String() || policy.Kind != "DeschedulerPolicy" {
+		return nil, fmt.Errorf("expected a %s DeschedulerPolicy, got apiVersion %q and kind %q", SchemeGroupVersion, policy.APIVersion, policy.Kind)
+	}
+	return policy, nil
+}
+
+// ConvertToV1alpha2 converts a v1alpha1 policy to a v1alpha2 one. Every enabled strategy is converted to
+// a profile enabling the plugin of the same name along with the DefaultEvictor, configured from the
+// parameters of the strategy and the evictor settings of the policy. The fields without a v1alpha2
+// equivalent are dropped and returned, along with their path.
+func ConvertToV1alpha2(in *DeschedulerPolicy) (*v1alpha2.DeschedulerPolicy, []string, error) {
+	out := &v1alpha2.DeschedulerPolicy{
+		NodeSelector:                   in.NodeSelector,
+		MaxNoOfPodsToEvictPerNode:      in.MaxNoOfPodsToEvictPerNode,
+		MaxNoOfPodsToEvictPerNamespace: in.MaxNoOfPodsToEvictPerNamespace,
+	}
+	out.APIVersion = v1alpha2.SchemeGroupVersion.String()
+	out.Kind = "DeschedulerPolicy"
+
+	names := make([]string, 0, len(in.Strategies))
+	for name := range in.Strategies {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	var dropped []string
+	for _, name := range names {
+		strategy := in.Strategies[StrategyName(name)]
+		path := fmt.Sprintf("strategies.%s", name)
+		if !strategy.Enabled {
+			continue
+		}
+		conversion, ok := strategyConversions[StrategyName(name)]
+		if !ok {
+			dropped = append(dropped, fmt.Sprintf("%s: unknown strategy", path))
+			continue
+		}
+		if strategy.Weight != 0 {
+			dropped = append(dropped, fmt.Sprintf("%s.weight: not supported", path))
+		}
+
+		params := strategy.Params
+		if params == nil {
+			params = &StrategyParameters{}
+		}
+		for _, param := range setParams(params) {
+			if !contains(conversion.params, param) {
+				dropped = append(dropped, fmt.Sprintf("%s.params.%s: not supported by the %s plugin", path, param, name))
+			}
+		}
+
+		evictorArgs := &defaultevictor.DefaultEvictorArgs{
+			EvictLocalStoragePods:   ptr.Deref(in.EvictLocalStoragePods, false),
+			EvictSystemCriticalPods: ptr.Deref(in.EvictSystemCriticalPods, false),
+			IgnorePvcPods:           ptr.Deref(in.IgnorePVCPods, false),
+			EvictFailedBarePods:     ptr.Deref(in.EvictFailedBarePods, false),
+		}
+		if params.NodeFit {
+			evictorArgs.NodeFit = ptr.To(true)
+		}
+		if in.NodeSelector != nil {
+			evictorArgs.NodeSelector = *in.NodeSelector
+		}
+		if params.ThresholdPriority != nil {
+			evictorArgs.PriorityThreshold = &api.PriorityThreshold{Value: params.ThresholdPriority}
+			if params.ThresholdPriorityClassName != "" {
+				dropped = append(dropped, fmt.Sprintf("%s.params.thresholdPriorityClassName: set along with thresholdPriority", path))
+			}
+		} else if params.ThresholdPriorityClassName != "" {
+			evictorArgs.PriorityThreshold = &api.PriorityThreshold{Name: params.ThresholdPriorityClassName}
+		}
+
+		filtering := api.FilteringArgs{LabelSelector: params.LabelSelector}
+		if params.Namespaces != nil {
+			filtering.Namespaces = &api.Namespaces{Include: params.Namespaces.Include, Exclude: params.Namespaces.Exclude}
+		}
+		args, droppedParams := conversion.args(params, filtering)
+		for _, param := range droppedParams {
+			dropped = append(dropped, fmt.Sprintf("%s.params.%s: not supported by the %s plugin", path, param, name))
+		}
+
+		evictorConfig, err := pluginConfig(defaultevictor.PluginName, evictorArgs)
+		if err != nil {
+			return nil, nil, err
+		}
+		config, err := pluginConfig(name, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		profile := v1alpha2.DeschedulerProfile{
+			Name:          fmt.Sprintf("strategy-%s-profile", name),
+			PluginConfigs: []v1alpha2.PluginConfig{evictorConfig, config},
+			Plugins: v1alpha2.Plugins{
+				Filter:            v1alpha2.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+				PreEvictionFilter: v1alpha2.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+			},
+		}
+		if conversion.balance {
+			profile.Plugins.Balance.Enabled = []string{name}
+		} else {
+			profile.Plugins.Deschedule.Enabled = []string{name}
+		}
+		out.Profiles = append(out.Profiles, profile)
+	}
+	return out, dropped, nil
+}
+
+func pluginConfig(name string, args runtime.Object) (v1alpha2.PluginConfig, error) {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return v1alpha2.PluginConfig{}, fmt.Errorf("encoding the arguments of the %s plugin: %v", name, err)
+	}
+	return v1alpha2.PluginConfig{Name: name, Args: runtime.RawExtension{Raw: raw}}, nil
+}
+
+// setParams returns the strategy specific parameters set, the common ones being converted for all the strategies
+func setParams(params *StrategyParameters) []string {
+	var set []string
+	if params.NodeResourceUtilizationThresholds != nil {
+		set = append(set, "nodeResourceUtilizationThresholds")
+	}
+	if len(params.NodeAffinityType) > 0 {
+		set = append(set, "nodeAffinityType")
+	}
+	if params.PodsHavingTooManyRestarts != nil {
+		set = append(set, "podsHavingTooManyRestarts")
+	}
+	if params.PodLifeTime != nil {
+		set = append(set, "podLifeTime")
+	}
+	if params.RemoveDuplicates != nil {
+		set = append(set, "removeDuplicates")
+	}
+	if params.FailedPods != nil {
+		set = append(set, "failedPods")
+	}
+	if params.IncludeSoftConstraints {
+		set = append(set, "includeSoftConstraints")
+	}
+	if params.IncludePreferNoSchedule {
+		set = append(set, "includePreferNoSchedule")
+	}
+	if len(params.ExcludedTaints) > 0 {
+		set = append(set, "excludedTaints")
+	}
+	if len(params.IncludedTaints) > 0 {
+		set = append(set, "includedTaints")
+	}
+	return set
+}
+
+func convertResourceThresholds(in ResourceThresholds) api.ResourceThresholds {
+	if in == nil {
+		return nil
+	}
+	out := make(api.ResourceThresholds, len(in))
+	for resource, percentage := range in {
+		out[resource] = api.Percentage(percentage)
+	}
+	return out
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
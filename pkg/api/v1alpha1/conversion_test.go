@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingtopologyspreadconstraint"
+)
+
+func TestConvertToV1alpha2(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        *DeschedulerPolicy
+		expectArgs    runtime.Object
+		expectEvictor *defaultevictor.DefaultEvictorArgs
+		expectBalance bool
+		expectDropped []string
+	}{
+		{
+			name: "PodLifeTime merges the pod status phases into the states",
+			policy: &DeschedulerPolicy{
+				EvictSystemCriticalPods: ptr.To(true),
+				Strategies: StrategyList{
+					"PodLifeTime": {Enabled: true, Params: &StrategyParameters{
+						PodLifeTime: &PodLifeTime{
+							MaxPodLifeTimeSeconds: ptr.To[uint](600),
+							States:                []string{"Pending"},
+							PodStatusPhases:       []string{"Pending", "Running"},
+						},
+						Namespaces:        &Namespaces{Exclude: []string{"kube-system"}},
+						LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						ThresholdPriority: ptr.To[int32](1000),
+					}},
+				},
+			},
+			expectArgs: &podlifetime.PodLifeTimeArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{Exclude: []string{"kube-system"}},
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				},
+				MaxPodLifeTimeSeconds: ptr.To[uint](600),
+				States:                []string{"Pending", "Running"},
+			},
+			expectEvictor: &defaultevictor.DefaultEvictorArgs{
+				EvictSystemCriticalPods: true,
+				PriorityThreshold:       &api.PriorityThreshold{Value: ptr.To[int32](1000)},
+			},
+		},
+		{
+			name: "HighNodeUtilization drops the target thresholds",
+			policy: &DeschedulerPolicy{
+				NodeSelector: ptr.To("pool=batch"),
+				Strategies: StrategyList{
+					"HighNodeUtilization": {Enabled: true, Params: &StrategyParameters{
+						NodeResourceUtilizationThresholds: &NodeResourceUtilizationThresholds{
+							Thresholds:       ResourceThresholds{v1.ResourceCPU: 20},
+							TargetThresholds: ResourceThresholds{v1.ResourceCPU: 50},
+							NumberOfNodes:    2,
+						},
+						NodeFit: true,
+					}},
+				},
+			},
+			expectArgs: &nodeutilization.HighNodeUtilizationArgs{
+				Thresholds:    api.ResourceThresholds{v1.ResourceCPU: 20},
+				NumberOfNodes: 2,
+			},
+			expectEvictor: &defaultevictor.DefaultEvictorArgs{
+				NodeSelector: "pool=batch",
+				NodeFit:      ptr.To(true),
+			},
+			expectBalance: true,
+			expectDropped: []string{"strategies.HighNodeUtilization.params.nodeResourceUtilizationThresholds.targetThresholds: not supported by the HighNodeUtilization plugin"},
+		},
+		{
+			name: "RemovePodsViolatingTopologySpreadConstraint includes the soft constraints",
+			policy: &DeschedulerPolicy{
+				Strategies: StrategyList{
+					"RemovePodsViolatingTopologySpreadConstraint": {Enabled: true, Weight: 1, Params: &StrategyParameters{
+						IncludeSoftConstraints:     true,
+						ThresholdPriority:          ptr.To[int32](1000),
+						ThresholdPriorityClassName: "system-cluster-critical",
+						FailedPods:                 &FailedPods{},
+					}},
+				},
+			},
+			expectArgs: &removepodsviolatingtopologyspreadconstraint.RemovePodsViolatingTopologySpreadConstraintArgs{
+				Constraints: []v1.UnsatisfiableConstraintAction{v1.DoNotSchedule, v1.ScheduleAnyway},
+			},
+			expectEvictor: &defaultevictor.DefaultEvictorArgs{
+				PriorityThreshold: &api.PriorityThreshold{Value: ptr.To[int32](1000)},
+			},
+			expectBalance: true,
+			expectDropped: []string{
+				"strategies.RemovePodsViolatingTopologySpreadConstraint.weight: not supported",
+				"strategies.RemovePodsViolatingTopologySpreadConstraint.params.failedPods: not supported by the RemovePodsViolatingTopologySpreadConstraint plugin",
+				"strategies.RemovePodsViolatingTopologySpreadConstraint.params.thresholdPriorityClassName: set along with thresholdPriority",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, dropped, err := ConvertToV1alpha2(tc.policy)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectDropped, dropped); diff != "" {
+				t.Errorf("Unexpected dropped fields (-want, +got):\n%s", diff)
+			}
+			if len(policy.Profiles) != 1 {
+				t.Fatalf("Expected a single profile, got %d", len(policy.Profiles))
+			}
+			profile := policy.Profiles[0]
+			enabled := profile.Plugins.Deschedule.Enabled
+			if tc.expectBalance {
+				enabled = profile.Plugins.Balance.Enabled
+			}
+			if len(enabled) != 1 || len(profile.PluginConfigs) != 2 || enabled[0] != profile.PluginConfigs[1].Name {
+				t.Fatalf("Unexpected plugins of the profile: %+v", profile)
+			}
+
+			evictorArgs := &defaultevictor.DefaultEvictorArgs{}
+			if err := json.Unmarshal(profile.PluginConfigs[0].Args.Raw, evictorArgs); err != nil {
+				t.Fatalf("Unable to decode the evictor arguments: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectEvictor, evictorArgs); diff != "" {
+				t.Errorf("Unexpected evictor arguments (-want, +got):\n%s", diff)
+			}
+			args := reflect.New(reflect.TypeOf(tc.expectArgs).Elem()).Interface().(runtime.Object)
+			if err := json.Unmarshal(profile.PluginConfigs[1].Args.Raw, args); err != nil {
+				t.Fatalf("Unable to decode the plugin arguments: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectArgs, args); diff != "" {
+				t.Errorf("Unexpected plugin arguments (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
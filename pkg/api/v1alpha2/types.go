@@ -32,6 +32,13 @@ type DeschedulerPolicy struct {
 	// NodeSelector for a set of nodes to operate over
 	NodeSelector *string `json:"nodeSelector,omitempty"`
 
+	// NodeFieldSelector for a set of nodes to operate over, e.g. spec.unschedulable=false.
+	// Both node selectors are applied by the API server when listing and watching nodes.
+	NodeFieldSelector *string `json:"nodeFieldSelector,omitempty"`
+
+	// NodeListPageSize lists the nodes in pages of the given size instead of all at once.
+	NodeListPageSize *int64 `json:"nodeListPageSize,omitempty"`
+
 	// MaxNoOfPodsToEvictPerNode restricts maximum of pods to be evicted per node.
 	MaxNoOfPodsToEvictPerNode *uint `json:"maxNoOfPodsToEvictPerNode,omitempty"`
 
@@ -40,12 +47,239 @@ type DeschedulerPolicy struct {
 
 	// MaxNoOfPodsToTotal restricts maximum of pods to be evicted total.
 	MaxNoOfPodsToEvictTotal *uint `json:"maxNoOfPodsToEvictTotal,omitempty"`
+
+	// WatchedNamespaces restricts the descheduler to pods in the listed namespaces.
+	// Pods are listed and watched per namespace instead of cluster wide
+	// so only namespace scoped permissions on pods are required.
+	WatchedNamespaces []string `json:"watchedNamespaces,omitempty"`
+
+	// EvictionPacing spreads the evictions of each descheduling cycle over a period of time
+	// instead of evicting all the pods at the start of the cycle.
+	EvictionPacing *EvictionPacing `json:"evictionPacing,omitempty"`
+
+	// PodCache configures which pod fields are dropped from the informer cache
+	// to reduce the memory used by the descheduler on large clusters.
+	PodCache *PodCache `json:"podCache,omitempty"`
+
+	// NodeDisruptionGuard prevents evictions from nodes already being disrupted
+	// by something else than the descheduler, e.g. kubelet evictions or drains.
+	NodeDisruptionGuard *NodeDisruptionGuard `json:"nodeDisruptionGuard,omitempty"`
+
+	// ClientConnection configures the rate limiting of the requests sent to the API server.
+	// The --client-connection-qps and --client-connection-burst flags take precedence.
+	ClientConnection *ClientConnection `json:"clientConnection,omitempty"`
+
+	// InformerResyncPeriod is how often the informers resync their cache, zero disables the resyncs.
+	// The --informer-resync-period flag takes precedence.
+	InformerResyncPeriod *metav1.Duration `json:"informerResyncPeriod,omitempty"`
+
+	// EvictionTimeout bounds each request to the eviction API.
+	// The --eviction-timeout flag takes precedence.
+	EvictionTimeout *metav1.Duration `json:"evictionTimeout,omitempty"`
+
+	// EvictionThrottling backs off the evictions while the API server keeps throttling them.
+	EvictionThrottling *EvictionThrottling `json:"evictionThrottling,omitempty"`
+
+	// EvictionBatching batches the writes accompanying the evictions, i.e. their events and the
+	// conditions set on the pods whose eviction is paced or held back, to reduce the API calls.
+	EvictionBatching *EvictionBatching `json:"evictionBatching,omitempty"`
+
+	// DisruptionBudgetPacing holds back the evictions of the pods covered by a PodDisruptionBudget
+	// allowing no more disruptions, and retries them later in the cycle as the budget recovers.
+	DisruptionBudgetPacing *DisruptionBudgetPacing `json:"disruptionBudgetPacing,omitempty"`
+
+	// CycleAnomalyGuard aborts a descheduling cycle evicting an abnormally high share of the pods
+	// of the cluster, e.g. because of a policy mistake.
+	CycleAnomalyGuard *CycleAnomalyGuard `json:"cycleAnomalyGuard,omitempty"`
+
+	// StateStore persists the state of the plugins, e.g. cooldowns or flap history,
+	// across restarts of the descheduler. The state is kept in memory when not set.
+	StateStore *StateStore `json:"stateStore,omitempty"`
+
+	// FeatureGates enables or disables the experimental features of the descheduler.
+	// The --feature-gates flag takes precedence.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// PluginLogVerbosity overrides the log verbosity of the listed plugins, e.g. to debug
+	// a single plugin at V(5) without raising the verbosity of the others.
+	PluginLogVerbosity map[string]int32 `json:"pluginLogVerbosity,omitempty"`
+
+	// PluginExecution bounds the execution time of the plugins and disables for a number of
+	// cycles the plugins repeatedly panicking or timing out, so they do not stall the cycles.
+	PluginExecution *PluginExecution `json:"pluginExecution,omitempty"`
+
+	// Defaults are inherited by the arguments of all the plugins which do not set them,
+	// e.g. to exclude a namespace from all the plugins at once.
+	Defaults *PluginDefaults `json:"defaults,omitempty"`
+
+	// EvictionOrdering orders the nodes handed to the plugins, so the evictions left out
+	// by the eviction limits are the most disruptive ones.
+	EvictionOrdering *EvictionOrdering `json:"evictionOrdering,omitempty"`
+}
+
+// ClientConnection configures the client side rate limiting of the requests sent to the API server
+type ClientConnection struct {
+	// QPS is the sustained number of requests per second. Defaults to the client-go default of 5.
+	QPS *float32 `json:"qps,omitempty"`
+
+	// Burst is the number of requests allowed on top of QPS for short periods of time.
+	// Defaults to the client-go default of 10.
+	Burst *int32 `json:"burst,omitempty"`
+}
+
+// EvictionThrottling configures how the evictions back off while the API server throttles them
+type EvictionThrottling struct {
+	// ThrottledEvictions is the number of consecutive evictions rejected with a 429 Too Many Requests
+	// response, other than because of a PodDisruptionBudget, before backing off. Defaults to 3.
+	ThrottledEvictions *int32 `json:"throttledEvictions,omitempty"`
+
+	// InitialBackoff is the first delay between two evictions once backing off, doubled for every
+	// other throttled eviction. Defaults to 1 second.
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// MaxBackoff caps the delay between two evictions. Defaults to 1 minute.
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// EvictionBatching configures when the batched writes accompanying the evictions are flushed
+type EvictionBatching struct {
+	// BatchSize is the number of pending writes flushing the batch. Defaults to 50.
+	BatchSize *int32 `json:"batchSize,omitempty"`
+
+	// FlushInterval is the longest time a write stays pending before the batch is flushed,
+	// the batch being flushed at the end of the cycle regardless. Defaults to 1 second.
+	FlushInterval *metav1.Duration `json:"flushInterval,omitempty"`
+}
+
+// DisruptionBudgetPacing configures how the evictions held back by a PodDisruptionBudget are retried
+type DisruptionBudgetPacing struct {
+	// RetryInterval is the delay between two retries of the evictions held back by a budget.
+	// Defaults to 10 seconds.
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+
+	// Retries is the number of times the held back evictions are retried at the end of the cycle
+	// before giving up on them until the next cycle. Defaults to 6.
+	Retries *int32 `json:"retries,omitempty"`
+}
+
+// PluginExecution configures the timeouts and the circuit breakers of the plugins
+type PluginExecution struct {
+	// Timeout bounds each run of a plugin at an extension point. Zero, the default, does not
+	// bound the runs.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// PluginTimeouts overrides the timeout of the listed plugins.
+	PluginTimeouts map[string]metav1.Duration `json:"pluginTimeouts,omitempty"`
+
+	// FailureThreshold is the number of consecutive runs of a plugin panicking or timing out
+	// tripping its circuit breaker. Defaults to 3.
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// CooldownCycles is the number of descheduling cycles a plugin is disabled for once its
+	// circuit breaker trips. Defaults to 5.
+	CooldownCycles *int32 `json:"cooldownCycles,omitempty"`
+
+	// CycleDeadline bounds each descheduling cycle. The plugin running at the deadline is cancelled
+	// and the plugins left are skipped until the next cycle. Zero, the default, does not bound the cycles.
+	CycleDeadline *metav1.Duration `json:"cycleDeadline,omitempty"`
+
+	// RunSkippedPluginsFirst runs the plugins skipped at the deadline of a cycle first the next cycle,
+	// so a slow plugin does not keep the plugins after it from ever running.
+	RunSkippedPluginsFirst bool `json:"runSkippedPluginsFirst,omitempty"`
+}
+
+// PluginDefaults carries the arguments inherited by the plugins which do not set them
+type PluginDefaults struct {
+	// Namespaces restricts the namespaces of the pods processed by the plugins
+	Namespaces *Namespaces `json:"namespaces,omitempty"`
+
+	// LabelSelector restricts the labels of the pods processed by the plugins
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// PriorityThreshold is the priority threshold of the DefaultEvictor, pods with
+	// a priority higher than or equal to the threshold not being evicted
+	PriorityThreshold *PriorityThreshold `json:"priorityThreshold,omitempty"`
+
+	// NodeFit is the nodeFit argument of the DefaultEvictor
+	NodeFit *bool `json:"nodeFit,omitempty"`
+}
+
+// Namespaces carries a list of included/excluded namespaces
+type Namespaces struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// PriorityThreshold carries the priority threshold either by value or by the name of a priority class
+type PriorityThreshold struct {
+	Value *int32 `json:"value,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// EvictionOrdering configures the order the nodes are handed to the plugins in
+type EvictionOrdering struct {
+	// DisruptionHeadroom hands the nodes to the plugins by decreasing disruption headroom: the ready nodes
+	// first, then the nodes whose pods belong to the workloads the most able to lose a replica.
+	DisruptionHeadroom bool `json:"disruptionHeadroom,omitempty"`
+}
+
+// StateStore configures the ConfigMap the plugins persist their state in
+type StateStore struct {
+	// Namespace of the ConfigMap. Defaults to kube-system.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the ConfigMap, created when missing. Defaults to descheduler-state.
+	Name string `json:"name,omitempty"`
+}
+
+// CycleAnomalyGuard configures when a descheduling cycle is aborted
+type CycleAnomalyGuard struct {
+	// MaxEvictionPercentage is the percentage of the pods of the cluster a cycle may evict before
+	// being aborted. At least one pod may always be evicted. Defaults to 10.
+	MaxEvictionPercentage *float64 `json:"maxEvictionPercentage,omitempty"`
+}
+
+// EvictionPacing configures how evictions get spread over time
+type EvictionPacing struct {
+	// Period over which the evictions of a descheduling cycle are evenly spread.
+	// Defaults to the descheduling interval.
+	Period *metav1.Duration `json:"period,omitempty"`
+}
+
+// PodCache configures which pod fields are dropped from the informer cache.
+// Fields required by the configured plugins are kept regardless.
+type PodCache struct {
+	// MaxAnnotationSize drops the pod annotations whose value is longer than
+	// the given number of bytes (e.g. kubectl.kubernetes.io/last-applied-configuration).
+	MaxAnnotationSize *int32 `json:"maxAnnotationSize,omitempty"`
+
+	// StripContainerEnv drops the environment variables of the pod containers.
+	StripContainerEnv bool `json:"stripContainerEnv,omitempty"`
+
+	// LabelSelector restricts the cached pods to the ones matching the label selector
+	// (e.g. "app.kubernetes.io/managed-by!=daemonset"). Plugins which need to see every
+	// pod of the nodes are rejected when the pod cache is restricted.
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// FieldSelector restricts the cached pods to the ones matching the field selector
+	// (e.g. "metadata.namespace!=kube-system").
+	FieldSelector string `json:"fieldSelector,omitempty"`
+}
+
+// NodeDisruptionGuard configures how ongoing node disruptions are detected
+type NodeDisruptionGuard struct {
+	// Window is how long a node is considered disrupted after a pod on it got
+	// evicted by the kubelet or disrupted. Defaults to 10 minutes.
+	Window *metav1.Duration `json:"window,omitempty"`
 }
 
 type DeschedulerProfile struct {
-	Name          string         `json:"name"`
-	PluginConfigs []PluginConfig `json:"pluginConfig"`
-	Plugins       Plugins        `json:"plugins"`
+	Name string `json:"name"`
+	// Labels attribute the evictions of the profile, e.g. to a team or a cost center. They are
+	// reported by the profile labels metric, in the events and in the logs of the evictions.
+	Labels        map[string]string `json:"labels,omitempty"`
+	PluginConfigs []PluginConfig    `json:"pluginConfig"`
+	Plugins       Plugins           `json:"plugins"`
 }
 
 type Plugins struct {
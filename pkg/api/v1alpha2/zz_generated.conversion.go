@@ -24,6 +24,7 @@ package v1alpha2
 import (
 	unsafe "unsafe"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	api "sigs.k8s.io/descheduler/pkg/api"
@@ -36,6 +37,26 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ClientConnection)(nil), (*api.ClientConnection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ClientConnection_To_api_ClientConnection(a.(*ClientConnection), b.(*api.ClientConnection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.ClientConnection)(nil), (*ClientConnection)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_ClientConnection_To_v1alpha2_ClientConnection(a.(*api.ClientConnection), b.(*ClientConnection), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CycleAnomalyGuard)(nil), (*api.CycleAnomalyGuard)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CycleAnomalyGuard_To_api_CycleAnomalyGuard(a.(*CycleAnomalyGuard), b.(*api.CycleAnomalyGuard), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.CycleAnomalyGuard)(nil), (*CycleAnomalyGuard)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_CycleAnomalyGuard_To_v1alpha2_CycleAnomalyGuard(a.(*api.CycleAnomalyGuard), b.(*CycleAnomalyGuard), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DeschedulerProfile)(nil), (*api.DeschedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile(a.(*DeschedulerProfile), b.(*api.DeschedulerProfile), scope)
 	}); err != nil {
@@ -46,11 +67,101 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DisruptionBudgetPacing)(nil), (*api.DisruptionBudgetPacing)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DisruptionBudgetPacing_To_api_DisruptionBudgetPacing(a.(*DisruptionBudgetPacing), b.(*api.DisruptionBudgetPacing), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.DisruptionBudgetPacing)(nil), (*DisruptionBudgetPacing)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_DisruptionBudgetPacing_To_v1alpha2_DisruptionBudgetPacing(a.(*api.DisruptionBudgetPacing), b.(*DisruptionBudgetPacing), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionBatching)(nil), (*api.EvictionBatching)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionBatching_To_api_EvictionBatching(a.(*EvictionBatching), b.(*api.EvictionBatching), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionBatching)(nil), (*EvictionBatching)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionBatching_To_v1alpha2_EvictionBatching(a.(*api.EvictionBatching), b.(*EvictionBatching), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionOrdering)(nil), (*api.EvictionOrdering)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionOrdering_To_api_EvictionOrdering(a.(*EvictionOrdering), b.(*api.EvictionOrdering), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionOrdering)(nil), (*EvictionOrdering)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionOrdering_To_v1alpha2_EvictionOrdering(a.(*api.EvictionOrdering), b.(*EvictionOrdering), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionPacing)(nil), (*api.EvictionPacing)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionPacing_To_api_EvictionPacing(a.(*EvictionPacing), b.(*api.EvictionPacing), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionPacing)(nil), (*EvictionPacing)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionPacing_To_v1alpha2_EvictionPacing(a.(*api.EvictionPacing), b.(*EvictionPacing), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionThrottling)(nil), (*api.EvictionThrottling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionThrottling_To_api_EvictionThrottling(a.(*EvictionThrottling), b.(*api.EvictionThrottling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionThrottling)(nil), (*EvictionThrottling)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionThrottling_To_v1alpha2_EvictionThrottling(a.(*api.EvictionThrottling), b.(*EvictionThrottling), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Namespaces)(nil), (*api.Namespaces)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Namespaces_To_api_Namespaces(a.(*Namespaces), b.(*api.Namespaces), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.Namespaces)(nil), (*Namespaces)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_Namespaces_To_v1alpha2_Namespaces(a.(*api.Namespaces), b.(*Namespaces), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeDisruptionGuard)(nil), (*api.NodeDisruptionGuard)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeDisruptionGuard_To_api_NodeDisruptionGuard(a.(*NodeDisruptionGuard), b.(*api.NodeDisruptionGuard), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NodeDisruptionGuard)(nil), (*NodeDisruptionGuard)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NodeDisruptionGuard_To_v1alpha2_NodeDisruptionGuard(a.(*api.NodeDisruptionGuard), b.(*NodeDisruptionGuard), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*api.PluginConfig)(nil), (*PluginConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_api_PluginConfig_To_v1alpha2_PluginConfig(a.(*api.PluginConfig), b.(*PluginConfig), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PluginDefaults)(nil), (*api.PluginDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PluginDefaults_To_api_PluginDefaults(a.(*PluginDefaults), b.(*api.PluginDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PluginDefaults)(nil), (*PluginDefaults)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PluginDefaults_To_v1alpha2_PluginDefaults(a.(*api.PluginDefaults), b.(*PluginDefaults), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PluginExecution)(nil), (*api.PluginExecution)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PluginExecution_To_api_PluginExecution(a.(*PluginExecution), b.(*api.PluginExecution), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PluginExecution)(nil), (*PluginExecution)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PluginExecution_To_v1alpha2_PluginExecution(a.(*api.PluginExecution), b.(*PluginExecution), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PluginSet)(nil), (*api.PluginSet)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_PluginSet_To_api_PluginSet(a.(*PluginSet), b.(*api.PluginSet), scope)
 	}); err != nil {
@@ -71,6 +182,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PodCache)(nil), (*api.PodCache)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PodCache_To_api_PodCache(a.(*PodCache), b.(*api.PodCache), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PodCache)(nil), (*PodCache)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PodCache_To_v1alpha2_PodCache(a.(*api.PodCache), b.(*PodCache), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PriorityThreshold)(nil), (*api.PriorityThreshold)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_PriorityThreshold_To_api_PriorityThreshold(a.(*PriorityThreshold), b.(*api.PriorityThreshold), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.PriorityThreshold)(nil), (*PriorityThreshold)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_PriorityThreshold_To_v1alpha2_PriorityThreshold(a.(*api.PriorityThreshold), b.(*PriorityThreshold), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StateStore)(nil), (*api.StateStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_StateStore_To_api_StateStore(a.(*StateStore), b.(*api.StateStore), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.StateStore)(nil), (*StateStore)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_StateStore_To_v1alpha2_StateStore(a.(*api.StateStore), b.(*StateStore), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*api.DeschedulerPolicy)(nil), (*DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(a.(*api.DeschedulerPolicy), b.(*DeschedulerPolicy), scope)
 	}); err != nil {
@@ -89,6 +230,48 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_ClientConnection_To_api_ClientConnection(in *ClientConnection, out *api.ClientConnection, s conversion.Scope) error {
+	out.QPS = (*float32)(unsafe.Pointer(in.QPS))
+	out.Burst = (*int32)(unsafe.Pointer(in.Burst))
+	return nil
+}
+
+// Convert_v1alpha2_ClientConnection_To_api_ClientConnection is an autogenerated conversion function.
+func Convert_v1alpha2_ClientConnection_To_api_ClientConnection(in *ClientConnection, out *api.ClientConnection, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ClientConnection_To_api_ClientConnection(in, out, s)
+}
+
+func autoConvert_api_ClientConnection_To_v1alpha2_ClientConnection(in *api.ClientConnection, out *ClientConnection, s conversion.Scope) error {
+	out.QPS = (*float32)(unsafe.Pointer(in.QPS))
+	out.Burst = (*int32)(unsafe.Pointer(in.Burst))
+	return nil
+}
+
+// Convert_api_ClientConnection_To_v1alpha2_ClientConnection is an autogenerated conversion function.
+func Convert_api_ClientConnection_To_v1alpha2_ClientConnection(in *api.ClientConnection, out *ClientConnection, s conversion.Scope) error {
+	return autoConvert_api_ClientConnection_To_v1alpha2_ClientConnection(in, out, s)
+}
+
+func autoConvert_v1alpha2_CycleAnomalyGuard_To_api_CycleAnomalyGuard(in *CycleAnomalyGuard, out *api.CycleAnomalyGuard, s conversion.Scope) error {
+	out.MaxEvictionPercentage = (*float64)(unsafe.Pointer(in.MaxEvictionPercentage))
+	return nil
+}
+
+// Convert_v1alpha2_CycleAnomalyGuard_To_api_CycleAnomalyGuard is an autogenerated conversion function.
+func Convert_v1alpha2_CycleAnomalyGuard_To_api_CycleAnomalyGuard(in *CycleAnomalyGuard, out *api.CycleAnomalyGuard, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CycleAnomalyGuard_To_api_CycleAnomalyGuard(in, out, s)
+}
+
+func autoConvert_api_CycleAnomalyGuard_To_v1alpha2_CycleAnomalyGuard(in *api.CycleAnomalyGuard, out *CycleAnomalyGuard, s conversion.Scope) error {
+	out.MaxEvictionPercentage = (*float64)(unsafe.Pointer(in.MaxEvictionPercentage))
+	return nil
+}
+
+// Convert_api_CycleAnomalyGuard_To_v1alpha2_CycleAnomalyGuard is an autogenerated conversion function.
+func Convert_api_CycleAnomalyGuard_To_v1alpha2_CycleAnomalyGuard(in *api.CycleAnomalyGuard, out *CycleAnomalyGuard, s conversion.Scope) error {
+	return autoConvert_api_CycleAnomalyGuard_To_v1alpha2_CycleAnomalyGuard(in, out, s)
+}
+
 func autoConvert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in *DeschedulerPolicy, out *api.DeschedulerPolicy, s conversion.Scope) error {
 	if in.Profiles != nil {
 		in, out := &in.Profiles, &out.Profiles
@@ -102,9 +285,28 @@ func autoConvert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in *Desched
 		out.Profiles = nil
 	}
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
+	out.NodeFieldSelector = (*string)(unsafe.Pointer(in.NodeFieldSelector))
+	out.NodeListPageSize = (*int64)(unsafe.Pointer(in.NodeListPageSize))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
 	out.MaxNoOfPodsToEvictTotal = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.WatchedNamespaces = *(*[]string)(unsafe.Pointer(&in.WatchedNamespaces))
+	out.EvictionPacing = (*api.EvictionPacing)(unsafe.Pointer(in.EvictionPacing))
+	out.PodCache = (*api.PodCache)(unsafe.Pointer(in.PodCache))
+	out.NodeDisruptionGuard = (*api.NodeDisruptionGuard)(unsafe.Pointer(in.NodeDisruptionGuard))
+	out.ClientConnection = (*api.ClientConnection)(unsafe.Pointer(in.ClientConnection))
+	out.InformerResyncPeriod = (*v1.Duration)(unsafe.Pointer(in.InformerResyncPeriod))
+	out.EvictionTimeout = (*v1.Duration)(unsafe.Pointer(in.EvictionTimeout))
+	out.EvictionThrottling = (*api.EvictionThrottling)(unsafe.Pointer(in.EvictionThrottling))
+	out.EvictionBatching = (*api.EvictionBatching)(unsafe.Pointer(in.EvictionBatching))
+	out.DisruptionBudgetPacing = (*api.DisruptionBudgetPacing)(unsafe.Pointer(in.DisruptionBudgetPacing))
+	out.CycleAnomalyGuard = (*api.CycleAnomalyGuard)(unsafe.Pointer(in.CycleAnomalyGuard))
+	out.StateStore = (*api.StateStore)(unsafe.Pointer(in.StateStore))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.PluginLogVerbosity = *(*map[string]int32)(unsafe.Pointer(&in.PluginLogVerbosity))
+	out.PluginExecution = (*api.PluginExecution)(unsafe.Pointer(in.PluginExecution))
+	out.Defaults = (*api.PluginDefaults)(unsafe.Pointer(in.Defaults))
+	out.EvictionOrdering = (*api.EvictionOrdering)(unsafe.Pointer(in.EvictionOrdering))
 	return nil
 }
 
@@ -121,14 +323,34 @@ func autoConvert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(in *api.Des
 		out.Profiles = nil
 	}
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
+	out.NodeFieldSelector = (*string)(unsafe.Pointer(in.NodeFieldSelector))
+	out.NodeListPageSize = (*int64)(unsafe.Pointer(in.NodeListPageSize))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
 	out.MaxNoOfPodsToEvictTotal = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictTotal))
+	out.WatchedNamespaces = *(*[]string)(unsafe.Pointer(&in.WatchedNamespaces))
+	out.EvictionPacing = (*EvictionPacing)(unsafe.Pointer(in.EvictionPacing))
+	out.PodCache = (*PodCache)(unsafe.Pointer(in.PodCache))
+	out.NodeDisruptionGuard = (*NodeDisruptionGuard)(unsafe.Pointer(in.NodeDisruptionGuard))
+	out.ClientConnection = (*ClientConnection)(unsafe.Pointer(in.ClientConnection))
+	out.InformerResyncPeriod = (*v1.Duration)(unsafe.Pointer(in.InformerResyncPeriod))
+	out.EvictionTimeout = (*v1.Duration)(unsafe.Pointer(in.EvictionTimeout))
+	out.EvictionThrottling = (*EvictionThrottling)(unsafe.Pointer(in.EvictionThrottling))
+	out.EvictionBatching = (*EvictionBatching)(unsafe.Pointer(in.EvictionBatching))
+	out.DisruptionBudgetPacing = (*DisruptionBudgetPacing)(unsafe.Pointer(in.DisruptionBudgetPacing))
+	out.CycleAnomalyGuard = (*CycleAnomalyGuard)(unsafe.Pointer(in.CycleAnomalyGuard))
+	out.StateStore = (*StateStore)(unsafe.Pointer(in.StateStore))
+	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.PluginLogVerbosity = *(*map[string]int32)(unsafe.Pointer(&in.PluginLogVerbosity))
+	out.PluginExecution = (*PluginExecution)(unsafe.Pointer(in.PluginExecution))
+	out.Defaults = (*PluginDefaults)(unsafe.Pointer(in.Defaults))
+	out.EvictionOrdering = (*EvictionOrdering)(unsafe.Pointer(in.EvictionOrdering))
 	return nil
 }
 
 func autoConvert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile(in *DeschedulerProfile, out *api.DeschedulerProfile, s conversion.Scope) error {
 	out.Name = in.Name
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
 	if in.PluginConfigs != nil {
 		in, out := &in.PluginConfigs, &out.PluginConfigs
 		*out = make([]api.PluginConfig, len(*in))
@@ -153,6 +375,7 @@ func Convert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile(in *Deschedul
 
 func autoConvert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *api.DeschedulerProfile, out *DeschedulerProfile, s conversion.Scope) error {
 	out.Name = in.Name
+	out.Labels = *(*map[string]string)(unsafe.Pointer(&in.Labels))
 	if in.PluginConfigs != nil {
 		in, out := &in.PluginConfigs, &out.PluginConfigs
 		*out = make([]PluginConfig, len(*in))
@@ -175,6 +398,156 @@ func Convert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *api.Desch
 	return autoConvert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in, out, s)
 }
 
+func autoConvert_v1alpha2_DisruptionBudgetPacing_To_api_DisruptionBudgetPacing(in *DisruptionBudgetPacing, out *api.DisruptionBudgetPacing, s conversion.Scope) error {
+	out.RetryInterval = (*v1.Duration)(unsafe.Pointer(in.RetryInterval))
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	return nil
+}
+
+// Convert_v1alpha2_DisruptionBudgetPacing_To_api_DisruptionBudgetPacing is an autogenerated conversion function.
+func Convert_v1alpha2_DisruptionBudgetPacing_To_api_DisruptionBudgetPacing(in *DisruptionBudgetPacing, out *api.DisruptionBudgetPacing, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DisruptionBudgetPacing_To_api_DisruptionBudgetPacing(in, out, s)
+}
+
+func autoConvert_api_DisruptionBudgetPacing_To_v1alpha2_DisruptionBudgetPacing(in *api.DisruptionBudgetPacing, out *DisruptionBudgetPacing, s conversion.Scope) error {
+	out.RetryInterval = (*v1.Duration)(unsafe.Pointer(in.RetryInterval))
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	return nil
+}
+
+// Convert_api_DisruptionBudgetPacing_To_v1alpha2_DisruptionBudgetPacing is an autogenerated conversion function.
+func Convert_api_DisruptionBudgetPacing_To_v1alpha2_DisruptionBudgetPacing(in *api.DisruptionBudgetPacing, out *DisruptionBudgetPacing, s conversion.Scope) error {
+	return autoConvert_api_DisruptionBudgetPacing_To_v1alpha2_DisruptionBudgetPacing(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictionBatching_To_api_EvictionBatching(in *EvictionBatching, out *api.EvictionBatching, s conversion.Scope) error {
+	out.BatchSize = (*int32)(unsafe.Pointer(in.BatchSize))
+	out.FlushInterval = (*v1.Duration)(unsafe.Pointer(in.FlushInterval))
+	return nil
+}
+
+// Convert_v1alpha2_EvictionBatching_To_api_EvictionBatching is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionBatching_To_api_EvictionBatching(in *EvictionBatching, out *api.EvictionBatching, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionBatching_To_api_EvictionBatching(in, out, s)
+}
+
+func autoConvert_api_EvictionBatching_To_v1alpha2_EvictionBatching(in *api.EvictionBatching, out *EvictionBatching, s conversion.Scope) error {
+	out.BatchSize = (*int32)(unsafe.Pointer(in.BatchSize))
+	out.FlushInterval = (*v1.Duration)(unsafe.Pointer(in.FlushInterval))
+	return nil
+}
+
+// Convert_api_EvictionBatching_To_v1alpha2_EvictionBatching is an autogenerated conversion function.
+func Convert_api_EvictionBatching_To_v1alpha2_EvictionBatching(in *api.EvictionBatching, out *EvictionBatching, s conversion.Scope) error {
+	return autoConvert_api_EvictionBatching_To_v1alpha2_EvictionBatching(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictionOrdering_To_api_EvictionOrdering(in *EvictionOrdering, out *api.EvictionOrdering, s conversion.Scope) error {
+	out.DisruptionHeadroom = in.DisruptionHeadroom
+	return nil
+}
+
+// Convert_v1alpha2_EvictionOrdering_To_api_EvictionOrdering is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionOrdering_To_api_EvictionOrdering(in *EvictionOrdering, out *api.EvictionOrdering, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionOrdering_To_api_EvictionOrdering(in, out, s)
+}
+
+func autoConvert_api_EvictionOrdering_To_v1alpha2_EvictionOrdering(in *api.EvictionOrdering, out *EvictionOrdering, s conversion.Scope) error {
+	out.DisruptionHeadroom = in.DisruptionHeadroom
+	return nil
+}
+
+// Convert_api_EvictionOrdering_To_v1alpha2_EvictionOrdering is an autogenerated conversion function.
+func Convert_api_EvictionOrdering_To_v1alpha2_EvictionOrdering(in *api.EvictionOrdering, out *EvictionOrdering, s conversion.Scope) error {
+	return autoConvert_api_EvictionOrdering_To_v1alpha2_EvictionOrdering(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictionPacing_To_api_EvictionPacing(in *EvictionPacing, out *api.EvictionPacing, s conversion.Scope) error {
+	out.Period = (*v1.Duration)(unsafe.Pointer(in.Period))
+	return nil
+}
+
+// Convert_v1alpha2_EvictionPacing_To_api_EvictionPacing is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionPacing_To_api_EvictionPacing(in *EvictionPacing, out *api.EvictionPacing, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionPacing_To_api_EvictionPacing(in, out, s)
+}
+
+func autoConvert_api_EvictionPacing_To_v1alpha2_EvictionPacing(in *api.EvictionPacing, out *EvictionPacing, s conversion.Scope) error {
+	out.Period = (*v1.Duration)(unsafe.Pointer(in.Period))
+	return nil
+}
+
+// Convert_api_EvictionPacing_To_v1alpha2_EvictionPacing is an autogenerated conversion function.
+func Convert_api_EvictionPacing_To_v1alpha2_EvictionPacing(in *api.EvictionPacing, out *EvictionPacing, s conversion.Scope) error {
+	return autoConvert_api_EvictionPacing_To_v1alpha2_EvictionPacing(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictionThrottling_To_api_EvictionThrottling(in *EvictionThrottling, out *api.EvictionThrottling, s conversion.Scope) error {
+	out.ThrottledEvictions = (*int32)(unsafe.Pointer(in.ThrottledEvictions))
+	out.InitialBackoff = (*v1.Duration)(unsafe.Pointer(in.InitialBackoff))
+	out.MaxBackoff = (*v1.Duration)(unsafe.Pointer(in.MaxBackoff))
+	return nil
+}
+
+// Convert_v1alpha2_EvictionThrottling_To_api_EvictionThrottling is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionThrottling_To_api_EvictionThrottling(in *EvictionThrottling, out *api.EvictionThrottling, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionThrottling_To_api_EvictionThrottling(in, out, s)
+}
+
+func autoConvert_api_EvictionThrottling_To_v1alpha2_EvictionThrottling(in *api.EvictionThrottling, out *EvictionThrottling, s conversion.Scope) error {
+	out.ThrottledEvictions = (*int32)(unsafe.Pointer(in.ThrottledEvictions))
+	out.InitialBackoff = (*v1.Duration)(unsafe.Pointer(in.InitialBackoff))
+	out.MaxBackoff = (*v1.Duration)(unsafe.Pointer(in.MaxBackoff))
+	return nil
+}
+
+// Convert_api_EvictionThrottling_To_v1alpha2_EvictionThrottling is an autogenerated conversion function.
+func Convert_api_EvictionThrottling_To_v1alpha2_EvictionThrottling(in *api.EvictionThrottling, out *EvictionThrottling, s conversion.Scope) error {
+	return autoConvert_api_EvictionThrottling_To_v1alpha2_EvictionThrottling(in, out, s)
+}
+
+func autoConvert_v1alpha2_Namespaces_To_api_Namespaces(in *Namespaces, out *api.Namespaces, s conversion.Scope) error {
+	out.Include = *(*[]string)(unsafe.Pointer(&in.Include))
+	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
+	return nil
+}
+
+// Convert_v1alpha2_Namespaces_To_api_Namespaces is an autogenerated conversion function.
+func Convert_v1alpha2_Namespaces_To_api_Namespaces(in *Namespaces, out *api.Namespaces, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Namespaces_To_api_Namespaces(in, out, s)
+}
+
+func autoConvert_api_Namespaces_To_v1alpha2_Namespaces(in *api.Namespaces, out *Namespaces, s conversion.Scope) error {
+	out.Include = *(*[]string)(unsafe.Pointer(&in.Include))
+	out.Exclude = *(*[]string)(unsafe.Pointer(&in.Exclude))
+	return nil
+}
+
+// Convert_api_Namespaces_To_v1alpha2_Namespaces is an autogenerated conversion function.
+func Convert_api_Namespaces_To_v1alpha2_Namespaces(in *api.Namespaces, out *Namespaces, s conversion.Scope) error {
+	return autoConvert_api_Namespaces_To_v1alpha2_Namespaces(in, out, s)
+}
+
+func autoConvert_v1alpha2_NodeDisruptionGuard_To_api_NodeDisruptionGuard(in *NodeDisruptionGuard, out *api.NodeDisruptionGuard, s conversion.Scope) error {
+	out.Window = (*v1.Duration)(unsafe.Pointer(in.Window))
+	return nil
+}
+
+// Convert_v1alpha2_NodeDisruptionGuard_To_api_NodeDisruptionGuard is an autogenerated conversion function.
+func Convert_v1alpha2_NodeDisruptionGuard_To_api_NodeDisruptionGuard(in *NodeDisruptionGuard, out *api.NodeDisruptionGuard, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NodeDisruptionGuard_To_api_NodeDisruptionGuard(in, out, s)
+}
+
+func autoConvert_api_NodeDisruptionGuard_To_v1alpha2_NodeDisruptionGuard(in *api.NodeDisruptionGuard, out *NodeDisruptionGuard, s conversion.Scope) error {
+	out.Window = (*v1.Duration)(unsafe.Pointer(in.Window))
+	return nil
+}
+
+// Convert_api_NodeDisruptionGuard_To_v1alpha2_NodeDisruptionGuard is an autogenerated conversion function.
+func Convert_api_NodeDisruptionGuard_To_v1alpha2_NodeDisruptionGuard(in *api.NodeDisruptionGuard, out *NodeDisruptionGuard, s conversion.Scope) error {
+	return autoConvert_api_NodeDisruptionGuard_To_v1alpha2_NodeDisruptionGuard(in, out, s)
+}
+
 func autoConvert_v1alpha2_PluginConfig_To_api_PluginConfig(in *PluginConfig, out *api.PluginConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&in.Args, &out.Args, s); err != nil {
@@ -196,6 +569,62 @@ func Convert_api_PluginConfig_To_v1alpha2_PluginConfig(in *api.PluginConfig, out
 	return autoConvert_api_PluginConfig_To_v1alpha2_PluginConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_PluginDefaults_To_api_PluginDefaults(in *PluginDefaults, out *api.PluginDefaults, s conversion.Scope) error {
+	out.Namespaces = (*api.Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.PriorityThreshold = (*api.PriorityThreshold)(unsafe.Pointer(in.PriorityThreshold))
+	out.NodeFit = (*bool)(unsafe.Pointer(in.NodeFit))
+	return nil
+}
+
+// Convert_v1alpha2_PluginDefaults_To_api_PluginDefaults is an autogenerated conversion function.
+func Convert_v1alpha2_PluginDefaults_To_api_PluginDefaults(in *PluginDefaults, out *api.PluginDefaults, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PluginDefaults_To_api_PluginDefaults(in, out, s)
+}
+
+func autoConvert_api_PluginDefaults_To_v1alpha2_PluginDefaults(in *api.PluginDefaults, out *PluginDefaults, s conversion.Scope) error {
+	out.Namespaces = (*Namespaces)(unsafe.Pointer(in.Namespaces))
+	out.LabelSelector = (*v1.LabelSelector)(unsafe.Pointer(in.LabelSelector))
+	out.PriorityThreshold = (*PriorityThreshold)(unsafe.Pointer(in.PriorityThreshold))
+	out.NodeFit = (*bool)(unsafe.Pointer(in.NodeFit))
+	return nil
+}
+
+// Convert_api_PluginDefaults_To_v1alpha2_PluginDefaults is an autogenerated conversion function.
+func Convert_api_PluginDefaults_To_v1alpha2_PluginDefaults(in *api.PluginDefaults, out *PluginDefaults, s conversion.Scope) error {
+	return autoConvert_api_PluginDefaults_To_v1alpha2_PluginDefaults(in, out, s)
+}
+
+func autoConvert_v1alpha2_PluginExecution_To_api_PluginExecution(in *PluginExecution, out *api.PluginExecution, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.PluginTimeouts = *(*map[string]v1.Duration)(unsafe.Pointer(&in.PluginTimeouts))
+	out.FailureThreshold = (*int32)(unsafe.Pointer(in.FailureThreshold))
+	out.CooldownCycles = (*int32)(unsafe.Pointer(in.CooldownCycles))
+	out.CycleDeadline = (*v1.Duration)(unsafe.Pointer(in.CycleDeadline))
+	out.RunSkippedPluginsFirst = in.RunSkippedPluginsFirst
+	return nil
+}
+
+// Convert_v1alpha2_PluginExecution_To_api_PluginExecution is an autogenerated conversion function.
+func Convert_v1alpha2_PluginExecution_To_api_PluginExecution(in *PluginExecution, out *api.PluginExecution, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PluginExecution_To_api_PluginExecution(in, out, s)
+}
+
+func autoConvert_api_PluginExecution_To_v1alpha2_PluginExecution(in *api.PluginExecution, out *PluginExecution, s conversion.Scope) error {
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	out.PluginTimeouts = *(*map[string]v1.Duration)(unsafe.Pointer(&in.PluginTimeouts))
+	out.FailureThreshold = (*int32)(unsafe.Pointer(in.FailureThreshold))
+	out.CooldownCycles = (*int32)(unsafe.Pointer(in.CooldownCycles))
+	out.CycleDeadline = (*v1.Duration)(unsafe.Pointer(in.CycleDeadline))
+	out.RunSkippedPluginsFirst = in.RunSkippedPluginsFirst
+	return nil
+}
+
+// Convert_api_PluginExecution_To_v1alpha2_PluginExecution is an autogenerated conversion function.
+func Convert_api_PluginExecution_To_v1alpha2_PluginExecution(in *api.PluginExecution, out *PluginExecution, s conversion.Scope) error {
+	return autoConvert_api_PluginExecution_To_v1alpha2_PluginExecution(in, out, s)
+}
+
 func autoConvert_v1alpha2_PluginSet_To_api_PluginSet(in *PluginSet, out *api.PluginSet, s conversion.Scope) error {
 	out.Enabled = *(*[]string)(unsafe.Pointer(&in.Enabled))
 	out.Disabled = *(*[]string)(unsafe.Pointer(&in.Disabled))
@@ -271,3 +700,73 @@ func autoConvert_api_Plugins_To_v1alpha2_Plugins(in *api.Plugins, out *Plugins,
 func Convert_api_Plugins_To_v1alpha2_Plugins(in *api.Plugins, out *Plugins, s conversion.Scope) error {
 	return autoConvert_api_Plugins_To_v1alpha2_Plugins(in, out, s)
 }
+
+func autoConvert_v1alpha2_PodCache_To_api_PodCache(in *PodCache, out *api.PodCache, s conversion.Scope) error {
+	out.MaxAnnotationSize = (*int32)(unsafe.Pointer(in.MaxAnnotationSize))
+	out.StripContainerEnv = in.StripContainerEnv
+	out.LabelSelector = in.LabelSelector
+	out.FieldSelector = in.FieldSelector
+	return nil
+}
+
+// Convert_v1alpha2_PodCache_To_api_PodCache is an autogenerated conversion function.
+func Convert_v1alpha2_PodCache_To_api_PodCache(in *PodCache, out *api.PodCache, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PodCache_To_api_PodCache(in, out, s)
+}
+
+func autoConvert_api_PodCache_To_v1alpha2_PodCache(in *api.PodCache, out *PodCache, s conversion.Scope) error {
+	out.MaxAnnotationSize = (*int32)(unsafe.Pointer(in.MaxAnnotationSize))
+	out.StripContainerEnv = in.StripContainerEnv
+	out.LabelSelector = in.LabelSelector
+	out.FieldSelector = in.FieldSelector
+	return nil
+}
+
+// Convert_api_PodCache_To_v1alpha2_PodCache is an autogenerated conversion function.
+func Convert_api_PodCache_To_v1alpha2_PodCache(in *api.PodCache, out *PodCache, s conversion.Scope) error {
+	return autoConvert_api_PodCache_To_v1alpha2_PodCache(in, out, s)
+}
+
+func autoConvert_v1alpha2_PriorityThreshold_To_api_PriorityThreshold(in *PriorityThreshold, out *api.PriorityThreshold, s conversion.Scope) error {
+	out.Value = (*int32)(unsafe.Pointer(in.Value))
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_PriorityThreshold_To_api_PriorityThreshold is an autogenerated conversion function.
+func Convert_v1alpha2_PriorityThreshold_To_api_PriorityThreshold(in *PriorityThreshold, out *api.PriorityThreshold, s conversion.Scope) error {
+	return autoConvert_v1alpha2_PriorityThreshold_To_api_PriorityThreshold(in, out, s)
+}
+
+func autoConvert_api_PriorityThreshold_To_v1alpha2_PriorityThreshold(in *api.PriorityThreshold, out *PriorityThreshold, s conversion.Scope) error {
+	out.Value = (*int32)(unsafe.Pointer(in.Value))
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_api_PriorityThreshold_To_v1alpha2_PriorityThreshold is an autogenerated conversion function.
+func Convert_api_PriorityThreshold_To_v1alpha2_PriorityThreshold(in *api.PriorityThreshold, out *PriorityThreshold, s conversion.Scope) error {
+	return autoConvert_api_PriorityThreshold_To_v1alpha2_PriorityThreshold(in, out, s)
+}
+
+func autoConvert_v1alpha2_StateStore_To_api_StateStore(in *StateStore, out *api.StateStore, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_StateStore_To_api_StateStore is an autogenerated conversion function.
+func Convert_v1alpha2_StateStore_To_api_StateStore(in *StateStore, out *api.StateStore, s conversion.Scope) error {
+	return autoConvert_v1alpha2_StateStore_To_api_StateStore(in, out, s)
+}
+
+func autoConvert_api_StateStore_To_v1alpha2_StateStore(in *api.StateStore, out *StateStore, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_api_StateStore_To_v1alpha2_StateStore is an autogenerated conversion function.
+func Convert_api_StateStore_To_v1alpha2_StateStore(in *api.StateStore, out *StateStore, s conversion.Scope) error {
+	return autoConvert_api_StateStore_To_v1alpha2_StateStore(in, out, s)
+}
@@ -22,9 +22,57 @@ limitations under the License.
 package v1alpha2
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientConnection) DeepCopyInto(out *ClientConnection) {
+	*out = *in
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(float32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientConnection.
+func (in *ClientConnection) DeepCopy() *ClientConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CycleAnomalyGuard) DeepCopyInto(out *CycleAnomalyGuard) {
+	*out = *in
+	if in.MaxEvictionPercentage != nil {
+		in, out := &in.MaxEvictionPercentage, &out.MaxEvictionPercentage
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CycleAnomalyGuard.
+func (in *CycleAnomalyGuard) DeepCopy() *CycleAnomalyGuard {
+	if in == nil {
+		return nil
+	}
+	out := new(CycleAnomalyGuard)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 	*out = *in
@@ -41,6 +89,16 @@ func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.NodeFieldSelector != nil {
+		in, out := &in.NodeFieldSelector, &out.NodeFieldSelector
+		*out = new(string)
+		**out = **in
+	}
+	if in.NodeListPageSize != nil {
+		in, out := &in.NodeListPageSize, &out.NodeListPageSize
+		*out = new(int64)
+		**out = **in
+	}
 	if in.MaxNoOfPodsToEvictPerNode != nil {
 		in, out := &in.MaxNoOfPodsToEvictPerNode, &out.MaxNoOfPodsToEvictPerNode
 		*out = new(uint)
@@ -56,6 +114,95 @@ func (in *DeschedulerPolicy) DeepCopyInto(out *DeschedulerPolicy) {
 		*out = new(uint)
 		**out = **in
 	}
+	if in.WatchedNamespaces != nil {
+		in, out := &in.WatchedNamespaces, &out.WatchedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EvictionPacing != nil {
+		in, out := &in.EvictionPacing, &out.EvictionPacing
+		*out = new(EvictionPacing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodCache != nil {
+		in, out := &in.PodCache, &out.PodCache
+		*out = new(PodCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeDisruptionGuard != nil {
+		in, out := &in.NodeDisruptionGuard, &out.NodeDisruptionGuard
+		*out = new(NodeDisruptionGuard)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientConnection != nil {
+		in, out := &in.ClientConnection, &out.ClientConnection
+		*out = new(ClientConnection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InformerResyncPeriod != nil {
+		in, out := &in.InformerResyncPeriod, &out.InformerResyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EvictionTimeout != nil {
+		in, out := &in.EvictionTimeout, &out.EvictionTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.EvictionThrottling != nil {
+		in, out := &in.EvictionThrottling, &out.EvictionThrottling
+		*out = new(EvictionThrottling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictionBatching != nil {
+		in, out := &in.EvictionBatching, &out.EvictionBatching
+		*out = new(EvictionBatching)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisruptionBudgetPacing != nil {
+		in, out := &in.DisruptionBudgetPacing, &out.DisruptionBudgetPacing
+		*out = new(DisruptionBudgetPacing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CycleAnomalyGuard != nil {
+		in, out := &in.CycleAnomalyGuard, &out.CycleAnomalyGuard
+		*out = new(CycleAnomalyGuard)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StateStore != nil {
+		in, out := &in.StateStore, &out.StateStore
+		*out = new(StateStore)
+		**out = **in
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PluginLogVerbosity != nil {
+		in, out := &in.PluginLogVerbosity, &out.PluginLogVerbosity
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PluginExecution != nil {
+		in, out := &in.PluginExecution, &out.PluginExecution
+		*out = new(PluginExecution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(PluginDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictionOrdering != nil {
+		in, out := &in.EvictionOrdering, &out.EvictionOrdering
+		*out = new(EvictionOrdering)
+		**out = **in
+	}
 	return
 }
 
@@ -80,6 +227,13 @@ func (in *DeschedulerPolicy) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeschedulerProfile) DeepCopyInto(out *DeschedulerProfile) {
 	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.PluginConfigs != nil {
 		in, out := &in.PluginConfigs, &out.PluginConfigs
 		*out = make([]PluginConfig, len(*in))
@@ -101,6 +255,173 @@ func (in *DeschedulerProfile) DeepCopy() *DeschedulerProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisruptionBudgetPacing) DeepCopyInto(out *DisruptionBudgetPacing) {
+	*out = *in
+	if in.RetryInterval != nil {
+		in, out := &in.RetryInterval, &out.RetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBudgetPacing.
+func (in *DisruptionBudgetPacing) DeepCopy() *DisruptionBudgetPacing {
+	if in == nil {
+		return nil
+	}
+	out := new(DisruptionBudgetPacing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionBatching) DeepCopyInto(out *EvictionBatching) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FlushInterval != nil {
+		in, out := &in.FlushInterval, &out.FlushInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionBatching.
+func (in *EvictionBatching) DeepCopy() *EvictionBatching {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionBatching)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionOrdering) DeepCopyInto(out *EvictionOrdering) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionOrdering.
+func (in *EvictionOrdering) DeepCopy() *EvictionOrdering {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionOrdering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionPacing) DeepCopyInto(out *EvictionPacing) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionPacing.
+func (in *EvictionPacing) DeepCopy() *EvictionPacing {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionPacing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionThrottling) DeepCopyInto(out *EvictionThrottling) {
+	*out = *in
+	if in.ThrottledEvictions != nil {
+		in, out := &in.ThrottledEvictions, &out.ThrottledEvictions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionThrottling.
+func (in *EvictionThrottling) DeepCopy() *EvictionThrottling {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionThrottling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Namespaces) DeepCopyInto(out *Namespaces) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Namespaces.
+func (in *Namespaces) DeepCopy() *Namespaces {
+	if in == nil {
+		return nil
+	}
+	out := new(Namespaces)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDisruptionGuard) DeepCopyInto(out *NodeDisruptionGuard) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDisruptionGuard.
+func (in *NodeDisruptionGuard) DeepCopy() *NodeDisruptionGuard {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDisruptionGuard)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginConfig) DeepCopyInto(out *PluginConfig) {
 	*out = *in
@@ -118,6 +439,85 @@ func (in *PluginConfig) DeepCopy() *PluginConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginDefaults) DeepCopyInto(out *PluginDefaults) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new(Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityThreshold != nil {
+		in, out := &in.PriorityThreshold, &out.PriorityThreshold
+		*out = new(PriorityThreshold)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeFit != nil {
+		in, out := &in.NodeFit, &out.NodeFit
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginDefaults.
+func (in *PluginDefaults) DeepCopy() *PluginDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginExecution) DeepCopyInto(out *PluginExecution) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PluginTimeouts != nil {
+		in, out := &in.PluginTimeouts, &out.PluginTimeouts
+		*out = make(map[string]v1.Duration, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CooldownCycles != nil {
+		in, out := &in.CooldownCycles, &out.CooldownCycles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CycleDeadline != nil {
+		in, out := &in.CycleDeadline, &out.CycleDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginExecution.
+func (in *PluginExecution) DeepCopy() *PluginExecution {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginExecution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginSet) DeepCopyInto(out *PluginSet) {
 	*out = *in
@@ -165,3 +565,61 @@ func (in *Plugins) DeepCopy() *Plugins {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCache) DeepCopyInto(out *PodCache) {
+	*out = *in
+	if in.MaxAnnotationSize != nil {
+		in, out := &in.MaxAnnotationSize, &out.MaxAnnotationSize
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCache.
+func (in *PodCache) DeepCopy() *PodCache {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityThreshold) DeepCopyInto(out *PriorityThreshold) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityThreshold.
+func (in *PriorityThreshold) DeepCopy() *PriorityThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateStore) DeepCopyInto(out *StateStore) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateStore.
+func (in *StateStore) DeepCopy() *StateStore {
+	if in == nil {
+		return nil
+	}
+	out := new(StateStore)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"time"
+
+	componentbaseconfig "k8s.io/component-base/config"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+const (
+	// defaultThrottledEvictions is the number of consecutive throttled evictions before backing off
+	defaultThrottledEvictions = 3
+	// defaultInitialEvictionBackoff is the first delay between two evictions once backing off
+	defaultInitialEvictionBackoff = time.Second
+	// defaultMaxEvictionBackoff caps the delay between two evictions
+	defaultMaxEvictionBackoff = time.Minute
+	// defaultEvictionBatchSize is the number of pending writes flushing the batch
+	defaultEvictionBatchSize = 50
+	// defaultEvictionBatchFlushInterval is the longest time a write stays pending
+	defaultEvictionBatchFlushInterval = time.Second
+)
+
+// applyPolicyClientConnection sets the QPS and burst of the policy the flags left unset,
+// and returns whether the client connection changed.
+func applyPolicyClientConnection(clientConnection *componentbaseconfig.ClientConnectionConfiguration, policy *api.ClientConnection) bool {
+	if policy == nil {
+		return false
+	}
+	changed := false
+	if policy.QPS != nil && clientConnection.QPS == 0 {
+		clientConnection.QPS = *policy.QPS
+		changed = true
+	}
+	if policy.Burst != nil && clientConnection.Burst == 0 {
+		clientConnection.Burst = *policy.Burst
+		changed = true
+	}
+	return changed
+}
+
+// informerResyncPeriod returns the resync period of the informers, the flag taking precedence over the policy
+func informerResyncPeriod(rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy) time.Duration {
+	if rs.InformerResyncPeriod == 0 && deschedulerPolicy.InformerResyncPeriod != nil {
+		return deschedulerPolicy.InformerResyncPeriod.Duration
+	}
+	return rs.InformerResyncPeriod
+}
+
+// evictionTimeout returns the timeout of the eviction requests, the flag taking precedence over the policy
+func evictionTimeout(rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy) time.Duration {
+	if rs.EvictionTimeout == 0 && deschedulerPolicy.EvictionTimeout != nil {
+		return deschedulerPolicy.EvictionTimeout.Duration
+	}
+	return rs.EvictionTimeout
+}
+
+// evictionThrottling returns the settings of the eviction backoff, filling in the defaults.
+// Zero throttled evictions disables the backoff.
+func evictionThrottling(throttling *api.EvictionThrottling) (throttledEvictions int, initialBackoff, maxBackoff time.Duration) {
+	throttledEvictions, initialBackoff, maxBackoff = defaultThrottledEvictions, defaultInitialEvictionBackoff, defaultMaxEvictionBackoff
+	if throttling == nil {
+		return
+	}
+	if throttling.ThrottledEvictions != nil {
+		throttledEvictions = int(*throttling.ThrottledEvictions)
+	}
+	if throttling.InitialBackoff != nil {
+		initialBackoff = throttling.InitialBackoff.Duration
+	}
+	if throttling.MaxBackoff != nil {
+		maxBackoff = throttling.MaxBackoff.Duration
+	}
+	return
+}
+
+// evictionBatching returns the batch size and the flush interval of the batched writes
+// accompanying the evictions, filling in the defaults
+func evictionBatching(batching *api.EvictionBatching) (batchSize int, flushInterval time.Duration) {
+	batchSize, flushInterval = defaultEvictionBatchSize, defaultEvictionBatchFlushInterval
+	if batching.BatchSize != nil {
+		batchSize = int(*batching.BatchSize)
+	}
+	if batching.FlushInterval != nil {
+		flushInterval = batching.FlushInterval.Duration
+	}
+	return
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	componentbaseconfig "k8s.io/component-base/config"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestApplyPolicyClientConnection(t *testing.T) {
+	tests := []struct {
+		description     string
+		flags           componentbaseconfig.ClientConnectionConfiguration
+		policy          *api.ClientConnection
+		expected        componentbaseconfig.ClientConnectionConfiguration
+		expectedChanged bool
+	}{
+		{
+			description: "no policy client connection",
+			flags:       componentbaseconfig.ClientConnectionConfiguration{QPS: 20},
+			expected:    componentbaseconfig.ClientConnectionConfiguration{QPS: 20},
+		},
+		{
+			description:     "policy client connection",
+			policy:          &api.ClientConnection{QPS: utilptr.To[float32](50), Burst: utilptr.To[int32](100)},
+			expected:        componentbaseconfig.ClientConnectionConfiguration{QPS: 50, Burst: 100},
+			expectedChanged: true,
+		},
+		{
+			description:     "flags take precedence",
+			flags:           componentbaseconfig.ClientConnectionConfiguration{QPS: 20},
+			policy:          &api.ClientConnection{QPS: utilptr.To[float32](50), Burst: utilptr.To[int32](100)},
+			expected:        componentbaseconfig.ClientConnectionConfiguration{QPS: 20, Burst: 100},
+			expectedChanged: true,
+		},
+		{
+			description: "all set through the flags",
+			flags:       componentbaseconfig.ClientConnectionConfiguration{QPS: 20, Burst: 40},
+			policy:      &api.ClientConnection{QPS: utilptr.To[float32](50), Burst: utilptr.To[int32](100)},
+			expected:    componentbaseconfig.ClientConnectionConfiguration{QPS: 20, Burst: 40},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			clientConnection := tc.flags
+			changed := applyPolicyClientConnection(&clientConnection, tc.policy)
+			if changed != tc.expectedChanged {
+				t.Errorf("Expected changed to be %v, got %v", tc.expectedChanged, changed)
+			}
+			if clientConnection != tc.expected {
+				t.Errorf("Expected client connection %+v, got %+v", tc.expected, clientConnection)
+			}
+		})
+	}
+}
+
+func TestInformerResyncPeriodAndEvictionTimeout(t *testing.T) {
+	deschedulerPolicy := &api.DeschedulerPolicy{
+		InformerResyncPeriod: &metav1.Duration{Duration: time.Hour},
+		EvictionTimeout:      &metav1.Duration{Duration: 30 * time.Second},
+	}
+
+	rs := &options.DeschedulerServer{}
+	if period := informerResyncPeriod(rs, deschedulerPolicy); period != time.Hour {
+		t.Errorf("Expected the resync period of the policy, got %v", period)
+	}
+	if timeout := evictionTimeout(rs, deschedulerPolicy); timeout != 30*time.Second {
+		t.Errorf("Expected the eviction timeout of the policy, got %v", timeout)
+	}
+
+	rs = &options.DeschedulerServer{InformerResyncPeriod: 10 * time.Minute, EvictionTimeout: time.Minute}
+	if period := informerResyncPeriod(rs, deschedulerPolicy); period != 10*time.Minute {
+		t.Errorf("Expected the resync period of the flag, got %v", period)
+	}
+	if timeout := evictionTimeout(rs, deschedulerPolicy); timeout != time.Minute {
+		t.Errorf("Expected the eviction timeout of the flag, got %v", timeout)
+	}
+
+	if period := informerResyncPeriod(&options.DeschedulerServer{}, &api.DeschedulerPolicy{}); period != 0 {
+		t.Errorf("Expected resyncs to be disabled by default, got %v", period)
+	}
+}
+
+func TestEvictionThrottling(t *testing.T) {
+	throttledEvictions, initialBackoff, maxBackoff := evictionThrottling(nil)
+	if throttledEvictions != defaultThrottledEvictions || initialBackoff != defaultInitialEvictionBackoff || maxBackoff != defaultMaxEvictionBackoff {
+		t.Errorf("Expected the default throttling, got %v %v %v", throttledEvictions, initialBackoff, maxBackoff)
+	}
+
+	throttledEvictions, initialBackoff, maxBackoff = evictionThrottling(&api.EvictionThrottling{
+		ThrottledEvictions: utilptr.To[int32](0),
+		MaxBackoff:         &metav1.Duration{Duration: 5 * time.Minute},
+	})
+	if throttledEvictions != 0 || initialBackoff != defaultInitialEvictionBackoff || maxBackoff != 5*time.Minute {
+		t.Errorf("Unexpected throttling %v %v %v", throttledEvictions, initialBackoff, maxBackoff)
+	}
+}
+
+func TestEvictionBatching(t *testing.T) {
+	batchSize, flushInterval := evictionBatching(&api.EvictionBatching{})
+	if batchSize != defaultEvictionBatchSize || flushInterval != defaultEvictionBatchFlushInterval {
+		t.Errorf("Expected the default batching, got %v %v", batchSize, flushInterval)
+	}
+
+	batchSize, flushInterval = evictionBatching(&api.EvictionBatching{
+		BatchSize: utilptr.To[int32](10),
+	})
+	if batchSize != 10 || flushInterval != defaultEvictionBatchFlushInterval {
+		t.Errorf("Unexpected batching %v %v", batchSize, flushInterval)
+	}
+}
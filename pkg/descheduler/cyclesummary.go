@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
+)
+
+// Formats of the summary logged at the end of each descheduling cycle
+const (
+	CycleSummaryFormatTable = "table"
+	CycleSummaryFormatJSON  = "json"
+	CycleSummaryFormatNone  = "none"
+)
+
+func validateCycleSummaryFormat(format string) error {
+	switch format {
+	case "", CycleSummaryFormatNone, CycleSummaryFormatTable, CycleSummaryFormatJSON:
+		return nil
+	}
+	return fmt.Errorf("unsupported cycle summary format %q, must be one of: %s, %s, %s", format, CycleSummaryFormatTable, CycleSummaryFormatJSON, CycleSummaryFormatNone)
+}
+
+// formatSkipped renders the skipped pods by reason as reason=count pairs sorted by reason
+func formatSkipped(skipped map[string]int) string {
+	if len(skipped) == 0 {
+		return "<none>"
+	}
+	reasons := make([]string, 0, len(skipped))
+	for reason := range skipped {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	pairs := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", reason, skipped[reason]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// formatDetails renders the details reported by a plugin as key=value pairs sorted by key
+func formatDetails(details map[string]string) string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, details[key]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func writeCycleSummaryTable(w io.Writer, summaries []frameworkprofile.PluginSummary) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	fmt.Fprintln(tw, "PROFILE\tPLUGIN\tEXTENSION POINT\tNODES\tCANDIDATES\tEVICTED\tSKIPPED\tDURATION\tERROR")
+	for _, summary := range summaries {
+		errorMessage := summary.Error
+		if errorMessage == "" {
+			errorMessage = "<none>"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\n",
+			summary.Profile,
+			summary.Plugin,
+			summary.ExtensionPoint,
+			summary.Nodes,
+			summary.Candidates,
+			summary.Evicted,
+			formatSkipped(summary.Skipped),
+			summary.Duration.Round(time.Millisecond),
+			errorMessage,
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if err := writeEvictionsTable(w, summaries); err != nil {
+		return err
+	}
+	return writeDetailsTable(w, summaries)
+}
+
+// writeDetailsTable lists the details reported by the plugins, nothing is written when none reported any
+func writeDetailsTable(w io.Writer, summaries []frameworkprofile.PluginSummary) error {
+	header := false
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	for _, summary := range summaries {
+		if len(summary.Details) == 0 {
+			continue
+		}
+		if !header {
+			fmt.Fprintln(tw, "\nPROFILE\tPLUGIN\tEXTENSION POINT\tDETAILS")
+			header = true
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", summary.Profile, summary.Plugin, summary.ExtensionPoint, formatDetails(summary.Details))
+	}
+	return tw.Flush()
+}
+
+// writeEvictionsTable lists the pods the plugins tried to evict along with their predicted destinations,
+// nothing is written when the destinations are not predicted
+func writeEvictionsTable(w io.Writer, summaries []frameworkprofile.PluginSummary) error {
+	header := false
+	tw := tabwriter.NewWriter(w, 0, 8, 3, ' ', 0)
+	for _, summary := range summaries {
+		for _, eviction := range summary.Evictions {
+			if !header {
+				fmt.Fprintln(tw, "\nPROFILE\tPLUGIN\tPOD\tNODE\tRESULT\tFITTING NODES\tDESTINATIONS")
+				header = true
+			}
+			destinations := strings.Join(eviction.Destinations, ",")
+			if destinations == "" {
+				destinations = "<none>"
+			} else if more := eviction.FittingNodes - len(eviction.Destinations); more > 0 {
+				destinations += fmt.Sprintf(",+%d", more)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+				summary.Profile,
+				summary.Plugin,
+				eviction.Pod,
+				eviction.Node,
+				eviction.Result,
+				eviction.FittingNodes,
+				destinations,
+			)
+		}
+	}
+	return tw.Flush()
+}
+
+// logCycleSummary logs the summaries of the plugins run during a descheduling cycle in the given format
+func logCycleSummary(format string, summaries []frameworkprofile.PluginSummary) {
+	switch format {
+	case CycleSummaryFormatTable:
+		var buf bytes.Buffer
+		if err := writeCycleSummaryTable(&buf, summaries); err != nil {
+			klog.ErrorS(err, "Unable to render the cycle summary")
+			return
+		}
+		klog.Infof("Descheduling cycle summary:\n%s", buf.String())
+	case CycleSummaryFormatJSON:
+		if summaries == nil {
+			summaries = []frameworkprofile.PluginSummary{}
+		}
+		data, err := json.Marshal(struct {
+			Plugins []frameworkprofile.PluginSummary `json:"plugins"`
+		}{Plugins: summaries})
+		if err != nil {
+			klog.ErrorS(err, "Unable to render the cycle summary")
+			return
+		}
+		klog.Info(string(data))
+	}
+}
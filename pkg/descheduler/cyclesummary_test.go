@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+func TestValidateCycleSummaryFormat(t *testing.T) {
+	for _, format := range []string{"", "none", "table", "json"} {
+		if err := validateCycleSummaryFormat(format); err != nil {
+			t.Errorf("Unexpected error for %q format: %v", format, err)
+		}
+	}
+	if err := validateCycleSummaryFormat("yaml"); err == nil {
+		t.Errorf("Expected an error for an unsupported format")
+	}
+}
+
+func TestWriteCycleSummaryTable(t *testing.T) {
+	summaries := []frameworkprofile.PluginSummary{
+		{
+			Profile:        "default",
+			Plugin:         "RemovePodsViolatingNodeTaints",
+			ExtensionPoint: frameworktypes.DescheduleExtensionPoint,
+			Nodes:          3,
+			Candidates:     10,
+			Evicted:        2,
+			Skipped: map[string]int{
+				frameworkprofile.SkippedByPreEvictionFilter: 1,
+				frameworkprofile.SkippedByFilter:            4,
+			},
+			Duration: metav1.Duration{Duration: 1234567 * time.Microsecond},
+		},
+		{
+			Profile:        "default",
+			Plugin:         "LowNodeUtilization",
+			ExtensionPoint: frameworktypes.BalanceExtensionPoint,
+			Nodes:          3,
+			Duration:       metav1.Duration{Duration: 20 * time.Millisecond},
+			Error:          "boom",
+		},
+	}
+
+	expected := `PROFILE   PLUGIN                          EXTENSION POINT   NODES   CANDIDATES   EVICTED   SKIPPED                        DURATION   ERROR
+default   RemovePodsViolatingNodeTaints   Deschedule        3       10           2         filter=4,preEvictionFilter=1   1.235s     <none>
+default   LowNodeUtilization              Balance           3       0            0         <none>                         20ms       boom
+`
+	var buf bytes.Buffer
+	if err := writeCycleSummaryTable(&buf, summaries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("Unexpected table, expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteCycleSummaryTableWithEvictions(t *testing.T) {
+	summaries := []frameworkprofile.PluginSummary{
+		{
+			Profile:        "default",
+			Plugin:         "RemoveDuplicates",
+			ExtensionPoint: frameworktypes.BalanceExtensionPoint,
+			Nodes:          8,
+			Candidates:     2,
+			Evicted:        1,
+			Skipped:        map[string]int{frameworkprofile.SkippedByNodeLimit: 1},
+			Duration:       metav1.Duration{Duration: 20 * time.Millisecond},
+			Evictions: []frameworkprofile.EvictionSummary{
+				{Pod: "default/p1", Node: "n1", Result: frameworkprofile.EvictionResultEvicted, Destinations: []string{"n2", "n3", "n4", "n5", "n6"}, FittingNodes: 7},
+				{Pod: "default/p2", Node: "n1", Result: frameworkprofile.SkippedByNodeLimit},
+			},
+		},
+	}
+
+	expected := `PROFILE   PLUGIN             EXTENSION POINT   NODES   CANDIDATES   EVICTED   SKIPPED       DURATION   ERROR
+default   RemoveDuplicates   Balance           8       2            1         nodeLimit=1   20ms       <none>
+
+PROFILE   PLUGIN             POD          NODE   RESULT      FITTING NODES   DESTINATIONS
+default   RemoveDuplicates   default/p1   n1     evicted     7               n2,n3,n4,n5,n6,+2
+default   RemoveDuplicates   default/p2   n1     nodeLimit   0               <none>
+`
+	var buf bytes.Buffer
+	if err := writeCycleSummaryTable(&buf, summaries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("Unexpected table, expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteCycleSummaryTableWithDetails(t *testing.T) {
+	summaries := []frameworkprofile.PluginSummary{
+		{
+			Profile:        "default",
+			Plugin:         "CrossNodeCostOptimizer",
+			ExtensionPoint: frameworktypes.BalanceExtensionPoint,
+			Nodes:          2,
+			Candidates:     1,
+			Evicted:        1,
+			Duration:       metav1.Duration{Duration: 20 * time.Millisecond},
+			Details:        map[string]string{"estimatedSavingsPerHour": "0.42", "movedPods": "1"},
+		},
+		{
+			Profile:        "default",
+			Plugin:         "RemoveDuplicates",
+			ExtensionPoint: frameworktypes.BalanceExtensionPoint,
+			Nodes:          2,
+			Duration:       metav1.Duration{Duration: 10 * time.Millisecond},
+		},
+	}
+
+	expected := `PROFILE   PLUGIN                   EXTENSION POINT   NODES   CANDIDATES   EVICTED   SKIPPED   DURATION   ERROR
+default   CrossNodeCostOptimizer   Balance           2       1            1         <none>    20ms       <none>
+default   RemoveDuplicates         Balance           2       0            0         <none>    10ms       <none>
+
+PROFILE   PLUGIN                   EXTENSION POINT   DETAILS
+default   CrossNodeCostOptimizer   Balance           estimatedSavingsPerHour=0.42,movedPods=1
+`
+	var buf bytes.Buffer
+	if err := writeCycleSummaryTable(&buf, summaries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("Unexpected table, expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
@@ -20,14 +20,15 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
-	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/events"
 	componentbaseconfig "k8s.io/component-base/config"
 	"k8s.io/klog/v2"
@@ -42,12 +43,14 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	listersv1 "k8s.io/client-go/listers/core/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1"
 	schedulingv1 "k8s.io/client-go/listers/scheduling/v1"
 	core "k8s.io/client-go/testing"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/client"
 	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/features"
 	"sigs.k8s.io/descheduler/pkg/tracing"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/pkg/version"
@@ -57,30 +60,61 @@ import (
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/logging"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
+	"sigs.k8s.io/descheduler/pkg/framework/statestore"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
 
+const (
+	// defaultNodeDisruptionGuardWindow is how long nodes are considered disrupted when no window is configured
+	defaultNodeDisruptionGuardWindow = 10 * time.Minute
+	// defaultDisruptionBudgetRetryInterval is the delay between two retries of the evictions held back by a budget
+	defaultDisruptionBudgetRetryInterval = 10 * time.Second
+	// defaultDisruptionBudgetRetries is the number of retries of the evictions held back by a budget
+	defaultDisruptionBudgetRetries = 6
+	// defaultMaxEvictionPercentage is the share of the pods of the cluster a cycle may evict before being aborted
+	defaultMaxEvictionPercentage = 10
+	// defaultStateStoreNamespace is the namespace of the ConfigMap the state of the plugins is persisted in
+	defaultStateStoreNamespace = "kube-system"
+	// defaultStateStoreName is the name of the ConfigMap the state of the plugins is persisted in
+	defaultStateStoreName = "descheduler-state"
+)
+
 type eprunner func(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status
 
 type profileRunner struct {
 	name                      string
 	descheduleEPs, balanceEPs eprunner
+	summaries                 func() []frameworkprofile.PluginSummary
 }
 
 type descheduler struct {
 	rs                     *options.DeschedulerServer
 	podLister              listersv1.PodLister
 	nodeLister             listersv1.NodeLister
+	nodeSnapshotter        *nodeutil.NodeSnapshotter
 	namespaceLister        listersv1.NamespaceLister
 	priorityClassLister    schedulingv1.PriorityClassLister
 	getPodsAssignedToNode  podutil.GetPodsAssignedToNodeFunc
 	sharedInformerFactory  informers.SharedInformerFactory
 	deschedulerPolicy      *api.DeschedulerPolicy
-	eventRecorder          events.EventRecorder
+	eventRecorder          *utils.VerbosityRecorder
 	podEvictor             *evictions.PodEvictor
 	podEvictionReactionFnc func(*fakeclientset.Clientset) func(action core.Action) (bool, runtime.Object, error)
+	status                 *Status
+	// stateStore keeps the state of the plugins across the descheduling cycles
+	stateStore frameworktypes.StateStore
+	// pluginGuard keeps the circuit breakers of the plugins across the descheduling cycles
+	pluginGuard *frameworkprofile.PluginGuard
+	// cycleDeadline bounds the plugins of each descheduling cycle, zero for no bound
+	cycleDeadline time.Duration
+	// pdbLister orders the nodes by disruption headroom, nil when the nodes keep their order.
+	// It reads the cluster even in dry run mode, the cached client holds no PodDisruptionBudget.
+	pdbLister policylisters.PodDisruptionBudgetLister
+	// clusterInformerFactory reads the cluster, the sharedInformerFactory reads the cached client in dry run mode
+	clusterInformerFactory informers.SharedInformerFactory
 }
 
 func newDescheduler(rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string, eventRecorder events.EventRecorder, sharedInformerFactory informers.SharedInformerFactory) (*descheduler, error) {
@@ -95,48 +129,199 @@ func newDescheduler(rs *options.DeschedulerServer, deschedulerPolicy *api.Desche
 		return nil, fmt.Errorf("build get pods assigned to node function error: %v", err)
 	}
 
-	podEvictor := evictions.NewPodEvictor(
-		nil,
-		eventRecorder,
-		evictions.NewOptions().
-			WithPolicyGroupVersion(evictionPolicyGroupVersion).
-			WithMaxPodsToEvictPerNode(deschedulerPolicy.MaxNoOfPodsToEvictPerNode).
-			WithMaxPodsToEvictPerNamespace(deschedulerPolicy.MaxNoOfPodsToEvictPerNamespace).
-			WithMaxPodsToEvictTotal(deschedulerPolicy.MaxNoOfPodsToEvictTotal).
-			WithDryRun(rs.DryRun).
-			WithMetricsEnabled(!rs.DisableMetrics),
-	)
+	var pacingPeriod time.Duration
+	if deschedulerPolicy.EvictionPacing != nil {
+		pacingPeriod = rs.DeschedulingInterval
+		if deschedulerPolicy.EvictionPacing.Period != nil {
+			pacingPeriod = deschedulerPolicy.EvictionPacing.Period.Duration
+		}
+		if pacingPeriod == 0 {
+			return nil, fmt.Errorf("eviction pacing requires either a pacing period or a descheduling interval")
+		}
+		if rs.DryRun {
+			// Nothing gets evicted for real, there is no point in holding the dry run back
+			klog.V(1).InfoS("Eviction pacing is disabled in dry run mode")
+			pacingPeriod = 0
+		}
+	}
+
+	evictorOptions := evictions.NewOptions().
+		WithPolicyGroupVersion(evictionPolicyGroupVersion).
+		WithMaxPodsToEvictPerNode(deschedulerPolicy.MaxNoOfPodsToEvictPerNode).
+		WithMaxPodsToEvictPerNamespace(deschedulerPolicy.MaxNoOfPodsToEvictPerNamespace).
+		WithMaxPodsToEvictTotal(deschedulerPolicy.MaxNoOfPodsToEvictTotal).
+		WithDryRun(rs.DryRun).
+		WithMetricsEnabled(!rs.DisableMetrics).
+		WithPacingPeriod(pacingPeriod).
+		WithEvictionTimeout(evictionTimeout(rs, deschedulerPolicy)).
+		WithNamespaceModes(sharedInformerFactory.Core().V1().Namespaces().Informer())
+	if throttledEvictions, initialBackoff, maxBackoff := evictionThrottling(deschedulerPolicy.EvictionThrottling); throttledEvictions > 0 {
+		evictorOptions.WithThrottling(throttledEvictions, initialBackoff, maxBackoff)
+	}
+	if deschedulerPolicy.EvictionBatching != nil {
+		evictorOptions.WithWriteBatching(evictionBatching(deschedulerPolicy.EvictionBatching))
+	}
+	if rs.DryRun && rs.ServerSideDryRun {
+		evictorOptions.WithServerSideDryRun(rs.Client)
+	}
+	if rs.DryRun && rs.DryRunPreviewAnnotations {
+		evictorOptions.WithEvictionPreview(rs.Client, podLister)
+	}
+	if deschedulerPolicy.NodeDisruptionGuard != nil {
+		window := defaultNodeDisruptionGuardWindow
+		if deschedulerPolicy.NodeDisruptionGuard.Window != nil {
+			window = deschedulerPolicy.NodeDisruptionGuard.Window.Duration
+		}
+		evictorOptions.WithNodeDisruptionGuard(nodeLister, getPodsAssignedToNode, window)
+	}
+	if deschedulerPolicy.DisruptionBudgetPacing != nil {
+		retryInterval, retries := disruptionBudgetPacing(deschedulerPolicy.DisruptionBudgetPacing)
+		evictorOptions.WithDisruptionBudgetPacing(sharedInformerFactory.Policy().V1().PodDisruptionBudgets().Lister(), retryInterval, retries)
+	}
+	if deschedulerPolicy.CycleAnomalyGuard != nil {
+		maxEvictionPercentage := float64(defaultMaxEvictionPercentage)
+		if deschedulerPolicy.CycleAnomalyGuard.MaxEvictionPercentage != nil {
+			maxEvictionPercentage = *deschedulerPolicy.CycleAnomalyGuard.MaxEvictionPercentage
+		}
+		evictorOptions.WithCycleAnomalyGuard(maxEvictionPercentage)
+	}
+
+	namespaceEventVerbosities := map[string]utils.EventVerbosity{}
+	for namespace, verbosity := range rs.NamespaceEventVerbosity {
+		namespaceEventVerbosities[namespace] = utils.EventVerbosity(verbosity)
+	}
+	eventVerbosity := utils.EventVerbosity(rs.EventVerbosity)
+	if eventVerbosity == "" {
+		eventVerbosity = utils.EventVerbosityPerEviction
+	}
+	verbosityRecorder := utils.NewVerbosityRecorder(eventRecorder, eventVerbosity, namespaceEventVerbosities)
+
+	podEvictor := evictions.NewPodEvictor(nil, verbosityRecorder, evictorOptions)
+
+	stateStore := statestore.NewMemoryStore()
+	if deschedulerPolicy.StateStore != nil {
+		namespace, name := stateStoreConfigMap(deschedulerPolicy.StateStore)
+		// The state is read from the cluster even in dry run mode, the writes are kept in memory
+		stateStore = statestore.NewConfigMapStore(rs.Client, namespace, name, rs.DryRun)
+	}
+
+	// The trips of the circuit breakers are reported on the lease of the descheduler, regardless of the leader election
+	pluginGuard := frameworkprofile.NewPluginGuard(pluginExecution(deschedulerPolicy.PluginExecution)).
+		WithEvents(eventRecorder, &v1.ObjectReference{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Namespace:  rs.LeaderElection.ResourceNamespace,
+			Name:       rs.LeaderElection.ResourceName,
+		})
+	var cycleDeadline time.Duration
+	if execution := deschedulerPolicy.PluginExecution; execution != nil {
+		if execution.CycleDeadline != nil {
+			cycleDeadline = execution.CycleDeadline.Duration
+		}
+		pluginGuard.WithSkippedPluginsFirst(execution.RunSkippedPluginsFirst)
+	}
+	var pdbLister policylisters.PodDisruptionBudgetLister
+	if disruptionHeadroomOrdering(deschedulerPolicy.EvictionOrdering) {
+		pdbLister = sharedInformerFactory.Policy().V1().PodDisruptionBudgets().Lister()
+	}
 
 	return &descheduler{
 		rs:                     rs,
 		podLister:              podLister,
 		nodeLister:             nodeLister,
+		nodeSnapshotter:        nodeutil.NewNodeSnapshotter(sharedInformerFactory.Core().V1().Nodes().Informer()),
 		namespaceLister:        namespaceLister,
 		priorityClassLister:    priorityClassLister,
 		getPodsAssignedToNode:  getPodsAssignedToNode,
 		sharedInformerFactory:  sharedInformerFactory,
+		clusterInformerFactory: sharedInformerFactory,
 		deschedulerPolicy:      deschedulerPolicy,
-		eventRecorder:          eventRecorder,
+		eventRecorder:          verbosityRecorder,
 		podEvictor:             podEvictor,
 		podEvictionReactionFnc: podEvictionReactionFnc,
+		status:                 DefaultStatus,
+		stateStore:             stateStore,
+		pluginGuard:            pluginGuard,
+		cycleDeadline:          cycleDeadline,
+		pdbLister:              pdbLister,
 	}, nil
 }
 
-func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node) error {
+// stateStoreConfigMap returns the namespace and the name of the ConfigMap the state of
+// the plugins is persisted in, filling in the defaults
+func stateStoreConfigMap(store *api.StateStore) (namespace, name string) {
+	namespace, name = defaultStateStoreNamespace, defaultStateStoreName
+	if store.Namespace != "" {
+		namespace = store.Namespace
+	}
+	if store.Name != "" {
+		name = store.Name
+	}
+	return namespace, name
+}
+
+// disruptionBudgetPacing returns the retry interval and the retries of the evictions
+// held back by a PodDisruptionBudget, filling in the defaults
+func disruptionBudgetPacing(pacing *api.DisruptionBudgetPacing) (retryInterval time.Duration, retries int) {
+	retryInterval, retries = defaultDisruptionBudgetRetryInterval, defaultDisruptionBudgetRetries
+	if pacing.RetryInterval != nil {
+		retryInterval = pacing.RetryInterval.Duration
+	}
+	if pacing.Retries != nil {
+		retries = int(*pacing.Retries)
+	}
+	return
+}
+
+// pluginExecution returns the timeout of the plugins, the timeouts of the listed plugins, the failure
+// threshold and the cooldown cycles of their circuit breakers, filling in the defaults
+func pluginExecution(execution *api.PluginExecution) (timeout time.Duration, pluginTimeouts map[string]time.Duration, failureThreshold, cooldownCycles int) {
+	failureThreshold, cooldownCycles = frameworkprofile.DefaultPluginFailureThreshold, frameworkprofile.DefaultPluginCooldownCycles
+	if execution == nil {
+		return
+	}
+	if execution.Timeout != nil {
+		timeout = execution.Timeout.Duration
+	}
+	if len(execution.PluginTimeouts) > 0 {
+		pluginTimeouts = make(map[string]time.Duration, len(execution.PluginTimeouts))
+		for plugin, pluginTimeout := range execution.PluginTimeouts {
+			pluginTimeouts[plugin] = pluginTimeout.Duration
+		}
+	}
+	if execution.FailureThreshold != nil {
+		failureThreshold = int(*execution.FailureThreshold)
+	}
+	if execution.CooldownCycles != nil {
+		cooldownCycles = int(*execution.CooldownCycles)
+	}
+	return
+}
+
+func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes *nodeutil.NodeSnapshot) (err error) {
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "runDeschedulerLoop")
 	defer span.End()
 	defer func(loopStartDuration time.Time) {
 		metrics.DeschedulerLoopDuration.With(map[string]string{}).Observe(time.Since(loopStartDuration).Seconds())
 	}(time.Now())
+	var summaries []frameworkprofile.PluginSummary
+	defer func(cycleStart time.Time) {
+		var totalEvicted uint
+		if err == nil {
+			totalEvicted = d.podEvictor.TotalEvicted()
+		}
+		d.status.recordCycle(cycleStart, totalEvicted, summaries, err)
+	}(time.Now())
 
 	// if len is still <= 1 error out
-	if len(nodes) <= 1 {
+	if nodes.Len() <= 1 {
 		klog.V(1).InfoS("The cluster size is 0 or 1 meaning eviction causes service disruption or degradation. So aborting..")
 		return fmt.Errorf("the cluster size is 0 or 1")
 	}
 
 	var client clientset.Interface
+	informersStopCh := ctx.Done()
 	// When the dry mode is enable, collect all the relevant objects (mostly pods) under a fake client.
 	// So when evicting pods while running multiple strategies in a row have the cummulative effect
 	// as is when evicting pods for real.
@@ -158,11 +343,16 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 		if err != nil {
 			return fmt.Errorf("build get pods assigned to node function error: %v", err)
 		}
+		// register the node informer as well, plugins list the nodes through the handle
+		fakeSharedInformerFactory.Core().V1().Nodes().Informer()
+		// and the namespace informer, plugins read namespace annotations through the lister
+		fakeSharedInformerFactory.Core().V1().Namespaces().Informer()
 
 		fakeCtx, cncl := context.WithCancel(context.TODO())
 		defer cncl()
 		fakeSharedInformerFactory.Start(fakeCtx.Done())
 		fakeSharedInformerFactory.WaitForCacheSync(fakeCtx.Done())
+		informersStopCh = fakeCtx.Done()
 
 		client = fakeClient
 		d.sharedInformerFactory = fakeSharedInformerFactory
@@ -173,8 +363,41 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 	klog.V(3).Infof("Setting up the pod evictor")
 	d.podEvictor.SetClient(client)
 	d.podEvictor.ResetCounters()
+	if d.deschedulerPolicy.CycleAnomalyGuard != nil {
+		pods, err := d.podLister.List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("unable to list the pods of the cluster: %v", err)
+		}
+		d.podEvictor.SetClusterPods(uint(len(pods)))
+	}
+
+	d.pluginGuard.StartCycle()
+	profilesCtx := ctx
+	if d.cycleDeadline > 0 {
+		// Only the plugins are bounded, the evictions they queued are still requested and their writes flushed
+		var cancel context.CancelFunc
+		profilesCtx, cancel = context.WithTimeoutCause(ctx, d.cycleDeadline, frameworkprofile.ErrCycleDeadlineExceeded)
+		defer cancel()
+	}
+	summaries = d.runProfiles(profilesCtx, client, nodes, informersStopCh, ctx.Done())
+	if skipped, overrun := overrunPlugins(summaries); overrun {
+		metrics.CycleOverruns.Inc()
+		klog.InfoS("Descheduling cycle overran its deadline, the plugins left got skipped", "deadline", d.cycleDeadline, "skippedPlugins", skipped)
+	}
+	// Request the evictions queued while pacing is enabled, spread over the pacing period
+	d.podEvictor.Drain(ctx)
+	// Write the events and the eviction conditions still pending in the batch
+	d.podEvictor.Flush(ctx)
+	// Emit the events summing up the cycle for the namespaces with the per-cycle event verbosity
+	d.eventRecorder.Flush()
+
+	// The guard emitted its event and metric already, only this cycle is aborted and the next one runs as usual
+	if d.podEvictor.CycleAborted() {
+		klog.ErrorS(nil, "Descheduling cycle aborted by the cycle anomaly guard", "totalEvicted", d.podEvictor.TotalEvicted())
+	}
 
-	d.runProfiles(ctx, client, nodes)
+	// Remove the annotations of the pods the dry run no longer evicts, once the cycle went through all of them
+	d.podEvictor.ReconcileEvictionPreview(ctx)
 
 	klog.V(1).InfoS("Number of evicted pods", "totalEvicted", d.podEvictor.TotalEvicted())
 
@@ -184,10 +407,16 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 // runProfiles runs all the deschedule plugins of all profiles and
 // later runs through all balance plugins of all profiles. (All Balance plugins should come after all Deschedule plugins)
 // see https://github.com/kubernetes-sigs/descheduler/issues/979
-func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interface, nodes []*v1.Node) {
+// The summaries of the plugins are returned in the order the plugins ran.
+func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interface, nodeSnapshot *nodeutil.NodeSnapshot, informersStopCh, clusterInformersStopCh <-chan struct{}) []frameworkprofile.PluginSummary {
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "runProfiles")
 	defer span.End()
+	var utilizationProvider frameworktypes.UtilizationProvider
+	if features.DefaultFeatureGate.Enabled(features.MetricsBasedUtilization) {
+		// The metrics are read from the cluster even in dry run mode, the fake client does not serve them
+		utilizationProvider = nodeutil.NewMetricsUtilizationProvider(d.rs.Client, d.getPodsAssignedToNode)
+	}
 	var profileRunners []profileRunner
 	for _, profile := range d.deschedulerPolicy.Profiles {
 		currProfile, err := frameworkprofile.NewProfile(
@@ -195,17 +424,37 @@ func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interfac
 			pluginregistry.PluginRegistry,
 			frameworkprofile.WithClientSet(client),
 			frameworkprofile.WithSharedInformerFactory(d.sharedInformerFactory),
+			frameworkprofile.WithClusterInformerFactory(d.clusterInformerFactory),
 			frameworkprofile.WithPodEvictor(d.podEvictor),
 			frameworkprofile.WithGetPodsAssignedToNodeFnc(d.getPodsAssignedToNode),
+			frameworkprofile.WithEventRecorder(d.eventRecorder),
+			frameworkprofile.WithUtilizationProvider(utilizationProvider),
+			frameworkprofile.WithDestinationPrediction(d.rs.CycleSummaryDestinations),
+			frameworkprofile.WithStateStore(d.stateStore),
+			frameworkprofile.WithPluginGuard(d.pluginGuard),
+			frameworkprofile.WithNodeSnapshot(nodeSnapshot),
 		)
 		if err != nil {
 			klog.ErrorS(err, "unable to create a profile", "profile", profile.Name)
 			continue
 		}
-		profileRunners = append(profileRunners, profileRunner{profile.Name, currProfile.RunDeschedulePlugins, currProfile.RunBalancePlugins})
-	}
-
-	for _, profileR := range profileRunners {
+		profileRunners = append(profileRunners, profileRunner{profile.Name, currProfile.RunDeschedulePlugins, currProfile.RunBalancePlugins, currProfile.Summaries})
+	}
+	// Start the informers the plugins requested while being built, the ones already started are left untouched
+	d.sharedInformerFactory.Start(informersStopCh)
+	d.sharedInformerFactory.WaitForCacheSync(informersStopCh)
+	// The informers reading the cluster outlive the cached client of the dry run mode
+	d.clusterInformerFactory.Start(clusterInformersStopCh)
+	d.clusterInformerFactory.WaitForCacheSync(clusterInformersStopCh)
+
+	nodes := nodeSnapshot.List()
+	if d.pdbLister != nil {
+		nodes = orderByDisruptionHeadroom(nodes, d.getPodsAssignedToNode, d.pdbLister)
+	}
+	for _, profileR := range prioritizedProfiles(d.pluginGuard, profileRunners, frameworktypes.DescheduleExtensionPoint) {
+		if d.podEvictor.CycleAborted() {
+			break
+		}
 		// First deschedule
 		status := profileR.descheduleEPs(ctx, nodes)
 		if status != nil && status.Err != nil {
@@ -215,7 +464,10 @@ func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interfac
 		}
 	}
 
-	for _, profileR := range profileRunners {
+	for _, profileR := range prioritizedProfiles(d.pluginGuard, profileRunners, frameworktypes.BalanceExtensionPoint) {
+		if d.podEvictor.CycleAborted() {
+			break
+		}
 		// Balance Later
 		status := profileR.balanceEPs(ctx, nodes)
 		if status != nil && status.Err != nil {
@@ -224,6 +476,45 @@ func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interfac
 			continue
 		}
 	}
+
+	var summaries []frameworkprofile.PluginSummary
+	for _, profileR := range profileRunners {
+		summaries = append(summaries, profileR.summaries()...)
+	}
+	// List the plugins in the order they ran, deschedule plugins of all profiles first
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].ExtensionPoint == frameworktypes.DescheduleExtensionPoint && summaries[j].ExtensionPoint != frameworktypes.DescheduleExtensionPoint
+	})
+	if d.rs.CycleSummaryFormat != "" && d.rs.CycleSummaryFormat != CycleSummaryFormatNone {
+		logCycleSummary(d.rs.CycleSummaryFormat, summaries)
+	}
+	return summaries
+}
+
+// prioritizedProfiles orders first the profiles with plugins running first at the extension point,
+// having been skipped at the deadline of the previous cycle
+func prioritizedProfiles(pluginGuard *frameworkprofile.PluginGuard, profileRunners []profileRunner, extensionPoint frameworktypes.ExtensionPoint) []profileRunner {
+	ordered := append([]profileRunner{}, profileRunners...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return pluginGuard.Prioritized(ordered[i].name, extensionPoint) && !pluginGuard.Prioritized(ordered[j].name, extensionPoint)
+	})
+	return ordered
+}
+
+// overrunPlugins returns the plugins skipped as the cycle overran its deadline, and whether it did
+func overrunPlugins(summaries []frameworkprofile.PluginSummary) ([]string, bool) {
+	var skipped []string
+	overrun := false
+	for _, summary := range summaries {
+		switch summary.Overrun {
+		case frameworkprofile.OverrunSkipped:
+			skipped = append(skipped, summary.Profile+"/"+summary.Plugin)
+			overrun = true
+		case frameworkprofile.OverrunCancelled:
+			overrun = true
+		}
+	}
+	return skipped, overrun
 }
 
 func Run(ctx context.Context, rs *options.DeschedulerServer) error {
@@ -232,6 +523,21 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 	defer span.End()
 	metrics.Register()
 
+	if err := validateCycleSummaryFormat(rs.CycleSummaryFormat); err != nil {
+		return err
+	}
+
+	if err := utils.ValidateEventVerbosity(utils.EventVerbosity(rs.EventVerbosity)); err != nil {
+		return err
+	}
+	for _, verbosity := range rs.NamespaceEventVerbosity {
+		if err := utils.ValidateEventVerbosity(utils.EventVerbosity(verbosity)); err != nil {
+			return err
+		}
+	}
+
+	utils.SetInPlacePodVerticalScaling(rs.InPlacePodVerticalScaling)
+
 	clientConnection := rs.ClientConnection
 	if rs.KubeconfigFile != "" && clientConnection.Kubeconfig == "" {
 		clientConnection.Kubeconfig = rs.KubeconfigFile
@@ -243,7 +549,7 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 	rs.Client = rsclient
 	rs.EventClient = eventClient
 
-	deschedulerPolicy, err := LoadPolicyConfig(rs.PolicyConfigFile, rs.Client, pluginregistry.PluginRegistry)
+	deschedulerPolicy, err := LoadPolicyConfig(rs.PolicyConfigFile, rs.PolicyStrict, rs.Client, pluginregistry.PluginRegistry)
 	if err != nil {
 		return err
 	}
@@ -251,6 +557,40 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 		return fmt.Errorf("deschedulerPolicy is nil")
 	}
 
+	// The --feature-gates flag takes precedence over the featureGates of the policy
+	if err := features.DefaultMutableFeatureGate.SetFromMap(deschedulerPolicy.FeatureGates); err != nil {
+		return err
+	}
+	if err := features.DefaultMutableFeatureGate.SetFromMap(rs.FeatureGates); err != nil {
+		return err
+	}
+	logging.DefaultLevels.SetPolicyLevels(deschedulerPolicy.PluginLogVerbosity)
+	if !rs.DisableMetrics {
+		labelsByProfile := make(map[string]map[string]string, len(deschedulerPolicy.Profiles))
+		for _, profile := range deschedulerPolicy.Profiles {
+			labelsByProfile[profile.Name] = profile.Labels
+		}
+		metrics.SetProfileLabels(labelsByProfile)
+	}
+
+	if rs.InformerResyncPeriod < 0 {
+		return fmt.Errorf("informer resync period can not be negative")
+	}
+	if rs.EvictionTimeout < 0 {
+		return fmt.Errorf("eviction timeout can not be negative")
+	}
+
+	// The QPS and burst of the policy apply unless set through the flags
+	if applyPolicyClientConnection(&clientConnection, deschedulerPolicy.ClientConnection) {
+		klog.V(1).InfoS("Using the client connection of the policy", "qps", clientConnection.QPS, "burst", clientConnection.Burst)
+		rsclient, eventClient, err = createClients(clientConnection)
+		if err != nil {
+			return err
+		}
+		rs.Client = rsclient
+		rs.EventClient = eventClient
+	}
+
 	// Add k8s compatibility warnings to logs
 	if err := validateVersionCompatibility(rs.Client.Discovery(), version.Get()); err != nil {
 		klog.Warning(err.Error())
@@ -270,11 +610,22 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 		return fmt.Errorf("leaderElection must be used with deschedulingInterval")
 	}
 
+	if rs.ServerSideDryRun && !rs.DryRun {
+		span.AddEvent("Validation Failure", trace.WithAttributes(attribute.String("err", "server-side dry run must be used with dry run")))
+		return fmt.Errorf("server-side dry run must be used with dry run")
+	}
+
+	if rs.DryRunPreviewAnnotations && !rs.DryRun {
+		span.AddEvent("Validation Failure", trace.WithAttributes(attribute.String("err", "dry run preview annotations must be used with dry run")))
+		return fmt.Errorf("dry run preview annotations must be used with dry run")
+	}
+
 	if rs.LeaderElection.LeaderElect && rs.DryRun {
 		klog.V(1).Info("Warning: DryRun is set to True. You need to disable it to use Leader Election.")
 	}
 
 	if rs.LeaderElection.LeaderElect && !rs.DryRun {
+		DefaultStatus.enableLeaderElection()
 		if err := NewLeaderElection(runFn, rsclient, &rs.LeaderElection, ctx); err != nil {
 			span.AddEvent("Leader Election Failure", trace.WithAttributes(attribute.String("err", err.Error())))
 			return fmt.Errorf("leaderElection: %w", err)
@@ -397,11 +748,30 @@ func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer
 	ctx, span = tracing.Tracer().Start(ctx, "RunDeschedulerStrategies")
 	defer span.End()
 
-	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(rs.Client, 0, informers.WithTransform(trimManagedFields))
+	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(rs.Client, informerResyncPeriod(rs, deschedulerPolicy), informers.WithTransform(podCacheTransform(deschedulerPolicy)))
+	var podLabelSelector, podFieldSelector string
+	if deschedulerPolicy.PodCache != nil {
+		podLabelSelector = deschedulerPolicy.PodCache.LabelSelector
+		podFieldSelector = deschedulerPolicy.PodCache.FieldSelector
+	}
+	if len(deschedulerPolicy.WatchedNamespaces) > 0 || podLabelSelector != "" || podFieldSelector != "" {
+		// Registered before anything else asks for the pod informer so every consumer shares the filtered one
+		sharedInformerFactory.InformerFor(&v1.Pod{}, podutil.NewFilteredPodInformer(deschedulerPolicy.WatchedNamespaces, podLabelSelector, podFieldSelector))
+	}
 
-	var nodeSelector string
+	nodeListOptions := nodeutil.ListOptions{}
 	if deschedulerPolicy.NodeSelector != nil {
-		nodeSelector = *deschedulerPolicy.NodeSelector
+		nodeListOptions.LabelSelector = *deschedulerPolicy.NodeSelector
+	}
+	if deschedulerPolicy.NodeFieldSelector != nil {
+		nodeListOptions.FieldSelector = *deschedulerPolicy.NodeFieldSelector
+	}
+	if deschedulerPolicy.NodeListPageSize != nil {
+		nodeListOptions.PageSize = *deschedulerPolicy.NodeListPageSize
+	}
+	if !nodeListOptions.IsEmpty() {
+		// Registered before anything else asks for the node informer so every consumer shares the filtered one
+		sharedInformerFactory.InformerFor(&v1.Node{}, nodeutil.NewNodeInformer(nodeListOptions))
 	}
 
 	var eventClient clientset.Interface
@@ -423,14 +793,17 @@ func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer
 
 	sharedInformerFactory.Start(ctx.Done())
 	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+	descheduler.status.setInformersSynced()
 
 	wait.NonSlidingUntil(func() {
 		// A next context is created here intentionally to avoid nesting the spans via context.
 		sCtx, sSpan := tracing.Tracer().Start(ctx, "NonSlidingUntil")
 		defer sSpan.End()
-		nodes, err := nodeutil.ReadyNodes(sCtx, rs.Client, descheduler.nodeLister, nodeSelector)
+		cycleStart := time.Now()
+		nodes, err := nodeutil.ReadyNodesSnapshot(sCtx, rs.Client, descheduler.nodeSnapshotter, nodeListOptions)
 		if err != nil {
 			sSpan.AddEvent("Failed to detect ready nodes", trace.WithAttributes(attribute.String("err", err.Error())))
+			descheduler.status.recordCycle(cycleStart, 0, nil, err)
 			klog.Error(err)
 			cancel()
 			return
@@ -474,9 +847,20 @@ func createClients(clientConnection componentbaseconfig.ClientConnectionConfigur
 	return kClient, eventClient, nil
 }
 
-func trimManagedFields(obj interface{}) (interface{}, error) {
-	if accessor, err := meta.Accessor(obj); err == nil {
-		accessor.SetManagedFields(nil)
+// podCacheTransform builds the transform shrinking the objects kept in the informer cache.
+// The pod fields read by the configured plugins are kept whatever the pod cache configuration.
+func podCacheTransform(deschedulerPolicy *api.DeschedulerPolicy) cache.TransformFunc {
+	options := podutil.CacheTransformOptions{}
+	if deschedulerPolicy.PodCache != nil {
+		options.MaxAnnotationSize = deschedulerPolicy.PodCache.MaxAnnotationSize
+		options.StripContainerEnv = deschedulerPolicy.PodCache.StripContainerEnv
+	}
+	for _, profile := range deschedulerPolicy.Profiles {
+		for _, pluginConfig := range profile.PluginConfigs {
+			if requirer, ok := pluginConfig.Args.(frameworktypes.PodFieldsRequirer); ok {
+				options.Required = append(options.Required, requirer.RequiredPodFields())
+			}
+		}
 	}
-	return obj, nil
+	return podutil.NewCacheTransform(options)
 }
@@ -19,10 +19,12 @@ import (
 	utilptr "k8s.io/utils/ptr"
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/pkg/api"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	deschedulerversion "sigs.k8s.io/descheduler/pkg/version"
 	"sigs.k8s.io/descheduler/test"
@@ -110,7 +112,7 @@ func initDescheduler(t *testing.T, ctx context.Context, internalDeschedulerPolic
 	rs.Client = client
 	rs.EventClient = eventClient
 
-	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(rs.Client, 0, informers.WithTransform(trimManagedFields))
+	sharedInformerFactory := informers.NewSharedInformerFactoryWithOptions(rs.Client, 0, informers.WithTransform(podCacheTransform(internalDeschedulerPolicy)))
 	eventBroadcaster, eventRecorder := utils.GetRecorderAndBroadcaster(ctx, client)
 
 	descheduler, err := newDescheduler(rs, internalDeschedulerPolicy, "v1", eventRecorder, sharedInformerFactory)
@@ -415,7 +417,7 @@ func TestPodEvictorReset(t *testing.T) {
 
 	// a single pod eviction expected
 	klog.Infof("2 pod eviction expected per a descheduling cycle, 2 real evictions in total")
-	if err := descheduler.runDeschedulerLoop(ctx, nodes); err != nil {
+	if err := descheduler.runDeschedulerLoop(ctx, nodeutil.NewNodeSnapshot(0, nodes)); err != nil {
 		t.Fatalf("Unable to run a descheduling loop: %v", err)
 	}
 	if descheduler.podEvictor.TotalEvicted() != 2 || len(evictedPods) != 2 || len(fakeEvictedPods) != 0 {
@@ -424,7 +426,7 @@ func TestPodEvictorReset(t *testing.T) {
 
 	// a single pod eviction expected
 	klog.Infof("2 pod eviction expected per a descheduling cycle, 4 real evictions in total")
-	if err := descheduler.runDeschedulerLoop(ctx, nodes); err != nil {
+	if err := descheduler.runDeschedulerLoop(ctx, nodeutil.NewNodeSnapshot(0, nodes)); err != nil {
 		t.Fatalf("Unable to run a descheduling loop: %v", err)
 	}
 	if descheduler.podEvictor.TotalEvicted() != 2 || len(evictedPods) != 4 || len(fakeEvictedPods) != 0 {
@@ -437,7 +439,7 @@ func TestPodEvictorReset(t *testing.T) {
 	evictedPods = []string{}
 
 	klog.Infof("2 pod eviction expected per a descheduling cycle, 2 fake evictions in total")
-	if err := descheduler.runDeschedulerLoop(ctx, nodes); err != nil {
+	if err := descheduler.runDeschedulerLoop(ctx, nodeutil.NewNodeSnapshot(0, nodes)); err != nil {
 		t.Fatalf("Unable to run a descheduling loop: %v", err)
 	}
 	if descheduler.podEvictor.TotalEvicted() != 2 || len(evictedPods) != 0 || len(fakeEvictedPods) != 2 {
@@ -445,7 +447,7 @@ func TestPodEvictorReset(t *testing.T) {
 	}
 
 	klog.Infof("2 pod eviction expected per a descheduling cycle, 4 fake evictions in total")
-	if err := descheduler.runDeschedulerLoop(ctx, nodes); err != nil {
+	if err := descheduler.runDeschedulerLoop(ctx, nodeutil.NewNodeSnapshot(0, nodes)); err != nil {
 		t.Fatalf("Unable to run a descheduling loop: %v", err)
 	}
 	if descheduler.podEvictor.TotalEvicted() != 2 || len(evictedPods) != 0 || len(fakeEvictedPods) != 4 {
@@ -527,7 +529,7 @@ func TestDeschedulingLimits(t *testing.T) {
 			}
 			time.Sleep(100 * time.Millisecond)
 
-			err := descheduler.runDeschedulerLoop(ctx, nodes)
+			err := descheduler.runDeschedulerLoop(ctx, nodeutil.NewNodeSnapshot(0, nodes))
 			if err != nil {
 				t.Fatalf("Unable to run a descheduling loop: %v", err)
 			}
@@ -539,3 +541,59 @@ func TestDeschedulingLimits(t *testing.T) {
 		})
 	}
 }
+
+func TestCycleAnomalyGuard(t *testing.T) {
+	initPluginRegistry()
+
+	ctx := context.Background()
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, taintNodeNoSchedule)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{node1, node2}
+
+	ownerRef1 := test.GetReplicaSetOwnerRefList()
+	updatePod := func(pod *v1.Pod) {
+		pod.Namespace = "dev"
+		pod.ObjectMeta.OwnerReferences = ownerRef1
+	}
+	p1 := test.BuildTestPod("p1", 100, 0, node1.Name, updatePod)
+	p2 := test.BuildTestPod("p2", 100, 0, node1.Name, updatePod)
+	p3 := test.BuildTestPod("p3", 100, 0, node1.Name, updatePod)
+
+	internalDeschedulerPolicy := removePodsViolatingNodeTaintsPolicy()
+	internalDeschedulerPolicy.CycleAnomalyGuard = &api.CycleAnomalyGuard{MaxEvictionPercentage: utilptr.To[float64](10)}
+	ctxCancel, cancel := context.WithCancel(ctx)
+	_, descheduler, client := initDescheduler(t, ctxCancel, internalDeschedulerPolicy, node1, node2, p1, p2, p3)
+	defer cancel()
+
+	var evictedPods []string
+	client.PrependReactor("create", "pods", podEvictionReactionTestingFnc(&evictedPods))
+
+	// Only the current cycle is aborted, the descheduler keeps running the next ones
+	for cycle := 1; cycle <= 2; cycle++ {
+		if err := descheduler.runDeschedulerLoop(ctx, nodeutil.NewNodeSnapshot(0, nodes)); err != nil {
+			t.Fatalf("Expected the aborted cycle %d not to fail, got: %v", cycle, err)
+		}
+		if !descheduler.podEvictor.CycleAborted() {
+			t.Errorf("Expected the cycle %d to be aborted", cycle)
+		}
+		if len(evictedPods) != cycle {
+			t.Errorf("Expected %d pods evicted after the cycle %d, got %d", cycle, cycle, len(evictedPods))
+		}
+	}
+}
+
+func TestPluginExecution(t *testing.T) {
+	timeout, pluginTimeouts, failureThreshold, cooldownCycles := pluginExecution(nil)
+	if timeout != 0 || pluginTimeouts != nil || failureThreshold != frameworkprofile.DefaultPluginFailureThreshold || cooldownCycles != frameworkprofile.DefaultPluginCooldownCycles {
+		t.Errorf("Expected the default plugin execution, got %v %v %v %v", timeout, pluginTimeouts, failureThreshold, cooldownCycles)
+	}
+
+	timeout, pluginTimeouts, failureThreshold, cooldownCycles = pluginExecution(&api.PluginExecution{
+		Timeout:        &metav1.Duration{Duration: time.Minute},
+		PluginTimeouts: map[string]metav1.Duration{"RemoveFailedPods": {Duration: time.Second}},
+		CooldownCycles: utilptr.To[int32](2),
+	})
+	if timeout != time.Minute || pluginTimeouts["RemoveFailedPods"] != time.Second || failureThreshold != frameworkprofile.DefaultPluginFailureThreshold || cooldownCycles != 2 {
+		t.Errorf("Unexpected plugin execution %v %v %v %v", timeout, pluginTimeouts, failureThreshold, cooldownCycles)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	policylisters "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// disruptionHeadroomOrdering tells whether the nodes are handed to the plugins by disruption headroom
+func disruptionHeadroomOrdering(ordering *api.EvictionOrdering) bool {
+	return ordering != nil && ordering.DisruptionHeadroom
+}
+
+// orderByDisruptionHeadroom returns the nodes by decreasing disruption headroom, so the plugins going through
+// the nodes in turn spend the eviction limits on the least disruptive evictions. The ready nodes come first,
+// then the nodes with the largest share of pods able to lose a replica of their workload: controlled by a
+// workload with other replicas on the nodes, and whose PodDisruptionBudgets allow a disruption. The DaemonSet
+// and the mirror pods are left out, and the nodes of the same headroom keep their order.
+func orderByDisruptionHeadroom(nodes []*v1.Node, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, pdbLister policylisters.PodDisruptionBudgetLister) []*v1.Node {
+	podsOnNodes := make(map[string][]*v1.Pod, len(nodes))
+	var pods []*v1.Pod
+	for _, node := range nodes {
+		nodePods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, func(pod *v1.Pod) bool {
+			return !utils.IsDaemonsetPod(pod.OwnerReferences) && !utils.IsMirrorPod(pod)
+		})
+		if err != nil {
+			klog.ErrorS(err, "Unable to order the nodes by disruption headroom, keeping their order")
+			return nodes
+		}
+		podsOnNodes[node.Name] = nodePods
+		pods = append(pods, nodePods...)
+	}
+
+	replicas := map[types.UID]int{}
+	for _, ownedPods := range podutil.GroupByOwnerRef(pods) {
+		for _, pod := range ownedPods {
+			replicas[pod.UID] = len(ownedPods)
+		}
+	}
+	budgets := &namespaceBudgets{lister: pdbLister, pdbs: map[string][]*policy.PodDisruptionBudget{}}
+
+	shares := make(map[string]float64, len(nodes))
+	for _, node := range nodes {
+		nodePods := podsOnNodes[node.Name]
+		if len(nodePods) == 0 {
+			shares[node.Name] = 1
+			continue
+		}
+		able := 0
+		for _, pod := range nodePods {
+			if replicas[pod.UID] > 1 && budgets.allowDisruption(pod) {
+				able++
+			}
+		}
+		shares[node.Name] = float64(able) / float64(len(nodePods))
+	}
+
+	ordered := append([]*v1.Node{}, nodes...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if readyI, readyJ := nodeutil.IsReady(ordered[i]), nodeutil.IsReady(ordered[j]); readyI != readyJ {
+			return readyI
+		}
+		return shares[ordered[i].Name] > shares[ordered[j].Name]
+	})
+	return ordered
+}
+
+// namespaceBudgets caches the PodDisruptionBudgets of the namespaces looked up so far
+type namespaceBudgets struct {
+	lister policylisters.PodDisruptionBudgetLister
+	pdbs   map[string][]*policy.PodDisruptionBudget
+}
+
+// allowDisruption tells whether all the PodDisruptionBudgets selecting the pod allow a disruption
+func (b *namespaceBudgets) allowDisruption(pod *v1.Pod) bool {
+	pdbs, ok := b.pdbs[pod.Namespace]
+	if !ok {
+		var err error
+		pdbs, err = b.lister.PodDisruptionBudgets(pod.Namespace).List(labels.Everything())
+		if err != nil {
+			klog.ErrorS(err, "Unable to list the pod disruption budgets", "namespace", pod.Namespace)
+		}
+		b.pdbs[pod.Namespace] = pdbs
+	}
+	for _, pdb := range utils.PodDisruptionBudgetsSelecting(pod, pdbs) {
+		if pdb.Status.DisruptionsAllowed < 1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestOrderByDisruptionHeadroom(t *testing.T) {
+	buildNode := func(name string, ready bool) *v1.Node {
+		return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+			if !ready {
+				node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionFalse}}
+			}
+		})
+	}
+	buildPod := func(name, nodeName, owner string, labels map[string]string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			pod.Labels = labels
+			if owner != "" {
+				pod.OwnerReferences = []metav1.OwnerReference{
+					{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: owner, UID: types.UID(owner), Controller: &[]bool{true}[0]},
+				}
+			}
+		})
+	}
+	buildPDB := func(app string, disruptionsAllowed int32) *policy.PodDisruptionBudget {
+		return &policy.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: app, Namespace: "default"},
+			Spec: policy.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			},
+			Status: policy.PodDisruptionBudgetStatus{DisruptionsAllowed: disruptionsAllowed},
+		}
+	}
+
+	tests := []struct {
+		description string
+		nodes       []*v1.Node
+		pods        []*v1.Pod
+		pdbs        []*policy.PodDisruptionBudget
+		expected    []string
+	}{
+		{
+			description: "Nodes of the same headroom keep their order",
+			nodes:       []*v1.Node{buildNode("n1", true), buildNode("n2", true), buildNode("n3", true)},
+			expected:    []string{"n1", "n2", "n3"},
+		},
+		{
+			description: "Not ready nodes come last",
+			nodes:       []*v1.Node{buildNode("n1", false), buildNode("n2", true)},
+			expected:    []string{"n2", "n1"},
+		},
+		{
+			description: "Nodes running the replicated workloads come first",
+			nodes:       []*v1.Node{buildNode("n1", true), buildNode("n2", true), buildNode("n3", true)},
+			pods: []*v1.Pod{
+				buildPod("single", "n1", "rs-single", nil),
+				buildPod("bare", "n2", "", nil),
+				buildPod("web-1", "n2", "rs-web", nil),
+				buildPod("web-2", "n3", "rs-web", nil),
+			},
+			expected: []string{"n3", "n2", "n1"},
+		},
+		{
+			description: "Nodes running the pods of exhausted disruption budgets come last",
+			nodes:       []*v1.Node{buildNode("n1", true), buildNode("n2", true)},
+			pods: []*v1.Pod{
+				buildPod("db-1", "n1", "rs-db", map[string]string{"app": "db"}),
+				buildPod("db-2", "n1", "rs-db", map[string]string{"app": "db"}),
+				buildPod("web-1", "n2", "rs-web", map[string]string{"app": "web"}),
+				buildPod("web-2", "n2", "rs-web", map[string]string{"app": "web"}),
+			},
+			pdbs:     []*policy.PodDisruptionBudget{buildPDB("db", 0), buildPDB("web", 1)},
+			expected: []string{"n2", "n1"},
+		},
+		{
+			description: "DaemonSet pods are left out",
+			nodes:       []*v1.Node{buildNode("n1", true), buildNode("n2", true)},
+			pods: []*v1.Pod{
+				buildPod("single", "n1", "rs-single", nil),
+				buildPod("web-1", "n1", "rs-web", nil),
+				test.BuildTestPod("ds-1", 100, 0, "n2", test.SetDSOwnerRef),
+				buildPod("web-2", "n2", "rs-web", nil),
+			},
+			expected: []string{"n2", "n1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			for _, pdb := range tc.pdbs {
+				objs = append(objs, pdb)
+			}
+			client := fake.NewSimpleClientset(objs...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(sharedInformerFactory.Core().V1().Pods().Informer())
+			if err != nil {
+				t.Fatalf("Build get pods assigned to node function error: %v", err)
+			}
+			pdbLister := sharedInformerFactory.Policy().V1().PodDisruptionBudgets().Lister()
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			var names []string
+			for _, node := range orderByDisruptionHeadroom(tc.nodes, getPodsAssignedToNode, pdbLister) {
+				names = append(names, node.Name)
+			}
+			if len(names) != len(tc.expected) {
+				t.Fatalf("Expected nodes %v, got %v", tc.expected, names)
+			}
+			for i := range names {
+				if names[i] != tc.expected[i] {
+					t.Fatalf("Expected nodes %v, got %v", tc.expected, names)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/metrics"
+)
+
+// CycleAbortedReason is the reason of the event reported on the pod whose eviction aborted the cycle
+const CycleAbortedReason = "DeschedulingCycleAborted"
+
+// cycleAnomalyGuard aborts a descheduling cycle once it evicted a given share of the pods of the
+// cluster, which is more likely caused by a policy mistake or a pathological state of the cluster
+// than by pods actually needing to be moved.
+type cycleAnomalyGuard struct {
+	maxEvictionPercentage float64
+	// clusterPods is the number of pods in the cluster at the start of the cycle
+	clusterPods uint
+	aborted     bool
+}
+
+func newCycleAnomalyGuard(maxEvictionPercentage float64) *cycleAnomalyGuard {
+	return &cycleAnomalyGuard{maxEvictionPercentage: maxEvictionPercentage}
+}
+
+// maxEvictions returns how many pods the cycle may evict, at least one
+func (g *cycleAnomalyGuard) maxEvictions() uint {
+	if maxEvictions := uint(float64(g.clusterPods) * g.maxEvictionPercentage / 100); maxEvictions > 1 {
+		return maxEvictions
+	}
+	return 1
+}
+
+// SetClusterPods sets the number of pods in the cluster the evictions of the cycle are compared to
+func (pe *PodEvictor) SetClusterPods(clusterPods uint) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	if pe.cycleAnomalyGuard != nil {
+		pe.cycleAnomalyGuard.clusterPods = clusterPods
+	}
+}
+
+// CycleAborted tells whether the cycle anomaly guard aborted the cycle since the counters were last reset
+func (pe *PodEvictor) CycleAborted() bool {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	return pe.cycleAnomalyGuard != nil && pe.cycleAnomalyGuard.aborted
+}
+
+// abortCycle aborts the cycle, dropping the evictions still queued or held back so none of them
+// gets requested. The evictions requested already can not be undone. The caller is expected to
+// hold pe.mu.
+func (pe *PodEvictor) abortCycle(ctx context.Context, pod *v1.Pod, opts EvictOptions) {
+	logger := klog.FromContext(ctx)
+	guard := pe.cycleAnomalyGuard
+	guard.aborted = true
+
+	dropped := 0
+	for _, queued := range pe.queue {
+		pe.decrementCounters(queued.pod)
+		pe.settleEvictionCondition(ctx, queued.pod, false)
+		dropped++
+	}
+	pe.queue = nil
+	if pe.disruptionBudgetPacer != nil {
+		for _, held := range pe.disruptionBudgetPacer.held {
+			pe.decrementCounters(held.pod)
+			pe.settleEvictionCondition(ctx, held.pod, false)
+			dropped++
+		}
+		pe.disruptionBudgetPacer.held = nil
+	}
+
+	logger.Error(nil, "Aborting the descheduling cycle, too many pods selected for eviction",
+		"maxEvictionPercentage", guard.maxEvictionPercentage, "clusterPods", guard.clusterPods, "evicted", pe.totalPodCount,
+		"droppedEvictions", dropped, "strategy", opts.StrategyName, "profile", opts.ProfileName)
+	if !pe.dryRun {
+		pe.eventRecorder.Eventf(pod, nil, v1.EventTypeWarning, CycleAbortedReason, "Descheduled",
+			"descheduling cycle aborted by sigs.k8s.io/descheduler: evicting the pod would exceed %v%% of the %d pods of the cluster",
+			guard.maxEvictionPercentage, guard.clusterPods)
+	}
+	if pe.metricsEnabled {
+		metrics.CyclesAborted.Inc()
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestCycleAnomalyGuard(t *testing.T) {
+	var pods []*v1.Pod
+	for i := 0; i < 5; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("p%d", i), 100, 0, "n1", nil))
+	}
+
+	tests := []struct {
+		description     string
+		clusterPods     uint
+		pacingPeriod    time.Duration
+		expectedEvicted []string
+		expectedAborted bool
+	}{
+		{
+			description:     "evictions below the ceiling",
+			clusterPods:     100,
+			expectedEvicted: []string{"p0", "p1", "p2", "p3", "p4"},
+		},
+		{
+			description:     "evictions above the ceiling abort the cycle",
+			clusterPods:     30,
+			expectedEvicted: []string{"p0", "p1", "p2"},
+			expectedAborted: true,
+		},
+		{
+			description:     "at least one pod may be evicted",
+			clusterPods:     2,
+			expectedEvicted: []string{"p0"},
+			expectedAborted: true,
+		},
+		{
+			description:     "queued evictions are dropped",
+			clusterPods:     30,
+			pacingPeriod:    time.Millisecond,
+			expectedEvicted: nil,
+			expectedAborted: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var evicted []string
+			client := fake.NewSimpleClientset()
+			client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				evicted = append(evicted, action.(core.CreateAction).GetObject().(*policy.Eviction).Name)
+				return true, nil, nil
+			})
+			recorder := events.NewFakeRecorder(10)
+			podEvictor := NewPodEvictor(client, recorder, NewOptions().WithCycleAnomalyGuard(10).WithPacingPeriod(tc.pacingPeriod))
+			podEvictor.SetClusterPods(tc.clusterPods)
+
+			for _, pod := range pods {
+				err := podEvictor.EvictPod(context.TODO(), pod, EvictOptions{})
+				if _, ok := err.(*EvictionTotalLimitError); err != nil && !ok {
+					t.Fatalf("Unexpected error evicting %v: %v", pod.Name, err)
+				}
+			}
+			podEvictor.Drain(context.TODO())
+
+			if diff := cmp.Diff(tc.expectedEvicted, evicted); diff != "" {
+				t.Errorf("Unexpected evictions (-want,+got):\n%s", diff)
+			}
+			if aborted := podEvictor.CycleAborted(); aborted != tc.expectedAborted {
+				t.Errorf("Expected the cycle to be aborted: %v, got %v", tc.expectedAborted, aborted)
+			}
+			if tc.expectedAborted {
+				if total := podEvictor.TotalEvicted(); total != uint(len(tc.expectedEvicted)) {
+					t.Errorf("Expected %d total evictions, got %d", len(tc.expectedEvicted), total)
+				}
+				aborted := false
+				for len(recorder.Events) > 0 {
+					if event := <-recorder.Events; strings.HasPrefix(event, "Warning DeschedulingCycleAborted descheduling cycle aborted") {
+						aborted = true
+					}
+				}
+				if !aborted {
+					t.Errorf("Expected an event reporting the aborted cycle")
+				}
+			}
+
+			// The next cycle starts over
+			podEvictor.ResetCounters()
+			if podEvictor.CycleAborted() {
+				t.Errorf("Expected the next cycle not to be aborted")
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/metrics"
+)
+
+// EvictionConditionType is the condition set on the pods whose eviction is paced or held back
+// while the writes are batched, so their owners can tell the pods are about to be evicted
+const EvictionConditionType v1.PodConditionType = "descheduler.io/EvictionRequested"
+
+const (
+	// EvictionPacedReason is the reason of the condition of the pods whose eviction is paced
+	EvictionPacedReason = "EvictionPaced"
+	// EvictionHeldBackReason is the reason of the condition of the pods whose eviction is held back by a PodDisruptionBudget
+	EvictionHeldBackReason = "EvictionHeldBack"
+	// EvictionAbandonedReason is the reason of the condition of the pods whose eviction was given up
+	EvictionAbandonedReason = "EvictionAbandoned"
+
+	// fieldManager owns the conditions the descheduler applies
+	fieldManager = "descheduler"
+)
+
+// pendingCondition is an eviction condition waiting to be applied on a pod
+type pendingCondition struct {
+	pod     *v1.Pod
+	status  v1.ConditionStatus
+	reason  string
+	message string
+	time    metav1.Time
+}
+
+// pendingEvent is an event waiting to be recorded on a pod
+type pendingEvent struct {
+	pod       *v1.Pod
+	eventtype string
+	reason    string
+	note      string
+}
+
+// writeBatcher coalesces the writes accompanying the evictions and flushes them in batches: a
+// later condition of a pod replaces its pending one, and an event identical to a pending one is
+// dropped. The batch is flushed once it holds batchSize writes, or its oldest write has been
+// pending for flushInterval, and at the end of the cycle.
+type writeBatcher struct {
+	batchSize     int
+	flushInterval time.Duration
+
+	conditions map[string]pendingCondition
+	events     []pendingEvent
+	eventKeys  sets.Set[string]
+	// applied are the pods whose condition was applied as true, so giving up on their
+	// eviction has to reset it
+	applied sets.Set[string]
+	// oldest is when the oldest pending write was added
+	oldest time.Time
+}
+
+func newWriteBatcher(batchSize int, flushInterval time.Duration) *writeBatcher {
+	return &writeBatcher{
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		conditions:    map[string]pendingCondition{},
+		eventKeys:     sets.New[string](),
+		applied:       sets.New[string](),
+	}
+}
+
+func (b *writeBatcher) pending() int {
+	return len(b.conditions) + len(b.events)
+}
+
+// due tells whether the batch has to be flushed
+func (b *writeBatcher) due() bool {
+	return b.pending() >= b.batchSize || (b.pending() > 0 && time.Since(b.oldest) >= b.flushInterval)
+}
+
+func (b *writeBatcher) added() {
+	if b.pending() == 1 {
+		b.oldest = time.Now()
+	}
+}
+
+// recordEvent records an event on the pod, through the batch when the writes are batched.
+// The caller is expected to hold pe.mu.
+func (pe *PodEvictor) recordEvent(ctx context.Context, pod *v1.Pod, eventtype, reason, note string) {
+	b := pe.writeBatcher
+	if b == nil {
+		pe.eventRecorder.Eventf(pod, nil, eventtype, reason, "Descheduled", "%s", note)
+		pe.countAPICall("events", "create")
+		return
+	}
+	key := fmt.Sprintf("%s/%s/%s/%s/%s", pod.Namespace, pod.Name, eventtype, reason, note)
+	if b.eventKeys.Has(key) {
+		pe.countAPICallCoalesced("events")
+		return
+	}
+	b.eventKeys.Insert(key)
+	b.events = append(b.events, pendingEvent{pod: pod, eventtype: eventtype, reason: reason, note: note})
+	b.added()
+	if b.due() {
+		pe.flushWrites(ctx)
+	}
+}
+
+// requestEvictionCondition sets the eviction condition on a pod whose eviction is paced or held
+// back, when the writes are batched. The caller is expected to hold pe.mu.
+func (pe *PodEvictor) requestEvictionCondition(ctx context.Context, pod *v1.Pod, opts EvictOptions, reason string) {
+	if pe.writeBatcher == nil || pe.dryRun {
+		return
+	}
+	strategy := opts.StrategyName
+	if len(strategy) == 0 {
+		strategy = "NotSet"
+	}
+	message := fmt.Sprintf("pod eviction requested by the %s strategy of sigs.k8s.io/descheduler%s", strategy, profileAttribution(opts))
+	if len(opts.Reason) > 0 {
+		message += ": " + opts.Reason
+	}
+	pe.setEvictionCondition(ctx, pod, v1.ConditionTrue, reason, message)
+}
+
+// settleEvictionCondition forgets the eviction condition of a pod once it got evicted, or resets
+// it once its eviction was given up. The caller is expected to hold pe.mu.
+func (pe *PodEvictor) settleEvictionCondition(ctx context.Context, pod *v1.Pod, evicted bool) {
+	b := pe.writeBatcher
+	if b == nil {
+		return
+	}
+	key := klog.KObj(pod).String()
+	if _, ok := b.conditions[key]; ok {
+		// The condition never reached the pod, there is nothing to reset
+		delete(b.conditions, key)
+		pe.countAPICallCoalesced("pods/status")
+		return
+	}
+	if !b.applied.Has(key) {
+		return
+	}
+	b.applied.Delete(key)
+	// The pods evicted get the DisruptionTarget condition of the API server
+	if !evicted {
+		pe.setEvictionCondition(ctx, pod, v1.ConditionFalse, EvictionAbandonedReason, "pod eviction by sigs.k8s.io/descheduler given up")
+	}
+}
+
+func (pe *PodEvictor) setEvictionCondition(ctx context.Context, pod *v1.Pod, status v1.ConditionStatus, reason, message string) {
+	b := pe.writeBatcher
+	key := klog.KObj(pod).String()
+	if _, ok := b.conditions[key]; ok {
+		pe.countAPICallCoalesced("pods/status")
+	}
+	b.conditions[key] = pendingCondition{pod: pod, status: status, reason: reason, message: message, time: metav1.Now()}
+	b.added()
+	if b.due() {
+		pe.flushWrites(ctx)
+	}
+}
+
+// Flush writes the pending writes accompanying the evictions, when they are batched
+func (pe *PodEvictor) Flush(ctx context.Context) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	pe.flushWrites(ctx)
+}
+
+// flushWrites writes the pending conditions and events. The caller is expected to hold pe.mu.
+func (pe *PodEvictor) flushWrites(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	b := pe.writeBatcher
+	if b == nil || b.pending() == 0 {
+		return
+	}
+	logger.V(3).Info("Flushing the writes accompanying the evictions", "conditions", len(b.conditions), "events", len(b.events))
+	for key, condition := range b.conditions {
+		err := applyEvictionCondition(ctx, pe, condition)
+		pe.countAPICall("pods/status", "apply")
+		switch {
+		case err == nil:
+			if condition.status == v1.ConditionTrue {
+				b.applied.Insert(key)
+			}
+		case apierrors.IsNotFound(err):
+			// The pod is gone already
+		default:
+			logger.Error(err, "Unable to set the eviction condition of pod", "pod", klog.KObj(condition.pod))
+		}
+	}
+	for _, event := range b.events {
+		pe.eventRecorder.Eventf(event.pod, nil, event.eventtype, event.reason, "Descheduled", "%s", event.note)
+		pe.countAPICall("events", "create")
+	}
+	b.conditions = map[string]pendingCondition{}
+	b.events = nil
+	b.eventKeys = sets.New[string]()
+}
+
+// applyEvictionCondition applies the condition on the pod through a server-side apply, owning
+// nothing but the eviction condition of the pod
+func applyEvictionCondition(ctx context.Context, pe *PodEvictor, condition pendingCondition) error {
+	status := corev1apply.PodStatus().WithConditions(corev1apply.PodCondition().
+		WithType(EvictionConditionType).
+		WithStatus(condition.status).
+		WithReason(condition.reason).
+		WithMessage(condition.message).
+		WithLastTransitionTime(condition.time))
+	pod := corev1apply.Pod(condition.pod.Name, condition.pod.Namespace).WithStatus(status)
+	_, err := pe.client.CoreV1().Pods(condition.pod.Namespace).ApplyStatus(ctx, pod, metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+	return err
+}
+
+func (pe *PodEvictor) countAPICall(resource, verb string) {
+	if pe.metricsEnabled {
+		metrics.APICalls.With(map[string]string{"resource": resource, "verb": verb}).Inc()
+	}
+}
+
+func (pe *PodEvictor) countAPICallCoalesced(resource string) {
+	if pe.metricsEnabled {
+		metrics.APICallsCoalesced.With(map[string]string{"resource": resource}).Inc()
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+// conditionRecorder records the eviction conditions applied on the pods
+type conditionRecorder struct {
+	mu         sync.Mutex
+	conditions []string
+}
+
+func (r *conditionRecorder) applied() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.conditions...)
+}
+
+func newBatchingPodEvictor(failing string, options *Options) (*PodEvictor, *conditionRecorder, *events.FakeRecorder) {
+	conditions := &conditionRecorder{}
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		if eviction := action.(core.CreateAction).GetObject().(*policy.Eviction); eviction.Name == failing {
+			return true, nil, fmt.Errorf("eviction of %v blocked", eviction.Name)
+		}
+		return true, nil, nil
+	})
+	fakeClient.PrependReactor("patch", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		patch := action.(core.PatchAction)
+		if patch.GetSubresource() != "status" || patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		status := "True"
+		if strings.Contains(string(patch.GetPatch()), `"status":"False"`) {
+			status = "False"
+		}
+		conditions.mu.Lock()
+		defer conditions.mu.Unlock()
+		conditions.conditions = append(conditions.conditions, patch.GetName()+"="+status)
+		return true, &v1.Pod{}, nil
+	})
+	recorder := &events.FakeRecorder{Events: make(chan string, 100)}
+	return NewPodEvictor(fakeClient, recorder, options), conditions, recorder
+}
+
+func TestWriteBatching(t *testing.T) {
+	var pods []*v1.Pod
+	for i := 0; i < 3; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("p%d", i), 100, 0, "n1", nil))
+	}
+
+	t.Run("conditions of paced evictions are applied once flushed", func(t *testing.T) {
+		podEvictor, conditions, recorder := newBatchingPodEvictor("", NewOptions().WithPacingPeriod(time.Millisecond).WithWriteBatching(10, time.Hour))
+		for _, pod := range pods {
+			if err := podEvictor.EvictPod(context.Background(), pod, EvictOptions{StrategyName: "Test"}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		if applied := conditions.applied(); len(applied) != 0 {
+			t.Fatalf("Expected no condition applied before flushing, got %v", applied)
+		}
+		podEvictor.Flush(context.Background())
+		if applied := conditions.applied(); len(applied) != len(pods) {
+			t.Fatalf("Expected %v conditions applied, got %v", len(pods), applied)
+		}
+
+		podEvictor.Drain(context.Background())
+		if len(recorder.Events) != 0 {
+			t.Fatalf("Expected no event recorded before flushing, got %v", len(recorder.Events))
+		}
+		podEvictor.Flush(context.Background())
+		if len(recorder.Events) != len(pods) {
+			t.Errorf("Expected %v events, got %v", len(pods), len(recorder.Events))
+		}
+		// The evicted pods get the DisruptionTarget condition of the API server instead
+		if applied := conditions.applied(); len(applied) != len(pods) {
+			t.Errorf("Expected no other condition applied, got %v", applied)
+		}
+	})
+
+	t.Run("pending conditions of pods evicted before the flush are dropped", func(t *testing.T) {
+		podEvictor, conditions, _ := newBatchingPodEvictor("", NewOptions().WithPacingPeriod(time.Millisecond).WithWriteBatching(10, time.Hour))
+		for _, pod := range pods {
+			if err := podEvictor.EvictPod(context.Background(), pod, EvictOptions{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		podEvictor.Drain(context.Background())
+		podEvictor.Flush(context.Background())
+		if applied := conditions.applied(); len(applied) != 0 {
+			t.Errorf("Expected no condition applied, got %v", applied)
+		}
+	})
+
+	t.Run("conditions of given up evictions are reset", func(t *testing.T) {
+		podEvictor, conditions, _ := newBatchingPodEvictor("p1", NewOptions().WithPacingPeriod(time.Millisecond).WithWriteBatching(10, time.Hour))
+		for _, pod := range pods {
+			if err := podEvictor.EvictPod(context.Background(), pod, EvictOptions{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		podEvictor.Flush(context.Background())
+		podEvictor.Drain(context.Background())
+		podEvictor.Flush(context.Background())
+		applied := conditions.applied()
+		if len(applied) != len(pods)+1 || applied[len(applied)-1] != "p1=False" {
+			t.Errorf("Expected the condition of p1 to be reset, got %v", applied)
+		}
+	})
+
+	t.Run("batch is flushed once full", func(t *testing.T) {
+		podEvictor, conditions, _ := newBatchingPodEvictor("", NewOptions().WithPacingPeriod(time.Millisecond).WithWriteBatching(2, time.Hour))
+		for _, pod := range pods {
+			if err := podEvictor.EvictPod(context.Background(), pod, EvictOptions{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		if applied := conditions.applied(); len(applied) != 2 {
+			t.Errorf("Expected 2 conditions applied, got %v", applied)
+		}
+	})
+
+	t.Run("identical events are coalesced", func(t *testing.T) {
+		podEvictor, _, recorder := newBatchingPodEvictor("", NewOptions().WithWriteBatching(10, time.Hour))
+		podEvictor.mu.Lock()
+		podEvictor.recordEvent(context.Background(), pods[0], v1.EventTypeWarning, "EvictionFailed", "failed")
+		podEvictor.recordEvent(context.Background(), pods[0], v1.EventTypeWarning, "EvictionFailed", "failed")
+		podEvictor.recordEvent(context.Background(), pods[1], v1.EventTypeWarning, "EvictionFailed", "failed")
+		podEvictor.mu.Unlock()
+		podEvictor.Flush(context.Background())
+		if len(recorder.Events) != 2 {
+			t.Errorf("Expected 2 events, got %v", len(recorder.Events))
+		}
+	})
+}
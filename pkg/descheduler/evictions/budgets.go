@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	policylisters "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/descheduler/metrics"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// disruptionBudgetPacer holds back the evictions of the pods covered by a PodDisruptionBudget
+// which allows no more disruptions, instead of requesting evictions the API server would refuse.
+// The disruptions a budget allows are read from its status the first time one of its pods is
+// evicted in the cycle, and every eviction of its pods counts against them. The held back
+// evictions are retried once the other evictions got requested, reading the budgets again.
+type disruptionBudgetPacer struct {
+	pdbLister     policylisters.PodDisruptionBudgetLister
+	retryInterval time.Duration
+	retries       int
+
+	// allowed is the number of disruptions each budget still allows
+	allowed map[types.NamespacedName]int32
+	held    []heldEviction
+	// after is replaced in tests to not actually wait
+	after func(d time.Duration) <-chan time.Time
+}
+
+func newDisruptionBudgetPacer(pdbLister policylisters.PodDisruptionBudgetLister, retryInterval time.Duration, retries int) *disruptionBudgetPacer {
+	return &disruptionBudgetPacer{
+		pdbLister:     pdbLister,
+		retryInterval: retryInterval,
+		retries:       retries,
+		allowed:       map[types.NamespacedName]int32{},
+		after:         time.After,
+	}
+}
+
+// budgets returns the keys of the budgets covering the pod
+func (p *disruptionBudgetPacer) budgets(logger klog.Logger, pod *v1.Pod) []types.NamespacedName {
+	pdbs, err := p.pdbLister.PodDisruptionBudgets(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "Unable to list the disruption budgets of the pod", "pod", klog.KObj(pod))
+		return nil
+	}
+	var keys []types.NamespacedName
+	for _, pdb := range utils.PodDisruptionBudgetsSelecting(pod, pdbs) {
+		key := types.NamespacedName{Namespace: pdb.Namespace, Name: pdb.Name}
+		if _, ok := p.allowed[key]; !ok {
+			p.allowed[key] = pdb.Status.DisruptionsAllowed
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// admit counts the eviction of the pod against its budgets, or returns the budget
+// allowing no more disruptions, in which case nothing is counted.
+func (p *disruptionBudgetPacer) admit(logger klog.Logger, pod *v1.Pod) (types.NamespacedName, bool) {
+	keys := p.budgets(logger, pod)
+	for _, key := range keys {
+		if p.allowed[key] <= 0 {
+			return key, false
+		}
+	}
+	for _, key := range keys {
+		p.allowed[key]--
+	}
+	return types.NamespacedName{}, true
+}
+
+// heldEviction is an eviction held back by the budget allowing no more disruptions
+type heldEviction struct {
+	queuedEviction
+	budget types.NamespacedName
+}
+
+func (p *disruptionBudgetPacer) hold(pod *v1.Pod, opts EvictOptions, budget types.NamespacedName) {
+	p.held = append(p.held, heldEviction{queuedEviction: queuedEviction{pod: pod, opts: opts}, budget: budget})
+}
+
+// refresh forgets the disruptions counted so far so the budgets are read again
+func (p *disruptionBudgetPacer) refresh() {
+	p.allowed = map[types.NamespacedName]int32{}
+}
+
+// HeldEvictions gives a number of evictions held back by a PodDisruptionBudget
+func (pe *PodEvictor) HeldEvictions() int {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	if pe.disruptionBudgetPacer == nil {
+		return 0
+	}
+	return len(pe.disruptionBudgetPacer.held)
+}
+
+// retryHeldEvictions retries the evictions held back by a PodDisruptionBudget every retry
+// interval, requesting the ones the budgets allow again. The evictions still held back after
+// the last retry, or once the context is done, are given up until the next cycle.
+func (pe *PodEvictor) retryHeldEvictions(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	pacer := pe.disruptionBudgetPacer
+	if pacer == nil {
+		return
+	}
+	// The evictions still have to be requested after the context is done
+	evictCtx := context.WithoutCancel(ctx)
+	for retry := 0; retry < pacer.retries && ctx.Err() == nil; retry++ {
+		pe.mu.Lock()
+		held := pacer.held
+		pe.mu.Unlock()
+		if len(held) == 0 {
+			return
+		}
+		logger.V(2).Info("Waiting for the disruption budgets to allow the held back evictions", "evictions", len(held), "retryInterval", pacer.retryInterval)
+		select {
+		case <-ctx.Done():
+			continue
+		case <-pacer.after(pacer.retryInterval):
+		}
+
+		pe.mu.Lock()
+		pacer.refresh()
+		pacer.held = nil
+		for _, queued := range held {
+			if budget, ok := pacer.admit(logger, queued.pod); !ok {
+				logger.V(3).Info("Eviction still held back by the disruption budget", "pod", klog.KObj(queued.pod), "podDisruptionBudget", budget)
+				pacer.hold(queued.pod, queued.opts, budget)
+				continue
+			}
+			if err := pe.evict(evictCtx, queued.pod, queued.opts); err != nil {
+				pe.decrementCounters(queued.pod)
+				pe.settleEvictionCondition(evictCtx, queued.pod, false)
+			}
+		}
+		pe.mu.Unlock()
+	}
+
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	for _, queued := range pacer.held {
+		err := NewEvictionDisruptionBudgetError(queued.budget.String())
+		logger.V(1).Info("Giving up on an eviction held back by a disruption budget until the next cycle", "pod", klog.KObj(queued.pod), "podDisruptionBudget", queued.budget, "strategy", queued.opts.StrategyName, "profile", queued.opts.ProfileName)
+		if pe.metricsEnabled {
+			metrics.IncWithExemplar(evictCtx, metrics.PodsEvicted.With(map[string]string{"result": err.Error(), "strategy": queued.opts.StrategyName, "namespace": queued.pod.Namespace, "node": queued.pod.Spec.NodeName, "profile": queued.opts.ProfileName}))
+		}
+		pe.decrementCounters(queued.pod)
+		pe.settleEvictionCondition(evictCtx, queued.pod, false)
+	}
+	pacer.held = nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	policylisters "k8s.io/client-go/listers/policy/v1"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildTestPDB(name string, selector *metav1.LabelSelector, disruptionsAllowed int32) *policy.PodDisruptionBudget {
+	return &policy.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       policy.PodDisruptionBudgetSpec{Selector: selector},
+		Status:     policy.PodDisruptionBudgetStatus{DisruptionsAllowed: disruptionsAllowed},
+	}
+}
+
+func TestDisruptionBudgetPacing(t *testing.T) {
+	var pods []*v1.Pod
+	for i := 0; i < 4; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("a%d", i), 100, 0, "n1", func(pod *v1.Pod) {
+			pod.Labels = map[string]string{"app": "a"}
+		}))
+	}
+	// Not covered by any budget, the nil selector of the "none" budget selects no pod
+	pods = append(pods, test.BuildTestPod("b0", 100, 0, "n1", nil))
+	// Terminal pods are evicted regardless of their budgets
+	pods = append(pods, test.BuildTestPod("a-failed", 100, 0, "n1", func(pod *v1.Pod) {
+		pod.Labels = map[string]string{"app": "a"}
+		pod.Status.Phase = v1.PodFailed
+	}))
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	indexer.Add(buildTestPDB("a", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}, 2))
+	indexer.Add(buildTestPDB("none", nil, 0))
+
+	var evicted []string
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		evicted = append(evicted, action.(core.CreateAction).GetObject().(*policy.Eviction).Name)
+		return true, nil, nil
+	})
+
+	podEvictor := NewPodEvictor(
+		client,
+		&events.FakeRecorder{},
+		NewOptions().WithDisruptionBudgetPacing(policylisters.NewPodDisruptionBudgetLister(indexer), 10*time.Second, 2),
+	)
+	retries := 0
+	podEvictor.disruptionBudgetPacer.after = func(d time.Duration) <-chan time.Time {
+		retries++
+		// The budget recovers a single disruption on the first retry only
+		disruptionsAllowed := int32(0)
+		if retries == 1 {
+			disruptionsAllowed = 1
+		}
+		indexer.Update(buildTestPDB("a", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}, disruptionsAllowed))
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+
+	for _, pod := range pods {
+		if err := podEvictor.EvictPod(context.TODO(), pod, EvictOptions{}); err != nil {
+			t.Fatalf("Unexpected error evicting %v: %v", pod.Name, err)
+		}
+	}
+	if diff := cmp.Diff([]string{"a0", "a1", "b0", "a-failed"}, evicted); diff != "" {
+		t.Errorf("Unexpected evictions before the retries (-want,+got):\n%s", diff)
+	}
+	if held := podEvictor.HeldEvictions(); held != 2 {
+		t.Errorf("Expected 2 held back evictions, got %d instead", held)
+	}
+	// The held back evictions count towards the limits
+	if total := podEvictor.TotalEvicted(); total != 6 {
+		t.Errorf("Expected 6 total evictions, got %d instead", total)
+	}
+
+	podEvictor.Drain(context.TODO())
+
+	if retries != 2 {
+		t.Errorf("Expected 2 retries, got %d instead", retries)
+	}
+	if diff := cmp.Diff([]string{"a0", "a1", "b0", "a-failed", "a2"}, evicted); diff != "" {
+		t.Errorf("Unexpected evictions after the retries (-want,+got):\n%s", diff)
+	}
+	if held := podEvictor.HeldEvictions(); held != 0 {
+		t.Errorf("Expected no held back evictions after the retries, got %d instead", held)
+	}
+	if total := podEvictor.TotalEvicted(); total != 5 {
+		t.Errorf("Expected 5 total evictions, got %d instead", total)
+	}
+
+	// A new cycle reads the budgets again
+	podEvictor.ResetCounters()
+	indexer.Update(buildTestPDB("a", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}, 1))
+	if err := podEvictor.EvictPod(context.TODO(), pods[3], EvictOptions{}); err != nil {
+		t.Fatalf("Unexpected error evicting %v: %v", pods[3].Name, err)
+	}
+	if held := podEvictor.HeldEvictions(); held != 0 || evicted[len(evicted)-1] != "a3" {
+		t.Errorf("Expected a3 to be evicted in the new cycle, got %d held back evictions and %v evicted", held, evicted)
+	}
+}
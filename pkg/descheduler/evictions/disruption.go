@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+)
+
+// podEvictedReason is the status reason of the pods evicted by the kubelet under node pressure
+const podEvictedReason = "Evicted"
+
+// nodeDisruptionGuard detects the nodes already being disrupted by something else than
+// the descheduler, so evictions are not stacked on top of them: nodes where the kubelet
+// recently evicted pods, where pods recently got a DisruptionTarget condition (e.g. the
+// kubelet terminating them, the taint manager deleting them, an eviction API call) or
+// cordoned nodes with pods being terminated, i.e. nodes being drained.
+type nodeDisruptionGuard struct {
+	nodeLister            listersv1.NodeLister
+	getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc
+	window                time.Duration
+	// evicted records when the descheduler evicted pods itself,
+	// its own evictions are not disruptions to guard against
+	evicted map[types.UID]time.Time
+	now     func() time.Time
+}
+
+func newNodeDisruptionGuard(nodeLister listersv1.NodeLister, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, window time.Duration) *nodeDisruptionGuard {
+	return &nodeDisruptionGuard{
+		nodeLister:            nodeLister,
+		getPodsAssignedToNode: getPodsAssignedToNode,
+		window:                window,
+		evicted:               map[types.UID]time.Time{},
+		now:                   time.Now,
+	}
+}
+
+// recordEviction marks the pod as evicted by the descheduler
+func (g *nodeDisruptionGuard) recordEviction(pod *v1.Pod) {
+	g.evicted[pod.UID] = g.now()
+}
+
+// prune forgets the evictions older than the window
+func (g *nodeDisruptionGuard) prune() {
+	since := g.now().Add(-g.window)
+	for uid, evicted := range g.evicted {
+		if evicted.Before(since) {
+			delete(g.evicted, uid)
+		}
+	}
+}
+
+// nodeDisruption tells why the node is being disrupted, or returns an empty string when it is not
+func (g *nodeDisruptionGuard) nodeDisruption(logger klog.Logger, nodeName string) string {
+	pods, err := g.getPodsAssignedToNode(nodeName, nil)
+	if err != nil {
+		logger.Error(err, "Unable to list the pods of the node to detect ongoing disruptions", "node", nodeName)
+		return ""
+	}
+	cordoned := false
+	if node, err := g.nodeLister.Get(nodeName); err == nil {
+		cordoned = node.Spec.Unschedulable
+	}
+
+	since := g.now().Add(-g.window)
+	for _, pod := range pods {
+		if _, ok := g.evicted[pod.UID]; ok {
+			continue
+		}
+		if condition := podCondition(pod, v1.DisruptionTarget); condition != nil && condition.Status == v1.ConditionTrue && !condition.LastTransitionTime.Time.Before(since) {
+			return fmt.Sprintf("pod %s/%s disrupted (%s)", pod.Namespace, pod.Name, condition.Reason)
+		}
+		if pod.Status.Phase == v1.PodFailed && pod.Status.Reason == podEvictedReason {
+			// Without the DisruptionTarget condition the pod stopped being ready when evicted
+			if condition := podCondition(pod, v1.PodReady); condition != nil && !condition.LastTransitionTime.Time.Before(since) {
+				return fmt.Sprintf("pod %s/%s evicted by the kubelet", pod.Namespace, pod.Name)
+			}
+		}
+		if cordoned && pod.DeletionTimestamp != nil {
+			return fmt.Sprintf("node drained, pod %s/%s terminating", pod.Namespace, pod.Name)
+		}
+	}
+	return ""
+}
+
+func podCondition(pod *v1.Pod, conditionType v1.PodConditionType) *v1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func newNodeLister(t *testing.T, nodes ...*v1.Node) listersv1.NodeLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, node := range nodes {
+		if err := indexer.Add(node); err != nil {
+			t.Fatalf("Unable to add node: %v", err)
+		}
+	}
+	return listersv1.NewNodeLister(indexer)
+}
+
+func podsAssignedToNode(pods ...*v1.Pod) podutil.GetPodsAssignedToNodeFunc {
+	return func(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+		var assigned []*v1.Pod
+		for _, pod := range pods {
+			if pod.Spec.NodeName == nodeName && (filter == nil || filter(pod)) {
+				assigned = append(assigned, pod)
+			}
+		}
+		return assigned, nil
+	}
+}
+
+func TestNodeDisruption(t *testing.T) {
+	now := time.Now()
+	withCondition := func(conditionType v1.PodConditionType, reason string, since time.Duration) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Status.Conditions = append(pod.Status.Conditions, v1.PodCondition{
+				Type:               conditionType,
+				Status:             v1.ConditionTrue,
+				Reason:             reason,
+				LastTransitionTime: metav1.NewTime(now.Add(-since)),
+			})
+		}
+	}
+	kubeletEvicted := func(since time.Duration) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Status.Phase = v1.PodFailed
+			pod.Status.Reason = "Evicted"
+			withCondition(v1.PodReady, "PodFailed", since)(pod)
+		}
+	}
+	terminating := func(pod *v1.Pod) {
+		pod.DeletionTimestamp = &metav1.Time{Time: now}
+	}
+
+	node := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	cordonedNode := test.BuildTestNode("n1", 2000, 3000, 10, test.SetNodeUnschedulable)
+	running := test.BuildTestPod("running", 100, 0, "n1", nil)
+
+	tests := []struct {
+		description string
+		node        *v1.Node
+		pod         *v1.Pod
+		evicted     bool
+		disrupted   bool
+	}{
+		{
+			description: "no disruption",
+			node:        node,
+			pod:         test.BuildTestPod("p", 100, 0, "n1", nil),
+		},
+		{
+			description: "recent DisruptionTarget condition",
+			node:        node,
+			pod:         test.BuildTestPod("p", 100, 0, "n1", withCondition(v1.DisruptionTarget, "TerminationByKubelet", time.Minute)),
+			disrupted:   true,
+		},
+		{
+			description: "DisruptionTarget condition out of the window",
+			node:        node,
+			pod:         test.BuildTestPod("p", 100, 0, "n1", withCondition(v1.DisruptionTarget, "TerminationByKubelet", time.Hour)),
+		},
+		{
+			description: "DisruptionTarget condition of a pod evicted by the descheduler",
+			node:        node,
+			pod:         test.BuildTestPod("p", 100, 0, "n1", withCondition(v1.DisruptionTarget, "EvictionByEvictionAPI", time.Minute)),
+			evicted:     true,
+		},
+		{
+			description: "recent kubelet eviction",
+			node:        node,
+			pod:         test.BuildTestPod("p", 100, 0, "n1", kubeletEvicted(time.Minute)),
+			disrupted:   true,
+		},
+		{
+			description: "kubelet eviction out of the window",
+			node:        node,
+			pod:         test.BuildTestPod("p", 100, 0, "n1", kubeletEvicted(time.Hour)),
+		},
+		{
+			description: "cordoned node with a terminating pod",
+			node:        cordonedNode,
+			pod:         test.BuildTestPod("p", 100, 0, "n1", terminating),
+			disrupted:   true,
+		},
+		{
+			description: "terminating pod on a schedulable node",
+			node:        node,
+			pod:         test.BuildTestPod("p", 100, 0, "n1", terminating),
+		},
+		{
+			description: "cordoned node without terminating pods",
+			node:        cordonedNode,
+			pod:         test.BuildTestPod("p", 100, 0, "n1", nil),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			guard := newNodeDisruptionGuard(newNodeLister(t, tc.node), podsAssignedToNode(running, tc.pod), 10*time.Minute)
+			guard.now = func() time.Time { return now }
+			if tc.evicted {
+				guard.recordEviction(tc.pod)
+			}
+			if disrupted := guard.nodeDisruption(klog.Background(), "n1") != ""; disrupted != tc.disrupted {
+				t.Errorf("Expected the node to be disrupted: %v, got %v", tc.disrupted, disrupted)
+			}
+		})
+	}
+}
+
+func TestNodeDisruptionGuard(t *testing.T) {
+	node := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	otherNode := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	disrupted := test.BuildTestPod("disrupted", 100, 0, "n1", func(pod *v1.Pod) {
+		pod.Status.Conditions = []v1.PodCondition{{
+			Type:               v1.DisruptionTarget,
+			Status:             v1.ConditionTrue,
+			Reason:             "TerminationByKubelet",
+			LastTransitionTime: metav1.Now(),
+		}}
+	})
+	running := test.BuildTestPod("running", 100, 0, "n1", nil)
+	failed := test.BuildTestPod("failed", 100, 0, "n1", func(pod *v1.Pod) {
+		pod.Status.Phase = v1.PodFailed
+	})
+	otherRunning := test.BuildTestPod("other-running", 100, 0, "n2", nil)
+
+	podEvictor := NewPodEvictor(
+		fake.NewSimpleClientset(running, failed, otherRunning),
+		&events.FakeRecorder{},
+		NewOptions().WithNodeDisruptionGuard(newNodeLister(t, node, otherNode), podsAssignedToNode(disrupted, running, failed, otherRunning), 10*time.Minute),
+	)
+
+	err := podEvictor.EvictPod(context.Background(), running, EvictOptions{})
+	if _, ok := err.(*EvictionNodeDisruptedError); !ok {
+		t.Errorf("Expected the eviction to be denied by the node disruption guard, got %v", err)
+	}
+	if err := podEvictor.EvictPod(context.Background(), failed, EvictOptions{}); err != nil {
+		t.Errorf("Expected terminal pods to be evicted from disrupted nodes, got %v", err)
+	}
+	if err := podEvictor.EvictPod(context.Background(), otherRunning, EvictOptions{}); err != nil {
+		t.Errorf("Expected pods to be evicted from other nodes, got %v", err)
+	}
+	if podEvictor.TotalEvicted() != 2 {
+		t.Errorf("Expected 2 evictions, got %v", podEvictor.TotalEvicted())
+	}
+}
@@ -1,5 +1,7 @@
 package evictions
 
+import "fmt"
+
 type EvictionNodeLimitError struct {
 	node string
 }
@@ -43,3 +45,81 @@ func NewEvictionTotalLimitError() *EvictionTotalLimitError {
 }
 
 var _ error = &EvictionTotalLimitError{}
+
+type EvictionNodeDisruptedError struct {
+	node   string
+	reason string
+}
+
+func (e EvictionNodeDisruptedError) Error() string {
+	return "node is already being disrupted"
+}
+
+func NewEvictionNodeDisruptedError(node, reason string) *EvictionNodeDisruptedError {
+	return &EvictionNodeDisruptedError{
+		node:   node,
+		reason: reason,
+	}
+}
+
+var _ error = &EvictionNodeDisruptedError{}
+
+type EvictionAlreadyProcessedError struct{}
+
+func (e EvictionAlreadyProcessedError) Error() string {
+	return "pod already evicted in the descheduling cycle"
+}
+
+func NewEvictionAlreadyProcessedError() *EvictionAlreadyProcessedError {
+	return &EvictionAlreadyProcessedError{}
+}
+
+var _ error = &EvictionAlreadyProcessedError{}
+
+type EvictionDisruptionBudgetError struct {
+	budget string
+}
+
+func (e EvictionDisruptionBudgetError) Error() string {
+	return "pod disruption budget allows no more disruptions"
+}
+
+func NewEvictionDisruptionBudgetError(budget string) *EvictionDisruptionBudgetError {
+	return &EvictionDisruptionBudgetError{
+		budget: budget,
+	}
+}
+
+var _ error = &EvictionDisruptionBudgetError{}
+
+type EvictionNamespaceDisabledError struct {
+	namespace string
+}
+
+func (e EvictionNamespaceDisabledError) Error() string {
+	return "descheduling disabled in the namespace"
+}
+
+func NewEvictionNamespaceDisabledError(namespace string) *EvictionNamespaceDisabledError {
+	return &EvictionNamespaceDisabledError{
+		namespace: namespace,
+	}
+}
+
+var _ error = &EvictionNamespaceDisabledError{}
+
+type EvictionCancelledError struct {
+	cause error
+}
+
+func (e EvictionCancelledError) Error() string {
+	return fmt.Sprintf("eviction requested after the plugin was cancelled: %v", e.cause)
+}
+
+func NewEvictionCancelledError(cause error) *EvictionCancelledError {
+	return &EvictionCancelledError{
+		cause: cause,
+	}
+}
+
+var _ error = &EvictionCancelledError{}
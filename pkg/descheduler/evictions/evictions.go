@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -27,6 +28,7 @@ import (
 	policy "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/klog/v2"
@@ -47,6 +49,7 @@ type PodEvictor struct {
 	client                     clientset.Interface
 	policyGroupVersion         string
 	dryRun                     bool
+	serverSideDryRunClient     clientset.Interface
 	maxPodsToEvictPerNode      *uint
 	maxPodsToEvictPerNamespace *uint
 	maxPodsToEvictTotal        *uint
@@ -55,6 +58,18 @@ type PodEvictor struct {
 	totalPodCount              uint
 	metricsEnabled             bool
 	eventRecorder              events.EventRecorder
+	pacingPeriod               time.Duration
+	queue                      []queuedEviction
+	nodeDisruptionGuard        *nodeDisruptionGuard
+	processedPods              *ProcessedPods
+	evictedPods                *ProcessedPods
+	evictionTimeout            time.Duration
+	throttle                   *evictionThrottle
+	disruptionBudgetPacer      *disruptionBudgetPacer
+	cycleAnomalyGuard          *cycleAnomalyGuard
+	namespaceModes             *namespaceModes
+	writeBatcher               *writeBatcher
+	evictionPreview            *evictionPreview
 }
 
 func NewPodEvictor(
@@ -66,17 +81,33 @@ func NewPodEvictor(
 		options = NewOptions()
 	}
 
+	if options.throttle != nil {
+		options.throttle.metricsEnabled = options.metricsEnabled
+	}
+
 	return &PodEvictor{
 		client:                     client,
 		eventRecorder:              eventRecorder,
 		policyGroupVersion:         options.policyGroupVersion,
 		dryRun:                     options.dryRun,
+		serverSideDryRunClient:     options.serverSideDryRunClient,
 		maxPodsToEvictPerNode:      options.maxPodsToEvictPerNode,
 		maxPodsToEvictPerNamespace: options.maxPodsToEvictPerNamespace,
 		maxPodsToEvictTotal:        options.maxPodsToEvictTotal,
 		metricsEnabled:             options.metricsEnabled,
+		pacingPeriod:               options.pacingPeriod,
+		nodeDisruptionGuard:        options.nodeDisruptionGuard,
+		evictionTimeout:            options.evictionTimeout,
+		throttle:                   options.throttle,
+		disruptionBudgetPacer:      options.disruptionBudgetPacer,
+		cycleAnomalyGuard:          options.cycleAnomalyGuard,
+		namespaceModes:             options.namespaceModes,
+		writeBatcher:               options.writeBatcher,
+		evictionPreview:            options.evictionPreview,
 		nodePodCount:               make(nodePodEvictedCount),
 		namespacePodCount:          make(namespacePodEvictCount),
+		processedPods:              newProcessedPods(),
+		evictedPods:                newProcessedPods(),
 	}
 }
 
@@ -100,6 +131,40 @@ func (pe *PodEvictor) ResetCounters() {
 	pe.nodePodCount = make(nodePodEvictedCount)
 	pe.namespacePodCount = make(namespacePodEvictCount)
 	pe.totalPodCount = 0
+	pe.processedPods.reset()
+	pe.evictedPods.reset()
+	if pe.evictionPreview != nil {
+		pe.evictionPreview.reset()
+	}
+	pe.resetBudgets()
+	if pe.nodeDisruptionGuard != nil {
+		pe.nodeDisruptionGuard.prune()
+	}
+	if pe.disruptionBudgetPacer != nil {
+		pe.disruptionBudgetPacer.refresh()
+	}
+	if pe.cycleAnomalyGuard != nil {
+		pe.cycleAnomalyGuard.aborted = false
+	}
+}
+
+// ProcessedPods returns the pods evicted, or queued for a paced eviction, since the counters were last reset
+func (pe *PodEvictor) ProcessedPods() *ProcessedPods {
+	return pe.processedPods
+}
+
+// EvictedPods returns the pods whose eviction the API server accepted since the counters were last reset,
+// leaving out the evictions queued, held back or simulated in dry run
+func (pe *PodEvictor) EvictedPods() *ProcessedPods {
+	return pe.evictedPods
+}
+
+// DefersEvictions tells whether the pods selected by the plugins may not leave their nodes during the cycle,
+// the evictions being spread over the pacing period, or only simulated in dry run
+func (pe *PodEvictor) DefersEvictions() bool {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	return pe.dryRun || pe.pacingPeriod > 0
 }
 
 func (pe *PodEvictor) SetClient(client clientset.Interface) {
@@ -114,76 +179,263 @@ type EvictOptions struct {
 	Reason string
 	// ProfileName allows for passing details about profile for observability.
 	ProfileName string
+	// ProfileLabels attribute the eviction, they are added to its events and logs.
+	ProfileLabels map[string]string
 	// StrategyName allows for passing details about strategy for observability.
 	StrategyName string
+	// Urgent evictions are meant for pods about to be terminated anyway, e.g. on nodes about to be
+	// interrupted. They are requested right away, regardless of the pacing and the node disruption
+	// guard, and are only subject to the total limit. They still count towards all the limits.
+	Urgent bool
 }
 
 // EvictPod evicts a pod while exercising eviction limits.
 // Returns true when the pod is evicted on the server side.
 func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, opts EvictOptions) error {
+	logger := klog.FromContext(ctx)
 	pe.mu.Lock()
 	defer pe.mu.Unlock()
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "EvictPod", trace.WithAttributes(attribute.String("podName", pod.Name), attribute.String("podNamespace", pod.Namespace), attribute.String("reason", opts.Reason), attribute.String("operation", tracing.EvictOperation)))
 	defer span.End()
 
+	// A plugin which timed out or overran the cycle deadline may keep running in the background,
+	// its evictions are refused once its context is done
+	if ctx.Err() != nil {
+		err := NewEvictionCancelledError(context.Cause(ctx))
+		span.AddEvent("Eviction Refused", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
+		logger.V(2).Info("Not evicting pod, the plugin was cancelled", "pod", klog.KObj(pod), "strategy", opts.StrategyName, "profile", opts.ProfileName, "cause", context.Cause(ctx))
+		return err
+	}
+
+	// A pod evicted already in this cycle is not subject to the limits again
+	if pe.processedPods.Has(pod) {
+		err := NewEvictionAlreadyProcessedError()
+		span.AddEvent("Eviction Skipped", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
+		logger.V(3).Info("Skipping pod already evicted in this cycle", "pod", klog.KObj(pod), "strategy", opts.StrategyName, "profile", opts.ProfileName)
+		return err
+	}
+
+	// An aborted cycle stops the plugins as if the total limit was reached
+	if guard := pe.cycleAnomalyGuard; guard != nil && (guard.aborted || pe.totalPodCount+1 > guard.maxEvictions()) {
+		if !guard.aborted {
+			pe.abortCycle(ctx, pod, opts)
+		}
+		err := NewEvictionTotalLimitError()
+		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", "descheduling cycle aborted")))
+		logger.V(3).Info("Not evicting pod, the descheduling cycle was aborted", "pod", klog.KObj(pod), "strategy", opts.StrategyName, "profile", opts.ProfileName)
+		return err
+	}
+
+	switch pe.NamespaceMode(pod.Namespace) {
+	case NamespaceModeDisabled:
+		err := NewEvictionNamespaceDisabledError(pod.Namespace)
+		if pe.metricsEnabled {
+			metrics.IncWithExemplar(ctx, metrics.PodsEvicted.With(map[string]string{"result": err.Error(), "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}))
+		}
+		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
+		logger.V(3).Info("Not evicting pod, descheduling is disabled in its namespace", "pod", klog.KObj(pod), "strategy", opts.StrategyName, "profile", opts.ProfileName)
+		return err
+	case NamespaceModeDryRun:
+		// The whole descheduler running in dry run mode simulates the eviction already
+		if !pe.dryRun {
+			span.AddEvent("Eviction Simulated", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName)))
+			return pe.evictInDryRunNamespace(ctx, pod, opts)
+		}
+	}
+
 	if pe.maxPodsToEvictTotal != nil && pe.totalPodCount+1 > *pe.maxPodsToEvictTotal {
 		err := NewEvictionTotalLimitError()
 		if pe.metricsEnabled {
-			metrics.PodsEvicted.With(map[string]string{"result": err.Error(), "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}).Inc()
+			metrics.IncWithExemplar(ctx, metrics.PodsEvicted.With(map[string]string{"result": err.Error(), "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}))
+			metrics.IncWithExemplar(ctx, metrics.EvictionsRejectedByLimit.With(map[string]string{"limit": "total", "strategy": opts.StrategyName, "profile": opts.ProfileName}))
 		}
 		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
-		klog.ErrorS(err, "Error evicting pod", "limit", *pe.maxPodsToEvictTotal)
+		logger.Error(err, "Error evicting pod", "limit", *pe.maxPodsToEvictTotal)
 		return err
 	}
 
-	if pod.Spec.NodeName != "" {
+	if pod.Spec.NodeName != "" && !opts.Urgent {
 		if pe.maxPodsToEvictPerNode != nil && pe.nodePodCount[pod.Spec.NodeName]+1 > *pe.maxPodsToEvictPerNode {
 			err := NewEvictionNodeLimitError(pod.Spec.NodeName)
 			if pe.metricsEnabled {
-				metrics.PodsEvicted.With(map[string]string{"result": err.Error(), "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}).Inc()
+				metrics.IncWithExemplar(ctx, metrics.PodsEvicted.With(map[string]string{"result": err.Error(), "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}))
+				metrics.IncWithExemplar(ctx, metrics.EvictionsRejectedByLimit.With(map[string]string{"limit": "node", "strategy": opts.StrategyName, "profile": opts.ProfileName}))
 			}
 			span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
-			klog.ErrorS(err, "Error evicting pod", "limit", *pe.maxPodsToEvictPerNode, "node", pod.Spec.NodeName)
+			logger.Error(err, "Error evicting pod", "limit", *pe.maxPodsToEvictPerNode, "node", pod.Spec.NodeName)
 			return err
 		}
 	}
 
-	if pe.maxPodsToEvictPerNamespace != nil && pe.namespacePodCount[pod.Namespace]+1 > *pe.maxPodsToEvictPerNamespace {
+	if pe.maxPodsToEvictPerNamespace != nil && !opts.Urgent && pe.namespacePodCount[pod.Namespace]+1 > *pe.maxPodsToEvictPerNamespace {
 		err := NewEvictionNamespaceLimitError(pod.Namespace)
 		if pe.metricsEnabled {
-			metrics.PodsEvicted.With(map[string]string{"result": err.Error(), "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}).Inc()
+			metrics.IncWithExemplar(ctx, metrics.PodsEvicted.With(map[string]string{"result": err.Error(), "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}))
+			metrics.IncWithExemplar(ctx, metrics.EvictionsRejectedByLimit.With(map[string]string{"limit": "namespace", "strategy": opts.StrategyName, "profile": opts.ProfileName}))
 		}
 		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
-		klog.ErrorS(err, "Error evicting pod", "limit", *pe.maxPodsToEvictPerNamespace, "namespace", pod.Namespace)
+		logger.Error(err, "Error evicting pod", "limit", *pe.maxPodsToEvictPerNamespace, "namespace", pod.Namespace)
 		return err
 	}
 
-	err := evictPod(ctx, pe.client, pod, pe.policyGroupVersion)
-	if err != nil {
-		// err is used only for logging purposes
+	// Terminal pods are not disrupted by their eviction
+	if pe.nodeDisruptionGuard != nil && !opts.Urgent && pod.Spec.NodeName != "" && pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+		if reason := pe.nodeDisruptionGuard.nodeDisruption(logger, pod.Spec.NodeName); reason != "" {
+			err := NewEvictionNodeDisruptedError(pod.Spec.NodeName, reason)
+			if pe.metricsEnabled {
+				metrics.IncWithExemplar(ctx, metrics.PodsEvicted.With(map[string]string{"result": err.Error(), "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}))
+			}
+			span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
+			logger.V(2).Info("Not evicting pod from a node already being disrupted", "pod", klog.KObj(pod), "node", pod.Spec.NodeName, "disruption", reason)
+			return err
+		}
+	}
+
+	// The eviction API does not check the disruption budgets of terminal pods
+	if pe.disruptionBudgetPacer != nil && !opts.Urgent && pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+		if budget, ok := pe.disruptionBudgetPacer.admit(logger, pod); !ok {
+			// Count the eviction right away so the limits keep applying to the held back evictions
+			pe.incrementCounters(pod)
+			pe.processedPods.insert(pod)
+			pe.disruptionBudgetPacer.hold(pod, opts, budget)
+			pe.requestEvictionCondition(ctx, pod, opts, EvictionHeldBackReason)
+			span.AddEvent("Eviction Held Back", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("podDisruptionBudget", budget.String())))
+			logger.V(2).Info("Holding back the eviction of pod until its disruption budget allows it", "pod", klog.KObj(pod), "podDisruptionBudget", budget, "strategy", opts.StrategyName, "profile", opts.ProfileName)
+			return nil
+		}
+	}
+
+	if pe.pacingPeriod > 0 && !opts.Urgent {
+		// Count the eviction right away so the limits keep applying to the queued evictions
+		pe.incrementCounters(pod)
+		pe.processedPods.insert(pod)
+		pe.queue = append(pe.queue, queuedEviction{pod: pod, opts: opts})
+		pe.requestEvictionCondition(ctx, pod, opts, EvictionPacedReason)
+		span.AddEvent("Eviction Queued", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName)))
+		logger.V(3).Info("Queued pod for a paced eviction", "pod", klog.KObj(pod), "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName)
+		return nil
+	}
+
+	if err := pe.evict(ctx, pod, opts); err != nil {
 		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
-		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", opts.Reason)
-		if pe.metricsEnabled {
-			metrics.PodsEvicted.With(map[string]string{"result": "error", "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}).Inc()
+		// The pod is gone already, there is no point in trying again in this cycle
+		if apierrors.IsNotFound(err) {
+			pe.processedPods.insert(pod)
 		}
 		return err
 	}
+	pe.incrementCounters(pod)
+	pe.processedPods.insert(pod)
+	return nil
+}
 
+func (pe *PodEvictor) incrementCounters(pod *v1.Pod) {
 	if pod.Spec.NodeName != "" {
 		pe.nodePodCount[pod.Spec.NodeName]++
 	}
 	pe.namespacePodCount[pod.Namespace]++
 	pe.totalPodCount++
+	pe.reportBudgets(pod)
+}
+
+func (pe *PodEvictor) decrementCounters(pod *v1.Pod) {
+	if pod.Spec.NodeName != "" {
+		pe.nodePodCount[pod.Spec.NodeName]--
+	}
+	pe.namespacePodCount[pod.Namespace]--
+	pe.totalPodCount--
+	pe.reportBudgets(pod)
+}
+
+// reportBudgets updates the remaining budget gauges of the limits the eviction of the pod counts towards.
+// The caller is expected to hold pe.mu.
+func (pe *PodEvictor) reportBudgets(pod *v1.Pod) {
+	if !pe.metricsEnabled {
+		return
+	}
+	if pe.maxPodsToEvictTotal != nil {
+		metrics.EvictionBudgetRemainingTotal.Set(remainingBudget(*pe.maxPodsToEvictTotal, pe.totalPodCount))
+	}
+	if pe.maxPodsToEvictPerNode != nil && pod.Spec.NodeName != "" {
+		metrics.EvictionBudgetRemainingPerNode.With(map[string]string{"node": pod.Spec.NodeName}).Set(remainingBudget(*pe.maxPodsToEvictPerNode, pe.nodePodCount[pod.Spec.NodeName]))
+	}
+	if pe.maxPodsToEvictPerNamespace != nil {
+		metrics.EvictionBudgetRemainingPerNamespace.With(map[string]string{"namespace": pod.Namespace}).Set(remainingBudget(*pe.maxPodsToEvictPerNamespace, pe.namespacePodCount[pod.Namespace]))
+	}
+}
+
+// resetBudgets reports the whole budgets for a new descheduling cycle. The caller is expected to hold pe.mu.
+func (pe *PodEvictor) resetBudgets() {
+	if !pe.metricsEnabled {
+		return
+	}
+	if pe.maxPodsToEvictTotal != nil {
+		metrics.EvictionBudgetRemainingTotal.Set(float64(*pe.maxPodsToEvictTotal))
+	}
+	metrics.EvictionBudgetRemainingPerNode.Reset()
+	metrics.EvictionBudgetRemainingPerNamespace.Reset()
+}
+
+func remainingBudget(limit, evicted uint) float64 {
+	if evicted >= limit {
+		return 0
+	}
+	return float64(limit - evicted)
+}
+
+// evict requests the eviction of the pod and reports the result. The caller is expected to hold pe.mu.
+func (pe *PodEvictor) evict(ctx context.Context, pod *v1.Pod, opts EvictOptions) error {
+	logger := klog.FromContext(ctx)
+	if pe.throttle != nil {
+		pe.throttle.wait(ctx)
+	}
+	evictCtx := ctx
+	if pe.evictionTimeout > 0 {
+		var cancel context.CancelFunc
+		evictCtx, cancel = context.WithTimeout(ctx, pe.evictionTimeout)
+		defer cancel()
+	}
+
+	var err error
+	if pe.dryRun && pe.serverSideDryRunClient != nil {
+		// Let the API server validate the eviction first, the simulated one can not be refused by PDBs or webhooks
+		err = evictPod(evictCtx, pe.serverSideDryRunClient, pod, pe.policyGroupVersion, true)
+	}
+	if err == nil {
+		err = evictPod(evictCtx, pe.client, pod, pe.policyGroupVersion, false)
+	}
+	pe.countAPICall("pods/eviction", "create")
+	if pe.throttle != nil {
+		pe.throttle.observe(logger, err)
+	}
+	if err != nil {
+		// err is used only for logging purposes
+		logger.Error(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", opts.Reason, "profile", opts.ProfileName, "profileLabels", opts.ProfileLabels)
+		if !pe.dryRun {
+			pe.recordEvent(ctx, pod, v1.EventTypeWarning, "EvictionFailed", fmt.Sprintf("pod eviction from %v node by sigs.k8s.io/descheduler%s failed: %v", pod.Spec.NodeName, profileAttribution(opts), err))
+		}
+		if pe.metricsEnabled {
+			metrics.IncWithExemplar(ctx, metrics.PodsEvicted.With(map[string]string{"result": "error", "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}))
+		}
+		return err
+	}
+
+	if pe.nodeDisruptionGuard != nil {
+		pe.nodeDisruptionGuard.recordEviction(pod)
+	}
+	pe.settleEvictionCondition(ctx, pod, true)
 
 	if pe.metricsEnabled {
-		metrics.PodsEvicted.With(map[string]string{"result": "success", "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}).Inc()
+		metrics.IncWithExemplar(ctx, metrics.PodsEvicted.With(map[string]string{"result": "success", "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}))
 	}
 
 	if pe.dryRun {
-		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName)
+		logger.V(1).Info("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName, "profileLabels", opts.ProfileLabels, "serverSide", pe.serverSideDryRunClient != nil)
+		pe.previewEviction(ctx, pod, opts)
 	} else {
-		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName)
+		pe.evictedPods.insert(pod)
+		logger.V(1).Info("Evicted pod", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName, "profileLabels", opts.ProfileLabels)
 		reason := opts.Reason
 		if len(reason) == 0 {
 			reason = opts.StrategyName
@@ -191,13 +443,25 @@ func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, opts EvictOptio
 				reason = "NotSet"
 			}
 		}
-		pe.eventRecorder.Eventf(pod, nil, v1.EventTypeNormal, reason, "Descheduled", "pod evicted from %v node by sigs.k8s.io/descheduler", pod.Spec.NodeName)
+		pe.recordEvent(ctx, pod, v1.EventTypeNormal, reason, fmt.Sprintf("pod evicted from %v node by sigs.k8s.io/descheduler%s", pod.Spec.NodeName, profileAttribution(opts)))
 	}
 	return nil
 }
 
-func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string) error {
+// profileAttribution returns the profile and its labels to add to the notes of the eviction
+// events, nothing when the profile has no label
+func profileAttribution(opts EvictOptions) string {
+	if len(opts.ProfileLabels) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (profile %s, %s)", opts.ProfileName, labels.Set(opts.ProfileLabels).String())
+}
+
+func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string, serverSideDryRun bool) error {
 	deleteOptions := &metav1.DeleteOptions{}
+	if serverSideDryRun {
+		deleteOptions.DryRun = []string{metav1.DryRunAll}
+	}
 	// GracePeriodSeconds ?
 	eviction := &policy.Eviction{
 		TypeMeta: metav1.TypeMeta{
@@ -213,10 +477,32 @@ func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, poli
 	err := client.PolicyV1().Evictions(eviction.Namespace).Evict(ctx, eviction)
 
 	if apierrors.IsTooManyRequests(err) {
-		return fmt.Errorf("error when evicting pod (ignoring) %q: %v", pod.Name, err)
+		return fmt.Errorf("error when evicting pod (ignoring) %q: %w", pod.Name, err)
 	}
 	if apierrors.IsNotFound(err) {
-		return fmt.Errorf("pod not found when evicting %q: %v", pod.Name, err)
+		return fmt.Errorf("pod not found when evicting %q: %w", pod.Name, err)
 	}
 	return err
 }
+
+// EvictPods evicts the pods in order with the given eviction function, e.g. the Evict method of the
+// evictor of a plugin, skipping the remaining pods once the limit of their node is reached. It returns
+// false when the total eviction limit got reached.
+func EvictPods(ctx context.Context, evict func(context.Context, *v1.Pod, EvictOptions) error, pods []*v1.Pod, opts EvictOptions) bool {
+	logger := klog.FromContext(ctx)
+	for _, pod := range pods {
+		err := evict(ctx, pod, opts)
+		if err == nil {
+			continue
+		}
+		switch err.(type) {
+		case *EvictionNodeLimitError:
+			return true
+		case *EvictionTotalLimitError:
+			return false
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	return true
+}
@@ -18,16 +18,23 @@ package evictions
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/events"
+	k8smetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/testutil"
 	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/metrics"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/test"
@@ -65,7 +72,7 @@ func TestEvictPod(t *testing.T) {
 		fakeClient.Fake.AddReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
 			return true, &v1.PodList{Items: test.pods}, nil
 		})
-		got := evictPod(ctx, fakeClient, test.pod, "v1")
+		got := evictPod(ctx, fakeClient, test.pod, "v1", false)
 		if got != test.want {
 			t.Errorf("Test error for Desc: %s. Expected %v pod eviction to be %v, got %v", test.description, test.pod.Name, test.want, got)
 		}
@@ -119,8 +126,9 @@ func TestPodTypes(t *testing.T) {
 
 func TestNewPodEvictor(t *testing.T) {
 	pod1 := test.BuildTestPod("pod", 400, 0, "node", nil)
+	pod2 := test.BuildTestPod("pod2", 400, 0, "node", nil)
 
-	fakeClient := fake.NewSimpleClientset(pod1)
+	fakeClient := fake.NewSimpleClientset(pod1, pod2)
 
 	eventRecorder := &events.FakeRecorder{}
 
@@ -154,7 +162,7 @@ func TestNewPodEvictor(t *testing.T) {
 		t.Errorf("Expected 1 total evictions, got %q instead", evictions)
 	}
 
-	err := podEvictor.EvictPod(context.TODO(), pod1, EvictOptions{})
+	err := podEvictor.EvictPod(context.TODO(), pod2, EvictOptions{})
 	if err == nil {
 		t.Errorf("Expected a pod eviction error, got nil instead")
 	}
@@ -165,3 +173,303 @@ func TestNewPodEvictor(t *testing.T) {
 		t.Errorf("Expected a pod eviction EvictionNodeLimitError error, got a different error instead: %v", err)
 	}
 }
+
+func TestEvictPodEventProfileLabels(t *testing.T) {
+	tests := []struct {
+		description   string
+		opts          EvictOptions
+		expectedEvent string
+	}{
+		{
+			description:   "profile without labels",
+			opts:          EvictOptions{ProfileName: "default", StrategyName: "PodLifeTime"},
+			expectedEvent: "Normal PodLifeTime pod evicted from node node by sigs.k8s.io/descheduler",
+		},
+		{
+			description:   "profile with labels",
+			opts:          EvictOptions{ProfileName: "team-a", StrategyName: "PodLifeTime", ProfileLabels: map[string]string{"team": "a", "cost-center": "42"}},
+			expectedEvent: "Normal PodLifeTime pod evicted from node node by sigs.k8s.io/descheduler (profile team-a, cost-center=42,team=a)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod1 := test.BuildTestPod("pod", 400, 0, "node", nil)
+			eventRecorder := events.NewFakeRecorder(1)
+			podEvictor := NewPodEvictor(fake.NewSimpleClientset(pod1), eventRecorder, nil)
+
+			if err := podEvictor.EvictPod(context.TODO(), pod1, tc.opts); err != nil {
+				t.Fatalf("Unexpected eviction error: %v", err)
+			}
+			if event := <-eventRecorder.Events; event != tc.expectedEvent {
+				t.Errorf("Expected event %q, got %q", tc.expectedEvent, event)
+			}
+		})
+	}
+}
+
+func TestEvictPodAlreadyProcessed(t *testing.T) {
+	pod1 := test.BuildTestPod("pod1", 400, 0, "node", nil)
+	pod2 := test.BuildTestPod("pod2", 400, 0, "node", nil)
+	pod3 := test.BuildTestPod("pod3", 400, 0, "node", nil)
+
+	// pod3 is not known to the client so its eviction fails with a not found error
+	fakeClient := fake.NewSimpleClientset(pod1, pod2)
+	podEvictor := NewPodEvictor(fakeClient, &events.FakeRecorder{}, nil)
+
+	if err := podEvictor.EvictPod(context.TODO(), pod1, EvictOptions{}); err != nil {
+		t.Fatalf("Expected a pod eviction, got an eviction error instead: %v", err)
+	}
+	if err := podEvictor.EvictPod(context.TODO(), pod3, EvictOptions{}); err == nil {
+		t.Fatalf("Expected a pod eviction error, got nil instead")
+	}
+
+	processed := podEvictor.ProcessedPods()
+	if !processed.Has(pod1) || processed.Has(pod2) || !processed.Has(pod3) {
+		t.Errorf("Expected pod1 and pod3 to be processed, got %v, %v and %v", processed.Has(pod1), processed.Has(pod2), processed.Has(pod3))
+	}
+
+	err := podEvictor.EvictPod(context.TODO(), pod1, EvictOptions{})
+	switch err.(type) {
+	case *EvictionAlreadyProcessedError:
+		// all good
+	default:
+		t.Errorf("Expected a pod eviction EvictionAlreadyProcessedError error, got a different error instead: %v", err)
+	}
+	if evictions := podEvictor.TotalEvicted(); evictions != 1 {
+		t.Errorf("Expected 1 total eviction, got %d instead", evictions)
+	}
+
+	// A pod with the same name but a different UID is a different pod
+	recreated := pod1.DeepCopy()
+	recreated.UID = "recreated"
+	if processed.Has(recreated) {
+		t.Errorf("Expected the recreated pod not to be processed")
+	}
+
+	podEvictor.ResetCounters()
+	if processed.Len() != 0 {
+		t.Errorf("Expected no processed pod after the counters reset, got %d", processed.Len())
+	}
+	if err := podEvictor.EvictPod(context.TODO(), pod2, EvictOptions{}); err != nil {
+		t.Errorf("Expected a pod eviction, got an eviction error instead: %v", err)
+	}
+}
+
+func TestEvictPodAlreadyProcessedBeforeLimits(t *testing.T) {
+	pod1 := test.BuildTestPod("pod1", 400, 0, "node", nil)
+
+	fakeClient := fake.NewSimpleClientset(pod1)
+	podEvictor := NewPodEvictor(
+		fakeClient,
+		&events.FakeRecorder{},
+		NewOptions().
+			WithMaxPodsToEvictTotal(utilptr.To[uint](1)).
+			WithMaxPodsToEvictPerNode(utilptr.To[uint](1)).
+			WithMaxPodsToEvictPerNamespace(utilptr.To[uint](1)),
+	)
+
+	if err := podEvictor.EvictPod(context.TODO(), pod1, EvictOptions{}); err != nil {
+		t.Fatalf("Expected a pod eviction, got an eviction error instead: %v", err)
+	}
+	// All the limits are reached, the pod is still reported as processed rather than rejected by a limit
+	err := podEvictor.EvictPod(context.TODO(), pod1, EvictOptions{})
+	if _, ok := err.(*EvictionAlreadyProcessedError); !ok {
+		t.Errorf("Expected a pod eviction EvictionAlreadyProcessedError error, got a different error instead: %v", err)
+	}
+}
+
+func TestEvictPodBudgetMetrics(t *testing.T) {
+	metrics.Register()
+	pod1 := test.BuildTestPod("pod1", 400, 0, "node1", nil)
+	pod2 := test.BuildTestPod("pod2", 400, 0, "node1", nil)
+	pod3 := test.BuildTestPod("pod3", 400, 0, "node2", nil)
+
+	fakeClient := fake.NewSimpleClientset(pod1, pod2, pod3)
+	podEvictor := NewPodEvictor(
+		fakeClient,
+		&events.FakeRecorder{},
+		NewOptions().
+			WithMetricsEnabled(true).
+			WithMaxPodsToEvictTotal(utilptr.To[uint](3)).
+			WithMaxPodsToEvictPerNode(utilptr.To[uint](1)).
+			WithMaxPodsToEvictPerNamespace(utilptr.To[uint](5)),
+	)
+	podEvictor.ResetCounters()
+
+	expectGauge := func(gauge k8smetrics.GaugeMetric, expected float64) {
+		t.Helper()
+		value, err := testutil.GetGaugeMetricValue(gauge)
+		if err != nil {
+			t.Fatalf("Unable to read the gauge: %v", err)
+		}
+		if value != expected {
+			t.Errorf("Expected a remaining budget of %v, got %v instead", expected, value)
+		}
+	}
+	expectGauge(metrics.EvictionBudgetRemainingTotal, 3)
+
+	rejected := metrics.EvictionsRejectedByLimit.With(map[string]string{"limit": "node", "strategy": "test", "profile": ""})
+	rejectedBefore, err := testutil.GetCounterMetricValue(rejected)
+	if err != nil {
+		t.Fatalf("Unable to read the counter: %v", err)
+	}
+
+	for _, pod := range []*v1.Pod{pod1, pod2, pod3} {
+		_ = podEvictor.EvictPod(context.TODO(), pod, EvictOptions{StrategyName: "test"})
+	}
+
+	expectGauge(metrics.EvictionBudgetRemainingTotal, 1)
+	expectGauge(metrics.EvictionBudgetRemainingPerNode.With(map[string]string{"node": "node1"}), 0)
+	expectGauge(metrics.EvictionBudgetRemainingPerNode.With(map[string]string{"node": "node2"}), 0)
+	expectGauge(metrics.EvictionBudgetRemainingPerNamespace.With(map[string]string{"namespace": "default"}), 3)
+
+	rejectedAfter, err := testutil.GetCounterMetricValue(rejected)
+	if err != nil {
+		t.Fatalf("Unable to read the counter: %v", err)
+	}
+	if rejectedAfter-rejectedBefore != 1 {
+		t.Errorf("Expected 1 eviction rejected by the node limit, got %v instead", rejectedAfter-rejectedBefore)
+	}
+
+	podEvictor.ResetCounters()
+	expectGauge(metrics.EvictionBudgetRemainingTotal, 3)
+}
+
+func TestEvictPodUrgent(t *testing.T) {
+	pod1 := test.BuildTestPod("pod1", 400, 0, "node", nil)
+	pod2 := test.BuildTestPod("pod2", 400, 0, "node", nil)
+	pod3 := test.BuildTestPod("pod3", 400, 0, "node", nil)
+
+	fakeClient := fake.NewSimpleClientset(pod1, pod2, pod3)
+	podEvictor := NewPodEvictor(
+		fakeClient,
+		&events.FakeRecorder{},
+		NewOptions().
+			WithMaxPodsToEvictPerNode(utilptr.To[uint](1)).
+			WithMaxPodsToEvictTotal(utilptr.To[uint](2)),
+	)
+
+	// Urgent evictions are not limited per node
+	for _, pod := range []*v1.Pod{pod1, pod2} {
+		if err := podEvictor.EvictPod(context.TODO(), pod, EvictOptions{Urgent: true}); err != nil {
+			t.Errorf("Expected a pod eviction, got an eviction error instead: %v", err)
+		}
+	}
+
+	err := podEvictor.EvictPod(context.TODO(), pod3, EvictOptions{Urgent: true})
+	switch err.(type) {
+	case *EvictionTotalLimitError:
+		// all good
+	default:
+		t.Errorf("Expected a pod eviction EvictionTotalLimitError error, got a different error instead: %v", err)
+	}
+}
+
+func TestEvictPodServerSideDryRun(t *testing.T) {
+	pod1 := test.BuildTestPod("pod1", 400, 0, "node", nil)
+	pod2 := test.BuildTestPod("pod2", 400, 0, "node", nil)
+
+	// pod2 is protected by a PodDisruptionBudget the server enforces even in dry run
+	serverClient := fake.NewSimpleClientset(pod1, pod2)
+	var dryRuns [][]string
+	serverClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(core.CreateAction).GetObject().(*policy.Eviction)
+		dryRuns = append(dryRuns, eviction.DeleteOptions.DryRun)
+		if eviction.Name == pod2.Name {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+		}
+		// the server only validates the eviction
+		return true, nil, nil
+	})
+
+	cachedClient := fake.NewSimpleClientset(pod1, pod2)
+	podEvictor := NewPodEvictor(
+		cachedClient,
+		&events.FakeRecorder{},
+		NewOptions().
+			WithDryRun(true).
+			WithServerSideDryRun(serverClient),
+	)
+
+	if err := podEvictor.EvictPod(context.TODO(), pod1, EvictOptions{}); err != nil {
+		t.Errorf("Expected a pod eviction, got an eviction error instead: %v", err)
+	}
+	if err := podEvictor.EvictPod(context.TODO(), pod2, EvictOptions{}); err == nil {
+		t.Errorf("Expected the pod eviction to be refused by the server")
+	}
+	if evictions := podEvictor.TotalEvicted(); evictions != 1 {
+		t.Errorf("Expected 1 total eviction, got %d instead", evictions)
+	}
+
+	for _, dryRun := range dryRuns {
+		if len(dryRun) != 1 || dryRun[0] != metav1.DryRunAll {
+			t.Errorf("Expected the evictions to be requested with dryRun=All, got %v instead", dryRun)
+		}
+	}
+	if len(dryRuns) != 2 {
+		t.Errorf("Expected 2 evictions requested from the server, got %d instead", len(dryRuns))
+	}
+	if _, err := cachedClient.CoreV1().Pods(pod2.Namespace).Get(context.TODO(), pod2.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the pod refused by the server to be kept in the cache: %v", err)
+	}
+}
+
+func TestEvictPods(t *testing.T) {
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 100, 0, "n1", nil),
+		test.BuildTestPod("p2", 100, 0, "n1", nil),
+		test.BuildTestPod("p3", 100, 0, "n1", nil),
+	}
+	tests := []struct {
+		description       string
+		errors            map[string]error
+		expectedEvictions []string
+		expectedContinue  bool
+	}{
+		{
+			description:       "all the pods evicted",
+			expectedEvictions: []string{"p1", "p2", "p3"},
+			expectedContinue:  true,
+		},
+		{
+			description:       "failed eviction skipped",
+			errors:            map[string]error{"p1": fmt.Errorf("eviction failed")},
+			expectedEvictions: []string{"p1", "p2", "p3"},
+			expectedContinue:  true,
+		},
+		{
+			description:       "node limit reached",
+			errors:            map[string]error{"p2": NewEvictionNodeLimitError("n1")},
+			expectedEvictions: []string{"p1", "p2"},
+			expectedContinue:  true,
+		},
+		{
+			description:       "total limit reached",
+			errors:            map[string]error{"p2": NewEvictionTotalLimitError()},
+			expectedEvictions: []string{"p1", "p2"},
+			expectedContinue:  false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var evicted []string
+			evict := func(_ context.Context, pod *v1.Pod, opts EvictOptions) error {
+				if opts.StrategyName != "Strategy" {
+					t.Errorf("Unexpected eviction options %+v", opts)
+				}
+				evicted = append(evicted, pod.Name)
+				return tc.errors[pod.Name]
+			}
+			if cont := EvictPods(context.Background(), evict, pods, EvictOptions{StrategyName: "Strategy"}); cont != tc.expectedContinue {
+				t.Errorf("Expected %v to be returned, got %v", tc.expectedContinue, cont)
+			}
+			if !reflect.DeepEqual(evicted, tc.expectedEvictions) {
+				t.Errorf("Expected evictions of %v, got %v", tc.expectedEvictions, evicted)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filters provides the checks the DefaultEvictor is built from as composable
+// constraints, so plugins and embedders can assemble their own eviction filters.
+package filters
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// Constraint checks whether a pod can be evicted. It returns an error describing
+// why the pod must not be evicted, nil otherwise.
+type Constraint func(pod *v1.Pod) error
+
+// Check runs all the constraints against the pod and aggregates their errors
+func Check(pod *v1.Pod, constraints ...Constraint) error {
+	var errs []error
+	for _, c := range constraints {
+		if err := c(pod); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// AsFilterFunc returns a filter function accepting the pods all the constraints are satisfied for
+func AsFilterFunc(constraints ...Constraint) podutil.FilterFunc {
+	return func(pod *v1.Pod) bool {
+		return Check(pod, constraints...) == nil
+	}
+}
+
+// MirrorPod rejects mirror pods
+func MirrorPod() Constraint {
+	return func(pod *v1.Pod) error {
+		if utils.IsMirrorPod(pod) {
+			return fmt.Errorf("pod is a mirror pod")
+		}
+		return nil
+	}
+}
+
+// StaticPod rejects static pods
+func StaticPod() Constraint {
+	return func(pod *v1.Pod) error {
+		if utils.IsStaticPod(pod) {
+			return fmt.Errorf("pod is a static pod")
+		}
+		return nil
+	}
+}
+
+// TerminatingPod rejects pods being deleted
+func TerminatingPod() Constraint {
+	return func(pod *v1.Pod) error {
+		if utils.IsPodTerminating(pod) {
+			return fmt.Errorf("pod is terminating")
+		}
+		return nil
+	}
+}
+
+// OwnerRefs rejects pods without owner references. When evictFailedBarePods is set,
+// bare pods in the failed phase are accepted.
+func OwnerRefs(evictFailedBarePods bool) Constraint {
+	return func(pod *v1.Pod) error {
+		if len(podutil.OwnerRef(pod)) > 0 {
+			return nil
+		}
+		if !evictFailedBarePods {
+			return fmt.Errorf("pod does not have any ownerRefs")
+		}
+		if pod.Status.Phase != v1.PodFailed {
+			return fmt.Errorf("pod does not have any ownerRefs and is not in failed phase")
+		}
+		return nil
+	}
+}
+
+// SystemCriticalPod rejects pods with a system critical priority
+func SystemCriticalPod() Constraint {
+	return func(pod *v1.Pod) error {
+		if utils.IsCriticalPriorityPod(pod) {
+			return fmt.Errorf("pod has system critical priority")
+		}
+		return nil
+	}
+}
+
+// IsPodEvictableBasedOnPriority checks if the given pod is evictable based on priority resolved from pod Spec.
+func IsPodEvictableBasedOnPriority(pod *v1.Pod, priority int32) bool {
+	return pod.Spec.Priority == nil || *pod.Spec.Priority < priority
+}
+
+// PriorityThreshold rejects pods with a priority not lower than the threshold.
+// Pods without a priority are accepted.
+func PriorityThreshold(priority int32) Constraint {
+	return func(pod *v1.Pod) error {
+		if IsPodEvictableBasedOnPriority(pod, priority) {
+			return nil
+		}
+		return fmt.Errorf("pod has higher priority than specified priority class threshold")
+	}
+}
+
+// LocalStoragePod rejects pods using local storage
+func LocalStoragePod() Constraint {
+	return func(pod *v1.Pod) error {
+		if utils.IsPodWithLocalStorage(pod) {
+			return fmt.Errorf("pod has local storage and descheduler is not configured with evictLocalStoragePods")
+		}
+		return nil
+	}
+}
+
+// DaemonSetPod rejects pods owned by a DaemonSet
+func DaemonSetPod() Constraint {
+	return func(pod *v1.Pod) error {
+		if utils.IsDaemonsetPod(podutil.OwnerRef(pod)) {
+			return fmt.Errorf("pod is related to daemonset and descheduler is not configured with evictDaemonSetPods")
+		}
+		return nil
+	}
+}
+
+// PVCPod rejects pods mounting a persistent volume claim
+func PVCPod() Constraint {
+	return func(pod *v1.Pod) error {
+		if utils.IsPodWithPVC(pod) {
+			return fmt.Errorf("pod has a PVC and descheduler is configured to ignore PVC pods")
+		}
+		return nil
+	}
+}
+
+// LabelSelector rejects pods whose labels do not match the selector
+func LabelSelector(selector labels.Selector) Constraint {
+	return func(pod *v1.Pod) error {
+		if !selector.Matches(labels.Set(pod.Labels)) {
+			return fmt.Errorf("pod labels do not match the labelSelector filter in the policy parameter")
+		}
+		return nil
+	}
+}
+
+// MinPodAge rejects pods started less than minAge ago, or not started yet
+func MinPodAge(minAge time.Duration) Constraint {
+	return func(pod *v1.Pod) error {
+		if pod.Status.StartTime == nil || time.Since(pod.Status.StartTime.Time) < minAge {
+			return fmt.Errorf("pod age is not older than MinPodAge: %s", minAge)
+		}
+		return nil
+	}
+}
+
+// NodesFunc lists the nodes a pod may be rescheduled to
+type NodesFunc func() ([]*v1.Node, error)
+
+// PendingPodsFunc lists the pending pods the scheduler places before the given pod once it is evicted
+type PendingPodsFunc func(pod *v1.Pod) ([]*v1.Pod, error)
+
+// PendingPodsFromLister lists the pending pods with the same or a higher priority than the given pod
+func PendingPodsFromLister(podLister listersv1.PodLister) PendingPodsFunc {
+	return func(pod *v1.Pod) ([]*v1.Pod, error) {
+		pods, err := podLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		priority := corev1helpers.PodPriority(pod)
+		var pendingPods []*v1.Pod
+		for _, p := range pods {
+			if nodeutil.IsPendingPod(p) && corev1helpers.PodPriority(p) >= priority {
+				pendingPods = append(pendingPods, p)
+			}
+		}
+		return pendingPods, nil
+	}
+}
+
+// NodeFit rejects pods which do not fit on any other node than their own because of
+// node selectors, taints, unschedulable nodes or insufficient resources. When pendingPods
+// is set, the resources requested by the pending pods are reserved on the nodes they
+// would be scheduled to first.
+func NodeFit(nodes NodesFunc, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, pendingPods PendingPodsFunc) Constraint {
+	return func(pod *v1.Pod) error {
+		readyNodes, err := nodes()
+		if err != nil {
+			return fmt.Errorf("unable to list ready nodes: %v", err)
+		}
+		podsAssignedToNode := getPodsAssignedToNode
+		if pendingPods != nil {
+			pending, err := pendingPods(pod)
+			if err != nil {
+				return fmt.Errorf("unable to list pending pods: %v", err)
+			}
+			podsAssignedToNode = nodeutil.WithPendingPods(getPodsAssignedToNode, pending, readyNodes)
+		}
+		if !nodeutil.PodFitsAnyOtherNode(podsAssignedToNode, pod, readyNodes) {
+			return fmt.Errorf("pod does not fit on any other node because of nodeSelector(s), Taint(s), or nodes marked as unschedulable")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilptr "k8s.io/utils/ptr"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/utils"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestConstraints(t *testing.T) {
+	tests := []struct {
+		description string
+		constraint  Constraint
+		pod         *v1.Pod
+		expectError bool
+	}{
+		{
+			description: "mirror pod is rejected",
+			constraint:  MirrorPod(),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				pod.Annotations = test.GetMirrorPodAnnotation()
+			}),
+			expectError: true,
+		},
+		{
+			description: "static pod is rejected",
+			constraint:  StaticPod(),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				pod.Annotations = map[string]string{"kubernetes.io/config.source": "file"}
+			}),
+			expectError: true,
+		},
+		{
+			description: "terminating pod is rejected",
+			constraint:  TerminatingPod(),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				pod.DeletionTimestamp = &metav1.Time{}
+			}),
+			expectError: true,
+		},
+		{
+			description: "bare pod is rejected",
+			constraint:  OwnerRefs(false),
+			pod:         test.BuildTestPod("p", 100, 0, "n1", nil),
+			expectError: true,
+		},
+		{
+			description: "failed bare pod is accepted with evictFailedBarePods",
+			constraint:  OwnerRefs(true),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				pod.Status.Phase = v1.PodFailed
+			}),
+		},
+		{
+			description: "running bare pod is rejected with evictFailedBarePods",
+			constraint:  OwnerRefs(true),
+			pod:         test.BuildTestPod("p", 100, 0, "n1", nil),
+			expectError: true,
+		},
+		{
+			description: "system critical pod is rejected",
+			constraint:  SystemCriticalPod(),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				pod.Spec.Priority = utilptr.To[int32](utils.SystemCriticalPriority)
+			}),
+			expectError: true,
+		},
+		{
+			description: "pod below the priority threshold is accepted",
+			constraint:  PriorityThreshold(100),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				test.SetPodPriority(pod, 99)
+			}),
+		},
+		{
+			description: "pod at the priority threshold is rejected",
+			constraint:  PriorityThreshold(100),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				test.SetPodPriority(pod, 100)
+			}),
+			expectError: true,
+		},
+		{
+			description: "pod with local storage is rejected",
+			constraint:  LocalStoragePod(),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				pod.Spec.Volumes = []v1.Volume{{Name: "v", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "DaemonSet pod is rejected",
+			constraint:  DaemonSetPod(),
+			pod:         test.BuildTestPod("p", 100, 0, "n1", test.SetDSOwnerRef),
+			expectError: true,
+		},
+		{
+			description: "ReplicaSet pod is accepted by the DaemonSet constraint",
+			constraint:  DaemonSetPod(),
+			pod:         test.BuildTestPod("p", 100, 0, "n1", test.SetRSOwnerRef),
+		},
+		{
+			description: "pod with a PVC is rejected",
+			constraint:  PVCPod(),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				pod.Spec.Volumes = []v1.Volume{{Name: "v", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"}}}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "pod not matching the label selector is rejected",
+			constraint:  LabelSelector(labels.SelectorFromSet(labels.Set{"app": "web"})),
+			pod:         test.BuildTestPod("p", 100, 0, "n1", nil),
+			expectError: true,
+		},
+		{
+			description: "young pod is rejected",
+			constraint:  MinPodAge(time.Hour),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				pod.Status.StartTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+			}),
+			expectError: true,
+		},
+		{
+			description: "old pod is accepted",
+			constraint:  MinPodAge(time.Minute),
+			pod: test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				pod.Status.StartTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+			}),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.constraint(tc.pod)
+			if hasError := err != nil; hasError != tc.expectError {
+				t.Errorf("expected error: %v, got: %v", tc.expectError, err)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	pod := test.BuildTestPod("p", 100, 0, "n1", test.SetDSOwnerRef)
+	if err := Check(pod, OwnerRefs(false), PVCPod()); err != nil {
+		t.Errorf("expected the pod to pass the checks, got: %v", err)
+	}
+	if err := Check(pod, OwnerRefs(false), DaemonSetPod(), MinPodAge(time.Hour)); err == nil {
+		t.Errorf("expected the pod to fail the checks")
+	}
+	if AsFilterFunc(DaemonSetPod())(pod) {
+		t.Errorf("expected the filter to reject the pod")
+	}
+}
+
+func TestNodeFit(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 1000, 2000, 10, nil)
+	node2 := test.BuildTestNode("n2", 1000, 2000, 10, nil)
+	pod := test.BuildTestPod("p", 600, 0, "n1", test.SetRSOwnerRef)
+	pending := test.BuildTestPod("pending", 600, 0, "", func(pod *v1.Pod) {
+		pod.Status.Phase = v1.PodPending
+	})
+
+	getPodsAssignedToNode := func(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+		if nodeName == node1.Name && (filter == nil || filter(pod)) {
+			return []*v1.Pod{pod}, nil
+		}
+		return nil, nil
+	}
+	nodes := func() ([]*v1.Node, error) {
+		return []*v1.Node{node1, node2}, nil
+	}
+
+	tests := []struct {
+		description string
+		nodes       NodesFunc
+		pendingPods PendingPodsFunc
+		expectError bool
+	}{
+		{
+			description: "pod fits on another node",
+			nodes:       nodes,
+		},
+		{
+			description: "no other node",
+			nodes: func() ([]*v1.Node, error) {
+				return []*v1.Node{node1}, nil
+			},
+			expectError: true,
+		},
+		{
+			description: "other node taken by a pending pod",
+			nodes:       nodes,
+			pendingPods: func(*v1.Pod) ([]*v1.Pod, error) {
+				return []*v1.Pod{pending}, nil
+			},
+			expectError: true,
+		},
+		{
+			description: "nodes can not be listed",
+			nodes: func() ([]*v1.Node, error) {
+				return nil, fmt.Errorf("no nodes")
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := NodeFit(tc.nodes, getPodsAssignedToNode, tc.pendingPods)(pod)
+			if hasError := err != nil; hasError != tc.expectError {
+				t.Errorf("expected error: %v, got: %v", tc.expectError, err)
+			}
+		})
+	}
+}
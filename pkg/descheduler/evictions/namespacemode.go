@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// NamespaceModeAnnotationKey is the annotation namespace owners set to opt their namespace out
+// of the descheduling, or into a dry run, without editing the policy
+const NamespaceModeAnnotationKey = "descheduler.io/mode"
+
+// NamespaceMode tells how the pods of a namespace are evicted
+type NamespaceMode string
+
+const (
+	// NamespaceModeEnforce evicts the pods of the namespace, it is the mode of the namespaces without the annotation
+	NamespaceModeEnforce NamespaceMode = "enforce"
+	// NamespaceModeDryRun only logs the evictions of the pods of the namespace
+	NamespaceModeDryRun NamespaceMode = "dryrun"
+	// NamespaceModeDisabled refuses the evictions of the pods of the namespace
+	NamespaceModeDisabled NamespaceMode = "disabled"
+)
+
+// namespaceModes caches the modes of the namespaces annotated with a mode other than
+// enforce, kept up to date by the namespace informer
+type namespaceModes struct {
+	mu    sync.RWMutex
+	modes map[string]NamespaceMode
+}
+
+func newNamespaceModes(namespaceInformer cache.SharedIndexInformer) *namespaceModes {
+	m := &namespaceModes{modes: map[string]NamespaceMode{}}
+	// Only a stopped informer refuses new handlers
+	if _, err := namespaceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: m.set,
+		UpdateFunc: func(_, newObj interface{}) {
+			m.set(newObj)
+		},
+		DeleteFunc: m.delete,
+	}); err != nil {
+		klog.ErrorS(err, "Unable to watch the descheduling modes of the namespaces")
+	}
+	return m
+}
+
+func (m *namespaceModes) set(obj interface{}) {
+	namespace, ok := obj.(*v1.Namespace)
+	if !ok {
+		return
+	}
+	mode := NamespaceMode(namespace.Annotations[NamespaceModeAnnotationKey])
+	switch mode {
+	case NamespaceModeDryRun, NamespaceModeDisabled:
+	case "", NamespaceModeEnforce:
+		mode = NamespaceModeEnforce
+	default:
+		klog.ErrorS(nil, "Ignoring the invalid descheduling mode of the namespace", "namespace", namespace.Name, "mode", mode)
+		mode = NamespaceModeEnforce
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if mode == NamespaceModeEnforce {
+		delete(m.modes, namespace.Name)
+		return
+	}
+	m.modes[namespace.Name] = mode
+}
+
+func (m *namespaceModes) delete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	namespace, ok := obj.(*v1.Namespace)
+	if !ok {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.modes, namespace.Name)
+}
+
+// mode returns the mode of the namespace, enforce unless annotated otherwise
+func (m *namespaceModes) mode(namespace string) NamespaceMode {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if mode, ok := m.modes[namespace]; ok {
+		return mode
+	}
+	return NamespaceModeEnforce
+}
+
+// NamespaceMode returns the mode of the namespace as set by its annotation, enforce when
+// the namespace modes are not watched
+func (pe *PodEvictor) NamespaceMode(namespace string) NamespaceMode {
+	if pe.namespaceModes == nil {
+		return NamespaceModeEnforce
+	}
+	return pe.namespaceModes.mode(namespace)
+}
+
+// evictInDryRunNamespace only logs the eviction of a pod of a namespace in dry run mode. The
+// simulated evictions do not count towards the limits, so they do not hold the actual ones
+// back. The caller is expected to hold pe.mu.
+func (pe *PodEvictor) evictInDryRunNamespace(ctx context.Context, pod *v1.Pod, opts EvictOptions) error {
+	logger := klog.FromContext(ctx)
+	if pe.processedPods.Has(pod) {
+		return NewEvictionAlreadyProcessedError()
+	}
+	pe.processedPods.insert(pod)
+	logger.V(1).Info("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName, "profileLabels", opts.ProfileLabels, "namespaceMode", NamespaceModeDryRun)
+	return nil
+}
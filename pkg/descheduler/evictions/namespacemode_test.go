@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildTestNamespace(name, mode string) *v1.Namespace {
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if mode != "" {
+		namespace.Annotations = map[string]string{NamespaceModeAnnotationKey: mode}
+	}
+	return namespace
+}
+
+func TestNamespaceModes(t *testing.T) {
+	tests := []struct {
+		description     string
+		mode            string
+		dryRun          bool
+		expectedEvicted []string
+		expectedTotal   uint
+		expectedErr     bool
+	}{
+		{
+			description:     "namespace without annotation",
+			expectedEvicted: []string{"p1"},
+			expectedTotal:   1,
+		},
+		{
+			description:     "namespace in enforce mode",
+			mode:            "enforce",
+			expectedEvicted: []string{"p1"},
+			expectedTotal:   1,
+		},
+		{
+			description:     "invalid mode is ignored",
+			mode:            "off",
+			expectedEvicted: []string{"p1"},
+			expectedTotal:   1,
+		},
+		{
+			description: "namespace in dryrun mode",
+			mode:        "dryrun",
+		},
+		{
+			description:     "namespace in dryrun mode with the descheduler in dry run mode",
+			mode:            "dryrun",
+			dryRun:          true,
+			expectedEvicted: []string{"p1"},
+			expectedTotal:   1,
+		},
+		{
+			description: "namespace in disabled mode",
+			mode:        "disabled",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var evicted []string
+			client := fake.NewSimpleClientset(buildTestNamespace("ns", tc.mode))
+			client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				evicted = append(evicted, action.(core.CreateAction).GetObject().(*policy.Eviction).Name)
+				return true, nil, nil
+			})
+			sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+			podEvictor := NewPodEvictor(client, &events.FakeRecorder{}, NewOptions().
+				WithDryRun(tc.dryRun).
+				WithNamespaceModes(sharedInformerFactory.Core().V1().Namespaces().Informer()))
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			pod := test.BuildTestPod("p1", 100, 0, "n1", nil)
+			pod.Namespace = "ns"
+			err := podEvictor.EvictPod(ctx, pod, EvictOptions{})
+			if _, ok := err.(*EvictionNamespaceDisabledError); ok != tc.expectedErr || (err != nil && !ok) {
+				t.Fatalf("Unexpected error evicting the pod: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedEvicted, evicted); diff != "" {
+				t.Errorf("Unexpected evictions (-want,+got):\n%s", diff)
+			}
+			if total := podEvictor.TotalEvicted(); total != tc.expectedTotal {
+				t.Errorf("Expected %d total evictions, got %d", tc.expectedTotal, total)
+			}
+			if tc.mode == "dryrun" && !podEvictor.ProcessedPods().Has(pod) {
+				t.Errorf("Expected the pod to be processed")
+			}
+		})
+	}
+}
+
+func TestNamespaceModesUpdate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := fake.NewSimpleClientset(buildTestNamespace("ns", ""))
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	podEvictor := NewPodEvictor(client, &events.FakeRecorder{}, NewOptions().
+		WithNamespaceModes(sharedInformerFactory.Core().V1().Namespaces().Informer()))
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	waitForMode := func(expected NamespaceMode) {
+		t.Helper()
+		if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+			return podEvictor.NamespaceMode("ns") == expected, nil
+		}); err != nil {
+			t.Fatalf("Expected the namespace to be in %v mode, got %v", expected, podEvictor.NamespaceMode("ns"))
+		}
+	}
+
+	waitForMode(NamespaceModeEnforce)
+	if _, err := client.CoreV1().Namespaces().Update(ctx, buildTestNamespace("ns", "disabled"), metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unable to update the namespace: %v", err)
+	}
+	waitForMode(NamespaceModeDisabled)
+	if err := client.CoreV1().Namespaces().Delete(ctx, "ns", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Unable to delete the namespace: %v", err)
+	}
+	waitForMode(NamespaceModeEnforce)
+}
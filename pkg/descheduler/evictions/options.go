@@ -1,16 +1,34 @@
 package evictions
 
 import (
+	"time"
+
 	policy "k8s.io/api/policy/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/client-go/tools/cache"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 )
 
 type Options struct {
 	policyGroupVersion         string
 	dryRun                     bool
+	serverSideDryRunClient     clientset.Interface
 	maxPodsToEvictPerNode      *uint
 	maxPodsToEvictPerNamespace *uint
 	maxPodsToEvictTotal        *uint
 	metricsEnabled             bool
+	pacingPeriod               time.Duration
+	nodeDisruptionGuard        *nodeDisruptionGuard
+	evictionTimeout            time.Duration
+	throttle                   *evictionThrottle
+	disruptionBudgetPacer      *disruptionBudgetPacer
+	cycleAnomalyGuard          *cycleAnomalyGuard
+	namespaceModes             *namespaceModes
+	writeBatcher               *writeBatcher
+	evictionPreview            *evictionPreview
 }
 
 // NewOptions returns an Options with default values.
@@ -30,6 +48,14 @@ func (o *Options) WithDryRun(dryRun bool) *Options {
 	return o
 }
 
+// WithServerSideDryRun validates the evictions against the API server through the given client
+// with dryRun=All before simulating them, so admission webhooks and PodDisruptionBudgets are checked
+// without anything getting evicted. It only applies in dry run mode.
+func (o *Options) WithServerSideDryRun(client clientset.Interface) *Options {
+	o.serverSideDryRunClient = client
+	return o
+}
+
 func (o *Options) WithMaxPodsToEvictPerNode(maxPodsToEvictPerNode *uint) *Options {
 	o.maxPodsToEvictPerNode = maxPodsToEvictPerNode
 	return o
@@ -49,3 +75,72 @@ func (o *Options) WithMetricsEnabled(metricsEnabled bool) *Options {
 	o.metricsEnabled = metricsEnabled
 	return o
 }
+
+// WithPacingPeriod spreads the evictions evenly over the given period.
+// Evictions are queued and requested once Drain is called.
+func (o *Options) WithPacingPeriod(pacingPeriod time.Duration) *Options {
+	o.pacingPeriod = pacingPeriod
+	return o
+}
+
+// WithNodeDisruptionGuard prevents evictions from the nodes disrupted by something else
+// than the descheduler within the given window, e.g. kubelet evictions or drains.
+func (o *Options) WithNodeDisruptionGuard(nodeLister listersv1.NodeLister, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, window time.Duration) *Options {
+	o.nodeDisruptionGuard = newNodeDisruptionGuard(nodeLister, getPodsAssignedToNode, window)
+	return o
+}
+
+// WithEvictionTimeout bounds each request to the eviction API, zero leaves them unbounded.
+func (o *Options) WithEvictionTimeout(evictionTimeout time.Duration) *Options {
+	o.evictionTimeout = evictionTimeout
+	return o
+}
+
+// WithThrottling backs off the evictions once the given number of consecutive evictions got
+// throttled by the API server, waiting from initialBackoff up to maxBackoff between two evictions.
+func (o *Options) WithThrottling(throttledEvictions int, initialBackoff, maxBackoff time.Duration) *Options {
+	o.throttle = newEvictionThrottle(throttledEvictions, initialBackoff, maxBackoff)
+	return o
+}
+
+// WithDisruptionBudgetPacing holds back the evictions of the pods covered by a PodDisruptionBudget
+// allowing no more disruptions. They are retried by Drain every retryInterval, up to retries times.
+func (o *Options) WithDisruptionBudgetPacing(pdbLister policylisters.PodDisruptionBudgetLister, retryInterval time.Duration, retries int) *Options {
+	o.disruptionBudgetPacer = newDisruptionBudgetPacer(pdbLister, retryInterval, retries)
+	return o
+}
+
+// WithCycleAnomalyGuard aborts the descheduling cycle once its evictions would exceed the given
+// percentage of the pods of the cluster, as set by SetClusterPods. The queued and held back
+// evictions are dropped and the following evictions of the cycle are refused.
+func (o *Options) WithCycleAnomalyGuard(maxEvictionPercentage float64) *Options {
+	o.cycleAnomalyGuard = newCycleAnomalyGuard(maxEvictionPercentage)
+	return o
+}
+
+// WithNamespaceModes applies the modes namespace owners set through the descheduler.io/mode
+// annotation, as watched by the given namespace informer: the evictions of the pods of the
+// namespaces in dryrun mode are only logged and the ones of the namespaces in disabled mode
+// are refused.
+func (o *Options) WithNamespaceModes(namespaceInformer cache.SharedIndexInformer) *Options {
+	o.namespaceModes = newNamespaceModes(namespaceInformer)
+	return o
+}
+
+// WithWriteBatching coalesces the writes accompanying the evictions, i.e. their events and the
+// eviction conditions set on the pods whose eviction is paced or held back, and flushes them once
+// batchSize writes are pending or the oldest one has been pending for flushInterval. The pending
+// writes are flushed by Flush regardless.
+func (o *Options) WithWriteBatching(batchSize int, flushInterval time.Duration) *Options {
+	o.writeBatcher = newWriteBatcher(batchSize, flushInterval)
+	return o
+}
+
+// WithEvictionPreview annotates the pods whose eviction got simulated in dry run mode with the
+// strategy that would evict them, through the given client to the cluster. The annotation of the
+// pods listed by podLister whose eviction is no longer simulated is removed by
+// ReconcileEvictionPreview. It only applies in dry run mode.
+func (o *Options) WithEvictionPreview(client clientset.Interface, podLister listersv1.PodLister) *Options {
+	o.evictionPreview = newEvictionPreview(client, podLister)
+	return o
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// queuedEviction is an eviction accepted by the PodEvictor while pacing is enabled
+// which is requested later on by Drain.
+type queuedEviction struct {
+	pod  *v1.Pod
+	opts EvictOptions
+}
+
+// QueuedEvictions gives a number of evictions waiting to be requested by Drain
+func (pe *PodEvictor) QueuedEvictions() int {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	return len(pe.queue)
+}
+
+// Drain requests the queued evictions one by one, leaking them evenly over the
+// pacing period: n queued evictions get requested period/n apart. Once the context
+// is done, e.g. when the descheduler is shutting down, the remaining evictions are
+// requested right away so no eviction accepted by the PodEvictor is dropped.
+// The evictions held back by a PodDisruptionBudget are retried next.
+// Drain returns once the queue is empty and the held back evictions are settled.
+func (pe *PodEvictor) Drain(ctx context.Context) {
+	pe.drainQueue(ctx)
+	pe.retryHeldEvictions(ctx)
+}
+
+func (pe *PodEvictor) drainQueue(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	pe.mu.Lock()
+	queue := pe.queue
+	pe.queue = nil
+	pe.mu.Unlock()
+	if len(queue) == 0 {
+		return
+	}
+
+	interval := pe.pacingPeriod / time.Duration(len(queue))
+	logger.V(1).Info("Pacing evictions", "evictions", len(queue), "period", pe.pacingPeriod, "interval", interval)
+	// The evictions still have to be requested after the context is done
+	evictCtx := context.WithoutCancel(ctx)
+	for i, queued := range queue {
+		if i > 0 && ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+				logger.V(1).Info("Requesting the remaining queued evictions right away", "evictions", len(queue)-i)
+			case <-time.After(interval):
+			}
+		}
+		pe.mu.Lock()
+		if err := pe.evict(evictCtx, queued.pod, queued.opts); err != nil {
+			pe.decrementCounters(queued.pod)
+			pe.settleEvictionCondition(evictCtx, queued.pod, false)
+		}
+		pe.mu.Unlock()
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+type evictionRecorder struct {
+	mu        sync.Mutex
+	evictions []time.Time
+	failing   string
+}
+
+func (r *evictionRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.evictions)
+}
+
+func newPacedPodEvictor(recorder *evictionRecorder, options *Options) *PodEvictor {
+	fakeClient := fake.NewSimpleClientset()
+	fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		eviction := action.(core.CreateAction).GetObject().(*policy.Eviction)
+		if eviction.Name == recorder.failing {
+			return true, nil, fmt.Errorf("eviction of %v blocked", eviction.Name)
+		}
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		recorder.evictions = append(recorder.evictions, time.Now())
+		return true, nil, nil
+	})
+	return NewPodEvictor(fakeClient, &events.FakeRecorder{}, options)
+}
+
+func TestPacedEvictions(t *testing.T) {
+	var pods []*v1.Pod
+	for i := 0; i < 4; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("p%d", i), 100, 0, "n1", nil))
+	}
+
+	t.Run("evictions are queued and spread over the pacing period", func(t *testing.T) {
+		recorder := &evictionRecorder{}
+		podEvictor := newPacedPodEvictor(recorder, NewOptions().WithPacingPeriod(400*time.Millisecond))
+		for _, pod := range pods {
+			if err := podEvictor.EvictPod(context.Background(), pod, EvictOptions{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		if recorder.count() != 0 {
+			t.Fatalf("Expected no eviction before draining, got %v", recorder.count())
+		}
+		if podEvictor.QueuedEvictions() != len(pods) || podEvictor.TotalEvicted() != uint(len(pods)) {
+			t.Fatalf("Expected %v queued evictions, got %v queued and %v evicted", len(pods), podEvictor.QueuedEvictions(), podEvictor.TotalEvicted())
+		}
+
+		podEvictor.Drain(context.Background())
+
+		if recorder.count() != len(pods) {
+			t.Fatalf("Expected %v evictions, got %v", len(pods), recorder.count())
+		}
+		for i := 1; i < len(recorder.evictions); i++ {
+			if gap := recorder.evictions[i].Sub(recorder.evictions[i-1]); gap < 90*time.Millisecond {
+				t.Errorf("Expected evictions to be 100ms apart, got %v", gap)
+			}
+		}
+		if podEvictor.QueuedEvictions() != 0 {
+			t.Errorf("Expected the queue to be empty, got %v", podEvictor.QueuedEvictions())
+		}
+	})
+
+	t.Run("limits apply to queued evictions", func(t *testing.T) {
+		recorder := &evictionRecorder{}
+		podEvictor := newPacedPodEvictor(recorder, NewOptions().WithPacingPeriod(time.Millisecond).WithMaxPodsToEvictPerNode(utilptr.To[uint](2)))
+		for i, pod := range pods {
+			err := podEvictor.EvictPod(context.Background(), pod, EvictOptions{})
+			if _, ok := err.(*EvictionNodeLimitError); i >= 2 && !ok {
+				t.Errorf("Expected a node limit error for pod %v, got %v", pod.Name, err)
+			}
+		}
+		podEvictor.Drain(context.Background())
+		if recorder.count() != 2 {
+			t.Errorf("Expected 2 evictions, got %v", recorder.count())
+		}
+	})
+
+	t.Run("failed evictions are not counted", func(t *testing.T) {
+		recorder := &evictionRecorder{failing: pods[1].Name}
+		podEvictor := newPacedPodEvictor(recorder, NewOptions().WithPacingPeriod(time.Millisecond))
+		for _, pod := range pods {
+			if err := podEvictor.EvictPod(context.Background(), pod, EvictOptions{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		podEvictor.Drain(context.Background())
+		if podEvictor.TotalEvicted() != uint(len(pods)-1) {
+			t.Errorf("Expected %v evicted pods, got %v", len(pods)-1, podEvictor.TotalEvicted())
+		}
+	})
+
+	t.Run("queued evictions are drained at shutdown", func(t *testing.T) {
+		recorder := &evictionRecorder{}
+		podEvictor := newPacedPodEvictor(recorder, NewOptions().WithPacingPeriod(time.Hour))
+		for _, pod := range pods {
+			if err := podEvictor.EvictPod(context.Background(), pod, EvictOptions{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		done := make(chan struct{})
+		go func() {
+			podEvictor.Drain(ctx)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected the queue to be drained once the context is done")
+		}
+		if recorder.count() != len(pods) {
+			t.Errorf("Expected %v evictions, got %v", len(pods), recorder.count())
+		}
+	})
+}
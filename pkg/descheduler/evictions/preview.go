@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// WouldEvictAnnotationKey is the annotation set in dry run mode on the pods the descheduler would
+// evict, naming the strategy that would evict them
+const WouldEvictAnnotationKey = "descheduler.io/would-evict"
+
+// evictionPreview annotates the pods whose eviction got simulated in dry run mode. The writes
+// go to the cluster, not to the copy of the cluster state the dry run evicts the pods from.
+type evictionPreview struct {
+	client    clientset.Interface
+	podLister listersv1.PodLister
+	// previewed maps the pods whose eviction got simulated during the cycle to the strategy
+	previewed map[types.NamespacedName]string
+}
+
+func newEvictionPreview(client clientset.Interface, podLister listersv1.PodLister) *evictionPreview {
+	return &evictionPreview{
+		client:    client,
+		podLister: podLister,
+		previewed: map[types.NamespacedName]string{},
+	}
+}
+
+// reset forgets the pods whose eviction got simulated, at the start of a cycle
+func (p *evictionPreview) reset() {
+	p.previewed = map[types.NamespacedName]string{}
+}
+
+// previewEviction annotates the pod whose eviction got simulated with the strategy that would
+// evict it. The caller is expected to hold pe.mu.
+func (pe *PodEvictor) previewEviction(ctx context.Context, pod *v1.Pod, opts EvictOptions) {
+	p := pe.evictionPreview
+	if p == nil || !pe.dryRun {
+		return
+	}
+	key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	strategy := opts.StrategyName
+	if len(strategy) == 0 {
+		strategy = "NotSet"
+	}
+	p.previewed[key] = strategy
+	if pod.Annotations[WouldEvictAnnotationKey] == strategy {
+		return
+	}
+	if err := pe.patchWouldEvictAnnotation(ctx, key, &strategy); err != nil {
+		klog.FromContext(ctx).Error(err, "Unable to annotate the pod with its eviction preview", "pod", klog.KObj(pod))
+	}
+}
+
+// ReconcileEvictionPreview removes the annotation of the pods annotated by the previous cycles
+// whose eviction did not get simulated during the current one.
+func (pe *PodEvictor) ReconcileEvictionPreview(ctx context.Context) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	p := pe.evictionPreview
+	if p == nil {
+		return
+	}
+	logger := klog.FromContext(ctx)
+	pods, err := p.podLister.List(labels.Everything())
+	if err != nil {
+		logger.Error(err, "Unable to list the pods to clean up the eviction previews")
+		return
+	}
+	for _, pod := range pods {
+		if _, ok := pod.Annotations[WouldEvictAnnotationKey]; !ok {
+			continue
+		}
+		key := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+		if _, ok := p.previewed[key]; ok {
+			continue
+		}
+		if err := pe.patchWouldEvictAnnotation(ctx, key, nil); err != nil {
+			logger.Error(err, "Unable to remove the stale eviction preview of the pod", "pod", klog.KObj(pod))
+		}
+	}
+}
+
+// patchWouldEvictAnnotation sets the annotation of the pod to the given strategy, or removes it
+// when nil. The caller is expected to hold pe.mu.
+func (pe *PodEvictor) patchWouldEvictAnnotation(ctx context.Context, key types.NamespacedName, strategy *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{WouldEvictAnnotationKey: strategy},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = pe.evictionPreview.client.CoreV1().Pods(key.Namespace).Patch(ctx, key.Name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: fieldManager})
+	pe.countAPICall("pods", "patch")
+	if apierrors.IsNotFound(err) {
+		// The pod is gone already
+		return nil
+	}
+	return err
+}
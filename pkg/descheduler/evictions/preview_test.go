@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestEvictionPreview(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	buildPod := func(name, wouldEvict string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, "n1", func(pod *v1.Pod) {
+			if wouldEvict != "" {
+				pod.Annotations = map[string]string{WouldEvictAnnotationKey: wouldEvict}
+			}
+		})
+	}
+	// p1 is evicted for the first time, p2 is no longer evicted and p3 is evicted again
+	p1, p2, p3 := buildPod("p1", ""), buildPod("p2", "RemoveDuplicates"), buildPod("p3", "RemoveFailedPods")
+
+	clusterClient := fake.NewSimpleClientset(p1, p2, p3)
+	var patched []string
+	clusterClient.PrependReactor("patch", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		patched = append(patched, action.(core.PatchAction).GetName())
+		return false, nil, nil
+	})
+	sharedInformerFactory := informers.NewSharedInformerFactory(clusterClient, 0)
+	podLister := sharedInformerFactory.Core().V1().Pods().Lister()
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	// The dry run evicts the pods from a copy of the cluster state
+	dryRunClient := fake.NewSimpleClientset(p1, p2, p3)
+	podEvictor := NewPodEvictor(dryRunClient, &events.FakeRecorder{}, NewOptions().
+		WithDryRun(true).
+		WithEvictionPreview(clusterClient, podLister))
+
+	podEvictor.ResetCounters()
+	for _, pod := range []*v1.Pod{p1, p3} {
+		if err := podEvictor.EvictPod(ctx, pod, EvictOptions{StrategyName: "RemoveFailedPods"}); err != nil {
+			t.Fatalf("Unexpected error evicting the pod: %v", err)
+		}
+	}
+	podEvictor.ReconcileEvictionPreview(ctx)
+
+	if diff := cmp.Diff([]string{"p1", "p2"}, patched); diff != "" {
+		t.Errorf("Unexpected patched pods (-want,+got):\n%s", diff)
+	}
+	expected := map[string]string{"p1": "RemoveFailedPods", "p2": "", "p3": "RemoveFailedPods"}
+	for name, wouldEvict := range expected {
+		pod, err := clusterClient.CoreV1().Pods(p1.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Unable to get the pod: %v", err)
+		}
+		if got := pod.Annotations[WouldEvictAnnotationKey]; got != wouldEvict {
+			t.Errorf("Expected pod %s to be annotated with %q, got %q", name, wouldEvict, got)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// processedPodKey identifies a pod. The namespace and name are kept next to the UID
+// as pods built by hand (e.g. in tests) do not always have one.
+type processedPodKey struct {
+	namespace string
+	name      string
+	uid       types.UID
+}
+
+func processedPodKeyOf(pod *v1.Pod) processedPodKey {
+	return processedPodKey{namespace: pod.Namespace, name: pod.Name, uid: pod.UID}
+}
+
+// ProcessedPods is the set of pods evicted, or queued for a paced eviction, in the
+// current descheduling cycle. Plugins check it to skip the pods another plugin already
+// took care of instead of evaluating them again and failing to evict them.
+type ProcessedPods struct {
+	mu   sync.RWMutex
+	pods sets.Set[processedPodKey]
+}
+
+func newProcessedPods() *ProcessedPods {
+	return &ProcessedPods{pods: sets.New[processedPodKey]()}
+}
+
+// Has checks if the pod was already evicted, or queued for eviction, in the current cycle
+func (p *ProcessedPods) Has(pod *v1.Pod) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.pods.Has(processedPodKeyOf(pod))
+}
+
+// Len returns the number of pods processed in the current cycle
+func (p *ProcessedPods) Len() int {
+	if p == nil {
+		return 0
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.pods.Len()
+}
+
+func (p *ProcessedPods) insert(pod *v1.Pod) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pods.Insert(processedPodKeyOf(pod))
+}
+
+func (p *ProcessedPods) reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pods = sets.New[processedPodKey]()
+}
+
+// Clone returns a copy of the set, not updated by the evictions to come
+func (p *ProcessedPods) Clone() *ProcessedPods {
+	if p == nil {
+		return newProcessedPods()
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return &ProcessedPods{pods: p.pods.Clone()}
+}
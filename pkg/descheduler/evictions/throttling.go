@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/descheduler/metrics"
+)
+
+// evictionThrottle backs off the evictions while the API server keeps throttling them. Once
+// threshold consecutive evictions got throttled, every eviction waits for the backoff first.
+// The backoff doubles for every other throttled eviction, up to maxBackoff, and is reset by
+// the first eviction which is not throttled.
+type evictionThrottle struct {
+	threshold      int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	metricsEnabled bool
+
+	throttled int
+	backoff   time.Duration
+	// after is replaced in tests to not actually wait
+	after func(d time.Duration) <-chan time.Time
+}
+
+func newEvictionThrottle(threshold int, initialBackoff, maxBackoff time.Duration) *evictionThrottle {
+	return &evictionThrottle{
+		threshold:      threshold,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		after:          time.After,
+	}
+}
+
+// isThrottled tells whether the eviction got rejected by the API server rate limiting, e.g. API
+// priority and fairness. A PodDisruptionBudget refusing an eviction is reported with a 429 too.
+func isThrottled(err error) bool {
+	if !apierrors.IsTooManyRequests(err) {
+		return false
+	}
+	var statusErr apierrors.APIStatus
+	if errors.As(err, &statusErr) && statusErr.Status().Details != nil {
+		for _, cause := range statusErr.Status().Details.Causes {
+			if cause.Type == policy.DisruptionBudgetCause {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// wait blocks for the current backoff, if any, or until the context is done
+func (t *evictionThrottle) wait(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+	if t.backoff == 0 {
+		return
+	}
+	logger.V(2).Info("Backing off evictions throttled by the API server", "backoff", t.backoff)
+	select {
+	case <-ctx.Done():
+	case <-t.after(t.backoff):
+	}
+}
+
+// observe updates the backoff given the result of an eviction
+func (t *evictionThrottle) observe(logger klog.Logger, err error) {
+	if !isThrottled(err) {
+		if t.backoff > 0 {
+			logger.V(1).Info("Evictions are no longer throttled by the API server")
+		}
+		t.throttled = 0
+		t.setBackoff(0)
+		return
+	}
+	t.throttled++
+	if t.throttled < t.threshold {
+		return
+	}
+	backoff := t.initialBackoff
+	if t.backoff > 0 {
+		backoff = min(2*t.backoff, t.maxBackoff)
+	}
+	if t.backoff == 0 {
+		logger.Info("Evictions are throttled by the API server, backing off", "throttledEvictions", t.throttled, "backoff", backoff)
+	}
+	t.setBackoff(backoff)
+}
+
+func (t *evictionThrottle) setBackoff(backoff time.Duration) {
+	t.backoff = backoff
+	if t.metricsEnabled {
+		metrics.EvictionThrottlingBackoff.Set(backoff.Seconds())
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func disruptionBudgetError() error {
+	err := apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+	err.ErrStatus.Details.Causes = []metav1.StatusCause{{Type: policy.DisruptionBudgetCause, Message: "The disruption budget pdb needs 1 healthy pods and has 1 currently"}}
+	return err
+}
+
+func TestIsThrottled(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		expected    bool
+	}{
+		{
+			description: "no error",
+		},
+		{
+			description: "too many requests",
+			err:         apierrors.NewTooManyRequests("too many requests, please try again later", 1),
+			expected:    true,
+		},
+		{
+			description: "wrapped too many requests",
+			err:         fmt.Errorf("error when evicting pod (ignoring) %q: %w", "p1", apierrors.NewTooManyRequests("too many requests, please try again later", 1)),
+			expected:    true,
+		},
+		{
+			description: "eviction refused by a disruption budget",
+			err:         disruptionBudgetError(),
+		},
+		{
+			description: "internal error",
+			err:         apierrors.NewInternalError(fmt.Errorf("etcd unavailable")),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if throttled := isThrottled(tc.err); throttled != tc.expected {
+				t.Errorf("Expected throttled to be %v, got %v", tc.expected, throttled)
+			}
+		})
+	}
+}
+
+func TestEvictPodThrottled(t *testing.T) {
+	var pods []runtime.Object
+	for i := 0; i < 8; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("p%d", i), 100, 0, "node", nil))
+	}
+	// The API server throttles the evictions of p0 to p4, p5 is refused by a disruption budget
+	client := fake.NewSimpleClientset(pods...)
+	client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		switch name := action.(core.CreateAction).GetObject().(*policy.Eviction).Name; name {
+		case "p0", "p1", "p2", "p3", "p4":
+			return true, nil, apierrors.NewTooManyRequests("too many requests, please try again later", 1)
+		case "p5":
+			return true, nil, disruptionBudgetError()
+		}
+		return false, nil, nil
+	})
+
+	podEvictor := NewPodEvictor(
+		client,
+		&events.FakeRecorder{},
+		NewOptions().WithThrottling(2, time.Second, 3*time.Second),
+	)
+	var waits []time.Duration
+	podEvictor.throttle.after = func(d time.Duration) <-chan time.Time {
+		waits = append(waits, d)
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+
+	for _, pod := range pods {
+		podEvictor.EvictPod(context.TODO(), pod.(*v1.Pod), EvictOptions{})
+	}
+
+	// The evictions back off from the second throttled one on, until one is not throttled
+	expectedWaits := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+	if diff := cmp.Diff(expectedWaits, waits); diff != "" {
+		t.Errorf("Unexpected backoffs (-want,+got):\n%s", diff)
+	}
+	if evictions := podEvictor.TotalEvicted(); evictions != 2 {
+		t.Errorf("Expected 2 total evictions, got %d instead", evictions)
+	}
+}
@@ -79,6 +79,7 @@ func NewLeaderElection(
 		Callbacks: leaderelection.LeaderCallbacks{
 			OnStartedLeading: func(ctx context.Context) {
 				klog.V(1).InfoS("Started leading")
+				DefaultStatus.setLeading(true)
 				err := run()
 				if err != nil {
 					klog.Error(err)
@@ -86,12 +87,14 @@ func NewLeaderElection(
 			},
 			OnStoppedLeading: func() {
 				klog.V(1).InfoS("Leader lost")
+				DefaultStatus.setLeading(false)
 			},
 			OnNewLeader: func(identity string) {
 				// Just got the lock
 				if identity == id {
 					return
 				}
+				DefaultStatus.setLeading(false)
 				klog.V(1).Infof("New leader elected: %v", identity)
 			},
 		},
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/pager"
+)
+
+// ListOptions selects the nodes the descheduler operates over. The selectors
+// are applied by the API server so the nodes filtered out are neither sent
+// to the descheduler nor kept in its cache.
+type ListOptions struct {
+	// LabelSelector selects the nodes by label
+	LabelSelector string
+	// FieldSelector selects the nodes by field, e.g. spec.unschedulable=false
+	FieldSelector string
+	// PageSize lists the nodes in pages of the given size instead of all at once
+	// when positive, so the API server does not build the whole list in memory.
+	PageSize int64
+}
+
+// IsEmpty returns true when no option is set, i.e. all the nodes are listed at once
+func (o ListOptions) IsEmpty() bool {
+	return o.LabelSelector == "" && o.FieldSelector == "" && o.PageSize <= 0
+}
+
+func (o ListOptions) apply(options *metav1.ListOptions) {
+	options.LabelSelector = o.LabelSelector
+	options.FieldSelector = o.FieldSelector
+}
+
+// NewNodeInformer returns a function building a node informer which lists and watches
+// the nodes selected by the options only. It is meant to be registered through
+// SharedInformerFactory.InformerFor before any other consumer requests the node
+// informer so all of them share the filtered one.
+func NewNodeInformer(listOptions ListOptions) func(clientset.Interface, time.Duration) cache.SharedIndexInformer {
+	return func(client clientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+		return cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					listOptions.apply(&options)
+					if listOptions.PageSize > 0 {
+						// The watch cache serves lists at resource version 0 at once regardless of the limit,
+						// the pages get read from etcd instead. The reflector follows the continue tokens.
+						if options.ResourceVersion == "0" {
+							options.ResourceVersion = ""
+							options.ResourceVersionMatch = ""
+						}
+						options.Limit = listOptions.PageSize
+					}
+					return client.CoreV1().Nodes().List(context.TODO(), options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					listOptions.apply(&options)
+					return client.CoreV1().Nodes().Watch(context.TODO(), options)
+				},
+			},
+			&v1.Node{},
+			resyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		)
+	}
+}
+
+// listNodes lists the nodes selected by the options from the API server, page by page
+func listNodes(ctx context.Context, client clientset.Interface, listOptions ListOptions) ([]*v1.Node, error) {
+	options := metav1.ListOptions{}
+	listOptions.apply(&options)
+	p := pager.New(pager.SimplePageFunc(func(options metav1.ListOptions) (runtime.Object, error) {
+		return client.CoreV1().Nodes().List(ctx, options)
+	}))
+	// The whole list is requested at once without page size
+	p.PageSize = max(listOptions.PageSize, 0)
+
+	var nodes []*v1.Node
+	err := p.EachListItem(ctx, options, func(obj runtime.Object) error {
+		nodes = append(nodes, obj.(*v1.Node))
+		return nil
+	})
+	return nodes, err
+}
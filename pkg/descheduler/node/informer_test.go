@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestNewNodeInformer(t *testing.T) {
+	worker := func(node *v1.Node) {
+		node.Labels = map[string]string{"role": "worker"}
+	}
+	client := fake.NewSimpleClientset(
+		test.BuildTestNode("n1", 1000, 2000, 9, worker),
+		test.BuildTestNode("n2", 1000, 2000, 9, worker),
+		test.BuildTestNode("n3", 1000, 2000, 9, nil),
+	)
+	var fieldSelectors []string
+	client.PrependReactor("list", "nodes", func(action core.Action) (bool, runtime.Object, error) {
+		fieldSelectors = append(fieldSelectors, action.(core.ListAction).GetListRestrictions().Fields.String())
+		return false, nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listOptions := ListOptions{LabelSelector: "role=worker", FieldSelector: "spec.unschedulable=false", PageSize: 1}
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	sharedInformerFactory.InformerFor(&v1.Node{}, NewNodeInformer(listOptions))
+	// Every consumer of the factory is expected to get the filtered informer
+	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	nodes, err := nodeLister.List(labels.Everything())
+	if err != nil {
+		t.Fatalf("Unable to list nodes: %v", err)
+	}
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	sort.Strings(names)
+	if expected := []string{"n1", "n2"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected nodes %v, got %v", expected, names)
+	}
+	if len(fieldSelectors) == 0 || fieldSelectors[0] != listOptions.FieldSelector {
+		t.Errorf("Expected nodes to be listed with field selector %q, got %v", listOptions.FieldSelector, fieldSelectors)
+	}
+}
+
+func TestReadyNodesWithOptions(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 1000, 2000, 9, func(node *v1.Node) {
+		node.Labels = map[string]string{"role": "worker"}
+	})
+	node2 := test.BuildTestNode("node2", 1000, 2000, 9, nil)
+	node3 := test.BuildTestNode("node3", 1000, 2000, 9, func(node *v1.Node) {
+		node.Labels = map[string]string{"role": "worker"}
+		node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionFalse}}
+	})
+	client := fake.NewSimpleClientset(node1, node2, node3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The lister of an informer which is not started is empty, the nodes get listed from the API server
+	nodeLister := informers.NewSharedInformerFactory(client, 0).Core().V1().Nodes().Lister()
+	nodes, err := ReadyNodesWithOptions(ctx, client, nodeLister, ListOptions{LabelSelector: "role=worker", PageSize: 1})
+	if err != nil {
+		t.Fatalf("Unable to list ready nodes: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != node1.Name {
+		t.Errorf("Expected only node %v to be ready, got %v", node1.Name, nodes)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync/atomic"
 
 	v1 "k8s.io/api/core/v1"
@@ -29,6 +30,7 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	listersv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/util/workqueue"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
 	"k8s.io/klog/v2"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
@@ -39,7 +41,14 @@ const workersCount = 100
 // ReadyNodes returns ready nodes irrespective of whether they are
 // schedulable or not.
 func ReadyNodes(ctx context.Context, client clientset.Interface, nodeLister listersv1.NodeLister, nodeSelector string) ([]*v1.Node, error) {
-	ns, err := labels.Parse(nodeSelector)
+	return ReadyNodesWithOptions(ctx, client, nodeLister, ListOptions{LabelSelector: nodeSelector})
+}
+
+// ReadyNodesWithOptions returns the ready nodes selected by the list options
+// irrespective of whether they are schedulable or not. The field selector
+// is expected to be applied by the node lister already.
+func ReadyNodesWithOptions(ctx context.Context, client clientset.Interface, nodeLister listersv1.NodeLister, listOptions ListOptions) ([]*v1.Node, error) {
+	ns, err := labels.Parse(listOptions.LabelSelector)
 	if err != nil {
 		return []*v1.Node{}, err
 	}
@@ -53,28 +62,32 @@ func ReadyNodes(ctx context.Context, client clientset.Interface, nodeLister list
 	if len(nodes) == 0 {
 		klog.V(2).InfoS("Node lister returned empty list, now fetch directly")
 
-		nItems, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: nodeSelector})
-		if err != nil {
+		if nodes, err = listNodes(ctx, client, listOptions); err != nil {
 			return []*v1.Node{}, err
 		}
+	}
 
-		if nItems == nil || len(nItems.Items) == 0 {
-			return []*v1.Node{}, nil
-		}
+	return filterReadyNodes(nodes), nil
+}
 
-		for i := range nItems.Items {
-			node := nItems.Items[i]
-			nodes = append(nodes, &node)
-		}
+// ReadyNodesFromLister returns the ready nodes of the lister matching the selector
+// irrespective of whether they are schedulable or not.
+func ReadyNodesFromLister(nodeLister listersv1.NodeLister, selector labels.Selector) ([]*v1.Node, error) {
+	nodes, err := nodeLister.List(selector)
+	if err != nil {
+		return nil, err
 	}
+	return filterReadyNodes(nodes), nil
+}
 
+func filterReadyNodes(nodes []*v1.Node) []*v1.Node {
 	readyNodes := make([]*v1.Node, 0, len(nodes))
 	for _, node := range nodes {
 		if IsReady(node) {
 			readyNodes = append(readyNodes, node)
 		}
 	}
-	return readyNodes, nil
+	return readyNodes
 }
 
 // IsReady checks if the descheduler could run against given node.
@@ -104,6 +117,20 @@ func IsReady(node *v1.Node) bool {
 	return true
 }
 
+// NotReadySince returns when the node stopped being ready, nil if the node is ready
+// or did not report its readiness yet.
+func NotReadySince(node *v1.Node) *metav1.Time {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			if condition.Status == v1.ConditionTrue {
+				return nil
+			}
+			return &condition.LastTransitionTime
+		}
+	}
+	return nil
+}
+
 // NodeFit returns true if the provided pod can be scheduled onto the provided node.
 // This function is used when the NodeFit pod filtering feature of the Descheduler is enabled.
 // This function currently considers a subset of the Kubernetes Scheduler's predicates when
@@ -202,6 +229,68 @@ func PodFitsCurrentNode(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.P
 	return false
 }
 
+// IsPendingPod checks if the pod is waiting for the scheduler to place it on a node.
+// Pods held by scheduling gates are not waiting for the scheduler yet.
+func IsPendingPod(pod *v1.Pod) bool {
+	return pod.Spec.NodeName == "" &&
+		pod.Status.Phase == v1.PodPending &&
+		pod.DeletionTimestamp == nil &&
+		len(pod.Spec.SchedulingGates) == 0
+}
+
+// WithPendingPods returns a function listing the pods assigned to a node along with the pending pods
+// expected to be scheduled onto it, so NodeFit does not consider the capacity they are about to consume
+// as available. Pending pods are placed, from the highest priority to the lowest, on their nominated node
+// when it is one of the given nodes, otherwise on the first of the given nodes they fit. Pending pods which
+// do not fit any of the nodes are ignored.
+func WithPendingPods(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pendingPods []*v1.Pod, nodes []*v1.Node) podutil.GetPodsAssignedToNodeFunc {
+	reserved := map[string][]*v1.Pod{}
+	withReserved := func(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+		pods, err := nodeIndexer(nodeName, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range reserved[nodeName] {
+			if filter == nil || filter(pod) {
+				pods = append(pods, pod)
+			}
+		}
+		return pods, nil
+	}
+
+	nodeNames := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		nodeNames[node.Name] = struct{}{}
+	}
+
+	sorted := make([]*v1.Pod, 0, len(pendingPods))
+	for _, pod := range pendingPods {
+		if IsPendingPod(pod) {
+			sorted = append(sorted, pod)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return corev1helpers.PodPriority(sorted[i]) > corev1helpers.PodPriority(sorted[j])
+	})
+
+	for _, pod := range sorted {
+		// The scheduler already picked a node for the pod, e.g. while preempting pods on it
+		if _, ok := nodeNames[pod.Status.NominatedNodeName]; ok {
+			reserved[pod.Status.NominatedNodeName] = append(reserved[pod.Status.NominatedNodeName], pod)
+			continue
+		}
+		for _, node := range nodes {
+			if err := NodeFit(withReserved, pod, node); err == nil {
+				klog.V(4).InfoS("Pending pod expected on node", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				reserved[node.Name] = append(reserved[node.Name], pod)
+				break
+			}
+		}
+	}
+
+	return withReserved
+}
+
 // IsNodeUnschedulable checks if the node is unschedulable. This is a helper function to check only in case of
 // underutilized node so that they won't be accounted for.
 func IsNodeUnschedulable(node *v1.Node) bool {
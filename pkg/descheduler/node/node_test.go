@@ -19,8 +19,10 @@ package node
 import (
 	"context"
 	"errors"
+	"reflect"
 	"sync"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -28,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/test"
 )
@@ -114,6 +117,46 @@ func TestIsNodeUnschedulable(t *testing.T) {
 	}
 }
 
+func TestNotReadySince(t *testing.T) {
+	transition := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		description string
+		conditions  []v1.NodeCondition
+		expected    *metav1.Time
+	}{
+		{
+			description: "Node without ready condition",
+			expected:    nil,
+		},
+		{
+			description: "Ready node",
+			conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue, LastTransitionTime: transition}},
+			expected:    nil,
+		},
+		{
+			description: "Node not ready",
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+				{Type: v1.NodeReady, Status: v1.ConditionFalse, LastTransitionTime: transition},
+			},
+			expected: &transition,
+		},
+		{
+			description: "Node with unknown readiness",
+			conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionUnknown, LastTransitionTime: transition}},
+			expected:    &transition,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			node := &v1.Node{Status: v1.NodeStatus{Conditions: test.conditions}}
+			if actual := NotReadySince(node); !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("Expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
 func TestPodFitsCurrentNode(t *testing.T) {
 	nodeLabelKey := "kubernetes.io/desiredNode"
 	nodeLabelValue := "yes"
@@ -1131,3 +1174,56 @@ func createResourceList(cpu, memory, ephemeralStorage int64) v1.ResourceList {
 	resourceList[v1.ResourceEphemeralStorage] = *resource.NewQuantity(ephemeralStorage, resource.DecimalSI)
 	return resourceList
 }
+
+func TestWithPendingPods(t *testing.T) {
+	nodes := []*v1.Node{
+		test.BuildTestNode("n1", 1000, 2000, 10, nil),
+		test.BuildTestNode("n2", 1000, 2000, 10, nil),
+	}
+	buildPendingPod := func(name string, cpu int64, apply func(*v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, cpu, 0, "", func(pod *v1.Pod) {
+			pod.Status.Phase = v1.PodPending
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	pendingPods := []*v1.Pod{
+		buildPendingPod("low", 600, nil),
+		buildPendingPod("nominated", 600, func(pod *v1.Pod) {
+			pod.Status.NominatedNodeName = "n2"
+		}),
+		buildPendingPod("high", 600, func(pod *v1.Pod) {
+			pod.Spec.Priority = utilptr.To[int32](100)
+		}),
+		buildPendingPod("gated", 100, func(pod *v1.Pod) {
+			pod.Spec.SchedulingGates = []v1.PodSchedulingGate{{Name: "gate"}}
+		}),
+		buildPendingPod("too-big", 2000, nil),
+	}
+	noPods := func(string, podutil.FilterFunc) ([]*v1.Pod, error) {
+		return nil, nil
+	}
+
+	getPodsAssignedToNode := WithPendingPods(noPods, pendingPods, nodes)
+
+	expected := map[string][]string{
+		// high is placed first, so low does not fit n1 anymore
+		"n1": {"high"},
+		// nominated is placed on its nominated node even though it does not fit
+		"n2": {"low", "nominated"},
+	}
+	for nodeName, expectedPods := range expected {
+		pods, err := getPodsAssignedToNode(nodeName, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var podNames []string
+		for _, pod := range pods {
+			podNames = append(podNames, pod.Name)
+		}
+		if !reflect.DeepEqual(podNames, expectedPods) {
+			t.Errorf("Expected pending pods %v on node %v, got %v", expectedPods, nodeName, podNames)
+		}
+	}
+}
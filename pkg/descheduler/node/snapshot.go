@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// NodeSnapshot is an immutable view of the nodes at a given version, shared by all the plugins
+// of a descheduling cycle so they see the same node data
+type NodeSnapshot struct {
+	version uint64
+	nodes   map[string]*v1.Node
+	list    []*v1.Node
+}
+
+// NewNodeSnapshot returns a snapshot of the given nodes at the given version
+func NewNodeSnapshot(version uint64, nodes []*v1.Node) *NodeSnapshot {
+	s := &NodeSnapshot{
+		version: version,
+		nodes:   make(map[string]*v1.Node, len(nodes)),
+		list:    make([]*v1.Node, 0, len(nodes)),
+	}
+	for _, node := range nodes {
+		if _, ok := s.nodes[node.Name]; ok {
+			continue
+		}
+		s.nodes[node.Name] = node
+		s.list = append(s.list, node)
+	}
+	sort.Slice(s.list, func(i, j int) bool {
+		return s.list[i].Name < s.list[j].Name
+	})
+	return s
+}
+
+// Version returns the version of the nodes the snapshot got taken at. It grows with every change
+// of the nodes, the snapshots built out of a list of nodes are at version zero.
+func (s *NodeSnapshot) Version() uint64 {
+	return s.version
+}
+
+// Get returns the node of the given name, and whether the snapshot holds it
+func (s *NodeSnapshot) Get(name string) (*v1.Node, bool) {
+	node, ok := s.nodes[name]
+	return node, ok
+}
+
+// List returns the nodes sorted by name. The slice is a copy, the nodes are shared and must not
+// be modified.
+func (s *NodeSnapshot) List() []*v1.Node {
+	return append([]*v1.Node(nil), s.list...)
+}
+
+// Len returns the number of nodes of the snapshot
+func (s *NodeSnapshot) Len() int {
+	return len(s.list)
+}
+
+// NodeMap returns the nodes by name. The map is shared by all the readers of the snapshot and
+// must not be modified.
+func (s *NodeSnapshot) NodeMap() map[string]*v1.Node {
+	return s.nodes
+}
+
+// Select returns a snapshot of the nodes matching the selector, at the same version
+func (s *NodeSnapshot) Select(selector api.NodeMatcher) *NodeSnapshot {
+	var nodes []*v1.Node
+	for _, node := range s.list {
+		if selector.Matches(labels.Set(node.Labels)) {
+			nodes = append(nodes, node)
+		}
+	}
+	return NewNodeSnapshot(s.version, nodes)
+}
+
+// NodeSnapshotter maintains the nodes incrementally from the events of the node informer, and
+// hands out snapshots of them. A snapshot is only copied out of the maintained nodes when read
+// after they changed, the readers of an unchanged version share the same one.
+type NodeSnapshotter struct {
+	mu      sync.Mutex
+	version uint64
+	nodes   map[string]*v1.Node
+	// snapshot is the last snapshot taken, and ready the ready nodes of the last snapshot taken
+	// by selector
+	snapshot *NodeSnapshot
+	ready    map[string]*NodeSnapshot
+}
+
+// NewNodeSnapshotter returns a snapshotter of the nodes watched by the given informer
+func NewNodeSnapshotter(nodeInformer cache.SharedIndexInformer) *NodeSnapshotter {
+	s := &NodeSnapshotter{nodes: map[string]*v1.Node{}}
+	// Only a stopped informer refuses new handlers
+	if _, err := nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: s.set,
+		UpdateFunc: func(_, newObj interface{}) {
+			s.set(newObj)
+		},
+		DeleteFunc: s.delete,
+	}); err != nil {
+		klog.ErrorS(err, "Unable to watch the nodes")
+	}
+	return s
+}
+
+func (s *NodeSnapshotter) set(obj interface{}) {
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodes[node.Name] = node
+	s.version++
+}
+
+func (s *NodeSnapshotter) delete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.nodes, node.Name)
+	s.version++
+}
+
+// Snapshot returns a snapshot of all the nodes
+func (s *NodeSnapshotter) Snapshot() *NodeSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshotLocked()
+}
+
+func (s *NodeSnapshotter) snapshotLocked() *NodeSnapshot {
+	if s.snapshot != nil && s.snapshot.version == s.version {
+		return s.snapshot
+	}
+	nodes := make([]*v1.Node, 0, len(s.nodes))
+	for _, node := range s.nodes {
+		nodes = append(nodes, node)
+	}
+	s.snapshot = NewNodeSnapshot(s.version, nodes)
+	s.ready = map[string]*NodeSnapshot{}
+	return s.snapshot
+}
+
+// ReadyNodes returns a snapshot of the ready nodes matching the selector, at the version of the
+// snapshot of all the nodes
+func (s *NodeSnapshotter) ReadyNodes(selector labels.Selector) *NodeSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := s.snapshotLocked()
+	key := selector.String()
+	if ready, ok := s.ready[key]; ok {
+		return ready
+	}
+	var nodes []*v1.Node
+	for _, node := range snapshot.list {
+		if selector.Matches(labels.Set(node.Labels)) && IsReady(node) {
+			nodes = append(nodes, node)
+		}
+	}
+	ready := NewNodeSnapshot(snapshot.version, nodes)
+	s.ready[key] = ready
+	return ready
+}
+
+// ReadyNodesSnapshot returns a snapshot of the ready nodes selected by the list options, out of the
+// nodes maintained by the snapshotter, or listed from the API server when the snapshotter has none
+func ReadyNodesSnapshot(ctx context.Context, client clientset.Interface, snapshotter *NodeSnapshotter, listOptions ListOptions) (*NodeSnapshot, error) {
+	selector, err := labels.Parse(listOptions.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshotter.Snapshot().Len() > 0 {
+		return snapshotter.ReadyNodes(selector), nil
+	}
+
+	klog.V(2).InfoS("Node snapshot is empty, now fetch directly")
+	nodes, err := listNodes(ctx, client, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	return NewNodeSnapshot(0, filterReadyNodes(nodes)), nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func nodeNames(snapshot *NodeSnapshot) []string {
+	var names []string
+	for _, node := range snapshot.List() {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func TestNodeSnapshot(t *testing.T) {
+	worker := func(node *v1.Node) {
+		node.Labels = map[string]string{"role": "worker"}
+	}
+	snapshot := NewNodeSnapshot(3, []*v1.Node{
+		test.BuildTestNode("n2", 1000, 2000, 9, worker),
+		test.BuildTestNode("n1", 1000, 2000, 9, nil),
+		test.BuildTestNode("n3", 1000, 2000, 9, worker),
+	})
+
+	if got := nodeNames(snapshot); len(got) != 3 || got[0] != "n1" || got[1] != "n2" || got[2] != "n3" {
+		t.Errorf("Expected the nodes sorted by name, got %v", got)
+	}
+	if _, ok := snapshot.Get("n2"); !ok {
+		t.Errorf("Expected n2 in the snapshot")
+	}
+	if _, ok := snapshot.Get("n4"); ok {
+		t.Errorf("Expected n4 not to be in the snapshot")
+	}
+
+	list := snapshot.List()
+	list[0] = nil
+	if snapshot.List()[0] == nil {
+		t.Errorf("Expected List to return a copy of the nodes")
+	}
+
+	workers := snapshot.Select(labels.SelectorFromSet(labels.Set{"role": "worker"}))
+	if workers.Version() != 3 {
+		t.Errorf("Expected the selected nodes at version 3, got %d", workers.Version())
+	}
+	if got := nodeNames(workers); len(got) != 2 || got[0] != "n2" || got[1] != "n3" {
+		t.Errorf("Expected the worker nodes to be selected, got %v", got)
+	}
+}
+
+func TestNodeSnapshotter(t *testing.T) {
+	worker := func(node *v1.Node) {
+		node.Labels = map[string]string{"role": "worker"}
+	}
+	n1 := test.BuildTestNode("n1", 1000, 2000, 9, worker)
+	n2 := test.BuildTestNode("n2", 1000, 2000, 9, nil)
+	n3 := test.BuildTestNode("n3", 1000, 2000, 9, func(node *v1.Node) {
+		worker(node)
+		node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionFalse}}
+	})
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	snapshotter := NewNodeSnapshotter(sharedInformerFactory.Core().V1().Nodes().Informer())
+	snapshotter.set(n1)
+	snapshotter.set(n2)
+	snapshotter.set(n3)
+
+	snapshot := snapshotter.Snapshot()
+	if snapshot.Version() != 3 || snapshot.Len() != 3 {
+		t.Fatalf("Expected 3 nodes at version 3, got %d nodes at version %d", snapshot.Len(), snapshot.Version())
+	}
+	if snapshotter.Snapshot() != snapshot {
+		t.Errorf("Expected the snapshot to be shared while the nodes are unchanged")
+	}
+
+	workers := labels.SelectorFromSet(labels.Set{"role": "worker"})
+	ready := snapshotter.ReadyNodes(workers)
+	if got := nodeNames(ready); len(got) != 1 || got[0] != "n1" {
+		t.Errorf("Expected n1 to be the only ready worker, got %v", got)
+	}
+	if snapshotter.ReadyNodes(workers) != ready {
+		t.Errorf("Expected the ready nodes to be shared while the nodes are unchanged")
+	}
+
+	n2Worker := n2.DeepCopy()
+	worker(n2Worker)
+	snapshotter.set(n2Worker)
+	snapshotter.delete(cache.DeletedFinalStateUnknown{Key: "n1", Obj: n1})
+
+	if got := snapshotter.Snapshot(); got.Version() != 5 || got.Len() != 2 {
+		t.Errorf("Expected 2 nodes at version 5, got %d nodes at version %d", got.Len(), got.Version())
+	}
+	if snapshot.Len() != 3 {
+		t.Errorf("Expected the previous snapshot to be left unchanged, got %d nodes", snapshot.Len())
+	}
+	if got := nodeNames(snapshotter.ReadyNodes(workers)); len(got) != 1 || got[0] != "n2" {
+		t.Errorf("Expected n2 to be the only ready worker, got %v", got)
+	}
+}
+
+func TestReadyNodesSnapshot(t *testing.T) {
+	ctx := context.Background()
+	n1 := test.BuildTestNode("n1", 1000, 2000, 9, nil)
+	n2 := test.BuildTestNode("n2", 1000, 2000, 9, func(node *v1.Node) {
+		node.Status.Conditions = []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionFalse}}
+	})
+	client := fake.NewSimpleClientset(n1, n2)
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	snapshotter := NewNodeSnapshotter(sharedInformerFactory.Core().V1().Nodes().Informer())
+
+	// The snapshotter has no nodes until the informer syncs, the nodes are listed directly
+	snapshot, err := ReadyNodesSnapshot(ctx, client, snapshotter, ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := nodeNames(snapshot); snapshot.Version() != 0 || len(got) != 1 || got[0] != "n1" {
+		t.Errorf("Expected n1 listed at version 0, got %v at version %d", got, snapshot.Version())
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sharedInformerFactory.Start(ctx.Done())
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		return snapshotter.Snapshot().Len() == 2, nil
+	}); err != nil {
+		t.Fatalf("Timed out waiting for the snapshotter to observe the nodes: %v", err)
+	}
+
+	snapshot, err = ReadyNodesSnapshot(ctx, client, snapshotter, ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := nodeNames(snapshot); snapshot.Version() == 0 || len(got) != 1 || got[0] != "n1" {
+		t.Errorf("Expected n1 out of the snapshotter, got %v at version %d", got, snapshot.Version())
+	}
+}
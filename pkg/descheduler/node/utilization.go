@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	clientset "k8s.io/client-go/kubernetes"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+)
+
+// RequestsUtilizationProvider computes a node's utilization as the sum
+// of the resource requests of all the pods assigned to the node.
+type RequestsUtilizationProvider struct {
+	getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc
+}
+
+// NewRequestsUtilizationProvider returns a utilization provider based on pod requests
+func NewRequestsUtilizationProvider(getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) *RequestsUtilizationProvider {
+	return &RequestsUtilizationProvider{
+		getPodsAssignedToNode: getPodsAssignedToNode,
+	}
+}
+
+// NodeUtilization returns the resources requested by the pods assigned to the node.
+// Only resources supplied in the resourceNames parameter (plus cpu, memory and pods) are calculated.
+func (p *RequestsUtilizationProvider) NodeUtilization(_ context.Context, node *v1.Node, resourceNames []v1.ResourceName) (map[v1.ResourceName]*resource.Quantity, error) {
+	pods, err := podutil.ListPodsOnANode(node.Name, p.getPodsAssignedToNode, nil)
+	if err != nil {
+		return nil, err
+	}
+	return NodeUtilization(pods, resourceNames), nil
+}
+
+// nodeMetrics is the part of the metrics.k8s.io/v1beta1 NodeMetrics read by MetricsUtilizationProvider
+type nodeMetrics struct {
+	Usage v1.ResourceList `json:"usage"`
+}
+
+// MetricsUtilizationProvider computes a node's cpu and memory utilization from the usage
+// reported by the resource metrics API (e.g. metrics-server). The utilization of the other
+// resources is the sum of the resource requests of the pods assigned to the node.
+type MetricsUtilizationProvider struct {
+	client   clientset.Interface
+	requests *RequestsUtilizationProvider
+}
+
+// NewMetricsUtilizationProvider returns a utilization provider based on the resource metrics API.
+// The client has to talk to an API server, the fake clients do not serve the metrics API.
+func NewMetricsUtilizationProvider(client clientset.Interface, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) *MetricsUtilizationProvider {
+	return &MetricsUtilizationProvider{
+		client:   client,
+		requests: NewRequestsUtilizationProvider(getPodsAssignedToNode),
+	}
+}
+
+// NodeUtilization returns the cpu and memory used on the node, along with the other
+// resources requested by the pods assigned to the node.
+func (p *MetricsUtilizationProvider) NodeUtilization(ctx context.Context, node *v1.Node, resourceNames []v1.ResourceName) (map[v1.ResourceName]*resource.Quantity, error) {
+	utilization, err := p.requests.NodeUtilization(ctx, node, resourceNames)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := p.client.CoreV1().RESTClient().Get().AbsPath("/apis/metrics.k8s.io/v1beta1/nodes", node.Name).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the metrics of node %q: %v", node.Name, err)
+	}
+	var metrics nodeMetrics
+	if err := json.Unmarshal(body, &metrics); err != nil {
+		return nil, fmt.Errorf("unable to decode the metrics of node %q: %v", node.Name, err)
+	}
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		usage, ok := metrics.Usage[name]
+		if !ok {
+			return nil, fmt.Errorf("metrics of node %q miss the %s usage", node.Name, name)
+		}
+		utilization[name] = &usage
+	}
+	return utilization, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRequestsUtilizationProvider(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 4000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 400, 100, node1.Name, nil)
+	p2 := test.BuildTestPod("p2", 600, 200, node1.Name, nil)
+	p3 := test.BuildTestPod("p3", 500, 300, node2.Name, nil)
+
+	fakeClient := fake.NewSimpleClientset([]runtime.Object{node1, node2, p1, p2, p3}...)
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+	if err != nil {
+		t.Errorf("Build get pods assigned to node function error: %v", err)
+	}
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	provider := NewRequestsUtilizationProvider(getPodsAssignedToNode)
+	usage, err := provider.NodeUtilization(ctx, node1, []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cpu := usage[v1.ResourceCPU].MilliValue(); cpu != 1000 {
+		t.Errorf("Expected 1000m of cpu in use, got %vm", cpu)
+	}
+	if memory := usage[v1.ResourceMemory].Value(); memory != 300 {
+		t.Errorf("Expected 300 bytes of memory in use, got %v", memory)
+	}
+	if pods := usage[v1.ResourcePods].Value(); pods != 2 {
+		t.Errorf("Expected 2 pods, got %v", pods)
+	}
+}
+
+func TestMetricsUtilizationProvider(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 4000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 400, 100, node1.Name, nil)
+	p2 := test.BuildTestPod("p2", 600, 200, node1.Name, nil)
+
+	fakeClient := fake.NewSimpleClientset([]runtime.Object{node1, node2, p1, p2}...)
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+	if err != nil {
+		t.Errorf("Build get pods assigned to node function error: %v", err)
+	}
+
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	// Serves the metrics of n1 only
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apis/metrics.k8s.io/v1beta1/nodes/n1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"kind":"NodeMetrics","apiVersion":"metrics.k8s.io/v1beta1","metadata":{"name":"n1"},"usage":{"cpu":"2500m","memory":"1Gi"}}`)
+	}))
+	defer server.Close()
+	client, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("Unable to create a client: %v", err)
+	}
+
+	provider := NewMetricsUtilizationProvider(client, getPodsAssignedToNode)
+	usage, err := provider.NodeUtilization(ctx, node1, []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cpu := usage[v1.ResourceCPU].MilliValue(); cpu != 2500 {
+		t.Errorf("Expected 2500m of cpu in use, got %vm", cpu)
+	}
+	if memory := usage[v1.ResourceMemory].Value(); memory != 1024*1024*1024 {
+		t.Errorf("Expected 1Gi of memory in use, got %v", memory)
+	}
+	if pods := usage[v1.ResourcePods].Value(); pods != 2 {
+		t.Errorf("Expected 2 pods, got %v", pods)
+	}
+
+	if _, err := provider.NodeUtilization(ctx, node2, []v1.ResourceName{v1.ResourceCPU}); err == nil {
+		t.Errorf("Expected an error for the node without metrics")
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"fmt"
+
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+)
+
+// validatePluginDefaults checks the defaults the same way the arguments of the plugins are checked.
+// The included namespaces can not be inherited by the plugins restricting the namespaces with
+// EvictableNamespaces, which only support excluding namespaces, so they must set their own.
+func validatePluginDefaults(in api.DeschedulerPolicy) error {
+	defaults := in.Defaults
+	if defaults == nil {
+		return nil
+	}
+	filteringArgs := api.FilteringArgs{Namespaces: defaults.Namespaces, LabelSelector: defaults.LabelSelector}
+	if err := filteringArgs.Validate(); err != nil {
+		return fmt.Errorf("invalid defaults: %v", err)
+	}
+	if defaults.PriorityThreshold != nil && defaults.PriorityThreshold.Value != nil && len(defaults.PriorityThreshold.Name) > 0 {
+		return fmt.Errorf("invalid defaults: only one of priorityThreshold fields can be set")
+	}
+	if defaults.Namespaces == nil || len(defaults.Namespaces.Include) == 0 {
+		return nil
+	}
+	for _, profile := range in.Profiles {
+		for _, pluginConfig := range profile.PluginConfigs {
+			if evictableArgs, ok := pluginConfig.Args.(api.EvictableNamespacesArgs); ok && evictableArgs.GetEvictableNamespaces() == nil {
+				return fmt.Errorf("invalid defaults: in profile %s: plugin %s only supports excluded namespaces, it can not inherit the included namespaces", profile.Name, pluginConfig.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// applyPluginDefaults sets the defaults of the policy in the arguments of the plugins which do not set them.
+// The namespaces and the label selector are inherited by the plugins filtering the pods, the priority
+// threshold and nodeFit by the DefaultEvictor, added to the profiles not configuring it. The plugins
+// restricting the namespaces with EvictableNamespaces inherit the excluded namespaces as their evictable
+// namespaces. They do not support included namespaces, which validatePluginDefaults rejects for them.
+func applyPluginDefaults(in *api.DeschedulerPolicy) {
+	defaults := in.Defaults
+	if defaults == nil {
+		return
+	}
+	for i := range in.Profiles {
+		profile := &in.Profiles[i]
+		if defaults.PriorityThreshold != nil || defaults.NodeFit != nil {
+			if pluginConfig, _ := GetPluginConfig(defaultevictor.PluginName, profile.PluginConfigs); pluginConfig == nil {
+				profile.PluginConfigs = append([]api.PluginConfig{{
+					Name: defaultevictor.PluginName,
+					Args: &defaultevictor.DefaultEvictorArgs{},
+				}}, profile.PluginConfigs...)
+			}
+		}
+		for _, pluginConfig := range profile.PluginConfigs {
+			if getter, ok := pluginConfig.Args.(api.FilteringArgsGetter); ok {
+				filteringArgs := getter.GetFilteringArgs()
+				if evictableArgs, ok := pluginConfig.Args.(api.EvictableNamespacesArgs); ok {
+					if evictableArgs.GetEvictableNamespaces() == nil && defaults.Namespaces != nil && len(defaults.Namespaces.Exclude) > 0 {
+						evictableArgs.SetEvictableNamespaces(&api.Namespaces{Exclude: append([]string(nil), defaults.Namespaces.Exclude...)})
+					}
+				} else if filteringArgs.Namespaces == nil && defaults.Namespaces != nil {
+					filteringArgs.Namespaces = defaults.Namespaces.DeepCopy()
+				}
+				if filteringArgs.LabelSelector == nil && defaults.LabelSelector != nil {
+					filteringArgs.LabelSelector = defaults.LabelSelector.DeepCopy()
+				}
+			}
+			if args, ok := pluginConfig.Args.(*defaultevictor.DefaultEvictorArgs); ok {
+				if args.PriorityThreshold == nil && defaults.PriorityThreshold != nil {
+					args.PriorityThreshold = defaults.PriorityThreshold.DeepCopy()
+				}
+				if args.NodeFit == nil && defaults.NodeFit != nil {
+					args.NodeFit = utilptr.To(*defaults.NodeFit)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NewPodInformerForNamespaces returns a function building a pod informer which lists
+// and watches pods of the given namespaces only, instead of pods of the whole cluster.
+// This way only namespace scoped permissions on pods are required. It is meant to be
+// registered through SharedInformerFactory.InformerFor before any other consumer
+// requests the pod informer so all of them share the namespaced one.
+func NewPodInformerForNamespaces(namespaces []string) func(clientset.Interface, time.Duration) cache.SharedIndexInformer {
+	return NewFilteredPodInformer(namespaces, "", "")
+}
+
+// NewFilteredPodInformer returns a function building a pod informer which lists and
+// watches the pods matching the label and field selectors only, in the given namespaces
+// or in the whole cluster when none is given. The selectors are applied by the API server
+// so the pods filtered out are neither sent to the descheduler nor kept in its cache.
+func NewFilteredPodInformer(namespaces []string, labelSelector, fieldSelector string) func(clientset.Interface, time.Duration) cache.SharedIndexInformer {
+	tweakListOptions := func(options *metav1.ListOptions) {
+		options.LabelSelector = labelSelector
+		options.FieldSelector = fieldSelector
+	}
+	return func(client clientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+		indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+		if len(namespaces) <= 1 {
+			namespace := metav1.NamespaceAll
+			if len(namespaces) == 1 {
+				namespace = namespaces[0]
+			}
+			return coreinformers.NewFilteredPodInformer(client, namespace, resyncPeriod, indexers, tweakListOptions)
+		}
+		return cache.NewSharedIndexInformer(
+			&multiNamespaceListWatch{
+				client:           client,
+				namespaces:       namespaces,
+				tweakListOptions: tweakListOptions,
+				resourceVersions: map[string]string{},
+			},
+			&v1.Pod{},
+			resyncPeriod,
+			indexers,
+		)
+	}
+}
+
+// multiNamespaceListWatch lists and watches pods in each namespace separately.
+// Resource versions are tracked per namespace so every watch resumes
+// from the last version observed in its own namespace.
+type multiNamespaceListWatch struct {
+	client           clientset.Interface
+	namespaces       []string
+	tweakListOptions func(*metav1.ListOptions)
+
+	mu               sync.Mutex
+	resourceVersions map[string]string
+}
+
+var _ cache.ListerWatcher = &multiNamespaceListWatch{}
+
+func (lw *multiNamespaceListWatch) List(options metav1.ListOptions) (runtime.Object, error) {
+	// Continue tokens are specific to a single list request, so pagination can not span namespaces
+	options.Limit = 0
+	options.Continue = ""
+	lw.tweakListOptions(&options)
+
+	list := &v1.PodList{}
+	resourceVersions := make(map[string]string, len(lw.namespaces))
+	var maxResourceVersion uint64
+	for _, namespace := range lw.namespaces {
+		podList, err := lw.client.CoreV1().Pods(namespace).List(context.TODO(), options)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list pods in %q namespace: %v", namespace, err)
+		}
+		list.Items = append(list.Items, podList.Items...)
+		resourceVersions[namespace] = podList.ResourceVersion
+		if rv, err := strconv.ParseUint(podList.ResourceVersion, 10, 64); err == nil && rv > maxResourceVersion {
+			maxResourceVersion = rv
+		}
+	}
+	if maxResourceVersion > 0 {
+		list.ResourceVersion = strconv.FormatUint(maxResourceVersion, 10)
+	}
+
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	lw.resourceVersions = resourceVersions
+	return list, nil
+}
+
+func (lw *multiNamespaceListWatch) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	lw.tweakListOptions(&options)
+	mw := &multiNamespaceWatch{
+		lw:     lw,
+		result: make(chan watch.Event),
+		stopCh: make(chan struct{}),
+	}
+	for _, namespace := range lw.namespaces {
+		namespaceOptions := options
+		lw.mu.Lock()
+		namespaceOptions.ResourceVersion = lw.resourceVersions[namespace]
+		lw.mu.Unlock()
+		w, err := lw.client.CoreV1().Pods(namespace).Watch(context.TODO(), namespaceOptions)
+		if err != nil {
+			mw.Stop()
+			return nil, fmt.Errorf("unable to watch pods in %q namespace: %v", namespace, err)
+		}
+		mw.watches = append(mw.watches, w)
+	}
+	for i, namespace := range lw.namespaces {
+		mw.wg.Add(1)
+		go mw.forward(namespace, mw.watches[i])
+	}
+	go func() {
+		mw.wg.Wait()
+		close(mw.result)
+	}()
+	return mw, nil
+}
+
+func (lw *multiNamespaceListWatch) observe(namespace string, event watch.Event) {
+	if event.Type == watch.Error {
+		return
+	}
+	accessor, err := meta.Accessor(event.Object)
+	if err != nil {
+		return
+	}
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	lw.resourceVersions[namespace] = accessor.GetResourceVersion()
+}
+
+// multiNamespaceWatch merges the per namespace watches into a single one.
+// Once any of the underlying watches ends, all of them are stopped so the
+// informer starts watching again from the tracked resource versions.
+type multiNamespaceWatch struct {
+	lw       *multiNamespaceListWatch
+	watches  []watch.Interface
+	result   chan watch.Event
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func (mw *multiNamespaceWatch) forward(namespace string, w watch.Interface) {
+	defer mw.wg.Done()
+	defer mw.Stop()
+	for {
+		select {
+		case <-mw.stopCh:
+			return
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+			mw.lw.observe(namespace, event)
+			select {
+			case mw.result <- event:
+			case <-mw.stopCh:
+				return
+			}
+		}
+	}
+}
+
+func (mw *multiNamespaceWatch) Stop() {
+	mw.stopOnce.Do(func() {
+		close(mw.stopCh)
+		for _, w := range mw.watches {
+			w.Stop()
+		}
+	})
+}
+
+func (mw *multiNamespaceWatch) ResultChan() <-chan watch.Event {
+	return mw.result
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func listedPods(t *testing.T, informer cache.SharedIndexInformer) []string {
+	pods, err := listersv1.NewPodLister(informer.GetIndexer()).List(labels.Everything())
+	if err != nil {
+		t.Fatalf("Unable to list pods: %v", err)
+	}
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestNewPodInformerForNamespaces(t *testing.T) {
+	newPod := func(name, namespace string) *v1.Pod {
+		pod := test.BuildTestPod(name, 100, 0, "n1", nil)
+		pod.Namespace = namespace
+		return pod
+	}
+
+	tests := []struct {
+		description string
+		namespaces  []string
+		created     []*v1.Pod
+		expected    []string
+	}{
+		{
+			description: "single namespace",
+			namespaces:  []string{"a"},
+			created:     []*v1.Pod{newPod("p4", "a"), newPod("p5", "c")},
+			expected:    []string{"a/p1", "a/p4"},
+		},
+		{
+			description: "multiple namespaces",
+			namespaces:  []string{"a", "b"},
+			created:     []*v1.Pod{newPod("p4", "b"), newPod("p5", "c")},
+			expected:    []string{"a/p1", "b/p2", "b/p4"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client := fake.NewSimpleClientset(newPod("p1", "a"), newPod("p2", "b"), newPod("p3", "c"))
+			sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+			sharedInformerFactory.InformerFor(&v1.Pod{}, NewPodInformerForNamespaces(tc.namespaces))
+			// Every consumer of the factory is expected to get the namespaced informer
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			for _, pod := range tc.created {
+				if _, err := client.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("Unable to create pod: %v", err)
+				}
+			}
+
+			var got []string
+			if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+				got = listedPods(t, podInformer)
+				return len(got) >= len(tc.expected), nil
+			}); err != nil {
+				t.Fatalf("Expected pods %v to be listed, got %v", tc.expected, got)
+			}
+			// Give events from unwatched namespaces a chance to leak in
+			time.Sleep(100 * time.Millisecond)
+			got = listedPods(t, podInformer)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("Expected pods %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewFilteredPodInformer(t *testing.T) {
+	tests := []struct {
+		description string
+		namespaces  []string
+		expected    []string
+	}{
+		{
+			description: "all namespaces",
+			expected:    []string{""},
+		},
+		{
+			description: "single namespace",
+			namespaces:  []string{"a"},
+			expected:    []string{"a"},
+		},
+		{
+			description: "multiple namespaces",
+			namespaces:  []string{"a", "b"},
+			expected:    []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client := fake.NewSimpleClientset()
+			listed := make(chan string, 10)
+			client.PrependReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				restrictions := action.(core.ListAction).GetListRestrictions()
+				if restrictions.Labels.String() != "app!=ds" || restrictions.Fields.String() != "metadata.namespace!=kube-system" {
+					t.Errorf("Unexpected selectors %q and %q listing pods", restrictions.Labels, restrictions.Fields)
+				}
+				listed <- action.GetNamespace()
+				return true, &v1.PodList{}, nil
+			})
+			sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+			sharedInformerFactory.InformerFor(&v1.Pod{}, NewFilteredPodInformer(tc.namespaces, "app!=ds", "metadata.namespace!=kube-system"))
+			sharedInformerFactory.Core().V1().Pods().Informer()
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			var got []string
+			for range tc.expected {
+				got = append(got, <-listed)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("Expected pods listed in namespaces %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
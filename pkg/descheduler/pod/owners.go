@@ -0,0 +1,272 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+// OwnerKey identifies the controller of a group of pods
+type OwnerKey struct {
+	Namespace  string
+	APIVersion string
+	Kind       string
+	Name       string
+	UID        types.UID
+}
+
+// ObjectReference returns a reference to the owner, e.g. to emit events on it
+func (o OwnerKey) ObjectReference() *v1.ObjectReference {
+	return &v1.ObjectReference{
+		APIVersion: o.APIVersion,
+		Kind:       o.Kind,
+		Namespace:  o.Namespace,
+		Name:       o.Name,
+		UID:        o.UID,
+	}
+}
+
+func ownerKeyOf(namespace string, ref *metav1.OwnerReference) OwnerKey {
+	return OwnerKey{
+		Namespace:  namespace,
+		APIVersion: ref.APIVersion,
+		Kind:       ref.Kind,
+		Name:       ref.Name,
+		UID:        ref.UID,
+	}
+}
+
+// GroupByOwnerRef groups pods by their controller. Pods without controller are ignored.
+func GroupByOwnerRef(pods []*v1.Pod) map[OwnerKey][]*v1.Pod {
+	m := make(map[OwnerKey][]*v1.Pod)
+	for _, pod := range pods {
+		if controllerRef := metav1.GetControllerOf(pod); controllerRef != nil {
+			key := ownerKeyOf(pod.Namespace, controllerRef)
+			m[key] = append(m[key], pod)
+		}
+	}
+	return m
+}
+
+// OwnerResolver resolves the workloads owning pods. Unlike GroupByOwnerRef, pods controlled
+// by a ReplicaSet are attributed to the Deployment controlling the ReplicaSet, so all the pods
+// of a Deployment are grouped together across its rollouts. Owners are read through the API
+// and the controllers of the ReplicaSets are cached for the lifetime of the resolver.
+type OwnerResolver struct {
+	client clientset.Interface
+
+	mu sync.Mutex
+	// replicaSetOwners holds the Deployments controlling the ReplicaSets resolved so far,
+	// nil for ReplicaSets not controlled by a Deployment
+	replicaSetOwners map[types.UID]*OwnerKey
+	// ownerRefs holds the owner references of the owners read by Owners
+	ownerRefs map[types.UID][]metav1.OwnerReference
+}
+
+// NewOwnerResolver returns an owner resolver reading owners with the given client
+func NewOwnerResolver(client clientset.Interface) *OwnerResolver {
+	return &OwnerResolver{
+		client:           client,
+		replicaSetOwners: map[types.UID]*OwnerKey{},
+		ownerRefs:        map[types.UID][]metav1.OwnerReference{},
+	}
+}
+
+// Owner returns the workload owning the pod: the Deployment controlling its ReplicaSet if any,
+// its controller otherwise. False is returned for pods without controller.
+func (r *OwnerResolver) Owner(ctx context.Context, pod *v1.Pod) (OwnerKey, bool, error) {
+	controllerRef := metav1.GetControllerOf(pod)
+	if controllerRef == nil {
+		return OwnerKey{}, false, nil
+	}
+	owner := ownerKeyOf(pod.Namespace, controllerRef)
+	if owner.Kind != "ReplicaSet" {
+		return owner, true, nil
+	}
+
+	r.mu.Lock()
+	deployment, cached := r.replicaSetOwners[owner.UID]
+	r.mu.Unlock()
+	if !cached {
+		replicaSet, err := r.client.AppsV1().ReplicaSets(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// The ReplicaSet is gone, nothing to resolve it to
+				return owner, true, nil
+			}
+			return OwnerKey{}, false, fmt.Errorf("unable to get the owner of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+		if replicaSet.UID != owner.UID {
+			// The ReplicaSet got recreated with the same name
+			return owner, true, nil
+		}
+		if rsControllerRef := metav1.GetControllerOf(replicaSet); rsControllerRef != nil && rsControllerRef.Kind == "Deployment" {
+			key := ownerKeyOf(replicaSet.Namespace, rsControllerRef)
+			deployment = &key
+		}
+		r.mu.Lock()
+		r.replicaSetOwners[owner.UID] = deployment
+		r.mu.Unlock()
+	}
+	if deployment != nil {
+		return *deployment, true, nil
+	}
+	return owner, true, nil
+}
+
+// GroupByOwnerRef groups pods by the workload owning them, see Owner. Pods without controller are ignored.
+func (r *OwnerResolver) GroupByOwnerRef(ctx context.Context, pods []*v1.Pod) (map[OwnerKey][]*v1.Pod, error) {
+	m := make(map[OwnerKey][]*v1.Pod)
+	for _, pod := range pods {
+		owner, ok, err := r.Owner(ctx, pod)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			m[owner] = append(m[owner], pod)
+		}
+	}
+	return m, nil
+}
+
+// DesiredReplicas returns the number of replicas requested by the spec of the owner.
+// Deployments, ReplicaSets, StatefulSets and ReplicationControllers are supported.
+func (r *OwnerResolver) DesiredReplicas(ctx context.Context, owner OwnerKey) (int32, error) {
+	var replicas *int32
+	switch owner.Kind {
+	case "Deployment":
+		deployment, err := r.client.AppsV1().Deployments(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		replicas = deployment.Spec.Replicas
+	case "ReplicaSet":
+		replicaSet, err := r.client.AppsV1().ReplicaSets(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		replicas = replicaSet.Spec.Replicas
+	case "StatefulSet":
+		statefulSet, err := r.client.AppsV1().StatefulSets(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		replicas = statefulSet.Spec.Replicas
+	case "ReplicationController":
+		replicationController, err := r.client.CoreV1().ReplicationControllers(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		replicas = replicationController.Spec.Replicas
+	default:
+		return 0, fmt.Errorf("unable to get the desired replicas of %s %s/%s: kind not supported", owner.Kind, owner.Namespace, owner.Name)
+	}
+	// The API server defaults the number of replicas to 1
+	if replicas == nil {
+		return 1, nil
+	}
+	return *replicas, nil
+}
+
+// maxOwnerDepth bounds the length of the owner chains followed by Owners
+const maxOwnerDepth = 5
+
+// Owners returns the objects owning the pod, directly or through other owners, e.g. the
+// ReplicaSet of a pod, the Deployment controlling the ReplicaSet and the custom resource
+// owning the Deployment. Only the owners of the workload kinds readable through the client
+// (ReplicaSets, Deployments, StatefulSets, DaemonSets, Jobs, CronJobs and ReplicationControllers)
+// are followed, an owner of another kind ends its chain.
+func (r *OwnerResolver) Owners(ctx context.Context, pod *v1.Pod) ([]OwnerKey, error) {
+	var owners []OwnerKey
+	seen := map[types.UID]bool{}
+	var queue []OwnerKey
+	for i := range pod.OwnerReferences {
+		queue = append(queue, ownerKeyOf(pod.Namespace, &pod.OwnerReferences[i]))
+	}
+	for depth := 0; len(queue) > 0 && depth < maxOwnerDepth; depth++ {
+		var next []OwnerKey
+		for _, owner := range queue {
+			if seen[owner.UID] {
+				continue
+			}
+			seen[owner.UID] = true
+			owners = append(owners, owner)
+			refs, err := r.ownerRefsOf(ctx, owner)
+			if err != nil {
+				return nil, fmt.Errorf("unable to get the owners of %s %s/%s: %v", owner.Kind, owner.Namespace, owner.Name, err)
+			}
+			for i := range refs {
+				next = append(next, ownerKeyOf(owner.Namespace, &refs[i]))
+			}
+		}
+		queue = next
+	}
+	return owners, nil
+}
+
+// ownerRefsOf returns the owner references of an owner, nil for the kinds which can not be read
+func (r *OwnerResolver) ownerRefsOf(ctx context.Context, owner OwnerKey) ([]metav1.OwnerReference, error) {
+	r.mu.Lock()
+	refs, cached := r.ownerRefs[owner.UID]
+	r.mu.Unlock()
+	if cached {
+		return refs, nil
+	}
+
+	var obj metav1.Object
+	var err error
+	gk := schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind).GroupKind()
+	switch gk {
+	case schema.GroupKind{Group: "apps", Kind: "ReplicaSet"}:
+		obj, err = r.client.AppsV1().ReplicaSets(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		obj, err = r.client.AppsV1().Deployments(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		obj, err = r.client.AppsV1().StatefulSets(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "apps", Kind: "DaemonSet"}:
+		obj, err = r.client.AppsV1().DaemonSets(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "batch", Kind: "Job"}:
+		obj, err = r.client.BatchV1().Jobs(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "batch", Kind: "CronJob"}:
+		obj, err = r.client.BatchV1().CronJobs(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	case schema.GroupKind{Kind: "ReplicationController"}:
+		obj, err = r.client.CoreV1().ReplicationControllers(owner.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+	default:
+		return nil, nil
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	// An owner which is gone or got recreated with the same name has no owner to follow
+	if err == nil && obj.GetUID() == owner.UID {
+		refs = obj.GetOwnerReferences()
+	}
+
+	r.mu.Lock()
+	r.ownerRefs[owner.UID] = refs
+	r.mu.Unlock()
+	return refs, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func controllerRef(kind, name string) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       kind,
+		Name:       name,
+		UID:        types.UID(kind + "-" + name),
+		Controller: utilptr.To(true),
+	}
+}
+
+func buildOwnedPod(name string, ownerRefs ...metav1.OwnerReference) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, "n1", func(pod *v1.Pod) {
+		pod.OwnerReferences = ownerRefs
+	})
+}
+
+func TestGroupByOwnerRef(t *testing.T) {
+	rsRef := controllerRef("ReplicaSet", "rs")
+	ssRef := controllerRef("StatefulSet", "ss")
+	p1 := buildOwnedPod("p1", rsRef)
+	p2 := buildOwnedPod("p2", rsRef)
+	p3 := buildOwnedPod("p3", ssRef)
+	// Owner references which are not controllers are not taken into account
+	p4 := buildOwnedPod("p4", metav1.OwnerReference{Kind: "ReplicaSet", Name: "rs", UID: rsRef.UID})
+	p5 := buildOwnedPod("p5")
+
+	expected := map[OwnerKey][]*v1.Pod{
+		{Namespace: "default", APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs", UID: rsRef.UID}:  {p1, p2},
+		{Namespace: "default", APIVersion: "apps/v1", Kind: "StatefulSet", Name: "ss", UID: ssRef.UID}: {p3},
+	}
+	if got := GroupByOwnerRef([]*v1.Pod{p1, p2, p3, p4, p5}); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unexpected groups, expected %v, got %v", expected, got)
+	}
+}
+
+func TestOwnerResolver(t *testing.T) {
+	ctx := context.Background()
+
+	deploymentRef := controllerRef("Deployment", "web")
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: deploymentRef.UID},
+		Spec:       appsv1.DeploymentSpec{Replicas: utilptr.To[int32](3)},
+	}
+	rsRefs := []metav1.OwnerReference{controllerRef("ReplicaSet", "web-1"), controllerRef("ReplicaSet", "web-2")}
+	var replicaSets []*appsv1.ReplicaSet
+	for _, rsRef := range rsRefs {
+		replicaSets = append(replicaSets, &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            rsRef.Name,
+				Namespace:       "default",
+				UID:             rsRef.UID,
+				OwnerReferences: []metav1.OwnerReference{deploymentRef},
+			},
+		})
+	}
+	standaloneRSRef := controllerRef("ReplicaSet", "standalone")
+	standaloneRS := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default", UID: standaloneRSRef.UID},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: utilptr.To[int32](2)},
+	}
+	ssRef := controllerRef("StatefulSet", "db")
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: ssRef.UID},
+	}
+	goneRSRef := controllerRef("ReplicaSet", "gone")
+
+	client := fake.NewSimpleClientset(deployment, replicaSets[0], replicaSets[1], standaloneRS, statefulSet)
+	resolver := NewOwnerResolver(client)
+
+	p1 := buildOwnedPod("p1", rsRefs[0])
+	p2 := buildOwnedPod("p2", rsRefs[1])
+	p3 := buildOwnedPod("p3", standaloneRSRef)
+	p4 := buildOwnedPod("p4", ssRef)
+	p5 := buildOwnedPod("p5", goneRSRef)
+	p6 := buildOwnedPod("p6")
+
+	deploymentKey := OwnerKey{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: deploymentRef.UID}
+	standaloneRSKey := OwnerKey{Namespace: "default", APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "standalone", UID: standaloneRSRef.UID}
+	ssKey := OwnerKey{Namespace: "default", APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", UID: ssRef.UID}
+	goneRSKey := OwnerKey{Namespace: "default", APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "gone", UID: goneRSRef.UID}
+
+	groups, err := resolver.GroupByOwnerRef(ctx, []*v1.Pod{p1, p2, p3, p4, p5, p6})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[OwnerKey][]*v1.Pod{
+		deploymentKey:   {p1, p2},
+		standaloneRSKey: {p3},
+		ssKey:           {p4},
+		goneRSKey:       {p5},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Unexpected groups, expected %v, got %v", expected, groups)
+	}
+
+	for _, tc := range []struct {
+		owner       OwnerKey
+		expected    int32
+		expectError bool
+	}{
+		{owner: deploymentKey, expected: 3},
+		{owner: standaloneRSKey, expected: 2},
+		// Replicas not set default to 1
+		{owner: ssKey, expected: 1},
+		{owner: goneRSKey, expectError: true},
+		{owner: OwnerKey{Namespace: "default", Kind: "DaemonSet", Name: "agent"}, expectError: true},
+	} {
+		replicas, err := resolver.DesiredReplicas(ctx, tc.owner)
+		if tc.expectError != (err != nil) {
+			t.Errorf("%s %s: unexpected error: %v", tc.owner.Kind, tc.owner.Name, err)
+		}
+		if replicas != tc.expected {
+			t.Errorf("%s %s: expected %d desired replicas, got %d", tc.owner.Kind, tc.owner.Name, tc.expected, replicas)
+		}
+	}
+}
+
+func TestOwnerResolverOwners(t *testing.T) {
+	ctx := context.Background()
+
+	rolloutRef := metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Rollout", Name: "web", UID: "Rollout-web", Controller: utilptr.To(true)}
+	deploymentRef := controllerRef("Deployment", "web")
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: deploymentRef.UID, OwnerReferences: []metav1.OwnerReference{rolloutRef}},
+	}
+	rsRef := controllerRef("ReplicaSet", "web-1")
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", UID: rsRef.UID, OwnerReferences: []metav1.OwnerReference{deploymentRef}},
+	}
+	// The ReplicaSet got recreated, its owners are not the ones of the referenced one
+	recreatedRSRef := controllerRef("ReplicaSet", "recreated")
+	recreatedRS := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "recreated", Namespace: "default", UID: "other", OwnerReferences: []metav1.OwnerReference{deploymentRef}},
+	}
+	vmiRef := metav1.OwnerReference{APIVersion: "kubevirt.io/v1", Kind: "VirtualMachineInstance", Name: "vm", UID: "VirtualMachineInstance-vm", Controller: utilptr.To(true)}
+
+	client := fake.NewSimpleClientset(deployment, replicaSet, recreatedRS)
+	resolver := NewOwnerResolver(client)
+
+	key := func(ref metav1.OwnerReference) OwnerKey {
+		return ownerKeyOf("default", &ref)
+	}
+	for _, tc := range []struct {
+		description string
+		pod         *v1.Pod
+		expected    []OwnerKey
+	}{
+		{
+			description: "owners are followed up to a custom resource",
+			pod:         buildOwnedPod("p1", rsRef),
+			expected:    []OwnerKey{key(rsRef), key(deploymentRef), key(rolloutRef)},
+		},
+		{
+			description: "owners of custom resources are not followed",
+			pod:         buildOwnedPod("p2", vmiRef),
+			expected:    []OwnerKey{key(vmiRef)},
+		},
+		{
+			description: "owners of recreated owners are not followed",
+			pod:         buildOwnedPod("p3", recreatedRSRef),
+			expected:    []OwnerKey{key(recreatedRSRef)},
+		},
+		{
+			description: "pod without owner",
+			pod:         buildOwnedPod("p4"),
+			expected:    nil,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			owners, err := resolver.Owners(ctx, tc.pod)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(owners, tc.expected) {
+				t.Errorf("Unexpected owners, expected %v, got %v", tc.expected, owners)
+			}
+		})
+	}
+}
@@ -18,10 +18,12 @@ package pod
 
 import (
 	"sort"
+	"strconv"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 
@@ -30,6 +32,11 @@ import (
 
 const (
 	nodeNameKeyIndex = "spec.nodeName"
+
+	// EvictionCostAnnotation sets the cost of evicting a pod, pods with a higher cost being
+	// evicted last. It takes precedence over the pod deletion cost annotation, so pods can be
+	// ordered for the descheduler without changing the order their controllers scale them down.
+	EvictionCostAnnotation = "descheduler.alpha.kubernetes.io/eviction-cost"
 )
 
 // FilterFunc is a filter for a pod.
@@ -227,6 +234,15 @@ func OwnerRefUIDs(pod *v1.Pod) []string {
 	return ownerRefUIDs
 }
 
+// OwnerUID returns the UID of the first owner of the pod, the UID of the pod itself when
+// unowned, e.g. to budget evictions by workload.
+func OwnerUID(pod *v1.Pod) types.UID {
+	if ownerRefs := OwnerRef(pod); len(ownerRefs) > 0 {
+		return ownerRefs[0].UID
+	}
+	return pod.UID
+}
+
 func IsBestEffortPod(pod *v1.Pod) bool {
 	return utils.GetPodQOS(pod) == v1.PodQOSBestEffort
 }
@@ -239,9 +255,26 @@ func IsGuaranteedPod(pod *v1.Pod) bool {
 	return utils.GetPodQOS(pod) == v1.PodQOSGuaranteed
 }
 
+// EvictionCost returns the cost of evicting the pod, as set by the eviction cost annotation or
+// else by the pod deletion cost annotation. Missing or invalid costs are 0.
+func EvictionCost(pod *v1.Pod) int32 {
+	for _, annotation := range []string{EvictionCostAnnotation, v1.PodDeletionCost} {
+		value, ok := pod.Annotations[annotation]
+		if !ok {
+			continue
+		}
+		cost, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			continue
+		}
+		return int32(cost)
+	}
+	return 0
+}
+
 // SortPodsBasedOnPriorityLowToHigh sorts pods based on their priorities from low to high.
-// If pods have same priorities, they will be sorted by QoS in the following order:
-// BestEffort, Burstable, Guaranteed
+// If pods have same priorities, they will be sorted by eviction cost from low to high, and
+// then by QoS in the following order: BestEffort, Burstable, Guaranteed
 func SortPodsBasedOnPriorityLowToHigh(pods []*v1.Pod) {
 	sort.Slice(pods, func(i, j int) bool {
 		if pods[i].Spec.Priority == nil && pods[j].Spec.Priority != nil {
@@ -251,6 +284,9 @@ func SortPodsBasedOnPriorityLowToHigh(pods []*v1.Pod) {
 			return false
 		}
 		if (pods[j].Spec.Priority == nil && pods[i].Spec.Priority == nil) || (*pods[i].Spec.Priority == *pods[j].Spec.Priority) {
+			if costI, costJ := EvictionCost(pods[i]), EvictionCost(pods[j]); costI != costJ {
+				return costI < costJ
+			}
 			if IsBestEffortPod(pods[i]) {
 				return true
 			}
@@ -263,6 +299,15 @@ func SortPodsBasedOnPriorityLowToHigh(pods []*v1.Pod) {
 	})
 }
 
+// SortPodsBasedOnEvictionCost sorts pods based on their eviction cost from low to high,
+// keeping the order of the pods with the same cost. It is meant to be called once the pods
+// are sorted by the order specific to a plugin.
+func SortPodsBasedOnEvictionCost(pods []*v1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return EvictionCost(pods[i]) < EvictionCost(pods[j])
+	})
+}
+
 // SortPodsBasedOnAge sorts Pods from oldest to most recent in place
 func SortPodsBasedOnAge(pods []*v1.Pod) {
 	sort.Slice(pods, func(i, j int) bool {
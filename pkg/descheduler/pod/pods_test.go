@@ -157,6 +157,100 @@ func TestSortPodsBasedOnPriorityLowToHigh(t *testing.T) {
 	}
 }
 
+func TestSortPodsBasedOnPriorityLowToHighWithEvictionCost(t *testing.T) {
+	withCost := func(annotation, cost string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			test.SetPodPriority(pod, lowPriority)
+			pod.Annotations = map[string]string{annotation: cost}
+		}
+	}
+	p1 := test.BuildTestPod("p1", 400, 0, "n1", withCost(v1.PodDeletionCost, "100"))
+	p2 := test.BuildTestPod("p2", 400, 0, "n1", withCost(EvictionCostAnnotation, "-10"))
+	p3 := test.BuildTestPod("p3", 400, 0, "n1", func(pod *v1.Pod) {
+		test.SetPodPriority(pod, lowPriority)
+	})
+	// The priority comes before the cost
+	p4 := test.BuildTestPod("p4", 400, 0, "n1", func(pod *v1.Pod) {
+		test.SetPodPriority(pod, highPriority)
+		pod.Annotations = map[string]string{v1.PodDeletionCost: "-100"}
+	})
+
+	podList := []*v1.Pod{p4, p1, p3, p2}
+	SortPodsBasedOnPriorityLowToHigh(podList)
+	var names []string
+	for _, pod := range podList {
+		names = append(names, pod.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"p2", "p3", "p1", "p4"}) {
+		t.Errorf("Expected pods to be sorted by priority and then by eviction cost, got %v", names)
+	}
+}
+
+func TestEvictionCost(t *testing.T) {
+	tests := []struct {
+		description string
+		annotations map[string]string
+		expected    int32
+	}{
+		{
+			description: "no annotation",
+			expected:    0,
+		},
+		{
+			description: "pod deletion cost",
+			annotations: map[string]string{v1.PodDeletionCost: "10"},
+			expected:    10,
+		},
+		{
+			description: "eviction cost takes precedence",
+			annotations: map[string]string{v1.PodDeletionCost: "10", EvictionCostAnnotation: "-5"},
+			expected:    -5,
+		},
+		{
+			description: "invalid eviction cost falls back to the pod deletion cost",
+			annotations: map[string]string{v1.PodDeletionCost: "10", EvictionCostAnnotation: "high"},
+			expected:    10,
+		},
+		{
+			description: "out of range cost",
+			annotations: map[string]string{v1.PodDeletionCost: "4294967296"},
+			expected:    0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 400, 0, "n1", func(pod *v1.Pod) {
+				pod.Annotations = tc.annotations
+			})
+			if cost := EvictionCost(pod); cost != tc.expected {
+				t.Errorf("Expected eviction cost %d, got %d", tc.expected, cost)
+			}
+		})
+	}
+}
+
+func TestSortPodsBasedOnEvictionCost(t *testing.T) {
+	var podList []*v1.Pod
+	for i, cost := range []string{"5", "", "-1", "5", ""} {
+		podList = append(podList, test.BuildTestPod(fmt.Sprintf("p%d", i), 1, 32, "n1", func(pod *v1.Pod) {
+			if cost != "" {
+				pod.Annotations = map[string]string{EvictionCostAnnotation: cost}
+			}
+		}))
+	}
+
+	SortPodsBasedOnEvictionCost(podList)
+
+	var names []string
+	for _, pod := range podList {
+		names = append(names, pod.Name)
+	}
+	// Pods with the same cost keep their order
+	if !reflect.DeepEqual(names, []string{"p2", "p1", "p4", "p0", "p3"}) {
+		t.Errorf("Expected pods to be sorted by eviction cost, got %v", names)
+	}
+}
+
 func TestSortPodsBasedOnAge(t *testing.T) {
 	podList := make([]*v1.Pod, 9)
 	n1 := test.BuildTestNode("n1", 4000, 3000, int64(len(podList)), nil)
@@ -240,3 +334,14 @@ func TestGroupByNodeName(t *testing.T) {
 		})
 	}
 }
+
+func TestOwnerUID(t *testing.T) {
+	owned := &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod", OwnerReferences: []metav1.OwnerReference{{UID: "owner"}}}}
+	if uid := OwnerUID(owned); uid != "owner" {
+		t.Errorf("Expected the UID of the owner, got %q", uid)
+	}
+	unowned := &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "pod"}}
+	if uid := OwnerUID(unowned); uid != "pod" {
+		t.Errorf("Expected the UID of the pod, got %q", uid)
+	}
+}
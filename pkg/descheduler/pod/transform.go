@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PodFields lists the pod fields a consumer of the pod cache reads
+// which the cache transform would drop otherwise.
+type PodFields struct {
+	// Annotations are the keys of the pod annotations to keep regardless of their size
+	Annotations []string
+	// ContainerEnv keeps the environment variables of the pod containers
+	ContainerEnv bool
+}
+
+// CacheTransformOptions configures which fields are dropped from the cached pods.
+type CacheTransformOptions struct {
+	// MaxAnnotationSize drops the pod annotations whose value is longer than the given
+	// number of bytes. No annotation is dropped when nil.
+	MaxAnnotationSize *int32
+	// StripContainerEnv drops the env and envFrom of all the pod containers.
+	StripContainerEnv bool
+	// Required lists the fields kept regardless of the options above.
+	Required []PodFields
+}
+
+// alwaysKeptAnnotations are read by the descheduler itself when deciding whether a pod can be evicted
+var alwaysKeptAnnotations = []string{
+	v1.MirrorPodAnnotationKey,
+	"kubernetes.io/config.source",
+}
+
+// NewCacheTransform returns a transform for the shared informers which drops the managed fields
+// of every object, and the pod fields configured in the options unless they are required.
+// Shrinking the cached objects noticeably cuts the memory of the descheduler on large clusters.
+func NewCacheTransform(options CacheTransformOptions) cache.TransformFunc {
+	keepAnnotations := sets.New(alwaysKeptAnnotations...)
+	stripContainerEnv := options.StripContainerEnv
+	for _, required := range options.Required {
+		keepAnnotations.Insert(required.Annotations...)
+		if required.ContainerEnv {
+			stripContainerEnv = false
+		}
+	}
+
+	return func(obj interface{}) (interface{}, error) {
+		if accessor, err := meta.Accessor(obj); err == nil {
+			accessor.SetManagedFields(nil)
+		}
+		pod, ok := obj.(*v1.Pod)
+		if !ok {
+			return obj, nil
+		}
+		if options.MaxAnnotationSize != nil {
+			for key, value := range pod.Annotations {
+				if len(value) > int(*options.MaxAnnotationSize) && !keepAnnotations.Has(key) {
+					delete(pod.Annotations, key)
+				}
+			}
+		}
+		if stripContainerEnv {
+			for i := range pod.Spec.InitContainers {
+				pod.Spec.InitContainers[i].Env = nil
+				pod.Spec.InitContainers[i].EnvFrom = nil
+			}
+			for i := range pod.Spec.Containers {
+				pod.Spec.Containers[i].Env = nil
+				pod.Spec.Containers[i].EnvFrom = nil
+			}
+			for i := range pod.Spec.EphemeralContainers {
+				pod.Spec.EphemeralContainers[i].Env = nil
+				pod.Spec.EphemeralContainers[i].EnvFrom = nil
+			}
+		}
+		return pod, nil
+	}
+}
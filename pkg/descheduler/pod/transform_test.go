@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestNewCacheTransform(t *testing.T) {
+	largeValue := strings.Repeat("x", 1024)
+	env := []v1.EnvVar{{Name: "FOO", Value: "bar"}}
+	envFrom := []v1.EnvFromSource{{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "cm"}}}}
+
+	buildPod := func(annotations map[string]string, withEnv bool) *v1.Pod {
+		return test.BuildTestPod("p1", 100, 0, "n1", func(pod *v1.Pod) {
+			pod.UID = "p1"
+			pod.Annotations = annotations
+			pod.Spec.InitContainers = []v1.Container{{Name: "init"}}
+			if withEnv {
+				pod.Spec.InitContainers[0].Env = env
+				pod.Spec.Containers[0].Env = env
+				pod.Spec.Containers[0].EnvFrom = envFrom
+			}
+		})
+	}
+	allAnnotations := map[string]string{
+		"small":                   "value",
+		"large":                   largeValue,
+		"required":                largeValue,
+		v1.MirrorPodAnnotationKey: largeValue,
+	}
+
+	tests := []struct {
+		description string
+		options     CacheTransformOptions
+		expected    *v1.Pod
+	}{
+		{
+			description: "no pod field dropped by default",
+			expected:    buildPod(allAnnotations, true),
+		},
+		{
+			description: "large annotations dropped",
+			options: CacheTransformOptions{
+				MaxAnnotationSize: utilptr.To[int32](512),
+			},
+			expected: buildPod(map[string]string{
+				"small":                   "value",
+				v1.MirrorPodAnnotationKey: largeValue,
+			}, true),
+		},
+		{
+			description: "large annotations dropped except the required ones",
+			options: CacheTransformOptions{
+				MaxAnnotationSize: utilptr.To[int32](512),
+				Required:          []PodFields{{Annotations: []string{"required"}}},
+			},
+			expected: buildPod(map[string]string{
+				"small":                   "value",
+				"required":                largeValue,
+				v1.MirrorPodAnnotationKey: largeValue,
+			}, true),
+		},
+		{
+			description: "all annotations dropped with a zero size",
+			options: CacheTransformOptions{
+				MaxAnnotationSize: utilptr.To[int32](0),
+			},
+			expected: buildPod(map[string]string{
+				v1.MirrorPodAnnotationKey: largeValue,
+			}, true),
+		},
+		{
+			description: "container env dropped",
+			options: CacheTransformOptions{
+				StripContainerEnv: true,
+			},
+			expected: buildPod(allAnnotations, false),
+		},
+		{
+			description: "container env kept when required",
+			options: CacheTransformOptions{
+				StripContainerEnv: true,
+				Required:          []PodFields{{}, {ContainerEnv: true}},
+			},
+			expected: buildPod(allAnnotations, true),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := buildPod(maps.Clone(allAnnotations), true)
+			pod.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubectl"}}
+
+			obj, err := NewCacheTransform(tc.options)(pod)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expected, obj); diff != "" {
+				t.Errorf("Unexpected transformed pod (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewCacheTransformNonPodObjects(t *testing.T) {
+	node := test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+		node.Annotations = map[string]string{"large": strings.Repeat("x", 1024)}
+		node.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "kubelet"}}
+	})
+
+	obj, err := NewCacheTransform(CacheTransformOptions{MaxAnnotationSize: utilptr.To[int32](0)})(node)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	transformed := obj.(*v1.Node)
+	if transformed.ManagedFields != nil {
+		t.Errorf("Expected the managed fields to be dropped, got %v", transformed.ManagedFields)
+	}
+	if len(transformed.Annotations) != 1 {
+		t.Errorf("Expected the node annotations to be kept, got %v", transformed.Annotations)
+	}
+}
@@ -20,22 +20,32 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"k8s.io/apimachinery/pkg/runtime"
+
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
 	"sigs.k8s.io/descheduler/pkg/descheduler/scheme"
+	"sigs.k8s.io/descheduler/pkg/features"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
-func LoadPolicyConfig(policyConfigFile string, client clientset.Interface, registry pluginregistry.Registry) (*api.DeschedulerPolicy, error) {
+// LoadPolicyConfig reads and decodes the policy config file, YAML or JSON. Unless strict is false,
+// the fields which are not part of the policy nor of the plugin arguments fail the decoding.
+func LoadPolicyConfig(policyConfigFile string, strict bool, client clientset.Interface, registry pluginregistry.Registry) (*api.DeschedulerPolicy, error) {
 	if policyConfigFile == "" {
 		klog.V(1).InfoS("Policy config file not specified")
 		return nil, nil
@@ -46,18 +56,30 @@ func LoadPolicyConfig(policyConfigFile string, client clientset.Interface, regis
 		return nil, fmt.Errorf("failed to read policy config file %q: %+v", policyConfigFile, err)
 	}
 
-	return decode(policyConfigFile, policy, client, registry)
+	return decode(policyConfigFile, policy, strict, client, registry)
 }
 
-func decode(policyConfigFile string, policy []byte, client clientset.Interface, registry pluginregistry.Registry) (*api.DeschedulerPolicy, error) {
+func decode(policyConfigFile string, policy []byte, strict bool, client clientset.Interface, registry pluginregistry.Registry) (*api.DeschedulerPolicy, error) {
 	internalPolicy := &api.DeschedulerPolicy{}
 	var err error
 
+	policy, fieldErrs := checkPolicyFields(policy, registry)
+	if len(fieldErrs) > 0 {
+		if strict {
+			return nil, fmt.Errorf("failed decoding descheduler's policy config %q: %v", policyConfigFile, utilerrors.NewAggregate(fieldErrs))
+		}
+		for _, fieldErr := range fieldErrs {
+			klog.InfoS("Warning: ignoring field of the policy config", "file", policyConfigFile, "err", fieldErr)
+		}
+	}
+
 	decoder := scheme.Codecs.UniversalDecoder(v1alpha2.SchemeGroupVersion, api.SchemeGroupVersion)
 	if err := runtime.DecodeInto(decoder, policy, internalPolicy); err != nil {
 		return nil, fmt.Errorf("failed decoding descheduler's policy config %q: %v", policyConfigFile, err)
 	}
 
+	applyPluginDefaults(internalPolicy)
+
 	err = validateDeschedulerConfiguration(*internalPolicy, registry)
 	if err != nil {
 		return nil, err
@@ -135,7 +157,22 @@ func setDefaultEvictor(profile api.DeschedulerProfile, client clientset.Interfac
 
 func validateDeschedulerConfiguration(in api.DeschedulerPolicy, registry pluginregistry.Registry) error {
 	var errorsInProfiles []error
+	// The labels of all the profiles share the label names of the profile labels metric
+	metricLabels := map[string]string{}
 	for _, profile := range in.Profiles {
+		for key, value := range profile.Labels {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: invalid label key %q: %s", profile.Name, key, strings.Join(errs, "; ")))
+			}
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: invalid value %q of label %q: %s", profile.Name, value, key, strings.Join(errs, "; ")))
+			}
+			name := metrics.ProfileLabelName(key)
+			if other, ok := metricLabels[name]; ok && other != key {
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: labels %q and %q are both reported as %s", profile.Name, other, key, name))
+			}
+			metricLabels[name] = key
+		}
 		for _, pluginConfig := range profile.PluginConfigs {
 			if _, ok := registry[pluginConfig.Name]; !ok {
 				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: plugin %s in pluginConfig not registered", profile.Name, pluginConfig.Name))
@@ -151,5 +188,149 @@ func validateDeschedulerConfiguration(in api.DeschedulerPolicy, registry pluginr
 			}
 		}
 	}
+	for _, namespace := range in.WatchedNamespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("invalid watched namespace %q: %s", namespace, strings.Join(errs, "; ")))
+		}
+	}
+	if in.NodeSelector != nil {
+		if _, err := labels.Parse(*in.NodeSelector); err != nil {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("invalid node selector: %v", err))
+		}
+	}
+	if in.NodeFieldSelector != nil {
+		if _, err := fields.ParseSelector(*in.NodeFieldSelector); err != nil {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("invalid node field selector: %v", err))
+		}
+	}
+	if in.NodeListPageSize != nil && *in.NodeListPageSize < 0 {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("node list page size can not be negative"))
+	}
+	if in.EvictionPacing != nil && in.EvictionPacing.Period != nil && in.EvictionPacing.Period.Duration < 0 {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("eviction pacing period can not be negative"))
+	}
+	if in.PodCache != nil && in.PodCache.MaxAnnotationSize != nil && *in.PodCache.MaxAnnotationSize < 0 {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("pod cache max annotation size can not be negative"))
+	}
+	if in.PodCache != nil && (in.PodCache.LabelSelector != "" || in.PodCache.FieldSelector != "") {
+		if _, err := labels.Parse(in.PodCache.LabelSelector); err != nil {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("invalid pod cache label selector: %v", err))
+		}
+		if _, err := fields.ParseSelector(in.PodCache.FieldSelector); err != nil {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("invalid pod cache field selector: %v", err))
+		}
+		// Plugins seeing only part of the pods of the nodes would take wrong decisions
+		for _, profile := range in.Profiles {
+			for _, pluginConfig := range profile.PluginConfigs {
+				if requirer, ok := pluginConfig.Args.(frameworktypes.AllPodsRequirer); ok && requirer.RequiresAllPods() {
+					errorsInProfiles = append(errorsInProfiles, fmt.Errorf("in profile %s: plugin %s requires all the pods while the pod cache is restricted by selectors", profile.Name, pluginConfig.Name))
+				}
+			}
+		}
+	}
+	if in.NodeDisruptionGuard != nil && in.NodeDisruptionGuard.Window != nil && in.NodeDisruptionGuard.Window.Duration < 0 {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("node disruption guard window can not be negative"))
+	}
+	if in.ClientConnection != nil {
+		if in.ClientConnection.QPS != nil && *in.ClientConnection.QPS <= 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("client connection qps must be greater than zero"))
+		}
+		if in.ClientConnection.Burst != nil && *in.ClientConnection.Burst <= 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("client connection burst must be greater than zero"))
+		}
+	}
+	if in.InformerResyncPeriod != nil && in.InformerResyncPeriod.Duration < 0 {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("informer resync period can not be negative"))
+	}
+	if in.EvictionTimeout != nil && in.EvictionTimeout.Duration < 0 {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("eviction timeout can not be negative"))
+	}
+	if in.EvictionThrottling != nil {
+		if in.EvictionThrottling.ThrottledEvictions != nil && *in.EvictionThrottling.ThrottledEvictions < 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("eviction throttling throttled evictions can not be negative"))
+		}
+		_, initialBackoff, maxBackoff := evictionThrottling(in.EvictionThrottling)
+		if initialBackoff <= 0 || maxBackoff <= 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("eviction throttling backoffs must be greater than zero"))
+		} else if maxBackoff < initialBackoff {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("eviction throttling max backoff can not be lower than the initial backoff"))
+		}
+	}
+	if in.EvictionBatching != nil {
+		batchSize, flushInterval := evictionBatching(in.EvictionBatching)
+		if batchSize <= 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("eviction batching batch size must be greater than zero"))
+		}
+		if flushInterval <= 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("eviction batching flush interval must be greater than zero"))
+		}
+	}
+	if in.DisruptionBudgetPacing != nil {
+		if in.DisruptionBudgetPacing.RetryInterval != nil && in.DisruptionBudgetPacing.RetryInterval.Duration <= 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("disruption budget pacing retry interval must be greater than zero"))
+		}
+		if in.DisruptionBudgetPacing.Retries != nil && *in.DisruptionBudgetPacing.Retries < 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("disruption budget pacing retries can not be negative"))
+		}
+	}
+	if in.CycleAnomalyGuard != nil && in.CycleAnomalyGuard.MaxEvictionPercentage != nil {
+		if percentage := *in.CycleAnomalyGuard.MaxEvictionPercentage; percentage <= 0 || percentage > 100 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("cycle anomaly guard max eviction percentage must be greater than 0 and at most 100"))
+		}
+	}
+	if in.StateStore != nil {
+		if in.StateStore.Namespace != "" {
+			if errs := validation.IsDNS1123Label(in.StateStore.Namespace); len(errs) > 0 {
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("invalid state store namespace %q: %s", in.StateStore.Namespace, strings.Join(errs, "; ")))
+			}
+		}
+		if in.StateStore.Name != "" {
+			if errs := validation.IsDNS1123Subdomain(in.StateStore.Name); len(errs) > 0 {
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("invalid state store name %q: %s", in.StateStore.Name, strings.Join(errs, "; ")))
+			}
+		}
+	}
+	if err := features.ValidateFeatureGates(in.FeatureGates); err != nil {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("invalid feature gates: %v", err))
+	}
+	if in.PluginExecution != nil {
+		if in.PluginExecution.Timeout != nil && in.PluginExecution.Timeout.Duration < 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("plugin execution timeout can not be negative"))
+		}
+		for plugin, timeout := range in.PluginExecution.PluginTimeouts {
+			if _, ok := registry[plugin]; !ok {
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("plugin %s in pluginTimeouts not registered", plugin))
+			}
+			if timeout.Duration < 0 {
+				errorsInProfiles = append(errorsInProfiles, fmt.Errorf("timeout of plugin %s can not be negative", plugin))
+			}
+		}
+		if in.PluginExecution.FailureThreshold != nil && *in.PluginExecution.FailureThreshold <= 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("plugin execution failure threshold must be greater than zero"))
+		}
+		if in.PluginExecution.CooldownCycles != nil && *in.PluginExecution.CooldownCycles < 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("plugin execution cooldown cycles can not be negative"))
+		}
+		if in.PluginExecution.CycleDeadline != nil && in.PluginExecution.CycleDeadline.Duration < 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("plugin execution cycle deadline can not be negative"))
+		}
+		if in.PluginExecution.RunSkippedPluginsFirst && (in.PluginExecution.CycleDeadline == nil || in.PluginExecution.CycleDeadline.Duration == 0) {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("running the skipped plugins first requires a cycle deadline"))
+		}
+	}
+	if disruptionHeadroomOrdering(in.EvictionOrdering) && in.MaxNoOfPodsToEvictTotal == nil && in.MaxNoOfPodsToEvictPerNamespace == nil {
+		errorsInProfiles = append(errorsInProfiles, fmt.Errorf("ordering the evictions by disruption headroom requires maxNoOfPodsToEvictTotal or maxNoOfPodsToEvictPerNamespace"))
+	}
+	if err := validatePluginDefaults(in); err != nil {
+		errorsInProfiles = append(errorsInProfiles, err)
+	}
+	for plugin, level := range in.PluginLogVerbosity {
+		if _, ok := registry[plugin]; !ok {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("plugin %s in pluginLogVerbosity not registered", plugin))
+		}
+		if level < 0 {
+			errorsInProfiles = append(errorsInProfiles, fmt.Errorf("log verbosity of plugin %s can not be negative", plugin))
+		}
+	}
 	return utilerrors.NewAggregate(errorsInProfiles)
 }
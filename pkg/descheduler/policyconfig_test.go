@@ -19,14 +19,18 @@ package descheduler
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/conversion"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	utilptr "k8s.io/utils/ptr"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingtopologyspreadconstraint"
@@ -95,7 +99,7 @@ profiles:
 									EvictLocalStoragePods:   true,
 									EvictDaemonSetPods:      true,
 									PriorityThreshold:       &api.PriorityThreshold{Value: utilptr.To[int32](2000000000)},
-									NodeFit:                 true,
+									NodeFit:                 utilptr.To(true),
 								},
 							},
 							{
@@ -125,7 +129,7 @@ profiles:
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			result, err := decode("filename", tc.policy, client, pluginregistry.PluginRegistry)
+			result, err := decode("filename", tc.policy, true, client, pluginregistry.PluginRegistry)
 			if err != nil {
 				if tc.err == nil {
 					t.Errorf("unexpected error: %s.", err.Error())
@@ -162,9 +166,11 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 							{
 								Name: removefailedpods.PluginName,
 								Args: &removefailedpods.RemoveFailedPodsArgs{
-									Namespaces: &api.Namespaces{
-										Include: []string{"test1"},
-										Exclude: []string{"test1"},
+									FilteringArgs: api.FilteringArgs{
+										Namespaces: &api.Namespaces{
+											Include: []string{"test1"},
+											Exclude: []string{"test1"},
+										},
 									},
 								},
 							},
@@ -179,9 +185,11 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 							{
 								Name: removepodsviolatingtopologyspreadconstraint.PluginName,
 								Args: &removepodsviolatingtopologyspreadconstraint.RemovePodsViolatingTopologySpreadConstraintArgs{
-									Namespaces: &api.Namespaces{
-										Include: []string{"test1"},
-										Exclude: []string{"test1"},
+									FilteringArgs: api.FilteringArgs{
+										Namespaces: &api.Namespaces{
+											Include: []string{"test1"},
+											Exclude: []string{"test1"},
+										},
 									},
 								},
 							},
@@ -191,6 +199,261 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 			},
 			result: fmt.Errorf("[in profile RemoveFailedPods: only one of Include/Exclude namespaces can be set, in profile RemovePodsViolatingTopologySpreadConstraint: only one of Include/Exclude namespaces can be set]"),
 		},
+		{
+			description: "invalid watched namespace",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				WatchedNamespaces: []string{"team-a", "Team_B"},
+			},
+			result: fmt.Errorf("invalid watched namespace \"Team_B\": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+		},
+		{
+			description: "invalid node field selector",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				NodeFieldSelector: utilptr.To("spec.unschedulable"),
+			},
+			result: fmt.Errorf("invalid node field selector: invalid selector: 'spec.unschedulable'; can't understand 'spec.unschedulable'"),
+		},
+		{
+			description: "negative node list page size",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				NodeListPageSize: utilptr.To[int64](-1),
+			},
+			result: fmt.Errorf("node list page size can not be negative"),
+		},
+		{
+			description: "negative eviction pacing period",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionPacing: &api.EvictionPacing{
+					Period: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			result: fmt.Errorf("eviction pacing period can not be negative"),
+		},
+		{
+			description: "negative pod cache max annotation size",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				PodCache: &api.PodCache{
+					MaxAnnotationSize: utilptr.To[int32](-1),
+				},
+			},
+			result: fmt.Errorf("pod cache max annotation size can not be negative"),
+		},
+		{
+			description: "invalid pod cache label selector",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				PodCache: &api.PodCache{
+					LabelSelector: "app in",
+				},
+			},
+			result: fmt.Errorf("invalid pod cache label selector: unable to parse requirement: found '' expected: '('"),
+		},
+		{
+			description: "plugin requiring all the pods with a restricted pod cache",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				PodCache: &api.PodCache{
+					FieldSelector: "metadata.namespace!=kube-system",
+				},
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name: "profile",
+						Plugins: api.Plugins{
+							Balance: api.PluginSet{Enabled: []string{removepodsviolatingtopologyspreadconstraint.PluginName}},
+						},
+						PluginConfigs: []api.PluginConfig{
+							{
+								Name: removefailedpods.PluginName,
+								Args: &removefailedpods.RemoveFailedPodsArgs{},
+							},
+							{
+								Name: removepodsviolatingtopologyspreadconstraint.PluginName,
+								Args: &removepodsviolatingtopologyspreadconstraint.RemovePodsViolatingTopologySpreadConstraintArgs{},
+							},
+						},
+					},
+				},
+			},
+			result: fmt.Errorf("in profile profile: plugin RemovePodsViolatingTopologySpreadConstraint requires all the pods while the pod cache is restricted by selectors"),
+		},
+		{
+			description: "negative node disruption guard window",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				NodeDisruptionGuard: &api.NodeDisruptionGuard{
+					Window: &metav1.Duration{Duration: -time.Minute},
+				},
+			},
+			result: fmt.Errorf("node disruption guard window can not be negative"),
+		},
+		{
+			description: "non positive client connection qps",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				ClientConnection: &api.ClientConnection{
+					QPS: utilptr.To[float32](0),
+				},
+			},
+			result: fmt.Errorf("client connection qps must be greater than zero"),
+		},
+		{
+			description: "non positive client connection burst",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				ClientConnection: &api.ClientConnection{
+					QPS:   utilptr.To[float32](50),
+					Burst: utilptr.To[int32](-1),
+				},
+			},
+			result: fmt.Errorf("client connection burst must be greater than zero"),
+		},
+		{
+			description: "negative informer resync period and eviction timeout",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				InformerResyncPeriod: &metav1.Duration{Duration: -time.Minute},
+				EvictionTimeout:      &metav1.Duration{Duration: -time.Minute},
+			},
+			result: fmt.Errorf("[informer resync period can not be negative, eviction timeout can not be negative]"),
+		},
+		{
+			description: "eviction throttling max backoff lower than the initial one",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionThrottling: &api.EvictionThrottling{
+					InitialBackoff: &metav1.Duration{Duration: time.Minute},
+					MaxBackoff:     &metav1.Duration{Duration: time.Second},
+				},
+			},
+			result: fmt.Errorf("eviction throttling max backoff can not be lower than the initial backoff"),
+		},
+		{
+			description: "invalid eviction throttling",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionThrottling: &api.EvictionThrottling{
+					ThrottledEvictions: utilptr.To[int32](-1),
+					InitialBackoff:     &metav1.Duration{Duration: 0},
+				},
+			},
+			result: fmt.Errorf("[eviction throttling throttled evictions can not be negative, eviction throttling backoffs must be greater than zero]"),
+		},
+		{
+			description: "invalid eviction batching",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionBatching: &api.EvictionBatching{
+					BatchSize:     utilptr.To[int32](0),
+					FlushInterval: &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			result: fmt.Errorf("[eviction batching batch size must be greater than zero, eviction batching flush interval must be greater than zero]"),
+		},
+		{
+			description: "invalid disruption budget pacing",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				DisruptionBudgetPacing: &api.DisruptionBudgetPacing{
+					RetryInterval: &metav1.Duration{Duration: 0},
+					Retries:       utilptr.To[int32](-1),
+				},
+			},
+			result: fmt.Errorf("[disruption budget pacing retry interval must be greater than zero, disruption budget pacing retries can not be negative]"),
+		},
+		{
+			description: "invalid cycle anomaly guard",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				CycleAnomalyGuard: &api.CycleAnomalyGuard{
+					MaxEvictionPercentage: utilptr.To[float64](150),
+				},
+			},
+			result: fmt.Errorf("cycle anomaly guard max eviction percentage must be greater than 0 and at most 100"),
+		},
+		{
+			description: "invalid state store",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				StateStore: &api.StateStore{
+					Namespace: "Kube_System",
+				},
+			},
+			result: fmt.Errorf("invalid state store namespace \"Kube_System\": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+		},
+		{
+			description: "unknown feature gate",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				FeatureGates: map[string]bool{"MetricsBasedUtilization": true, "UnknownFeature": true},
+			},
+			result: fmt.Errorf("invalid feature gates: unrecognized feature gate: UnknownFeature"),
+		},
+		{
+			description: "log verbosity of an unknown plugin",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				PluginLogVerbosity: map[string]int32{"UnknownPlugin": 5},
+			},
+			result: fmt.Errorf("plugin UnknownPlugin in pluginLogVerbosity not registered"),
+		},
+		{
+			description: "negative plugin log verbosity",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				PluginLogVerbosity: map[string]int32{"RemoveFailedPods": -1},
+			},
+			result: fmt.Errorf("log verbosity of plugin RemoveFailedPods can not be negative"),
+		},
+		{
+			description: "invalid plugin execution",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				PluginExecution: &api.PluginExecution{
+					Timeout:          &metav1.Duration{Duration: -time.Second},
+					PluginTimeouts:   map[string]metav1.Duration{"UnknownPlugin": {Duration: time.Minute}},
+					FailureThreshold: utilptr.To[int32](0),
+					CooldownCycles:   utilptr.To[int32](-1),
+				},
+			},
+			result: fmt.Errorf("[plugin execution timeout can not be negative, plugin UnknownPlugin in pluginTimeouts not registered, plugin execution failure threshold must be greater than zero, plugin execution cooldown cycles can not be negative]"),
+		},
+		{
+			description: "invalid plugin execution cycle deadline",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				PluginExecution: &api.PluginExecution{
+					CycleDeadline: &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			result: fmt.Errorf("plugin execution cycle deadline can not be negative"),
+		},
+		{
+			description: "skipped plugins run first without a cycle deadline",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				PluginExecution: &api.PluginExecution{
+					RunSkippedPluginsFirst: true,
+				},
+			},
+			result: fmt.Errorf("running the skipped plugins first requires a cycle deadline"),
+		},
+		{
+			description: "invalid profile label",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Profiles: []api.DeschedulerProfile{
+					{Name: "team-a", Labels: map[string]string{"team": "a b"}},
+				},
+			},
+			result: fmt.Errorf(`in profile team-a: invalid value "a b" of label "team": a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyValue',  or 'my_value',  or '12345', regex used for validation is '(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?')`),
+		},
+		{
+			description: "profile labels reported under the same metric label",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Profiles: []api.DeschedulerProfile{
+					{Name: "team-a", Labels: map[string]string{"cost-center": "1"}},
+					{Name: "team-b", Labels: map[string]string{"cost.center": "2"}},
+				},
+			},
+			result: fmt.Errorf(`in profile team-b: labels "cost-center" and "cost.center" are both reported as label_cost_center`),
+		},
+		{
+			description: "eviction ordering without eviction limits",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionOrdering: &api.EvictionOrdering{DisruptionHeadroom: true},
+			},
+			result: fmt.Errorf("ordering the evictions by disruption headroom requires maxNoOfPodsToEvictTotal or maxNoOfPodsToEvictPerNamespace"),
+		},
+		{
+			description: "invalid defaults",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Defaults: &api.PluginDefaults{
+					Namespaces: &api.Namespaces{Include: []string{"a"}, Exclude: []string{"b"}},
+				},
+			},
+			result: fmt.Errorf("invalid defaults: only one of Include/Exclude namespaces can be set"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -252,7 +515,7 @@ profiles:
 									EvictLocalStoragePods:   true,
 									EvictDaemonSetPods:      true,
 									PriorityThreshold:       &api.PriorityThreshold{Value: utilptr.To[int32](2000000000)},
-									NodeFit:                 true,
+									NodeFit:                 utilptr.To(true),
 								},
 							},
 							{
@@ -310,7 +573,7 @@ profiles:
 									EvictLocalStoragePods:   true,
 									EvictDaemonSetPods:      true,
 									PriorityThreshold:       &api.PriorityThreshold{Value: utilptr.To[int32](2000000000)},
-									NodeFit:                 true,
+									NodeFit:                 utilptr.To(true),
 								},
 							},
 							{
@@ -335,17 +598,206 @@ profiles:
 				},
 			},
 		},
+		{
+			description: "inherit the defaults unless set by the plugins",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+defaults:
+  namespaces:
+    exclude:
+      - "kube-system"
+  labelSelector:
+    matchLabels:
+      app: web
+  priorityThreshold:
+    value: 100
+  nodeFit: true
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - name: "RemoveFailedPods"
+    - name: "RemovePodsHavingTooManyRestarts"
+      args:
+        podRestartThreshold: 10
+        namespaces:
+          include:
+            - "default"
+    plugins:
+      deschedule:
+        enabled:
+          - "RemoveFailedPods"
+          - "RemovePodsHavingTooManyRestarts"
+`),
+			result: &api.DeschedulerPolicy{
+				Defaults: &api.PluginDefaults{
+					Namespaces:        &api.Namespaces{Exclude: []string{"kube-system"}},
+					LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					PriorityThreshold: &api.PriorityThreshold{Value: utilptr.To[int32](100)},
+					NodeFit:           utilptr.To(true),
+				},
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name: "ProfileName",
+						PluginConfigs: []api.PluginConfig{
+							{
+								Name: defaultevictor.PluginName,
+								Args: &defaultevictor.DefaultEvictorArgs{
+									PriorityThreshold: &api.PriorityThreshold{Value: utilptr.To[int32](100)},
+									NodeFit:           utilptr.To(true),
+								},
+							},
+							{
+								Name: removefailedpods.PluginName,
+								Args: &removefailedpods.RemoveFailedPodsArgs{
+									FilteringArgs: api.FilteringArgs{
+										Namespaces:    &api.Namespaces{Exclude: []string{"kube-system"}},
+										LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+									},
+									MinPodLifetimeSeconds: utilptr.To[uint](3600),
+								},
+							},
+							{
+								Name: removepodshavingtoomanyrestarts.PluginName,
+								Args: &removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestartsArgs{
+									FilteringArgs: api.FilteringArgs{
+										Namespaces:    &api.Namespaces{Include: []string{"default"}},
+										LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+									},
+									PodRestartThreshold: 10,
+								},
+							},
+						},
+						Plugins: api.Plugins{
+							Filter: api.PluginSet{
+								Enabled: []string{defaultevictor.PluginName},
+							},
+							PreEvictionFilter: api.PluginSet{
+								Enabled: []string{defaultevictor.PluginName},
+							},
+							Deschedule: api.PluginSet{
+								Enabled: []string{removefailedpods.PluginName, removepodshavingtoomanyrestarts.PluginName},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "inherit the excluded namespaces as evictable namespaces and keep nodeFit set by the plugins",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+defaults:
+  namespaces:
+    exclude:
+      - "kube-system"
+  nodeFit: true
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - name: "DefaultEvictor"
+      args:
+        nodeFit: false
+    - name: "LowNodeUtilization"
+      args:
+        thresholds:
+          cpu: 20
+        targetThresholds:
+          cpu: 50
+    - name: "HighNodeUtilization"
+      args:
+        thresholds:
+          cpu: 20
+        evictableNamespaces:
+          exclude:
+            - "monitoring"
+    plugins:
+      balance:
+        enabled:
+          - "LowNodeUtilization"
+          - "HighNodeUtilization"
+`),
+			result: &api.DeschedulerPolicy{
+				Defaults: &api.PluginDefaults{
+					Namespaces: &api.Namespaces{Exclude: []string{"kube-system"}},
+					NodeFit:    utilptr.To(true),
+				},
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name: "ProfileName",
+						PluginConfigs: []api.PluginConfig{
+							{
+								Name: defaultevictor.PluginName,
+								Args: &defaultevictor.DefaultEvictorArgs{
+									PriorityThreshold: &api.PriorityThreshold{Value: utilptr.To[int32](2000000000)},
+									NodeFit:           utilptr.To(false),
+								},
+							},
+							{
+								Name: nodeutilization.LowNodeUtilizationPluginName,
+								Args: &nodeutilization.LowNodeUtilizationArgs{
+									Thresholds:          api.ResourceThresholds{v1.ResourceCPU: 20},
+									TargetThresholds:    api.ResourceThresholds{v1.ResourceCPU: 50},
+									EvictableNamespaces: &api.Namespaces{Exclude: []string{"kube-system"}},
+								},
+							},
+							{
+								Name: nodeutilization.HighNodeUtilizationPluginName,
+								Args: &nodeutilization.HighNodeUtilizationArgs{
+									Thresholds:          api.ResourceThresholds{v1.ResourceCPU: 20},
+									EvictableNamespaces: &api.Namespaces{Exclude: []string{"monitoring"}},
+								},
+							},
+						},
+						Plugins: api.Plugins{
+							Filter: api.PluginSet{
+								Enabled: []string{defaultevictor.PluginName},
+							},
+							PreEvictionFilter: api.PluginSet{
+								Enabled: []string{defaultevictor.PluginName},
+							},
+							Balance: api.PluginSet{
+								Enabled: []string{nodeutilization.LowNodeUtilizationPluginName, nodeutilization.HighNodeUtilizationPluginName},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "reject the included namespaces for the plugins with evictable namespaces",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+defaults:
+  namespaces:
+    include:
+      - "default"
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - name: "HighNodeUtilization"
+      args:
+        thresholds:
+          cpu: 20
+    plugins:
+      balance:
+        enabled:
+          - "HighNodeUtilization"
+`),
+			err: fmt.Errorf("invalid defaults: in profile ProfileName: plugin HighNodeUtilization only supports excluded namespaces, it can not inherit the included namespaces"),
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.description, func(t *testing.T) {
-			result, err := decode("filename", tc.policy, client, pluginregistry.PluginRegistry)
+			result, err := decode("filename", tc.policy, true, client, pluginregistry.PluginRegistry)
 			if err != nil {
 				if tc.err == nil {
 					t.Errorf("unexpected error: %s.", err.Error())
-				} else {
+				} else if err.Error() != tc.err.Error() {
 					t.Errorf("unexpected error: %s. Was expecting %s", err.Error(), tc.err.Error())
 				}
+			} else if tc.err != nil {
+				t.Errorf("expected error: %s.", tc.err.Error())
 			}
 			diff := cmp.Diff(tc.result, result)
 			if diff != "" && err == nil {
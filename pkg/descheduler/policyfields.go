@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	pluginConfigType    = reflect.TypeOf(v1alpha2.PluginConfig{})
+)
+
+// policyFieldChecker reports the fields of a policy document which are not part of the policy,
+// nor of the arguments of the configured plugins, along with their path and line. The decoder
+// drops such fields, e.g. plugin arguments misindented at the level of the plugin config.
+type policyFieldChecker struct {
+	registry pluginregistry.Registry
+	errs     []error
+	// renamed tells whether keys matching their field in another case were renamed
+	renamed bool
+}
+
+// checkPolicyFields checks the fields of a YAML or JSON policy document. Documents which can not be
+// parsed or are not a versioned policy are left to the decoder to report. The decoder matches the
+// fields case-sensitively, so the policy is returned with the keys matching their field in another
+// case renamed to the field, like encoding/json would match them.
+func checkPolicyFields(policy []byte, registry pluginregistry.Registry) ([]byte, []error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(policy, &document); err != nil || len(document.Content) == 0 {
+		return policy, nil
+	}
+	root := document.Content[0]
+	if mappingValue(root, "apiVersion") != v1alpha2.SchemeGroupVersion.String() || mappingValue(root, "kind") != "DeschedulerPolicy" {
+		return policy, nil
+	}
+	c := &policyFieldChecker{registry: registry}
+	c.check(root, reflect.TypeOf(v1alpha2.DeschedulerPolicy{}), "")
+	if !c.renamed {
+		return policy, c.errs
+	}
+	renamed, err := yaml.Marshal(&document)
+	if err != nil {
+		return policy, append(c.errs, fmt.Errorf("unable to rename the fields of the policy: %v", err))
+	}
+	return renamed, c.errs
+}
+
+func (c *policyFieldChecker) errorf(node *yaml.Node, format string, args ...interface{}) {
+	c.errs = append(c.errs, fmt.Errorf("line %d: %s", node.Line, fmt.Sprintf(format, args...)))
+}
+
+func (c *policyFieldChecker) check(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// Types decoding themselves, e.g. durations or quantities, are not checked further
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := jsonFields(t)
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			fieldPath := joinFieldPath(path, key.Value)
+			name, fieldType, ok := lookupJSONField(fields, key.Value)
+			if !ok {
+				c.errorf(key, "unknown field %q", fieldPath)
+				continue
+			}
+			if seen[name] {
+				c.errorf(key, "duplicate field %q", fieldPath)
+				continue
+			}
+			seen[name] = true
+			if key.Value != name {
+				key.Value = name
+				c.renamed = true
+			}
+			if t == pluginConfigType && name == "args" {
+				c.checkPluginArgs(value, pluginConfigName(node), fieldPath)
+				continue
+			}
+			c.check(value, fieldType, fieldPath)
+		}
+	case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			c.check(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			c.check(node.Content[i+1], t.Elem(), joinFieldPath(path, node.Content[i].Value))
+		}
+	}
+	// Values of the wrong type are reported by the decoder
+}
+
+// checkPluginArgs checks the arguments against the arguments type of the plugin.
+// The arguments of plugins which are not registered are reported by the validation.
+func (c *policyFieldChecker) checkPluginArgs(node *yaml.Node, pluginName, path string) {
+	pluginUtilities, ok := c.registry[pluginName]
+	if !ok || pluginUtilities.PluginArgInstance == nil {
+		return
+	}
+	c.check(node, reflect.TypeOf(pluginUtilities.PluginArgInstance), path)
+}
+
+// jsonFields returns the types of the fields of the struct by their JSON name,
+// including the fields of the embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		if name == "" && field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for embeddedName, embeddedType := range jsonFields(embedded) {
+					if _, ok := fields[embeddedName]; !ok {
+						fields[embeddedName] = embeddedType
+					}
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// lookupJSONField returns the JSON name and the type of the field the key decodes into. Like
+// encoding/json, an exact match is preferred and the key is otherwise matched case-insensitively.
+func lookupJSONField(fields map[string]reflect.Type, key string) (string, reflect.Type, bool) {
+	if fieldType, ok := fields[key]; ok {
+		return key, fieldType, true
+	}
+	for name, fieldType := range fields {
+		if strings.EqualFold(name, key) {
+			return name, fieldType, true
+		}
+	}
+	return "", nil, false
+}
+
+// pluginConfigName returns the name of the plugin of a plugin config, whatever the case of its key
+func pluginConfigName(node *yaml.Node) string {
+	if name := mappingValue(node, "name"); name != "" {
+		return name
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, "name") {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// mappingValue returns the scalar value of the key of the mapping, empty if missing
+func mappingValue(node *yaml.Node, key string) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+func joinFieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
+)
+
+func TestDecodePolicyFields(t *testing.T) {
+	client := fakeclientset.NewSimpleClientset()
+	SetupPlugins()
+
+	misindentedArgs := []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - name: "RemoveFailedPods"
+    args:
+      minPodLifetimeSeconds: 3600
+    plugins:
+      deschedule:
+        enabled:
+          - "RemoveFailedPods"
+`)
+
+	testCases := []struct {
+		description string
+		policy      []byte
+		strict      bool
+		err         string
+		// podRestartThreshold is the threshold of the decoded RemovePodsHavingTooManyRestarts arguments
+		podRestartThreshold int32
+	}{
+		{
+			description: "json policy",
+			policy: []byte(`{
+  "apiVersion": "descheduler/v1alpha2",
+  "kind": "DeschedulerPolicy",
+  "profiles": [{
+    "name": "ProfileName",
+    "pluginConfig": [{"name": "RemovePodsHavingTooManyRestarts", "args": {"podRestartThreshold": 100}}],
+    "plugins": {"deschedule": {"enabled": ["RemovePodsHavingTooManyRestarts"]}}
+  }]
+}`),
+			strict:              true,
+			podRestartThreshold: 100,
+		},
+		{
+			description: "misindented plugin arguments",
+			policy:      misindentedArgs,
+			strict:      true,
+			err:         `failed decoding descheduler's policy config "filename": line 7: unknown field "profiles[0].args"`,
+		},
+		{
+			description: "misindented plugin arguments ignored when not strict",
+			policy:      misindentedArgs,
+			strict:      false,
+		},
+		{
+			description: "unknown and duplicate fields",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+maxNoOfPodsToEvictPerNode: 5
+maxNoOfPodsToEvictPerNode: 5
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - name: "RemovePodsHavingTooManyRestarts"
+      args:
+        podRestartThreshold: 100
+        includingInitContainer: true
+    plugins:
+      deschedule:
+        enabled:
+          - "RemovePodsHavingTooManyRestarts"
+`),
+			strict: true,
+			err:    `failed decoding descheduler's policy config "filename": [line 4: duplicate field "maxNoOfPodsToEvictPerNode", line 11: unknown field "profiles[0].pluginConfig[0].args.includingInitContainer"]`,
+		},
+		{
+			description: "fields matched case-insensitively",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+profiles:
+  - name: ProfileName
+    pluginConfig:
+    - Name: "RemovePodsHavingTooManyRestarts"
+      args:
+        PodRestartThreshold: 100
+    plugins:
+      preEvictionFilter:
+        enabled:
+          - "DefaultEvictor"
+      deschedule:
+        enabled:
+          - "RemovePodsHavingTooManyRestarts"
+`),
+			strict:              true,
+			podRestartThreshold: 100,
+		},
+		{
+			description: "json fields matched case-insensitively",
+			policy: []byte(`{"apiVersion": "descheduler/v1alpha2", "kind": "DeschedulerPolicy", "profiles": [{"name": "ProfileName",
+  "pluginConfig": [{"name": "RemovePodsHavingTooManyRestarts", "args": {"PodRestartThreshold": 100}}],
+  "plugins": {"Deschedule": {"enabled": ["RemovePodsHavingTooManyRestarts"]}}}]}`),
+			strict:              true,
+			podRestartThreshold: 100,
+		},
+		{
+			description: "unknown plugin arguments fields fail when not strict",
+			policy: []byte(`{"apiVersion": "descheduler/v1alpha2", "kind": "DeschedulerPolicy", "profiles": [{"name": "ProfileName",
+  "pluginConfig": [{"name": "RemovePodsHavingTooManyRestarts", "args": {"podRestartThreshold": 100, "includingInitContainer": true}}],
+  "plugins": {"deschedule": {"enabled": ["RemovePodsHavingTooManyRestarts"]}}}]}`),
+			strict: false,
+			err:    `failed decoding descheduler's policy config "filename": strict decoding error: unknown field "includingInitContainer"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			result, err := decode("filename", tc.policy, tc.strict, client, pluginregistry.PluginRegistry)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("unexpected error: %v. Was expecting %s", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var podRestartThreshold int32
+			for _, pluginConfig := range result.Profiles[0].PluginConfigs {
+				if args, ok := pluginConfig.Args.(*removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestartsArgs); ok {
+					podRestartThreshold = args.PodRestartThreshold
+				}
+			}
+			if diff := cmp.Diff(tc.podRestartThreshold, podRestartThreshold); diff != "" {
+				t.Errorf("unexpected podRestartThreshold (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,16 +18,62 @@ package descheduler
 
 import (
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/agedimagerefreshevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/antiaffinityrelaxationdetector"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/balancebycustommetric"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/consolidatestatefulsetstoragelocality"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/crossnodecostoptimizer"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/deschedulepodsbeforespotinterruption"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/deschedulepodsfornodelabelrollout"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/deschedulercanary"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/duplicatepvcmountconflictresolver"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/enforcemaxpodspernamespacepernode"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/ephemeralcontainersessionterminator"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/evictfornodecertificateorkubeletversionskew"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/evictpodsfromoverheatednodes"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/evictpodswithstaleimagepullsecrets"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/failedschedulingfeedbackloop"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/gpusharefragmentationdefragmenter"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/hotspotspreadbyservicebackend"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/hugepagesandreservedmemorymisfitevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/idlenodescaledownassistant"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/jobawarepodlifetime"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/namespacedecommissioner"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/numaawarerebalancer"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/ownerspreadacrosscontrolplaneupdates"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podschedulinggatejanitor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/podspercorerebalancer"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/preferlocalimagenodes"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/rebalancedaemonsetsurge"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removelongpendingpodsownerscaler"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removemisscheduleddaemonsetpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsfromdisconnectednodes"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsfromnodesexceedingpoddensitylimits"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsonnodespendingosupgrade"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingdedicatednodepolicy"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatinghostportconflictsrisk"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatinginterpodantiaffinity"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatinglimitranges"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnetworkpolicyisolation"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodeaffinity"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingpodtopologylabelsconsistency"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingtopologyspreadconstraint"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodswithdeprecatedapisowners"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodswithmissingserviceaccounts"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/replicasetgenerationcleaner"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/secretandconfigmapreferenceintegrityevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/sidecardriftevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/stalenodeleaseevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/statefulworkloadzonerebalancer"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/sysctlandkernelparamcompatibilityevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/workloadrightsizingnudger"
 )
 
 func SetupPlugins() {
@@ -36,15 +82,64 @@ func SetupPlugins() {
 }
 
 func RegisterDefaultPlugins(registry pluginregistry.Registry) {
+	pluginregistry.Register(antiaffinityrelaxationdetector.PluginName, antiaffinityrelaxationdetector.New, &antiaffinityrelaxationdetector.AntiAffinityRelaxationDetector{}, &antiaffinityrelaxationdetector.AntiAffinityRelaxationDetectorArgs{}, antiaffinityrelaxationdetector.ValidateAntiAffinityRelaxationDetectorArgs, antiaffinityrelaxationdetector.SetDefaults_AntiAffinityRelaxationDetectorArgs, registry)
+	pluginregistry.Register(balancebycustommetric.PluginName, balancebycustommetric.New, &balancebycustommetric.BalanceByCustomMetric{}, &balancebycustommetric.BalanceByCustomMetricArgs{}, balancebycustommetric.ValidateBalanceByCustomMetricArgs, balancebycustommetric.SetDefaults_BalanceByCustomMetricArgs, registry)
+	pluginregistry.Register(consolidatestatefulsetstoragelocality.PluginName, consolidatestatefulsetstoragelocality.New, &consolidatestatefulsetstoragelocality.ConsolidateStatefulSetStorageLocality{}, &consolidatestatefulsetstoragelocality.ConsolidateStatefulSetStorageLocalityArgs{}, consolidatestatefulsetstoragelocality.ValidateConsolidateStatefulSetStorageLocalityArgs, consolidatestatefulsetstoragelocality.SetDefaults_ConsolidateStatefulSetStorageLocalityArgs, registry)
 	pluginregistry.Register(defaultevictor.PluginName, defaultevictor.New, &defaultevictor.DefaultEvictor{}, &defaultevictor.DefaultEvictorArgs{}, defaultevictor.ValidateDefaultEvictorArgs, defaultevictor.SetDefaults_DefaultEvictorArgs, registry)
+	pluginregistry.Register(deschedulepodsbeforespotinterruption.PluginName, deschedulepodsbeforespotinterruption.New, &deschedulepodsbeforespotinterruption.DeschedulePodsBeforeSpotInterruption{}, &deschedulepodsbeforespotinterruption.DeschedulePodsBeforeSpotInterruptionArgs{}, deschedulepodsbeforespotinterruption.ValidateDeschedulePodsBeforeSpotInterruptionArgs, deschedulepodsbeforespotinterruption.SetDefaults_DeschedulePodsBeforeSpotInterruptionArgs, registry)
+	pluginregistry.Register(deschedulercanary.PluginName, deschedulercanary.New, &deschedulercanary.DeschedulerCanary{}, &deschedulercanary.DeschedulerCanaryArgs{}, deschedulercanary.ValidateDeschedulerCanaryArgs, deschedulercanary.SetDefaults_DeschedulerCanaryArgs, registry)
+	pluginregistry.Register(enforcemaxpodspernamespacepernode.PluginName, enforcemaxpodspernamespacepernode.New, &enforcemaxpodspernamespacepernode.EnforceMaxPodsPerNamespacePerNode{}, &enforcemaxpodspernamespacepernode.EnforceMaxPodsPerNamespacePerNodeArgs{}, enforcemaxpodspernamespacepernode.ValidateEnforceMaxPodsPerNamespacePerNodeArgs, enforcemaxpodspernamespacepernode.SetDefaults_EnforceMaxPodsPerNamespacePerNodeArgs, registry)
+	pluginregistry.Register(evictpodsfromoverheatednodes.PluginName, evictpodsfromoverheatednodes.New, &evictpodsfromoverheatednodes.EvictPodsFromOverheatedNodes{}, &evictpodsfromoverheatednodes.EvictPodsFromOverheatedNodesArgs{}, evictpodsfromoverheatednodes.ValidateEvictPodsFromOverheatedNodesArgs, evictpodsfromoverheatednodes.SetDefaults_EvictPodsFromOverheatedNodesArgs, registry)
+	pluginregistry.Register(evictpodswithstaleimagepullsecrets.PluginName, evictpodswithstaleimagepullsecrets.New, &evictpodswithstaleimagepullsecrets.EvictPodsWithStaleImagePullSecrets{}, &evictpodswithstaleimagepullsecrets.EvictPodsWithStaleImagePullSecretsArgs{}, evictpodswithstaleimagepullsecrets.ValidateEvictPodsWithStaleImagePullSecretsArgs, evictpodswithstaleimagepullsecrets.SetDefaults_EvictPodsWithStaleImagePullSecretsArgs, registry)
+	pluginregistry.Register(jobawarepodlifetime.PluginName, jobawarepodlifetime.New, &jobawarepodlifetime.JobAwarePodLifeTime{}, &jobawarepodlifetime.JobAwarePodLifeTimeArgs{}, jobawarepodlifetime.ValidateJobAwarePodLifeTimeArgs, jobawarepodlifetime.SetDefaults_JobAwarePodLifeTimeArgs, registry)
 	pluginregistry.Register(nodeutilization.LowNodeUtilizationPluginName, nodeutilization.NewLowNodeUtilization, &nodeutilization.LowNodeUtilization{}, &nodeutilization.LowNodeUtilizationArgs{}, nodeutilization.ValidateLowNodeUtilizationArgs, nodeutilization.SetDefaults_LowNodeUtilizationArgs, registry)
 	pluginregistry.Register(nodeutilization.HighNodeUtilizationPluginName, nodeutilization.NewHighNodeUtilization, &nodeutilization.HighNodeUtilization{}, &nodeutilization.HighNodeUtilizationArgs{}, nodeutilization.ValidateHighNodeUtilizationArgs, nodeutilization.SetDefaults_HighNodeUtilizationArgs, registry)
+	pluginregistry.Register(nodeutilization.ColdStartAwareConsolidationPluginName, nodeutilization.NewColdStartAwareConsolidation, &nodeutilization.ColdStartAwareConsolidation{}, &nodeutilization.ColdStartAwareConsolidationArgs{}, nodeutilization.ValidateColdStartAwareConsolidationArgs, nodeutilization.SetDefaults_ColdStartAwareConsolidationArgs, registry)
+	pluginregistry.Register(nodeutilization.ScheduledDownscaleHelperPluginName, nodeutilization.NewScheduledDownscaleHelper, &nodeutilization.ScheduledDownscaleHelper{}, &nodeutilization.ScheduledDownscaleHelperArgs{}, nodeutilization.ValidateScheduledDownscaleHelperArgs, nodeutilization.SetDefaults_ScheduledDownscaleHelperArgs, registry)
+	pluginregistry.Register(nodeutilization.VolumeAttachmentAwareConsolidationPluginName, nodeutilization.NewVolumeAttachmentAwareConsolidation, &nodeutilization.VolumeAttachmentAwareConsolidation{}, &nodeutilization.VolumeAttachmentAwareConsolidationArgs{}, nodeutilization.ValidateVolumeAttachmentAwareConsolidationArgs, nodeutilization.SetDefaults_VolumeAttachmentAwareConsolidationArgs, registry)
+	pluginregistry.Register(numaawarerebalancer.PluginName, numaawarerebalancer.New, &numaawarerebalancer.NUMAAwareRebalancer{}, &numaawarerebalancer.NUMAAwareRebalancerArgs{}, numaawarerebalancer.ValidateNUMAAwareRebalancerArgs, numaawarerebalancer.SetDefaults_NUMAAwareRebalancerArgs, registry)
+	pluginregistry.Register(ownerspreadacrosscontrolplaneupdates.PluginName, ownerspreadacrosscontrolplaneupdates.New, &ownerspreadacrosscontrolplaneupdates.OwnerSpreadAcrossControlPlaneUpdates{}, &ownerspreadacrosscontrolplaneupdates.OwnerSpreadAcrossControlPlaneUpdatesArgs{}, ownerspreadacrosscontrolplaneupdates.ValidateOwnerSpreadAcrossControlPlaneUpdatesArgs, ownerspreadacrosscontrolplaneupdates.SetDefaults_OwnerSpreadAcrossControlPlaneUpdatesArgs, registry)
 	pluginregistry.Register(podlifetime.PluginName, podlifetime.New, &podlifetime.PodLifeTime{}, &podlifetime.PodLifeTimeArgs{}, podlifetime.ValidatePodLifeTimeArgs, podlifetime.SetDefaults_PodLifeTimeArgs, registry)
+	pluginregistry.Register(podschedulinggatejanitor.PluginName, podschedulinggatejanitor.New, &podschedulinggatejanitor.PodSchedulingGateJanitor{}, &podschedulinggatejanitor.PodSchedulingGateJanitorArgs{}, podschedulinggatejanitor.ValidatePodSchedulingGateJanitorArgs, podschedulinggatejanitor.SetDefaults_PodSchedulingGateJanitorArgs, registry)
+	pluginregistry.Register(podspercorerebalancer.PluginName, podspercorerebalancer.New, &podspercorerebalancer.PodsPerCoreRebalancer{}, &podspercorerebalancer.PodsPerCoreRebalancerArgs{}, podspercorerebalancer.ValidatePodsPerCoreRebalancerArgs, podspercorerebalancer.SetDefaults_PodsPerCoreRebalancerArgs, registry)
+	pluginregistry.Register(rebalancedaemonsetsurge.PluginName, rebalancedaemonsetsurge.New, &rebalancedaemonsetsurge.RebalanceDaemonSetSurge{}, &rebalancedaemonsetsurge.RebalanceDaemonSetSurgeArgs{}, rebalancedaemonsetsurge.ValidateRebalanceDaemonSetSurgeArgs, rebalancedaemonsetsurge.SetDefaults_RebalanceDaemonSetSurgeArgs, registry)
 	pluginregistry.Register(removeduplicates.PluginName, removeduplicates.New, &removeduplicates.RemoveDuplicates{}, &removeduplicates.RemoveDuplicatesArgs{}, removeduplicates.ValidateRemoveDuplicatesArgs, removeduplicates.SetDefaults_RemoveDuplicatesArgs, registry)
 	pluginregistry.Register(removefailedpods.PluginName, removefailedpods.New, &removefailedpods.RemoveFailedPods{}, &removefailedpods.RemoveFailedPodsArgs{}, removefailedpods.ValidateRemoveFailedPodsArgs, removefailedpods.SetDefaults_RemoveFailedPodsArgs, registry)
+	pluginregistry.Register(removelongpendingpodsownerscaler.PluginName, removelongpendingpodsownerscaler.New, &removelongpendingpodsownerscaler.RemoveLongPendingPodsOwnerScaler{}, &removelongpendingpodsownerscaler.RemoveLongPendingPodsOwnerScalerArgs{}, removelongpendingpodsownerscaler.ValidateRemoveLongPendingPodsOwnerScalerArgs, removelongpendingpodsownerscaler.SetDefaults_RemoveLongPendingPodsOwnerScalerArgs, registry)
+	pluginregistry.Register(removemisscheduleddaemonsetpods.PluginName, removemisscheduleddaemonsetpods.New, &removemisscheduleddaemonsetpods.RemoveMisscheduledDaemonSetPods{}, &removemisscheduleddaemonsetpods.RemoveMisscheduledDaemonSetPodsArgs{}, removemisscheduleddaemonsetpods.ValidateRemoveMisscheduledDaemonSetPodsArgs, removemisscheduleddaemonsetpods.SetDefaults_RemoveMisscheduledDaemonSetPodsArgs, registry)
+	pluginregistry.Register(removepodsfromdisconnectednodes.PluginName, removepodsfromdisconnectednodes.New, &removepodsfromdisconnectednodes.RemovePodsFromDisconnectedNodes{}, &removepodsfromdisconnectednodes.RemovePodsFromDisconnectedNodesArgs{}, removepodsfromdisconnectednodes.ValidateRemovePodsFromDisconnectedNodesArgs, removepodsfromdisconnectednodes.SetDefaults_RemovePodsFromDisconnectedNodesArgs, registry)
+	pluginregistry.Register(removepodsfromnodesexceedingpoddensitylimits.PluginName, removepodsfromnodesexceedingpoddensitylimits.New, &removepodsfromnodesexceedingpoddensitylimits.RemovePodsFromNodesExceedingPodDensityLimits{}, &removepodsfromnodesexceedingpoddensitylimits.RemovePodsFromNodesExceedingPodDensityLimitsArgs{}, removepodsfromnodesexceedingpoddensitylimits.ValidateRemovePodsFromNodesExceedingPodDensityLimitsArgs, removepodsfromnodesexceedingpoddensitylimits.SetDefaults_RemovePodsFromNodesExceedingPodDensityLimitsArgs, registry)
 	pluginregistry.Register(removepodshavingtoomanyrestarts.PluginName, removepodshavingtoomanyrestarts.New, &removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestarts{}, &removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestartsArgs{}, removepodshavingtoomanyrestarts.ValidateRemovePodsHavingTooManyRestartsArgs, removepodshavingtoomanyrestarts.SetDefaults_RemovePodsHavingTooManyRestartsArgs, registry)
+	pluginregistry.Register(removepodsonnodespendingosupgrade.PluginName, removepodsonnodespendingosupgrade.New, &removepodsonnodespendingosupgrade.RemovePodsOnNodesPendingOSUpgrade{}, &removepodsonnodespendingosupgrade.RemovePodsOnNodesPendingOSUpgradeArgs{}, removepodsonnodespendingosupgrade.ValidateRemovePodsOnNodesPendingOSUpgradeArgs, removepodsonnodespendingosupgrade.SetDefaults_RemovePodsOnNodesPendingOSUpgradeArgs, registry)
+	pluginregistry.Register(removepodsviolatinghostportconflictsrisk.PluginName, removepodsviolatinghostportconflictsrisk.New, &removepodsviolatinghostportconflictsrisk.RemovePodsViolatingHostPortConflictsRisk{}, &removepodsviolatinghostportconflictsrisk.RemovePodsViolatingHostPortConflictsRiskArgs{}, removepodsviolatinghostportconflictsrisk.ValidateRemovePodsViolatingHostPortConflictsRiskArgs, removepodsviolatinghostportconflictsrisk.SetDefaults_RemovePodsViolatingHostPortConflictsRiskArgs, registry)
 	pluginregistry.Register(removepodsviolatinginterpodantiaffinity.PluginName, removepodsviolatinginterpodantiaffinity.New, &removepodsviolatinginterpodantiaffinity.RemovePodsViolatingInterPodAntiAffinity{}, &removepodsviolatinginterpodantiaffinity.RemovePodsViolatingInterPodAntiAffinityArgs{}, removepodsviolatinginterpodantiaffinity.ValidateRemovePodsViolatingInterPodAntiAffinityArgs, removepodsviolatinginterpodantiaffinity.SetDefaults_RemovePodsViolatingInterPodAntiAffinityArgs, registry)
+	pluginregistry.Register(removepodsviolatinglimitranges.PluginName, removepodsviolatinglimitranges.New, &removepodsviolatinglimitranges.RemovePodsViolatingLimitRanges{}, &removepodsviolatinglimitranges.RemovePodsViolatingLimitRangesArgs{}, removepodsviolatinglimitranges.ValidateRemovePodsViolatingLimitRangesArgs, removepodsviolatinglimitranges.SetDefaults_RemovePodsViolatingLimitRangesArgs, registry)
+	pluginregistry.Register(removepodsviolatingnetworkpolicyisolation.PluginName, removepodsviolatingnetworkpolicyisolation.New, &removepodsviolatingnetworkpolicyisolation.RemovePodsViolatingNetworkPolicyIsolation{}, &removepodsviolatingnetworkpolicyisolation.RemovePodsViolatingNetworkPolicyIsolationArgs{}, removepodsviolatingnetworkpolicyisolation.ValidateRemovePodsViolatingNetworkPolicyIsolationArgs, removepodsviolatingnetworkpolicyisolation.SetDefaults_RemovePodsViolatingNetworkPolicyIsolationArgs, registry)
 	pluginregistry.Register(removepodsviolatingnodeaffinity.PluginName, removepodsviolatingnodeaffinity.New, &removepodsviolatingnodeaffinity.RemovePodsViolatingNodeAffinity{}, &removepodsviolatingnodeaffinity.RemovePodsViolatingNodeAffinityArgs{}, removepodsviolatingnodeaffinity.ValidateRemovePodsViolatingNodeAffinityArgs, removepodsviolatingnodeaffinity.SetDefaults_RemovePodsViolatingNodeAffinityArgs, registry)
 	pluginregistry.Register(removepodsviolatingnodetaints.PluginName, removepodsviolatingnodetaints.New, &removepodsviolatingnodetaints.RemovePodsViolatingNodeTaints{}, &removepodsviolatingnodetaints.RemovePodsViolatingNodeTaintsArgs{}, removepodsviolatingnodetaints.ValidateRemovePodsViolatingNodeTaintsArgs, removepodsviolatingnodetaints.SetDefaults_RemovePodsViolatingNodeTaintsArgs, registry)
 	pluginregistry.Register(removepodsviolatingtopologyspreadconstraint.PluginName, removepodsviolatingtopologyspreadconstraint.New, &removepodsviolatingtopologyspreadconstraint.RemovePodsViolatingTopologySpreadConstraint{}, &removepodsviolatingtopologyspreadconstraint.RemovePodsViolatingTopologySpreadConstraintArgs{}, removepodsviolatingtopologyspreadconstraint.ValidateRemovePodsViolatingTopologySpreadConstraintArgs, removepodsviolatingtopologyspreadconstraint.SetDefaults_RemovePodsViolatingTopologySpreadConstraintArgs, registry)
+	pluginregistry.Register(removepodswithdeprecatedapisowners.PluginName, removepodswithdeprecatedapisowners.New, &removepodswithdeprecatedapisowners.RemovePodsWithDeprecatedAPIsOwners{}, &removepodswithdeprecatedapisowners.RemovePodsWithDeprecatedAPIsOwnersArgs{}, removepodswithdeprecatedapisowners.ValidateRemovePodsWithDeprecatedAPIsOwnersArgs, removepodswithdeprecatedapisowners.SetDefaults_RemovePodsWithDeprecatedAPIsOwnersArgs, registry)
+	pluginregistry.Register(removepodswithmissingserviceaccounts.PluginName, removepodswithmissingserviceaccounts.New, &removepodswithmissingserviceaccounts.RemovePodsWithMissingServiceAccounts{}, &removepodswithmissingserviceaccounts.RemovePodsWithMissingServiceAccountsArgs{}, removepodswithmissingserviceaccounts.ValidateRemovePodsWithMissingServiceAccountsArgs, removepodswithmissingserviceaccounts.SetDefaults_RemovePodsWithMissingServiceAccountsArgs, registry)
+	pluginregistry.Register(replicasetgenerationcleaner.PluginName, replicasetgenerationcleaner.New, &replicasetgenerationcleaner.ReplicaSetGenerationCleaner{}, &replicasetgenerationcleaner.ReplicaSetGenerationCleanerArgs{}, replicasetgenerationcleaner.ValidateReplicaSetGenerationCleanerArgs, replicasetgenerationcleaner.SetDefaults_ReplicaSetGenerationCleanerArgs, registry)
+	pluginregistry.Register(secretandconfigmapreferenceintegrityevictor.PluginName, secretandconfigmapreferenceintegrityevictor.New, &secretandconfigmapreferenceintegrityevictor.SecretAndConfigMapReferenceIntegrityEvictor{}, &secretandconfigmapreferenceintegrityevictor.SecretAndConfigMapReferenceIntegrityEvictorArgs{}, secretandconfigmapreferenceintegrityevictor.ValidateSecretAndConfigMapReferenceIntegrityEvictorArgs, secretandconfigmapreferenceintegrityevictor.SetDefaults_SecretAndConfigMapReferenceIntegrityEvictorArgs, registry)
+	pluginregistry.Register(workloadrightsizingnudger.PluginName, workloadrightsizingnudger.New, &workloadrightsizingnudger.WorkloadRightSizingNudger{}, &workloadrightsizingnudger.WorkloadRightSizingNudgerArgs{}, workloadrightsizingnudger.ValidateWorkloadRightSizingNudgerArgs, workloadrightsizingnudger.SetDefaults_WorkloadRightSizingNudgerArgs, registry)
+	pluginregistry.Register(sysctlandkernelparamcompatibilityevictor.PluginName, sysctlandkernelparamcompatibilityevictor.New, &sysctlandkernelparamcompatibilityevictor.SysctlAndKernelParamCompatibilityEvictor{}, &sysctlandkernelparamcompatibilityevictor.SysctlAndKernelParamCompatibilityEvictorArgs{}, sysctlandkernelparamcompatibilityevictor.ValidateSysctlAndKernelParamCompatibilityEvictorArgs, sysctlandkernelparamcompatibilityevictor.SetDefaults_SysctlAndKernelParamCompatibilityEvictorArgs, registry)
+	pluginregistry.Register(failedschedulingfeedbackloop.PluginName, failedschedulingfeedbackloop.New, &failedschedulingfeedbackloop.FailedSchedulingFeedbackLoop{}, &failedschedulingfeedbackloop.FailedSchedulingFeedbackLoopArgs{}, failedschedulingfeedbackloop.ValidateFailedSchedulingFeedbackLoopArgs, failedschedulingfeedbackloop.SetDefaults_FailedSchedulingFeedbackLoopArgs, registry)
+	pluginregistry.Register(deschedulepodsfornodelabelrollout.PluginName, deschedulepodsfornodelabelrollout.New, &deschedulepodsfornodelabelrollout.DeschedulePodsForNodeLabelRollout{}, &deschedulepodsfornodelabelrollout.DeschedulePodsForNodeLabelRolloutArgs{}, deschedulepodsfornodelabelrollout.ValidateDeschedulePodsForNodeLabelRolloutArgs, deschedulepodsfornodelabelrollout.SetDefaults_DeschedulePodsForNodeLabelRolloutArgs, registry)
+	pluginregistry.Register(evictfornodecertificateorkubeletversionskew.PluginName, evictfornodecertificateorkubeletversionskew.New, &evictfornodecertificateorkubeletversionskew.EvictForNodeCertificateOrKubeletVersionSkew{}, &evictfornodecertificateorkubeletversionskew.EvictForNodeCertificateOrKubeletVersionSkewArgs{}, evictfornodecertificateorkubeletversionskew.ValidateEvictForNodeCertificateOrKubeletVersionSkewArgs, evictfornodecertificateorkubeletversionskew.SetDefaults_EvictForNodeCertificateOrKubeletVersionSkewArgs, registry)
+	pluginregistry.Register(idlenodescaledownassistant.PluginName, idlenodescaledownassistant.New, &idlenodescaledownassistant.IdleNodeScaleDownAssistant{}, &idlenodescaledownassistant.IdleNodeScaleDownAssistantArgs{}, idlenodescaledownassistant.ValidateIdleNodeScaleDownAssistantArgs, idlenodescaledownassistant.SetDefaults_IdleNodeScaleDownAssistantArgs, registry)
+	pluginregistry.Register(crossnodecostoptimizer.PluginName, crossnodecostoptimizer.New, &crossnodecostoptimizer.CrossNodeCostOptimizer{}, &crossnodecostoptimizer.CrossNodeCostOptimizerArgs{}, crossnodecostoptimizer.ValidateCrossNodeCostOptimizerArgs, crossnodecostoptimizer.SetDefaults_CrossNodeCostOptimizerArgs, registry)
+	pluginregistry.Register(hotspotspreadbyservicebackend.PluginName, hotspotspreadbyservicebackend.New, &hotspotspreadbyservicebackend.HotspotSpreadByServiceBackend{}, &hotspotspreadbyservicebackend.HotspotSpreadByServiceBackendArgs{}, hotspotspreadbyservicebackend.ValidateHotspotSpreadByServiceBackendArgs, hotspotspreadbyservicebackend.SetDefaults_HotspotSpreadByServiceBackendArgs, registry)
+	pluginregistry.Register(removepodsviolatingpodtopologylabelsconsistency.PluginName, removepodsviolatingpodtopologylabelsconsistency.New, &removepodsviolatingpodtopologylabelsconsistency.RemovePodsViolatingPodTopologyLabelsConsistency{}, &removepodsviolatingpodtopologylabelsconsistency.RemovePodsViolatingPodTopologyLabelsConsistencyArgs{}, removepodsviolatingpodtopologylabelsconsistency.ValidateRemovePodsViolatingPodTopologyLabelsConsistencyArgs, removepodsviolatingpodtopologylabelsconsistency.SetDefaults_RemovePodsViolatingPodTopologyLabelsConsistencyArgs, registry)
+	pluginregistry.Register(gpusharefragmentationdefragmenter.PluginName, gpusharefragmentationdefragmenter.New, &gpusharefragmentationdefragmenter.GPUShareFragmentationDefragmenter{}, &gpusharefragmentationdefragmenter.GPUShareFragmentationDefragmenterArgs{}, gpusharefragmentationdefragmenter.ValidateGPUShareFragmentationDefragmenterArgs, gpusharefragmentationdefragmenter.SetDefaults_GPUShareFragmentationDefragmenterArgs, registry)
+	pluginregistry.Register(stalenodeleaseevictor.PluginName, stalenodeleaseevictor.New, &stalenodeleaseevictor.StaleNodeLeaseEvictor{}, &stalenodeleaseevictor.StaleNodeLeaseEvictorArgs{}, stalenodeleaseevictor.ValidateStaleNodeLeaseEvictorArgs, stalenodeleaseevictor.SetDefaults_StaleNodeLeaseEvictorArgs, registry)
+	pluginregistry.Register(ephemeralcontainersessionterminator.PluginName, ephemeralcontainersessionterminator.New, &ephemeralcontainersessionterminator.EphemeralContainerSessionTerminator{}, &ephemeralcontainersessionterminator.EphemeralContainerSessionTerminatorArgs{}, ephemeralcontainersessionterminator.ValidateEphemeralContainerSessionTerminatorArgs, ephemeralcontainersessionterminator.SetDefaults_EphemeralContainerSessionTerminatorArgs, registry)
+	pluginregistry.Register(preferlocalimagenodes.PluginName, preferlocalimagenodes.New, &preferlocalimagenodes.PreferLocalImageNodes{}, &preferlocalimagenodes.PreferLocalImageNodesArgs{}, preferlocalimagenodes.ValidatePreferLocalImageNodesArgs, preferlocalimagenodes.SetDefaults_PreferLocalImageNodesArgs, registry)
+	pluginregistry.Register(namespacedecommissioner.PluginName, namespacedecommissioner.New, &namespacedecommissioner.NamespaceDecommissioner{}, &namespacedecommissioner.NamespaceDecommissionerArgs{}, namespacedecommissioner.ValidateNamespaceDecommissionerArgs, namespacedecommissioner.SetDefaults_NamespaceDecommissionerArgs, registry)
+	pluginregistry.Register(removepodsviolatingdedicatednodepolicy.PluginName, removepodsviolatingdedicatednodepolicy.New, &removepodsviolatingdedicatednodepolicy.RemovePodsViolatingDedicatedNodePolicy{}, &removepodsviolatingdedicatednodepolicy.RemovePodsViolatingDedicatedNodePolicyArgs{}, removepodsviolatingdedicatednodepolicy.ValidateRemovePodsViolatingDedicatedNodePolicyArgs, removepodsviolatingdedicatednodepolicy.SetDefaults_RemovePodsViolatingDedicatedNodePolicyArgs, registry)
+	pluginregistry.Register(statefulworkloadzonerebalancer.PluginName, statefulworkloadzonerebalancer.New, &statefulworkloadzonerebalancer.StatefulWorkloadZoneRebalancer{}, &statefulworkloadzonerebalancer.StatefulWorkloadZoneRebalancerArgs{}, statefulworkloadzonerebalancer.ValidateStatefulWorkloadZoneRebalancerArgs, statefulworkloadzonerebalancer.SetDefaults_StatefulWorkloadZoneRebalancerArgs, registry)
+	pluginregistry.Register(agedimagerefreshevictor.PluginName, agedimagerefreshevictor.New, &agedimagerefreshevictor.AgedImageRefreshEvictor{}, &agedimagerefreshevictor.AgedImageRefreshEvictorArgs{}, agedimagerefreshevictor.ValidateAgedImageRefreshEvictorArgs, agedimagerefreshevictor.SetDefaults_AgedImageRefreshEvictorArgs, registry)
+	pluginregistry.Register(duplicatepvcmountconflictresolver.PluginName, duplicatepvcmountconflictresolver.New, &duplicatepvcmountconflictresolver.DuplicatePVCMountConflictResolver{}, &duplicatepvcmountconflictresolver.DuplicatePVCMountConflictResolverArgs{}, duplicatepvcmountconflictresolver.ValidateDuplicatePVCMountConflictResolverArgs, duplicatepvcmountconflictresolver.SetDefaults_DuplicatePVCMountConflictResolverArgs, registry)
+	pluginregistry.Register(sidecardriftevictor.PluginName, sidecardriftevictor.New, &sidecardriftevictor.SidecarDriftEvictor{}, &sidecardriftevictor.SidecarDriftEvictorArgs{}, sidecardriftevictor.ValidateSidecarDriftEvictorArgs, sidecardriftevictor.SetDefaults_SidecarDriftEvictorArgs, registry)
+	pluginregistry.Register(hugepagesandreservedmemorymisfitevictor.PluginName, hugepagesandreservedmemorymisfitevictor.New, &hugepagesandreservedmemorymisfitevictor.HugePagesAndReservedMemoryMisfitEvictor{}, &hugepagesandreservedmemorymisfitevictor.HugePagesAndReservedMemoryMisfitEvictorArgs{}, hugepagesandreservedmemorymisfitevictor.ValidateHugePagesAndReservedMemoryMisfitEvictorArgs, hugepagesandreservedmemorymisfitevictor.SetDefaults_HugePagesAndReservedMemoryMisfitEvictorArgs, registry)
 }
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/server/healthz"
+
+	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
+)
+
+// DefaultStatus is the status of the descheduler running in this process
+var DefaultStatus = NewStatus()
+
+// Status tracks the state of the descheduler served by the readiness and the status endpoints
+type Status struct {
+	mu              sync.RWMutex
+	informersSynced bool
+	leaderElection  *LeaderElectionStatus
+	lastCycle       *CycleStatus
+}
+
+// StatusReport is the state of the descheduler served by the status endpoint
+type StatusReport struct {
+	InformersSynced bool                  `json:"informersSynced"`
+	LeaderElection  *LeaderElectionStatus `json:"leaderElection,omitempty"`
+	LastCycle       *CycleStatus          `json:"lastCycle,omitempty"`
+}
+
+// LeaderElectionStatus reports the state of the leader election, when enabled
+type LeaderElectionStatus struct {
+	// Resolved is true once this instance either acquired the lease or observed another leader
+	Resolved bool `json:"resolved"`
+	Leading  bool `json:"leading"`
+}
+
+// CycleStatus reports the outcome of a descheduling cycle
+type CycleStatus struct {
+	StartTime    metav1.Time     `json:"startTime"`
+	Duration     metav1.Duration `json:"duration"`
+	TotalEvicted uint            `json:"totalEvicted"`
+	// Error is the error the cycle failed with, the errors of the plugins are reported by their summaries
+	Error string `json:"error,omitempty"`
+	// Overrun is true when the cycle overran its deadline, the plugins cancelled or skipped for it
+	// being reported by their summaries
+	Overrun bool                             `json:"overrun,omitempty"`
+	Plugins []frameworkprofile.PluginSummary `json:"plugins"`
+}
+
+// NewStatus creates the status of a descheduler not started yet
+func NewStatus() *Status {
+	return &Status{}
+}
+
+func (s *Status) setInformersSynced() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.informersSynced = true
+}
+
+// enableLeaderElection makes the readiness wait for the leader election to resolve
+func (s *Status) enableLeaderElection() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leaderElection = &LeaderElectionStatus{}
+}
+
+func (s *Status) setLeading(leading bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.leaderElection == nil {
+		s.leaderElection = &LeaderElectionStatus{}
+	}
+	s.leaderElection.Resolved = true
+	s.leaderElection.Leading = leading
+}
+
+func (s *Status) recordCycle(startTime time.Time, totalEvicted uint, summaries []frameworkprofile.PluginSummary, err error) {
+	cycle := &CycleStatus{
+		StartTime:    metav1.NewTime(startTime),
+		Duration:     metav1.Duration{Duration: time.Since(startTime)},
+		TotalEvicted: totalEvicted,
+		Plugins:      summaries,
+	}
+	if cycle.Plugins == nil {
+		cycle.Plugins = []frameworkprofile.PluginSummary{}
+	}
+	_, cycle.Overrun = overrunPlugins(summaries)
+	if err != nil {
+		cycle.Error = err.Error()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastCycle = cycle
+}
+
+// Report returns a snapshot of the status
+func (s *Status) Report() StatusReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	report := StatusReport{InformersSynced: s.informersSynced}
+	if s.leaderElection != nil {
+		leaderElection := *s.leaderElection
+		report.LeaderElection = &leaderElection
+	}
+	if s.lastCycle != nil {
+		lastCycle := *s.lastCycle
+		report.LastCycle = &lastCycle
+	}
+	return report
+}
+
+// ReadyzChecks returns the checks failing the readiness until the informers synced and the leader
+// election resolved, and as long as the last descheduling cycle failed.
+func (s *Status) ReadyzChecks() []healthz.HealthChecker {
+	return []healthz.HealthChecker{
+		healthz.NamedCheck("informer-sync", func(_ *http.Request) error {
+			if !s.Report().InformersSynced {
+				return fmt.Errorf("informers not synced yet")
+			}
+			return nil
+		}),
+		healthz.NamedCheck("leader-election", func(_ *http.Request) error {
+			if leaderElection := s.Report().LeaderElection; leaderElection != nil && !leaderElection.Resolved {
+				return fmt.Errorf("leader election not resolved yet")
+			}
+			return nil
+		}),
+		healthz.NamedCheck("last-cycle", func(_ *http.Request) error {
+			if lastCycle := s.Report().LastCycle; lastCycle != nil && lastCycle.Error != "" {
+				return fmt.Errorf("last descheduling cycle failed: %s", lastCycle.Error)
+			}
+			return nil
+		}),
+	}
+}
+
+// StatusHandler serves the status of the descheduler as JSON
+func StatusHandler(status *Status) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(status.Report()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
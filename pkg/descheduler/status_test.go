@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package descheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestStatusReadyzChecks(t *testing.T) {
+	status := NewStatus()
+	failingChecks := func() []string {
+		var names []string
+		for _, check := range status.ReadyzChecks() {
+			if err := check.Check(nil); err != nil {
+				names = append(names, check.Name())
+			}
+		}
+		return names
+	}
+	expectFailing := func(step string, expected ...string) {
+		t.Helper()
+		if actual := failingChecks(); fmt.Sprint(actual) != fmt.Sprint(expected) {
+			t.Errorf("%s: expected failing checks %v, got %v", step, expected, actual)
+		}
+	}
+
+	expectFailing("not started", "informer-sync")
+	status.enableLeaderElection()
+	expectFailing("leader election enabled", "informer-sync", "leader-election")
+	status.setInformersSynced()
+	expectFailing("informers synced", "leader-election")
+	status.setLeading(false)
+	expectFailing("another leader elected")
+	status.recordCycle(time.Now(), 0, nil, fmt.Errorf("the cluster size is 0 or 1"))
+	expectFailing("failed cycle", "last-cycle")
+	status.recordCycle(time.Now(), 0, nil, nil)
+	expectFailing("successful cycle")
+}
+
+func TestStatusRecordsCycles(t *testing.T) {
+	initPluginRegistry()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, taintNodeNoSchedule)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, node1.Name, func(pod *v1.Pod) {
+		pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+	})
+
+	_, descheduler, client := initDescheduler(t, ctx, removePodsViolatingNodeTaintsPolicy(), node1, node2, p1)
+	var evictedPods []string
+	client.PrependReactor("create", "pods", podEvictionReactionTestingFnc(&evictedPods))
+	descheduler.status = NewStatus()
+	handler := StatusHandler(descheduler.status)
+	getReport := func() StatusReport {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/statusz", nil))
+		var report StatusReport
+		if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil {
+			t.Fatalf("Unable to decode the status: %v", err)
+		}
+		return report
+	}
+
+	if report := getReport(); report.LastCycle != nil {
+		t.Fatalf("Expected no cycle reported before the first cycle, got %#v", report.LastCycle)
+	}
+
+	if err := descheduler.runDeschedulerLoop(ctx, nodeutil.NewNodeSnapshot(0, []*v1.Node{node1, node2})); err != nil {
+		t.Fatalf("Unable to run a descheduling loop: %v", err)
+	}
+	lastCycle := getReport().LastCycle
+	if lastCycle == nil || lastCycle.Error != "" || lastCycle.TotalEvicted != 1 {
+		t.Fatalf("Expected a successful cycle evicting a pod, got %#v", lastCycle)
+	}
+	if len(lastCycle.Plugins) != 1 || lastCycle.Plugins[0].Plugin != "RemovePodsViolatingNodeTaints" || lastCycle.Plugins[0].Evicted != 1 {
+		t.Errorf("Expected the summary of RemovePodsViolatingNodeTaints evicting a pod, got %#v", lastCycle.Plugins)
+	}
+
+	if err := descheduler.runDeschedulerLoop(ctx, nodeutil.NewNodeSnapshot(0, []*v1.Node{node1})); err == nil {
+		t.Fatalf("Expected the descheduling loop to fail over a single node")
+	}
+	lastCycle = getReport().LastCycle
+	if lastCycle == nil || lastCycle.Error == "" || lastCycle.TotalEvicted != 0 || len(lastCycle.Plugins) != 0 {
+		t.Errorf("Expected a failed cycle, got %#v", lastCycle)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+const (
+	// Every feature gate should add method here following this template:
+	//
+	// // owner: @username
+	// // alpha: v1.X
+	// MyFeature featuregate.Feature = "MyFeature"
+	//
+	// Feature gates should be listed in alphabetical, case-sensitive
+	// (upper before any lower case character) order.
+
+	// alpha: v0.30
+	//
+	// Computes the cpu and memory utilization of the nodes from the usage reported
+	// by the resource metrics API (e.g. metrics-server) instead of the pod requests.
+	MetricsBasedUtilization featuregate.Feature = "MetricsBasedUtilization"
+)
+
+// DefaultMutableFeatureGate holds the descheduler specific feature gates, next to the
+// gates of the component-base libraries (e.g. logging). It is not the feature gate of the
+// Kubernetes components so the descheduler features do not clash with theirs.
+var DefaultMutableFeatureGate featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
+
+// DefaultFeatureGate is a shared global FeatureGate.
+// Top-level commands/options setup that needs to modify this feature gate should use DefaultMutableFeatureGate.
+var DefaultFeatureGate featuregate.FeatureGate = DefaultMutableFeatureGate
+
+func init() {
+	runtime.Must(DefaultMutableFeatureGate.Add(defaultDeschedulerFeatureGates))
+}
+
+// defaultDeschedulerFeatureGates consists of all known descheduler-specific feature keys.
+// To add a new feature, define a key for it above and add it here.
+var defaultDeschedulerFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	MetricsBasedUtilization: {Default: false, PreRelease: featuregate.Alpha},
+}
+
+// ValidateFeatureGates returns an error if the given gates can not be set,
+// e.g. they are not known or are locked to their default.
+func ValidateFeatureGates(gates map[string]bool) error {
+	return DefaultMutableFeatureGate.DeepCopy().SetFromMap(gates)
+}
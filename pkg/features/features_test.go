@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"testing"
+)
+
+func TestValidateFeatureGates(t *testing.T) {
+	tests := []struct {
+		description string
+		gates       map[string]bool
+		expectError bool
+	}{
+		{
+			description: "no gate",
+		},
+		{
+			description: "known gate",
+			gates:       map[string]bool{string(MetricsBasedUtilization): true},
+		},
+		{
+			description: "unknown gate",
+			gates:       map[string]bool{"UnknownFeature": true},
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateFeatureGates(tc.gates)
+			if hasError := err != nil; hasError != tc.expectError {
+				t.Errorf("Expected error %v, got %v", tc.expectError, err)
+			}
+			// Validating the gates does not set them
+			if DefaultFeatureGate.Enabled(MetricsBasedUtilization) {
+				t.Errorf("Expected %s to stay disabled", MetricsBasedUtilization)
+			}
+		})
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
@@ -16,8 +19,13 @@ type HandleImpl struct {
 	ClientsetImpl                 clientset.Interface
 	GetPodsAssignedToNodeFuncImpl podutil.GetPodsAssignedToNodeFunc
 	SharedInformerFactoryImpl     informers.SharedInformerFactory
+	ClusterInformerFactoryImpl    informers.SharedInformerFactory
 	EvictorFilterImpl             frameworktypes.EvictorPlugin
 	PodEvictorImpl                *evictions.PodEvictor
+	UtilizationProviderImpl       frameworktypes.UtilizationProvider
+	EventRecorderImpl             events.EventRecorder
+	StateStoreImpl                frameworktypes.StateStore
+	NodeSnapshotImpl              *nodeutil.NodeSnapshot
 }
 
 var _ frameworktypes.Handle = &HandleImpl{}
@@ -34,10 +42,48 @@ func (hi *HandleImpl) SharedInformerFactory() informers.SharedInformerFactory {
 	return hi.SharedInformerFactoryImpl
 }
 
+func (hi *HandleImpl) ClusterInformerFactory() informers.SharedInformerFactory {
+	if hi.ClusterInformerFactoryImpl == nil {
+		return hi.SharedInformerFactoryImpl
+	}
+	return hi.ClusterInformerFactoryImpl
+}
+
 func (hi *HandleImpl) Evictor() frameworktypes.Evictor {
 	return hi
 }
 
+func (hi *HandleImpl) UtilizationProvider() frameworktypes.UtilizationProvider {
+	return hi.UtilizationProviderImpl
+}
+
+func (hi *HandleImpl) EventRecorder() events.EventRecorder {
+	return hi.EventRecorderImpl
+}
+
+func (hi *HandleImpl) ProcessedPods() *evictions.ProcessedPods {
+	if hi.PodEvictorImpl == nil {
+		return nil
+	}
+	return hi.PodEvictorImpl.ProcessedPods()
+}
+
+func (hi *HandleImpl) ReadyNodes(selector labels.Selector) ([]*v1.Node, error) {
+	return nodeutil.ReadyNodesFromLister(hi.SharedInformerFactoryImpl.Core().V1().Nodes().Lister(), selector)
+}
+
+func (hi *HandleImpl) StateStore() frameworktypes.StateStore {
+	return hi.StateStoreImpl
+}
+
+func (hi *HandleImpl) NodeSnapshot() *nodeutil.NodeSnapshot {
+	if hi.NodeSnapshotImpl == nil {
+		nodes, _ := hi.ReadyNodes(labels.Everything())
+		hi.NodeSnapshotImpl = nodeutil.NewNodeSnapshot(0, nodes)
+	}
+	return hi.NodeSnapshotImpl
+}
+
 func (hi *HandleImpl) Filter(pod *v1.Pod) bool {
 	return hi.EvictorFilterImpl.Filter(pod)
 }
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scenario runs a plugin against a fake cluster and checks the pods it evicts, so
+// plugin tests, in-tree or out-of-tree, do not have to set up the client, the informers,
+// the pod evictor and the default evictor themselves:
+//
+//	scenario.New().
+//		WithNodes(node1, node2).
+//		WithPods(p1, p2, p3).
+//		WithDefaultEvictorArgs(defaultevictor.DefaultEvictorArgs{NodeFit: ptr.To(true)}).
+//		ExpectEvicted("p1").
+//		Run(t, New, &PluginArgs{})
+package scenario
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+// Scenario describes the cluster a plugin runs against and what is expected from the run
+type Scenario struct {
+	nodes              []*v1.Node
+	processedNodes     []*v1.Node
+	pods               []*v1.Pod
+	objects            []runtime.Object
+	evictionOptions    *evictions.Options
+	defaultEvictorArgs defaultevictor.DefaultEvictorArgs
+	podsSorter         func([]*v1.Pod)
+
+	expectEvicted        bool
+	expectedEvicted      []string
+	expectedEvictedCount *uint
+	expectError          bool
+}
+
+// New returns an empty scenario, evicting with the default options and the default evictor
+// configured with its zero arguments.
+func New() *Scenario {
+	return &Scenario{
+		evictionOptions: evictions.NewOptions(),
+	}
+}
+
+// WithNodes adds nodes to the cluster. The plugin processes all of them unless
+// WithProcessedNodes is used.
+func (s *Scenario) WithNodes(nodes ...*v1.Node) *Scenario {
+	s.nodes = append(s.nodes, nodes...)
+	return s
+}
+
+// WithProcessedNodes sets the nodes the plugin is given, e.g. to check the plugin leaves the
+// pods of the other nodes of the cluster alone. The nodes are added to the cluster if missing.
+func (s *Scenario) WithProcessedNodes(nodes ...*v1.Node) *Scenario {
+	for _, node := range nodes {
+		found := false
+		for _, existing := range s.nodes {
+			if existing.Name == node.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			s.nodes = append(s.nodes, node)
+		}
+	}
+	s.processedNodes = nodes
+	return s
+}
+
+// WithPods adds pods to the cluster
+func (s *Scenario) WithPods(pods ...*v1.Pod) *Scenario {
+	s.pods = append(s.pods, pods...)
+	return s
+}
+
+// WithObjects adds any other object the plugin reads to the cluster, e.g. owners or namespaces
+func (s *Scenario) WithObjects(objects ...runtime.Object) *Scenario {
+	s.objects = append(s.objects, objects...)
+	return s
+}
+
+// WithEvictionOptions sets the options of the pod evictor, e.g. its limits
+func (s *Scenario) WithEvictionOptions(options *evictions.Options) *Scenario {
+	s.evictionOptions = options
+	return s
+}
+
+// WithDefaultEvictorArgs sets the arguments of the default evictor filtering the pods
+func (s *Scenario) WithDefaultEvictorArgs(args defaultevictor.DefaultEvictorArgs) *Scenario {
+	s.defaultEvictorArgs = args
+	return s
+}
+
+// WithPodsSorter sorts the pods assigned to a node as the plugin lists them, e.g. to make
+// the pods picked for eviction deterministic.
+func (s *Scenario) WithPodsSorter(sorter func([]*v1.Pod)) *Scenario {
+	s.podsSorter = sorter
+	return s
+}
+
+// ExpectEvicted expects exactly the pods with the given names to be evicted, in any order.
+// Called without names, it expects no pod to be evicted.
+func (s *Scenario) ExpectEvicted(names ...string) *Scenario {
+	s.expectEvicted = true
+	s.expectedEvicted = names
+	return s
+}
+
+// ExpectEvictedCount expects the given number of pods to be evicted
+func (s *Scenario) ExpectEvictedCount(count uint) *Scenario {
+	s.expectedEvictedCount = &count
+	return s
+}
+
+// ExpectError expects the extension points of the plugin to return an error
+func (s *Scenario) ExpectError() *Scenario {
+	s.expectError = true
+	return s
+}
+
+// Result is the outcome of a run, for the checks the expectations do not cover
+type Result struct {
+	Client     *fake.Clientset
+	Handle     *frameworkfake.HandleImpl
+	PodEvictor *evictions.PodEvictor
+	// Evicted lists the names of the evicted pods, in eviction order
+	Evicted []string
+	// Status is the status returned by the last extension point of the plugin
+	Status *frameworktypes.Status
+}
+
+// Run builds the plugin with the arguments, runs its Deschedule and then its Balance extension
+// points, whichever it implements, and checks the expectations of the scenario.
+func (s *Scenario) Run(t testing.TB, builder pluginregistry.PluginBuilder, args runtime.Object) *Result {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var objects []runtime.Object
+	for _, node := range s.nodes {
+		objects = append(objects, node)
+	}
+	for _, pod := range s.pods {
+		objects = append(objects, pod)
+	}
+	objects = append(objects, s.objects...)
+	client := fake.NewSimpleClientset(objects...)
+
+	result := &Result{Client: client}
+	var mu sync.Mutex
+	client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" {
+			mu.Lock()
+			defer mu.Unlock()
+			result.Evicted = append(result.Evicted, action.(core.CreateAction).GetObject().(*policy.Eviction).Name)
+		}
+		// The eviction itself is left to the default reactors
+		return false, nil, nil
+	})
+
+	handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, client, s.evictionOptions, s.defaultEvictorArgs, s.podsSorter)
+	if err != nil {
+		t.Fatalf("Unable to initialize a framework handle: %v", err)
+	}
+	result.Handle = handle
+	result.PodEvictor = podEvictor
+
+	plugin, err := builder(args, handle)
+	if err != nil {
+		t.Fatalf("Unable to initialize the plugin: %v", err)
+	}
+	// Informers requested by the plugin are started once it is built
+	handle.SharedInformerFactoryImpl.Start(ctx.Done())
+	handle.SharedInformerFactoryImpl.WaitForCacheSync(ctx.Done())
+
+	nodes := s.nodes
+	if s.processedNodes != nil {
+		nodes = s.processedNodes
+	}
+	// The plugin is given the snapshot of the nodes it processes, as in a descheduling cycle
+	handle.NodeSnapshotImpl = nodeutil.NewNodeSnapshot(0, nodes)
+	failed := false
+	if deschedulePlugin, ok := plugin.(frameworktypes.DeschedulePlugin); ok {
+		result.Status = deschedulePlugin.Deschedule(ctx, nodes)
+		failed = s.checkStatus(t, "Deschedule", result.Status) || failed
+	}
+	if balancePlugin, ok := plugin.(frameworktypes.BalancePlugin); ok {
+		result.Status = balancePlugin.Balance(ctx, nodes)
+		failed = s.checkStatus(t, "Balance", result.Status) || failed
+	}
+	if s.expectError && !failed {
+		t.Errorf("Expected an error from the plugin")
+	}
+	podEvictor.Drain(ctx)
+
+	if s.expectedEvictedCount != nil {
+		if evicted := podEvictor.TotalEvicted(); evicted != *s.expectedEvictedCount {
+			t.Errorf("Expected %v pod evictions, but got %v pod evictions: %v", *s.expectedEvictedCount, evicted, result.Evicted)
+		}
+	}
+	if s.expectEvicted {
+		expected := append([]string{}, s.expectedEvicted...)
+		got := append([]string{}, result.Evicted...)
+		sort.Strings(expected)
+		sort.Strings(got)
+		if diff := cmp.Diff(expected, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("Unexpected evicted pods (-want,+got):\n%s", diff)
+		}
+	}
+	return result
+}
+
+// checkStatus reports an unexpected error, returning whether the extension point failed
+func (s *Scenario) checkStatus(t testing.TB, extensionPoint string, status *frameworktypes.Status) bool {
+	t.Helper()
+	if status == nil || status.Err == nil {
+		return false
+	}
+	if !s.expectError {
+		t.Errorf("Unexpected error from %s: %v", extensionPoint, status.Err)
+	}
+	return true
+}
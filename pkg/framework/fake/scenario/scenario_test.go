@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	fakeplugin "sigs.k8s.io/descheduler/pkg/framework/fake/plugin"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+// labelEvictor evicts the pods labeled to be evicted, or fails when asked to
+type labelEvictor struct {
+	handle frameworktypes.Handle
+	fail   bool
+}
+
+func (l *labelEvictor) Name() string {
+	return "LabelEvictor"
+}
+
+func (l *labelEvictor) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	if l.fail {
+		return &frameworktypes.Status{Err: errors.New("failed")}
+	}
+	pods, err := podutil.ListPodsOnNodes(nodes, l.handle.GetPodsAssignedToNodeFunc(), func(pod *v1.Pod) bool {
+		return pod.Labels["evict"] == "true" && l.handle.Evictor().Filter(pod)
+	})
+	if err != nil {
+		return &frameworktypes.Status{Err: err}
+	}
+	for _, pod := range pods {
+		l.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: l.Name()})
+	}
+	return nil
+}
+
+func newLabelEvictor(fail bool) func(runtime.Object, frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	return func(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+		return &labelEvictor{handle: handle, fail: fail}, nil
+	}
+}
+
+// recordingTB records the failures instead of failing the test
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestScenario(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	buildPod := func(name, nodeName string, evict bool) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			if evict {
+				pod.Labels = map[string]string{"evict": "true"}
+			}
+		})
+	}
+	pods := []*v1.Pod{
+		buildPod("p1", "n1", true),
+		buildPod("p2", "n1", false),
+		buildPod("p3", "n2", true),
+	}
+
+	tests := []struct {
+		description    string
+		scenario       *Scenario
+		fail           bool
+		expectedErrors int
+	}{
+		{
+			description: "expected evictions",
+			scenario:    New().WithNodes(node1, node2).WithPods(pods...).ExpectEvicted("p3", "p1").ExpectEvictedCount(2),
+		},
+		{
+			description: "only the processed nodes are given to the plugin",
+			scenario:    New().WithNodes(node1, node2).WithPods(pods...).WithProcessedNodes(node2).ExpectEvicted("p3"),
+		},
+		{
+			description: "eviction limits",
+			scenario: New().WithNodes(node1, node2).WithPods(pods...).
+				WithEvictionOptions(evictions.NewOptions().WithMaxPodsToEvictTotal(utilptr.To[uint](1))).
+				ExpectEvictedCount(1),
+		},
+		{
+			description: "no eviction expected",
+			scenario:    New().WithNodes(node1).WithPods(buildPod("p2", "n1", false)).ExpectEvicted(),
+		},
+		{
+			description: "expected error",
+			scenario:    New().WithNodes(node1).ExpectError().ExpectEvicted(),
+			fail:        true,
+		},
+		{
+			description:    "unexpected evictions are reported",
+			scenario:       New().WithNodes(node1, node2).WithPods(pods...).ExpectEvicted("p1").ExpectEvictedCount(1),
+			expectedErrors: 2,
+		},
+		{
+			description:    "unexpected errors are reported",
+			scenario:       New().WithNodes(node1),
+			fail:           true,
+			expectedErrors: 1,
+		},
+		{
+			description:    "missing errors are reported",
+			scenario:       New().WithNodes(node1).ExpectError(),
+			expectedErrors: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			tc.scenario.Run(tb, newLabelEvictor(tc.fail), &fakeplugin.FakePluginArgs{})
+			if len(tb.errors) != tc.expectedErrors {
+				t.Errorf("Expected %d failures, got %d: %v", tc.expectedErrors, len(tb.errors), tb.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Handler serves the verbosity overrides of the plugins.
+//
+//	GET                             lists the effective overrides
+//	PUT    ?plugin=<name>&v=<level> overrides the verbosity of a plugin
+//	DELETE ?plugin=<name>           drops the runtime override of a plugin
+//
+// isPlugin reports whether a plugin name is known so typos do not go unnoticed.
+func Handler(levels *Levels, isPlugin func(name string) bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodDelete:
+			plugin := req.URL.Query().Get("plugin")
+			if !isPlugin(plugin) {
+				http.Error(w, fmt.Sprintf("unknown plugin %q", plugin), http.StatusBadRequest)
+				return
+			}
+			if req.Method == http.MethodDelete {
+				levels.Unset(plugin)
+				break
+			}
+			level, err := strconv.Atoi(req.URL.Query().Get("v"))
+			if err != nil || level < 0 {
+				http.Error(w, "v must be a non-negative integer", http.StatusBadRequest)
+				return
+			}
+			levels.Set(plugin, level)
+		default:
+			w.Header().Set("Allow", "GET, PUT, DELETE")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(levels.All()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging provides per plugin overrides of the log verbosity so a single
+// plugin can be debugged at a high verbosity without raising the verbosity of the others.
+package logging
+
+import (
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// DefaultLevels holds the verbosity overrides of the descheduler.
+var DefaultLevels = NewLevels()
+
+// Levels is a registry of log verbosity overrides keyed by plugin name.
+// The overrides set at runtime take precedence over the ones of the policy.
+type Levels struct {
+	lock    sync.RWMutex
+	policy  map[string]int
+	runtime map[string]int
+}
+
+// NewLevels returns an empty registry of verbosity overrides.
+func NewLevels() *Levels {
+	return &Levels{
+		policy:  map[string]int{},
+		runtime: map[string]int{},
+	}
+}
+
+// SetPolicyLevels replaces the overrides configured through the policy.
+func (l *Levels) SetPolicyLevels(levels map[string]int32) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.policy = make(map[string]int, len(levels))
+	for plugin, level := range levels {
+		l.policy[plugin] = int(level)
+	}
+}
+
+// Set overrides the verbosity of a plugin at runtime.
+func (l *Levels) Set(plugin string, level int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.runtime[plugin] = level
+}
+
+// Unset drops the runtime override of a plugin, falling back to the override of the policy if any.
+func (l *Levels) Unset(plugin string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.runtime, plugin)
+}
+
+// Get returns the verbosity override of a plugin and whether one is set.
+func (l *Levels) Get(plugin string) (int, bool) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	if level, ok := l.runtime[plugin]; ok {
+		return level, true
+	}
+	level, ok := l.policy[plugin]
+	return level, ok
+}
+
+// All returns the effective verbosity overrides of all the plugins.
+func (l *Levels) All() map[string]int {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	all := make(map[string]int, len(l.policy)+len(l.runtime))
+	for plugin, level := range l.policy {
+		all[plugin] = level
+	}
+	for plugin, level := range l.runtime {
+		all[plugin] = level
+	}
+	return all
+}
+
+// PluginLogger returns a logger named after the plugin which honors the verbosity
+// override of the plugin, if any, instead of the global verbosity.
+func (l *Levels) PluginLogger(logger klog.Logger, plugin string) klog.Logger {
+	logger = logger.WithName(plugin)
+	sink := logger.GetSink()
+	if sink == nil {
+		return logger
+	}
+	// Skip the frame of the levelSink so the call sites get reported
+	if withCallDepth, ok := sink.(callDepthSink); ok {
+		sink = withCallDepth.WithCallDepth(1)
+	}
+	return klog.New(&levelSink{sink: sink, plugin: plugin, levels: l})
+}
+
+type callDepthSink interface {
+	WithCallDepth(depth int) klog.LogSink
+}
+
+// levelSink wraps the sink of a logger to replace the verbosity check
+// with the override of the plugin while one is set.
+type levelSink struct {
+	sink   klog.LogSink
+	plugin string
+	levels *Levels
+}
+
+var _ klog.LogSink = &levelSink{}
+
+// Init is a no-op as the wrapped sink is already initialized.
+func (s *levelSink) Init(info klog.RuntimeInfo) {}
+
+func (s *levelSink) Enabled(level int) bool {
+	if override, ok := s.levels.Get(s.plugin); ok {
+		return level <= override
+	}
+	return s.sink.Enabled(level)
+}
+
+func (s *levelSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	if _, ok := s.levels.Get(s.plugin); ok {
+		// The message already passed the override check, the klog sink
+		// would otherwise drop it again based on the global verbosity.
+		level = 0
+	}
+	s.sink.Info(level, msg, keysAndValues...)
+}
+
+func (s *levelSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.sink.Error(err, msg, keysAndValues...)
+}
+
+func (s *levelSink) WithValues(keysAndValues ...interface{}) klog.LogSink {
+	return &levelSink{sink: s.sink.WithValues(keysAndValues...), plugin: s.plugin, levels: s.levels}
+}
+
+func (s *levelSink) WithName(name string) klog.LogSink {
+	return &levelSink{sink: s.sink.WithName(name), plugin: s.plugin, levels: s.levels}
+}
+
+func (s *levelSink) WithCallDepth(depth int) klog.LogSink {
+	if withCallDepth, ok := s.sink.(callDepthSink); ok {
+		return &levelSink{sink: withCallDepth.WithCallDepth(depth), plugin: s.plugin, levels: s.levels}
+	}
+	return s
+}
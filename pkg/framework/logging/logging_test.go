@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"k8s.io/klog/v2"
+)
+
+// recordingSink records the messages passing the global verbosity
+type recordingSink struct {
+	verbosity int
+	names     string
+	messages  *[]string
+}
+
+func (s *recordingSink) Init(info klog.RuntimeInfo) {}
+
+func (s *recordingSink) Enabled(level int) bool {
+	return level <= s.verbosity
+}
+
+func (s *recordingSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	// Mimic klog checking the global verbosity again
+	if s.Enabled(level) {
+		*s.messages = append(*s.messages, s.names+msg)
+	}
+}
+
+func (s *recordingSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	*s.messages = append(*s.messages, s.names+msg)
+}
+
+func (s *recordingSink) WithValues(keysAndValues ...interface{}) klog.LogSink {
+	return s
+}
+
+func (s *recordingSink) WithName(name string) klog.LogSink {
+	return &recordingSink{verbosity: s.verbosity, names: s.names + name + ": ", messages: s.messages}
+}
+
+func TestLevels(t *testing.T) {
+	levels := NewLevels()
+	if _, ok := levels.Get("A"); ok {
+		t.Fatalf("expected no override")
+	}
+
+	levels.SetPolicyLevels(map[string]int32{"A": 2, "B": 3})
+	levels.Set("A", 5)
+	if level, ok := levels.Get("A"); !ok || level != 5 {
+		t.Errorf("expected the runtime override to take precedence, got %v", level)
+	}
+	if !reflect.DeepEqual(levels.All(), map[string]int{"A": 5, "B": 3}) {
+		t.Errorf("unexpected overrides: %v", levels.All())
+	}
+
+	levels.Unset("A")
+	if level, ok := levels.Get("A"); !ok || level != 2 {
+		t.Errorf("expected to fall back to the policy override, got %v", level)
+	}
+
+	levels.SetPolicyLevels(nil)
+	if _, ok := levels.Get("B"); ok {
+		t.Errorf("expected the policy overrides to be replaced")
+	}
+}
+
+func TestPluginLogger(t *testing.T) {
+	var messages []string
+	logger := klog.New(&recordingSink{verbosity: 2, messages: &messages})
+	levels := NewLevels()
+	levels.Set("Verbose", 5)
+	levels.Set("Quiet", 0)
+
+	for _, plugin := range []string{"Verbose", "Quiet", "Default"} {
+		pluginLogger := levels.PluginLogger(logger, plugin)
+		pluginLogger.V(1).Info("v1")
+		pluginLogger.V(4).Info("v4")
+		pluginLogger.WithValues("pod", "p1").V(5).Info("v5")
+		pluginLogger.V(6).Info("v6")
+		pluginLogger.Error(nil, "error")
+	}
+	logger.V(4).Info("global v4")
+
+	expected := []string{
+		"Verbose: v1", "Verbose: v4", "Verbose: v5", "Verbose: error",
+		"Quiet: error",
+		"Default: v1", "Default: error",
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("expected %v, got %v", expected, messages)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	levels := NewLevels()
+	levels.SetPolicyLevels(map[string]int32{"A": 2})
+	handler := Handler(levels, func(name string) bool { return name == "A" || name == "B" })
+
+	tests := []struct {
+		method     string
+		query      string
+		statusCode int
+		expected   map[string]int
+	}{
+		{method: http.MethodGet, statusCode: http.StatusOK, expected: map[string]int{"A": 2}},
+		{method: http.MethodPut, query: "?plugin=B&v=5", statusCode: http.StatusOK, expected: map[string]int{"A": 2, "B": 5}},
+		{method: http.MethodPut, query: "?plugin=A&v=4", statusCode: http.StatusOK, expected: map[string]int{"A": 4, "B": 5}},
+		{method: http.MethodDelete, query: "?plugin=A", statusCode: http.StatusOK, expected: map[string]int{"A": 2, "B": 5}},
+		{method: http.MethodPut, query: "?plugin=C&v=5", statusCode: http.StatusBadRequest},
+		{method: http.MethodPut, query: "?plugin=B&v=-1", statusCode: http.StatusBadRequest},
+		{method: http.MethodPut, query: "?plugin=B", statusCode: http.StatusBadRequest},
+		{method: http.MethodPost, query: "?plugin=B&v=1", statusCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(test.method, "/debug/plugins/v"+test.query, nil))
+		if recorder.Code != test.statusCode {
+			t.Fatalf("%v %v: expected status %v, got %v", test.method, test.query, test.statusCode, recorder.Code)
+		}
+		if test.statusCode != http.StatusOK {
+			continue
+		}
+		got := map[string]int{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+			t.Fatalf("%v %v: unable to decode the response: %v", test.method, test.query, err)
+		}
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%v %v: expected %v, got %v", test.method, test.query, test.expected, got)
+		}
+	}
+}
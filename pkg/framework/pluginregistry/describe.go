@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluginregistry
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+// PluginDescription describes a registered plugin for the tools built around the descheduler
+// policy, e.g. to render a configuration form or to validate a policy.
+type PluginDescription struct {
+	Name string `json:"name"`
+	// ExtensionPoints are the extension points the plugin can be enabled at
+	ExtensionPoints []frameworktypes.ExtensionPoint `json:"extensionPoints"`
+	// ArgsKind is the kind of the plugin arguments
+	ArgsKind string `json:"argsKind"`
+	// ArgsSchema is the JSON schema of the plugin arguments
+	ArgsSchema *Schema `json:"argsSchema"`
+	// Defaults are the plugin arguments the plugin runs with when none is configured
+	Defaults json.RawMessage `json:"defaults"`
+}
+
+// Describe returns the description of the registered plugins, sorted by name
+func (r Registry) Describe() ([]PluginDescription, error) {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	descriptions := make([]PluginDescription, 0, len(names))
+	for _, name := range names {
+		description, err := r.DescribePlugin(name)
+		if err != nil {
+			return nil, err
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions, nil
+}
+
+// DescribePlugin returns the description of a registered plugin
+func (r Registry) DescribePlugin(name string) (PluginDescription, error) {
+	utilities, ok := r[name]
+	if !ok {
+		return PluginDescription{}, fmt.Errorf("plugin %q is not registered", name)
+	}
+
+	args := utilities.PluginArgInstance.DeepCopyObject()
+	if utilities.PluginArgDefaulter != nil {
+		utilities.PluginArgDefaulter(args)
+	}
+	defaults, err := json.Marshal(args)
+	if err != nil {
+		return PluginDescription{}, fmt.Errorf("unable to encode the default arguments of plugin %q: %v", name, err)
+	}
+
+	argsType := reflect.TypeOf(utilities.PluginArgInstance)
+	for argsType.Kind() == reflect.Pointer {
+		argsType = argsType.Elem()
+	}
+
+	return PluginDescription{
+		Name:            name,
+		ExtensionPoints: extensionPoints(utilities.PluginType),
+		ArgsKind:        argsType.Name(),
+		ArgsSchema:      SchemaFor(argsType),
+		Defaults:        defaults,
+	}, nil
+}
+
+// extensionPoints returns the extension points implemented by the plugin type
+func extensionPoints(pluginType interface{}) []frameworktypes.ExtensionPoint {
+	var points []frameworktypes.ExtensionPoint
+	if _, ok := pluginType.(frameworktypes.DeschedulePlugin); ok {
+		points = append(points, frameworktypes.DescheduleExtensionPoint)
+	}
+	if _, ok := pluginType.(frameworktypes.BalancePlugin); ok {
+		points = append(points, frameworktypes.BalanceExtensionPoint)
+	}
+	if _, ok := pluginType.(frameworktypes.EvictorPlugin); ok {
+		points = append(points, frameworktypes.FilterExtensionPoint, frameworktypes.PreEvictionFilterExtensionPoint)
+	}
+	return points
+}
+
+// DescribeHandler serves the description of the registered plugins as JSON
+func DescribeHandler(registry Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		descriptions, err := registry.Describe()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(descriptions); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
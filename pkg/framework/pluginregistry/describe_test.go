@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluginregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+type fakeArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	embeddedArgs
+	Enabled  bool                `json:"enabled"`
+	Count    *int32              `json:"count,omitempty"`
+	Ratio    float64             `json:"ratio"`
+	Names    []string            `json:"names"`
+	Weights  map[string]float64  `json:"weights"`
+	Interval *metav1.Duration    `json:"interval"`
+	Limit    *intstr.IntOrString `json:"limit"`
+	Ignored  string              `json:"-"`
+	NoTag    string
+}
+
+type embeddedArgs struct {
+	Selector string `json:"selector"`
+}
+
+func (in *fakeArgs) DeepCopyObject() runtime.Object {
+	out := *in
+	return &out
+}
+
+type fakePlugin struct{}
+
+func (*fakePlugin) Name() string { return "FakePlugin" }
+
+func (*fakePlugin) Deschedule(context.Context, []*v1.Node) *frameworktypes.Status { return nil }
+
+func (*fakePlugin) Balance(context.Context, []*v1.Node) *frameworktypes.Status { return nil }
+
+func fakeRegistry() Registry {
+	registry := NewRegistry()
+	Register(
+		"FakePlugin",
+		func(runtime.Object, frameworktypes.Handle) (frameworktypes.Plugin, error) { return &fakePlugin{}, nil },
+		&fakePlugin{},
+		&fakeArgs{},
+		func(runtime.Object) error { return nil },
+		func(obj runtime.Object) { obj.(*fakeArgs).Enabled = true },
+		registry,
+	)
+	return registry
+}
+
+func TestSchemaFor(t *testing.T) {
+	expected := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"selector": {Type: "string"},
+			"enabled":  {Type: "boolean"},
+			"count":    {Type: "integer"},
+			"ratio":    {Type: "number"},
+			"names":    {Type: "array", Items: &Schema{Type: "string"}},
+			"weights":  {Type: "object", AdditionalProperties: &Schema{Type: "number"}},
+			"interval": {Type: "string", Format: "duration"},
+			"limit":    {IntOrString: true},
+			"NoTag":    {Type: "string"},
+		},
+	}
+	if diff := cmp.Diff(expected, SchemaFor(reflect.TypeOf(&fakeArgs{}))); diff != "" {
+		t.Errorf("Got unexpected schema (-want, +got):\n%s", diff)
+	}
+}
+
+func TestDescribePlugin(t *testing.T) {
+	registry := fakeRegistry()
+
+	description, err := registry.DescribePlugin("FakePlugin")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedPoints := []frameworktypes.ExtensionPoint{frameworktypes.DescheduleExtensionPoint, frameworktypes.BalanceExtensionPoint}
+	if !reflect.DeepEqual(description.ExtensionPoints, expectedPoints) {
+		t.Errorf("Expected extension points %v, got %v", expectedPoints, description.ExtensionPoints)
+	}
+	if description.ArgsKind != "fakeArgs" {
+		t.Errorf("Expected args kind fakeArgs, got %v", description.ArgsKind)
+	}
+	var defaults map[string]interface{}
+	if err := json.Unmarshal(description.Defaults, &defaults); err != nil {
+		t.Fatalf("Unable to decode the defaults: %v", err)
+	}
+	if defaults["enabled"] != true {
+		t.Errorf("Expected the defaulted args, got %s", description.Defaults)
+	}
+	// The registered instance is left untouched
+	if registry["FakePlugin"].PluginArgInstance.(*fakeArgs).Enabled {
+		t.Errorf("Expected the registered args instance not to be defaulted")
+	}
+
+	if _, err := registry.DescribePlugin("UnknownPlugin"); err == nil {
+		t.Errorf("Expected an error describing an unknown plugin")
+	}
+}
+
+func TestDescribeHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	DescribeHandler(fakeRegistry()).ServeHTTP(recorder, httptest.NewRequest("GET", "/plugins", nil))
+
+	var descriptions []PluginDescription
+	if err := json.Unmarshal(recorder.Body.Bytes(), &descriptions); err != nil {
+		t.Fatalf("Unable to decode the response: %v", err)
+	}
+	if len(descriptions) != 1 || descriptions[0].Name != "FakePlugin" {
+		t.Errorf("Expected the description of FakePlugin, got %s", recorder.Body.String())
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluginregistry
+
+import (
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Schema is the subset of a JSON schema describing the arguments of the plugins
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	// IntOrString tells the value is either an integer or a string, like in the Kubernetes API schemas
+	IntOrString bool `json:"x-kubernetes-int-or-string,omitempty"`
+}
+
+var (
+	durationType    = reflect.TypeOf(metav1.Duration{})
+	timeType        = reflect.TypeOf(metav1.Time{})
+	typeMetaType    = reflect.TypeOf(metav1.TypeMeta{})
+	intOrStringType = reflect.TypeOf(intstr.IntOrString{})
+	quantityType    = reflect.TypeOf(resource.Quantity{})
+)
+
+// SchemaFor returns the JSON schema of the values of the given type, as encoded by encoding/json.
+func SchemaFor(t reflect.Type) *Schema {
+	return schemaFor(t, map[reflect.Type]bool{})
+}
+
+func schemaFor(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case durationType:
+		return &Schema{Type: "string", Format: "duration"}
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case intOrStringType, quantityType:
+		return &Schema{IntOrString: true}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: schemaFor(t.Elem(), visiting)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaFor(t.Elem(), visiting)}
+	case reflect.Struct:
+		// Recursive types are described down to their first repetition
+		if visiting[t] {
+			return &Schema{Type: "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		addProperties(schema, t, visiting)
+		return schema
+	}
+	// Interfaces and the kinds encoding/json does not support
+	return &Schema{}
+}
+
+// addProperties adds the fields of the struct to the properties of the schema,
+// following the encoding/json rules for the field names and the embedded structs.
+func addProperties(schema *Schema, t reflect.Type, visiting map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// The kind and the api version are given by the plugin configuration
+		if field.Type == typeMetaType {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addProperties(schema, embedded, visiting)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = schemaFor(field.Type, visiting)
+	}
+}
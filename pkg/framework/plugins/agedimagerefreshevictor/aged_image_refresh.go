@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agedimagerefreshevictor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "AgedImageRefreshEvictor"
+
+// AgedImageRefreshEvictor evicts the pods running images built longer ago than the configured age, so
+// they get recreated on the patched images their tags got rebuilt into. The build date of the images is
+// read from an annotation of the pods, or resolved from the manifests of the registries the plugin is
+// configured with. Images resolved from a registry are only considered aged when their tag now points to
+// another image, evicting the pods being useless otherwise, and images pinned by digest never are. The
+// pods running the oldest images are evicted first, within budgets of evictions per cycle and per owner.
+type AgedImageRefreshEvictor struct {
+	handle     frameworktypes.Handle
+	args       *AgedImageRefreshEvictorArgs
+	podFilter  podutil.FilterFunc
+	registries map[string]*registryClient
+	// images caches the images resolved from the registries in the cycle
+	images map[string]resolvedImage
+}
+
+var _ frameworktypes.DeschedulePlugin = &AgedImageRefreshEvictor{}
+
+// resolvedImage is the outcome of the resolution of an image from its registry
+type resolvedImage struct {
+	builtAt time.Time
+	// digests of the manifests of the image, or of the image its tag points to
+	digests []string
+	err     error
+}
+
+// agedPod is a pod running an image built longer ago than the configured age
+type agedPod struct {
+	pod     *v1.Pod
+	builtAt time.Time
+	reason  string
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	agedArgs, ok := args.(*AgedImageRefreshEvictorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type AgedImageRefreshEvictorArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if agedArgs.Namespaces != nil {
+		includedNamespaces = sets.New(agedArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(agedArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(agedArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	registries := map[string]*registryClient{}
+	for _, registry := range agedArgs.Registries {
+		registries[registry.Host] = newRegistryClient(registry)
+	}
+
+	return &AgedImageRefreshEvictor{
+		handle:     handle,
+		args:       agedArgs,
+		podFilter:  podFilter,
+		registries: registries,
+		images:     map[string]resolvedImage{},
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *AgedImageRefreshEvictor) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *AgedImageRefreshEvictor) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	now := time.Now()
+	var aged []agedPod
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		p := nodePlatform(node)
+		for _, pod := range pods {
+			builtAt, reason, ok := d.oldestImage(ctx, pod, p)
+			if !ok || now.Sub(builtAt) <= d.args.MaxImageAge.Duration {
+				continue
+			}
+			aged = append(aged, agedPod{pod: pod, builtAt: builtAt, reason: reason})
+		}
+	}
+
+	// The pods running the oldest images are refreshed first
+	sort.SliceStable(aged, func(i, j int) bool {
+		return aged[i].builtAt.Before(aged[j].builtAt)
+	})
+	var evicted uint
+	evictedByOwner := map[types.UID]uint{}
+	for _, candidate := range aged {
+		owner := podutil.OwnerUID(candidate.pod)
+		if evictedByOwner[owner] >= *d.args.MaxEvictionsPerOwner {
+			continue
+		}
+		logger.V(2).Info("Pod runs an aged image", "pod", klog.KObj(candidate.pod), "reason", candidate.reason)
+		err := d.handle.Evictor().Evict(ctx, candidate.pod, evictions.EvictOptions{StrategyName: PluginName, Reason: candidate.reason})
+		if err == nil {
+			evicted++
+			evictedByOwner[owner]++
+			if evicted >= *d.args.MaxEvictionsPerCycle {
+				return nil
+			}
+			continue
+		}
+		switch err.(type) {
+		case *evictions.EvictionNodeLimitError:
+			continue
+		case *evictions.EvictionTotalLimitError:
+			return nil
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	return nil
+}
+
+// oldestImage returns the build date of the oldest image of the pod a refresh would replace with the
+// reason to evict the pod for it, false when none is known
+func (d *AgedImageRefreshEvictor) oldestImage(ctx context.Context, pod *v1.Pod, p platform) (time.Time, string, bool) {
+	logger := klog.FromContext(ctx)
+	if value, ok := pod.Annotations[d.args.BuildDateAnnotation]; ok {
+		builtAt, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+		if err != nil {
+			logger.Error(err, "Unable to parse the build date of the images", "pod", klog.KObj(pod), "annotation", d.args.BuildDateAnnotation)
+			return time.Time{}, "", false
+		}
+		return builtAt, fmt.Sprintf("images built on %s, more than %s ago", builtAt.Format(time.RFC3339), d.args.MaxImageAge.Duration), true
+	}
+
+	imageIDs := map[string]string{}
+	for _, status := range pod.Status.ContainerStatuses {
+		imageIDs[status.Name] = status.ImageID
+	}
+	var oldest time.Time
+	var reason string
+	for _, container := range pod.Spec.Containers {
+		ref := parseImageReference(container.Image)
+		client, ok := d.registries[ref.registry]
+		running := imageDigest(imageIDs[container.Name])
+		if !ok || ref.pinned() || running == "" {
+			continue
+		}
+		image := d.resolve(ctx, client, ref.registry, ref.repository, running, p)
+		if image.err != nil {
+			logger.Error(image.err, "Unable to resolve the build date of the image", "pod", klog.KObj(pod), "image", container.Image)
+			continue
+		}
+		if !oldest.IsZero() && !image.builtAt.Before(oldest) {
+			continue
+		}
+		latest := d.resolve(ctx, client, ref.registry, ref.repository, ref.reference, p)
+		if latest.err != nil {
+			logger.Error(latest.err, "Unable to resolve the image of the tag", "pod", klog.KObj(pod), "image", container.Image)
+			continue
+		}
+		if sets.New(latest.digests...).HasAny(image.digests...) {
+			logger.V(3).Info("No newer image to refresh to", "pod", klog.KObj(pod), "image", container.Image)
+			continue
+		}
+		oldest = image.builtAt
+		reason = fmt.Sprintf("image %s built on %s, more than %s ago", container.Image, image.builtAt.Format(time.RFC3339), d.args.MaxImageAge.Duration)
+	}
+	return oldest, reason, !oldest.IsZero()
+}
+
+// resolve resolves the image of the registry built for the platform, once per cycle
+func (d *AgedImageRefreshEvictor) resolve(ctx context.Context, client *registryClient, registry, repository, reference string, p platform) resolvedImage {
+	key := registry + "/" + repository + "@" + reference + " " + p.String()
+	if image, ok := d.images[key]; ok {
+		return image
+	}
+	var image resolvedImage
+	m, digests, err := client.resolve(ctx, repository, reference, p)
+	if err == nil {
+		image.digests = digests
+		image.builtAt, err = client.buildDate(ctx, repository, m)
+	}
+	image.err = err
+	d.images[key] = image
+	return image
+}
+
+// nodePlatform returns the platform of the images run by the node, linux/amd64 when not reported
+func nodePlatform(node *v1.Node) platform {
+	p := platform{os: node.Status.NodeInfo.OperatingSystem, architecture: node.Status.NodeInfo.Architecture}
+	if p.os == "" {
+		p.os = "linux"
+	}
+	if p.architecture == "" {
+		p.architecture = "amd64"
+	}
+	return p
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agedimagerefreshevictor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func digestOf(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// fakeRegistry serves the manifests and image configurations of the team/app repository, handing
+// out a token to the clients authenticating as descheduler
+type fakeRegistry struct {
+	*httptest.Server
+	// oldDigest is the digest of the image built in 2020, newDigest of the one built a day ago
+	// which the 1.0 tag points to through an image index, the 2.0 tag pointing to the old image
+	oldDigest string
+	newDigest string
+}
+
+func newFakeRegistry(t *testing.T) *fakeRegistry {
+	config := `{"created":"2020-01-01T00:00:00Z"}`
+	oldManifest := fmt.Sprintf(`{"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"digest":%q}}`, digestOf(config))
+	newManifest := fmt.Sprintf(`{"mediaType":"application/vnd.oci.image.manifest.v1+json","annotations":{%q:%q}}`,
+		createdAnnotation, time.Now().Add(-24*time.Hour).UTC().Format(time.RFC3339))
+	index := fmt.Sprintf(`{"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[`+
+		`{"digest":"sha256:arm","platform":{"architecture":"arm64","os":"linux"}},`+
+		`{"digest":%q,"platform":{"architecture":"amd64","os":"linux"}}]}`, digestOf(newManifest))
+
+	r := &fakeRegistry{oldDigest: digestOf(oldManifest), newDigest: digestOf(newManifest)}
+	paths := map[string]string{
+		"/v2/team/app/manifests/1.0":             index,
+		"/v2/team/app/manifests/2.0":             oldManifest,
+		"/v2/team/app/manifests/" + r.oldDigest:  oldManifest,
+		"/v2/team/app/manifests/" + r.newDigest:  newManifest,
+		"/v2/team/app/blobs/" + digestOf(config): config,
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			if user, password, ok := req.BasicAuth(); !ok || user != "descheduler" || password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if req.URL.Query().Get("scope") != "repository:team/app:pull" {
+				t.Errorf("Unexpected token scope %q", req.URL.Query().Get("scope"))
+			}
+			fmt.Fprint(w, `{"token":"pull-token"}`)
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer pull-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:team/app:pull"`, r.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, ok := paths[req.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func TestAgedImageRefreshEvictor(t *testing.T) {
+	registry := newFakeRegistry(t)
+	host := strings.TrimPrefix(registry.URL, "http://")
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatalf("Unable to write the password file: %v", err)
+	}
+
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, nil)
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	buildPod := func(name, owner string, apply func(pod *v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, n1.Name, func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "v1", Name: owner, UID: types.UID(owner)}}
+			apply(pod)
+		})
+	}
+	withBuildDate := func(date string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Annotations = map[string]string{DefaultBuildDateAnnotation: date}
+		}
+	}
+	withImage := func(image, digest string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.Containers[0].Image = image
+			pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+				Name:    pod.Spec.Containers[0].Name,
+				Image:   image,
+				ImageID: image[:strings.LastIndexAny(image, ":@")] + "@" + digest,
+			}}
+		}
+	}
+
+	tests := []struct {
+		description     string
+		pods            []*v1.Pod
+		args            AgedImageRefreshEvictorArgs
+		expectedEvicted []string
+	}{
+		{
+			description: "Pod annotated with an old build date is evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withBuildDate(old)),
+				buildPod("p2", "rs2", withBuildDate(recent)),
+				buildPod("p3", "rs3", withBuildDate("yesterday")),
+			},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Pod running an old image whose tag got rebuilt is evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withImage(host+"/team/app:1.0", registry.oldDigest)),
+				buildPod("p2", "rs2", withImage(host+"/team/app:1.0", registry.newDigest)),
+			},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Pod running an old image whose tag was not rebuilt is not evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withImage(host+"/team/app:2.0", registry.oldDigest)),
+			},
+		},
+		{
+			description: "Pod running an old image pinned by digest is not evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withImage(host+"/team/app@"+registry.oldDigest, registry.oldDigest)),
+			},
+		},
+		{
+			description: "Pod running an image of another registry is not evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withImage("registry.example.com/team/app:1.0", registry.oldDigest)),
+			},
+		},
+		{
+			description: "Pods running the oldest images are evicted first, once per owner",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withBuildDate("2021-01-01T00:00:00Z")),
+				buildPod("p2", "rs1", withBuildDate("2019-01-01T00:00:00Z")),
+				buildPod("p3", "rs2", withImage(host+"/team/app:1.0", registry.oldDigest)),
+				buildPod("p4", "rs3", withBuildDate("2022-01-01T00:00:00Z")),
+			},
+			args:            AgedImageRefreshEvictorArgs{MaxEvictionsPerCycle: utilptr.To[uint](2)},
+			expectedEvicted: []string{"p2", "p3"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			args := tc.args
+			args.MaxImageAge = metav1.Duration{Duration: 30 * 24 * time.Hour}
+			args.Registries = []Registry{{Host: host, Insecure: true, Username: "descheduler", PasswordFile: passwordFile}}
+			SetDefaults_AgedImageRefreshEvictorArgs(&args)
+
+			scenario.New().
+				WithNodes(n1).
+				WithPods(tc.pods...).
+				ExpectEvicted(tc.expectedEvicted...).
+				Run(t, New, &args)
+		})
+	}
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image string
+		want  imageReference
+	}{
+		{image: "nginx", want: imageReference{registry: "docker.io", repository: "library/nginx", reference: "latest"}},
+		{image: "localhost:5000/team/app:1.0", want: imageReference{registry: "localhost:5000", repository: "team/app", reference: "1.0"}},
+		{image: "registry.example.com/app:1.0@sha256:abc", want: imageReference{registry: "registry.example.com", repository: "app", reference: "sha256:abc"}},
+	}
+	for _, tc := range tests {
+		if got := parseImageReference(tc.image); got != tc.want {
+			t.Errorf("Expected %q to be parsed into %+v, got %+v", tc.image, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agedimagerefreshevictor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestSetDefaults_AgedImageRefreshEvictorArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "AgedImageRefreshEvictorArgs empty",
+			in:   &AgedImageRefreshEvictorArgs{},
+			want: &AgedImageRefreshEvictorArgs{
+				BuildDateAnnotation:  "descheduler.io/image-build-date",
+				MaxEvictionsPerCycle: utilptr.To[uint](1),
+				MaxEvictionsPerOwner: utilptr.To[uint](1),
+			},
+		},
+		{
+			name: "AgedImageRefreshEvictorArgs with registries",
+			in: &AgedImageRefreshEvictorArgs{
+				MaxImageAge:          metav1.Duration{Duration: 720 * time.Hour},
+				BuildDateAnnotation:  "example.com/built-at",
+				Registries:           []Registry{{Host: "registry.example.com"}, {Host: "docker.io", Timeout: &metav1.Duration{Duration: time.Minute}}},
+				MaxEvictionsPerCycle: utilptr.To[uint](5),
+				MaxEvictionsPerOwner: utilptr.To[uint](2),
+			},
+			want: &AgedImageRefreshEvictorArgs{
+				MaxImageAge:         metav1.Duration{Duration: 720 * time.Hour},
+				BuildDateAnnotation: "example.com/built-at",
+				Registries: []Registry{
+					{Host: "registry.example.com", Timeout: &metav1.Duration{Duration: 10 * time.Second}},
+					{Host: "docker.io", Timeout: &metav1.Duration{Duration: time.Minute}},
+				},
+				MaxEvictionsPerCycle: utilptr.To[uint](5),
+				MaxEvictionsPerOwner: utilptr.To[uint](2),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_AgedImageRefreshEvictorArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,362 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agedimagerefreshevictor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	// createdAnnotation is the annotation of the OCI manifests holding the build date of the image
+	createdAnnotation = "org.opencontainers.image.created"
+	// maxResponseSize bounds the manifests and image configurations read from the registries
+	maxResponseSize = 4 << 20
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// imageReference is an image of a registry, e.g. registry.example.com/team/app:1.0
+type imageReference struct {
+	registry   string
+	repository string
+	// reference is the tag or the digest of the image
+	reference string
+}
+
+// parseImageReference splits the image into its registry, repository and tag or digest, the way the
+// container runtimes expand it
+func parseImageReference(image string) imageReference {
+	name := utils.NormalizeImageReference(image)
+	slash := strings.Index(name, "/")
+	ref := imageReference{registry: name[:slash], repository: name[slash+1:]}
+	if at := strings.Index(ref.repository, "@"); at >= 0 {
+		ref.repository, ref.reference = ref.repository[:at], ref.repository[at+1:]
+	}
+	// The tag is dropped from the references pinned by digest
+	if colon := strings.LastIndex(ref.repository, ":"); colon > strings.LastIndex(ref.repository, "/") {
+		if ref.reference == "" {
+			ref.reference = ref.repository[colon+1:]
+		}
+		ref.repository = ref.repository[:colon]
+	}
+	return ref
+}
+
+// pinned tells whether the image is referenced by digest, pulling it again always getting the same image
+func (r imageReference) pinned() bool {
+	return strings.HasPrefix(r.reference, "sha256:")
+}
+
+// imageDigest returns the digest of the image the container runs as reported in its status,
+// e.g. docker.io/library/nginx@sha256:..., or an empty string when not reported
+func imageDigest(imageID string) string {
+	at := strings.LastIndex(imageID, "@")
+	if at < 0 || !strings.HasPrefix(imageID[at+1:], "sha256:") {
+		return ""
+	}
+	return imageID[at+1:]
+}
+
+// platform is the operating system and architecture the images of a node are built for
+type platform struct {
+	os           string
+	architecture string
+}
+
+func (p platform) String() string {
+	return p.os + "/" + p.architecture
+}
+
+// manifest is an image manifest or an image index of the OCI or docker distribution formats
+type manifest struct {
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform *struct {
+			Architecture string `json:"architecture"`
+			OS           string `json:"os"`
+		} `json:"platform,omitempty"`
+	} `json:"manifests,omitempty"`
+	Config *struct {
+		Digest string `json:"digest"`
+	} `json:"config,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// platformManifest returns the digest of the manifest of the image index built for the platform
+func (m *manifest) platformManifest(p platform) string {
+	for _, entry := range m.Manifests {
+		if entry.Platform != nil && entry.Platform.OS == p.os && entry.Platform.Architecture == p.architecture {
+			return entry.Digest
+		}
+	}
+	return ""
+}
+
+// registryClient fetches the manifests and image configurations of a registry through the
+// distribution API, authenticating with the configured credentials or the tokens they get
+// from the authorization server of the registry.
+type registryClient struct {
+	httpClient      *http.Client
+	baseURL         string
+	username        string
+	passwordFile    string
+	bearerTokenFile string
+
+	mu sync.Mutex
+	// tokens handed out by the authorization server, by repository
+	tokens map[string]string
+}
+
+func newRegistryClient(registry Registry) *registryClient {
+	scheme := "https"
+	if registry.Insecure {
+		scheme = "http"
+	}
+	host := registry.Host
+	// The images of docker.io are served by another host
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	return &registryClient{
+		httpClient:      utils.NewHTTPClient(registry.Timeout),
+		baseURL:         scheme + "://" + host,
+		username:        registry.Username,
+		passwordFile:    registry.PasswordFile,
+		bearerTokenFile: registry.BearerTokenFile,
+		tokens:          map[string]string{},
+	}
+}
+
+// resolve fetches the manifest of the image built for the platform, following the image index
+// the reference may point to. The digests of the manifests fetched along the way are returned too.
+func (c *registryClient) resolve(ctx context.Context, repository, reference string, p platform) (*manifest, []string, error) {
+	var digests []string
+	// An image index only lists image manifests
+	for i := 0; i < 2; i++ {
+		body, err := c.fetch(ctx, repository, "/v2/"+repository+"/manifests/"+reference, manifestMediaTypes)
+		if err != nil {
+			return nil, nil, err
+		}
+		sum := sha256.Sum256(body)
+		digests = append(digests, "sha256:"+hex.EncodeToString(sum[:]))
+		m := &manifest{}
+		if err := json.Unmarshal(body, m); err != nil {
+			return nil, nil, fmt.Errorf("unable to decode the manifest of %s: %v", reference, err)
+		}
+		if len(m.Manifests) == 0 {
+			return m, digests, nil
+		}
+		if reference = m.platformManifest(p); reference == "" {
+			return nil, nil, fmt.Errorf("no image built for %s", p)
+		}
+	}
+	return nil, nil, fmt.Errorf("image index of %s lists another image index", reference)
+}
+
+// buildDate returns the build date of the image of the manifest, out of its annotations or its configuration
+func (c *registryClient) buildDate(ctx context.Context, repository string, m *manifest) (time.Time, error) {
+	if created, ok := m.Annotations[createdAnnotation]; ok {
+		return time.Parse(time.RFC3339, created)
+	}
+	if m.Config == nil || m.Config.Digest == "" {
+		return time.Time{}, fmt.Errorf("manifest has no image configuration")
+	}
+	body, err := c.fetch(ctx, repository, "/v2/"+repository+"/blobs/"+m.Config.Digest, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	config := struct {
+		Created *time.Time `json:"created"`
+	}{}
+	if err := json.Unmarshal(body, &config); err != nil {
+		return time.Time{}, fmt.Errorf("unable to decode the image configuration: %v", err)
+	}
+	if config.Created == nil {
+		return time.Time{}, fmt.Errorf("image configuration has no build date")
+	}
+	return *config.Created, nil
+}
+
+// fetch gets the path of the repository, authenticating again when the registry asks to
+func (c *registryClient) fetch(ctx context.Context, repository, path string, accept []string) ([]byte, error) {
+	resp, err := c.get(ctx, repository, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.bearerTokenFile == "" {
+		resp.Body.Close()
+		if err := c.authenticate(ctx, repository, resp.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, err
+		}
+		if resp, err = c.get(ctx, repository, path, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned status %d for %s", resp.StatusCode, path)
+	}
+	return body, nil
+}
+
+func (c *registryClient) get(ctx context.Context, repository, path string, accept []string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, mediaType := range accept {
+		req.Header.Add("Accept", mediaType)
+	}
+	if c.bearerTokenFile != "" {
+		if err := utils.SetBearerToken(req, c.bearerTokenFile); err != nil {
+			return nil, err
+		}
+	} else if token, ok := c.token(repository); ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if c.username != "" {
+		password, err := c.password()
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(c.username, password)
+	}
+	return c.httpClient.Do(req)
+}
+
+func (c *registryClient) token(repository string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	token, ok := c.tokens[repository]
+	return token, ok
+}
+
+func (c *registryClient) password() (string, error) {
+	password, err := os.ReadFile(c.passwordFile)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the registry password: %v", err)
+	}
+	return strings.TrimSpace(string(password)), nil
+}
+
+// authenticate gets a token to pull the images of the repository from the authorization server
+// named by the challenge of the registry
+func (c *registryClient) authenticate(ctx context.Context, repository, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	if !strings.EqualFold(scheme, "bearer") || params["realm"] == "" {
+		return fmt.Errorf("registry refused the credentials")
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil {
+		return fmt.Errorf("invalid authorization server %q: %v", params["realm"], err)
+	}
+	query := realm.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = "repository:" + repository + ":pull"
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		password, err := c.password()
+		if err != nil {
+			return err
+		}
+		req.SetBasicAuth(c.username, password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("authorization server returned status %d", resp.StatusCode)
+	}
+	response := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&response); err != nil {
+		return fmt.Errorf("unable to decode the token: %v", err)
+	}
+	token := response.Token
+	if token == "" {
+		token = response.AccessToken
+	}
+	if token == "" {
+		return fmt.Errorf("authorization server returned no token")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens[repository] = token
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header, e.g.
+// Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:app:pull"
+func parseChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for {
+		rest = strings.TrimLeft(rest, " ,")
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			return scheme, params
+		}
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[strings.ToLower(strings.TrimSpace(key))] = value[1:]
+				return scheme, params
+			}
+			params[strings.ToLower(strings.TrimSpace(key))] = value[1 : end+1]
+			rest = value[end+2:]
+			continue
+		}
+		value, rest, _ = strings.Cut(value, ",")
+		params[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agedimagerefreshevictor
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AgedImageRefreshEvictorArgs holds arguments used to configure AgedImageRefreshEvictor plugin.
+type AgedImageRefreshEvictorArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// MaxImageAge is how long ago the images of a pod may have been built before the pod gets evicted
+	MaxImageAge metav1.Duration `json:"maxImageAge"`
+	// BuildDateAnnotation is the annotation of the pods holding the build date of their images, in RFC 3339
+	// format, e.g. as set by the image pipeline. It takes precedence over the registries.
+	// Defaults to descheduler.io/image-build-date.
+	BuildDateAnnotation string `json:"buildDateAnnotation,omitempty"`
+	// Registries are the registries the build date of the images is resolved from. The images of the
+	// other registries are only aged through the annotation.
+	Registries []Registry `json:"registries,omitempty"`
+	// MaxEvictionsPerCycle limits the pods evicted by the plugin in a descheduling cycle. Defaults to 1.
+	MaxEvictionsPerCycle *uint `json:"maxEvictionsPerCycle,omitempty"`
+	// MaxEvictionsPerOwner limits the pods of the same owner evicted by the plugin in a descheduling cycle.
+	// Defaults to 1.
+	MaxEvictionsPerOwner *uint `json:"maxEvictionsPerOwner,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Registry configures how the manifests of the images of a registry are fetched.
+type Registry struct {
+	// Host of the registry, as found in the image references, e.g. registry.example.com or docker.io
+	Host string `json:"host"`
+	// Insecure talks to the registry over plain HTTP
+	Insecure bool `json:"insecure,omitempty"`
+	// Username authenticates against the registry along with the password of PasswordFile
+	Username string `json:"username,omitempty"`
+	// PasswordFile is a file holding the password of Username
+	PasswordFile string `json:"passwordFile,omitempty"`
+	// BearerTokenFile is a file holding the token sent to authenticate against the registry, instead
+	// of the username and password
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// Timeout of each request to the registry
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agedimagerefreshevictor
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ValidateAgedImageRefreshEvictorArgs validates AgedImageRefreshEvictor arguments
+func ValidateAgedImageRefreshEvictorArgs(obj runtime.Object) error {
+	args := obj.(*AgedImageRefreshEvictorArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.MaxImageAge.Duration <= 0 {
+		return fmt.Errorf("maxImageAge has to be positive")
+	}
+	if args.MaxEvictionsPerCycle != nil && *args.MaxEvictionsPerCycle == 0 {
+		return fmt.Errorf("maxEvictionsPerCycle has to be positive")
+	}
+	if args.MaxEvictionsPerOwner != nil && *args.MaxEvictionsPerOwner == 0 {
+		return fmt.Errorf("maxEvictionsPerOwner has to be positive")
+	}
+
+	hosts := sets.New[string]()
+	for _, registry := range args.Registries {
+		if registry.Host == "" || strings.ContainsAny(registry.Host, "/ ") {
+			return fmt.Errorf("registry host %q is not a valid host", registry.Host)
+		}
+		if hosts.Has(registry.Host) {
+			return fmt.Errorf("registry %q is configured more than once", registry.Host)
+		}
+		hosts.Insert(registry.Host)
+		if registry.BearerTokenFile != "" && (registry.Username != "" || registry.PasswordFile != "") {
+			return fmt.Errorf("registry %q can not authenticate with both a bearer token and a username", registry.Host)
+		}
+		if (registry.Username == "") != (registry.PasswordFile == "") {
+			return fmt.Errorf("registry %q needs both a username and a password file", registry.Host)
+		}
+		if registry.Timeout != nil && registry.Timeout.Duration <= 0 {
+			return fmt.Errorf("registry %q timeout has to be positive", registry.Host)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agedimagerefreshevictor
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateAgedImageRefreshEvictorArgs(t *testing.T) {
+	maxImageAge := metav1.Duration{Duration: 720 * time.Hour}
+	testCases := []struct {
+		description string
+		args        *AgedImageRefreshEvictorArgs
+		expectError bool
+	}{
+		{
+			description: "valid annotation only, no errors",
+			args:        &AgedImageRefreshEvictorArgs{MaxImageAge: maxImageAge},
+			expectError: false,
+		},
+		{
+			description: "valid registries, no errors",
+			args: &AgedImageRefreshEvictorArgs{
+				MaxImageAge: maxImageAge,
+				Registries: []Registry{
+					{Host: "registry.example.com", Username: "descheduler", PasswordFile: "/etc/registry/password"},
+					{Host: "localhost:5000", Insecure: true, BearerTokenFile: "/etc/registry/token"},
+				},
+				MaxEvictionsPerCycle: utilptr.To[uint](3),
+			},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &AgedImageRefreshEvictorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+				MaxImageAge: maxImageAge,
+			},
+			expectError: true,
+		},
+		{
+			description: "no max image age, expects error",
+			args:        &AgedImageRefreshEvictorArgs{},
+			expectError: true,
+		},
+		{
+			description: "zero max evictions per cycle, expects error",
+			args:        &AgedImageRefreshEvictorArgs{MaxImageAge: maxImageAge, MaxEvictionsPerCycle: utilptr.To[uint](0)},
+			expectError: true,
+		},
+		{
+			description: "zero max evictions per owner, expects error",
+			args:        &AgedImageRefreshEvictorArgs{MaxImageAge: maxImageAge, MaxEvictionsPerOwner: utilptr.To[uint](0)},
+			expectError: true,
+		},
+		{
+			description: "registry host with a path, expects error",
+			args:        &AgedImageRefreshEvictorArgs{MaxImageAge: maxImageAge, Registries: []Registry{{Host: "registry.example.com/team"}}},
+			expectError: true,
+		},
+		{
+			description: "registry configured twice, expects error",
+			args: &AgedImageRefreshEvictorArgs{
+				MaxImageAge: maxImageAge,
+				Registries:  []Registry{{Host: "registry.example.com"}, {Host: "registry.example.com"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "username without password, expects error",
+			args: &AgedImageRefreshEvictorArgs{
+				MaxImageAge: maxImageAge,
+				Registries:  []Registry{{Host: "registry.example.com", Username: "descheduler"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "both bearer token and username, expects error",
+			args: &AgedImageRefreshEvictorArgs{
+				MaxImageAge: maxImageAge,
+				Registries: []Registry{{
+					Host:            "registry.example.com",
+					Username:        "descheduler",
+					PasswordFile:    "/etc/registry/password",
+					BearerTokenFile: "/etc/registry/token",
+				}},
+			},
+			expectError: true,
+		},
+		{
+			description: "negative registry timeout, expects error",
+			args: &AgedImageRefreshEvictorArgs{
+				MaxImageAge: maxImageAge,
+				Registries:  []Registry{{Host: "registry.example.com", Timeout: &metav1.Duration{Duration: -time.Second}}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateAgedImageRefreshEvictorArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package antiaffinityrelaxationdetector
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_AntiAffinityRelaxationDetectorArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_AntiAffinityRelaxationDetectorArgs(obj runtime.Object) {
+	args := obj.(*AntiAffinityRelaxationDetectorArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.HeadroomThresholds == nil {
+		args.HeadroomThresholds = api.ResourceThresholds{
+			v1.ResourceCPU:    70,
+			v1.ResourceMemory: 70,
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package antiaffinityrelaxationdetector
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestSetDefaults_AntiAffinityRelaxationDetectorArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "AntiAffinityRelaxationDetectorArgs empty",
+			in:   &AntiAffinityRelaxationDetectorArgs{},
+			want: &AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: api.ResourceThresholds{
+					v1.ResourceCPU:    70,
+					v1.ResourceMemory: 70,
+				},
+			},
+		},
+		{
+			name: "AntiAffinityRelaxationDetectorArgs with value",
+			in: &AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: api.ResourceThresholds{
+					v1.ResourcePods: 50,
+				},
+				IncludeSoftTopologySpreadConstraints: true,
+			},
+			want: &AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: api.ResourceThresholds{
+					v1.ResourcePods: 50,
+				},
+				IncludeSoftTopologySpreadConstraints: true,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_AntiAffinityRelaxationDetectorArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
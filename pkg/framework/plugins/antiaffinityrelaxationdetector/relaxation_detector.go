@@ -0,0 +1,294 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package antiaffinityrelaxationdetector
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "AntiAffinityRelaxationDetector"
+
+// AntiAffinityRelaxationDetector moves pods whose soft placement preferences got relaxed by
+// the scheduler, typically while the cluster was short of capacity: pods placed next to pods
+// matching their preferred pod anti-affinity and, optionally, pods skewing their ScheduleAnyway
+// topology spread constraints. Pods are only moved once the cluster has headroom again, i.e. the
+// cluster wide resource requests are below the configured thresholds, and when they fit on a node
+// satisfying their preferences.
+type AntiAffinityRelaxationDetector struct {
+	handle    frameworktypes.Handle
+	args      *AntiAffinityRelaxationDetectorArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.BalancePlugin = &AntiAffinityRelaxationDetector{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	relaxationArgs, ok := args.(*AntiAffinityRelaxationDetectorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type AntiAffinityRelaxationDetectorArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if relaxationArgs.Namespaces != nil {
+		includedNamespaces = sets.New(relaxationArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(relaxationArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(relaxationArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &AntiAffinityRelaxationDetector{
+		handle:    handle,
+		args:      relaxationArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *AntiAffinityRelaxationDetector) Name() string {
+	return PluginName
+}
+
+// clusterUsage returns the resources requested by the pods in percent of the allocatable resources of the nodes
+func clusterUsage(nodes []*v1.Node, pods []*v1.Pod, resourceNames []v1.ResourceName) map[v1.ResourceName]float64 {
+	requested := nodeutil.NodeUtilization(pods, resourceNames)
+	usage := make(map[v1.ResourceName]float64, len(resourceNames))
+	for _, name := range resourceNames {
+		var allocatable int64
+		for _, node := range nodes {
+			quantity := node.Status.Allocatable[name]
+			if name == v1.ResourceCPU {
+				allocatable += quantity.MilliValue()
+			} else {
+				allocatable += quantity.Value()
+			}
+		}
+		if allocatable == 0 {
+			usage[name] = math.Inf(1)
+			continue
+		}
+		used := requested[name].Value()
+		if name == v1.ResourceCPU {
+			used = requested[name].MilliValue()
+		}
+		usage[name] = float64(used) * 100 / float64(allocatable)
+	}
+	return usage
+}
+
+// hasHeadroom returns true when the usage of every resource is below its threshold
+func hasHeadroom(usage map[v1.ResourceName]float64, thresholds api.ResourceThresholds) bool {
+	for name, threshold := range thresholds {
+		if usage[name] >= float64(threshold) {
+			return false
+		}
+	}
+	return true
+}
+
+// placements tracks where the pods already processed run, or are expected to run once rescheduled
+type placements struct {
+	logger klog.Logger
+	nodes  map[string]*v1.Node
+	// pods by namespace, with the node they are placed on
+	pods map[string]map[*v1.Pod]*v1.Node
+}
+
+func newPlacements(logger klog.Logger, nodes *nodeutil.NodeSnapshot) *placements {
+	return &placements{
+		logger: logger,
+		nodes:  nodes.NodeMap(),
+		pods:   map[string]map[*v1.Pod]*v1.Node{},
+	}
+}
+
+func (p *placements) place(pod *v1.Pod, node *v1.Node) {
+	if p.pods[pod.Namespace] == nil {
+		p.pods[pod.Namespace] = map[*v1.Pod]*v1.Node{}
+	}
+	p.pods[pod.Namespace][pod] = node
+}
+
+// violatesAntiAffinity returns true if placing the pod on the node puts it in the same topology
+// domain as a pod matching one of its preferred pod anti-affinity terms
+func (p *placements) violatesAntiAffinity(pod *v1.Pod, node *v1.Node) bool {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.PodAntiAffinity == nil {
+		return false
+	}
+	for _, weightedTerm := range pod.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		term := weightedTerm.PodAffinityTerm
+		value, ok := node.Labels[term.TopologyKey]
+		if !ok {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			p.logger.Error(err, "Unable to convert LabelSelector into Selector", "pod", klog.KObj(pod))
+			continue
+		}
+		namespaces := utils.GetNamespacesFromPodAffinityTerm(pod, &term)
+		for namespace := range namespaces {
+			for other, otherNode := range p.pods[namespace] {
+				if other.UID == pod.UID || !utils.PodMatchesTermsNamespaceAndSelector(other, namespaces, selector) {
+					continue
+				}
+				if otherNode.Labels[term.TopologyKey] == value {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// violatesSpreadConstraints returns true if placing the pod on the node skews one of its
+// ScheduleAnyway topology spread constraints beyond its maxSkew
+func (p *placements) violatesSpreadConstraints(pod *v1.Pod, node *v1.Node) bool {
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != v1.ScheduleAnyway {
+			continue
+		}
+		value, ok := node.Labels[constraint.TopologyKey]
+		if !ok {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+		if err != nil {
+			p.logger.Error(err, "Unable to convert LabelSelector into Selector", "pod", klog.KObj(pod))
+			continue
+		}
+		counts := map[string]int{}
+		for _, n := range p.nodes {
+			if domain, ok := n.Labels[constraint.TopologyKey]; ok {
+				counts[domain] = 0
+			}
+		}
+		for other, otherNode := range p.pods[pod.Namespace] {
+			if other.UID == pod.UID || !selector.Matches(labels.Set(other.Labels)) {
+				continue
+			}
+			if domain, ok := otherNode.Labels[constraint.TopologyKey]; ok {
+				counts[domain]++
+			}
+		}
+		counts[value]++
+		minCount := math.MaxInt32
+		for _, count := range counts {
+			minCount = min(minCount, count)
+		}
+		if int32(counts[value]-minCount) > constraint.MaxSkew {
+			return true
+		}
+	}
+	return false
+}
+
+// Balance extension point implementation for the plugin
+func (d *AntiAffinityRelaxationDetector) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	pods, err := podutil.ListPodsOnNodes(nodes, d.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing all pods: %v", err),
+		}
+	}
+
+	resourceNames := make([]v1.ResourceName, 0, len(d.args.HeadroomThresholds))
+	for name := range d.args.HeadroomThresholds {
+		resourceNames = append(resourceNames, name)
+	}
+	usage := clusterUsage(nodes, pods, resourceNames)
+	if !hasHeadroom(usage, d.args.HeadroomThresholds) {
+		logger.V(1).Info("Cluster has no headroom, skipping", "usage", usage, "thresholds", d.args.HeadroomThresholds)
+		return nil
+	}
+
+	violates := func(p *placements, pod *v1.Pod, node *v1.Node) bool {
+		if p.violatesAntiAffinity(pod, node) {
+			return true
+		}
+		return d.args.IncludeSoftTopologySpreadConstraints && p.violatesSpreadConstraints(pod, node)
+	}
+
+	// Pods are processed oldest first, the youngest pods being the ones placed once the preferences got relaxed
+	podutil.SortPodsBasedOnAge(pods)
+	placed := newPlacements(logger, d.handle.NodeSnapshot())
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	for _, pod := range pods {
+		node := placed.nodes[pod.Spec.NodeName]
+		if utils.IsPodTerminating(pod) || !d.podFilter(pod) || !violates(placed, pod, node) {
+			placed.place(pod, node)
+			continue
+		}
+
+		// The pod is expected to be rescheduled to the first node it fits on while satisfying its preferences
+		var target *v1.Node
+		for _, candidate := range nodes {
+			if candidate.Name == node.Name || violates(placed, pod, candidate) {
+				continue
+			}
+			if err := nodeutil.NodeFit(getPodsAssignedToNode, pod, candidate); err == nil {
+				target = candidate
+				break
+			}
+		}
+		if target == nil {
+			logger.V(2).Info("Pod placement preferences are relaxed but no node satisfies them", "pod", klog.KObj(pod), "node", klog.KObj(node))
+			placed.place(pod, node)
+			continue
+		}
+
+		logger.V(2).Info("Moving pod whose placement preferences got relaxed", "pod", klog.KObj(pod), "node", klog.KObj(node), "target", klog.KObj(target))
+		err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+		if err == nil {
+			placed.place(pod, target)
+			continue
+		}
+		placed.place(pod, node)
+		switch err.(type) {
+		case *evictions.EvictionNodeLimitError:
+			continue
+		case *evictions.EvictionTotalLimitError:
+			return nil
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	return nil
+}
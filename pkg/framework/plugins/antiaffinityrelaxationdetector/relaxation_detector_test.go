@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package antiaffinityrelaxationdetector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildNode(name, zone string) *v1.Node {
+	return test.BuildTestNode(name, 4000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{v1.LabelHostname: name, v1.LabelTopologyZone: zone}
+	})
+}
+
+func buildPods(count int, nodeName string, apply func(*v1.Pod)) []*v1.Pod {
+	var pods []*v1.Pod
+	for i := 0; i < count; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("%s-p%d", nodeName, i), 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.UID = types.UID(pod.Name)
+			pod.Labels = map[string]string{"app": "web"}
+			pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Duration(count-i) * time.Minute))
+			if apply != nil {
+				apply(pod)
+			}
+		}))
+	}
+	return pods
+}
+
+func withPreferredAntiAffinity(pod *v1.Pod) {
+	pod.Spec.Affinity = &v1.Affinity{
+		PodAntiAffinity: &v1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: v1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						TopologyKey:   v1.LabelHostname,
+					},
+				},
+			},
+		},
+	}
+}
+
+func withSoftSpreadConstraint(pod *v1.Pod) {
+	pod.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       v1.LabelTopologyZone,
+			WhenUnsatisfiable: v1.ScheduleAnyway,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+}
+
+func TestAntiAffinityRelaxationDetector(t *testing.T) {
+	n1 := buildNode("n1", "zoneA")
+	n2 := buildNode("n2", "zoneB")
+	tainted := buildNode("n2", "zoneB")
+	tainted.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "db", Effect: v1.TaintEffectNoSchedule}}
+
+	defaultThresholds := api.ResourceThresholds{v1.ResourceCPU: 70, v1.ResourceMemory: 70}
+
+	tests := []struct {
+		description          string
+		nodes                []*v1.Node
+		pods                 []*v1.Pod
+		args                 AntiAffinityRelaxationDetectorArgs
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pod next to a pod matching its preferred anti-affinity is moved",
+			nodes:       []*v1.Node{n1, n2},
+			pods:        buildPods(2, "n1", withPreferredAntiAffinity),
+			args: AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: defaultThresholds,
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods are not moved while the cluster has no headroom",
+			nodes:       []*v1.Node{n1, n2},
+			pods:        buildPods(2, "n1", withPreferredAntiAffinity),
+			args: AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: api.ResourceThresholds{v1.ResourceCPU: 2},
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods are not moved when no other node is schedulable",
+			nodes:       []*v1.Node{n1, tainted},
+			pods:        buildPods(2, "n1", withPreferredAntiAffinity),
+			args: AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: defaultThresholds,
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Only as many pods as nodes satisfying the preferences are moved",
+			nodes:       []*v1.Node{n1, n2},
+			pods:        buildPods(3, "n1", withPreferredAntiAffinity),
+			args: AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: defaultThresholds,
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods skewing soft topology spread constraints are ignored by default",
+			nodes:       []*v1.Node{n1, n2},
+			pods:        buildPods(3, "n1", withSoftSpreadConstraint),
+			args: AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: defaultThresholds,
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods skewing soft topology spread constraints are moved when enabled",
+			nodes:       []*v1.Node{n1, n2},
+			pods:        buildPods(3, "n1", withSoftSpreadConstraint),
+			args: AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds:                   defaultThresholds,
+				IncludeSoftTopologySpreadConstraints: true,
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods in excluded namespaces are not moved",
+			nodes:       []*v1.Node{n1, n2},
+			pods:        buildPods(2, "n1", withPreferredAntiAffinity),
+			args: AntiAffinityRelaxationDetectorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"default"}},
+				},
+				HeadroomThresholds: defaultThresholds,
+			},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
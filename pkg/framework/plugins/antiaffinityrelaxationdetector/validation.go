@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package antiaffinityrelaxationdetector
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	minResourcePercentage = 0
+	maxResourcePercentage = 100
+)
+
+// ValidateAntiAffinityRelaxationDetectorArgs validates AntiAffinityRelaxationDetector arguments
+func ValidateAntiAffinityRelaxationDetectorArgs(obj runtime.Object) error {
+	args := obj.(*AntiAffinityRelaxationDetectorArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if len(args.HeadroomThresholds) == 0 {
+		return fmt.Errorf("no headroom thresholds provided")
+	}
+	for name, value := range args.HeadroomThresholds {
+		if name == "" {
+			return fmt.Errorf("headroom thresholds must not contain empty resource names")
+		}
+		if value < minResourcePercentage || value > maxResourcePercentage {
+			return fmt.Errorf("%v headroom threshold not in [%v, %v] range", name, minResourcePercentage, maxResourcePercentage)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package antiaffinityrelaxationdetector
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateAntiAffinityRelaxationDetectorArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *AntiAffinityRelaxationDetectorArgs
+		expectError bool
+	}{
+		{
+			description: "valid args, no errors",
+			args: &AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: api.ResourceThresholds{v1.ResourceCPU: 70},
+			},
+			expectError: false,
+		},
+		{
+			description: "no headroom thresholds, expects error",
+			args:        &AntiAffinityRelaxationDetectorArgs{},
+			expectError: true,
+		},
+		{
+			description: "headroom threshold out of range, expects error",
+			args: &AntiAffinityRelaxationDetectorArgs{
+				HeadroomThresholds: api.ResourceThresholds{v1.ResourceCPU: 120},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid namespaces args, expects error",
+			args: &AntiAffinityRelaxationDetectorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+						Exclude: []string{"kube-system"},
+					},
+				},
+				HeadroomThresholds: api.ResourceThresholds{v1.ResourceCPU: 70},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateAntiAffinityRelaxationDetectorArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancebycustommetric
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "BalanceByCustomMetric"
+
+// BalanceByCustomMetric ranks the nodes by the result of a Prometheus query (e.g. network saturation,
+// disk IOPS) and evicts pods from the nodes ranked in the top percentile so they get scheduled
+// onto the nodes ranked in the bottom percentile.
+type BalanceByCustomMetric struct {
+	handle    frameworktypes.Handle
+	args      *BalanceByCustomMetricArgs
+	podFilter podutil.FilterFunc
+	client    *queryClient
+}
+
+// rankedNode is a node along with its query result
+type rankedNode struct {
+	node  *v1.Node
+	value float64
+}
+
+var _ frameworktypes.BalancePlugin = &BalanceByCustomMetric{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	customMetricArgs, ok := args.(*BalanceByCustomMetricArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type BalanceByCustomMetricArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if customMetricArgs.Namespaces != nil {
+		includedNamespaces = sets.New(customMetricArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(customMetricArgs.Namespaces.Exclude...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(customMetricArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &BalanceByCustomMetric{
+		handle:    handle,
+		args:      customMetricArgs,
+		podFilter: podFilter,
+		client:    newQueryClient(customMetricArgs.Prometheus),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (b *BalanceByCustomMetric) Name() string {
+	return PluginName
+}
+
+// Balance extension point implementation for the plugin
+func (b *BalanceByCustomMetric) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	values, err := b.nodeValues(ctx)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error querying prometheus: %v", err),
+		}
+	}
+
+	sourceNodes, destinationNodes := b.classifyNodes(logger, rankNodes(logger, nodes, values))
+	if len(sourceNodes) == 0 || len(destinationNodes) == 0 {
+		logger.V(1).Info("No node to balance pods from or to", "sourceNodes", len(sourceNodes), "destinationNodes", len(destinationNodes))
+		return nil
+	}
+
+	getPodsAssignedToNode := b.handle.GetPodsAssignedToNodeFunc()
+	for _, source := range sourceNodes {
+		logger.V(1).Info("Evicting pods from node ranked in the top percentile", "node", klog.KObj(source.node), "value", source.value)
+		pods, err := podutil.ListPodsOnANode(source.node.Name, getPodsAssignedToNode, b.podFilter)
+		if err != nil {
+			logger.Error(err, "Error listing pods on node", "node", klog.KObj(source.node))
+			continue
+		}
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+
+		evicted := uint(0)
+	loop:
+		for _, pod := range pods {
+			if evicted >= *b.args.MaxPodsToEvictPerNode {
+				break
+			}
+			if !nodeutil.PodFitsAnyNode(getPodsAssignedToNode, pod, destinationNodes) {
+				logger.V(3).Info("Pod does not fit any node in the bottom percentile", "pod", klog.KObj(pod))
+				continue
+			}
+			if !b.handle.Evictor().PreEvictionFilter(pod) {
+				continue
+			}
+			err := b.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				evicted++
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// nodeValues runs the query unless its result is still cached
+func (b *BalanceByCustomMetric) nodeValues(ctx context.Context) (map[string]float64, error) {
+	logger := klog.FromContext(ctx)
+	var ttl time.Duration
+	if b.args.QueryCacheTTL != nil {
+		ttl = b.args.QueryCacheTTL.Duration
+	}
+	key := b.args.Prometheus.URL + "\x00" + b.args.NodeLabel + "\x00" + b.args.Query
+	now := time.Now()
+	if values, ok := queryCache.get(key, ttl, now); ok {
+		logger.V(3).Info("Using the cached query result", "query", b.args.Query)
+		return values, nil
+	}
+	values, err := b.client.nodeValues(ctx, b.args.Query, b.args.NodeLabel)
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		queryCache.set(key, values, now)
+	}
+	return values, nil
+}
+
+// rankNodes sorts the nodes by their query result, highest first.
+// Nodes without a result are left out.
+func rankNodes(logger klog.Logger, nodes []*v1.Node, values map[string]float64) []rankedNode {
+	ranked := make([]rankedNode, 0, len(nodes))
+	for _, node := range nodes {
+		value, ok := values[node.Name]
+		if !ok || math.IsNaN(value) {
+			logger.V(2).Info("Node has no query result, not considered", "node", klog.KObj(node))
+			continue
+		}
+		ranked = append(ranked, rankedNode{node: node, value: value})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].value > ranked[j].value
+	})
+	return ranked
+}
+
+// classifyNodes returns the ranked nodes in the top percentile pods get evicted from
+// and the nodes in the bottom percentile the evicted pods have to fit on.
+func (b *BalanceByCustomMetric) classifyNodes(logger klog.Logger, ranked []rankedNode) ([]rankedNode, []*v1.Node) {
+	topCount := int(math.Ceil(float64(len(ranked)) * float64(b.args.TopPercentile) / 100))
+	bottomCount := int(math.Ceil(float64(len(ranked)) * float64(b.args.BottomPercentile) / 100))
+	if topCount+bottomCount > len(ranked) {
+		bottomCount = len(ranked) - topCount
+	}
+
+	var destinationNodes []*v1.Node
+	highestDestinationValue := math.Inf(-1)
+	for _, candidate := range ranked[len(ranked)-bottomCount:] {
+		if b.args.LowThreshold != nil && candidate.value >= *b.args.LowThreshold {
+			continue
+		}
+		if nodeutil.IsNodeUnschedulable(candidate.node) {
+			logger.V(2).Info("Node is unschedulable, thus not considered as a destination", "node", klog.KObj(candidate.node))
+			continue
+		}
+		destinationNodes = append(destinationNodes, candidate.node)
+		highestDestinationValue = math.Max(highestDestinationValue, candidate.value)
+	}
+
+	var sourceNodes []rankedNode
+	for _, candidate := range ranked[:topCount] {
+		if b.args.HighThreshold != nil && candidate.value <= *b.args.HighThreshold {
+			continue
+		}
+		// Moving pods between nodes with the same value does not balance anything
+		if candidate.value <= highestDestinationValue {
+			continue
+		}
+		sourceNodes = append(sourceNodes, candidate)
+	}
+	return sourceNodes, destinationNodes
+}
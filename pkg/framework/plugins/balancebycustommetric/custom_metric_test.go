@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancebycustommetric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+// newPrometheusServer serves the given values as the result of any instant query
+func newPrometheusServer(t *testing.T, values map[string]float64, requests *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/api/v1/query" || r.FormValue("query") == "" {
+			t.Errorf("Unexpected request %s %v", r.URL.Path, r.Form)
+		}
+		if values == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+			return
+		}
+		var result []map[string]interface{}
+		for node, value := range values {
+			result = append(result, map[string]interface{}{
+				"metric": map[string]string{"node": node},
+				"value":  []interface{}{1700000000, fmt.Sprint(value)},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"data":   map[string]interface{}{"resultType": "vector", "result": result},
+		})
+	}))
+}
+
+func TestBalanceByCustomMetric(t *testing.T) {
+	var nodes []*v1.Node
+	var objs []runtime.Object
+	values := map[string]float64{}
+	for i := 0; i < 10; i++ {
+		node := test.BuildTestNode(fmt.Sprintf("n%d", i), 2000, 3000, 10, nil)
+		nodes = append(nodes, node)
+		objs = append(objs, node)
+		values[node.Name] = float64(i)
+		for _, name := range []string{"a", "b"} {
+			objs = append(objs, test.BuildTestPod(node.Name+"-"+name, 100, 0, node.Name, test.SetRSOwnerRef))
+		}
+	}
+	sameValues := map[string]float64{}
+	for _, node := range nodes {
+		sameValues[node.Name] = 1
+	}
+	unschedulableNode := test.BuildTestNode("n0", 2000, 3000, 10, test.SetNodeUnschedulable)
+
+	tests := []struct {
+		description          string
+		args                 BalanceByCustomMetricArgs
+		values               map[string]float64
+		nodes                []*v1.Node
+		expectedEvictedCount uint
+		expectedError        bool
+	}{
+		{
+			description:          "Pods are evicted from the node in the top percentile",
+			args:                 BalanceByCustomMetricArgs{},
+			values:               values,
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods are evicted from all the nodes in the top percentile",
+			args: BalanceByCustomMetricArgs{
+				TopPercentile:         20,
+				MaxPodsToEvictPerNode: utilptr.To[uint](2),
+			},
+			values:               values,
+			expectedEvictedCount: 4,
+		},
+		{
+			description:          "Nodes in the top percentile have to exceed the high threshold",
+			args:                 BalanceByCustomMetricArgs{HighThreshold: utilptr.To(9.5)},
+			values:               values,
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Nodes in the bottom percentile have to stay under the low threshold",
+			args:                 BalanceByCustomMetricArgs{LowThreshold: utilptr.To(0.0)},
+			values:               values,
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Unschedulable nodes do not receive pods",
+			args:                 BalanceByCustomMetricArgs{},
+			values:               values,
+			nodes:                append([]*v1.Node{unschedulableNode}, nodes[1:]...),
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Nodes with the same value are not balanced",
+			args:                 BalanceByCustomMetricArgs{},
+			values:               sameValues,
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Query errors are reported",
+			args:                 BalanceByCustomMetricArgs{},
+			values:               nil,
+			expectedEvictedCount: 0,
+			expectedError:        true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var requests atomic.Int32
+			server := newPrometheusServer(t, tc.values, &requests)
+			defer server.Close()
+
+			fakeClient := fake.NewSimpleClientset(objs...)
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{NodeFit: utilptr.To(true)},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := tc.args
+			args.Prometheus.URL = server.URL
+			args.Query = "node_network_saturation"
+			SetDefaults_BalanceByCustomMetricArgs(&args)
+			plugin, err := New(&args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			balanceNodes := nodes
+			if tc.nodes != nil {
+				balanceNodes = tc.nodes
+			}
+			status := plugin.(frameworktypes.BalancePlugin).Balance(ctx, balanceNodes)
+			if hasError := status != nil && status.Err != nil; hasError != tc.expectedError {
+				t.Errorf("Expected error %v, got status %v", tc.expectedError, status)
+			}
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
+
+func TestBalanceByCustomMetricQueryCache(t *testing.T) {
+	var requests atomic.Int32
+	server := newPrometheusServer(t, map[string]float64{"n1": 1, "n2": 2}, &requests)
+	defer server.Close()
+
+	b := &BalanceByCustomMetric{
+		args: &BalanceByCustomMetricArgs{
+			Prometheus:    Prometheus{URL: server.URL},
+			Query:         "node_network_saturation",
+			NodeLabel:     "node",
+			QueryCacheTTL: &metav1.Duration{Duration: time.Hour},
+		},
+		client: newQueryClient(Prometheus{URL: server.URL}),
+	}
+	for i := 0; i < 3; i++ {
+		values, err := b.nodeValues(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(values) != 2 || values["n2"] != 2 {
+			t.Errorf("Unexpected query result: %v", values)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected the query result to be cached, got %v requests", requests.Load())
+	}
+
+	b.args.QueryCacheTTL = nil
+	b.args.Query = "node_disk_iops"
+	for i := 0; i < 2; i++ {
+		if _, err := b.nodeValues(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Expected the query to run on every call without a cache ttl, got %v requests", requests.Load())
+	}
+}
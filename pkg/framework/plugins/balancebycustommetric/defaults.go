@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancebycustommetric
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+const (
+	DefaultNodeLabel             = "node"
+	DefaultTopPercentile         = 10
+	DefaultBottomPercentile      = 10
+	DefaultMaxPodsToEvictPerNode = 1
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_BalanceByCustomMetricArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_BalanceByCustomMetricArgs(obj runtime.Object) {
+	args := obj.(*BalanceByCustomMetricArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.NodeLabel == "" {
+		args.NodeLabel = DefaultNodeLabel
+	}
+	if args.TopPercentile == 0 {
+		args.TopPercentile = DefaultTopPercentile
+	}
+	if args.BottomPercentile == 0 {
+		args.BottomPercentile = DefaultBottomPercentile
+	}
+	if args.MaxPodsToEvictPerNode == nil {
+		args.MaxPodsToEvictPerNode = utilptr.To[uint](DefaultMaxPodsToEvictPerNode)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancebycustommetric
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestSetDefaults_BalanceByCustomMetricArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "BalanceByCustomMetricArgs empty",
+			in:   &BalanceByCustomMetricArgs{},
+			want: &BalanceByCustomMetricArgs{
+				NodeLabel:             "node",
+				TopPercentile:         10,
+				BottomPercentile:      10,
+				MaxPodsToEvictPerNode: utilptr.To[uint](1),
+			},
+		},
+		{
+			name: "BalanceByCustomMetricArgs with value",
+			in: &BalanceByCustomMetricArgs{
+				Prometheus:            Prometheus{URL: "http://prometheus:9090"},
+				Query:                 "node_network_saturation",
+				NodeLabel:             "instance",
+				TopPercentile:         20,
+				BottomPercentile:      30,
+				HighThreshold:         utilptr.To(0.8),
+				MaxPodsToEvictPerNode: utilptr.To[uint](5),
+				QueryCacheTTL:         &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			want: &BalanceByCustomMetricArgs{
+				Prometheus:            Prometheus{URL: "http://prometheus:9090"},
+				Query:                 "node_network_saturation",
+				NodeLabel:             "instance",
+				TopPercentile:         20,
+				BottomPercentile:      30,
+				HighThreshold:         utilptr.To(0.8),
+				MaxPodsToEvictPerNode: utilptr.To[uint](5),
+				QueryCacheTTL:         &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_BalanceByCustomMetricArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
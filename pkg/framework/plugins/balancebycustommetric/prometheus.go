@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancebycustommetric
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+const queryTimeout = 30 * time.Second
+
+// queryResponse is the envelope of the responses of the Prometheus HTTP API
+type queryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Data      struct {
+		ResultType model.ValueType `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// queryClient runs instant queries against a Prometheus server
+type queryClient struct {
+	httpClient      *http.Client
+	url             string
+	bearerTokenFile string
+}
+
+func newQueryClient(config Prometheus) *queryClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &queryClient{
+		httpClient:      &http.Client{Transport: transport, Timeout: queryTimeout},
+		url:             config.URL,
+		bearerTokenFile: config.BearerTokenFile,
+	}
+}
+
+// nodeValues runs the query and returns the value of the samples by node name,
+// the node name being read from the given label of each sample.
+func (c *queryClient) nodeValues(ctx context.Context, query, nodeLabel string) (map[string]float64, error) {
+	endpoint, err := url.JoinPath(c.url, "api/v1/query")
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(url.Values{"query": {query}}.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if c.bearerTokenFile != "" {
+		// Read on every query so rotated tokens get picked up
+		token, err := os.ReadFile(c.bearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the bearer token: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response queryResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("unable to decode the response (status %d): %v", resp.StatusCode, err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("query failed (status %d): %s: %s", resp.StatusCode, response.ErrorType, response.Error)
+	}
+	if response.Data.ResultType != model.ValVector {
+		return nil, fmt.Errorf("query returned a %s, expected a vector", response.Data.ResultType)
+	}
+	var vector model.Vector
+	if err := json.Unmarshal(response.Data.Result, &vector); err != nil {
+		return nil, fmt.Errorf("unable to decode the query result: %v", err)
+	}
+
+	values := make(map[string]float64, len(vector))
+	for _, sample := range vector {
+		nodeName := string(sample.Metric[model.LabelName(nodeLabel)])
+		if nodeName == "" {
+			continue
+		}
+		if _, ok := values[nodeName]; ok {
+			return nil, fmt.Errorf("query returned more than one sample for node %q", nodeName)
+		}
+		values[nodeName] = float64(sample.Value)
+	}
+	return values, nil
+}
+
+// queryCache keeps the query results across descheduling cycles
+// since the plugin is built again on every cycle
+var queryCache = &resultCache{entries: map[string]cachedResult{}}
+
+type cachedResult struct {
+	values    map[string]float64
+	timestamp time.Time
+}
+
+type resultCache struct {
+	sync.Mutex
+	entries map[string]cachedResult
+}
+
+func (c *resultCache) get(key string, ttl time.Duration, now time.Time) (map[string]float64, bool) {
+	c.Lock()
+	defer c.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.Sub(entry.timestamp) >= ttl {
+		return nil, false
+	}
+	return entry.values, true
+}
+
+func (c *resultCache) set(key string, values map[string]float64, now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	c.entries[key] = cachedResult{values: values, timestamp: now}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancebycustommetric
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BalanceByCustomMetricArgs holds arguments used to configure BalanceByCustomMetric plugin.
+type BalanceByCustomMetricArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// Prometheus is the server the query is sent to
+	Prometheus Prometheus `json:"prometheus"`
+	// Query is a PromQL instant query returning one sample per node
+	Query string `json:"query"`
+	// NodeLabel is the label of the query samples holding the node name
+	NodeLabel string `json:"nodeLabel"`
+	// TopPercentile is the percentage of the nodes with the highest values pods are evicted from
+	TopPercentile api.Percentage `json:"topPercentile"`
+	// BottomPercentile is the percentage of the nodes with the lowest values the evicted pods have to fit on
+	BottomPercentile api.Percentage `json:"bottomPercentile"`
+	// HighThreshold is the value a node in the top percentile has to exceed for pods to be evicted from it
+	HighThreshold *float64 `json:"highThreshold,omitempty"`
+	// LowThreshold is the value a node in the bottom percentile has to stay under to receive pods
+	LowThreshold *float64 `json:"lowThreshold,omitempty"`
+	// MaxPodsToEvictPerNode limits the number of pods evicted from each node in a descheduling cycle
+	MaxPodsToEvictPerNode *uint `json:"maxPodsToEvictPerNode"`
+	// QueryCacheTTL is how long the query result is reused across descheduling cycles
+	QueryCacheTTL *metav1.Duration `json:"queryCacheTTL,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Prometheus configures the access to a Prometheus server.
+type Prometheus struct {
+	// URL of the Prometheus server, e.g. http://prometheus.monitoring.svc:9090
+	URL string `json:"url"`
+	// BearerTokenFile is a file holding the token sent to authenticate against the server
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// InsecureSkipVerify disables the verification of the server certificate
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
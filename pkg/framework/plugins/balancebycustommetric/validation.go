@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancebycustommetric
+
+import (
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateBalanceByCustomMetricArgs validates BalanceByCustomMetric arguments
+func ValidateBalanceByCustomMetricArgs(obj runtime.Object) error {
+	args := obj.(*BalanceByCustomMetricArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.Prometheus.URL == "" {
+		return fmt.Errorf("prometheus url can not be empty")
+	}
+	if u, err := url.Parse(args.Prometheus.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("prometheus url %q is not a valid http(s) url", args.Prometheus.URL)
+	}
+	if args.Query == "" {
+		return fmt.Errorf("query can not be empty")
+	}
+	if args.TopPercentile <= 0 || args.TopPercentile > 100 {
+		return fmt.Errorf("topPercentile should be in (0, 100], got %v", args.TopPercentile)
+	}
+	if args.BottomPercentile <= 0 || args.BottomPercentile > 100 {
+		return fmt.Errorf("bottomPercentile should be in (0, 100], got %v", args.BottomPercentile)
+	}
+	if args.TopPercentile+args.BottomPercentile > 100 {
+		return fmt.Errorf("topPercentile and bottomPercentile can not add up to more than 100")
+	}
+	if args.HighThreshold != nil && args.LowThreshold != nil && *args.LowThreshold > *args.HighThreshold {
+		return fmt.Errorf("lowThreshold can not be greater than highThreshold")
+	}
+	if args.QueryCacheTTL != nil && args.QueryCacheTTL.Duration < 0 {
+		return fmt.Errorf("queryCacheTTL can not be negative")
+	}
+
+	return nil
+}
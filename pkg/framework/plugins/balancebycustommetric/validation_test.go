@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package balancebycustommetric
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateBalanceByCustomMetricArgs(t *testing.T) {
+	validArgs := func(mutate func(args *BalanceByCustomMetricArgs)) *BalanceByCustomMetricArgs {
+		args := &BalanceByCustomMetricArgs{
+			Prometheus:       Prometheus{URL: "http://prometheus.monitoring.svc:9090"},
+			Query:            "node_network_saturation",
+			NodeLabel:        "node",
+			TopPercentile:    10,
+			BottomPercentile: 10,
+		}
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *BalanceByCustomMetricArgs
+		expectError bool
+	}{
+		{
+			description: "valid args, no errors",
+			args: validArgs(func(args *BalanceByCustomMetricArgs) {
+				args.HighThreshold = utilptr.To(0.8)
+				args.LowThreshold = utilptr.To(0.5)
+			}),
+			expectError: false,
+		},
+		{
+			description: "empty prometheus url, expects error",
+			args:        validArgs(func(args *BalanceByCustomMetricArgs) { args.Prometheus.URL = "" }),
+			expectError: true,
+		},
+		{
+			description: "invalid prometheus url, expects error",
+			args:        validArgs(func(args *BalanceByCustomMetricArgs) { args.Prometheus.URL = "prometheus:9090" }),
+			expectError: true,
+		},
+		{
+			description: "empty query, expects error",
+			args:        validArgs(func(args *BalanceByCustomMetricArgs) { args.Query = "" }),
+			expectError: true,
+		},
+		{
+			description: "top percentile out of range, expects error",
+			args:        validArgs(func(args *BalanceByCustomMetricArgs) { args.TopPercentile = 120 }),
+			expectError: true,
+		},
+		{
+			description: "percentiles adding up to more than 100, expects error",
+			args: validArgs(func(args *BalanceByCustomMetricArgs) {
+				args.TopPercentile = 60
+				args.BottomPercentile = 60
+			}),
+			expectError: true,
+		},
+		{
+			description: "low threshold greater than high threshold, expects error",
+			args: validArgs(func(args *BalanceByCustomMetricArgs) {
+				args.HighThreshold = utilptr.To(0.5)
+				args.LowThreshold = utilptr.To(0.8)
+			}),
+			expectError: true,
+		},
+		{
+			description: "negative query cache ttl, expects error",
+			args: validArgs(func(args *BalanceByCustomMetricArgs) {
+				args.QueryCacheTTL = &metav1.Duration{Duration: -1}
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid namespaces args, expects error",
+			args: validArgs(func(args *BalanceByCustomMetricArgs) {
+				args.Namespaces = &api.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				}
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid label selector args, expects errors",
+			args: validArgs(func(args *BalanceByCustomMetricArgs) {
+				args.LabelSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Operator: metav1.LabelSelectorOpIn,
+						},
+					},
+				}
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateBalanceByCustomMetricArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consolidatestatefulsetstoragelocality
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetDefaults_ConsolidateStatefulSetStorageLocalityArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "ConsolidateStatefulSetStorageLocalityArgs empty",
+			in:   &ConsolidateStatefulSetStorageLocalityArgs{},
+			want: &ConsolidateStatefulSetStorageLocalityArgs{
+				LocalityLabel: v1.LabelTopologyZone,
+			},
+		},
+		{
+			name: "ConsolidateStatefulSetStorageLocalityArgs with value",
+			in: &ConsolidateStatefulSetStorageLocalityArgs{
+				LocalityLabel:     "example.com/rack",
+				StorageClassNames: []string{"local-storage"},
+			},
+			want: &ConsolidateStatefulSetStorageLocalityArgs{
+				LocalityLabel:     "example.com/rack",
+				StorageClassNames: []string{"local-storage"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_ConsolidateStatefulSetStorageLocalityArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
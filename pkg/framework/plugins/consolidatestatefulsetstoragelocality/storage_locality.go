@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consolidatestatefulsetstoragelocality
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	v1helper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "ConsolidateStatefulSetStorageLocality"
+
+// ConsolidateStatefulSetStorageLocality evicts StatefulSet pods running in a locality domain
+// (as given by a node label) where none of the local persistent volumes available as
+// replacements for their claims live. After a node replacement, local storage operators
+// provision new local volumes on the new nodes and rebuild the data next to them, so
+// moving the pods closer to those volumes restores data locality.
+type ConsolidateStatefulSetStorageLocality struct {
+	handle         frameworktypes.Handle
+	args           *ConsolidateStatefulSetStorageLocalityArgs
+	podFilter      podutil.FilterFunc
+	storageClasses sets.Set[string]
+	nodeLister     listersv1.NodeLister
+	pvLister       listersv1.PersistentVolumeLister
+	pvcLister      listersv1.PersistentVolumeClaimLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &ConsolidateStatefulSetStorageLocality{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	storageLocalityArgs, ok := args.(*ConsolidateStatefulSetStorageLocalityArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type ConsolidateStatefulSetStorageLocalityArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if storageLocalityArgs.Namespaces != nil {
+		includedNamespaces = sets.New(storageLocalityArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(storageLocalityArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(storageLocalityArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &ConsolidateStatefulSetStorageLocality{
+		handle:         handle,
+		args:           storageLocalityArgs,
+		podFilter:      podFilter,
+		storageClasses: sets.New(storageLocalityArgs.StorageClassNames...),
+		nodeLister:     handle.SharedInformerFactory().Core().V1().Nodes().Lister(),
+		// The volumes and claims are read from the cluster, the cached client of the dry run mode holds none
+		pvLister:  handle.ClusterInformerFactory().Core().V1().PersistentVolumes().Lister(),
+		pvcLister: handle.ClusterInformerFactory().Core().V1().PersistentVolumeClaims().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *ConsolidateStatefulSetStorageLocality) Name() string {
+	return PluginName
+}
+
+// volumeLocalities holds the locality domains the local persistent volumes are pinned to
+type volumeLocalities struct {
+	// bound maps names of bound volumes to their locality domains
+	bound map[string]sets.Set[string]
+	// available maps storage classes to the locality domains of their available volumes
+	available map[string]sets.Set[string]
+}
+
+func (d *ConsolidateStatefulSetStorageLocality) handlesStorageClass(storageClass string) bool {
+	return storageClass != "" && (d.storageClasses.Len() == 0 || d.storageClasses.Has(storageClass))
+}
+
+// localitiesOf returns locality domains of the nodes a persistent volume is pinned to through its node affinity
+func (d *ConsolidateStatefulSetStorageLocality) localitiesOf(pv *v1.PersistentVolume, nodes []*v1.Node) sets.Set[string] {
+	localities := sets.New[string]()
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return localities
+	}
+	for _, node := range nodes {
+		locality, ok := node.Labels[d.args.LocalityLabel]
+		if !ok {
+			continue
+		}
+		if match, err := v1helper.MatchNodeSelectorTerms(node, pv.Spec.NodeAffinity.Required); err == nil && match {
+			localities.Insert(locality)
+		}
+	}
+	return localities
+}
+
+func (d *ConsolidateStatefulSetStorageLocality) listVolumeLocalities() (*volumeLocalities, error) {
+	nodes, err := d.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list nodes: %v", err)
+	}
+	pvs, err := d.pvLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list persistent volumes: %v", err)
+	}
+
+	localities := &volumeLocalities{
+		bound:     map[string]sets.Set[string]{},
+		available: map[string]sets.Set[string]{},
+	}
+	for _, pv := range pvs {
+		if !d.handlesStorageClass(pv.Spec.StorageClassName) {
+			continue
+		}
+		pvLocalities := d.localitiesOf(pv, nodes)
+		if pvLocalities.Len() == 0 {
+			continue
+		}
+		if pv.Spec.ClaimRef != nil {
+			localities.bound[pv.Name] = pvLocalities
+			continue
+		}
+		if pv.Status.Phase != v1.VolumeAvailable {
+			continue
+		}
+		if localities.available[pv.Spec.StorageClassName] == nil {
+			localities.available[pv.Spec.StorageClassName] = sets.New[string]()
+		}
+		localities.available[pv.Spec.StorageClassName].Insert(pvLocalities.UnsortedList()...)
+	}
+	return localities, nil
+}
+
+// isAwayFromStorage checks whether a StatefulSet pod has claims with replacement volumes
+// available only outside of its locality domain, while none of its claims is already
+// bound to a local volume of the domain.
+func (d *ConsolidateStatefulSetStorageLocality) isAwayFromStorage(ctx context.Context, pod *v1.Pod, locality string, localities *volumeLocalities) bool {
+	logger := klog.FromContext(ctx)
+	if !utils.IsStatefulSetPod(podutil.OwnerRef(pod)) {
+		return false
+	}
+
+	away := false
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc, err := d.pvcLister.PersistentVolumeClaims(pod.Namespace).Get(volume.PersistentVolumeClaim.ClaimName)
+		if err != nil {
+			logger.V(3).Info("Unable to get persistent volume claim", "pod", klog.KObj(pod), "claim", volume.PersistentVolumeClaim.ClaimName, "err", err)
+			continue
+		}
+		if pvc.Spec.StorageClassName == nil || !d.handlesStorageClass(*pvc.Spec.StorageClassName) {
+			continue
+		}
+		if boundLocalities, ok := localities.bound[pvc.Spec.VolumeName]; ok && boundLocalities.Has(locality) {
+			// The data already lives next to the pod
+			return false
+		}
+		candidates := localities.available[*pvc.Spec.StorageClassName]
+		if candidates.Len() == 0 {
+			continue
+		}
+		if candidates.Has(locality) {
+			return false
+		}
+		away = true
+	}
+	return away
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *ConsolidateStatefulSetStorageLocality) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	localities, err := d.listVolumeLocalities()
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing local persistent volumes: %v", err),
+		}
+	}
+	if len(localities.available) == 0 {
+		logger.V(1).Info("No local persistent volumes available as replacements, nothing to consolidate")
+		return nil
+	}
+
+	for _, node := range nodes {
+		locality, ok := node.Labels[d.args.LocalityLabel]
+		if !ok {
+			logger.V(3).Info("Skipping node without locality label", "node", klog.KObj(node), "label", d.args.LocalityLabel)
+			continue
+		}
+		logger.V(1).Info("Processing node", "node", klog.KObj(node), "locality", locality)
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			if !d.isAwayFromStorage(ctx, pod, locality, localities) {
+				continue
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
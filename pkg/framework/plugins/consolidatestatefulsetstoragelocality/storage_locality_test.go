@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consolidatestatefulsetstoragelocality
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildZonedNode(name, zone string) *v1.Node {
+	return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels[v1.LabelHostname] = name
+		node.Labels[v1.LabelTopologyZone] = zone
+	})
+}
+
+func buildLocalPV(name, storageClass, nodeName string, claimRef *v1.ObjectReference) *v1.PersistentVolume {
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1.PersistentVolumeSpec{
+			StorageClassName: storageClass,
+			ClaimRef:         claimRef,
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeAvailable},
+	}
+	if claimRef != nil {
+		pv.Status.Phase = v1.VolumeBound
+	}
+	if nodeName != "" {
+		pv.Spec.NodeAffinity = &v1.VolumeNodeAffinity{
+			Required: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{{
+					MatchExpressions: []v1.NodeSelectorRequirement{{
+						Key:      v1.LabelHostname,
+						Operator: v1.NodeSelectorOpIn,
+						Values:   []string{nodeName},
+					}},
+				}},
+			},
+		}
+	}
+	return pv
+}
+
+func buildPVC(name, storageClass, volumeName string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: utilptr.To(storageClass),
+			VolumeName:       volumeName,
+		},
+	}
+}
+
+func buildPodWithClaim(name, nodeName, claimName string, apply func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		pod.Namespace = "default"
+		test.SetSSOwnerRef(pod)
+		pod.Spec.Volumes = []v1.Volume{{
+			Name: "data",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			},
+		}}
+		if apply != nil {
+			apply(pod)
+		}
+	})
+}
+
+func TestConsolidateStatefulSetStorageLocality(t *testing.T) {
+	node1 := buildZonedNode("n1", "zone-a")
+	node2 := buildZonedNode("n2", "zone-b")
+	node3 := test.BuildTestNode("n3", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{node1, node2, node3}
+
+	// n1 got replaced, its data now only survives on network storage while
+	// the storage operator provisioned a new local volume on n2
+	pvReplacement := buildLocalPV("pv-replacement", "local-storage", node2.Name, nil)
+	pvNetwork := buildLocalPV("pv-network", "local-storage", "", &v1.ObjectReference{Name: "data-p1"})
+	pvLocal := buildLocalPV("pv-local", "local-storage", node1.Name, &v1.ObjectReference{Name: "data-p4"})
+
+	pvcs := []runtime.Object{
+		buildPVC("data-p1", "local-storage", pvNetwork.Name),
+		buildPVC("data-p2", "local-storage", ""),
+		buildPVC("data-p3", "local-storage", ""),
+		buildPVC("data-p4", "local-storage", pvLocal.Name),
+		buildPVC("data-p5", "standard", ""),
+		buildPVC("data-p6", "local-storage", ""),
+	}
+
+	// away from the replacement volume
+	p1 := buildPodWithClaim("p1", node1.Name, "data-p1", nil)
+	// next to the replacement volume
+	p2 := buildPodWithClaim("p2", node2.Name, "data-p2", nil)
+	// not a StatefulSet pod
+	p3 := buildPodWithClaim("p3", node1.Name, "data-p3", test.SetRSOwnerRef)
+	// already bound to a local volume in its own zone
+	p4 := buildPodWithClaim("p4", node1.Name, "data-p4", nil)
+	// storage class without any replacement volume
+	p5 := buildPodWithClaim("p5", node1.Name, "data-p5", nil)
+	// node without a locality label
+	p6 := buildPodWithClaim("p6", node3.Name, "data-p6", nil)
+
+	tests := []struct {
+		description     string
+		nodeLabels      map[string]string
+		pvs             []runtime.Object
+		args            ConsolidateStatefulSetStorageLocalityArgs
+		expectedEvicted uint
+	}{
+		{
+			description: "Evict StatefulSet pods away from their replacement volumes",
+			pvs:         []runtime.Object{pvReplacement, pvNetwork, pvLocal},
+			args: ConsolidateStatefulSetStorageLocalityArgs{
+				LocalityLabel: v1.LabelTopologyZone,
+			},
+			expectedEvicted: 1,
+		},
+		{
+			description: "No replacement volumes available, no eviction",
+			pvs:         []runtime.Object{pvNetwork, pvLocal},
+			args: ConsolidateStatefulSetStorageLocalityArgs{
+				LocalityLabel: v1.LabelTopologyZone,
+			},
+			expectedEvicted: 0,
+		},
+		{
+			description: "Storage classes not configured, no eviction",
+			pvs:         []runtime.Object{pvReplacement, pvNetwork, pvLocal},
+			args: ConsolidateStatefulSetStorageLocalityArgs{
+				LocalityLabel:     v1.LabelTopologyZone,
+				StorageClassNames: []string{"standard"},
+			},
+			expectedEvicted: 0,
+		},
+		{
+			description: "Replacement volumes in the same locality domain, no eviction",
+			nodeLabels:  map[string]string{"example.com/region": "region-1"},
+			pvs:         []runtime.Object{pvReplacement, pvNetwork, pvLocal},
+			args: ConsolidateStatefulSetStorageLocalityArgs{
+				LocalityLabel: "example.com/region",
+			},
+			expectedEvicted: 0,
+		},
+		{
+			description: "Pods in excluded namespaces are ignored",
+			pvs:         []runtime.Object{pvReplacement, pvNetwork, pvLocal},
+			args: ConsolidateStatefulSetStorageLocalityArgs{
+				LocalityLabel: v1.LabelTopologyZone,
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Exclude: []string{"default"},
+					},
+				},
+			},
+			expectedEvicted: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var testNodes []*v1.Node
+			var objs []runtime.Object
+			for _, node := range nodes {
+				node = node.DeepCopy()
+				for key, value := range tc.nodeLabels {
+					node.Labels[key] = value
+				}
+				testNodes = append(testNodes, node)
+				objs = append(objs, node)
+			}
+			for _, pod := range []*v1.Pod{p1, p2, p3, p4, p5, p6} {
+				objs = append(objs, pod)
+			}
+			objs = append(objs, pvcs...)
+			objs = append(objs, tc.pvs...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			// Start the PersistentVolume and PersistentVolumeClaim informers requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, testNodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvicted {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvicted, actualEvictedPodCount)
+			}
+		})
+	}
+}
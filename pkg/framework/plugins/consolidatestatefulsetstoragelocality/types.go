@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consolidatestatefulsetstoragelocality
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConsolidateStatefulSetStorageLocalityArgs holds arguments used to configure ConsolidateStatefulSetStorageLocality plugin.
+type ConsolidateStatefulSetStorageLocalityArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// LocalityLabel is the node label whose value identifies the locality domain
+	// (e.g. a zone or a rack) of both nodes and the local persistent volumes pinned to them
+	LocalityLabel string `json:"localityLabel"`
+	// StorageClassNames limits the plugin to claims of the listed storage classes.
+	// Claims of any storage class are considered when empty.
+	StorageClassNames []string `json:"storageClassNames"`
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consolidatestatefulsetstoragelocality
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateConsolidateStatefulSetStorageLocalityArgs validates ConsolidateStatefulSetStorageLocality arguments
+func ValidateConsolidateStatefulSetStorageLocalityArgs(obj runtime.Object) error {
+	args := obj.(*ConsolidateStatefulSetStorageLocalityArgs)
+	if args.LocalityLabel == "" {
+		return fmt.Errorf("LocalityLabel not set")
+	}
+	if errs := validation.IsQualifiedName(args.LocalityLabel); len(errs) > 0 {
+		return fmt.Errorf("invalid LocalityLabel %q: %s", args.LocalityLabel, strings.Join(errs, "; "))
+	}
+
+	for _, name := range args.StorageClassNames {
+		if name == "" {
+			return fmt.Errorf("StorageClassNames can not contain an empty name")
+		}
+	}
+
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
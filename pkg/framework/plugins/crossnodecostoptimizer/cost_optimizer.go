@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossnodecostoptimizer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	PluginName = "CrossNodeCostOptimizer"
+
+	// EstimatedHourlySavingsDetail is the detail of the cycle summary reporting the hourly savings
+	// the evictions of the last run are estimated to bring
+	EstimatedHourlySavingsDetail = "estimatedHourlySavings"
+)
+
+// CrossNodeCostOptimizer evicts pods from the expensive nodes when they fit a node where they would
+// cost sufficiently less, the cost of a pod being the share of the node price matching the share of
+// the node resources it requests. The prices of the nodes are given by a pluggable price provider.
+type CrossNodeCostOptimizer struct {
+	handle        frameworktypes.Handle
+	args          *CrossNodeCostOptimizerArgs
+	podFilter     podutil.FilterFunc
+	priceProvider PriceProvider
+
+	// estimatedSavings is the hourly cost saved by the evictions of the last run
+	estimatedSavings float64
+}
+
+var (
+	_ frameworktypes.BalancePlugin   = &CrossNodeCostOptimizer{}
+	_ frameworktypes.SummaryReporter = &CrossNodeCostOptimizer{}
+)
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	costArgs, ok := args.(*CrossNodeCostOptimizerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type CrossNodeCostOptimizerArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if costArgs.Namespaces != nil {
+		includedNamespaces = sets.New(costArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(costArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(costArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	priceProvider, err := newPriceProvider(costArgs.Pricing)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing the price provider: %v", err)
+	}
+
+	return &CrossNodeCostOptimizer{
+		handle:        handle,
+		args:          costArgs,
+		podFilter:     podFilter,
+		priceProvider: priceProvider,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *CrossNodeCostOptimizer) Name() string {
+	return PluginName
+}
+
+// SummaryDetails reports the hourly savings the evictions of the last run are estimated to bring
+func (d *CrossNodeCostOptimizer) SummaryDetails() map[string]string {
+	if d.estimatedSavings == 0 {
+		return nil
+	}
+	return map[string]string{EstimatedHourlySavingsDetail: fmt.Sprintf("%.4f", d.estimatedSavings)}
+}
+
+// podCost is the share of the hourly price of the node matching the largest share
+// of the cpu and memory of the node the pod requests
+func podCost(pod *v1.Pod, node *v1.Node, price float64) float64 {
+	share := 0.0
+	for _, resourceName := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		allocatable := node.Status.Allocatable[resourceName]
+		if allocatable.IsZero() {
+			continue
+		}
+		request := utils.GetResourceRequestQuantity(pod, resourceName)
+		if resourceShare := float64(request.MilliValue()) / float64(allocatable.MilliValue()); resourceShare > share {
+			share = resourceShare
+		}
+	}
+	return share * price
+}
+
+type pricedNode struct {
+	node  *v1.Node
+	price float64
+}
+
+// Balance extension point implementation for the plugin
+func (d *CrossNodeCostOptimizer) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	d.estimatedSavings = 0
+
+	var priced []pricedNode
+	for _, node := range nodes {
+		price, ok, err := d.priceProvider.NodePrice(ctx, node)
+		if err != nil {
+			logger.Error(err, "Unable to get the price of the node", "node", klog.KObj(node))
+			continue
+		}
+		if !ok {
+			logger.V(3).Info("Price of the node is unknown, skipping", "node", klog.KObj(node))
+			continue
+		}
+		priced = append(priced, pricedNode{node: node, price: price})
+	}
+	if len(priced) < 2 {
+		return nil
+	}
+	// The pods of the most expensive nodes are moved first
+	sort.SliceStable(priced, func(i, j int) bool {
+		return priced[i].price > priced[j].price
+	})
+
+	// The pods evicted toward a node are accounted for on it, so the next pods are not
+	// evicted expecting the capacity they are about to consume
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	reserved := map[string][]*v1.Pod{}
+	withReserved := func(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+		pods, err := getPodsAssignedToNode(nodeName, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range reserved[nodeName] {
+			if filter == nil || filter(pod) {
+				pods = append(pods, pod)
+			}
+		}
+		return pods, nil
+	}
+
+	minSavingsRatio := *d.args.MinSavingsPercentage / 100
+	for _, source := range priced {
+		pods, err := podutil.ListPodsOnANode(source.node.Name, getPodsAssignedToNode, d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+	loop:
+		for _, pod := range pods {
+			cost := podCost(pod, source.node, source.price)
+			// Pods requesting nothing cost nothing wherever they run
+			if cost == 0 {
+				continue
+			}
+			destination, destinationCost := cheapestDestination(withReserved, pod, source.node, priced, cost*(1-minSavingsRatio))
+			if destination == nil {
+				continue
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				reserved[destination.Name] = append(reserved[destination.Name], pod)
+				d.estimatedSavings += cost - destinationCost
+				logger.V(1).Info("Evicted pod toward a cheaper node", "pod", klog.KObj(pod), "node", klog.KObj(source.node),
+					"destination", klog.KObj(destination), "hourlyCost", cost, "destinationHourlyCost", destinationCost)
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// cheapestDestination returns the node, other than the current node of the pod, where the pod fits and
+// costs the least, provided it costs no more than the given maximum cost.
+func cheapestDestination(getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, source *v1.Node, priced []pricedNode, maxCost float64) (*v1.Node, float64) {
+	type candidate struct {
+		node *v1.Node
+		cost float64
+	}
+	var candidates []candidate
+	for _, p := range priced {
+		if p.node.Name == source.Name {
+			continue
+		}
+		if cost := podCost(pod, p.node, p.price); cost <= maxCost {
+			candidates = append(candidates, candidate{node: p.node, cost: cost})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].cost < candidates[j].cost
+	})
+	for _, c := range candidates {
+		if err := nodeutil.NodeFit(getPodsAssignedToNode, pod, c.node); err == nil {
+			return c.node, c.cost
+		}
+	}
+	return nil, 0
+}
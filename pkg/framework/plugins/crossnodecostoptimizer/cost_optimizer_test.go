@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossnodecostoptimizer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildPricedNode(name string, millicpu int64, instanceType string) *v1.Node {
+	return test.BuildTestNode(name, millicpu, 3000, 10, func(node *v1.Node) {
+		if instanceType != "" {
+			node.Labels[v1.LabelInstanceTypeStable] = instanceType
+		}
+	})
+}
+
+// newRecordingBuilder builds the plugin, keeping it for the checks of its summary details
+func newRecordingBuilder(plugin **CrossNodeCostOptimizer) func(runtime.Object, frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	return func(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+		pl, err := New(args, handle)
+		if err == nil {
+			*plugin = pl.(*CrossNodeCostOptimizer)
+		}
+		return pl, err
+	}
+}
+
+func TestCrossNodeCostOptimizer(t *testing.T) {
+	prices := map[string]float64{
+		"m5.xlarge": 1,
+		"t3.xlarge": 0.4,
+		"t3.small":  0.04,
+	}
+	expensiveNode := buildPricedNode("n1", 2000, "m5.xlarge")
+	cheapNode := buildPricedNode("n2", 2000, "t3.xlarge")
+	smallNode := buildPricedNode("n3", 250, "t3.small")
+	unknownNode := buildPricedNode("n4", 2000, "")
+
+	buildPod := func(name string, millicpu int64, nodeName string) *v1.Pod {
+		return test.BuildTestPod(name, millicpu, 0, nodeName, test.SetRSOwnerRef)
+	}
+	highPriorityPod := buildPod("p3", 100, "n1")
+	highPriorityPod.Spec.Priority = utilptr.To[int32](1000)
+
+	tests := []struct {
+		description          string
+		nodes                []*v1.Node
+		pods                 []*v1.Pod
+		minSavingsPercentage float64
+		expectedEvicted      []string
+		expectedSavings      string
+	}{
+		{
+			description:          "pods of the expensive node are evicted toward the cheaper node",
+			nodes:                []*v1.Node{expensiveNode, cheapNode},
+			pods:                 []*v1.Pod{buildPod("p1", 100, "n1"), buildPod("p2", 100, "n1"), buildPod("p3", 100, "n2")},
+			minSavingsPercentage: 20,
+			expectedEvicted:      []string{"p1", "p2"},
+			expectedSavings:      "0.0600",
+		},
+		{
+			description:          "pods are not evicted when the savings are below the minimum",
+			nodes:                []*v1.Node{expensiveNode, cheapNode},
+			pods:                 []*v1.Pod{buildPod("p1", 100, "n1"), buildPod("p2", 100, "n1")},
+			minSavingsPercentage: 70,
+			expectedEvicted:      []string{},
+		},
+		{
+			description:          "pods requesting nothing are not evicted",
+			nodes:                []*v1.Node{expensiveNode, cheapNode},
+			pods:                 []*v1.Pod{buildPod("p1", 0, "n1")},
+			minSavingsPercentage: 20,
+			expectedEvicted:      []string{},
+		},
+		{
+			description:          "pods are only evicted as long as they fit the cheaper node, lowest priority first",
+			nodes:                []*v1.Node{expensiveNode, smallNode},
+			pods:                 []*v1.Pod{buildPod("p1", 100, "n1"), buildPod("p2", 100, "n1"), highPriorityPod},
+			minSavingsPercentage: 20,
+			expectedEvicted:      []string{"p1", "p2"},
+			expectedSavings:      "0.0680",
+		},
+		{
+			description:          "nodes with an unknown price are not destinations",
+			nodes:                []*v1.Node{expensiveNode, unknownNode},
+			pods:                 []*v1.Pod{buildPod("p1", 100, "n1")},
+			minSavingsPercentage: 20,
+			expectedEvicted:      []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var plugin *CrossNodeCostOptimizer
+			scenario.New().
+				WithNodes(tc.nodes...).
+				WithPods(tc.pods...).
+				ExpectEvicted(tc.expectedEvicted...).
+				Run(t, newRecordingBuilder(&plugin), &CrossNodeCostOptimizerArgs{
+					Pricing: Pricing{
+						Name:   StaticPriceProvider,
+						Static: &StaticPricing{InstanceTypeLabel: v1.LabelInstanceTypeStable, Prices: prices},
+					},
+					MinSavingsPercentage: utilptr.To(tc.minSavingsPercentage),
+				})
+
+			if savings := plugin.SummaryDetails()[EstimatedHourlySavingsDetail]; savings != tc.expectedSavings {
+				t.Errorf("Expected estimated savings %q, got %q", tc.expectedSavings, savings)
+			}
+		})
+	}
+}
+
+func TestCrossNodeCostOptimizerWebhookPricing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request priceRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Unable to decode the request: %v", err)
+		}
+		var response priceResponse
+		switch request.Labels[v1.LabelInstanceTypeStable] {
+		case "m5.xlarge":
+			response.PricePerHour = utilptr.To(1.0)
+		case "t3.xlarge":
+			response.PricePerHour = utilptr.To(0.4)
+		case "broken":
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	args := &CrossNodeCostOptimizerArgs{
+		Pricing: Pricing{
+			Name:    WebhookPriceProvider,
+			Webhook: &Webhook{URL: server.URL, Timeout: &metav1.Duration{Duration: 5 * time.Second}},
+		},
+		MinSavingsPercentage: utilptr.To[float64](20),
+	}
+	scenario.New().
+		WithNodes(
+			buildPricedNode("n1", 2000, "m5.xlarge"),
+			buildPricedNode("n2", 2000, "t3.xlarge"),
+			buildPricedNode("n3", 2000, "unpriced"),
+			buildPricedNode("n4", 2000, "broken"),
+		).
+		WithPods(
+			test.BuildTestPod("p1", 100, 0, "n1", test.SetRSOwnerRef),
+			test.BuildTestPod("p3", 100, 0, "n3", test.SetRSOwnerRef),
+			test.BuildTestPod("p4", 100, 0, "n4", test.SetRSOwnerRef),
+		).
+		ExpectEvicted("p1").
+		Run(t, New, args)
+}
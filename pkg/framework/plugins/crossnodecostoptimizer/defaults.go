@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossnodecostoptimizer
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+const (
+	DefaultMinSavingsPercentage = 20
+	DefaultWebhookTimeout       = 10 * time.Second
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_CrossNodeCostOptimizerArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_CrossNodeCostOptimizerArgs(obj runtime.Object) {
+	args := obj.(*CrossNodeCostOptimizerArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.MinSavingsPercentage == nil {
+		args.MinSavingsPercentage = utilptr.To[float64](DefaultMinSavingsPercentage)
+	}
+	if args.Pricing.Name == "" {
+		args.Pricing.Name = StaticPriceProvider
+	}
+	if args.Pricing.Static != nil && args.Pricing.Static.InstanceTypeLabel == "" {
+		args.Pricing.Static.InstanceTypeLabel = v1.LabelInstanceTypeStable
+	}
+	if args.Pricing.Webhook != nil && args.Pricing.Webhook.Timeout == nil {
+		args.Pricing.Webhook.Timeout = &metav1.Duration{Duration: DefaultWebhookTimeout}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossnodecostoptimizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestSetDefaults_CrossNodeCostOptimizerArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "CrossNodeCostOptimizerArgs empty",
+			in:   &CrossNodeCostOptimizerArgs{},
+			want: &CrossNodeCostOptimizerArgs{
+				Pricing:              Pricing{Name: StaticPriceProvider},
+				MinSavingsPercentage: utilptr.To[float64](20),
+			},
+		},
+		{
+			name: "CrossNodeCostOptimizerArgs with static prices",
+			in: &CrossNodeCostOptimizerArgs{
+				Pricing: Pricing{Static: &StaticPricing{Prices: map[string]float64{"m5.large": 0.096}}},
+			},
+			want: &CrossNodeCostOptimizerArgs{
+				Pricing: Pricing{
+					Name:   StaticPriceProvider,
+					Static: &StaticPricing{InstanceTypeLabel: "node.kubernetes.io/instance-type", Prices: map[string]float64{"m5.large": 0.096}},
+				},
+				MinSavingsPercentage: utilptr.To[float64](20),
+			},
+		},
+		{
+			name: "CrossNodeCostOptimizerArgs with webhook",
+			in: &CrossNodeCostOptimizerArgs{
+				Pricing:              Pricing{Name: WebhookPriceProvider, Webhook: &Webhook{URL: "http://pricing:8080"}},
+				MinSavingsPercentage: utilptr.To[float64](40),
+			},
+			want: &CrossNodeCostOptimizerArgs{
+				Pricing: Pricing{
+					Name:    WebhookPriceProvider,
+					Webhook: &Webhook{URL: "http://pricing:8080", Timeout: &metav1.Duration{Duration: 10 * time.Second}},
+				},
+				MinSavingsPercentage: utilptr.To[float64](40),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_CrossNodeCostOptimizerArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossnodecostoptimizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	// StaticPriceProvider is the name of the price provider reading a table of prices by instance type
+	StaticPriceProvider = "static"
+	// WebhookPriceProvider is the name of the price provider asking an HTTP endpoint
+	WebhookPriceProvider = "webhook"
+)
+
+// PriceProvider tells the hourly price of the nodes. Implementations read a price table or talk
+// to a cloud pricing API (AWS, GCP, Azure...) or to anything able to answer for it, like the webhook.
+type PriceProvider interface {
+	// NodePrice returns the hourly price of the node, false when the price of the node is unknown
+	NodePrice(ctx context.Context, node *v1.Node) (float64, bool, error)
+}
+
+// PriceProviderBuilder builds a price provider from the plugin configuration
+type PriceProviderBuilder func(config Pricing) (PriceProvider, error)
+
+var (
+	priceProvidersLock sync.RWMutex
+	priceProviders     = map[string]PriceProviderBuilder{
+		StaticPriceProvider:  newStaticPriceProvider,
+		WebhookPriceProvider: newWebhookPriceProvider,
+	}
+)
+
+// RegisterPriceProvider makes a price provider available under the given name,
+// e.g. so builds of the descheduler vendoring a cloud provider SDK can query its pricing API directly.
+func RegisterPriceProvider(name string, builder PriceProviderBuilder) {
+	priceProvidersLock.Lock()
+	defer priceProvidersLock.Unlock()
+	priceProviders[name] = builder
+}
+
+func isRegisteredPriceProvider(name string) bool {
+	priceProvidersLock.RLock()
+	defer priceProvidersLock.RUnlock()
+	_, ok := priceProviders[name]
+	return ok
+}
+
+func newPriceProvider(config Pricing) (PriceProvider, error) {
+	priceProvidersLock.RLock()
+	builder, ok := priceProviders[config.Name]
+	priceProvidersLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown price provider %q", config.Name)
+	}
+	return builder(config)
+}
+
+// staticPriceProvider prices the nodes by their instance type
+type staticPriceProvider struct {
+	instanceTypeLabel string
+	prices            map[string]float64
+}
+
+func newStaticPriceProvider(config Pricing) (PriceProvider, error) {
+	if config.Static == nil {
+		return nil, fmt.Errorf("static prices are not configured")
+	}
+	return &staticPriceProvider{
+		instanceTypeLabel: config.Static.InstanceTypeLabel,
+		prices:            config.Static.Prices,
+	}, nil
+}
+
+func (p *staticPriceProvider) NodePrice(ctx context.Context, node *v1.Node) (float64, bool, error) {
+	instanceType, ok := node.Labels[p.instanceTypeLabel]
+	if !ok {
+		return 0, false, nil
+	}
+	price, ok := p.prices[instanceType]
+	return price, ok, nil
+}
+
+// priceRequest is the body of the requests sent to the webhook
+type priceRequest struct {
+	NodeName   string            `json:"nodeName"`
+	ProviderID string            `json:"providerID"`
+	Labels     map[string]string `json:"labels,omitempty"`
+}
+
+// priceResponse is the body of the responses expected from the webhook, a missing price meaning the price is unknown
+type priceResponse struct {
+	PricePerHour *float64 `json:"pricePerHour"`
+}
+
+// webhookPriceProvider posts the nodes to an HTTP endpoint which looks up their price,
+// the labels of the node carrying the instance type, region, zone or capacity type
+type webhookPriceProvider struct {
+	httpClient      *http.Client
+	url             string
+	bearerTokenFile string
+}
+
+func newWebhookPriceProvider(config Pricing) (PriceProvider, error) {
+	if config.Webhook == nil {
+		return nil, fmt.Errorf("webhook is not configured")
+	}
+	return &webhookPriceProvider{
+		httpClient:      utils.NewHTTPClient(config.Webhook.Timeout),
+		url:             config.Webhook.URL,
+		bearerTokenFile: config.Webhook.BearerTokenFile,
+	}, nil
+}
+
+func (p *webhookPriceProvider) NodePrice(ctx context.Context, node *v1.Node) (float64, bool, error) {
+	body, err := json.Marshal(priceRequest{
+		NodeName:   node.Name,
+		ProviderID: node.Spec.ProviderID,
+		Labels:     node.Labels,
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.bearerTokenFile != "" {
+		if err := utils.SetBearerToken(req, p.bearerTokenFile); err != nil {
+			return 0, false, err
+		}
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var response priceResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return 0, false, fmt.Errorf("unable to decode the response: %v", err)
+	}
+	if response.PricePerHour == nil {
+		return 0, false, nil
+	}
+	if *response.PricePerHour < 0 {
+		return 0, false, fmt.Errorf("webhook returned a negative price %v", *response.PricePerHour)
+	}
+	return *response.PricePerHour, true, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossnodecostoptimizer
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CrossNodeCostOptimizerArgs holds arguments used to configure CrossNodeCostOptimizer plugin.
+type CrossNodeCostOptimizerArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// Pricing tells how the hourly price of the nodes is looked up
+	Pricing Pricing `json:"pricing"`
+	// MinSavingsPercentage is how much cheaper a pod has to be on another node, in percent of its cost
+	// on its current node, to get evicted. Defaults to 20.
+	MinSavingsPercentage *float64 `json:"minSavingsPercentage,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Pricing configures how the hourly price of the nodes is looked up.
+type Pricing struct {
+	// Name of the price provider, "static", "webhook" or a provider registered with RegisterPriceProvider.
+	// Defaults to "static".
+	Name string `json:"name"`
+	// Static configures the "static" price provider
+	Static *StaticPricing `json:"static,omitempty"`
+	// Webhook configures the "webhook" price provider
+	Webhook *Webhook `json:"webhook,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// StaticPricing prices the nodes from a table of hourly prices by instance type.
+type StaticPricing struct {
+	// InstanceTypeLabel is the label of the nodes holding their instance type.
+	// Defaults to node.kubernetes.io/instance-type.
+	InstanceTypeLabel string `json:"instanceTypeLabel,omitempty"`
+	// Prices are the hourly prices of the nodes by instance type
+	Prices map[string]float64 `json:"prices"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Webhook configures an HTTP endpoint telling the hourly price of a node, e.g. backed by a cloud pricing API.
+type Webhook struct {
+	// URL the price lookups are posted to, e.g. http://pricing.kube-system.svc:8080/prices
+	URL string `json:"url"`
+	// BearerTokenFile is a file holding the token sent to authenticate against the endpoint
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// Timeout of each lookup
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
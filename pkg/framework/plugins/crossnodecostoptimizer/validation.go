@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossnodecostoptimizer
+
+import (
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateCrossNodeCostOptimizerArgs validates CrossNodeCostOptimizer arguments
+func ValidateCrossNodeCostOptimizerArgs(obj runtime.Object) error {
+	args := obj.(*CrossNodeCostOptimizerArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.MinSavingsPercentage != nil && (*args.MinSavingsPercentage <= 0 || *args.MinSavingsPercentage >= 100) {
+		return fmt.Errorf("minSavingsPercentage must be greater than 0 and lower than 100")
+	}
+
+	// The arguments are validated before being defaulted
+	name := args.Pricing.Name
+	if name == "" {
+		name = StaticPriceProvider
+	}
+	if !isRegisteredPriceProvider(name) {
+		return fmt.Errorf("unknown price provider %q", name)
+	}
+	switch name {
+	case StaticPriceProvider:
+		static := args.Pricing.Static
+		if static == nil || len(static.Prices) == 0 {
+			return fmt.Errorf("static prices can not be empty")
+		}
+		for instanceType, price := range static.Prices {
+			if price < 0 {
+				return fmt.Errorf("price of instance type %q can not be negative", instanceType)
+			}
+		}
+	case WebhookPriceProvider:
+		webhook := args.Pricing.Webhook
+		if webhook == nil || webhook.URL == "" {
+			return fmt.Errorf("webhook url can not be empty")
+		}
+		if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook url %q is not a valid http(s) url", webhook.URL)
+		}
+		if webhook.Timeout != nil && webhook.Timeout.Duration <= 0 {
+			return fmt.Errorf("webhook timeout has to be positive")
+		}
+	}
+
+	return nil
+}
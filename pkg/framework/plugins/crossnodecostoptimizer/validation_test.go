@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossnodecostoptimizer
+
+import (
+	"testing"
+
+	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateCrossNodeCostOptimizerArgs(t *testing.T) {
+	static := Pricing{Name: StaticPriceProvider, Static: &StaticPricing{Prices: map[string]float64{"m5.large": 0.096}}}
+	testCases := []struct {
+		description string
+		args        *CrossNodeCostOptimizerArgs
+		expectError bool
+	}{
+		{
+			description: "valid static pricing, no errors",
+			args:        &CrossNodeCostOptimizerArgs{Pricing: static, MinSavingsPercentage: utilptr.To[float64](20)},
+			expectError: false,
+		},
+		{
+			description: "static pricing by default, no errors",
+			args: &CrossNodeCostOptimizerArgs{
+				Pricing: Pricing{Static: &StaticPricing{Prices: map[string]float64{"m5.large": 0.096}}},
+			},
+			expectError: false,
+		},
+		{
+			description: "valid webhook, no errors",
+			args: &CrossNodeCostOptimizerArgs{
+				Pricing: Pricing{Name: WebhookPriceProvider, Webhook: &Webhook{URL: "https://pricing.kube-system.svc/prices"}},
+			},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &CrossNodeCostOptimizerArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+				Pricing: static,
+			},
+			expectError: true,
+		},
+		{
+			description: "min savings percentage out of range, expects error",
+			args:        &CrossNodeCostOptimizerArgs{Pricing: static, MinSavingsPercentage: utilptr.To[float64](100)},
+			expectError: true,
+		},
+		{
+			description: "unknown price provider, expects error",
+			args:        &CrossNodeCostOptimizerArgs{Pricing: Pricing{Name: "unknown"}},
+			expectError: true,
+		},
+		{
+			description: "no static prices, expects error",
+			args:        &CrossNodeCostOptimizerArgs{Pricing: Pricing{Name: StaticPriceProvider}},
+			expectError: true,
+		},
+		{
+			description: "negative static price, expects error",
+			args: &CrossNodeCostOptimizerArgs{
+				Pricing: Pricing{Name: StaticPriceProvider, Static: &StaticPricing{Prices: map[string]float64{"m5.large": -1}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid webhook url, expects error",
+			args: &CrossNodeCostOptimizerArgs{
+				Pricing: Pricing{Name: WebhookPriceProvider, Webhook: &Webhook{URL: "pricing:8080"}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateCrossNodeCostOptimizerArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
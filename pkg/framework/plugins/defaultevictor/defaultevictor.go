@@ -18,17 +18,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
+	"strings"
+	"sync"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	policylisters "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/metrics"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions/filters"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/logging"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
@@ -36,11 +48,25 @@ import (
 const (
 	PluginName            = "DefaultEvictor"
 	evictPodAnnotationKey = "descheduler.alpha.kubernetes.io/evict"
+	// evictSingletonAnnotationKey lifts the protection of the singleton workloads for the annotated pod
+	evictSingletonAnnotationKey = "descheduler.alpha.kubernetes.io/evict-singleton"
 )
 
 var _ frameworktypes.EvictorPlugin = &DefaultEvictor{}
 
-type constraint func(pod *v1.Pod) error
+var _ frameworktypes.PodFieldsRequirer = &DefaultEvictorArgs{}
+
+// RequiredPodFields keeps the evict annotations in the pod cache
+func (d *DefaultEvictorArgs) RequiredPodFields() podutil.PodFields {
+	return podutil.PodFields{Annotations: []string{evictPodAnnotationKey, evictSingletonAnnotationKey}}
+}
+
+var _ frameworktypes.AllPodsRequirer = &DefaultEvictorArgs{}
+
+// RequiresAllPods tells the node fit check counts the requests of all the pods of the nodes
+func (d *DefaultEvictorArgs) RequiresAllPods() bool {
+	return utilptr.Deref(d.NodeFit, false)
+}
 
 // DefaultEvictor is the first EvictorPlugin, which defines the default extension points of the
 // pre-baked evictor that is shipped.
@@ -49,13 +75,16 @@ type constraint func(pod *v1.Pod) error
 // like filtering, sorting, and other ones that might be relevant in the future
 type DefaultEvictor struct {
 	args        *DefaultEvictorArgs
-	constraints []constraint
+	constraints []filters.Constraint
+	nodeFit     filters.Constraint
 	handle      frameworktypes.Handle
+	// logger honors the verbosity override of the plugin as the filters get no context
+	logger klog.Logger
 }
 
 // IsPodEvictableBasedOnPriority checks if the given pod is evictable based on priority resolved from pod Spec.
 func IsPodEvictableBasedOnPriority(pod *v1.Pod, priority int32) bool {
-	return pod.Spec.Priority == nil || *pod.Spec.Priority < priority
+	return filters.IsPodEvictableBasedOnPriority(pod, priority)
 }
 
 // HaveEvictAnnotation checks if the pod have evict annotation
@@ -75,86 +104,42 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 	ev := &DefaultEvictor{
 		handle: handle,
 		args:   defaultEvictorArgs,
+		logger: logging.DefaultLevels.PluginLogger(klog.Background(), PluginName),
 	}
 
+	ev.constraints = append(ev.constraints, filters.MirrorPod(), filters.StaticPod(), filters.TerminatingPod())
 	if defaultEvictorArgs.EvictFailedBarePods {
-		klog.V(1).InfoS("Warning: EvictFailedBarePods is set to True. This could cause eviction of pods without ownerReferences.")
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			ownerRefList := podutil.OwnerRef(pod)
-			// Enable evictFailedBarePods to evict bare pods in failed phase
-			if len(ownerRefList) == 0 && pod.Status.Phase != v1.PodFailed {
-				return fmt.Errorf("pod does not have any ownerRefs and is not in failed phase")
-			}
-			return nil
-		})
-	} else {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			ownerRefList := podutil.OwnerRef(pod)
-			if len(ownerRefList) == 0 {
-				return fmt.Errorf("pod does not have any ownerRefs")
-			}
-			return nil
-		})
+		ev.logger.V(1).Info("Warning: EvictFailedBarePods is set to True. This could cause eviction of pods without ownerReferences.")
 	}
+	ev.constraints = append(ev.constraints, filters.OwnerRefs(defaultEvictorArgs.EvictFailedBarePods))
 	if !defaultEvictorArgs.EvictSystemCriticalPods {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			if utils.IsCriticalPriorityPod(pod) {
-				return fmt.Errorf("pod has system critical priority")
-			}
-			return nil
-		})
+		ev.constraints = append(ev.constraints, filters.SystemCriticalPod())
 
 		if defaultEvictorArgs.PriorityThreshold != nil && (defaultEvictorArgs.PriorityThreshold.Value != nil || len(defaultEvictorArgs.PriorityThreshold.Name) > 0) {
 			thresholdPriority, err := utils.GetPriorityValueFromPriorityThreshold(context.TODO(), handle.ClientSet(), defaultEvictorArgs.PriorityThreshold)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get priority threshold: %v", err)
 			}
-			ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-				if IsPodEvictableBasedOnPriority(pod, thresholdPriority) {
-					return nil
-				}
-				return fmt.Errorf("pod has higher priority than specified priority class threshold")
-			})
+			ev.constraints = append(ev.constraints, filters.PriorityThreshold(thresholdPriority))
 		}
 	} else {
-		klog.V(1).InfoS("Warning: EvictSystemCriticalPods is set to True. This could cause eviction of Kubernetes system pods.")
+		ev.logger.V(1).Info("Warning: EvictSystemCriticalPods is set to True. This could cause eviction of Kubernetes system pods.")
 	}
 	if !defaultEvictorArgs.EvictLocalStoragePods {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			if utils.IsPodWithLocalStorage(pod) {
-				return fmt.Errorf("pod has local storage and descheduler is not configured with evictLocalStoragePods")
-			}
-			return nil
-		})
+		ev.constraints = append(ev.constraints, filters.LocalStoragePod())
 	}
 	if !defaultEvictorArgs.EvictDaemonSetPods {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			ownerRefList := podutil.OwnerRef(pod)
-			if utils.IsDaemonsetPod(ownerRefList) {
-				return fmt.Errorf("pod is related to daemonset and descheduler is not configured with evictDaemonSetPods")
-			}
-			return nil
-		})
+		ev.constraints = append(ev.constraints, filters.DaemonSetPod())
 	}
 	if defaultEvictorArgs.IgnorePvcPods {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			if utils.IsPodWithPVC(pod) {
-				return fmt.Errorf("pod has a PVC and descheduler is configured to ignore PVC pods")
-			}
-			return nil
-		})
+		ev.constraints = append(ev.constraints, filters.PVCPod())
 	}
 	selector, err := metav1.LabelSelectorAsSelector(defaultEvictorArgs.LabelSelector)
 	if err != nil {
 		return nil, fmt.Errorf("could not get selector from label selector")
 	}
 	if defaultEvictorArgs.LabelSelector != nil && !selector.Empty() {
-		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			if !selector.Matches(labels.Set(pod.Labels)) {
-				return fmt.Errorf("pod labels do not match the labelSelector filter in the policy parameter")
-			}
-			return nil
-		})
+		ev.constraints = append(ev.constraints, filters.LabelSelector(selector))
 	}
 
 	if defaultEvictorArgs.MinReplicas > 1 {
@@ -169,7 +154,7 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 			}
 
 			if len(pod.OwnerReferences) > 1 {
-				klog.V(5).InfoS("pod has multiple owner references which is not supported for minReplicas check", "size", len(pod.OwnerReferences), "pod", klog.KObj(pod))
+				ev.logger.V(5).Info("pod has multiple owner references which is not supported for minReplicas check", "size", len(pod.OwnerReferences), "pod", klog.KObj(pod))
 				return nil
 			}
 
@@ -188,64 +173,242 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 	}
 
 	if defaultEvictorArgs.MinPodAge != nil {
+		ev.constraints = append(ev.constraints, filters.MinPodAge(defaultEvictorArgs.MinPodAge.Duration))
+	}
+
+	if len(defaultEvictorArgs.IgnoreOwnerKinds) > 0 || len(defaultEvictorArgs.OnlyOwnerKinds) > 0 {
+		ignoredKinds, err := parseOwnerKinds(defaultEvictorArgs.IgnoreOwnerKinds)
+		if err != nil {
+			return nil, err
+		}
+		onlyKinds, err := parseOwnerKinds(defaultEvictorArgs.OnlyOwnerKinds)
+		if err != nil {
+			return nil, err
+		}
+		resolver := podutil.NewOwnerResolver(handle.ClientSet())
 		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			if pod.Status.StartTime == nil || time.Since(pod.Status.StartTime.Time) < defaultEvictorArgs.MinPodAge.Duration {
-				return fmt.Errorf("pod age is not older than MinPodAge: %s seconds", defaultEvictorArgs.MinPodAge.String())
+			owners, err := resolver.Owners(context.TODO(), pod)
+			if err != nil {
+				return fmt.Errorf("unable to resolve the owners of the pod: %v", err)
+			}
+			ownedByOnlyKinds := false
+			for _, owner := range owners {
+				if ignoredKinds.matches(owner) {
+					return fmt.Errorf("pod is owned by %s %s/%s and descheduler is configured to ignore pods owned by %s", owner.Kind, owner.Namespace, owner.Name, owner.Kind)
+				}
+				if onlyKinds.matches(owner) {
+					ownedByOnlyKinds = true
+				}
+			}
+			if len(onlyKinds) > 0 && !ownedByOnlyKinds {
+				return fmt.Errorf("pod is not owned by any of the kinds in the onlyOwnerKinds filter in the policy parameter")
 			}
 			return nil
 		})
 	}
+
+	if defaultEvictorArgs.ProtectSingletonWorkloads {
+		ev.constraints = append(ev.constraints, newSingletonWorkloads(handle.ClusterInformerFactory()).constraint)
+	}
+
+	if utilptr.Deref(defaultEvictorArgs.NodeFit, false) {
+		var pendingPods filters.PendingPodsFunc
+		if defaultEvictorArgs.NodeFitPendingPods {
+			pendingPods = filters.PendingPodsFromLister(handle.SharedInformerFactory().Core().V1().Pods().Lister())
+		}
+		ev.nodeFit = filters.NodeFit(func() ([]*v1.Node, error) {
+			return nodeutil.ReadyNodes(context.TODO(), handle.ClientSet(), handle.SharedInformerFactory().Core().V1().Nodes().Lister(), defaultEvictorArgs.NodeSelector)
+		}, handle.GetPodsAssignedToNodeFunc(), pendingPods)
+	}
 	return ev, nil
 }
 
-// Name retrieves the plugin name
-func (d *DefaultEvictor) Name() string {
-	return PluginName
+// singletonWorkloads rejects the pods whose workload has exactly one desired replica and no
+// PodDisruptionBudget covering the pod, unless the pod carries the evict-singleton annotation.
+// Pods without controller, or controlled by a kind without replicas, are not concerned.
+// The workloads and the budgets are read from the cluster, in dry run mode as well. The evictor
+// is built for every descheduling cycle, so the replicas are cached and the protections counted
+// for the cycle.
+type singletonWorkloads struct {
+	deployments            appslisters.DeploymentLister
+	replicaSets            appslisters.ReplicaSetLister
+	statefulSets           appslisters.StatefulSetLister
+	replicationControllers listersv1.ReplicationControllerLister
+	pdbLister              policylisters.PodDisruptionBudgetLister
+
+	mu sync.Mutex
+	// replicas holds the desired replicas of the owners looked up so far, nil for the owners which are gone
+	replicas map[types.UID]*int32
+	// counted holds the pods already counted in the protections metric
+	counted sets.Set[types.UID]
 }
 
-func (d *DefaultEvictor) PreEvictionFilter(pod *v1.Pod) bool {
-	if d.args.NodeFit {
-		nodes, err := nodeutil.ReadyNodes(context.TODO(), d.handle.ClientSet(), d.handle.SharedInformerFactory().Core().V1().Nodes().Lister(), d.args.NodeSelector)
-		if err != nil {
-			klog.ErrorS(err, "unable to list ready nodes", "pod", klog.KObj(pod))
-			return false
-		}
-		if !nodeutil.PodFitsAnyOtherNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes) {
-			klog.InfoS("pod does not fit on any other node because of nodeSelector(s), Taint(s), or nodes marked as unschedulable", "pod", klog.KObj(pod))
-			return false
-		}
-		return true
+func newSingletonWorkloads(informerFactory informers.SharedInformerFactory) *singletonWorkloads {
+	return &singletonWorkloads{
+		deployments:            informerFactory.Apps().V1().Deployments().Lister(),
+		replicaSets:            informerFactory.Apps().V1().ReplicaSets().Lister(),
+		statefulSets:           informerFactory.Apps().V1().StatefulSets().Lister(),
+		replicationControllers: informerFactory.Core().V1().ReplicationControllers().Lister(),
+		pdbLister:              informerFactory.Policy().V1().PodDisruptionBudgets().Lister(),
+		replicas:               map[types.UID]*int32{},
+		counted:                sets.New[types.UID](),
 	}
-	return true
 }
 
-func (d *DefaultEvictor) Filter(pod *v1.Pod) bool {
-	checkErrs := []error{}
+func (s *singletonWorkloads) constraint(pod *v1.Pod) error {
+	owner := s.owner(pod)
+	if owner == nil {
+		return nil
+	}
+	switch owner.Kind {
+	case "Deployment", "ReplicaSet", "StatefulSet", "ReplicationController":
+	default:
+		return nil
+	}
+	replicas := s.desiredReplicas(pod.Namespace, owner)
+	if replicas == nil || *replicas != 1 {
+		return nil
+	}
+	pdbs, err := s.pdbLister.PodDisruptionBudgets(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("unable to list the pod disruption budgets: %v", err)
+	}
+	if len(utils.PodDisruptionBudgetsSelecting(pod, pdbs)) > 0 {
+		return nil
+	}
+	if _, found := pod.Annotations[evictSingletonAnnotationKey]; found {
+		s.count(pod, "overridden")
+		return nil
+	}
+	s.count(pod, "protected")
+	return fmt.Errorf("pod is the only desired replica of %s %s/%s and no PodDisruptionBudget covers it", owner.Kind, pod.Namespace, owner.Name)
+}
 
-	if HaveEvictAnnotation(pod) {
-		return true
+// owner returns the workload owning the pod: the Deployment controlling its ReplicaSet if any,
+// its controller otherwise, nil for the pods without controller.
+func (s *singletonWorkloads) owner(pod *v1.Pod) *metav1.OwnerReference {
+	controllerRef := metav1.GetControllerOf(pod)
+	if controllerRef == nil || controllerRef.Kind != "ReplicaSet" {
+		return controllerRef
+	}
+	replicaSet, err := s.replicaSets.ReplicaSets(pod.Namespace).Get(controllerRef.Name)
+	if err != nil || replicaSet.UID != controllerRef.UID {
+		return controllerRef
+	}
+	if rsControllerRef := metav1.GetControllerOf(replicaSet); rsControllerRef != nil && rsControllerRef.Kind == "Deployment" {
+		return rsControllerRef
 	}
+	return controllerRef
+}
 
-	if utils.IsMirrorPod(pod) {
-		checkErrs = append(checkErrs, fmt.Errorf("pod is a mirror pod"))
+// desiredReplicas returns the number of replicas requested by the spec of the owner, nil when the owner is gone
+func (s *singletonWorkloads) desiredReplicas(namespace string, owner *metav1.OwnerReference) *int32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if replicas, ok := s.replicas[owner.UID]; ok {
+		return replicas
 	}
 
-	if utils.IsStaticPod(pod) {
-		checkErrs = append(checkErrs, fmt.Errorf("pod is a static pod"))
+	var obj metav1.Object
+	var replicas *int32
+	var err error
+	switch owner.Kind {
+	case "Deployment":
+		var deployment *appsv1.Deployment
+		if deployment, err = s.deployments.Deployments(namespace).Get(owner.Name); err == nil {
+			obj, replicas = deployment, deployment.Spec.Replicas
+		}
+	case "ReplicaSet":
+		var replicaSet *appsv1.ReplicaSet
+		if replicaSet, err = s.replicaSets.ReplicaSets(namespace).Get(owner.Name); err == nil {
+			obj, replicas = replicaSet, replicaSet.Spec.Replicas
+		}
+	case "StatefulSet":
+		var statefulSet *appsv1.StatefulSet
+		if statefulSet, err = s.statefulSets.StatefulSets(namespace).Get(owner.Name); err == nil {
+			obj, replicas = statefulSet, statefulSet.Spec.Replicas
+		}
+	case "ReplicationController":
+		var replicationController *v1.ReplicationController
+		if replicationController, err = s.replicationControllers.ReplicationControllers(namespace).Get(owner.Name); err == nil {
+			obj, replicas = replicationController, replicationController.Spec.Replicas
+		}
+	}
+	// An owner which is gone, or got recreated with the same name, has no replicas to protect
+	if err != nil || obj == nil || obj.GetUID() != owner.UID {
+		s.replicas[owner.UID] = nil
+		return nil
 	}
+	// The API server defaults the number of replicas to 1
+	if replicas == nil {
+		replicas = utilptr.To[int32](1)
+	}
+	s.replicas[owner.UID] = replicas
+	return replicas
+}
 
-	if utils.IsPodTerminating(pod) {
-		checkErrs = append(checkErrs, fmt.Errorf("pod is terminating"))
+// count counts the protection of the pod in the metric, once per pod, whatever the number of
+// plugins and filters checking the pod
+func (s *singletonWorkloads) count(pod *v1.Pod, result string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counted.Has(pod.UID) {
+		return
 	}
+	s.counted.Insert(pod.UID)
+	metrics.SingletonWorkloadProtections.With(map[string]string{"result": result, "namespace": pod.Namespace}).Inc()
+}
+
+// ownerKinds matches the kinds of owners given as "Kind" or "Kind.group" strings,
+// e.g. "Node", "ReplicaSet.apps" or "VirtualMachineInstance.kubevirt.io". A kind
+// given without group matches the kind in any group.
+type ownerKinds []schema.GroupKind
+
+func parseOwnerKinds(kinds []string) (ownerKinds, error) {
+	var parsed ownerKinds
+	for _, kind := range kinds {
+		gk := schema.ParseGroupKind(kind)
+		if gk.Kind == "" || strings.ContainsAny(kind, " /") {
+			return nil, fmt.Errorf("invalid owner kind %q, expected Kind or Kind.group", kind)
+		}
+		parsed = append(parsed, gk)
+	}
+	return parsed, nil
+}
 
-	for _, c := range d.constraints {
-		if err := c(pod); err != nil {
-			checkErrs = append(checkErrs, err)
+func (k ownerKinds) matches(owner podutil.OwnerKey) bool {
+	gk := schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind).GroupKind()
+	for _, kind := range k {
+		if kind.Kind == gk.Kind && (kind.Group == "" || kind.Group == gk.Group) {
+			return true
 		}
 	}
+	return false
+}
+
+// Name retrieves the plugin name
+func (d *DefaultEvictor) Name() string {
+	return PluginName
+}
+
+func (d *DefaultEvictor) PreEvictionFilter(pod *v1.Pod) bool {
+	if d.nodeFit == nil {
+		return true
+	}
+	if err := d.nodeFit(pod); err != nil {
+		d.logger.Info("Pod fails the node fit check", "pod", klog.KObj(pod), "reason", err)
+		return false
+	}
+	return true
+}
+
+func (d *DefaultEvictor) Filter(pod *v1.Pod) bool {
+	if HaveEvictAnnotation(pod) {
+		return true
+	}
 
-	if len(checkErrs) > 0 {
-		klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod), "checks", utilerrors.NewAggregate(checkErrs).Error())
+	if err := filters.Check(pod, d.constraints...); err != nil {
+		d.logger.V(4).Info("Pod fails the following checks", "pod", klog.KObj(pod), "checks", err.Error())
 		return false
 	}
 
@@ -21,12 +21,17 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/component-base/metrics/testutil"
+	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/api"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
@@ -44,8 +49,13 @@ type testCase struct {
 	evictSystemCriticalPods bool
 	priorityThreshold       *int32
 	nodeFit                 bool
+	nodeFitPendingPods      bool
 	minReplicas             uint
 	minPodAge               *metav1.Duration
+	ignoreOwnerKinds        []string
+	onlyOwnerKinds          []string
+	protectSingletons       bool
+	objects                 []runtime.Object
 	result                  bool
 }
 
@@ -304,6 +314,75 @@ func TestDefaultEvictorPreEvictionFilter(t *testing.T) {
 			evictSystemCriticalPods: false,
 			nodeFit:                 false,
 			result:                  true,
+		}, {
+			description: "Pending pod with a higher priority about to fill the only other node",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 400, 0, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+				}),
+				test.BuildTestPod("pending", 800, 0, "", func(pod *v1.Pod) {
+					pod.Status.Phase = v1.PodPending
+					pod.Spec.Priority = utilptr.To[int32](100)
+				}),
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode("node2", 1000, 2000, 13, nil),
+			},
+			nodeFit:            true,
+			nodeFitPendingPods: true,
+			result:             false,
+		}, {
+			description: "Pending pod with a higher priority about to fill the only other node, pending pods ignored",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 400, 0, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+				}),
+				test.BuildTestPod("pending", 800, 0, "", func(pod *v1.Pod) {
+					pod.Status.Phase = v1.PodPending
+					pod.Spec.Priority = utilptr.To[int32](100)
+				}),
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode("node2", 1000, 2000, 13, nil),
+			},
+			nodeFit: true,
+			result:  true,
+		}, {
+			description: "Pending pod with a lower priority on the only other node",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 400, 0, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+					pod.Spec.Priority = utilptr.To[int32](100)
+				}),
+				test.BuildTestPod("pending", 800, 0, "", func(pod *v1.Pod) {
+					pod.Status.Phase = v1.PodPending
+					pod.Spec.Priority = utilptr.To[int32](10)
+				}),
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode("node2", 1000, 2000, 13, nil),
+			},
+			nodeFit:            true,
+			nodeFitPendingPods: true,
+			result:             true,
+		}, {
+			description: "Pending pod nominated to another node",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 400, 0, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+				}),
+				test.BuildTestPod("pending", 800, 0, "", func(pod *v1.Pod) {
+					pod.Status.Phase = v1.PodPending
+					pod.Status.NominatedNodeName = "node3"
+				}),
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode("node2", 1000, 2000, 13, nil),
+				test.BuildTestNode("node3", 1000, 2000, 13, nil),
+			},
+			nodeFit:            true,
+			nodeFitPendingPods: true,
+			result:             true,
 		},
 	}
 
@@ -337,6 +416,17 @@ func TestDefaultEvictorFilter(t *testing.T) {
 
 	ownerRefUUID := uuid.NewUUID()
 
+	vmiRef := metav1.OwnerReference{APIVersion: "kubevirt.io/v1", Kind: "VirtualMachineInstance", Name: "vm", UID: uuid.NewUUID()}
+	rolloutRef := metav1.OwnerReference{APIVersion: "example.com/v1", Kind: "Rollout", Name: "web", UID: uuid.NewUUID()}
+	deploymentRef := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: uuid.NewUUID()}
+	rsRef := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-1", UID: uuid.NewUUID()}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: deploymentRef.UID, OwnerReferences: []metav1.OwnerReference{rolloutRef}},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", UID: rsRef.UID, OwnerReferences: []metav1.OwnerReference{deploymentRef}},
+	}
+
 	testCases := []testCase{
 		{
 			description: "Failed pod eviction with no ownerRefs",
@@ -739,6 +829,175 @@ func TestDefaultEvictorFilter(t *testing.T) {
 				}),
 			},
 			result: true,
+		}, {
+			description: "Pod owned by an ignored kind, no eviction",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1, 1, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{vmiRef}
+				}),
+			},
+			ignoreOwnerKinds: []string{"VirtualMachineInstance.kubevirt.io"},
+			result:           false,
+		}, {
+			description: "Pod owned by a kind of another group than the ignored one, evicts",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1, 1, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{vmiRef}
+				}),
+			},
+			ignoreOwnerKinds: []string{"VirtualMachineInstance.example.com"},
+			result:           true,
+		}, {
+			description: "Pod owned by an ignored kind given without group, no eviction",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1, 1, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{vmiRef}
+				}),
+			},
+			ignoreOwnerKinds: []string{"VirtualMachineInstance"},
+			result:           false,
+		}, {
+			description: "Pod owned by an ignored kind through its ReplicaSet and Deployment, no eviction",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1, 1, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{rsRef}
+				}),
+			},
+			objects:          []runtime.Object{replicaSet, deployment},
+			ignoreOwnerKinds: []string{"Rollout.example.com"},
+			result:           false,
+		}, {
+			description: "Pod owned by one of the only kinds through its ReplicaSet, evicts",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1, 1, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{rsRef}
+				}),
+			},
+			objects:        []runtime.Object{replicaSet, deployment},
+			onlyOwnerKinds: []string{"Deployment.apps", "StatefulSet.apps"},
+			result:         true,
+		}, {
+			description: "Pod not owned by any of the only kinds, no eviction",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1, 1, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{vmiRef}
+				}),
+			},
+			onlyOwnerKinds: []string{"Deployment.apps", "StatefulSet.apps"},
+			result:         false,
+		}, {
+			description: "Ignored kinds win over the only kinds, no eviction",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1, 1, n1.Name, func(pod *v1.Pod) {
+					pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{rsRef}
+				}),
+			},
+			objects:          []runtime.Object{replicaSet, deployment},
+			onlyOwnerKinds:   []string{"Deployment.apps"},
+			ignoreOwnerKinds: []string{"Rollout.example.com"},
+			result:           false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			evictorPlugin, err := initializePlugin(ctx, test)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			result := evictorPlugin.(frameworktypes.EvictorPlugin).Filter(test.pods[0])
+			if (result) != test.result {
+				t.Errorf("Filter should return for pod %s %t, but it returns %t", test.pods[0].Name, test.result, result)
+			}
+		})
+	}
+}
+
+func TestDefaultEvictorProtectSingletonWorkloads(t *testing.T) {
+	metrics.Register()
+	n1 := test.BuildTestNode("node1", 1000, 2000, 13, nil)
+
+	deploymentRef := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: uuid.NewUUID(), Controller: utilptr.To(true)}
+	rsRef := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web-1", UID: uuid.NewUUID(), Controller: utilptr.To(true)}
+	stsRef := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", UID: uuid.NewUUID(), Controller: utilptr.To(true)}
+	jobRef := metav1.OwnerReference{APIVersion: "batch/v1", Kind: "Job", Name: "backup", UID: uuid.NewUUID(), Controller: utilptr.To(true)}
+	deployment := func(replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: deploymentRef.UID},
+			Spec:       appsv1.DeploymentSpec{Replicas: utilptr.To(replicas)},
+		}
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "default", UID: rsRef.UID, OwnerReferences: []metav1.OwnerReference{deploymentRef}},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: utilptr.To[int32](1)},
+	}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: stsRef.UID},
+	}
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+	buildPod := func(ownerRef metav1.OwnerReference, apply func(*v1.Pod)) *v1.Pod {
+		return test.BuildTestPod("p1", 1, 1, n1.Name, func(pod *v1.Pod) {
+			pod.Labels = map[string]string{"app": "web"}
+			pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{ownerRef}
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+
+	testCases := []testCase{
+		{
+			description:       "Only replica of a Deployment without PodDisruptionBudget, no eviction",
+			pods:              []*v1.Pod{buildPod(rsRef, nil)},
+			objects:           []runtime.Object{replicaSet, deployment(1)},
+			protectSingletons: true,
+			result:            false,
+		}, {
+			description:       "Only replica of a Deployment without PodDisruptionBudget, protection disabled, evicts",
+			pods:              []*v1.Pod{buildPod(rsRef, nil)},
+			objects:           []runtime.Object{replicaSet, deployment(1)},
+			protectSingletons: false,
+			result:            true,
+		}, {
+			description:       "Only replica of a Deployment covered by a PodDisruptionBudget, evicts",
+			pods:              []*v1.Pod{buildPod(rsRef, nil)},
+			objects:           []runtime.Object{replicaSet, deployment(1), pdb},
+			protectSingletons: true,
+			result:            true,
+		}, {
+			description:       "Replica of a Deployment with several desired replicas, evicts",
+			pods:              []*v1.Pod{buildPod(rsRef, nil)},
+			objects:           []runtime.Object{replicaSet, deployment(3)},
+			protectSingletons: true,
+			result:            true,
+		}, {
+			description: "Only replica of a Deployment with the evict-singleton annotation, evicts",
+			pods: []*v1.Pod{buildPod(rsRef, func(pod *v1.Pod) {
+				pod.Annotations = map[string]string{evictSingletonAnnotationKey: "true"}
+			})},
+			objects:           []runtime.Object{replicaSet, deployment(1)},
+			protectSingletons: true,
+			result:            true,
+		}, {
+			description:       "Only replica of a StatefulSet with defaulted replicas, no eviction",
+			pods:              []*v1.Pod{buildPod(stsRef, nil)},
+			objects:           []runtime.Object{statefulSet},
+			protectSingletons: true,
+			result:            false,
+		}, {
+			description:       "Pod of a Job, evicts",
+			pods:              []*v1.Pod{buildPod(jobRef, nil)},
+			protectSingletons: true,
+			result:            true,
 		},
 	}
 
@@ -756,8 +1015,68 @@ func TestDefaultEvictorFilter(t *testing.T) {
 			if (result) != test.result {
 				t.Errorf("Filter should return for pod %s %t, but it returns %t", test.pods[0].Name, test.result, result)
 			}
+			// Another plugin checking the pod gets the same answer, the protection is counted once
+			if result := evictorPlugin.(frameworktypes.EvictorPlugin).Filter(test.pods[0]); result != test.result {
+				t.Errorf("Filter should return for pod %s %t the second time, but it returns %t", test.pods[0].Name, test.result, result)
+			}
 		})
 	}
+
+	protected, err := testutil.GetCounterMetricValue(metrics.SingletonWorkloadProtections.With(map[string]string{"result": "protected", "namespace": "default"}))
+	if err != nil {
+		t.Fatalf("Unable to read the counter: %v", err)
+	}
+	if protected != 2 {
+		t.Errorf("Expected the protection to trigger 2 times, got %v", protected)
+	}
+	overridden, err := testutil.GetCounterMetricValue(metrics.SingletonWorkloadProtections.With(map[string]string{"result": "overridden", "namespace": "default"}))
+	if err != nil {
+		t.Fatalf("Unable to read the counter: %v", err)
+	}
+	if overridden != 1 {
+		t.Errorf("Expected the protection to be overridden once, got %v", overridden)
+	}
+}
+
+func TestDefaultEvictorProtectSingletonWorkloadsReadsTheCluster(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n1 := test.BuildTestNode("node1", 1000, 2000, 13, nil)
+	stsRef := metav1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", UID: uuid.NewUUID(), Controller: utilptr.To(true)}
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: stsRef.UID},
+	}
+	pod := test.BuildTestPod("p1", 1, 1, n1.Name, func(pod *v1.Pod) {
+		pod.ObjectMeta.OwnerReferences = []metav1.OwnerReference{stsRef}
+	})
+
+	// Like the cached client of the dry run mode, the shared informer factory holds the pods only
+	sharedInformerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(n1, pod), 0)
+	clusterClient := fake.NewSimpleClientset(n1, pod, statefulSet)
+	clusterInformerFactory := informers.NewSharedInformerFactory(clusterClient, 0)
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(sharedInformerFactory.Core().V1().Pods().Informer())
+	if err != nil {
+		t.Fatalf("Build get pods assigned to node function error: %v", err)
+	}
+
+	evictorPlugin, err := New(&DefaultEvictorArgs{ProtectSingletonWorkloads: true}, &frameworkfake.HandleImpl{
+		ClientsetImpl:                 clusterClient,
+		GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+		SharedInformerFactoryImpl:     sharedInformerFactory,
+		ClusterInformerFactoryImpl:    clusterInformerFactory,
+	})
+	if err != nil {
+		t.Fatalf("Unable to initialize the plugin: %v", err)
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+	clusterInformerFactory.Start(ctx.Done())
+	clusterInformerFactory.WaitForCacheSync(ctx.Done())
+
+	if evictorPlugin.(frameworktypes.EvictorPlugin).Filter(pod) {
+		t.Errorf("Expected the only replica of the StatefulSet of the cluster to be protected")
+	}
 }
 
 func TestReinitialization(t *testing.T) {
@@ -811,6 +1130,7 @@ func initializePlugin(ctx context.Context, test testCase) (frameworktypes.Plugin
 	for _, pod := range test.pods {
 		objs = append(objs, pod)
 	}
+	objs = append(objs, test.objects...)
 
 	fakeClient := fake.NewSimpleClientset(objs...)
 
@@ -833,9 +1153,15 @@ func initializePlugin(ctx context.Context, test testCase) (frameworktypes.Plugin
 		PriorityThreshold: &api.PriorityThreshold{
 			Value: test.priorityThreshold,
 		},
-		NodeFit:     test.nodeFit,
-		MinReplicas: test.minReplicas,
-		MinPodAge:   test.minPodAge,
+		NodeFit:            utilptr.To(test.nodeFit),
+		NodeFitPendingPods: test.nodeFitPendingPods,
+		MinReplicas:        test.minReplicas,
+		MinPodAge:          test.minPodAge,
+
+		IgnoreOwnerKinds: test.ignoreOwnerKinds,
+		OnlyOwnerKinds:   test.onlyOwnerKinds,
+
+		ProtectSingletonWorkloads: test.protectSingletons,
 	}
 
 	evictorPlugin, err := New(
@@ -848,6 +1174,9 @@ func initializePlugin(ctx context.Context, test testCase) (frameworktypes.Plugin
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize the plugin: %v", err)
 	}
+	// Start the informers the plugin requested while being built
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
 
 	return evictorPlugin, nil
 }
@@ -49,7 +49,7 @@ func SetDefaults_DefaultEvictorArgs(obj runtime.Object) {
 	if args.PriorityThreshold == nil {
 		args.PriorityThreshold = nil
 	}
-	if !args.NodeFit {
-		args.NodeFit = false
+	if args.NodeFit == nil {
+		args.NodeFit = nil
 	}
 }
@@ -41,7 +41,7 @@ func TestSetDefaults_DefaultEvictorArgs(t *testing.T) {
 				EvictFailedBarePods:     false,
 				LabelSelector:           nil,
 				PriorityThreshold:       nil,
-				NodeFit:                 false,
+				NodeFit:                 nil,
 			},
 		},
 		{
@@ -57,7 +57,7 @@ func TestSetDefaults_DefaultEvictorArgs(t *testing.T) {
 				PriorityThreshold: &api.PriorityThreshold{
 					Value: utilptr.To[int32](800),
 				},
-				NodeFit: true,
+				NodeFit: utilptr.To(true),
 			},
 			want: &DefaultEvictorArgs{
 				NodeSelector:            "NodeSelector",
@@ -70,7 +70,7 @@ func TestSetDefaults_DefaultEvictorArgs(t *testing.T) {
 				PriorityThreshold: &api.PriorityThreshold{
 					Value: utilptr.To[int32](800),
 				},
-				NodeFit: true,
+				NodeFit: utilptr.To(true),
 			},
 		},
 	}
@@ -33,7 +33,15 @@ type DefaultEvictorArgs struct {
 	EvictFailedBarePods     bool                   `json:"evictFailedBarePods"`
 	LabelSelector           *metav1.LabelSelector  `json:"labelSelector"`
 	PriorityThreshold       *api.PriorityThreshold `json:"priorityThreshold"`
-	NodeFit                 bool                   `json:"nodeFit"`
+	NodeFit                 *bool                  `json:"nodeFit"`
 	MinReplicas             uint                   `json:"minReplicas"`
 	MinPodAge               *metav1.Duration       `json:"minPodAge"`
+	IgnoreOwnerKinds        []string               `json:"ignoreOwnerKinds"`
+	OnlyOwnerKinds          []string               `json:"onlyOwnerKinds"`
+	// NodeFitPendingPods makes nodeFit account for the pending pods with the same or a higher
+	// priority than the evicted pod, which are about to consume the capacity of the nodes
+	NodeFitPendingPods bool `json:"nodeFitPendingPods,omitempty"`
+	// ProtectSingletonWorkloads skips the pods whose workload has exactly one desired replica and
+	// no PodDisruptionBudget covering it, as evicting them means a guaranteed downtime
+	ProtectSingletonWorkloads bool `json:"protectSingletonWorkloads,omitempty"`
 }
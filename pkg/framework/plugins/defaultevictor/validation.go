@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"k8s.io/klog/v2"
+	utilptr "k8s.io/utils/ptr"
 
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -28,9 +29,19 @@ func ValidateDefaultEvictorArgs(obj runtime.Object) error {
 		return fmt.Errorf("priority threshold misconfigured, only one of priorityThreshold fields can be set, got %v", args)
 	}
 
+	if args.NodeFitPendingPods && !utilptr.Deref(args.NodeFit, false) {
+		return fmt.Errorf("nodeFitPendingPods can only be set when nodeFit is enabled")
+	}
+
 	if args.MinReplicas == 1 {
 		klog.V(4).Info("DefaultEvictor minReplicas must be greater than 1 to check for min pods during eviction. This check will be ignored during eviction.")
 	}
 
+	for _, kinds := range [][]string{args.IgnoreOwnerKinds, args.OnlyOwnerKinds} {
+		if _, err := parseOwnerKinds(kinds); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -41,11 +41,26 @@ func (in *DefaultEvictorArgs) DeepCopyInto(out *DefaultEvictorArgs) {
 		*out = new(api.PriorityThreshold)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeFit != nil {
+		in, out := &in.NodeFit, &out.NodeFit
+		*out = new(bool)
+		**out = **in
+	}
 	if in.MinPodAge != nil {
 		in, out := &in.MinPodAge, &out.MinPodAge
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.IgnoreOwnerKinds != nil {
+		in, out := &in.IgnoreOwnerKinds, &out.IgnoreOwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OnlyOwnerKinds != nil {
+		in, out := &in.OnlyOwnerKinds, &out.OnlyOwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsbeforespotinterruption
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+// InterruptionAnnotation is the annotation tools without a dedicated signal can set on the nodes about to be interrupted
+const InterruptionAnnotation = "descheduler.alpha.kubernetes.io/interruption"
+
+// DefaultInterruptionTaints are the taints set by the AWS node termination handler and by GKE
+var DefaultInterruptionTaints = []string{
+	"aws-node-termination-handler/spot-itn",
+	"aws-node-termination-handler/scheduled-maintenance",
+	"aws-node-termination-handler/asg-lifecycle-termination",
+	"cloud.google.com/impending-node-termination",
+}
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_DeschedulePodsBeforeSpotInterruptionArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_DeschedulePodsBeforeSpotInterruptionArgs(obj runtime.Object) {
+	args := obj.(*DeschedulePodsBeforeSpotInterruptionArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if len(args.InterruptionTaints) == 0 && len(args.InterruptionLabels) == 0 && len(args.InterruptionAnnotations) == 0 {
+		args.InterruptionTaints = append([]string{}, DefaultInterruptionTaints...)
+		args.InterruptionAnnotations = []string{InterruptionAnnotation}
+	}
+	if args.EvictStatefulPods == nil {
+		args.EvictStatefulPods = utilptr.To(true)
+	}
+	if args.SlowStartThreshold == nil {
+		args.SlowStartThreshold = &metav1.Duration{Duration: 30 * time.Second}
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsbeforespotinterruption
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestSetDefaults_DeschedulePodsBeforeSpotInterruptionArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "DeschedulePodsBeforeSpotInterruptionArgs empty",
+			in:   &DeschedulePodsBeforeSpotInterruptionArgs{},
+			want: &DeschedulePodsBeforeSpotInterruptionArgs{
+				InterruptionTaints:      DefaultInterruptionTaints,
+				InterruptionAnnotations: []string{InterruptionAnnotation},
+				EvictStatefulPods:       utilptr.To(true),
+				SlowStartThreshold:      &metav1.Duration{Duration: 30 * time.Second},
+			},
+		},
+		{
+			name: "DeschedulePodsBeforeSpotInterruptionArgs with value",
+			in: &DeschedulePodsBeforeSpotInterruptionArgs{
+				InterruptionLabels: []string{"example.com/interruption"},
+				EvictStatefulPods:  utilptr.To(false),
+				SlowStartThreshold: &metav1.Duration{Duration: time.Minute},
+			},
+			want: &DeschedulePodsBeforeSpotInterruptionArgs{
+				InterruptionLabels: []string{"example.com/interruption"},
+				EvictStatefulPods:  utilptr.To(false),
+				SlowStartThreshold: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_DeschedulePodsBeforeSpotInterruptionArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsbeforespotinterruption
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "DeschedulePodsBeforeSpotInterruption"
+
+// DeschedulePodsBeforeSpotInterruption evicts the stateful and slow starting pods from the nodes
+// about to be interrupted (e.g. spot instances reclaimed by the cloud provider), so they get
+// rescheduled before the node goes away instead of after it, within the short notice given by
+// the cloud providers. The evictions are urgent: the pods are terminated with the node anyway.
+type DeschedulePodsBeforeSpotInterruption struct {
+	handle    frameworktypes.Handle
+	args      *DeschedulePodsBeforeSpotInterruptionArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &DeschedulePodsBeforeSpotInterruption{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	interruptionArgs, ok := args.(*DeschedulePodsBeforeSpotInterruptionArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type DeschedulePodsBeforeSpotInterruptionArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if interruptionArgs.Namespaces != nil {
+		includedNamespaces = sets.New(interruptionArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(interruptionArgs.Namespaces.Exclude...)
+	}
+
+	d := &DeschedulePodsBeforeSpotInterruption{
+		handle: handle,
+		args:   interruptionArgs,
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, func(pod *v1.Pod) bool {
+			return d.isStateful(pod) || d.startupDuration(pod) >= d.slowStartThreshold()
+		})).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(interruptionArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	d.podFilter = podFilter
+
+	return d, nil
+}
+
+// Name retrieves the plugin name
+func (d *DeschedulePodsBeforeSpotInterruption) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *DeschedulePodsBeforeSpotInterruption) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	for _, node := range nodes {
+		signal := d.interruptionSignal(node)
+		if signal == "" {
+			continue
+		}
+		logger.V(1).Info("Node about to be interrupted", "node", klog.KObj(node), "signal", signal)
+
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		// Stateful pods first, then the slowest to start, as they need the most time to be replaced
+		sort.SliceStable(pods, func(i, j int) bool {
+			if statefulI, statefulJ := d.isStateful(pods[i]), d.isStateful(pods[j]); statefulI != statefulJ {
+				return statefulI
+			}
+			return d.startupDuration(pods[i]) > d.startupDuration(pods[j])
+		})
+		podutil.SortPodsBasedOnEvictionCost(pods)
+
+		for _, pod := range pods {
+			if !d.handle.Evictor().PreEvictionFilter(pod) {
+				continue
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{
+				StrategyName: PluginName,
+				Reason:       fmt.Sprintf("node about to be interrupted (%s)", signal),
+				Urgent:       true,
+			})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// interruptionSignal returns the taint, label or annotation signaling the node is about to be
+// interrupted, or an empty string when the node is not about to be interrupted.
+func (d *DeschedulePodsBeforeSpotInterruption) interruptionSignal(node *v1.Node) string {
+	for _, key := range d.args.InterruptionTaints {
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == key {
+				return "taint " + key
+			}
+		}
+	}
+	for _, key := range d.args.InterruptionLabels {
+		if _, ok := node.Labels[key]; ok {
+			return "label " + key
+		}
+	}
+	for _, key := range d.args.InterruptionAnnotations {
+		if _, ok := node.Annotations[key]; ok {
+			return "annotation " + key
+		}
+	}
+	return ""
+}
+
+// isStateful checks if the pod is owned by a StatefulSet or uses persistent volume claims
+func (d *DeschedulePodsBeforeSpotInterruption) isStateful(pod *v1.Pod) bool {
+	if !utilptr.Deref(d.args.EvictStatefulPods, true) {
+		return false
+	}
+	return utils.IsStatefulSetPod(podutil.OwnerRef(pod)) || utils.IsPodWithPVC(pod)
+}
+
+func (d *DeschedulePodsBeforeSpotInterruption) slowStartThreshold() time.Duration {
+	if d.args.SlowStartThreshold == nil {
+		// No threshold, only the stateful pods are evicted
+		return time.Duration(math.MaxInt64)
+	}
+	return d.args.SlowStartThreshold.Duration
+}
+
+// startupDuration returns how long the pod took to get ready after it started,
+// or a negative duration when the pod is not ready yet.
+func (d *DeschedulePodsBeforeSpotInterruption) startupDuration(pod *v1.Pod) time.Duration {
+	if pod.Status.StartTime == nil || !utils.IsPodReady(pod) {
+		return -1
+	}
+	return utils.GetPodCondition(pod, v1.PodReady).LastTransitionTime.Sub(pod.Status.StartTime.Time)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsbeforespotinterruption
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDeschedulePodsBeforeSpotInterruption(t *testing.T) {
+	startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	withStartup := func(startup time.Duration) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Status.StartTime = &startTime
+			pod.Status.Conditions = []v1.PodCondition{{
+				Type:               v1.PodReady,
+				Status:             v1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(startTime.Add(startup)),
+			}}
+		}
+	}
+	buildPods := func(nodeName string) []*v1.Pod {
+		return []*v1.Pod{
+			test.BuildTestPod("stateful", 100, 0, nodeName, func(pod *v1.Pod) {
+				test.SetSSOwnerRef(pod)
+				withStartup(time.Second)(pod)
+			}),
+			test.BuildTestPod("with-pvc", 100, 0, nodeName, func(pod *v1.Pod) {
+				test.SetRSOwnerRef(pod)
+				withStartup(time.Second)(pod)
+				pod.Spec.Volumes = []v1.Volume{{
+					Name: "data",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+					},
+				}}
+			}),
+			test.BuildTestPod("slow", 100, 0, nodeName, func(pod *v1.Pod) {
+				test.SetRSOwnerRef(pod)
+				withStartup(2 * time.Minute)(pod)
+			}),
+			test.BuildTestPod("fast", 100, 0, nodeName, func(pod *v1.Pod) {
+				test.SetRSOwnerRef(pod)
+				withStartup(time.Second)(pod)
+			}),
+			test.BuildTestPod("not-ready", 100, 0, nodeName, test.SetRSOwnerRef),
+		}
+	}
+	spotTaint := func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "aws-node-termination-handler/spot-itn", Effect: v1.TaintEffectNoSchedule}}
+	}
+
+	tests := []struct {
+		description          string
+		args                 DeschedulePodsBeforeSpotInterruptionArgs
+		node                 *v1.Node
+		maxPodsToEvictTotal  *uint
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "Stateful and slow starting pods are evicted from a node tainted by the AWS node termination handler",
+			node:                 test.BuildTestNode("n1", 2000, 3000, 10, spotTaint),
+			expectedEvictedCount: 3,
+		},
+		{
+			description: "Pods are evicted from a node with the interruption annotation",
+			node: test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+				node.Annotations = map[string]string{InterruptionAnnotation: "true"}
+			}),
+			expectedEvictedCount: 3,
+		},
+		{
+			description: "Pods are evicted from a node with a configured interruption label",
+			args: DeschedulePodsBeforeSpotInterruptionArgs{
+				InterruptionLabels: []string{"example.com/interruption"},
+			},
+			node: test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+				node.Labels["example.com/interruption"] = "true"
+			}),
+			expectedEvictedCount: 3,
+		},
+		{
+			description:          "No pod is evicted from a node not about to be interrupted",
+			node:                 test.BuildTestNode("n1", 2000, 3000, 10, nil),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Only slow starting pods are evicted when stateful pods are not",
+			args: DeschedulePodsBeforeSpotInterruptionArgs{
+				EvictStatefulPods: utilptr.To(false),
+			},
+			node:                 test.BuildTestNode("n1", 2000, 3000, 10, spotTaint),
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Fast starting pods are evicted with a lower threshold",
+			args: DeschedulePodsBeforeSpotInterruptionArgs{
+				SlowStartThreshold: &metav1.Duration{Duration: time.Second},
+			},
+			node:                 test.BuildTestNode("n1", 2000, 3000, 10, spotTaint),
+			expectedEvictedCount: 4,
+		},
+		{
+			description:          "The total limit still applies",
+			node:                 test.BuildTestNode("n1", 2000, 3000, 10, spotTaint),
+			maxPodsToEvictTotal:  utilptr.To[uint](2),
+			expectedEvictedCount: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{tc.node}
+			for _, pod := range buildPods(tc.node.Name) {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			// The per node limit does not apply to the urgent evictions
+			evictionOptions := evictions.NewOptions().
+				WithMaxPodsToEvictPerNode(utilptr.To[uint](1)).
+				WithMaxPodsToEvictTotal(tc.maxPodsToEvictTotal)
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictionOptions,
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := tc.args
+			SetDefaults_DeschedulePodsBeforeSpotInterruptionArgs(&args)
+			plugin, err := New(&args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{tc.node})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsbeforespotinterruption
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulePodsBeforeSpotInterruptionArgs holds arguments used to configure DeschedulePodsBeforeSpotInterruption plugin.
+type DeschedulePodsBeforeSpotInterruptionArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// InterruptionTaints are the keys of the node taints signaling an imminent interruption
+	InterruptionTaints []string `json:"interruptionTaints"`
+	// InterruptionLabels are the keys of the node labels signaling an imminent interruption
+	InterruptionLabels []string `json:"interruptionLabels"`
+	// InterruptionAnnotations are the keys of the node annotations signaling an imminent interruption
+	InterruptionAnnotations []string `json:"interruptionAnnotations"`
+	// EvictStatefulPods evicts the pods owned by a StatefulSet or using persistent volume claims
+	EvictStatefulPods *bool `json:"evictStatefulPods"`
+	// SlowStartThreshold evicts the pods which took at least this long to get ready after they started
+	SlowStartThreshold *metav1.Duration `json:"slowStartThreshold"`
+}
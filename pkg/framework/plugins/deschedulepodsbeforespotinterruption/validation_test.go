@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsbeforespotinterruption
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateDeschedulePodsBeforeSpotInterruptionArgs(t *testing.T) {
+	validArgs := func(mutate func(*DeschedulePodsBeforeSpotInterruptionArgs)) *DeschedulePodsBeforeSpotInterruptionArgs {
+		args := &DeschedulePodsBeforeSpotInterruptionArgs{}
+		SetDefaults_DeschedulePodsBeforeSpotInterruptionArgs(args)
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *DeschedulePodsBeforeSpotInterruptionArgs
+		expectError bool
+	}{
+		{
+			description: "valid args, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *DeschedulePodsBeforeSpotInterruptionArgs) {
+				args.Namespaces = &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects error",
+			args: validArgs(func(args *DeschedulePodsBeforeSpotInterruptionArgs) {
+				args.LabelSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+				}
+			}),
+			expectError: true,
+		},
+		{
+			description: "no interruption signal, expects error",
+			args: validArgs(func(args *DeschedulePodsBeforeSpotInterruptionArgs) {
+				args.InterruptionTaints = nil
+				args.InterruptionAnnotations = nil
+			}),
+			expectError: true,
+		},
+		{
+			description: "negative slow start threshold, expects error",
+			args: validArgs(func(args *DeschedulePodsBeforeSpotInterruptionArgs) {
+				args.SlowStartThreshold = &metav1.Duration{Duration: -time.Second}
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateDeschedulePodsBeforeSpotInterruptionArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsfornodelabelrollout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "DeschedulePodsForNodeLabelRollout"
+
+	// NodeLabelRolloutReason is the reason of the events reporting the progress of the workloads
+	NodeLabelRolloutReason = "NodeLabelRollout"
+)
+
+// DeschedulePodsForNodeLabelRollout helps rolling out a new node label scheme. Pods pinned to a
+// node label being phased out, through their node selector or their required node affinity, are
+// evicted at a controlled rate so they get recreated against the new labels, e.g. by a mutating
+// webhook translating the labels or by a controller whose template got updated but which does not
+// replace its pods on its own (OnDelete update strategy). A pod is only evicted when a node carries
+// the new labels it would select. The progress of every workload is reported through events.
+type DeschedulePodsForNodeLabelRollout struct {
+	handle    frameworktypes.Handle
+	args      *DeschedulePodsForNodeLabelRolloutArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &DeschedulePodsForNodeLabelRollout{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	rolloutArgs, ok := args.(*DeschedulePodsForNodeLabelRolloutArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type DeschedulePodsForNodeLabelRolloutArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if rolloutArgs.Namespaces != nil {
+		includedNamespaces = sets.New(rolloutArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(rolloutArgs.Namespaces.Exclude...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(rolloutArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &DeschedulePodsForNodeLabelRollout{
+		handle:    handle,
+		args:      rolloutArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *DeschedulePodsForNodeLabelRollout) Name() string {
+	return PluginName
+}
+
+// matches returns true if the mapping applies to the label value
+func (m NodeLabelMapping) matches(value string) bool {
+	return m.OldValue == "" || m.OldValue == value
+}
+
+// translate returns the new label value of the old label value
+func (m NodeLabelMapping) translate(value string) string {
+	if m.NewValue == "" {
+		return value
+	}
+	return m.NewValue
+}
+
+// mapping returns the mapping of the old label the pod selects through the requirement, if any
+func (d *DeschedulePodsForNodeLabelRollout) mapping(requirement v1.NodeSelectorRequirement) (NodeLabelMapping, bool) {
+	for _, mapping := range d.args.Mappings {
+		if requirement.Key != mapping.OldKey {
+			continue
+		}
+		if mapping.OldValue == "" {
+			return mapping, true
+		}
+		for _, value := range requirement.Values {
+			if mapping.matches(value) {
+				return mapping, true
+			}
+		}
+	}
+	return NodeLabelMapping{}, false
+}
+
+// translatePod returns a copy of the pod selecting the new labels instead of the old ones, and
+// whether the pod selects any of the old labels at all.
+func (d *DeschedulePodsForNodeLabelRollout) translatePod(pod *v1.Pod) (*v1.Pod, bool) {
+	translated := pod.DeepCopy()
+	pinned := false
+	for key, value := range pod.Spec.NodeSelector {
+		mapping, ok := d.mapping(v1.NodeSelectorRequirement{Key: key, Values: []string{value}})
+		if !ok {
+			continue
+		}
+		pinned = true
+		delete(translated.Spec.NodeSelector, key)
+		translated.Spec.NodeSelector[mapping.NewKey] = mapping.translate(value)
+	}
+
+	affinity := translated.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return translated, pinned
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for i := range term.MatchExpressions {
+			requirement := &term.MatchExpressions[i]
+			mapping, ok := d.mapping(*requirement)
+			if !ok {
+				continue
+			}
+			pinned = true
+			requirement.Key = mapping.NewKey
+			for j, value := range requirement.Values {
+				if mapping.matches(value) {
+					requirement.Values[j] = mapping.translate(value)
+				}
+			}
+		}
+	}
+	return translated, pinned
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *DeschedulePodsForNodeLabelRollout) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	pods, err := podutil.ListPodsOnNodes(nodes, d.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing all pods: %v", err),
+		}
+	}
+
+	// Workloads are processed in a stable order so all of them make progress over the cycles
+	workloads := podutil.GroupByOwnerRef(pods)
+	owners := make([]podutil.OwnerKey, 0, len(workloads))
+	for owner := range workloads {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i].Namespace != owners[j].Namespace {
+			return owners[i].Namespace < owners[j].Namespace
+		}
+		if owners[i].Kind != owners[j].Kind {
+			return owners[i].Kind < owners[j].Kind
+		}
+		return owners[i].Name < owners[j].Name
+	})
+
+	var evicted uint
+	for _, owner := range owners {
+		var pinned []*v1.Pod
+		for _, pod := range workloads[owner] {
+			if _, ok := d.translatePod(pod); ok {
+				pinned = append(pinned, pod)
+			}
+		}
+		if len(pinned) == 0 {
+			continue
+		}
+
+		var evictedOfWorkload uint
+		podutil.SortPodsBasedOnPriorityLowToHigh(pinned)
+		for _, pod := range pinned {
+			if evicted >= *d.args.MaxEvictionsPerCycle || evictedOfWorkload >= *d.args.MaxEvictionsPerWorkload {
+				break
+			}
+			if !d.podFilter(pod) {
+				continue
+			}
+			translated, _ := d.translatePod(pod)
+			if !nodeutil.PodFitsAnyNode(d.handle.GetPodsAssignedToNodeFunc(), translated, nodes) {
+				logger.V(2).Info("No node carries the new labels the pod would select", "pod", klog.KObj(pod))
+				continue
+			}
+			if !d.handle.Evictor().PreEvictionFilter(pod) {
+				continue
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				evicted++
+				evictedOfWorkload++
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				continue
+			case *evictions.EvictionTotalLimitError:
+				d.reportProgress(logger, owner, workloads[owner], len(pinned)-int(evictedOfWorkload))
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+		d.reportProgress(logger, owner, workloads[owner], len(pinned)-int(evictedOfWorkload))
+	}
+	return nil
+}
+
+// reportProgress reports how many pods of the workload still select the old labels
+func (d *DeschedulePodsForNodeLabelRollout) reportProgress(logger klog.Logger, owner podutil.OwnerKey, pods []*v1.Pod, remaining int) {
+	migrated := len(pods) - remaining
+	logger.V(1).Info("Node label rollout progress", "kind", owner.Kind, "owner", klog.KRef(owner.Namespace, owner.Name), "migratedPods", migrated, "remainingPods", remaining)
+	d.handle.EventRecorder().Eventf(owner.ObjectReference(), pods[0], v1.EventTypeNormal, NodeLabelRolloutReason, "Descheduled",
+		"%d/%d pod(s) migrated to the new node labels, %d pod(s) still select the old ones", migrated, len(pods), remaining)
+}
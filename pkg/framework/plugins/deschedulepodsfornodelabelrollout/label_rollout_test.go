@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsfornodelabelrollout
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDeschedulePodsForNodeLabelRollout(t *testing.T) {
+	oldNode := test.BuildTestNode("old", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"pool": "a", "zone": "z1"}
+	})
+	newNode := test.BuildTestNode("new", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"example.com/pool": "a", "zone": "z2"}
+	})
+
+	buildPod := func(name, owner string, apply func(*v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, "old", func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "v1", Name: owner, Controller: utilptr.To(true)}}
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	withNodeSelector := func(key, value string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.NodeSelector = map[string]string{key: value}
+		}
+	}
+	withNodeAffinity := func(key string, values ...string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.Affinity = &v1.Affinity{
+				NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{
+							MatchExpressions: []v1.NodeSelectorRequirement{{Key: key, Operator: v1.NodeSelectorOpIn, Values: values}},
+						}},
+					},
+				},
+			}
+		}
+	}
+
+	poolMapping := NodeLabelMapping{OldKey: "pool", NewKey: "example.com/pool"}
+
+	tests := []struct {
+		description             string
+		pods                    []*v1.Pod
+		nodes                   []*v1.Node
+		mappings                []NodeLabelMapping
+		maxEvictionsPerCycle    uint
+		maxEvictionsPerWorkload uint
+		maxPodsToEvictTotal     *uint
+		expectedEvicted         []string
+	}{
+		{
+			description: "pods pinned through their node selector or node affinity are evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withNodeSelector("pool", "a")),
+				buildPod("p2", "rs2", withNodeAffinity("pool", "a")),
+				buildPod("p3", "rs3", nil),
+			},
+			nodes:                   []*v1.Node{oldNode, newNode},
+			mappings:                []NodeLabelMapping{poolMapping},
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 1,
+			expectedEvicted:         []string{"p1", "p2"},
+		},
+		{
+			description: "pods are not evicted when no node carries the new labels",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withNodeSelector("pool", "a")),
+				buildPod("p2", "rs2", withNodeAffinity("pool", "b")),
+			},
+			nodes:                   []*v1.Node{oldNode, newNode},
+			mappings:                []NodeLabelMapping{poolMapping, {OldKey: "zone", NewKey: "zone", OldValue: "z1", NewValue: "z3"}},
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 1,
+			expectedEvicted:         []string{"p1"},
+		},
+		{
+			description: "only the mapped label values are rolled out",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withNodeSelector("zone", "z1")),
+				buildPod("p2", "rs2", withNodeSelector("zone", "z2")),
+			},
+			nodes:                   []*v1.Node{oldNode, newNode},
+			mappings:                []NodeLabelMapping{{OldKey: "zone", NewKey: "zone", OldValue: "z1", NewValue: "z2"}},
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 1,
+			expectedEvicted:         []string{"p1"},
+		},
+		{
+			description: "evictions are limited per workload, lowest priority first",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", func(pod *v1.Pod) {
+					withNodeSelector("pool", "a")(pod)
+					test.SetPodPriority(pod, 100)
+				}),
+				buildPod("p2", "rs1", func(pod *v1.Pod) {
+					withNodeSelector("pool", "a")(pod)
+					test.SetPodPriority(pod, 10)
+				}),
+				buildPod("p3", "rs1", func(pod *v1.Pod) {
+					withNodeSelector("pool", "a")(pod)
+					test.SetPodPriority(pod, 50)
+				}),
+			},
+			nodes:                   []*v1.Node{oldNode, newNode},
+			mappings:                []NodeLabelMapping{poolMapping},
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 2,
+			expectedEvicted:         []string{"p2", "p3"},
+		},
+		{
+			description: "evictions are limited per cycle, workloads in order",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs2", withNodeSelector("pool", "a")),
+				buildPod("p2", "rs1", withNodeSelector("pool", "a")),
+				buildPod("p3", "rs3", withNodeSelector("pool", "a")),
+			},
+			nodes:                   []*v1.Node{oldNode, newNode},
+			mappings:                []NodeLabelMapping{poolMapping},
+			maxEvictionsPerCycle:    2,
+			maxEvictionsPerWorkload: 1,
+			expectedEvicted:         []string{"p1", "p2"},
+		},
+		{
+			description: "the total eviction limit stops the rollout",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withNodeSelector("pool", "a")),
+				buildPod("p2", "rs2", withNodeSelector("pool", "a")),
+			},
+			nodes:                   []*v1.Node{oldNode, newNode},
+			mappings:                []NodeLabelMapping{poolMapping},
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 1,
+			maxPodsToEvictTotal:     utilptr.To[uint](1),
+			expectedEvicted:         []string{"p1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			scenario.New().
+				WithNodes(tc.nodes...).
+				WithPods(tc.pods...).
+				WithEvictionOptions(evictions.NewOptions().WithMaxPodsToEvictTotal(tc.maxPodsToEvictTotal)).
+				ExpectEvicted(tc.expectedEvicted...).
+				Run(t, New, &DeschedulePodsForNodeLabelRolloutArgs{
+					Mappings:                tc.mappings,
+					MaxEvictionsPerCycle:    &tc.maxEvictionsPerCycle,
+					MaxEvictionsPerWorkload: &tc.maxEvictionsPerWorkload,
+				})
+		})
+	}
+}
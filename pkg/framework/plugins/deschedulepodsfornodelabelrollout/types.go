@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsfornodelabelrollout
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulePodsForNodeLabelRolloutArgs holds arguments used to configure DeschedulePodsForNodeLabelRollout plugin.
+type DeschedulePodsForNodeLabelRolloutArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// Mappings maps the node labels being phased out to the labels replacing them
+	Mappings []NodeLabelMapping `json:"mappings"`
+	// MaxEvictionsPerCycle caps the pods evicted in a descheduling cycle
+	MaxEvictionsPerCycle *uint `json:"maxEvictionsPerCycle,omitempty"`
+	// MaxEvictionsPerWorkload caps the pods of a single workload evicted in a descheduling cycle
+	MaxEvictionsPerWorkload *uint `json:"maxEvictionsPerWorkload,omitempty"`
+}
+
+// NodeLabelMapping maps a node label being phased out to the label replacing it
+type NodeLabelMapping struct {
+	// OldKey is the key of the label being phased out
+	OldKey string `json:"oldKey"`
+	// OldValue restricts the mapping to a single value of the old label, all its values are mapped when empty
+	OldValue string `json:"oldValue,omitempty"`
+	// NewKey is the key of the label replacing it
+	NewKey string `json:"newKey"`
+	// NewValue is the value of the new label, the value of the old label is kept when empty
+	NewValue string `json:"newValue,omitempty"`
+}
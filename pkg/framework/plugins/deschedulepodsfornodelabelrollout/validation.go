@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsfornodelabelrollout
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateDeschedulePodsForNodeLabelRolloutArgs validates DeschedulePodsForNodeLabelRollout arguments
+func ValidateDeschedulePodsForNodeLabelRolloutArgs(obj runtime.Object) error {
+	args := obj.(*DeschedulePodsForNodeLabelRolloutArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+	if len(args.Mappings) == 0 {
+		return fmt.Errorf("at least one label mapping is required")
+	}
+	for _, mapping := range args.Mappings {
+		if err := validateLabel("old", mapping.OldKey, mapping.OldValue); err != nil {
+			return err
+		}
+		if err := validateLabel("new", mapping.NewKey, mapping.NewValue); err != nil {
+			return err
+		}
+		if mapping.OldKey == mapping.NewKey && (mapping.NewValue == "" || mapping.OldValue == mapping.NewValue) {
+			return fmt.Errorf("label %q is mapped to itself", mapping.OldKey)
+		}
+	}
+	return nil
+}
+
+func validateLabel(name, key, value string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("invalid %s label key %q: %s", name, key, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return fmt.Errorf("invalid %s label value %q: %s", name, value, strings.Join(errs, ", "))
+	}
+	return nil
+}
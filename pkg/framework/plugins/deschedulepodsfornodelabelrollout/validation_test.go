@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulepodsfornodelabelrollout
+
+import (
+	"testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateDeschedulePodsForNodeLabelRolloutArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *DeschedulePodsForNodeLabelRolloutArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &DeschedulePodsForNodeLabelRolloutArgs{
+				Mappings: []NodeLabelMapping{
+					{OldKey: "pool", NewKey: "example.com/pool"},
+					{OldKey: "zone", OldValue: "a", NewKey: "zone", NewValue: "b"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &DeschedulePodsForNodeLabelRolloutArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+				Mappings: []NodeLabelMapping{{OldKey: "pool", NewKey: "example.com/pool"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "no mapping, expects error",
+			args:        &DeschedulePodsForNodeLabelRolloutArgs{},
+			expectError: true,
+		},
+		{
+			description: "invalid key, expects error",
+			args: &DeschedulePodsForNodeLabelRolloutArgs{
+				Mappings: []NodeLabelMapping{{OldKey: "pool", NewKey: "example.com/pool/"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid value, expects error",
+			args: &DeschedulePodsForNodeLabelRolloutArgs{
+				Mappings: []NodeLabelMapping{{OldKey: "pool", OldValue: "a b", NewKey: "example.com/pool"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "label mapped to itself, expects error",
+			args: &DeschedulePodsForNodeLabelRolloutArgs{
+				Mappings: []NodeLabelMapping{{OldKey: "pool", OldValue: "a", NewKey: "pool"}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateDeschedulePodsForNodeLabelRolloutArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulercanary
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "DeschedulerCanary"
+
+// DeschedulerCanary evicts a small random sample of pods on a schedule to continuously validate
+// that the workloads tolerate being rescheduled. Each run evicts at most one pod per workload,
+// workloads are left alone for a cooldown once one of their pods got evicted, and workloads
+// with less than a minimum number of ready pods (e.g. singletons) are never sampled.
+type DeschedulerCanary struct {
+	handle    frameworktypes.Handle
+	args      *DeschedulerCanaryArgs
+	podFilter podutil.FilterFunc
+	podLister listersv1.PodLister
+	state     *canaryState
+}
+
+// canaryState outlives the plugin, which is built again on every descheduling cycle
+type canaryState struct {
+	sync.Mutex
+	lastRun time.Time
+	// targetEvictions holds when a pod of each workload was last evicted
+	targetEvictions map[podutil.OwnerKey]time.Time
+}
+
+func newCanaryState() *canaryState {
+	return &canaryState{targetEvictions: map[podutil.OwnerKey]time.Time{}}
+}
+
+var state = newCanaryState()
+
+var _ frameworktypes.DeschedulePlugin = &DeschedulerCanary{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	canaryArgs, ok := args.(*DeschedulerCanaryArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type DeschedulerCanaryArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if canaryArgs.Namespaces != nil {
+		includedNamespaces = sets.New(canaryArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(canaryArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(canaryArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &DeschedulerCanary{
+		handle:    handle,
+		args:      canaryArgs,
+		podFilter: podFilter,
+		podLister: handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+		state:     state,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *DeschedulerCanary) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *DeschedulerCanary) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	d.state.Lock()
+	defer d.state.Unlock()
+
+	now := time.Now()
+	if next := d.state.lastRun.Add(d.args.Interval.Duration); now.Before(next) {
+		logger.V(2).Info("Skipping the canary until its next run", "nextRun", next)
+		return nil
+	}
+	d.state.lastRun = now
+	for target, evicted := range d.state.targetEvictions {
+		if now.Sub(evicted) >= d.args.TargetCooldown.Duration {
+			delete(d.state.targetEvictions, target)
+		}
+	}
+
+	candidates, err := podutil.ListPodsOnNodes(nodes, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods: %v", err),
+		}
+	}
+	resolver := podutil.NewOwnerResolver(d.handle.ClientSet())
+	targets, err := resolver.GroupByOwnerRef(ctx, candidates)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error grouping pods by owner: %v", err),
+		}
+	}
+
+	var eligible []podutil.OwnerKey
+	for target := range targets {
+		if _, ok := d.state.targetEvictions[target]; ok {
+			logger.V(3).Info("Skipping workload in cooldown", "kind", target.Kind, "workload", klog.KRef(target.Namespace, target.Name))
+			continue
+		}
+		readyPods, err := d.readyPods(ctx, resolver, target)
+		if err != nil {
+			logger.Error(err, "Unable to count the ready pods of the workload", "kind", target.Kind, "workload", klog.KRef(target.Namespace, target.Name))
+			continue
+		}
+		if readyPods < d.args.MinReplicas {
+			logger.V(3).Info("Skipping workload with too few ready pods", "kind", target.Kind, "workload", klog.KRef(target.Namespace, target.Name), "readyPods", readyPods)
+			continue
+		}
+		eligible = append(eligible, target)
+	}
+
+	rand.Shuffle(len(eligible), func(i, j int) {
+		eligible[i], eligible[j] = eligible[j], eligible[i]
+	})
+	evicted := uint(0)
+	for _, target := range eligible {
+		if evicted >= d.args.SampleSize {
+			break
+		}
+		pods := targets[target]
+		pod := pods[rand.Intn(len(pods))]
+		err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+		if err == nil {
+			evicted++
+			d.state.targetEvictions[target] = now
+			continue
+		}
+		switch err.(type) {
+		case *evictions.EvictionTotalLimitError:
+			return nil
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	logger.V(1).Info("Canary run done", "evicted", evicted, "eligibleWorkloads", len(eligible))
+	return nil
+}
+
+// readyPods counts the ready pods of the workload in the whole cluster,
+// not only the pods matching the selectors of the plugin
+func (d *DeschedulerCanary) readyPods(ctx context.Context, resolver *podutil.OwnerResolver, target podutil.OwnerKey) (uint, error) {
+	pods, err := d.podLister.Pods(target.Namespace).List(labels.Everything())
+	if err != nil {
+		return 0, err
+	}
+	ready := uint(0)
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !utils.IsPodReady(pod) {
+			continue
+		}
+		owner, ok, err := resolver.Owner(ctx, pod)
+		if err != nil {
+			return 0, err
+		}
+		if ok && owner == target {
+			ready++
+		}
+	}
+	return ready, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulercanary
+
+import (
+	"context"
+	"fmt"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDeschedulerCanary(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	objs := []runtime.Object{node1, node2}
+	addWorkload := func(name string, replicas, ready int) {
+		for i := 0; i < replicas; i++ {
+			node := node1
+			if i%2 == 1 {
+				node = node2
+			}
+			objs = append(objs, test.BuildTestPod(fmt.Sprintf("%s-%d", name, i), 100, 0, node.Name, func(pod *v1.Pod) {
+				pod.Labels = map[string]string{"canary": "true"}
+				pod.OwnerReferences = []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       name,
+					UID:        types.UID(name),
+					Controller: utilptr.To(true),
+				}}
+				status := v1.ConditionFalse
+				if i < ready {
+					status = v1.ConditionTrue
+				}
+				pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: status}}
+			}))
+		}
+	}
+	addWorkload("web", 3, 3)
+	addWorkload("api", 2, 2)
+	addWorkload("singleton", 1, 1)
+	addWorkload("degraded", 3, 1)
+	objs = append(objs, test.BuildTestPod("not-selected", 100, 0, node1.Name, test.SetRSOwnerRef))
+
+	web := podutil.OwnerKey{Namespace: "default", APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "web", UID: "web"}
+
+	tests := []struct {
+		description          string
+		args                 DeschedulerCanaryArgs
+		state                func(state *canaryState)
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "A single pod is evicted by default",
+			expectedEvictedCount: 1,
+		},
+		{
+			description:          "At most one pod is evicted per workload",
+			args:                 DeschedulerCanaryArgs{SampleSize: 5},
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Workloads need the minimum number of ready pods",
+			args:                 DeschedulerCanaryArgs{SampleSize: 5, MinReplicas: 3},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Workloads in cooldown are skipped",
+			args:        DeschedulerCanaryArgs{SampleSize: 5},
+			state: func(state *canaryState) {
+				state.targetEvictions[web] = time.Now().Add(-time.Hour)
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Workloads are sampled again once the cooldown is over",
+			args:        DeschedulerCanaryArgs{SampleSize: 5},
+			state: func(state *canaryState) {
+				state.lastRun = time.Now().Add(-48 * time.Hour)
+				state.targetEvictions[web] = time.Now().Add(-48 * time.Hour)
+			},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Nothing is evicted before the next run",
+			args:        DeschedulerCanaryArgs{SampleSize: 5},
+			state: func(state *canaryState) {
+				state.lastRun = time.Now().Add(-time.Minute)
+			},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			fakeClient := fake.NewSimpleClientset(objs...)
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := tc.args
+			args.LabelSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}
+			SetDefaults_DeschedulerCanaryArgs(&args)
+			plugin, err := New(&args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			canary := plugin.(*DeschedulerCanary)
+			canary.state = newCanaryState()
+			if tc.state != nil {
+				tc.state(canary.state)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node1, node2})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
+
+func TestDeschedulerCanaryRunsOnSchedule(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	objs := []runtime.Object{node}
+	for i := 0; i < 2; i++ {
+		for _, name := range []string{"web", "api"} {
+			objs = append(objs, test.BuildTestPod(fmt.Sprintf("%s-%d", name, i), 100, 0, node.Name, func(pod *v1.Pod) {
+				pod.Labels = map[string]string{"canary": "true"}
+				pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: name, UID: types.UID(name), Controller: utilptr.To(true)}}
+				pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+			}))
+		}
+	}
+
+	handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fake.NewSimpleClientset(objs...), evictions.NewOptions(), defaultevictor.DefaultEvictorArgs{}, nil)
+	if err != nil {
+		t.Fatalf("Unable to initialize a framework handle: %v", err)
+	}
+	args := &DeschedulerCanaryArgs{FilteringArgs: api.FilteringArgs{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}}}
+	SetDefaults_DeschedulerCanaryArgs(args)
+
+	// The plugin is built again on every descheduling cycle, the schedule has to hold across instances
+	state := newCanaryState()
+	for i := 0; i < 3; i++ {
+		plugin, err := New(args, handle)
+		if err != nil {
+			t.Fatalf("Unable to initialize the plugin: %v", err)
+		}
+		plugin.(*DeschedulerCanary).state = state
+		plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node})
+	}
+	if podEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected a single canary run, got %v evictions", podEvictor.TotalEvicted())
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulercanary
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	DefaultInterval       = time.Hour
+	DefaultSampleSize     = 1
+	DefaultTargetCooldown = 24 * time.Hour
+	DefaultMinReplicas    = 2
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_DeschedulerCanaryArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_DeschedulerCanaryArgs(obj runtime.Object) {
+	args := obj.(*DeschedulerCanaryArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.Interval == nil {
+		args.Interval = &metav1.Duration{Duration: DefaultInterval}
+	}
+	if args.SampleSize == 0 {
+		args.SampleSize = DefaultSampleSize
+	}
+	if args.TargetCooldown == nil {
+		args.TargetCooldown = &metav1.Duration{Duration: DefaultTargetCooldown}
+	}
+	if args.MinReplicas == 0 {
+		args.MinReplicas = DefaultMinReplicas
+	}
+}
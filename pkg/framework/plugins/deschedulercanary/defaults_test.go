@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulercanary
+
+import (
+	"sigs.k8s.io/descheduler/pkg/api"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetDefaults_DeschedulerCanaryArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "DeschedulerCanaryArgs empty",
+			in:   &DeschedulerCanaryArgs{},
+			want: &DeschedulerCanaryArgs{
+				Interval:       &metav1.Duration{Duration: time.Hour},
+				SampleSize:     1,
+				TargetCooldown: &metav1.Duration{Duration: 24 * time.Hour},
+				MinReplicas:    2,
+			},
+		},
+		{
+			name: "DeschedulerCanaryArgs with value",
+			in: &DeschedulerCanaryArgs{
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+				},
+				Interval:       &metav1.Duration{Duration: 10 * time.Minute},
+				SampleSize:     3,
+				TargetCooldown: &metav1.Duration{Duration: time.Hour},
+				MinReplicas:    5,
+			},
+			want: &DeschedulerCanaryArgs{
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+				},
+				Interval:       &metav1.Duration{Duration: 10 * time.Minute},
+				SampleSize:     3,
+				TargetCooldown: &metav1.Duration{Duration: time.Hour},
+				MinReplicas:    5,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_DeschedulerCanaryArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulercanary
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DeschedulerCanaryArgs holds arguments used to configure DeschedulerCanary plugin.
+type DeschedulerCanaryArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// The label selector selects the pods which can be evicted, it is required
+	// so pods are never sampled from the whole cluster by mistake
+	api.FilteringArgs `json:",inline"`
+	// Interval is the minimum time between two runs of the canary
+	Interval *metav1.Duration `json:"interval"`
+	// SampleSize is the number of pods evicted on each run
+	SampleSize uint `json:"sampleSize"`
+	// TargetCooldown is the minimum time before pods of the same workload are evicted again
+	TargetCooldown *metav1.Duration `json:"targetCooldown"`
+	// MinReplicas is the number of ready pods a workload needs for its pods to be evicted
+	MinReplicas uint `json:"minReplicas"`
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulercanary
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// MaxSampleSize bounds the number of pods the canary evicts on each run
+const MaxSampleSize = 10
+
+// ValidateDeschedulerCanaryArgs validates DeschedulerCanary arguments
+func ValidateDeschedulerCanaryArgs(obj runtime.Object) error {
+	args := obj.(*DeschedulerCanaryArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.LabelSelector == nil {
+		return fmt.Errorf("labelSelector is required")
+	}
+	selector, err := metav1.LabelSelectorAsSelector(args.LabelSelector)
+	if err != nil {
+		return fmt.Errorf("failed to get label selectors from strategy's params: %+v", err)
+	}
+	if selector.Empty() {
+		return fmt.Errorf("labelSelector can not select all the pods")
+	}
+
+	if args.Interval == nil || args.Interval.Duration <= 0 {
+		return fmt.Errorf("interval has to be positive")
+	}
+	if args.SampleSize == 0 || args.SampleSize > MaxSampleSize {
+		return fmt.Errorf("sampleSize should be between 1 and %d, got %d", MaxSampleSize, args.SampleSize)
+	}
+	if args.TargetCooldown != nil && args.TargetCooldown.Duration < 0 {
+		return fmt.Errorf("targetCooldown can not be negative")
+	}
+	if args.MinReplicas < 2 {
+		return fmt.Errorf("minReplicas can not be less than 2, singletons are never evicted")
+	}
+
+	return nil
+}
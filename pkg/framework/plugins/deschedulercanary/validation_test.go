@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deschedulercanary
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateDeschedulerCanaryArgs(t *testing.T) {
+	validArgs := func(mutate func(args *DeschedulerCanaryArgs)) *DeschedulerCanaryArgs {
+		args := &DeschedulerCanaryArgs{
+			FilteringArgs: api.FilteringArgs{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+			},
+			Interval:       &metav1.Duration{Duration: time.Hour},
+			SampleSize:     1,
+			TargetCooldown: &metav1.Duration{Duration: 24 * time.Hour},
+			MinReplicas:    2,
+		}
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *DeschedulerCanaryArgs
+		expectError bool
+	}{
+		{
+			description: "valid args, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "missing label selector, expects error",
+			args:        validArgs(func(args *DeschedulerCanaryArgs) { args.LabelSelector = nil }),
+			expectError: true,
+		},
+		{
+			description: "empty label selector, expects error",
+			args:        validArgs(func(args *DeschedulerCanaryArgs) { args.LabelSelector = &metav1.LabelSelector{} }),
+			expectError: true,
+		},
+		{
+			description: "invalid label selector args, expects errors",
+			args: validArgs(func(args *DeschedulerCanaryArgs) {
+				args.LabelSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Operator: metav1.LabelSelectorOpIn,
+						},
+					},
+				}
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero interval, expects error",
+			args:        validArgs(func(args *DeschedulerCanaryArgs) { args.Interval = &metav1.Duration{} }),
+			expectError: true,
+		},
+		{
+			description: "sample size above the maximum, expects error",
+			args:        validArgs(func(args *DeschedulerCanaryArgs) { args.SampleSize = MaxSampleSize + 1 }),
+			expectError: true,
+		},
+		{
+			description: "negative target cooldown, expects error",
+			args:        validArgs(func(args *DeschedulerCanaryArgs) { args.TargetCooldown = &metav1.Duration{Duration: -time.Minute} }),
+			expectError: true,
+		},
+		{
+			description: "singletons allowed, expects error",
+			args:        validArgs(func(args *DeschedulerCanaryArgs) { args.MinReplicas = 1 }),
+			expectError: true,
+		},
+		{
+			description: "invalid namespaces args, expects error",
+			args: validArgs(func(args *DeschedulerCanaryArgs) {
+				args.Namespaces = &api.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				}
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateDeschedulerCanaryArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
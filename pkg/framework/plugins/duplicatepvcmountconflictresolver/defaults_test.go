@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package duplicatepvcmountconflictresolver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetDefaults_DuplicatePVCMountConflictResolverArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "DuplicatePVCMountConflictResolverArgs empty",
+			in:   &DuplicatePVCMountConflictResolverArgs{},
+			want: &DuplicatePVCMountConflictResolverArgs{
+				NotReadyThreshold: &metav1.Duration{Duration: 5 * time.Minute},
+				BlockedThreshold:  &metav1.Duration{Duration: 2 * time.Minute},
+			},
+		},
+		{
+			name: "DuplicatePVCMountConflictResolverArgs with value",
+			in: &DuplicatePVCMountConflictResolverArgs{
+				NotReadyThreshold: &metav1.Duration{Duration: time.Minute},
+				BlockedThreshold:  &metav1.Duration{Duration: 30 * time.Second},
+			},
+			want: &DuplicatePVCMountConflictResolverArgs{
+				NotReadyThreshold: &metav1.Duration{Duration: time.Minute},
+				BlockedThreshold:  &metav1.Duration{Duration: 30 * time.Second},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_DuplicatePVCMountConflictResolverArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
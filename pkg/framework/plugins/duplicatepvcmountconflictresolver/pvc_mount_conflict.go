@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package duplicatepvcmountconflictresolver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+	utilptr "k8s.io/utils/ptr"
+
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "DuplicatePVCMountConflictResolver"
+
+	// VolumeHeldByNotReadyNodeReason is the reason of the events emitted on the force deleted pods
+	VolumeHeldByNotReadyNodeReason = "VolumeHeldByNotReadyNode"
+)
+
+// DuplicatePVCMountConflictResolver force deletes the pods of not ready nodes holding a ReadWriteOnce
+// volume another pod is blocked on. Such a pod never stops as far as the API server knows, so the volume
+// is never detached from the dead node and the replacement pod stays stuck attaching it. A StatefulSet
+// does not even create the replacement until its terminating pod is gone.
+type DuplicatePVCMountConflictResolver struct {
+	handle     frameworktypes.Handle
+	args       *DuplicatePVCMountConflictResolverArgs
+	podFilter  podutil.FilterFunc
+	podLister  listersv1.PodLister
+	pvcLister  listersv1.PersistentVolumeClaimLister
+	nodeLister listersv1.NodeLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &DuplicatePVCMountConflictResolver{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	conflictArgs, ok := args.(*DuplicatePVCMountConflictResolverArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type DuplicatePVCMountConflictResolverArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if conflictArgs.Namespaces != nil {
+		includedNamespaces = sets.New(conflictArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(conflictArgs.Namespaces.Exclude...)
+	}
+
+	// Pods already being deleted are kept: on a node which is not ready they stay terminating forever
+	podFilter, err := podutil.NewOptions().
+		WithFilter(func(pod *v1.Pod) bool {
+			return pod.DeletionTimestamp != nil || handle.Evictor().Filter(pod)
+		}).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(conflictArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &DuplicatePVCMountConflictResolver{
+		handle:    handle,
+		args:      conflictArgs,
+		podFilter: podFilter,
+		podLister: handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+		// The claims and the not ready nodes are read from the cluster, the cached client of the dry run mode
+		// holds no claim and only the nodes the descheduling cycle started with
+		pvcLister:  handle.ClusterInformerFactory().Core().V1().PersistentVolumeClaims().Lister(),
+		nodeLister: handle.ClusterInformerFactory().Core().V1().Nodes().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *DuplicatePVCMountConflictResolver) Name() string {
+	return PluginName
+}
+
+// stalePod is a pod of another node holding the volume claim a pod is blocked on
+type stalePod struct {
+	pod       *v1.Pod
+	claimName string
+	blocked   *v1.Pod
+}
+
+// blockedSince returns since when the pod waits for its volumes, nil if the pod is not waiting.
+// A scheduled pod waits for its volumes to be attached since it got scheduled, a pod left
+// unscheduled because of a ReadWriteOncePod claim in use waits since its creation.
+func blockedSince(pod *v1.Pod) *metav1.Time {
+	if pod.Status.Phase != v1.PodPending || pod.DeletionTimestamp != nil {
+		return nil
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionTrue {
+			return &condition.LastTransitionTime
+		}
+	}
+	return &pod.CreationTimestamp
+}
+
+// terminatingStatefulSetPod checks whether the pod is a terminating StatefulSet pod, whose replacement
+// is not created until the pod is gone
+func terminatingStatefulSetPod(pod *v1.Pod) bool {
+	if pod.DeletionTimestamp == nil {
+		return false
+	}
+	for _, ownerRef := range pod.OwnerReferences {
+		if ownerRef.Kind == "StatefulSet" {
+			return true
+		}
+	}
+	return false
+}
+
+func claimNames(pod *v1.Pod) []string {
+	var names []string
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			names = append(names, volume.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	return names
+}
+
+// Deschedule extension point implementation for the plugin.
+// Only ready nodes are given to the plugins, the pods of the not ready nodes are listed here.
+func (d *DuplicatePVCMountConflictResolver) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	pods, err := d.podLister.List(labels.Everything())
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods: %v", err),
+		}
+	}
+
+	// claimUsers are the scheduled pods using each claim, keyed by the namespace and the name of the claim
+	claimUsers := map[string][]*v1.Pod{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		for _, claimName := range claimNames(pod) {
+			key := pod.Namespace + "/" + claimName
+			claimUsers[key] = append(claimUsers[key], pod)
+		}
+	}
+
+	now := time.Now()
+	// stalePods are keyed by the pod and the claim it holds
+	stalePods := map[string]stalePod{}
+	for _, pod := range pods {
+		if terminatingStatefulSetPod(pod) && pod.Spec.NodeName != "" && now.Sub(pod.DeletionTimestamp.Time) >= d.args.BlockedThreshold.Duration {
+			for _, claimName := range claimNames(pod) {
+				stalePods[string(pod.UID)+"/"+claimName] = stalePod{pod: pod, claimName: claimName}
+			}
+			continue
+		}
+		since := blockedSince(pod)
+		if since == nil || now.Sub(since.Time) < d.args.BlockedThreshold.Duration {
+			continue
+		}
+		for _, claimName := range claimNames(pod) {
+			for _, user := range claimUsers[pod.Namespace+"/"+claimName] {
+				// A ReadWriteOnce volume is shared by the pods of a single node
+				if user.UID == pod.UID || user.Spec.NodeName == pod.Spec.NodeName {
+					continue
+				}
+				if _, ok := stalePods[string(user.UID)+"/"+claimName]; !ok {
+					stalePods[string(user.UID)+"/"+claimName] = stalePod{pod: user, claimName: claimName, blocked: pod}
+				}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(stalePods))
+	for key := range stalePods {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	singleNodeClaims := map[string]bool{}
+	notReadyNodes := map[string]*v1.Node{}
+	deleted := sets.New[types.UID]()
+	for _, key := range keys {
+		stale := stalePods[key]
+		if deleted.Has(stale.pod.UID) || !d.podFilter(stale.pod) {
+			continue
+		}
+		claimKey := stale.pod.Namespace + "/" + stale.claimName
+		singleNode, ok := singleNodeClaims[claimKey]
+		if !ok {
+			singleNode = d.singleNodeClaim(ctx, stale.pod.Namespace, stale.claimName)
+			singleNodeClaims[claimKey] = singleNode
+		}
+		if !singleNode {
+			continue
+		}
+		node, ok := notReadyNodes[stale.pod.Spec.NodeName]
+		if !ok {
+			node = d.notReadyNode(ctx, stale.pod.Spec.NodeName)
+			notReadyNodes[stale.pod.Spec.NodeName] = node
+		}
+		if node == nil {
+			continue
+		}
+		logger.V(1).Info("Force deleting a pod of a not ready node holding a ReadWriteOnce volume", "pod", klog.KObj(stale.pod), "node", klog.KObj(node), "claim", stale.claimName, "blockedPod", klog.KObj(stale.blocked))
+		d.forceDelete(ctx, stale, node)
+		deleted.Insert(stale.pod.UID)
+	}
+	return nil
+}
+
+// singleNodeClaim checks whether the volume of the claim can only be mounted by the pods of a single node
+func (d *DuplicatePVCMountConflictResolver) singleNodeClaim(ctx context.Context, namespace, claimName string) bool {
+	pvc, err := d.pvcLister.PersistentVolumeClaims(namespace).Get(claimName)
+	if err != nil {
+		klog.FromContext(ctx).V(3).Info("Unable to get persistent volume claim", "claim", klog.KRef(namespace, claimName), "err", err)
+		return false
+	}
+	accessModes := pvc.Status.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = pvc.Spec.AccessModes
+	}
+	for _, accessMode := range accessModes {
+		if accessMode == v1.ReadWriteMany || accessMode == v1.ReadOnlyMany {
+			return false
+		}
+	}
+	return len(accessModes) > 0
+}
+
+// notReadyNode verifies against the cluster that the node has not been ready for the threshold,
+// returning nil otherwise. A node which is gone is left to the pod garbage collector.
+func (d *DuplicatePVCMountConflictResolver) notReadyNode(ctx context.Context, nodeName string) *v1.Node {
+	logger := klog.FromContext(ctx)
+	node, err := d.nodeLister.Get(nodeName)
+	if err != nil {
+		logger.V(3).Info("Unable to get node", "node", nodeName, "err", err)
+		return nil
+	}
+	since := nodeutil.NotReadySince(node)
+	if since == nil || time.Since(since.Time) < d.args.NotReadyThreshold.Duration {
+		logger.V(3).Info("Node holding a ReadWriteOnce volume is ready or recently not ready, skipping", "node", klog.KObj(node))
+		return nil
+	}
+	return node
+}
+
+// forceDelete deletes the pod without waiting for the kubelet to confirm its containers are stopped,
+// which never happens while the node is not ready.
+func (d *DuplicatePVCMountConflictResolver) forceDelete(ctx context.Context, stale stalePod, node *v1.Node) {
+	logger := klog.FromContext(ctx)
+	err := d.handle.ClientSet().CoreV1().Pods(stale.pod.Namespace).Delete(ctx, stale.pod.Name, metav1.DeleteOptions{
+		GracePeriodSeconds: utilptr.To[int64](0),
+		Preconditions:      metav1.NewUIDPreconditions(string(stale.pod.UID)),
+	})
+	if err != nil {
+		logger.Error(err, "Unable to force delete pod", "pod", klog.KObj(stale.pod), "node", klog.KObj(node))
+		return
+	}
+	logger.V(1).Info("Force deleted pod", "pod", klog.KObj(stale.pod), "node", klog.KObj(node))
+	d.handle.EventRecorder().Eventf(stale.pod, node, v1.EventTypeWarning, VolumeHeldByNotReadyNodeReason, "ForceDeleted",
+		"pod force deleted by sigs.k8s.io/descheduler as it holds volume claim %v on not ready node %v", stale.claimName, node.Name)
+}
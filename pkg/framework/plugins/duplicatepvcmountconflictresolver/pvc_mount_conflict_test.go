@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package duplicatepvcmountconflictresolver
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildNotReadyNode(name string, notReadyFor time.Duration) *v1.Node {
+	return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+		node.Status.Conditions = []v1.NodeCondition{{
+			Type:               v1.NodeReady,
+			Status:             v1.ConditionUnknown,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-notReadyFor)),
+		}}
+	})
+}
+
+func buildClaim(name string, accessMode v1.PersistentVolumeAccessMode) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1.PersistentVolumeClaimSpec{AccessModes: []v1.PersistentVolumeAccessMode{accessMode}},
+	}
+}
+
+func withClaim(claimName string) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: "data",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			},
+		})
+	}
+}
+
+// buildBlockedPod builds a pod scheduled for the given time and still waiting for the volume of the claim
+func buildBlockedPod(name, nodeName, claimName string, scheduledFor time.Duration) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		withClaim(claimName)(pod)
+		pod.Status.Phase = v1.PodPending
+		pod.Status.Conditions = []v1.PodCondition{{
+			Type:               v1.PodScheduled,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-scheduledFor)),
+		}}
+	})
+}
+
+func TestDuplicatePVCMountConflictResolver(t *testing.T) {
+	deadNode := buildNotReadyNode("n1", 10*time.Minute)
+	readyNode := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	recentlyNotReadyNode := buildNotReadyNode("n3", time.Minute)
+
+	objs := []runtime.Object{
+		deadNode, readyNode, recentlyNotReadyNode,
+		buildClaim("data", v1.ReadWriteOnce),
+		buildClaim("shared", v1.ReadWriteMany),
+		buildClaim("recent", v1.ReadWriteOnce),
+		buildClaim("fresh", v1.ReadWriteOncePod),
+		buildClaim("alive", v1.ReadWriteOnce),
+		buildClaim("web-0", v1.ReadWriteOnce),
+		// The volume of a dead node is held back from the pod replacing its pod
+		test.BuildTestPod("stale", 100, 0, "n1", withClaim("data")),
+		buildBlockedPod("replacement", "n2", "data", 5*time.Minute),
+		// The volume can be mounted by several nodes, the pod is blocked on something else
+		test.BuildTestPod("shared-holder", 100, 0, "n1", withClaim("shared")),
+		buildBlockedPod("shared-blocked", "n2", "shared", 5*time.Minute),
+		// The node is not ready for less than the threshold
+		test.BuildTestPod("recent-holder", 100, 0, "n3", withClaim("recent")),
+		buildBlockedPod("recent-blocked", "n2", "recent", 5*time.Minute),
+		// The pod is blocked for less than the threshold
+		test.BuildTestPod("fresh-holder", 100, 0, "n1", withClaim("fresh")),
+		buildBlockedPod("fresh-blocked", "n2", "fresh", 10*time.Second),
+		// The volume is held by a ready node
+		test.BuildTestPod("alive-holder", 100, 0, "n2", withClaim("alive")),
+		buildBlockedPod("alive-blocked", "n1", "alive", 5*time.Minute),
+		// The StatefulSet does not recreate its pod until the terminating pod is gone
+		test.BuildTestPod("web-0", 100, 0, "n1", func(pod *v1.Pod) {
+			withClaim("web-0")(pod)
+			pod.OwnerReferences = nil
+			test.SetSSOwnerRef(pod)
+			pod.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-5 * time.Minute)}
+		}),
+	}
+
+	tests := []struct {
+		description     string
+		args            DuplicatePVCMountConflictResolverArgs
+		expectedDeleted []string
+	}{
+		{
+			description:     "Pods of not ready nodes holding the volume of a blocked pod are force deleted",
+			args:            DuplicatePVCMountConflictResolverArgs{},
+			expectedDeleted: []string{"stale", "web-0"},
+		},
+		{
+			description: "Pods blocked for less than the threshold are considered",
+			args: DuplicatePVCMountConflictResolverArgs{
+				BlockedThreshold: &metav1.Duration{Duration: time.Second},
+			},
+			expectedDeleted: []string{"fresh-holder", "stale", "web-0"},
+		},
+		{
+			description: "Nodes not ready for less than the threshold are considered",
+			args: DuplicatePVCMountConflictResolverArgs{
+				NotReadyThreshold: &metav1.Duration{Duration: time.Second},
+			},
+			expectedDeleted: []string{"recent-holder", "stale", "web-0"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			fakeClient := fake.NewSimpleClientset(objs...)
+			var deleted []string
+			fakeClient.PrependReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				deleteAction := action.(core.DeleteAction)
+				if gracePeriod := deleteAction.GetDeleteOptions().GracePeriodSeconds; gracePeriod == nil || *gracePeriod != 0 {
+					t.Errorf("Expected pod %v to be deleted with a zero grace period", deleteAction.GetName())
+				}
+				deleted = append(deleted, deleteAction.GetName())
+				return true, nil, nil
+			})
+
+			handle, _, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := tc.args
+			SetDefaults_DuplicatePVCMountConflictResolverArgs(&args)
+			plugin, err := New(&args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			// Start the PersistentVolumeClaim and Node informers requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			status := plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{readyNode})
+			if status != nil && status.Err != nil {
+				t.Fatalf("Unexpected error: %v", status.Err)
+			}
+			sort.Strings(deleted)
+			if diff := cmp.Diff(tc.expectedDeleted, deleted); diff != "" {
+				t.Errorf("Unexpected deleted pods (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package duplicatepvcmountconflictresolver
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateDuplicatePVCMountConflictResolverArgs validates DuplicatePVCMountConflictResolver arguments
+func ValidateDuplicatePVCMountConflictResolverArgs(obj runtime.Object) error {
+	args := obj.(*DuplicatePVCMountConflictResolverArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.NotReadyThreshold != nil && args.NotReadyThreshold.Duration < 0 {
+		return fmt.Errorf("notReadyThreshold can not be negative")
+	}
+	if args.BlockedThreshold != nil && args.BlockedThreshold.Duration < 0 {
+		return fmt.Errorf("blockedThreshold can not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enforcemaxpodspernamespacepernode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "EnforceMaxPodsPerNamespacePerNode"
+
+// EnforceMaxPodsPerNamespacePerNode evicts the pods of a namespace from the nodes running
+// more of them than allowed, so losing a node does not take down too many pods of a single
+// tenant, even when the tenants do not set topology spread constraints themselves.
+type EnforceMaxPodsPerNamespacePerNode struct {
+	handle    frameworktypes.Handle
+	args      *EnforceMaxPodsPerNamespacePerNodeArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &EnforceMaxPodsPerNamespacePerNode{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	maxPodsArgs, ok := args.(*EnforceMaxPodsPerNamespacePerNodeArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type EnforceMaxPodsPerNamespacePerNodeArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if maxPodsArgs.Namespaces != nil {
+		includedNamespaces = sets.New(maxPodsArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(maxPodsArgs.Namespaces.Exclude...)
+	}
+
+	// All the selected pods count towards the limit, evictable or not
+	podFilter, err := podutil.NewOptions().
+		WithFilter(func(pod *v1.Pod) bool {
+			return pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed
+		}).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(maxPodsArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &EnforceMaxPodsPerNamespacePerNode{
+		handle:    handle,
+		args:      maxPodsArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *EnforceMaxPodsPerNamespacePerNode) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *EnforceMaxPodsPerNamespacePerNode) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	podsByNode := make(map[string]map[string][]*v1.Pod, len(nodes))
+	namespacePods := map[string]int{}
+	for _, node := range nodes {
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		byNamespace := map[string][]*v1.Pod{}
+		for _, pod := range pods {
+			byNamespace[pod.Namespace] = append(byNamespace[pod.Namespace], pod)
+			namespacePods[pod.Namespace]++
+		}
+		podsByNode[node.Name] = byNamespace
+	}
+
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		byNamespace := podsByNode[node.Name]
+		namespaces := make([]string, 0, len(byNamespace))
+		for namespace := range byNamespace {
+			namespaces = append(namespaces, namespace)
+		}
+		sort.Strings(namespaces)
+
+	loop:
+		for _, namespace := range namespaces {
+			limit, err := d.maxPodsPerNode(namespacePods[namespace])
+			if err != nil {
+				return &frameworktypes.Status{
+					Err: fmt.Errorf("error computing the maximum number of pods per node: %v", err),
+				}
+			}
+			excess := len(byNamespace[namespace]) - limit
+			if excess <= 0 {
+				continue
+			}
+			logger.V(1).Info("Node runs too many pods of a namespace", "node", klog.KObj(node), "namespace", namespace, "pods", len(byNamespace[namespace]), "maxPodsPerNode", limit)
+
+			var evictable []*v1.Pod
+			for _, pod := range byNamespace[namespace] {
+				if d.handle.Evictor().Filter(pod) {
+					evictable = append(evictable, pod)
+				}
+			}
+			podutil.SortPodsBasedOnPriorityLowToHigh(evictable)
+
+			for _, pod := range evictable {
+				if excess <= 0 {
+					break
+				}
+				if !d.handle.Evictor().PreEvictionFilter(pod) {
+					continue
+				}
+				err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+				if err == nil {
+					excess--
+					continue
+				}
+				switch err.(type) {
+				case *evictions.EvictionNodeLimitError:
+					break loop
+				case *evictions.EvictionTotalLimitError:
+					return nil
+				default:
+					logger.Error(err, "Eviction failed")
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// maxPodsPerNode returns the number of pods a node may run for a namespace running
+// the given number of pods. A node may always run at least one pod of a namespace.
+func (d *EnforceMaxPodsPerNamespacePerNode) maxPodsPerNode(namespacePods int) (int, error) {
+	limit, err := intstr.GetScaledValueFromIntOrPercent(d.args.MaxPodsPerNode, namespacePods, true)
+	if err != nil {
+		return 0, err
+	}
+	return max(limit, 1), nil
+}
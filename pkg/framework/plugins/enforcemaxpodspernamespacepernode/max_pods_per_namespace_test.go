@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enforcemaxpodspernamespacepernode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+// buildPods returns the given number of pods of the namespace assigned to the node
+func buildPods(namespace, node string, count int, apply func(*v1.Pod)) []runtime.Object {
+	var pods []runtime.Object
+	for i := 0; i < count; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("%s-%s-p%d", namespace, node, i), 100, 0, node, func(pod *v1.Pod) {
+			pod.Namespace = namespace
+			test.SetRSOwnerRef(pod)
+			if apply != nil {
+				apply(pod)
+			}
+		}))
+	}
+	return pods
+}
+
+func TestEnforceMaxPodsPerNamespacePerNode(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, nil)
+	n2 := test.BuildTestNode("n2", 4000, 3000, 20, nil)
+	maxPods := intstr.FromInt32(2)
+	halfPods := intstr.FromString("50%")
+	fewPods := intstr.FromString("10%")
+	bare := func(pod *v1.Pod) {
+		pod.Name += "-bare"
+		pod.OwnerReferences = nil
+	}
+	join := func(pods ...[]runtime.Object) []runtime.Object {
+		var objs []runtime.Object
+		for _, p := range pods {
+			objs = append(objs, p...)
+		}
+		return objs
+	}
+
+	tests := []struct {
+		description          string
+		args                 EnforceMaxPodsPerNamespacePerNodeArgs
+		pods                 []runtime.Object
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "Pods above the absolute limit are evicted",
+			args:                 EnforceMaxPodsPerNamespacePerNodeArgs{MaxPodsPerNode: &maxPods},
+			pods:                 join(buildPods("ns1", "n1", 5, nil), buildPods("ns1", "n2", 1, nil)),
+			expectedEvictedCount: 3,
+		},
+		{
+			description:          "Pods of each namespace are counted separately",
+			args:                 EnforceMaxPodsPerNamespacePerNodeArgs{MaxPodsPerNode: &maxPods},
+			pods:                 join(buildPods("ns1", "n1", 2, nil), buildPods("ns2", "n1", 3, nil)),
+			expectedEvictedCount: 1,
+		},
+		{
+			description:          "Percentage of the pods of the namespace on all the nodes",
+			args:                 EnforceMaxPodsPerNamespacePerNodeArgs{MaxPodsPerNode: &halfPods},
+			pods:                 join(buildPods("ns1", "n1", 5, nil), buildPods("ns1", "n2", 1, nil)),
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Nodes may always run one pod of a namespace",
+			args:                 EnforceMaxPodsPerNamespacePerNodeArgs{MaxPodsPerNode: &fewPods},
+			pods:                 join(buildPods("ns1", "n1", 2, nil), buildPods("ns1", "n2", 1, nil)),
+			expectedEvictedCount: 1,
+		},
+		{
+			description:          "Pods which can not be evicted count towards the limit",
+			args:                 EnforceMaxPodsPerNamespacePerNodeArgs{MaxPodsPerNode: &maxPods},
+			pods:                 join(buildPods("ns1", "n1", 3, bare), buildPods("ns1", "n1", 2, nil)),
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Terminated pods do not count towards the limit",
+			args:        EnforceMaxPodsPerNamespacePerNodeArgs{MaxPodsPerNode: &maxPods},
+			pods: join(buildPods("ns1", "n1", 2, nil), buildPods("ns1", "n1", 3, func(pod *v1.Pod) {
+				pod.Name += "-succeeded"
+				pod.Status.Phase = v1.PodSucceeded
+			})),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of excluded namespaces are not evicted",
+			args: EnforceMaxPodsPerNamespacePerNodeArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"ns1"}},
+				},
+				MaxPodsPerNode: &maxPods,
+			},
+			pods:                 join(buildPods("ns1", "n1", 5, nil), buildPods("ns2", "n1", 3, nil)),
+			expectedEvictedCount: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{n1, n2}
+			objs := append([]runtime.Object{n1, n2}, tc.pods...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
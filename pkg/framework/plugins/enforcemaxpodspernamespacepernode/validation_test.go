@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enforcemaxpodspernamespacepernode
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateEnforceMaxPodsPerNamespacePerNodeArgs(t *testing.T) {
+	validArgs := func(mutate func(*EnforceMaxPodsPerNamespacePerNodeArgs)) *EnforceMaxPodsPerNamespacePerNodeArgs {
+		maxPods := intstr.FromInt32(3)
+		args := &EnforceMaxPodsPerNamespacePerNodeArgs{MaxPodsPerNode: &maxPods}
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+	maxPods := func(value intstr.IntOrString) func(*EnforceMaxPodsPerNamespacePerNodeArgs) {
+		return func(args *EnforceMaxPodsPerNamespacePerNodeArgs) {
+			args.MaxPodsPerNode = &value
+		}
+	}
+
+	testCases := []struct {
+		description string
+		args        *EnforceMaxPodsPerNamespacePerNodeArgs
+		expectError bool
+	}{
+		{
+			description: "valid absolute limit, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "valid percentage, no errors",
+			args:        validArgs(maxPods(intstr.FromString("25%"))),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *EnforceMaxPodsPerNamespacePerNodeArgs) {
+				args.Namespaces = &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects error",
+			args: validArgs(func(args *EnforceMaxPodsPerNamespacePerNodeArgs) {
+				args.LabelSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+				}
+			}),
+			expectError: true,
+		},
+		{
+			description: "missing limit, expects error",
+			args: validArgs(func(args *EnforceMaxPodsPerNamespacePerNodeArgs) {
+				args.MaxPodsPerNode = nil
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero limit, expects error",
+			args:        validArgs(maxPods(intstr.FromInt32(0))),
+			expectError: true,
+		},
+		{
+			description: "not a percentage, expects error",
+			args:        validArgs(maxPods(intstr.FromString("3"))),
+			expectError: true,
+		},
+		{
+			description: "percentage above 100%, expects error",
+			args:        validArgs(maxPods(intstr.FromString("120%"))),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateEnforceMaxPodsPerNamespacePerNodeArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
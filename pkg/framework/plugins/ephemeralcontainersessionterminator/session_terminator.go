@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ephemeralcontainersessionterminator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "EphemeralContainerSessionTerminator"
+
+	// EphemeralContainerSessionExpiredReason is the reason of the events emitted on the pods in report only mode
+	EphemeralContainerSessionExpiredReason = "EphemeralContainerSessionExpired"
+
+	actionReported = "Reported"
+)
+
+// EphemeralContainerSessionTerminator evicts, or only reports, pods an ephemeral container, e.g. a
+// kubectl debug session, got attached to for longer than the configured duration. Ephemeral containers
+// can not be removed from a pod, evicting it replaces the pod with a pristine one.
+type EphemeralContainerSessionTerminator struct {
+	handle    frameworktypes.Handle
+	args      *EphemeralContainerSessionTerminatorArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &EphemeralContainerSessionTerminator{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	sessionArgs, ok := args.(*EphemeralContainerSessionTerminatorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type EphemeralContainerSessionTerminatorArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if sessionArgs.Namespaces != nil {
+		includedNamespaces = sets.New(sessionArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(sessionArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(sessionArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &EphemeralContainerSessionTerminator{
+		handle: handle,
+		args:   sessionArgs,
+		podFilter: func(pod *v1.Pod) bool {
+			return len(pod.Spec.EphemeralContainers) > 0 && podFilter(pod)
+		},
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *EphemeralContainerSessionTerminator) Name() string {
+	return PluginName
+}
+
+// sessionStart returns when the first ephemeral container of the pod started, along with its name.
+// Ephemeral containers which did not start yet are ignored.
+func sessionStart(pod *v1.Pod) (time.Time, string) {
+	var start time.Time
+	var name string
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		var startedAt time.Time
+		switch {
+		case status.State.Running != nil:
+			startedAt = status.State.Running.StartedAt.Time
+		case status.State.Terminated != nil:
+			startedAt = status.State.Terminated.StartedAt.Time
+		case status.LastTerminationState.Terminated != nil:
+			startedAt = status.LastTerminationState.Terminated.StartedAt.Time
+		}
+		if startedAt.IsZero() {
+			continue
+		}
+		if start.IsZero() || startedAt.Before(start) {
+			start, name = startedAt, status.Name
+		}
+	}
+	return start, name
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *EphemeralContainerSessionTerminator) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	now := time.Now()
+	for _, node := range nodes {
+		logger.V(1).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			start, container := sessionStart(pod)
+			if start.IsZero() {
+				continue
+			}
+			session := now.Sub(start)
+			if session <= d.args.MaxSessionDuration.Duration {
+				continue
+			}
+			logger.V(2).Info("Pod has an ephemeral container attached for longer than the maximum session duration", "pod", klog.KObj(pod), "container", container, "session", session.Round(time.Second), "maxSessionDuration", d.args.MaxSessionDuration.Duration)
+			if d.args.ReportOnly {
+				d.handle.EventRecorder().Eventf(pod, nil, v1.EventTypeWarning, EphemeralContainerSessionExpiredReason, actionReported,
+					"Ephemeral container %s attached %s ago, above the maximum session duration of %s", container, session.Round(time.Second), d.args.MaxSessionDuration.Duration)
+				continue
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
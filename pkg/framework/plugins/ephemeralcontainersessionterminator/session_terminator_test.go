@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ephemeralcontainersessionterminator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestEphemeralContainerSessionTerminator(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+
+	debuggedPod := func(name, namespace string, state v1.ContainerState) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			pod.Namespace = namespace
+			pod.Spec.EphemeralContainers = []v1.EphemeralContainer{{
+				EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger", Image: "busybox"},
+			}}
+			pod.Status.EphemeralContainerStatuses = []v1.ContainerStatus{{Name: "debugger", State: state}}
+		})
+	}
+	running := func(startedAgo time.Duration) v1.ContainerState {
+		return v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now().Add(-startedAgo))}}
+	}
+	terminated := func(startedAgo time.Duration) v1.ContainerState {
+		return v1.ContainerState{Terminated: &v1.ContainerStateTerminated{StartedAt: metav1.NewTime(time.Now().Add(-startedAgo))}}
+	}
+
+	pods := []runtime.Object{
+		// not debugged
+		test.BuildTestPod("p1", 100, 0, node1.Name, test.SetRSOwnerRef),
+		// debug session started recently
+		debuggedPod("p2", "production", running(10*time.Minute)),
+		// debug session started long ago, still running
+		debuggedPod("p3", "production", running(2*time.Hour)),
+		// debug session started long ago, terminated since
+		debuggedPod("p4", "production", terminated(3*time.Hour)),
+		// ephemeral container not started yet
+		debuggedPod("p5", "production", v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}),
+		// debug session started long ago, outside of the production namespace
+		debuggedPod("p6", "staging", running(2*time.Hour)),
+	}
+
+	tests := []struct {
+		description          string
+		args                 EphemeralContainerSessionTerminatorArgs
+		expectedEvictedCount uint
+		expectedEvents       int
+	}{
+		{
+			description:          "Pods debugged for longer than the maximum session duration are evicted",
+			args:                 EphemeralContainerSessionTerminatorArgs{MaxSessionDuration: &metav1.Duration{Duration: time.Hour}},
+			expectedEvictedCount: 3,
+		},
+		{
+			description: "Only pods of the included namespaces are evicted",
+			args: EphemeralContainerSessionTerminatorArgs{
+				FilteringArgs:      api.FilteringArgs{Namespaces: &api.Namespaces{Include: []string{"production"}}},
+				MaxSessionDuration: &metav1.Duration{Duration: time.Hour},
+			},
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Longer maximum session duration",
+			args:                 EphemeralContainerSessionTerminatorArgs{MaxSessionDuration: &metav1.Duration{Duration: 150 * time.Minute}},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods are only reported in report only mode",
+			args: EphemeralContainerSessionTerminatorArgs{
+				MaxSessionDuration: &metav1.Duration{Duration: time.Hour},
+				ReportOnly:         true,
+			},
+			expectedEvictedCount: 0,
+			expectedEvents:       3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := append([]runtime.Object{node1}, pods...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+			eventRecorder := events.NewFakeRecorder(10)
+			handle.EventRecorderImpl = eventRecorder
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node1})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+			if len(eventRecorder.Events) != tc.expectedEvents {
+				t.Errorf("Expected %v events, got %v", tc.expectedEvents, len(eventRecorder.Events))
+			}
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if !strings.Contains(event, EphemeralContainerSessionExpiredReason) {
+					t.Errorf("Unexpected event: %v", event)
+				}
+			}
+		})
+	}
+}
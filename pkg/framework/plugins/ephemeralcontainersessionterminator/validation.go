@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ephemeralcontainersessionterminator
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateEphemeralContainerSessionTerminatorArgs validates EphemeralContainerSessionTerminator arguments
+func ValidateEphemeralContainerSessionTerminatorArgs(obj runtime.Object) error {
+	args := obj.(*EphemeralContainerSessionTerminatorArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.MaxSessionDuration != nil && args.MaxSessionDuration.Duration <= 0 {
+		return fmt.Errorf("maxSessionDuration must be greater than zero")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ephemeralcontainersessionterminator
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateEphemeralContainerSessionTerminatorArgs(t *testing.T) {
+	validArgs := func(mutate func(*EphemeralContainerSessionTerminatorArgs)) *EphemeralContainerSessionTerminatorArgs {
+		args := &EphemeralContainerSessionTerminatorArgs{}
+		SetDefaults_EphemeralContainerSessionTerminatorArgs(args)
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *EphemeralContainerSessionTerminatorArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *EphemeralContainerSessionTerminatorArgs) {
+				args.Namespaces = &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero max session duration, expects error",
+			args: validArgs(func(args *EphemeralContainerSessionTerminatorArgs) {
+				args.MaxSessionDuration = &metav1.Duration{}
+			}),
+			expectError: true,
+		},
+		{
+			description: "negative max session duration, expects error",
+			args: validArgs(func(args *EphemeralContainerSessionTerminatorArgs) {
+				args.MaxSessionDuration = &metav1.Duration{Duration: -time.Minute}
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateEphemeralContainerSessionTerminatorArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictfornodecertificateorkubeletversionskew
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestSetDefaults_EvictForNodeCertificateOrKubeletVersionSkewArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "EvictForNodeCertificateOrKubeletVersionSkewArgs empty",
+			in:   &EvictForNodeCertificateOrKubeletVersionSkewArgs{},
+			want: &EvictForNodeCertificateOrKubeletVersionSkewArgs{
+				MaxMinorVersionSkew:   utilptr.To[uint](3),
+				MaxPodsToEvictPerNode: utilptr.To[uint](2),
+			},
+		},
+		{
+			name: "EvictForNodeCertificateOrKubeletVersionSkewArgs with value",
+			in: &EvictForNodeCertificateOrKubeletVersionSkewArgs{
+				ControlPlaneVersion:   "v1.30.0",
+				MaxMinorVersionSkew:   utilptr.To[uint](2),
+				MaxPodsToEvictPerNode: utilptr.To[uint](5),
+			},
+			want: &EvictForNodeCertificateOrKubeletVersionSkewArgs{
+				ControlPlaneVersion:   "v1.30.0",
+				MaxMinorVersionSkew:   utilptr.To[uint](2),
+				MaxPodsToEvictPerNode: utilptr.To[uint](5),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_EvictForNodeCertificateOrKubeletVersionSkewArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictfornodecertificateorkubeletversionskew
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "EvictForNodeCertificateOrKubeletVersionSkew"
+
+	// KubeletVersionSkewReason is the reason of the events reported on the skewed nodes
+	KubeletVersionSkewReason = "KubeletVersionSkew"
+
+	actionReported = "Reported"
+)
+
+// EvictForNodeCertificateOrKubeletVersionSkew progressively moves the pods off the nodes whose kubelet
+// version is out of the skew supported against the control plane, a few pods per node and cycle, so
+// the stragglers left behind by an upgrade are empty by the time they get forcibly replaced.
+type EvictForNodeCertificateOrKubeletVersionSkew struct {
+	handle              frameworktypes.Handle
+	args                *EvictForNodeCertificateOrKubeletVersionSkewArgs
+	podFilter           podutil.FilterFunc
+	controlPlaneVersion *version.Version
+}
+
+var _ frameworktypes.DeschedulePlugin = &EvictForNodeCertificateOrKubeletVersionSkew{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	skewArgs, ok := args.(*EvictForNodeCertificateOrKubeletVersionSkewArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type EvictForNodeCertificateOrKubeletVersionSkewArgs, got %T", args)
+	}
+
+	controlPlaneVersion, err := version.ParseGeneric(skewArgs.ControlPlaneVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid control plane version: %v", err)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if skewArgs.Namespaces != nil {
+		includedNamespaces = sets.New(skewArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(skewArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(skewArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &EvictForNodeCertificateOrKubeletVersionSkew{
+		handle:              handle,
+		args:                skewArgs,
+		podFilter:           podFilter,
+		controlPlaneVersion: controlPlaneVersion,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *EvictForNodeCertificateOrKubeletVersionSkew) Name() string {
+	return PluginName
+}
+
+// minorVersionSkew returns how many minor versions the kubelet is older than the control plane.
+// Kubelets of another major version or newer than the control plane are never supported.
+func (d *EvictForNodeCertificateOrKubeletVersionSkew) minorVersionSkew(kubeletVersion *version.Version) (int, bool) {
+	if kubeletVersion.Major() != d.controlPlaneVersion.Major() || kubeletVersion.Minor() > d.controlPlaneVersion.Minor() {
+		return 0, false
+	}
+	skew := int(d.controlPlaneVersion.Minor() - kubeletVersion.Minor())
+	return skew, skew <= int(*d.args.MaxMinorVersionSkew)
+}
+
+type skewedNode struct {
+	node           *v1.Node
+	kubeletVersion string
+	skew           int
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *EvictForNodeCertificateOrKubeletVersionSkew) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+
+	var skewed []skewedNode
+	var supported []*v1.Node
+	for _, node := range nodes {
+		kubeletVersion, err := version.ParseGeneric(node.Status.NodeInfo.KubeletVersion)
+		if err != nil {
+			logger.V(2).Info("Unable to parse the kubelet version of the node", "node", klog.KObj(node), "kubeletVersion", node.Status.NodeInfo.KubeletVersion, "err", err)
+			continue
+		}
+		skew, ok := d.minorVersionSkew(kubeletVersion)
+		if ok {
+			supported = append(supported, node)
+			continue
+		}
+		skewed = append(skewed, skewedNode{node: node, kubeletVersion: node.Status.NodeInfo.KubeletVersion, skew: skew})
+	}
+	if len(skewed) == 0 {
+		return nil
+	}
+
+	// The most outdated nodes are emptied first. Kubelets newer than the control plane or of another major
+	// version have no minor version skew and come before all the others.
+	sort.SliceStable(skewed, func(i, j int) bool {
+		if skewed[i].skew != skewed[j].skew {
+			return skewed[i].skew == 0 || (skewed[j].skew != 0 && skewed[i].skew > skewed[j].skew)
+		}
+		return skewed[i].node.Name < skewed[j].node.Name
+	})
+
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	for _, s := range skewed {
+		logger.V(1).Info("Processing node out of the supported kubelet version skew", "node", klog.KObj(s.node), "kubeletVersion", s.kubeletVersion, "controlPlaneVersion", d.args.ControlPlaneVersion)
+		d.handle.EventRecorder().Eventf(s.node, nil, v1.EventTypeWarning, KubeletVersionSkewReason, actionReported,
+			"Kubelet version %s is out of the supported skew against the control plane version %s", s.kubeletVersion, d.args.ControlPlaneVersion)
+
+		pods, err := podutil.ListPodsOnANode(s.node.Name, getPodsAssignedToNode, d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		// Move the least important pods first in case the node limit is reached
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+		var evicted uint
+	loop:
+		for _, pod := range pods {
+			if evicted >= *d.args.MaxPodsToEvictPerNode {
+				break
+			}
+			// Moving the pod to another skewed node would only postpone its eviction
+			if !nodeutil.PodFitsAnyNode(getPodsAssignedToNode, pod, supported) {
+				logger.V(2).Info("Pod does not fit any node with a supported kubelet version", "pod", klog.KObj(pod))
+				continue
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				evicted++
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictfornodecertificateorkubeletversionskew
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestEvictForNodeCertificateOrKubeletVersionSkew(t *testing.T) {
+	buildNode := func(name, kubeletVersion string) *v1.Node {
+		return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+			node.Status.NodeInfo.KubeletVersion = kubeletVersion
+		})
+	}
+	outdated := buildNode("outdated", "v1.26.3")
+	oldest := buildNode("oldest", "v1.25.0")
+	newer := buildNode("newer", "v1.31.0")
+	supported := buildNode("supported", "v1.27.1-eks-1234")
+	current := buildNode("current", "v1.30.1")
+	unknown := buildNode("unknown", "unknown")
+	full := test.BuildTestNode("full", 100, 3000, 10, func(node *v1.Node) {
+		node.Status.NodeInfo.KubeletVersion = "v1.30.1"
+	})
+
+	buildPod := func(name, nodeName string, priority int32) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			test.SetRSOwnerRef(pod)
+			test.SetPodPriority(pod, priority)
+		})
+	}
+
+	tests := []struct {
+		description           string
+		nodes                 []*v1.Node
+		pods                  []*v1.Pod
+		maxMinorVersionSkew   uint
+		maxPodsToEvictPerNode uint
+		expectedEvicted       []string
+	}{
+		{
+			description: "pods on nodes out of the skew are evicted, lowest priority first",
+			nodes:       []*v1.Node{outdated, supported, current, unknown},
+			pods: []*v1.Pod{
+				buildPod("p1", "outdated", 100),
+				buildPod("p2", "outdated", 10),
+				buildPod("p3", "outdated", 50),
+				buildPod("p4", "supported", 10),
+				buildPod("p5", "current", 10),
+				buildPod("p6", "unknown", 10),
+			},
+			maxMinorVersionSkew:   3,
+			maxPodsToEvictPerNode: 2,
+			expectedEvicted:       []string{"p2", "p3"},
+		},
+		{
+			description: "pods on nodes newer than the control plane are evicted",
+			nodes:       []*v1.Node{newer, oldest, current},
+			pods: []*v1.Pod{
+				buildPod("p1", "newer", 10),
+				buildPod("p2", "oldest", 10),
+				buildPod("p3", "current", 10),
+			},
+			maxMinorVersionSkew:   3,
+			maxPodsToEvictPerNode: 2,
+			expectedEvicted:       []string{"p1", "p2"},
+		},
+		{
+			description: "the skew is configurable",
+			nodes:       []*v1.Node{outdated, oldest, current},
+			pods: []*v1.Pod{
+				buildPod("p1", "outdated", 10),
+				buildPod("p2", "oldest", 10),
+			},
+			maxMinorVersionSkew:   4,
+			maxPodsToEvictPerNode: 2,
+			expectedEvicted:       []string{"p2"},
+		},
+		{
+			description: "pods are not moved to other skewed nodes",
+			nodes:       []*v1.Node{outdated, oldest, full},
+			pods: []*v1.Pod{
+				buildPod("p1", "outdated", 10),
+				buildPod("p2", "oldest", 10),
+				buildPod("p3", "full", 10),
+			},
+			maxMinorVersionSkew:   3,
+			maxPodsToEvictPerNode: 2,
+			expectedEvicted:       []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			scenario.New().
+				WithNodes(tc.nodes...).
+				WithPods(tc.pods...).
+				ExpectEvicted(tc.expectedEvicted...).
+				Run(t, New, &EvictForNodeCertificateOrKubeletVersionSkewArgs{
+					ControlPlaneVersion:   "v1.30.2",
+					MaxMinorVersionSkew:   utilptr.To(tc.maxMinorVersionSkew),
+					MaxPodsToEvictPerNode: utilptr.To(tc.maxPodsToEvictPerNode),
+				})
+		})
+	}
+}
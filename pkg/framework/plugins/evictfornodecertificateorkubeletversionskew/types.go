@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictfornodecertificateorkubeletversionskew
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EvictForNodeCertificateOrKubeletVersionSkewArgs holds arguments used to configure EvictForNodeCertificateOrKubeletVersionSkew plugin.
+type EvictForNodeCertificateOrKubeletVersionSkewArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// ControlPlaneVersion is the version of the control plane the kubelet versions are compared to, e.g. "v1.30.2".
+	ControlPlaneVersion string `json:"controlPlaneVersion"`
+	// MaxMinorVersionSkew is the number of minor versions a kubelet may be older than the control plane.
+	// Defaults to 3, the skew supported by Kubernetes since 1.28.
+	MaxMinorVersionSkew *uint `json:"maxMinorVersionSkew,omitempty"`
+	// MaxPodsToEvictPerNode limits the pods evicted from each skewed node per descheduling cycle,
+	// so the nodes are emptied progressively. Defaults to 2.
+	MaxPodsToEvictPerNode *uint `json:"maxPodsToEvictPerNode,omitempty"`
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictfornodecertificateorkubeletversionskew
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// ValidateEvictForNodeCertificateOrKubeletVersionSkewArgs validates EvictForNodeCertificateOrKubeletVersionSkew arguments
+func ValidateEvictForNodeCertificateOrKubeletVersionSkewArgs(obj runtime.Object) error {
+	args := obj.(*EvictForNodeCertificateOrKubeletVersionSkewArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+	if args.ControlPlaneVersion == "" {
+		return fmt.Errorf("controlPlaneVersion is required")
+	}
+	if _, err := version.ParseGeneric(args.ControlPlaneVersion); err != nil {
+		return fmt.Errorf("invalid controlPlaneVersion: %v", err)
+	}
+	if args.MaxPodsToEvictPerNode != nil && *args.MaxPodsToEvictPerNode == 0 {
+		return fmt.Errorf("maxPodsToEvictPerNode must be greater than 0")
+	}
+	return nil
+}
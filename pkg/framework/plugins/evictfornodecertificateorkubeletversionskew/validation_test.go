@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictfornodecertificateorkubeletversionskew
+
+import (
+	"testing"
+
+	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateEvictForNodeCertificateOrKubeletVersionSkewArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *EvictForNodeCertificateOrKubeletVersionSkewArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &EvictForNodeCertificateOrKubeletVersionSkewArgs{
+				ControlPlaneVersion: "v1.30.2",
+			},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &EvictForNodeCertificateOrKubeletVersionSkewArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+				ControlPlaneVersion: "v1.30.2",
+			},
+			expectError: true,
+		},
+		{
+			description: "no control plane version, expects error",
+			args:        &EvictForNodeCertificateOrKubeletVersionSkewArgs{},
+			expectError: true,
+		},
+		{
+			description: "invalid control plane version, expects error",
+			args: &EvictForNodeCertificateOrKubeletVersionSkewArgs{
+				ControlPlaneVersion: "latest",
+			},
+			expectError: true,
+		},
+		{
+			description: "no pods to evict per node, expects error",
+			args: &EvictForNodeCertificateOrKubeletVersionSkewArgs{
+				ControlPlaneVersion:   "v1.30.2",
+				MaxPodsToEvictPerNode: utilptr.To[uint](0),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateEvictForNodeCertificateOrKubeletVersionSkewArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictpodsfromoverheatednodes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetDefaults_EvictPodsFromOverheatedNodesArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "EvictPodsFromOverheatedNodesArgs empty",
+			in:   &EvictPodsFromOverheatedNodesArgs{},
+			want: &EvictPodsFromOverheatedNodesArgs{},
+		},
+		{
+			name: "EvictPodsFromOverheatedNodesArgs node condition status not set",
+			in: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{
+					{Name: "thermal", NodeCondition: &NodeConditionMatch{Type: "ThermalPressure"}},
+				},
+			},
+			want: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{
+					{Name: "thermal", NodeCondition: &NodeConditionMatch{Type: "ThermalPressure", Status: v1.ConditionTrue}},
+				},
+			},
+		},
+		{
+			name: "EvictPodsFromOverheatedNodesArgs with value",
+			in: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{
+					{Name: "kernel", NodeCondition: &NodeConditionMatch{Type: "KernelDeadlock", Status: v1.ConditionUnknown}},
+					{Name: "annotated", NodeAnnotations: map[string]string{"hardware.vendor/thermal": "critical"}},
+				},
+			},
+			want: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{
+					{Name: "kernel", NodeCondition: &NodeConditionMatch{Type: "KernelDeadlock", Status: v1.ConditionUnknown}},
+					{Name: "annotated", NodeAnnotations: map[string]string{"hardware.vendor/thermal": "critical"}},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_EvictPodsFromOverheatedNodesArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
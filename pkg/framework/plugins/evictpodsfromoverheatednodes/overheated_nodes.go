@@ -0,0 +1,182 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictpodsfromoverheatednodes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "EvictPodsFromOverheatedNodes"
+
+// EvictPodsFromOverheatedNodes evicts pods from nodes matching configurable triggers: node conditions
+// (e.g. custom conditions reported by the Node Problem Detector), node labels or node annotations.
+// This makes signals like a critical thermal state of the hardware actionable.
+type EvictPodsFromOverheatedNodes struct {
+	handle    frameworktypes.Handle
+	args      *EvictPodsFromOverheatedNodesArgs
+	podFilter podutil.FilterFunc
+	triggers  []*trigger
+}
+
+// trigger is the compiled form of a NodeTrigger
+type trigger struct {
+	*NodeTrigger
+	nodeSelector labels.Selector
+}
+
+var _ frameworktypes.DeschedulePlugin = &EvictPodsFromOverheatedNodes{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	overheatedArgs, ok := args.(*EvictPodsFromOverheatedNodesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type EvictPodsFromOverheatedNodesArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if overheatedArgs.Namespaces != nil {
+		includedNamespaces = sets.New(overheatedArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(overheatedArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(overheatedArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	triggers := make([]*trigger, 0, len(overheatedArgs.Triggers))
+	for i := range overheatedArgs.Triggers {
+		t := &trigger{NodeTrigger: &overheatedArgs.Triggers[i], nodeSelector: labels.Everything()}
+		if t.NodeSelector != nil {
+			t.nodeSelector, err = metav1.LabelSelectorAsSelector(t.NodeSelector)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing the node selector of trigger %q: %v", t.Name, err)
+			}
+		}
+		triggers = append(triggers, t)
+	}
+
+	return &EvictPodsFromOverheatedNodes{
+		handle:    handle,
+		args:      overheatedArgs,
+		podFilter: podFilter,
+		triggers:  triggers,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *EvictPodsFromOverheatedNodes) Name() string {
+	return PluginName
+}
+
+// matches returns true if the node matches all the predicates of the trigger
+func (t *trigger) matches(node *v1.Node, now time.Time) bool {
+	if !t.nodeSelector.Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	for key, value := range t.NodeAnnotations {
+		if actual, ok := node.Annotations[key]; !ok || actual != value {
+			return false
+		}
+	}
+	if t.NodeCondition == nil {
+		return true
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != t.NodeCondition.Type {
+			continue
+		}
+		if condition.Status != t.NodeCondition.Status {
+			return false
+		}
+		return t.NodeCondition.MinDuration == nil || now.Sub(condition.LastTransitionTime.Time) >= t.NodeCondition.MinDuration.Duration
+	}
+	return false
+}
+
+// matchingTrigger returns the first trigger the node matches, nil if none
+func (d *EvictPodsFromOverheatedNodes) matchingTrigger(node *v1.Node, now time.Time) *trigger {
+	for _, t := range d.triggers {
+		if t.matches(node, now) {
+			return t
+		}
+	}
+	return nil
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *EvictPodsFromOverheatedNodes) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	now := time.Now()
+	evicted := map[string]uint{}
+	for _, node := range nodes {
+		t := d.matchingTrigger(node, now)
+		if t == nil {
+			continue
+		}
+		logger.V(1).Info("Processing node", "node", klog.KObj(node), "trigger", t.Name)
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		// Evict the least important pods first in case the trigger limits the number of evictions
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+	loop:
+		for _, pod := range pods {
+			if t.MaxPodsToEvictPerCycle != nil && evicted[t.Name] >= *t.MaxPodsToEvictPerCycle {
+				logger.V(2).Info("Maximum number of evicted pods per cycle reached for the trigger", "trigger", t.Name, "limit", *t.MaxPodsToEvictPerCycle)
+				break
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				evicted[t.Name]++
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
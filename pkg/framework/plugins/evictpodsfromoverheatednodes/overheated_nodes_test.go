@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictpodsfromoverheatednodes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestEvictPodsFromOverheatedNodes(t *testing.T) {
+	thermalCondition := func(status v1.ConditionStatus, since time.Duration) func(*v1.Node) {
+		return func(node *v1.Node) {
+			node.Status.Conditions = append(node.Status.Conditions, v1.NodeCondition{
+				Type:               "ThermalPressure",
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+			})
+		}
+	}
+
+	hotNode := test.BuildTestNode("hot", 2000, 3000, 10, thermalCondition(v1.ConditionTrue, time.Hour))
+	recentlyHotNode := test.BuildTestNode("recently-hot", 2000, 3000, 10, thermalCondition(v1.ConditionTrue, time.Second))
+	coolNode := test.BuildTestNode("cool", 2000, 3000, 10, thermalCondition(v1.ConditionFalse, time.Hour))
+	labeledNode := test.BuildTestNode("labeled", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels["hardware.vendor/thermal"] = "critical"
+		node.Annotations = map[string]string{"hardware.vendor/fan": "failed"}
+	})
+	nodes := []*v1.Node{hotNode, recentlyHotNode, coolNode, labeledNode}
+
+	var pods []runtime.Object
+	for _, node := range nodes {
+		for _, name := range []string{"a", "b"} {
+			pods = append(pods, test.BuildTestPod(node.Name+"-"+name, 100, 0, node.Name, func(pod *v1.Pod) {
+				test.SetRSOwnerRef(pod)
+				if name == "b" {
+					pod.Namespace = "kube-system"
+				}
+			}))
+		}
+	}
+
+	thermal := NodeTrigger{
+		Name:          "thermal",
+		NodeCondition: &NodeConditionMatch{Type: "ThermalPressure", Status: v1.ConditionTrue},
+	}
+
+	tests := []struct {
+		description          string
+		args                 EvictPodsFromOverheatedNodesArgs
+		nodes                []*v1.Node
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "Pods are evicted from nodes with the condition",
+			args:                 EvictPodsFromOverheatedNodesArgs{Triggers: []NodeTrigger{thermal}},
+			nodes:                nodes,
+			expectedEvictedCount: 4,
+		},
+		{
+			description: "Condition has to be in the status for the minimum duration",
+			args: EvictPodsFromOverheatedNodesArgs{Triggers: []NodeTrigger{{
+				Name:          "thermal",
+				NodeCondition: &NodeConditionMatch{Type: "ThermalPressure", Status: v1.ConditionTrue, MinDuration: &metav1.Duration{Duration: 10 * time.Minute}},
+			}}},
+			nodes:                nodes,
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Nodes matching the label and annotation predicates",
+			args: EvictPodsFromOverheatedNodesArgs{Triggers: []NodeTrigger{{
+				Name:            "vendor",
+				NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"hardware.vendor/thermal": "critical"}},
+				NodeAnnotations: map[string]string{"hardware.vendor/fan": "failed"},
+			}}},
+			nodes:                nodes,
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "All predicates of a trigger have to match",
+			args: EvictPodsFromOverheatedNodesArgs{Triggers: []NodeTrigger{{
+				Name:            "vendor",
+				NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"hardware.vendor/thermal": "critical"}},
+				NodeAnnotations: map[string]string{"hardware.vendor/fan": "slow"},
+			}}},
+			nodes:                nodes,
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Evictions are paced per trigger",
+			args: EvictPodsFromOverheatedNodesArgs{Triggers: []NodeTrigger{
+				{
+					Name:                   "thermal",
+					NodeCondition:          thermal.NodeCondition,
+					MaxPodsToEvictPerCycle: utilptr.To[uint](3),
+				},
+				{
+					Name:                   "vendor",
+					NodeAnnotations:        map[string]string{"hardware.vendor/fan": "failed"},
+					MaxPodsToEvictPerCycle: utilptr.To[uint](1),
+				},
+			}},
+			nodes:                nodes,
+			expectedEvictedCount: 4,
+		},
+		{
+			description: "Pods in excluded namespaces are ignored",
+			args: EvictPodsFromOverheatedNodesArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"kube-system"}},
+				},
+				Triggers: []NodeTrigger{thermal},
+			},
+			nodes:                nodes,
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Only the given nodes are processed",
+			args:                 EvictPodsFromOverheatedNodesArgs{Triggers: []NodeTrigger{thermal}},
+			nodes:                []*v1.Node{coolNode, labeledNode},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := append([]runtime.Object{}, pods...)
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
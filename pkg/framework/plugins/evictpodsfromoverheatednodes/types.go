@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictpodsfromoverheatednodes
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EvictPodsFromOverheatedNodesArgs holds arguments used to configure EvictPodsFromOverheatedNodes plugin.
+type EvictPodsFromOverheatedNodesArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// Triggers lists the node signals pods get evicted for.
+	// A node is handled by the first trigger it matches.
+	Triggers []NodeTrigger `json:"triggers"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// NodeTrigger matches nodes pods should be evicted from.
+// All the predicates set have to match for a node to match.
+type NodeTrigger struct {
+	// Name identifies the trigger in logs
+	Name string `json:"name"`
+	// NodeCondition matches a condition of the node, e.g. set by the Node Problem Detector
+	NodeCondition *NodeConditionMatch `json:"nodeCondition,omitempty"`
+	// NodeSelector matches the labels of the node
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+	// NodeAnnotations matches the annotations of the node, all of them have to be set with the given value
+	NodeAnnotations map[string]string `json:"nodeAnnotations,omitempty"`
+	// MaxPodsToEvictPerCycle paces the evictions by limiting the number of pods evicted
+	// for the trigger in a descheduling cycle, across all the matching nodes
+	MaxPodsToEvictPerCycle *uint `json:"maxPodsToEvictPerCycle,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// NodeConditionMatch matches a node condition of a given type and status.
+type NodeConditionMatch struct {
+	Type   v1.NodeConditionType `json:"type"`
+	Status v1.ConditionStatus   `json:"status,omitempty"`
+	// MinDuration is how long the condition has to be in the given status before pods are evicted
+	MinDuration *metav1.Duration `json:"minDuration,omitempty"`
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictpodsfromoverheatednodes
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ValidateEvictPodsFromOverheatedNodesArgs validates EvictPodsFromOverheatedNodes arguments
+func ValidateEvictPodsFromOverheatedNodesArgs(obj runtime.Object) error {
+	args := obj.(*EvictPodsFromOverheatedNodesArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if len(args.Triggers) == 0 {
+		return fmt.Errorf("at least one trigger is required")
+	}
+	names := sets.New[string]()
+	for _, trigger := range args.Triggers {
+		if trigger.Name == "" {
+			return fmt.Errorf("trigger name can not be empty")
+		}
+		if names.Has(trigger.Name) {
+			return fmt.Errorf("trigger %q is defined more than once", trigger.Name)
+		}
+		names.Insert(trigger.Name)
+
+		if trigger.NodeCondition == nil && trigger.NodeSelector == nil && len(trigger.NodeAnnotations) == 0 {
+			return fmt.Errorf("trigger %q: one of nodeCondition, nodeSelector or nodeAnnotations is required", trigger.Name)
+		}
+		if condition := trigger.NodeCondition; condition != nil {
+			if condition.Type == "" {
+				return fmt.Errorf("trigger %q: node condition type can not be empty", trigger.Name)
+			}
+			switch condition.Status {
+			case v1.ConditionTrue, v1.ConditionFalse, v1.ConditionUnknown:
+			default:
+				return fmt.Errorf("trigger %q: node condition status %q is not one of True, False or Unknown", trigger.Name, condition.Status)
+			}
+			if condition.MinDuration != nil && condition.MinDuration.Duration < 0 {
+				return fmt.Errorf("trigger %q: node condition minDuration can not be negative", trigger.Name)
+			}
+		}
+		if trigger.NodeSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(trigger.NodeSelector); err != nil {
+				return fmt.Errorf("trigger %q: failed to get node selector: %+v", trigger.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictpodsfromoverheatednodes
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateEvictPodsFromOverheatedNodesArgs(t *testing.T) {
+	thermal := NodeTrigger{
+		Name:          "thermal",
+		NodeCondition: &NodeConditionMatch{Type: "ThermalPressure", Status: v1.ConditionTrue},
+	}
+
+	testCases := []struct {
+		description string
+		args        *EvictPodsFromOverheatedNodesArgs
+		expectError bool
+	}{
+		{
+			description: "valid args, no errors",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+					},
+				},
+				Triggers: []NodeTrigger{
+					thermal,
+					{
+						Name:            "labeled",
+						NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"hardware.vendor/thermal": "critical"}},
+						NodeAnnotations: map[string]string{"hardware.vendor/fan": "failed"},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			description: "no triggers, expects error",
+			args:        &EvictPodsFromOverheatedNodesArgs{},
+			expectError: true,
+		},
+		{
+			description: "trigger without name, expects error",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{{NodeAnnotations: map[string]string{"hardware.vendor/thermal": "critical"}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "duplicated trigger names, expects error",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{thermal, thermal},
+			},
+			expectError: true,
+		},
+		{
+			description: "trigger without predicate, expects error",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{{Name: "empty"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "node condition without type, expects error",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{{Name: "thermal", NodeCondition: &NodeConditionMatch{Status: v1.ConditionTrue}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid node condition status, expects error",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{{Name: "thermal", NodeCondition: &NodeConditionMatch{Type: "ThermalPressure", Status: "Critical"}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "negative node condition duration, expects error",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{{
+					Name:          "thermal",
+					NodeCondition: &NodeConditionMatch{Type: "ThermalPressure", Status: v1.ConditionTrue, MinDuration: &metav1.Duration{Duration: -time.Minute}},
+				}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid node selector, expects error",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				Triggers: []NodeTrigger{{
+					Name: "labeled",
+					NodeSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Operator: metav1.LabelSelectorOpIn,
+							},
+						},
+					},
+				}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid namespaces args, expects error",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+						Exclude: []string{"kube-system"},
+					},
+				},
+				Triggers: []NodeTrigger{thermal},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector args, expects errors",
+			args: &EvictPodsFromOverheatedNodesArgs{
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Operator: metav1.LabelSelectorOpIn,
+							},
+						},
+					},
+				},
+				Triggers: []NodeTrigger{thermal},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateEvictPodsFromOverheatedNodesArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictpodswithstaleimagepullsecrets
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "EvictPodsWithStaleImagePullSecrets"
+
+// EvictPodsWithStaleImagePullSecrets evicts the pods referencing image pull secrets that were deleted,
+// or replaced by secrets no longer holding registry credentials, while some of their images are not
+// cached by their node. The next pull of such images on the node would fail, e.g. when a container
+// restarts, so the pods are evicted while the failure can still be noticed.
+type EvictPodsWithStaleImagePullSecrets struct {
+	handle       frameworktypes.Handle
+	args         *EvictPodsWithStaleImagePullSecretsArgs
+	podFilter    podutil.FilterFunc
+	secretLister listersv1.SecretLister
+	// secretsSynced tells whether the secrets could be listed, their informer does not sync without the permission
+	secretsSynced cache.InformerSynced
+}
+
+var _ frameworktypes.DeschedulePlugin = &EvictPodsWithStaleImagePullSecrets{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	secretsArgs, ok := args.(*EvictPodsWithStaleImagePullSecretsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type EvictPodsWithStaleImagePullSecretsArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if secretsArgs.Namespaces != nil {
+		includedNamespaces = sets.New(secretsArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(secretsArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(secretsArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	podFilter = podutil.WrapFilterFuncs(podFilter, func(pod *v1.Pod) bool {
+		return len(pod.Spec.ImagePullSecrets) > 0
+	})
+
+	// The secrets are read from the cluster, the cached client of the dry run mode holds none
+	secretInformer := handle.ClusterInformerFactory().Core().V1().Secrets()
+
+	return &EvictPodsWithStaleImagePullSecrets{
+		handle:        handle,
+		args:          secretsArgs,
+		podFilter:     podFilter,
+		secretLister:  secretInformer.Lister(),
+		secretsSynced: secretInformer.Informer().HasSynced,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *EvictPodsWithStaleImagePullSecrets) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *EvictPodsWithStaleImagePullSecrets) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	if !d.secretsSynced() {
+		logger.V(1).Info("Unable to read the secrets, skipping")
+		return nil
+	}
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		nodeImages := utils.NodeImages(node)
+	loop:
+		for _, pod := range pods {
+
+			reason, err := d.staleImagePullSecret(pod)
+			if err != nil {
+				return &frameworktypes.Status{
+					Err: fmt.Errorf("error getting the image pull secrets of pod %s/%s: %v", pod.Namespace, pod.Name, err),
+				}
+			}
+			if reason == "" || !pullsImage(pod, nodeImages) {
+				continue
+			}
+			logger.V(2).Info("Pod references a stale image pull secret", "pod", klog.KObj(pod), "reason", reason)
+			err = d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName, Reason: reason})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// staleImagePullSecret returns why one of the image pull secrets of the pod can no longer be used,
+// or an empty string when all of them still can.
+func (d *EvictPodsWithStaleImagePullSecrets) staleImagePullSecret(pod *v1.Pod) (string, error) {
+	for _, reference := range pod.Spec.ImagePullSecrets {
+		secret, err := d.secretLister.Secrets(pod.Namespace).Get(reference.Name)
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("image pull secret %q not found", reference.Name), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if !hasRegistryCredentials(secret) {
+			return fmt.Sprintf("image pull secret %q holds no registry credentials", reference.Name), nil
+		}
+	}
+	return "", nil
+}
+
+// hasRegistryCredentials checks the secret holds the credentials the kubelet pulls images with
+func hasRegistryCredentials(secret *v1.Secret) bool {
+	switch secret.Type {
+	case v1.SecretTypeDockerConfigJson:
+		return len(secret.Data[v1.DockerConfigJsonKey]) > 0
+	case v1.SecretTypeDockercfg:
+		return len(secret.Data[v1.DockerConfigKey]) > 0
+	}
+	return false
+}
+
+// pullsImage checks whether the node would pull one of the images of the pod when its containers
+// restart, i.e. the image is not cached by the node or is always pulled.
+func pullsImage(pod *v1.Pod, nodeImages map[string]int64) bool {
+	containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		if container.ImagePullPolicy == v1.PullAlways {
+			return true
+		}
+		if container.ImagePullPolicy == v1.PullNever {
+			continue
+		}
+		if _, ok := nodeImages[utils.NormalizeImageReference(container.Image)]; !ok {
+			return true
+		}
+	}
+	return false
+}
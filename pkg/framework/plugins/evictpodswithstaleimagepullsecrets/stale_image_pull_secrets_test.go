@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictpodswithstaleimagepullsecrets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildSecret(name string, secretType v1.SecretType, data map[string][]byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       secretType,
+		Data:       data,
+	}
+}
+
+func TestEvictPodsWithStaleImagePullSecrets(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, func(node *v1.Node) {
+		node.Status.Images = []v1.ContainerImage{{Names: []string{"docker.io/library/cached:1.0"}, SizeBytes: 1 << 20}}
+	})
+	valid := buildSecret("valid", v1.SecretTypeDockerConfigJson, map[string][]byte{v1.DockerConfigJsonKey: []byte("{}")})
+	legacy := buildSecret("legacy", v1.SecretTypeDockercfg, map[string][]byte{v1.DockerConfigKey: []byte("{}")})
+	opaque := buildSecret("opaque", v1.SecretTypeOpaque, map[string][]byte{"token": []byte("t")})
+	emptied := buildSecret("emptied", v1.SecretTypeDockerConfigJson, nil)
+
+	buildPod := func(name, image string, pullPolicy v1.PullPolicy, secrets ...string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, n1.Name, func(pod *v1.Pod) {
+			pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+			pod.Spec.Containers[0].Image = image
+			pod.Spec.Containers[0].ImagePullPolicy = pullPolicy
+			for _, secret := range secrets {
+				pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, v1.LocalObjectReference{Name: secret})
+			}
+		})
+	}
+
+	tests := []struct {
+		description          string
+		args                 EvictPodsWithStaleImagePullSecretsArgs
+		objects              []runtime.Object
+		clusterObjects       []runtime.Object
+		forbidden            bool
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pods referencing deleted pull secrets are evicted",
+			objects: []runtime.Object{
+				valid,
+				buildPod("p1", "registry.example.com/app:1.0", v1.PullIfNotPresent, "deleted"),
+				buildPod("p2", "registry.example.com/app:1.0", v1.PullIfNotPresent, "valid", "deleted"),
+			},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Pods referencing pull secrets without registry credentials are evicted",
+			objects: []runtime.Object{
+				opaque, emptied,
+				buildPod("p1", "registry.example.com/app:1.0", v1.PullIfNotPresent, "opaque"),
+				buildPod("p2", "registry.example.com/app:1.0", v1.PullIfNotPresent, "emptied"),
+			},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Pods referencing valid pull secrets are not evicted",
+			objects: []runtime.Object{
+				valid, legacy,
+				buildPod("p1", "registry.example.com/app:1.0", v1.PullIfNotPresent, "valid"),
+				buildPod("p2", "registry.example.com/app:1.0", v1.PullIfNotPresent, "legacy"),
+				buildPod("p3", "registry.example.com/app:1.0", v1.PullIfNotPresent),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods whose images are cached by the node are not evicted",
+			objects: []runtime.Object{
+				valid,
+				buildPod("p1", "cached:1.0", v1.PullIfNotPresent, "deleted"),
+				buildPod("p2", "registry.example.com/app:1.0", v1.PullNever, "deleted"),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods always pulling their cached images are evicted",
+			objects: []runtime.Object{
+				valid,
+				buildPod("p1", "cached:1.0", v1.PullAlways, "deleted"),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods of namespaces whose only pull secret got deleted are evicted",
+			objects: []runtime.Object{
+				buildPod("p1", "registry.example.com/app:1.0", v1.PullIfNotPresent, "deleted"),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods are not evicted when the secrets can not be read",
+			objects: []runtime.Object{
+				buildPod("p1", "registry.example.com/app:1.0", v1.PullIfNotPresent, "deleted"),
+			},
+			forbidden:            true,
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "The secrets are read from the cluster in dry run mode",
+			objects: []runtime.Object{
+				buildPod("p1", "registry.example.com/app:1.0", v1.PullIfNotPresent, "valid"),
+				buildPod("p2", "registry.example.com/app:1.0", v1.PullIfNotPresent, "opaque"),
+			},
+			clusterObjects:       []runtime.Object{valid, opaque},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods of excluded namespaces are not evicted",
+			args: EvictPodsWithStaleImagePullSecretsArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"default"}},
+				},
+			},
+			objects: []runtime.Object{
+				valid,
+				buildPod("p1", "registry.example.com/app:1.0", v1.PullIfNotPresent, "deleted"),
+			},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := append([]runtime.Object{n1}, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+			if tc.forbidden {
+				fakeClient.PrependReactor("list", "secrets", func(action core.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", nil)
+				})
+			}
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			if tc.clusterObjects != nil {
+				handle.ClusterInformerFactoryImpl = informers.NewSharedInformerFactory(fake.NewSimpleClientset(tc.clusterObjects...), 0)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			// Start the secret informer requested by the plugin, it never syncs when not allowed to list the secrets
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			syncCtx, syncCancel := context.WithTimeout(ctx, time.Second)
+			defer syncCancel()
+			handle.ClusterInformerFactory().WaitForCacheSync(syncCtx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{n1})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
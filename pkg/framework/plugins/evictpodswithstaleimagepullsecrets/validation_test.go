@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictpodswithstaleimagepullsecrets
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateEvictPodsWithStaleImagePullSecretsArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *EvictPodsWithStaleImagePullSecretsArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args:        &EvictPodsWithStaleImagePullSecretsArgs{},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &EvictPodsWithStaleImagePullSecretsArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects error",
+			args: &EvictPodsWithStaleImagePullSecretsArgs{
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid node selector, expects error",
+			args: &EvictPodsWithStaleImagePullSecretsArgs{
+				FilteringArgs: api.FilteringArgs{NodeSelector: "pool in (a"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateEvictPodsWithStaleImagePullSecretsArgs(tc.args)
+			if tc.expectError != (err != nil) {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
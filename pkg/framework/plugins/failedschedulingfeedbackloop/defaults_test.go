@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failedschedulingfeedbackloop
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestSetDefaults_FailedSchedulingFeedbackLoopArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "FailedSchedulingFeedbackLoopArgs empty",
+			in:   &FailedSchedulingFeedbackLoopArgs{},
+			want: &FailedSchedulingFeedbackLoopArgs{
+				MinFailedAttempts:         utilptr.To[int32](3),
+				MaxEventAgeSeconds:        utilptr.To[uint](600),
+				MaxEvictionsPerPendingPod: utilptr.To[int32](1),
+			},
+		},
+		{
+			name: "FailedSchedulingFeedbackLoopArgs with value",
+			in: &FailedSchedulingFeedbackLoopArgs{
+				MinFailedAttempts:         utilptr.To[int32](1),
+				MaxEventAgeSeconds:        utilptr.To[uint](60),
+				MaxEvictionsPerPendingPod: utilptr.To[int32](3),
+			},
+			want: &FailedSchedulingFeedbackLoopArgs{
+				MinFailedAttempts:         utilptr.To[int32](1),
+				MaxEventAgeSeconds:        utilptr.To[uint](60),
+				MaxEvictionsPerPendingPod: utilptr.To[int32](3),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_FailedSchedulingFeedbackLoopArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
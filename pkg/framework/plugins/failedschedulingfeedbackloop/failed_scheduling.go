@@ -0,0 +1,411 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failedschedulingfeedbackloop
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	PluginName = "FailedSchedulingFeedbackLoop"
+
+	// failedSchedulingReason is the reason of the events the scheduler emits on the pods it can not place
+	failedSchedulingReason = "FailedScheduling"
+
+	// predicateAntiAffinity is reported for the nodes running pods matching the anti-affinity of the pending pod
+	predicateAntiAffinity = "node(s) didn't match pod anti-affinity rules"
+	// predicateExistingAntiAffinity is reported for the nodes running pods whose anti-affinity matches the pending pod
+	predicateExistingAntiAffinity = "node(s) didn't satisfy existing pods anti-affinity rules"
+)
+
+// FailedSchedulingFeedbackLoop closes the loop between the scheduler and the descheduler. It
+// reads the FailedScheduling events of the pending pods, aggregates the predicates the nodes
+// failed, and evicts the pods the messages point at as blocking, e.g. the pods matching the pod
+// anti-affinity of a pending pod on the node requiring the fewest evictions. Predicates no pod
+// eviction can satisfy, e.g. insufficient resources, are only reported.
+type FailedSchedulingFeedbackLoop struct {
+	handle      frameworktypes.Handle
+	args        *FailedSchedulingFeedbackLoopArgs
+	podFilter   podutil.FilterFunc
+	podLister   listersv1.PodLister
+	eventLister listersv1.EventLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &FailedSchedulingFeedbackLoop{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	feedbackLoopArgs, ok := args.(*FailedSchedulingFeedbackLoopArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type FailedSchedulingFeedbackLoopArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if feedbackLoopArgs.Namespaces != nil {
+		includedNamespaces = sets.New(feedbackLoopArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(feedbackLoopArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(feedbackLoopArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	eventInformer := handle.SharedInformerFactory().InformerFor(&v1.Event{}, newFailedSchedulingEventInformer)
+	return &FailedSchedulingFeedbackLoop{
+		handle:      handle,
+		args:        feedbackLoopArgs,
+		podFilter:   podFilter,
+		podLister:   handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+		eventLister: listersv1.NewEventLister(eventInformer.GetIndexer()),
+	}, nil
+}
+
+// newFailedSchedulingEventInformer builds an informer caching the FailedScheduling events only,
+// instead of all the events of the cluster.
+func newFailedSchedulingEventInformer(client clientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return coreinformers.NewFilteredEventInformer(client, metav1.NamespaceAll, resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("reason", failedSchedulingReason).String()
+		},
+	)
+}
+
+// Name retrieves the plugin name
+func (d *FailedSchedulingFeedbackLoop) Name() string {
+	return PluginName
+}
+
+// failedSchedulingPod is a pending pod along with the feedback of the scheduler about it
+type failedSchedulingPod struct {
+	pod *v1.Pod
+	// attempts is the number of recent failed scheduling attempts
+	attempts int32
+	// lastSeen is when the last FailedScheduling event was observed
+	lastSeen time.Time
+	// predicates counts the nodes failing each predicate in the last FailedScheduling event
+	predicates map[string]int
+}
+
+// parsePredicates returns the number of nodes failing each predicate reported in the message of
+// a FailedScheduling event, e.g. "0/3 nodes are available: 1 node(s) didn't match pod anti-affinity
+// rules, 2 Insufficient cpu. preemption: ...". The preemption outcome is left out.
+func parsePredicates(message string) map[string]int {
+	_, message, ok := strings.Cut(message, "nodes are available: ")
+	if !ok {
+		return nil
+	}
+	message, _, _ = strings.Cut(message, ". preemption:")
+	message = strings.TrimSuffix(strings.TrimSpace(message), ".")
+
+	predicates := map[string]int{}
+	for _, item := range strings.Split(message, ", ") {
+		count, predicate, ok := strings.Cut(strings.TrimSpace(item), " ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			continue
+		}
+		predicates[predicate] += n
+	}
+	return predicates
+}
+
+// eventLastSeen returns when the event was last observed, whichever API created it
+func eventLastSeen(event *v1.Event) time.Time {
+	lastSeen := event.LastTimestamp.Time
+	if event.EventTime.After(lastSeen) {
+		lastSeen = event.EventTime.Time
+	}
+	if event.Series != nil && event.Series.LastObservedTime.After(lastSeen) {
+		lastSeen = event.Series.LastObservedTime.Time
+	}
+	return lastSeen
+}
+
+// eventCount returns how many times the event was observed, whichever API created it
+func eventCount(event *v1.Event) int32 {
+	if event.Series != nil && event.Series.Count > event.Count {
+		return event.Series.Count
+	}
+	return max(event.Count, 1)
+}
+
+// failedSchedulingPods returns the pods still pending which recently failed scheduling at least
+// the configured number of times, highest priority first.
+func (d *FailedSchedulingFeedbackLoop) failedSchedulingPods() ([]*failedSchedulingPod, error) {
+	events, err := d.eventLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	oldest := time.Now().Add(-time.Duration(*d.args.MaxEventAgeSeconds) * time.Second)
+	byPod := map[types.UID]*failedSchedulingPod{}
+	for _, event := range events {
+		if event.Reason != failedSchedulingReason || event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		lastSeen := eventLastSeen(event)
+		if lastSeen.Before(oldest) {
+			continue
+		}
+		failed, ok := byPod[event.InvolvedObject.UID]
+		if !ok {
+			pod, err := d.podLister.Pods(event.InvolvedObject.Namespace).Get(event.InvolvedObject.Name)
+			// Events of a former pod with the same name are ignored
+			if err != nil || pod.UID != event.InvolvedObject.UID || !utils.IsPodUnschedulable(pod) {
+				continue
+			}
+			failed = &failedSchedulingPod{pod: pod}
+			byPod[event.InvolvedObject.UID] = failed
+		}
+		failed.attempts += eventCount(event)
+		if lastSeen.After(failed.lastSeen) || failed.predicates == nil {
+			failed.lastSeen = lastSeen
+			failed.predicates = parsePredicates(event.Message)
+		}
+	}
+
+	var pending []*failedSchedulingPod
+	for _, failed := range byPod {
+		if failed.attempts >= *d.args.MinFailedAttempts {
+			pending = append(pending, failed)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if utils.GetPodPriority(pending[i].pod) != utils.GetPodPriority(pending[j].pod) {
+			return utils.GetPodPriority(pending[i].pod) > utils.GetPodPriority(pending[j].pod)
+		}
+		if !pending[i].pod.CreationTimestamp.Equal(&pending[j].pod.CreationTimestamp) {
+			return pending[i].pod.CreationTimestamp.Before(&pending[j].pod.CreationTimestamp)
+		}
+		return pending[i].pod.UID < pending[j].pod.UID
+	})
+	return pending, nil
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *FailedSchedulingFeedbackLoop) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	pending, err := d.failedSchedulingPods()
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods failing scheduling: %v", err),
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	predicates := map[string]int{}
+	for _, failed := range pending {
+		for predicate, count := range failed.predicates {
+			predicates[predicate] += count
+		}
+	}
+	logger.V(1).Info("Pods failing scheduling", "pods", len(pending), "unsatisfiedPredicates", predicates)
+
+	pods, err := podutil.ListPodsOnNodes(nodes, d.handle.GetPodsAssignedToNodeFunc(), func(pod *v1.Pod) bool {
+		return !utils.IsPodTerminating(pod)
+	})
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing all pods: %v", err),
+		}
+	}
+	nodeSnapshot := d.handle.NodeSnapshot()
+
+	for _, failed := range pending {
+		if failed.predicates[predicateAntiAffinity] == 0 && failed.predicates[predicateExistingAntiAffinity] == 0 {
+			continue
+		}
+		node, blockers := d.bestNode(failed, nodes, pods, nodeSnapshot)
+		if node == nil {
+			logger.V(2).Info("Unable to make room for a pod failing scheduling", "pod", klog.KObj(failed.pod), "predicates", failed.predicates)
+			continue
+		}
+		logger.V(2).Info("Evicting pods blocking the scheduling of a pending pod", "pod", klog.KObj(failed.pod), "node", klog.KObj(node), "blockingPods", len(blockers))
+		if !d.evict(ctx, failed.pod, blockers) {
+			return nil
+		}
+		blocking := sets.New[types.UID]()
+		for _, pod := range blockers {
+			blocking.Insert(pod.UID)
+		}
+		pods = removePods(pods, blocking)
+	}
+	return nil
+}
+
+// bestNode returns the node requiring the fewest evictions for the pending pod to be scheduled
+// there, along with the pods to evict.
+func (d *FailedSchedulingFeedbackLoop) bestNode(failed *failedSchedulingPod, nodes []*v1.Node, pods []*v1.Pod, nodeSnapshot *nodeutil.NodeSnapshot) (*v1.Node, []*v1.Pod) {
+	var bestNode *v1.Node
+	var bestBlockers []*v1.Pod
+	for _, node := range nodes {
+		blockers := blockingPods(failed, node, pods, nodeSnapshot)
+		if len(blockers) == 0 || len(blockers) > int(*d.args.MaxEvictionsPerPendingPod) {
+			continue
+		}
+		if bestNode != nil && len(blockers) >= len(bestBlockers) {
+			continue
+		}
+		if d.canEvict(failed.pod, blockers, nodes) && d.fitsWithout(failed.pod, node, blockers) {
+			bestNode, bestBlockers = node, blockers
+		}
+	}
+	return bestNode, bestBlockers
+}
+
+// blockingPods returns the pods preventing the pending pod from being scheduled on the node
+// according to the predicates the scheduler reported.
+func blockingPods(failed *failedSchedulingPod, node *v1.Node, pods []*v1.Pod, nodeSnapshot *nodeutil.NodeSnapshot) []*v1.Pod {
+	var blockers []*v1.Pod
+	for _, pod := range pods {
+		podNode, ok := nodeSnapshot.Get(pod.Spec.NodeName)
+		if !ok {
+			continue
+		}
+		if (failed.predicates[predicateAntiAffinity] > 0 && matchesAntiAffinity(failed.pod, pod, node, podNode)) ||
+			(failed.predicates[predicateExistingAntiAffinity] > 0 && matchesAntiAffinity(pod, failed.pod, podNode, node)) {
+			blockers = append(blockers, pod)
+		}
+	}
+	return blockers
+}
+
+// matchesAntiAffinity returns true if the other pod matches one of the required anti-affinity
+// terms of the pod, both nodes being in the same topology domain of the term.
+func matchesAntiAffinity(pod, other *v1.Pod, node, otherNode *v1.Node) bool {
+	if pod.Spec.Affinity == nil {
+		return false
+	}
+	for _, term := range utils.GetPodAntiAffinityTerms(pod.Spec.Affinity.PodAntiAffinity) {
+		if !sameTopologyDomain(node, otherNode, term.TopologyKey) {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			klog.ErrorS(err, "Unable to convert LabelSelector into Selector", "pod", klog.KObj(pod))
+			continue
+		}
+		if utils.PodMatchesTermsNamespaceAndSelector(other, utils.GetNamespacesFromPodAffinityTerm(pod, &term), selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameTopologyDomain returns true if both nodes have the same value for the topology key
+func sameTopologyDomain(node, other *v1.Node, topologyKey string) bool {
+	value, ok := node.Labels[topologyKey]
+	if !ok {
+		return false
+	}
+	otherValue, ok := other.Labels[topologyKey]
+	return ok && value == otherValue
+}
+
+// canEvict returns true if all the blocking pods can be evicted and moved to another node. Evicting
+// only some of them would not let the pending pod be scheduled.
+func (d *FailedSchedulingFeedbackLoop) canEvict(pending *v1.Pod, blockers []*v1.Pod, nodes []*v1.Node) bool {
+	for _, pod := range blockers {
+		if utils.GetPodPriority(pod) > utils.GetPodPriority(pending) || !d.podFilter(pod) || !nodeutil.PodFitsAnyOtherNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes) {
+			return false
+		}
+	}
+	return true
+}
+
+// fitsWithout returns true if the pending pod fits the node once the blocking pods are gone
+func (d *FailedSchedulingFeedbackLoop) fitsWithout(pending *v1.Pod, node *v1.Node, blockers []*v1.Pod) bool {
+	blocking := sets.New[types.UID]()
+	for _, pod := range blockers {
+		blocking.Insert(pod.UID)
+	}
+	getPodsAssignedToNode := func(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+		return d.handle.GetPodsAssignedToNodeFunc()(nodeName, podutil.WrapFilterFuncs(filter, func(pod *v1.Pod) bool {
+			return !blocking.Has(pod.UID)
+		}))
+	}
+	return nodeutil.NodeFit(getPodsAssignedToNode, pending, node) == nil
+}
+
+// removePods returns the pods besides the given ones
+func removePods(pods []*v1.Pod, removed sets.Set[types.UID]) []*v1.Pod {
+	var remaining []*v1.Pod
+	for _, pod := range pods {
+		if !removed.Has(pod.UID) {
+			remaining = append(remaining, pod)
+		}
+	}
+	return remaining
+}
+
+// evict evicts the pods blocking the pending pod, returning false when the total eviction limit got reached
+func (d *FailedSchedulingFeedbackLoop) evict(ctx context.Context, pending *v1.Pod, pods []*v1.Pod) bool {
+	logger := klog.FromContext(ctx)
+	reason := fmt.Sprintf("blocking the scheduling of pod %s/%s", pending.Namespace, pending.Name)
+	for _, pod := range pods {
+		err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName, Reason: reason})
+		if err == nil {
+			continue
+		}
+		switch err.(type) {
+		case *evictions.EvictionNodeLimitError:
+			return true
+		case *evictions.EvictionTotalLimitError:
+			return false
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	return true
+}
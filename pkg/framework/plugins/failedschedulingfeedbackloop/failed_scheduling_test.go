@@ -0,0 +1,298 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failedschedulingfeedbackloop
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+const antiAffinityMessage = "0/2 nodes are available: 2 node(s) didn't match pod anti-affinity rules. preemption: 0/2 nodes are available: 2 No preemption victims found for incoming pod."
+
+func TestParsePredicates(t *testing.T) {
+	tests := []struct {
+		description string
+		message     string
+		expected    map[string]int
+	}{
+		{
+			description: "predicates with preemption outcome",
+			message:     "0/5 nodes are available: 1 node(s) didn't match pod anti-affinity rules, 1 node(s) had untolerated taint {dedicated: infra}, 3 Insufficient cpu. preemption: 0/5 nodes are available: 5 No preemption victims found for incoming pod.",
+			expected: map[string]int{
+				predicateAntiAffinity:                              1,
+				"node(s) had untolerated taint {dedicated: infra}": 1,
+				"Insufficient cpu":                                 3,
+			},
+		},
+		{
+			description: "predicates without preemption outcome",
+			message:     "0/3 nodes are available: 3 node(s) didn't satisfy existing pods anti-affinity rules.",
+			expected:    map[string]int{predicateExistingAntiAffinity: 3},
+		},
+		{
+			description: "unknown message",
+			message:     "running PreFilter plugin \"VolumeBinding\": pod has unbound immediate PersistentVolumeClaims",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, parsePredicates(tc.message)); diff != "" {
+				t.Errorf("Unexpected predicates (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func buildNode(name string) *v1.Node {
+	return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels[v1.LabelHostname] = name
+	})
+}
+
+func withLabels(labels map[string]string) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		pod.Labels = labels
+	}
+}
+
+func withAntiAffinity(labels map[string]string) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		pod.Spec.Affinity = &v1.Affinity{
+			PodAntiAffinity: &v1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+					{
+						LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+						TopologyKey:   v1.LabelHostname,
+					},
+				},
+			},
+		}
+	}
+}
+
+func buildPendingPod(name string, cpu int64, priority int32, apply ...func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, cpu, 0, "", func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Priority = utilptr.To(priority)
+		pod.Status.Phase = v1.PodPending
+		pod.Status.Conditions = []v1.PodCondition{
+			{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable},
+		}
+		for _, f := range apply {
+			f(pod)
+		}
+	})
+}
+
+func buildRunningPod(name, nodeName string, cpu int64, priority int32, apply ...func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, cpu, 0, nodeName, func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Priority = utilptr.To(priority)
+		pod.Status.Phase = v1.PodRunning
+		for _, f := range apply {
+			f(pod)
+		}
+	})
+}
+
+func buildFailedSchedulingEvent(pod *v1.Pod, count int32, age time.Duration, message string) *v1.Event {
+	return &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name + ".failed"},
+		InvolvedObject: v1.ObjectReference{
+			Kind:      "Pod",
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			UID:       pod.UID,
+		},
+		Reason:        failedSchedulingReason,
+		Message:       message,
+		Count:         count,
+		LastTimestamp: metav1.NewTime(time.Now().Add(-age)),
+	}
+}
+
+func TestFailedSchedulingFeedbackLoop(t *testing.T) {
+	node1 := buildNode("n1")
+	node2 := buildNode("n2")
+	// Where the blocking pods can be moved to
+	node3 := buildNode("n3")
+	appA := map[string]string{"app": "a"}
+	appB := map[string]string{"app": "b"}
+
+	pending := buildPendingPod("pending", 100, 100, withLabels(appB), withAntiAffinity(appA))
+
+	tests := []struct {
+		description          string
+		pods                 []*v1.Pod
+		events               []*v1.Event
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pod matching the anti-affinity of the pending pod is evicted",
+			pods: []*v1.Pod{
+				pending,
+				buildRunningPod("a1", "n1", 100, 0, withLabels(appA)),
+				buildRunningPod("a2", "n2", 100, 0, withLabels(appA)),
+				buildRunningPod("a3", "n2", 100, 0, withLabels(appA)),
+			},
+			events:               []*v1.Event{buildFailedSchedulingEvent(pending, 3, time.Minute, antiAffinityMessage)},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pod whose anti-affinity matches the pending pod is evicted",
+			pods: []*v1.Pod{
+				buildPendingPod("pending", 100, 100, func(pod *v1.Pod) { pod.UID = pending.UID }, withLabels(appB)),
+				buildRunningPod("b1", "n1", 100, 0, withAntiAffinity(appB)),
+				buildRunningPod("b2", "n2", 100, 0, withAntiAffinity(appB)),
+			},
+			events: []*v1.Event{
+				buildFailedSchedulingEvent(pending, 3, time.Minute, "0/2 nodes are available: 2 node(s) didn't satisfy existing pods anti-affinity rules."),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pod failing scheduling too few times is ignored",
+			pods: []*v1.Pod{
+				pending,
+				buildRunningPod("a1", "n1", 100, 0, withLabels(appA)),
+				buildRunningPod("a2", "n2", 100, 0, withLabels(appA)),
+			},
+			events:               []*v1.Event{buildFailedSchedulingEvent(pending, 2, time.Minute, antiAffinityMessage)},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Events last seen long ago are ignored",
+			pods: []*v1.Pod{
+				pending,
+				buildRunningPod("a1", "n1", 100, 0, withLabels(appA)),
+				buildRunningPod("a2", "n2", 100, 0, withLabels(appA)),
+			},
+			events:               []*v1.Event{buildFailedSchedulingEvent(pending, 3, time.Hour, antiAffinityMessage)},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Events of a former pod with the same name are ignored",
+			pods: []*v1.Pod{
+				buildPendingPod("pending", 100, 100, withLabels(appB), withAntiAffinity(appA)),
+				buildRunningPod("a1", "n1", 100, 0, withLabels(appA)),
+				buildRunningPod("a2", "n2", 100, 0, withLabels(appA)),
+			},
+			events:               []*v1.Event{buildFailedSchedulingEvent(pending, 3, time.Minute, antiAffinityMessage)},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Predicates no eviction can satisfy are only reported",
+			pods: []*v1.Pod{
+				pending,
+				buildRunningPod("a1", "n1", 100, 0, withLabels(appA)),
+				buildRunningPod("a2", "n2", 100, 0, withLabels(appA)),
+			},
+			events:               []*v1.Event{buildFailedSchedulingEvent(pending, 3, time.Minute, "0/2 nodes are available: 2 Insufficient cpu.")},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Blocking pod with a higher priority is not evicted",
+			pods: []*v1.Pod{
+				pending,
+				buildRunningPod("a1", "n1", 100, 1000, withLabels(appA)),
+				buildRunningPod("a2", "n2", 100, 1000, withLabels(appA)),
+			},
+			events:               []*v1.Event{buildFailedSchedulingEvent(pending, 3, time.Minute, antiAffinityMessage)},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Blocking pod is not evicted when the pending pod would still not fit",
+			pods: []*v1.Pod{
+				pending,
+				buildRunningPod("a1", "n1", 100, 0, withLabels(appA)),
+				buildRunningPod("c1", "n1", 1950, 0),
+				buildRunningPod("a2", "n2", 100, 0, withLabels(appA)),
+				buildRunningPod("c2", "n2", 1950, 0),
+			},
+			events:               []*v1.Event{buildFailedSchedulingEvent(pending, 3, time.Minute, antiAffinityMessage)},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Nodes with more blocking pods than allowed are skipped",
+			pods: []*v1.Pod{
+				pending,
+				buildRunningPod("a1", "n1", 100, 0, withLabels(appA)),
+				buildRunningPod("a2", "n1", 100, 0, withLabels(appA)),
+				buildRunningPod("a3", "n2", 100, 0, withLabels(appA)),
+				buildRunningPod("a4", "n2", 100, 0, withLabels(appA)),
+			},
+			events:               []*v1.Event{buildFailedSchedulingEvent(pending, 3, time.Minute, antiAffinityMessage)},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{node1, node2, node3}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			for _, event := range tc.events {
+				objs = append(objs, event)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := &FailedSchedulingFeedbackLoopArgs{}
+			SetDefaults_FailedSchedulingFeedbackLoopArgs(args)
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			handle.SharedInformerFactoryImpl.Start(ctx.Done())
+			handle.SharedInformerFactoryImpl.WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node1, node2, node3})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failedschedulingfeedbackloop
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// FailedSchedulingFeedbackLoopArgs holds arguments used to configure FailedSchedulingFeedbackLoop plugin.
+type FailedSchedulingFeedbackLoopArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// MinFailedAttempts is how many times the scheduler must have failed to place a pending pod
+	// before the pods blocking it get evicted, so transient failures are left to the scheduler.
+	MinFailedAttempts *int32 `json:"minFailedAttempts,omitempty"`
+	// MaxEventAgeSeconds ignores the FailedScheduling events last seen longer ago.
+	MaxEventAgeSeconds *uint `json:"maxEventAgeSeconds,omitempty"`
+	// MaxEvictionsPerPendingPod caps the blocking pods evicted to make room for a single pending pod.
+	MaxEvictionsPerPendingPod *int32 `json:"maxEvictionsPerPendingPod,omitempty"`
+}
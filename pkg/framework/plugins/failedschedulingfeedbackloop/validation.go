@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failedschedulingfeedbackloop
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateFailedSchedulingFeedbackLoopArgs validates FailedSchedulingFeedbackLoop arguments
+func ValidateFailedSchedulingFeedbackLoopArgs(obj runtime.Object) error {
+	args := obj.(*FailedSchedulingFeedbackLoopArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+	if args.MinFailedAttempts != nil && *args.MinFailedAttempts < 1 {
+		return fmt.Errorf("minFailedAttempts must be at least 1")
+	}
+	if args.MaxEvictionsPerPendingPod != nil && *args.MaxEvictionsPerPendingPod < 1 {
+		return fmt.Errorf("maxEvictionsPerPendingPod must be at least 1")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package failedschedulingfeedbackloop
+
+import (
+	"testing"
+
+	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateFailedSchedulingFeedbackLoopArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *FailedSchedulingFeedbackLoopArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &FailedSchedulingFeedbackLoopArgs{
+				MinFailedAttempts:         utilptr.To[int32](3),
+				MaxEvictionsPerPendingPod: utilptr.To[int32](1),
+			},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &FailedSchedulingFeedbackLoopArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "no failed attempts, expects error",
+			args: &FailedSchedulingFeedbackLoopArgs{
+				MinFailedAttempts: utilptr.To[int32](0),
+			},
+			expectError: true,
+		},
+		{
+			description: "no evictions per pending pod, expects error",
+			args: &FailedSchedulingFeedbackLoopArgs{
+				MaxEvictionsPerPendingPod: utilptr.To[int32](0),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateFailedSchedulingFeedbackLoopArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpusharefragmentationdefragmenter
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	// VendorNVIDIA models the GPU resources advertised by the NVIDIA device plugin
+	VendorNVIDIA = "nvidia"
+
+	nvidiaGPUResource       v1.ResourceName = "nvidia.com/gpu"
+	nvidiaSharedGPUResource v1.ResourceName = "nvidia.com/gpu.shared"
+	// nvidiaGPUCountLabel is set by the GPU feature discovery to the number of physical GPUs of the node
+	nvidiaGPUCountLabel = "nvidia.com/gpu.count"
+	// nvidiaMIGComputeSlices is the number of compute slices a MIG capable GPU is partitioned into
+	nvidiaMIGComputeSlices = 7
+)
+
+// nvidiaMIGResource matches the MIG devices advertised with the mixed strategy, e.g. nvidia.com/mig-3g.20gb
+var nvidiaMIGResource = regexp.MustCompile(`^nvidia\.com/mig-([0-9]+)g\.`)
+
+// gpuModel describes how the device plugin of a GPU vendor advertises the GPUs of the nodes and
+// the shares of GPUs, partitions or time-sliced replicas, requested by the pods.
+type gpuModel interface {
+	// physicalGPUs returns the number of physical GPUs of the node, whole or shared.
+	physicalGPUs(node *v1.Node) int64
+	// wholeGPUs returns the number of GPUs of the node allocatable as whole GPUs.
+	wholeGPUs(node *v1.Node) int64
+	// wholeGPURequest returns the number of whole GPUs requested by the pod.
+	wholeGPURequest(pod *v1.Pod) int64
+	// sharedGPURequest returns the fraction of physical GPUs requested by the pod through GPU shares.
+	sharedGPURequest(pod *v1.Pod) float64
+}
+
+// gpuModels holds the supported vendors, keyed by the value of the vendor argument
+var gpuModels = map[string]func(args *GPUShareFragmentationDefragmenterArgs) gpuModel{
+	VendorNVIDIA: newNVIDIAModel,
+}
+
+// nvidiaModel models the NVIDIA device plugin advertising whole GPUs as nvidia.com/gpu, MIG devices
+// with the mixed strategy and time-sliced replicas renamed to nvidia.com/gpu.shared.
+type nvidiaModel struct {
+	timeSlicingReplicas float64
+}
+
+func newNVIDIAModel(args *GPUShareFragmentationDefragmenterArgs) gpuModel {
+	return &nvidiaModel{timeSlicingReplicas: float64(*args.TimeSlicingReplicas)}
+}
+
+// share returns the fraction of a physical GPU held by one unit of the resource, 0 when the resource is no GPU share.
+func (m *nvidiaModel) share(name v1.ResourceName) float64 {
+	if name == nvidiaSharedGPUResource {
+		return 1 / m.timeSlicingReplicas
+	}
+	match := nvidiaMIGResource.FindStringSubmatch(string(name))
+	if match == nil {
+		return 0
+	}
+	slices, err := strconv.Atoi(match[1])
+	if err != nil || slices > nvidiaMIGComputeSlices {
+		return 0
+	}
+	return float64(slices) / nvidiaMIGComputeSlices
+}
+
+func (m *nvidiaModel) physicalGPUs(node *v1.Node) int64 {
+	if count, err := strconv.ParseInt(node.Labels[nvidiaGPUCountLabel], 10, 64); err == nil {
+		return count
+	}
+	// Without the GPU feature discovery, the shares are assumed to fill the GPUs they are carved from
+	var shared float64
+	for name, quantity := range node.Status.Allocatable {
+		shared += m.share(name) * float64(quantity.Value())
+	}
+	return m.wholeGPUs(node) + int64(math.Ceil(shared))
+}
+
+func (m *nvidiaModel) wholeGPUs(node *v1.Node) int64 {
+	quantity := node.Status.Allocatable[nvidiaGPUResource]
+	return quantity.Value()
+}
+
+func (m *nvidiaModel) wholeGPURequest(pod *v1.Pod) int64 {
+	return utils.GetResourceRequest(pod, nvidiaGPUResource)
+}
+
+func (m *nvidiaModel) sharedGPURequest(pod *v1.Pod) float64 {
+	requests, _ := utils.PodRequestsAndLimits(pod)
+	var shared float64
+	for name, quantity := range requests {
+		shared += m.share(name) * float64(quantity.Value())
+	}
+	return shared
+}
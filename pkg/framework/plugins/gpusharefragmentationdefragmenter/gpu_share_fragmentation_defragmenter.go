@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpusharefragmentationdefragmenter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "GPUShareFragmentationDefragmenter"
+
+// GPUShareFragmentationDefragmenter evicts the pods holding GPU shares, MIG partitions or time-sliced
+// replicas, off a node so its shared GPUs can be reconfigured as whole GPUs for a pending pod requesting
+// whole GPUs no node has available. The node freeing enough GPUs with the fewest evictions is picked and
+// its GPU-share pods are only evicted when all of them fit on another node.
+type GPUShareFragmentationDefragmenter struct {
+	handle    frameworktypes.Handle
+	args      *GPUShareFragmentationDefragmenterArgs
+	model     gpuModel
+	podFilter podutil.FilterFunc
+	podLister listersv1.PodLister
+}
+
+var _ frameworktypes.BalancePlugin = &GPUShareFragmentationDefragmenter{}
+
+var _ frameworktypes.AllPodsRequirer = &GPUShareFragmentationDefragmenterArgs{}
+
+// RequiresAllPods tells the GPUs in use on the nodes and the pending pods are computed from all the pods
+func (a *GPUShareFragmentationDefragmenterArgs) RequiresAllPods() bool {
+	return true
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	gpuArgs, ok := args.(*GPUShareFragmentationDefragmenterArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type GPUShareFragmentationDefragmenterArgs, got %T", args)
+	}
+	newModel, ok := gpuModels[gpuArgs.Vendor]
+	if !ok {
+		return nil, fmt.Errorf("unsupported GPU vendor %q", gpuArgs.Vendor)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if gpuArgs.Namespaces != nil {
+		includedNamespaces = sets.New(gpuArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(gpuArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(gpuArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &GPUShareFragmentationDefragmenter{
+		handle:    handle,
+		args:      gpuArgs,
+		model:     newModel(gpuArgs),
+		podFilter: podFilter,
+		podLister: handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *GPUShareFragmentationDefragmenter) Name() string {
+	return PluginName
+}
+
+// gpuNode holds the GPUs of a node and the pods sharing them
+type gpuNode struct {
+	node *v1.Node
+	// freeGPUs is the number of whole GPUs of the node no pod requests
+	freeGPUs int64
+	// sharedGPUs is the number of physical GPUs of the node handed out as GPU shares
+	sharedGPUs int64
+	sharePods  []*v1.Pod
+}
+
+// Balance extension point implementation for the plugin
+func (d *GPUShareFragmentationDefragmenter) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	pendingPods, err := d.pendingWholeGPUPods()
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pending pods: %v", err),
+		}
+	}
+	if len(pendingPods) == 0 {
+		logger.V(1).Info("No pending pod requesting whole GPUs")
+		return nil
+	}
+
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	var gpuNodes []*gpuNode
+	for _, node := range nodes {
+		physical := d.model.physicalGPUs(node)
+		if physical == 0 {
+			continue
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, nil)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		whole := d.model.wholeGPUs(node)
+		gn := &gpuNode{node: node, freeGPUs: whole, sharedGPUs: physical - whole}
+		for _, pod := range pods {
+			gn.freeGPUs -= d.model.wholeGPURequest(pod)
+			if d.model.sharedGPURequest(pod) > 0 {
+				gn.sharePods = append(gn.sharePods, pod)
+			}
+		}
+		gpuNodes = append(gpuNodes, gn)
+	}
+
+	defragmented := 0
+	for _, pending := range pendingPods {
+		if uint(defragmented) >= *d.args.MaxNodesPerCycle {
+			return nil
+		}
+		request := d.model.wholeGPURequest(pending)
+		if hasFreeGPUs(gpuNodes, pending, request) {
+			logger.V(3).Info("Pending pod is not waiting for GPUs", "pod", klog.KObj(pending))
+			continue
+		}
+		target := d.defragmentationTarget(gpuNodes, nodes, pending, request)
+		if target == nil {
+			logger.V(3).Info("No node can free enough GPUs for the pending pod", "pod", klog.KObj(pending), "gpus", request)
+			continue
+		}
+		logger.V(2).Info("Evicting GPU-share pods to free whole GPUs", "node", klog.KObj(target.node), "pendingPod", klog.KObj(pending), "gpus", request, "pods", len(target.sharePods))
+		defragmented++
+	loop:
+		for _, pod := range target.sharePods {
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+		// The freed GPUs are expected to be taken by the pending pod
+		target.freeGPUs = 0
+		target.sharedGPUs = 0
+		target.sharePods = nil
+	}
+	return nil
+}
+
+// pendingWholeGPUPods lists the pending pods requesting whole GPUs, from the highest priority to the lowest.
+func (d *GPUShareFragmentationDefragmenter) pendingWholeGPUPods() ([]*v1.Pod, error) {
+	pods, err := d.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var pendingPods []*v1.Pod
+	for _, pod := range pods {
+		if nodeutil.IsPendingPod(pod) && d.model.wholeGPURequest(pod) > 0 {
+			pendingPods = append(pendingPods, pod)
+		}
+	}
+	sort.SliceStable(pendingPods, func(i, j int) bool {
+		return corev1helpers.PodPriority(pendingPods[i]) > corev1helpers.PodPriority(pendingPods[j])
+	})
+	return pendingPods, nil
+}
+
+// defragmentationTarget returns the node the pending pod can be placed on once its GPU-share pods are
+// evicted, requiring the fewest evictions, or nil when there is none. The GPU-share pods of the node
+// must all be evictable and fit on another node.
+func (d *GPUShareFragmentationDefragmenter) defragmentationTarget(gpuNodes []*gpuNode, nodes []*v1.Node, pending *v1.Pod, request int64) *gpuNode {
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	var target *gpuNode
+	for _, gn := range gpuNodes {
+		if len(gn.sharePods) == 0 || gn.freeGPUs+gn.sharedGPUs < request || !admitsPod(gn.node, pending) {
+			continue
+		}
+		if target != nil && len(target.sharePods) <= len(gn.sharePods) {
+			continue
+		}
+		movable := true
+		for _, pod := range gn.sharePods {
+			if !d.podFilter(pod) || !nodeutil.PodFitsAnyOtherNode(getPodsAssignedToNode, pod, nodes) {
+				movable = false
+				break
+			}
+		}
+		if movable {
+			target = gn
+		}
+	}
+	return target
+}
+
+// hasFreeGPUs checks whether a node the pod can be placed on already has the requested whole GPUs free.
+func hasFreeGPUs(gpuNodes []*gpuNode, pod *v1.Pod, request int64) bool {
+	for _, gn := range gpuNodes {
+		if gn.freeGPUs >= request && admitsPod(gn.node, pod) {
+			return true
+		}
+	}
+	return false
+}
+
+// admitsPod checks the node is schedulable, matches the node selector and affinity of the pod and has
+// no taint the pod does not tolerate.
+func admitsPod(node *v1.Node, pod *v1.Pod) bool {
+	if nodeutil.IsNodeUnschedulable(node) {
+		return false
+	}
+	if ok, err := utils.PodMatchNodeSelector(pod, node); err != nil || !ok {
+		return false
+	}
+	return utils.TolerationsTolerateTaintsWithFilter(pod.Spec.Tolerations, node.Spec.Taints, func(taint *v1.Taint) bool {
+		return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
+	})
+}
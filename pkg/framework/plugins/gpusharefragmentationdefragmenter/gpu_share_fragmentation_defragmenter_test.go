@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpusharefragmentationdefragmenter
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+const migResource v1.ResourceName = "nvidia.com/mig-3g.20gb"
+
+// buildGPUNode builds a node with physical GPUs, of which wholeGPUs are allocatable as whole GPUs and
+// the others are shared through the given resource.
+func buildGPUNode(name string, physicalGPUs, wholeGPUs int64, shareResource v1.ResourceName, shares int64) *v1.Node {
+	return test.BuildTestNode(name, 8000, 16000000000, 20, func(node *v1.Node) {
+		node.Labels[nvidiaGPUCountLabel] = strconv.FormatInt(physicalGPUs, 10)
+		node.Status.Allocatable[nvidiaGPUResource] = *resource.NewQuantity(wholeGPUs, resource.DecimalSI)
+		node.Status.Allocatable[shareResource] = *resource.NewQuantity(shares, resource.DecimalSI)
+	})
+}
+
+func buildGPUPod(name, nodeName string, gpuResource v1.ResourceName, count int64) *v1.Pod {
+	return test.BuildTestPod(name, 100, 100, nodeName, func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Containers[0].Resources.Requests[gpuResource] = *resource.NewQuantity(count, resource.DecimalSI)
+	})
+}
+
+func buildPendingPod(name string, gpus int64) *v1.Pod {
+	pod := buildGPUPod(name, "", nvidiaGPUResource, gpus)
+	pod.Status.Phase = v1.PodPending
+	return pod
+}
+
+func TestGPUShareFragmentationDefragmenter(t *testing.T) {
+	// n1 and n2 have 2 of their 4 GPUs partitioned, n3 has room for the partitions of the others
+	n1 := buildGPUNode("n1", 4, 2, migResource, 4)
+	n2 := buildGPUNode("n2", 4, 2, migResource, 4)
+	n3 := buildGPUNode("n3", 2, 0, migResource, 4)
+	fragmentedPods := []*v1.Pod{
+		buildGPUPod("n1-whole", "n1", nvidiaGPUResource, 2),
+		buildGPUPod("n1-mig-1", "n1", migResource, 1),
+		buildGPUPod("n1-mig-2", "n1", migResource, 1),
+		buildGPUPod("n2-whole", "n2", nvidiaGPUResource, 2),
+		buildGPUPod("n2-mig-1", "n2", migResource, 1),
+	}
+
+	tests := []struct {
+		description          string
+		args                 GPUShareFragmentationDefragmenterArgs
+		nodes                []*v1.Node
+		pods                 []*v1.Pod
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "GPU-share pods of the node with the fewest shares are evicted",
+			nodes:                []*v1.Node{n1, n2, n3},
+			pods:                 append([]*v1.Pod{buildPendingPod("pending", 2)}, fragmentedPods...),
+			expectedEvictedCount: 1,
+		},
+		{
+			description:          "No pending pod requesting whole GPUs, no eviction",
+			nodes:                []*v1.Node{n1, n2, n3},
+			pods:                 fragmentedPods,
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Pending pod requesting more GPUs than a node can free, no eviction",
+			nodes:                []*v1.Node{n1, n2, n3},
+			pods:                 append([]*v1.Pod{buildPendingPod("pending", 3)}, fragmentedPods...),
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Node with free whole GPUs, no eviction",
+			nodes:                []*v1.Node{n1, n2, n3, buildGPUNode("n4", 2, 2, migResource, 0)},
+			pods:                 append([]*v1.Pod{buildPendingPod("pending", 2)}, fragmentedPods...),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "GPU-share pods fitting no other node, no eviction",
+			nodes: []*v1.Node{
+				buildGPUNode("n1", 4, 2, migResource, 2),
+				buildGPUNode("n2", 4, 2, migResource, 1),
+			},
+			pods:                 append([]*v1.Pod{buildPendingPod("pending", 2)}, fragmentedPods...),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "GPU-share pods of excluded namespaces are not evicted",
+			args: GPUShareFragmentationDefragmenterArgs{
+				FilteringArgs: api.FilteringArgs{Namespaces: &api.Namespaces{Exclude: []string{"default"}}},
+			},
+			nodes:                []*v1.Node{n1, n2, n3},
+			pods:                 append([]*v1.Pod{buildPendingPod("pending", 2)}, fragmentedPods...),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Several nodes are defragmented up to the limit",
+			nodes:       []*v1.Node{n1, n2, n3, buildGPUNode("n4", 2, 0, migResource, 8)},
+			pods: append([]*v1.Pod{
+				buildPendingPod("pending-1", 2),
+				buildPendingPod("pending-2", 2),
+			}, fragmentedPods...),
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Time-sliced pods are evicted",
+			nodes: []*v1.Node{
+				buildGPUNode("n1", 2, 0, nvidiaSharedGPUResource, 8),
+				buildGPUNode("n2", 1, 0, nvidiaSharedGPUResource, 4),
+			},
+			pods: []*v1.Pod{
+				buildPendingPod("pending", 1),
+				buildGPUPod("n1-shared-1", "n1", nvidiaSharedGPUResource, 1),
+				buildGPUPod("n1-shared-2", "n1", nvidiaSharedGPUResource, 1),
+			},
+			expectedEvictedCount: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := tc.args.DeepCopy()
+			SetDefaults_GPUShareFragmentationDefragmenterArgs(args)
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
+
+func TestNVIDIAModel(t *testing.T) {
+	model := newNVIDIAModel(&GPUShareFragmentationDefragmenterArgs{TimeSlicingReplicas: ptr.To[uint](4)})
+
+	node := buildGPUNode("n1", 4, 1, migResource, 2)
+	if got := model.physicalGPUs(node); got != 4 {
+		t.Errorf("Expected 4 physical GPUs from the GPU count label, got %d", got)
+	}
+	delete(node.Labels, nvidiaGPUCountLabel)
+	node.Status.Allocatable[nvidiaSharedGPUResource] = *resource.NewQuantity(8, resource.DecimalSI)
+	// 1 whole GPU, 2 3g partitions filling a GPU and 8 time-sliced replicas of 2 GPUs
+	if got := model.physicalGPUs(node); got != 4 {
+		t.Errorf("Expected 4 physical GPUs derived from the allocatable resources, got %d", got)
+	}
+
+	pod := buildGPUPod("p1", "n1", migResource, 2)
+	pod.Spec.Containers[0].Resources.Requests[nvidiaSharedGPUResource] = *resource.NewQuantity(1, resource.DecimalSI)
+	if got, want := model.sharedGPURequest(pod), 6.0/7+1.0/4; got != want {
+		t.Errorf("Expected a GPU share of %v, got %v", want, got)
+	}
+	if got := model.wholeGPURequest(pod); got != 0 {
+		t.Errorf("Expected no whole GPU requested, got %d", got)
+	}
+}
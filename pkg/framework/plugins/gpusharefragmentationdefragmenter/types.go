@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpusharefragmentationdefragmenter
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GPUShareFragmentationDefragmenterArgs holds arguments used to configure GPUShareFragmentationDefragmenter plugin.
+type GPUShareFragmentationDefragmenterArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// Vendor selects the model of the GPU resources advertised by the device plugin of the nodes.
+	// Only nvidia is supported for now. Defaults to nvidia.
+	Vendor string `json:"vendor,omitempty"`
+	// TimeSlicingReplicas is the number of replicas each physical GPU is shared into when the
+	// GPUs are time-sliced. Defaults to 4.
+	TimeSlicingReplicas *uint `json:"timeSlicingReplicas,omitempty"`
+	// MaxNodesPerCycle is the number of nodes whose GPU-share pods are evicted per descheduling
+	// cycle. Defaults to 1.
+	MaxNodesPerCycle *uint `json:"maxNodesPerCycle,omitempty"`
+}
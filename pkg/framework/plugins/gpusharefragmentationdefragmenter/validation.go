@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpusharefragmentationdefragmenter
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateGPUShareFragmentationDefragmenterArgs validates GPUShareFragmentationDefragmenter arguments
+func ValidateGPUShareFragmentationDefragmenterArgs(obj runtime.Object) error {
+	args := obj.(*GPUShareFragmentationDefragmenterArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if _, ok := gpuModels[args.Vendor]; !ok {
+		return fmt.Errorf("unsupported GPU vendor %q", args.Vendor)
+	}
+	if args.TimeSlicingReplicas != nil && *args.TimeSlicingReplicas == 0 {
+		return fmt.Errorf("timeSlicingReplicas must be greater than 0")
+	}
+	if args.MaxNodesPerCycle != nil && *args.MaxNodesPerCycle == 0 {
+		return fmt.Errorf("maxNodesPerCycle must be greater than 0")
+	}
+
+	return nil
+}
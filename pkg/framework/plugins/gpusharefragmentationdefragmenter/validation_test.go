@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gpusharefragmentationdefragmenter
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateGPUShareFragmentationDefragmenterArgs(t *testing.T) {
+	validArgs := func(mutate func(*GPUShareFragmentationDefragmenterArgs)) *GPUShareFragmentationDefragmenterArgs {
+		args := &GPUShareFragmentationDefragmenterArgs{}
+		SetDefaults_GPUShareFragmentationDefragmenterArgs(args)
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *GPUShareFragmentationDefragmenterArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *GPUShareFragmentationDefragmenterArgs) {
+				args.Namespaces = &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "unsupported vendor, expects error",
+			args: validArgs(func(args *GPUShareFragmentationDefragmenterArgs) {
+				args.Vendor = "unknown"
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero time-slicing replicas, expects error",
+			args: validArgs(func(args *GPUShareFragmentationDefragmenterArgs) {
+				args.TimeSlicingReplicas = ptr.To[uint](0)
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero max nodes per cycle, expects error",
+			args: validArgs(func(args *GPUShareFragmentationDefragmenterArgs) {
+				args.MaxNodesPerCycle = ptr.To[uint](0)
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateGPUShareFragmentationDefragmenterArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
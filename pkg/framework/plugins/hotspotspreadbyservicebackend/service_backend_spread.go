@@ -0,0 +1,240 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hotspotspreadbyservicebackend
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	discoverylisters "k8s.io/client-go/listers/discovery/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "HotspotSpreadByServiceBackend"
+
+// HotspotSpreadByServiceBackend evicts the backends of a Service when too many of its endpoints
+// run on a single node, so the failure of the node does not take the Service down, even when the
+// pods declare no topology spread constraint. The endpoints are read from the EndpointSlices.
+type HotspotSpreadByServiceBackend struct {
+	handle              frameworktypes.Handle
+	args                *HotspotSpreadByServiceBackendArgs
+	podFilter           podutil.FilterFunc
+	endpointSliceLister discoverylisters.EndpointSliceLister
+}
+
+var _ frameworktypes.BalancePlugin = &HotspotSpreadByServiceBackend{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	spreadArgs, ok := args.(*HotspotSpreadByServiceBackendArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type HotspotSpreadByServiceBackendArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if spreadArgs.Namespaces != nil {
+		includedNamespaces = sets.New(spreadArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(spreadArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(spreadArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &HotspotSpreadByServiceBackend{
+		handle:    handle,
+		args:      spreadArgs,
+		podFilter: podFilter,
+		// The EndpointSlices are read from the cluster, the cached client of the dry run mode holds none
+		endpointSliceLister: handle.ClusterInformerFactory().Discovery().V1().EndpointSlices().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *HotspotSpreadByServiceBackend) Name() string {
+	return PluginName
+}
+
+// serviceBackends maps the names of the pods backing a Service to the nodes they run on
+type serviceBackends map[string]string
+
+// listServiceBackends returns the backends of each Service. The endpoints
+// of the pods being terminated are left out, as are the ones listed by several EndpointSlices only
+// counted once, e.g. the IPv4 and IPv6 endpoints of a dual stack Service.
+func (d *HotspotSpreadByServiceBackend) listServiceBackends() (map[types.NamespacedName]serviceBackends, error) {
+	slices, err := d.endpointSliceLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	services := map[types.NamespacedName]serviceBackends{}
+	for _, slice := range slices {
+		serviceName, ok := slice.Labels[discoveryv1.LabelServiceName]
+		if !ok || serviceName == "" {
+			continue
+		}
+		key := types.NamespacedName{Namespace: slice.Namespace, Name: serviceName}
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" || endpoint.NodeName == nil {
+				continue
+			}
+			if endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating {
+				continue
+			}
+			if services[key] == nil {
+				services[key] = serviceBackends{}
+			}
+			services[key][endpoint.TargetRef.Name] = *endpoint.NodeName
+		}
+	}
+	return services, nil
+}
+
+// maxBackendsPerNode returns how many backends of a Service with the given number of
+// backends may run on a single node, at least one
+func (d *HotspotSpreadByServiceBackend) maxBackendsPerNode(backends int) int {
+	if maxBackends := int(math.Floor(float64(backends) * *d.args.MaxFractionPerNode)); maxBackends > 1 {
+		return maxBackends
+	}
+	return 1
+}
+
+// Balance extension point implementation for the plugin
+func (d *HotspotSpreadByServiceBackend) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	services, err := d.listServiceBackends()
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing endpoint slices: %v", err),
+		}
+	}
+
+	nodesByName := make(map[string]*v1.Node, len(nodes))
+	for _, node := range nodes {
+		nodesByName[node.Name] = node
+	}
+	// The pods are listed once per node and cycle
+	podsByNode := map[string][]*v1.Pod{}
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	// A pod backing several Services is evicted once
+	evicted := sets.New[types.UID]()
+
+	keys := make([]types.NamespacedName, 0, len(services))
+	for key := range services {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	for _, key := range keys {
+		backends := services[key]
+		if len(backends) < int(*d.args.MinEndpoints) {
+			continue
+		}
+		maxBackends := d.maxBackendsPerNode(len(backends))
+		// Spreading the backends is not possible with too few nodes
+		if neededNodes := (len(backends) + maxBackends - 1) / maxBackends; neededNodes > len(nodes) {
+			logger.V(2).Info("Not enough nodes to spread the backends of the service", "service", key, "backends", len(backends), "maxBackendsPerNode", maxBackends, "nodes", len(nodes))
+			continue
+		}
+
+		backendsByNode := map[string]sets.Set[string]{}
+		for podName, nodeName := range backends {
+			if backendsByNode[nodeName] == nil {
+				backendsByNode[nodeName] = sets.New[string]()
+			}
+			backendsByNode[nodeName].Insert(podName)
+		}
+		nodeNames := make([]string, 0, len(backendsByNode))
+		for nodeName := range backendsByNode {
+			nodeNames = append(nodeNames, nodeName)
+		}
+		sort.Strings(nodeNames)
+
+		var serviceEvicted uint
+		for _, nodeName := range nodeNames {
+			excess := backendsByNode[nodeName].Len() - maxBackends
+			node, ok := nodesByName[nodeName]
+			if excess <= 0 || !ok {
+				continue
+			}
+			logger.V(1).Info("Too many backends of the service on a single node", "service", key, "node", klog.KObj(node), "backends", backendsByNode[nodeName].Len(), "maxBackendsPerNode", maxBackends)
+
+			pods, ok := podsByNode[nodeName]
+			if !ok {
+				pods, err = podutil.ListPodsOnANode(nodeName, getPodsAssignedToNode, d.podFilter)
+				if err != nil {
+					// no pods evicted as error encountered retrieving evictable Pods
+					return &frameworktypes.Status{
+						Err: fmt.Errorf("error listing pods on a node: %v", err),
+					}
+				}
+				// Move the least important pods first in case the limits are reached
+				podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+				podsByNode[nodeName] = pods
+			}
+
+			for _, pod := range pods {
+				if excess == 0 || serviceEvicted >= *d.args.MaxEvictionsPerService {
+					break
+				}
+				if pod.Namespace != key.Namespace || !backendsByNode[nodeName].Has(pod.Name) || evicted.Has(pod.UID) {
+					continue
+				}
+				if !nodeutil.PodFitsAnyOtherNode(getPodsAssignedToNode, pod, nodes) {
+					logger.V(2).Info("Backend does not fit any other node", "pod", klog.KObj(pod), "service", key)
+					continue
+				}
+				err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName, Reason: fmt.Sprintf("too many backends of service %s on the node", key)})
+				if err == nil {
+					evicted.Insert(pod.UID)
+					serviceEvicted++
+					excess--
+					continue
+				}
+				switch err.(type) {
+				case *evictions.EvictionNodeLimitError:
+					excess = 0
+				case *evictions.EvictionTotalLimitError:
+					return nil
+				default:
+					logger.Error(err, "Eviction failed")
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hotspotspreadbyservicebackend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildPod(name, nodeName string) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		pod.Namespace = "ns1"
+		test.SetRSOwnerRef(pod)
+	})
+}
+
+// buildEndpointSlice builds an EndpointSlice of the service listing the given pods
+func buildEndpointSlice(name, service string, pods ...*v1.Pod) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns1",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	for _, pod := range pods {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses: []string{"10.0.0.1"},
+			NodeName:  ptr.To(pod.Spec.NodeName),
+			TargetRef: &v1.ObjectReference{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name},
+		})
+	}
+	return slice
+}
+
+func TestHotspotSpreadByServiceBackend(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, nil)
+	n2 := test.BuildTestNode("n2", 4000, 3000, 20, nil)
+	n3 := test.BuildTestNode("n3", 4000, 3000, 20, nil)
+
+	var onN1 []*v1.Pod
+	for i := 0; i < 4; i++ {
+		onN1 = append(onN1, buildPod(fmt.Sprintf("p%d", i), "n1"))
+	}
+	onN2 := buildPod("p4", "n2")
+
+	external := buildEndpointSlice("svc1-external", "svc1", onN1[1])
+	external.Endpoints[0].TargetRef = nil
+
+	terminating := buildEndpointSlice("svc1-terminating", "svc1", onN1[2], onN1[3])
+	for i := range terminating.Endpoints {
+		terminating.Endpoints[i].Conditions.Terminating = ptr.To(true)
+	}
+
+	tests := []struct {
+		description          string
+		args                 HotspotSpreadByServiceBackendArgs
+		objects              []runtime.Object
+		nodes                []*v1.Node
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "Spread service is left alone",
+			objects:              []runtime.Object{buildEndpointSlice("svc1-a", "svc1", onN1[0], onN2)},
+			nodes:                []*v1.Node{n1, n2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Backend of a service with all its endpoints on a node is evicted",
+			objects:              []runtime.Object{buildEndpointSlice("svc1-a", "svc1", onN1[0], onN1[1])},
+			nodes:                []*v1.Node{n1, n2},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Backends are evicted up to the limit per service",
+			args:        HotspotSpreadByServiceBackendArgs{MaxEvictionsPerService: ptr.To[uint](2)},
+			objects: []runtime.Object{
+				buildEndpointSlice("svc1-a", "svc1", onN1[0], onN1[1], onN1[2], onN1[3]),
+			},
+			nodes:                []*v1.Node{n1, n2},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Endpoints listed by several slices are counted once",
+			args:        HotspotSpreadByServiceBackendArgs{MaxFractionPerNode: ptr.To(0.6)},
+			objects: []runtime.Object{
+				buildEndpointSlice("svc1-ipv4", "svc1", onN1[0], onN2),
+				buildEndpointSlice("svc1-ipv6", "svc1", onN1[0], onN2),
+			},
+			nodes:                []*v1.Node{n1, n2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Terminating endpoints are not counted",
+			objects: []runtime.Object{
+				buildEndpointSlice("svc1-a", "svc1", onN1[0], onN2),
+				terminating,
+			},
+			nodes:                []*v1.Node{n1, n2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Services with too few endpoints are left alone",
+			args:                 HotspotSpreadByServiceBackendArgs{MinEndpoints: ptr.To[uint](3)},
+			objects:              []runtime.Object{buildEndpointSlice("svc1-a", "svc1", onN1[0], onN1[1])},
+			nodes:                []*v1.Node{n1, n2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Services with too many endpoints per node for the nodes are left alone",
+			args:                 HotspotSpreadByServiceBackendArgs{MaxFractionPerNode: ptr.To(0.25)},
+			objects:              []runtime.Object{buildEndpointSlice("svc1-a", "svc1", onN1...)},
+			nodes:                []*v1.Node{n1, n2, n3},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Backends of several services are evicted once",
+			objects: []runtime.Object{
+				buildEndpointSlice("svc1-a", "svc1", onN1[0], onN1[1]),
+				buildEndpointSlice("svc2-a", "svc2", onN1[0], onN1[1]),
+			},
+			nodes:                []*v1.Node{n1, n2},
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Endpoints without pod are ignored",
+			objects:              []runtime.Object{buildEndpointSlice("svc1-a", "svc1", onN1[0]), external},
+			nodes:                []*v1.Node{n1, n2},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{n1, n2, n3, onN2}
+			for _, pod := range onN1 {
+				objs = append(objs, pod)
+			}
+			objs = append(objs, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			SetDefaults_HotspotSpreadByServiceBackendArgs(&tc.args)
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			// Start the EndpointSlice informer requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hotspotspreadbyservicebackend
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HotspotSpreadByServiceBackendArgs holds arguments used to configure HotspotSpreadByServiceBackend plugin.
+type HotspotSpreadByServiceBackendArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// MaxFractionPerNode is the highest fraction of the endpoints of a Service allowed on a
+	// single node, at least one endpoint is always allowed. Defaults to 0.5.
+	MaxFractionPerNode *float64 `json:"maxFractionPerNode,omitempty"`
+	// MinEndpoints is the number of endpoints below which the Services are left alone. Defaults to 2.
+	MinEndpoints *uint `json:"minEndpoints,omitempty"`
+	// MaxEvictionsPerService is the number of backends of a Service evicted per descheduling cycle.
+	// Defaults to 1.
+	MaxEvictionsPerService *uint `json:"maxEvictionsPerService,omitempty"`
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hotspotspreadbyservicebackend
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateHotspotSpreadByServiceBackendArgs validates HotspotSpreadByServiceBackend arguments
+func ValidateHotspotSpreadByServiceBackendArgs(obj runtime.Object) error {
+	args := obj.(*HotspotSpreadByServiceBackendArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.MaxFractionPerNode != nil && (*args.MaxFractionPerNode <= 0 || *args.MaxFractionPerNode >= 1) {
+		return fmt.Errorf("maxFractionPerNode must be greater than 0 and lower than 1, got %v", *args.MaxFractionPerNode)
+	}
+	if args.MinEndpoints != nil && *args.MinEndpoints < 2 {
+		return fmt.Errorf("minEndpoints must be at least 2, got %d", *args.MinEndpoints)
+	}
+	if args.MaxEvictionsPerService != nil && *args.MaxEvictionsPerService == 0 {
+		return fmt.Errorf("maxEvictionsPerService must be greater than 0")
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hotspotspreadbyservicebackend
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateHotspotSpreadByServiceBackendArgs(t *testing.T) {
+	validArgs := func(mutate func(*HotspotSpreadByServiceBackendArgs)) *HotspotSpreadByServiceBackendArgs {
+		args := &HotspotSpreadByServiceBackendArgs{}
+		SetDefaults_HotspotSpreadByServiceBackendArgs(args)
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *HotspotSpreadByServiceBackendArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *HotspotSpreadByServiceBackendArgs) {
+				args.Namespaces = &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects error",
+			args: validArgs(func(args *HotspotSpreadByServiceBackendArgs) {
+				args.LabelSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+				}
+			}),
+			expectError: true,
+		},
+		{
+			description: "max fraction per node of 1, expects error",
+			args: validArgs(func(args *HotspotSpreadByServiceBackendArgs) {
+				args.MaxFractionPerNode = ptr.To(1.0)
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero max fraction per node, expects error",
+			args: validArgs(func(args *HotspotSpreadByServiceBackendArgs) {
+				args.MaxFractionPerNode = ptr.To(0.0)
+			}),
+			expectError: true,
+		},
+		{
+			description: "min endpoints lower than 2, expects error",
+			args: validArgs(func(args *HotspotSpreadByServiceBackendArgs) {
+				args.MinEndpoints = ptr.To[uint](1)
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero max evictions per service, expects error",
+			args: validArgs(func(args *HotspotSpreadByServiceBackendArgs) {
+				args.MaxEvictionsPerService = ptr.To[uint](0)
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateHotspotSpreadByServiceBackendArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hugepagesandreservedmemorymisfitevictor
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DefaultReservedMemoryLabel is the label nodes advertise the reserved memory of their memory manager with
+const DefaultReservedMemoryLabel = "memory-manager.descheduler.alpha.kubernetes.io/reserved-memory"
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_HugePagesAndReservedMemoryMisfitEvictorArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_HugePagesAndReservedMemoryMisfitEvictorArgs(obj runtime.Object) {
+	args := obj.(*HugePagesAndReservedMemoryMisfitEvictorArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.ReservedMemoryLabel == "" {
+		args.ReservedMemoryLabel = DefaultReservedMemoryLabel
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hugepagesandreservedmemorymisfitevictor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	PluginName = "HugePagesAndReservedMemoryMisfitEvictor"
+
+	// RequiresReservedMemoryAnnotation marks, when set to "true", the pods requiring the memory manager
+	// of their node to reserve memory, e.g. to be pinned to NUMA nodes by its static policy.
+	RequiresReservedMemoryAnnotation = "descheduler.alpha.kubernetes.io/requires-reserved-memory"
+)
+
+// HugePagesAndReservedMemoryMisfitEvictor evicts the pods requesting hugepages, or requiring reserved memory,
+// from the nodes which stopped provisioning them, e.g. after the kernel command line of a node image dropped the
+// hugepages of a size or the reserved memory label got removed. A pod is only evicted when another node provisions
+// all its requirements and fits it, hugepages of each requested size included.
+type HugePagesAndReservedMemoryMisfitEvictor struct {
+	handle    frameworktypes.Handle
+	args      *HugePagesAndReservedMemoryMisfitEvictorArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &HugePagesAndReservedMemoryMisfitEvictor{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	misfitArgs, ok := args.(*HugePagesAndReservedMemoryMisfitEvictorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type HugePagesAndReservedMemoryMisfitEvictorArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if misfitArgs.Namespaces != nil {
+		includedNamespaces = sets.New(misfitArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(misfitArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(misfitArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &HugePagesAndReservedMemoryMisfitEvictor{
+		handle:    handle,
+		args:      misfitArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *HugePagesAndReservedMemoryMisfitEvictor) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *HugePagesAndReservedMemoryMisfitEvictor) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			sizes := podHugePages(pod)
+			reservedMemory := pod.Annotations[RequiresReservedMemoryAnnotation] == "true"
+			missing := d.missingRequirements(node, sizes, reservedMemory)
+			if len(missing) == 0 {
+				continue
+			}
+
+			var provisioningNodes []*v1.Node
+			for _, candidate := range nodes {
+				if len(d.missingRequirements(candidate, sizes, reservedMemory)) == 0 {
+					provisioningNodes = append(provisioningNodes, candidate)
+				}
+			}
+			if !nodeutil.PodFitsAnyOtherNode(d.handle.GetPodsAssignedToNodeFunc(), pod, provisioningNodes) {
+				logger.V(2).Info("Pod requires hugepages or reserved memory its node does not provision, but no other node provisions them and fits it", "pod", klog.KObj(pod), "node", klog.KObj(node), "missing", missing)
+				continue
+			}
+
+			reason := fmt.Sprintf("node does not provision %s", strings.Join(missing, ", "))
+			logger.V(2).Info("Pod requires hugepages or reserved memory its node does not provision", "pod", klog.KObj(pod), "node", klog.KObj(node), "missing", missing)
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName, Reason: reason})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// missingRequirements returns the hugepage resources and the reserved memory the node does not provision.
+// A node provisions the hugepages of a size when it allocates some, and advertises them when
+// HugePagesLabelPrefix is set.
+func (d *HugePagesAndReservedMemoryMisfitEvictor) missingRequirements(node *v1.Node, sizes []v1.ResourceName, reservedMemory bool) []string {
+	var missing []string
+	for _, size := range sizes {
+		allocatable, ok := node.Status.Allocatable[size]
+		if !ok || allocatable.IsZero() {
+			missing = append(missing, string(size))
+			continue
+		}
+		if d.args.HugePagesLabelPrefix != "" {
+			if _, ok := node.Labels[d.args.HugePagesLabelPrefix+string(size)]; !ok {
+				missing = append(missing, string(size))
+			}
+		}
+	}
+	if reservedMemory {
+		if _, ok := node.Labels[d.args.ReservedMemoryLabel]; !ok {
+			missing = append(missing, "reserved memory")
+		}
+	}
+	return missing
+}
+
+// podHugePages returns the hugepage resources the containers of the pod request, or its
+// emptyDir volumes are backed by
+func podHugePages(pod *v1.Pod) []v1.ResourceName {
+	sizes := sets.New[v1.ResourceName]()
+	requests, limits := utils.PodRequestsAndLimits(pod)
+	for _, list := range []v1.ResourceList{requests, limits} {
+		for name, quantity := range list {
+			if strings.HasPrefix(string(name), v1.ResourceHugePagesPrefix) && !quantity.IsZero() {
+				sizes.Insert(name)
+			}
+		}
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir == nil {
+			continue
+		}
+		if size, ok := strings.CutPrefix(string(volume.EmptyDir.Medium), string(v1.StorageMediumHugePagesPrefix)); ok && size != "" {
+			sizes.Insert(v1.ResourceName(v1.ResourceHugePagesPrefix + size))
+		}
+	}
+	return sets.List(sizes)
+}
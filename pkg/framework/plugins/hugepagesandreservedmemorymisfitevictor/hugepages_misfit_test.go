@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hugepagesandreservedmemorymisfitevictor
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestHugePagesAndReservedMemoryMisfitEvictor(t *testing.T) {
+	hugePages2Mi := v1.ResourceName(v1.ResourceHugePagesPrefix + "2Mi")
+	hugePages1Gi := v1.ResourceName(v1.ResourceHugePagesPrefix + "1Gi")
+
+	buildNode := func(name string, hugePages map[v1.ResourceName]string, labels ...string) *v1.Node {
+		return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+			node.Labels = map[string]string{}
+			for _, label := range labels {
+				node.Labels[label] = "true"
+			}
+			for size, quantity := range hugePages {
+				node.Status.Allocatable[size] = resource.MustParse(quantity)
+				node.Status.Capacity[size] = resource.MustParse(quantity)
+			}
+		})
+	}
+	buildPod := func(name string, apply func(*v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, "n1", func(pod *v1.Pod) {
+			pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	withHugePages := func(size v1.ResourceName, quantity string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.Containers[0].Resources.Requests[size] = resource.MustParse(quantity)
+		}
+	}
+	withHugePagesVolume := func(medium v1.StorageMedium) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+				Name:         "hugepages",
+				VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{Medium: medium}},
+			})
+		}
+	}
+	withReservedMemory := func(pod *v1.Pod) {
+		pod.Annotations = map[string]string{RequiresReservedMemoryAnnotation: "true"}
+	}
+
+	tests := []struct {
+		description          string
+		args                 HugePagesAndReservedMemoryMisfitEvictorArgs
+		nodes                []*v1.Node
+		pods                 []*v1.Pod
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pods requesting hugepages their node stopped allocating are evicted",
+			nodes: []*v1.Node{
+				buildNode("n1", map[v1.ResourceName]string{hugePages2Mi: "0"}),
+				buildNode("n2", map[v1.ResourceName]string{hugePages2Mi: "1Gi"}),
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", withHugePages(hugePages2Mi, "100Mi")),
+				buildPod("p2", withHugePagesVolume(v1.StorageMediumHugePagesPrefix+"2Mi")),
+				buildPod("p3", nil),
+			},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Pods requesting hugepages their node allocates are not evicted",
+			nodes: []*v1.Node{
+				buildNode("n1", map[v1.ResourceName]string{hugePages2Mi: "1Gi"}),
+				buildNode("n2", map[v1.ResourceName]string{hugePages2Mi: "1Gi"}),
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", withHugePages(hugePages2Mi, "100Mi")),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods are not evicted when other nodes only allocate hugepages of another size",
+			nodes: []*v1.Node{
+				buildNode("n1", nil),
+				buildNode("n2", map[v1.ResourceName]string{hugePages2Mi: "4Gi"}),
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", withHugePages(hugePages1Gi, "1Gi")),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods are not evicted when they do not fit the hugepages left on the other nodes",
+			nodes: []*v1.Node{
+				buildNode("n1", nil),
+				buildNode("n2", map[v1.ResourceName]string{hugePages1Gi: "1Gi"}),
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", withHugePages(hugePages1Gi, "2Gi")),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods requesting hugepages their node stopped advertising are evicted",
+			args: HugePagesAndReservedMemoryMisfitEvictorArgs{
+				HugePagesLabelPrefix: "feature.example.com/",
+			},
+			nodes: []*v1.Node{
+				buildNode("n1", map[v1.ResourceName]string{hugePages2Mi: "1Gi"}),
+				buildNode("n2", map[v1.ResourceName]string{hugePages2Mi: "1Gi"}, "feature.example.com/hugepages-2Mi"),
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", withHugePages(hugePages2Mi, "100Mi")),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods requiring reserved memory their node stopped advertising are evicted",
+			nodes:       []*v1.Node{buildNode("n1", nil), buildNode("n2", nil, DefaultReservedMemoryLabel)},
+			pods: []*v1.Pod{
+				buildPod("p1", withReservedMemory),
+				buildPod("p2", nil),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods are not evicted when no other node provisions all their requirements",
+			nodes: []*v1.Node{
+				buildNode("n1", nil),
+				buildNode("n2", map[v1.ResourceName]string{hugePages2Mi: "1Gi"}),
+				buildNode("n3", nil, DefaultReservedMemoryLabel),
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", func(pod *v1.Pod) {
+					withHugePages(hugePages2Mi, "100Mi")(pod)
+					withReservedMemory(pod)
+				}),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of namespaces not opted in are not evicted",
+			args: HugePagesAndReservedMemoryMisfitEvictorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"opted-in"}},
+				},
+			},
+			nodes: []*v1.Node{buildNode("n1", nil), buildNode("n2", nil, DefaultReservedMemoryLabel)},
+			pods: []*v1.Pod{
+				buildPod("p1", withReservedMemory),
+			},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				// The hugepages are mounted through emptyDir volumes
+				defaultevictor.DefaultEvictorArgs{EvictLocalStoragePods: true},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			SetDefaults_HugePagesAndReservedMemoryMisfitEvictorArgs(&tc.args)
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
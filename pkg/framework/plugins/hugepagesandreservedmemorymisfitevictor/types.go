@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hugepagesandreservedmemorymisfitevictor
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HugePagesAndReservedMemoryMisfitEvictorArgs holds arguments used to configure HugePagesAndReservedMemoryMisfitEvictor plugin.
+type HugePagesAndReservedMemoryMisfitEvictorArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// HugePagesLabelPrefix, when set, is prepended to the name of a hugepage resource, e.g. hugepages-2Mi,
+	// to build the label nodes advertise the hugepages of that size with, on top of allocating them.
+	HugePagesLabelPrefix string `json:"hugePagesLabelPrefix,omitempty"`
+	// ReservedMemoryLabel is the label nodes advertise the reserved memory of their memory manager with.
+	// Defaults to "memory-manager.descheduler.alpha.kubernetes.io/reserved-memory".
+	ReservedMemoryLabel string `json:"reservedMemoryLabel,omitempty"`
+}
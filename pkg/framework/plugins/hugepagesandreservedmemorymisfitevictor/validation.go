@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hugepagesandreservedmemorymisfitevictor
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateHugePagesAndReservedMemoryMisfitEvictorArgs validates HugePagesAndReservedMemoryMisfitEvictor arguments
+func ValidateHugePagesAndReservedMemoryMisfitEvictorArgs(obj runtime.Object) error {
+	args := obj.(*HugePagesAndReservedMemoryMisfitEvictorArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.HugePagesLabelPrefix != "" {
+		if errs := validation.IsQualifiedName(args.HugePagesLabelPrefix + v1.ResourceHugePagesPrefix + "2Mi"); len(errs) > 0 {
+			return fmt.Errorf("invalid hugePagesLabelPrefix %q: %s", args.HugePagesLabelPrefix, strings.Join(errs, ", "))
+		}
+	}
+	if errs := validation.IsQualifiedName(args.ReservedMemoryLabel); len(errs) > 0 {
+		return fmt.Errorf("invalid reservedMemoryLabel %q: %s", args.ReservedMemoryLabel, strings.Join(errs, ", "))
+	}
+	return nil
+}
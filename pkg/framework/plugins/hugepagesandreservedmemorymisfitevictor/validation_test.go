@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hugepagesandreservedmemorymisfitevictor
+
+import (
+	"testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateHugePagesAndReservedMemoryMisfitEvictorArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *HugePagesAndReservedMemoryMisfitEvictorArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &HugePagesAndReservedMemoryMisfitEvictorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}},
+				},
+				HugePagesLabelPrefix: "feature.example.com/",
+				ReservedMemoryLabel:  DefaultReservedMemoryLabel,
+			},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &HugePagesAndReservedMemoryMisfitEvictorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+				ReservedMemoryLabel: DefaultReservedMemoryLabel,
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid hugepages label prefix, expects error",
+			args: &HugePagesAndReservedMemoryMisfitEvictorArgs{
+				HugePagesLabelPrefix: "example.com/hugepages/",
+				ReservedMemoryLabel:  DefaultReservedMemoryLabel,
+			},
+			expectError: true,
+		},
+		{
+			description: "missing reserved memory label, expects error",
+			args:        &HugePagesAndReservedMemoryMisfitEvictorArgs{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateHugePagesAndReservedMemoryMisfitEvictorArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
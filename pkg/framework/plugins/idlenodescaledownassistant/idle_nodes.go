@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idlenodescaledownassistant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	PluginName = "IdleNodeScaleDownAssistant"
+
+	// IdleSinceAnnotationKey records when the node was first seen hosting only DaemonSet and system pods
+	IdleSinceAnnotationKey = "descheduler.alpha.kubernetes.io/idle-since"
+	// ScaleDownCandidateAnnotationKey marks the nodes idle for longer than the threshold
+	ScaleDownCandidateAnnotationKey = "descheduler.alpha.kubernetes.io/scale-down-candidate"
+	// CordonedAnnotationKey records the node was cordoned by the plugin, so only such nodes are uncordoned
+	CordonedAnnotationKey = "descheduler.alpha.kubernetes.io/cordoned-for-scale-down"
+	// ScaleDownCandidateTaintKey is the key of the PreferNoSchedule taint keeping new pods off the candidates
+	ScaleDownCandidateTaintKey = "descheduler.alpha.kubernetes.io/scale-down-candidate"
+
+	// ScaleDownCandidateReason is the reason of the events reported on the nodes marked as candidates
+	ScaleDownCandidateReason = "ScaleDownCandidate"
+
+	actionMarked = "Marked"
+)
+
+// IdleNodeScaleDownAssistant marks the nodes hosting only DaemonSet and system pods for longer than
+// a threshold as scale down candidates, with an annotation and a PreferNoSchedule taint, optionally
+// cordoning them. It can also evict the system pods which keep the cluster autoscaler from removing
+// such nodes. The marks are removed as soon as other pods run on the node again.
+type IdleNodeScaleDownAssistant struct {
+	handle           frameworktypes.Handle
+	args             *IdleNodeScaleDownAssistantArgs
+	systemNamespaces sets.Set[string]
+}
+
+var _ frameworktypes.DeschedulePlugin = &IdleNodeScaleDownAssistant{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	idleNodesArgs, ok := args.(*IdleNodeScaleDownAssistantArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type IdleNodeScaleDownAssistantArgs, got %T", args)
+	}
+
+	return &IdleNodeScaleDownAssistant{
+		handle:           handle,
+		args:             idleNodesArgs,
+		systemNamespaces: sets.New(idleNodesArgs.SystemNamespaces...),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *IdleNodeScaleDownAssistant) Name() string {
+	return PluginName
+}
+
+// isDaemonSetOrMirrorPod tells whether the pod is bound to its node and goes away with it
+func isDaemonSetOrMirrorPod(pod *v1.Pod) bool {
+	return utils.IsDaemonsetPod(pod.OwnerReferences) || utils.IsMirrorPod(pod) || utils.IsStaticPod(pod)
+}
+
+// idle tells whether none of the pods keeps the node busy. Terminating pods are on their way out.
+func (d *IdleNodeScaleDownAssistant) idle(pods []*v1.Pod) bool {
+	for _, pod := range pods {
+		if utils.IsPodTerminating(pod) || isDaemonSetOrMirrorPod(pod) || d.systemNamespaces.Has(pod.Namespace) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *IdleNodeScaleDownAssistant) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+
+	now := time.Now()
+	for _, node := range nodes {
+		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, nil)
+		if err != nil {
+			// no pods evicted as error encountered retrieving the Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+
+		if !d.idle(pods) {
+			if err := d.updateNode(ctx, node, unmark); err != nil {
+				logger.Error(err, "Unable to remove the scale down candidate marks of the node", "node", klog.KObj(node))
+			}
+			continue
+		}
+
+		idleSince, err := time.Parse(time.RFC3339, node.Annotations[IdleSinceAnnotationKey])
+		if err != nil {
+			// First time the node is seen idle, or the annotation is not a valid time
+			logger.V(2).Info("Node hosts only DaemonSet and system pods", "node", klog.KObj(node))
+			if err := d.updateNode(ctx, node, func(node *v1.Node) {
+				setAnnotation(node, IdleSinceAnnotationKey, now.UTC().Format(time.RFC3339))
+			}); err != nil {
+				logger.Error(err, "Unable to record since when the node is idle", "node", klog.KObj(node))
+			}
+			continue
+		}
+		if now.Sub(idleSince) < d.args.IdleThreshold.Duration {
+			continue
+		}
+
+		if err := d.markCandidate(ctx, node, idleSince); err != nil {
+			logger.Error(err, "Unable to mark the node as a scale down candidate", "node", klog.KObj(node))
+			continue
+		}
+		if d.args.EvictBlockingPods && d.evictBlockingPods(ctx, pods) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// markCandidate annotates and taints the node, and cordons it if requested
+func (d *IdleNodeScaleDownAssistant) markCandidate(ctx context.Context, node *v1.Node, idleSince time.Time) error {
+	marked := node.Annotations[ScaleDownCandidateAnnotationKey] == "true"
+	err := d.updateNode(ctx, node, func(node *v1.Node) {
+		setAnnotation(node, ScaleDownCandidateAnnotationKey, "true")
+		if !hasCandidateTaint(node) {
+			node.Spec.Taints = append(node.Spec.Taints, v1.Taint{
+				Key:    ScaleDownCandidateTaintKey,
+				Effect: v1.TaintEffectPreferNoSchedule,
+			})
+		}
+		// A node cordoned by someone else is left to them
+		if d.args.Cordon && !node.Spec.Unschedulable {
+			node.Spec.Unschedulable = true
+			setAnnotation(node, CordonedAnnotationKey, "true")
+		}
+	})
+	if err != nil || marked {
+		return err
+	}
+	klog.FromContext(ctx).V(1).Info("Marked node as a scale down candidate", "node", klog.KObj(node), "idleSince", idleSince)
+	d.handle.EventRecorder().Eventf(node, nil, v1.EventTypeNormal, ScaleDownCandidateReason, actionMarked,
+		"Node hosts only DaemonSet and system pods since %s, marked as a scale down candidate", idleSince.Format(time.RFC3339))
+	return nil
+}
+
+// evictBlockingPods evicts the system pods not bound to the node, returning whether the total
+// eviction limit is reached.
+func (d *IdleNodeScaleDownAssistant) evictBlockingPods(ctx context.Context, pods []*v1.Pod) bool {
+	logger := klog.FromContext(ctx)
+	for _, pod := range pods {
+		if utils.IsPodTerminating(pod) || isDaemonSetOrMirrorPod(pod) {
+			continue
+		}
+		if !d.handle.Evictor().Filter(pod) || !d.handle.Evictor().PreEvictionFilter(pod) {
+			continue
+		}
+		err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+		if err == nil {
+			continue
+		}
+		switch err.(type) {
+		case *evictions.EvictionNodeLimitError:
+			return false
+		case *evictions.EvictionTotalLimitError:
+			return true
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	return false
+}
+
+// updateNode updates the node once changed by the mutation, which is given a copy of the node
+func (d *IdleNodeScaleDownAssistant) updateNode(ctx context.Context, node *v1.Node, mutate func(*v1.Node)) error {
+	updated := node.DeepCopy()
+	mutate(updated)
+	if apiequality.Semantic.DeepEqual(node, updated) {
+		return nil
+	}
+	_, err := d.handle.ClientSet().CoreV1().Nodes().Update(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
+
+// unmark removes the annotations and the taint of the plugin, and uncordons the node if it cordoned it
+func unmark(node *v1.Node) {
+	if node.Annotations[CordonedAnnotationKey] == "true" {
+		node.Spec.Unschedulable = false
+	}
+	delete(node.Annotations, IdleSinceAnnotationKey)
+	delete(node.Annotations, ScaleDownCandidateAnnotationKey)
+	delete(node.Annotations, CordonedAnnotationKey)
+	var taints []v1.Taint
+	for _, taint := range node.Spec.Taints {
+		if taint.Key != ScaleDownCandidateTaintKey {
+			taints = append(taints, taint)
+		}
+	}
+	if len(taints) != len(node.Spec.Taints) {
+		node.Spec.Taints = taints
+	}
+}
+
+func hasCandidateTaint(node *v1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == ScaleDownCandidateTaintKey {
+			return true
+		}
+	}
+	return false
+}
+
+func setAnnotation(node *v1.Node, key, value string) {
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	node.Annotations[key] = value
+}
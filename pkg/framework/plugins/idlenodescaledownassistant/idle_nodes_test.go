@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idlenodescaledownassistant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestIdleNodeScaleDownAssistant(t *testing.T) {
+	buildNode := func(apply func(*v1.Node)) *v1.Node {
+		return test.BuildTestNode("n1", 2000, 3000, 10, apply)
+	}
+	idleSince := func(d time.Duration) func(*v1.Node) {
+		return func(node *v1.Node) {
+			node.Annotations = map[string]string{IdleSinceAnnotationKey: time.Now().Add(-d).UTC().Format(time.RFC3339)}
+		}
+	}
+	marked := func(node *v1.Node) {
+		node.Annotations = map[string]string{
+			IdleSinceAnnotationKey:          time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+			ScaleDownCandidateAnnotationKey: "true",
+			CordonedAnnotationKey:           "true",
+		}
+		node.Spec.Unschedulable = true
+		node.Spec.Taints = []v1.Taint{
+			{Key: "dedicated", Value: "infra", Effect: v1.TaintEffectNoSchedule},
+			{Key: ScaleDownCandidateTaintKey, Effect: v1.TaintEffectPreferNoSchedule},
+		}
+	}
+
+	daemonSetPod := test.BuildTestPod("ds", 100, 0, "n1", test.SetDSOwnerRef)
+	systemPod := test.BuildTestPod("coredns", 100, 0, "n1", func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Namespace = metav1.NamespaceSystem
+	})
+	mirrorPod := test.BuildTestPod("mirror", 100, 0, "n1", func(pod *v1.Pod) {
+		pod.Namespace = "infra"
+		pod.Annotations = test.GetMirrorPodAnnotation()
+	})
+	appPod := test.BuildTestPod("app", 100, 0, "n1", test.SetRSOwnerRef)
+	completedPod := test.BuildTestPod("completed", 100, 0, "n1", func(pod *v1.Pod) {
+		test.SetNormalOwnerRef(pod)
+		pod.Status.Phase = v1.PodSucceeded
+	})
+
+	tests := []struct {
+		description         string
+		node                *v1.Node
+		pods                []*v1.Pod
+		cordon              bool
+		evictBlockingPods   bool
+		expectIdleSince     bool
+		expectCandidate     bool
+		expectUnschedulable bool
+		expectedEvicted     []string
+	}{
+		{
+			description:     "idle node is recorded as idle",
+			node:            buildNode(nil),
+			pods:            []*v1.Pod{daemonSetPod, systemPod, mirrorPod, completedPod},
+			expectIdleSince: true,
+			expectedEvicted: []string{},
+		},
+		{
+			description:       "node idle for less than the threshold is not marked",
+			node:              buildNode(idleSince(time.Minute)),
+			pods:              []*v1.Pod{daemonSetPod, systemPod},
+			evictBlockingPods: true,
+			expectIdleSince:   true,
+			expectedEvicted:   []string{},
+		},
+		{
+			description:     "node idle for longer than the threshold is marked",
+			node:            buildNode(idleSince(time.Hour)),
+			pods:            []*v1.Pod{daemonSetPod, systemPod},
+			expectIdleSince: true,
+			expectCandidate: true,
+			expectedEvicted: []string{},
+		},
+		{
+			description:         "node idle for longer than the threshold is cordoned",
+			node:                buildNode(idleSince(time.Hour)),
+			pods:                []*v1.Pod{daemonSetPod},
+			cordon:              true,
+			expectIdleSince:     true,
+			expectCandidate:     true,
+			expectUnschedulable: true,
+			expectedEvicted:     []string{},
+		},
+		{
+			description:       "blocking system pods are evicted from the candidates",
+			node:              buildNode(idleSince(time.Hour)),
+			pods:              []*v1.Pod{daemonSetPod, systemPod, mirrorPod},
+			evictBlockingPods: true,
+			expectIdleSince:   true,
+			expectCandidate:   true,
+			expectedEvicted:   []string{"coredns"},
+		},
+		{
+			description:     "busy node is unmarked and uncordoned",
+			node:            buildNode(marked),
+			pods:            []*v1.Pod{daemonSetPod, appPod},
+			cordon:          true,
+			expectedEvicted: []string{},
+		},
+		{
+			description: "busy node cordoned by someone else stays cordoned",
+			node: buildNode(func(node *v1.Node) {
+				idleSince(time.Hour)(node)
+				node.Spec.Unschedulable = true
+			}),
+			pods:                []*v1.Pod{appPod},
+			expectUnschedulable: true,
+			expectedEvicted:     []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			result := scenario.New().
+				WithNodes(tc.node).
+				WithPods(tc.pods...).
+				ExpectEvicted(tc.expectedEvicted...).
+				Run(t, New, &IdleNodeScaleDownAssistantArgs{
+					IdleThreshold:     &metav1.Duration{Duration: 10 * time.Minute},
+					SystemNamespaces:  []string{metav1.NamespaceSystem},
+					Cordon:            tc.cordon,
+					EvictBlockingPods: tc.evictBlockingPods,
+				})
+
+			node, err := result.Client.CoreV1().Nodes().Get(context.TODO(), tc.node.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Unable to get the node: %v", err)
+			}
+			if _, ok := node.Annotations[IdleSinceAnnotationKey]; ok != tc.expectIdleSince {
+				t.Errorf("Expected idle since annotation: %v, got annotations %v", tc.expectIdleSince, node.Annotations)
+			}
+			if candidate := node.Annotations[ScaleDownCandidateAnnotationKey] == "true"; candidate != tc.expectCandidate {
+				t.Errorf("Expected scale down candidate annotation: %v, got annotations %v", tc.expectCandidate, node.Annotations)
+			}
+			if tainted := hasCandidateTaint(node); tainted != tc.expectCandidate {
+				t.Errorf("Expected scale down candidate taint: %v, got taints %v", tc.expectCandidate, node.Spec.Taints)
+			}
+			if node.Spec.Unschedulable != tc.expectUnschedulable {
+				t.Errorf("Expected unschedulable: %v, got %v", tc.expectUnschedulable, node.Spec.Unschedulable)
+			}
+			if tc.node.Spec.Taints != nil && len(node.Spec.Taints) == 0 {
+				t.Errorf("Expected the taints of others to be kept")
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idlenodescaledownassistant
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IdleNodeScaleDownAssistantArgs holds arguments used to configure IdleNodeScaleDownAssistant plugin.
+type IdleNodeScaleDownAssistantArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// IdleThreshold is how long a node has to host only DaemonSet and system pods before it is
+	// marked as a scale down candidate. Defaults to 10 minutes.
+	IdleThreshold *metav1.Duration `json:"idleThreshold,omitempty"`
+	// SystemNamespaces lists the namespaces whose pods do not keep a node busy. Defaults to kube-system.
+	SystemNamespaces []string `json:"systemNamespaces,omitempty"`
+	// Cordon also marks the scale down candidates unschedulable, on top of the PreferNoSchedule taint
+	Cordon bool `json:"cordon,omitempty"`
+	// EvictBlockingPods evicts the system pods not owned by a DaemonSet from the scale down candidates,
+	// e.g. the replicated kube-system pods without a disruption budget the cluster autoscaler refuses to move.
+	EvictBlockingPods bool `json:"evictBlockingPods,omitempty"`
+}
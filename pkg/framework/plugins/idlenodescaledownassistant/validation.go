@@ -0,0 +1,34 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idlenodescaledownassistant
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateIdleNodeScaleDownAssistantArgs validates IdleNodeScaleDownAssistant arguments
+func ValidateIdleNodeScaleDownAssistantArgs(obj runtime.Object) error {
+	args := obj.(*IdleNodeScaleDownAssistantArgs)
+	if args.IdleThreshold != nil && args.IdleThreshold.Duration <= 0 {
+		return fmt.Errorf("idleThreshold must be greater than 0")
+	}
+	for _, namespace := range args.SystemNamespaces {
+		if namespace == "" {
+			return fmt.Errorf("systemNamespaces must not contain an empty namespace")
+		}
+	}
+	return nil
+}
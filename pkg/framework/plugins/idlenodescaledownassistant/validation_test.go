@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idlenodescaledownassistant
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateIdleNodeScaleDownAssistantArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *IdleNodeScaleDownAssistantArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &IdleNodeScaleDownAssistantArgs{
+				IdleThreshold:    &metav1.Duration{Duration: 1},
+				SystemNamespaces: []string{"kube-system"},
+			},
+			expectError: false,
+		},
+		{
+			description: "zero idle threshold, expects error",
+			args: &IdleNodeScaleDownAssistantArgs{
+				IdleThreshold: &metav1.Duration{},
+			},
+			expectError: true,
+		},
+		{
+			description: "empty system namespace, expects error",
+			args: &IdleNodeScaleDownAssistantArgs{
+				SystemNamespaces: []string{""},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateIdleNodeScaleDownAssistantArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobawarepodlifetime
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DefaultExpectedRuntimeLabel is the label holding the expected runtime of a Job by default
+const DefaultExpectedRuntimeLabel = "descheduler.alpha.kubernetes.io/expected-runtime"
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_JobAwarePodLifeTimeArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_JobAwarePodLifeTimeArgs(obj runtime.Object) {
+	args := obj.(*JobAwarePodLifeTimeArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.ExpectedRuntimeLabel == "" {
+		args.ExpectedRuntimeLabel = DefaultExpectedRuntimeLabel
+	}
+}
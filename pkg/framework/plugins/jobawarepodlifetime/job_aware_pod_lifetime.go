@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobawarepodlifetime
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "JobAwarePodLifeTime"
+
+// JobAwarePodLifeTime evicts the pods of Jobs running longer than expected, while
+// leaving the pods of Jobs still within their deadline and making progress alone.
+type JobAwarePodLifeTime struct {
+	handle    frameworktypes.Handle
+	args      *JobAwarePodLifeTimeArgs
+	podFilter podutil.FilterFunc
+	jobLister batchlisters.JobLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &JobAwarePodLifeTime{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	jobArgs, ok := args.(*JobAwarePodLifeTimeArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type JobAwarePodLifeTimeArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if jobArgs.Namespaces != nil {
+		includedNamespaces = sets.New(jobArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(jobArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(jobArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	// Only the pods of Jobs still running are considered
+	podFilter = podutil.WrapFilterFuncs(podFilter, func(pod *v1.Pod) bool {
+		ownerRef := metav1.GetControllerOf(pod)
+		return ownerRef != nil && ownerRef.Kind == "Job" &&
+			pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed
+	})
+
+	return &JobAwarePodLifeTime{
+		handle:    handle,
+		args:      jobArgs,
+		podFilter: podFilter,
+		// The Jobs are read from the cluster, the cached client of the dry run mode holds none
+		jobLister: handle.ClusterInformerFactory().Batch().V1().Jobs().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *JobAwarePodLifeTime) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *JobAwarePodLifeTime) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		// Evict the pods of the Jobs running for the longest time first, unless their eviction costs more
+		podutil.SortPodsBasedOnAge(pods)
+		podutil.SortPodsBasedOnEvictionCost(pods)
+	loop:
+		for _, pod := range pods {
+			job, err := d.jobLister.Jobs(pod.Namespace).Get(metav1.GetControllerOf(pod).Name)
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return &frameworktypes.Status{
+					Err: fmt.Errorf("error getting job of pod %s/%s: %v", pod.Namespace, pod.Name, err),
+				}
+			}
+			if !d.exceedsExpectedRuntime(logger, job, pod) {
+				continue
+			}
+			err = d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// exceedsExpectedRuntime checks whether the Job of the pod runs for longer than expected.
+// The pods of a Job within its active deadline and making progress are never evicted.
+func (d *JobAwarePodLifeTime) exceedsExpectedRuntime(logger klog.Logger, job *batchv1.Job, pod *v1.Pod) bool {
+	if jobFinished(job) {
+		return false
+	}
+	startTime := pod.CreationTimestamp
+	if job.Status.StartTime != nil {
+		startTime = *job.Status.StartTime
+	}
+	elapsed := time.Since(startTime.Time)
+
+	if job.Spec.ActiveDeadlineSeconds != nil && elapsed < time.Duration(*job.Spec.ActiveDeadlineSeconds)*time.Second && makingProgress(job, pod) {
+		return false
+	}
+
+	expectedRuntime, ok := d.expectedRuntime(logger, job, pod)
+	if !ok || elapsed <= expectedRuntime {
+		return false
+	}
+	logger.V(2).Info("Job runs for longer than expected", "pod", klog.KObj(pod), "job", klog.KObj(job), "runtime", elapsed, "expectedRuntime", expectedRuntime)
+	return true
+}
+
+// expectedRuntime derives the expected runtime of the Job from the expected runtime label of the Job
+// or of the pod, then from the active deadline of the Job and finally from the default expected runtime.
+func (d *JobAwarePodLifeTime) expectedRuntime(logger klog.Logger, job *batchv1.Job, pod *v1.Pod) (time.Duration, bool) {
+	for _, labels := range []map[string]string{job.Labels, pod.Labels} {
+		value, ok := labels[d.args.ExpectedRuntimeLabel]
+		if !ok {
+			continue
+		}
+		expectedRuntime, err := parseExpectedRuntime(value)
+		if err != nil {
+			logger.V(3).Info("Ignoring invalid expected runtime", "pod", klog.KObj(pod), "job", klog.KObj(job), "value", value, "err", err)
+			continue
+		}
+		return expectedRuntime, true
+	}
+	if job.Spec.ActiveDeadlineSeconds != nil {
+		return time.Duration(*job.Spec.ActiveDeadlineSeconds) * time.Second, true
+	}
+	if d.args.DefaultExpectedRuntimeSeconds != nil {
+		return time.Duration(*d.args.DefaultExpectedRuntimeSeconds) * time.Second, true
+	}
+	return 0, false
+}
+
+// parseExpectedRuntime parses either a duration or a number of seconds
+func parseExpectedRuntime(value string) (time.Duration, error) {
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	expectedRuntime, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if expectedRuntime <= 0 {
+		return 0, fmt.Errorf("expected runtime must be positive")
+	}
+	return expectedRuntime, nil
+}
+
+// jobFinished checks whether the Job completed or failed already
+func jobFinished(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == v1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// makingProgress checks the Job is not suspended and the pod is running with all its containers
+// running, i.e. none of them is waiting, e.g. crash looping or failing to pull its image.
+func makingProgress(job *batchv1.Job, pod *v1.Pod) bool {
+	if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		return false
+	}
+	if pod.Status.Phase != v1.PodRunning {
+		return false
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.State.Running == nil {
+			return false
+		}
+	}
+	return true
+}
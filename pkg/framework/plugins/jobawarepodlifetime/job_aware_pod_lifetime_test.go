@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobawarepodlifetime
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildJob(name string, age time.Duration, apply func(*batchv1.Job)) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Status: batchv1.JobStatus{
+			StartTime: &metav1.Time{Time: time.Now().Add(-age)},
+		},
+	}
+	if apply != nil {
+		apply(job)
+	}
+	return job
+}
+
+func buildPods(job *batchv1.Job, count int, apply func(*v1.Pod)) []runtime.Object {
+	var pods []runtime.Object
+	for i := 0; i < count; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("%s-p%d", job.Name, i), 100, 0, "n1", func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "batch/v1",
+				Kind:       "Job",
+				Name:       job.Name,
+				Controller: utilptr.To(true),
+			}}
+			pod.Status.Phase = v1.PodRunning
+			pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+				State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+			}}
+			if apply != nil {
+				apply(pod)
+			}
+		}))
+	}
+	return pods
+}
+
+func TestJobAwarePodLifeTime(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, nil)
+	withDeadline := func(seconds int64) func(*batchv1.Job) {
+		return func(job *batchv1.Job) {
+			job.Spec.ActiveDeadlineSeconds = utilptr.To(seconds)
+		}
+	}
+	withExpectedRuntime := func(value string) func(*batchv1.Job) {
+		return func(job *batchv1.Job) {
+			job.Labels = map[string]string{DefaultExpectedRuntimeLabel: value}
+		}
+	}
+	crashLooping := func(pod *v1.Pod) {
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+			State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}}
+	}
+	defaultArgs := JobAwarePodLifeTimeArgs{ExpectedRuntimeLabel: DefaultExpectedRuntimeLabel}
+	objects := func(job *batchv1.Job, count int, apply func(*v1.Pod)) []runtime.Object {
+		return append(buildPods(job, count, apply), job)
+	}
+
+	tests := []struct {
+		description          string
+		args                 JobAwarePodLifeTimeArgs
+		objects              []runtime.Object
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "Pods of Jobs exceeding the expected runtime label are evicted",
+			args:                 defaultArgs,
+			objects:              objects(buildJob("job", 2*time.Hour, withExpectedRuntime("1h")), 2, nil),
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Expected runtime label in seconds",
+			args:                 defaultArgs,
+			objects:              objects(buildJob("job", 2*time.Hour, withExpectedRuntime("3600")), 1, nil),
+			expectedEvictedCount: 1,
+		},
+		{
+			description:          "Pods of Jobs within the expected runtime are not evicted",
+			args:                 defaultArgs,
+			objects:              objects(buildJob("job", 30*time.Minute, withExpectedRuntime("1h")), 2, nil),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Expected runtime label set on the pods",
+			args:        defaultArgs,
+			objects: objects(buildJob("job", 2*time.Hour, nil), 2, func(pod *v1.Pod) {
+				pod.Labels = map[string]string{DefaultExpectedRuntimeLabel: "90m"}
+			}),
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Pods of Jobs within their deadline and making progress are not evicted",
+			args:        defaultArgs,
+			objects: objects(buildJob("job", 2*time.Hour, func(job *batchv1.Job) {
+				withExpectedRuntime("1h")(job)
+				withDeadline(3 * 3600)(job)
+			}), 2, nil),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of Jobs within their deadline but not making progress are evicted",
+			args:        defaultArgs,
+			objects: objects(buildJob("job", 2*time.Hour, func(job *batchv1.Job) {
+				withExpectedRuntime("1h")(job)
+				withDeadline(3 * 3600)(job)
+			}), 2, crashLooping),
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Pods of Jobs exceeding their deadline are evicted",
+			args:                 defaultArgs,
+			objects:              objects(buildJob("job", 2*time.Hour, withDeadline(3600)), 2, nil),
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Pods of Jobs without expected runtime are not evicted",
+			args:                 defaultArgs,
+			objects:              objects(buildJob("job", 48*time.Hour, nil), 2, nil),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Default expected runtime",
+			args: JobAwarePodLifeTimeArgs{
+				ExpectedRuntimeLabel:          DefaultExpectedRuntimeLabel,
+				DefaultExpectedRuntimeSeconds: utilptr.To[uint](24 * 3600),
+			},
+			objects:              objects(buildJob("job", 48*time.Hour, nil), 2, nil),
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Invalid expected runtime label is ignored",
+			args:                 defaultArgs,
+			objects:              objects(buildJob("job", 2*time.Hour, withExpectedRuntime("soon")), 2, nil),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of finished Jobs are not evicted",
+			args:        defaultArgs,
+			objects: objects(buildJob("job", 2*time.Hour, func(job *batchv1.Job) {
+				withExpectedRuntime("1h")(job)
+				job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue}}
+			}), 2, nil),
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Pods of missing Jobs are not evicted",
+			args:                 defaultArgs,
+			objects:              buildPods(buildJob("job", 2*time.Hour, withExpectedRuntime("1h")), 2, nil),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of excluded namespaces are not evicted",
+			args: JobAwarePodLifeTimeArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"default"}},
+				},
+				ExpectedRuntimeLabel: DefaultExpectedRuntimeLabel,
+			},
+			objects:              objects(buildJob("job", 2*time.Hour, withExpectedRuntime("1h")), 2, nil),
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := append([]runtime.Object{n1}, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			// Start the Job informer requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{n1})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
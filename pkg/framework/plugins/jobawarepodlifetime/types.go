@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobawarepodlifetime
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JobAwarePodLifeTimeArgs holds arguments used to configure JobAwarePodLifeTime plugin.
+type JobAwarePodLifeTimeArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// ExpectedRuntimeLabel is the label of the Job, or of its pods, holding the expected runtime
+	// of the Job, either as a duration (e.g. 90m) or as a number of seconds.
+	ExpectedRuntimeLabel string `json:"expectedRuntimeLabel,omitempty"`
+	// DefaultExpectedRuntimeSeconds is the expected runtime of the Jobs without the label and
+	// without activeDeadlineSeconds. The pods of such Jobs are never evicted when not set.
+	DefaultExpectedRuntimeSeconds *uint `json:"defaultExpectedRuntimeSeconds,omitempty"`
+}
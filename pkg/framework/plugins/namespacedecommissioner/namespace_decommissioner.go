@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacedecommissioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "NamespaceDecommissioner"
+
+	// DecommissionLabelKey is the label the namespaces being decommissioned are selected with by default
+	DecommissionLabelKey = "descheduler.alpha.kubernetes.io/decommission"
+	// DecommissionStartedAnnotationKey records on the namespace when its decommission started
+	DecommissionStartedAnnotationKey = "descheduler.alpha.kubernetes.io/decommission-started"
+	// DecommissionedAnnotationKey records on the namespace when its last pod got removed
+	DecommissionedAnnotationKey = "descheduler.alpha.kubernetes.io/decommissioned"
+
+	// NamespaceDecommissionReason is the reason of the events emitted on the namespaces and on the force deleted pods
+	NamespaceDecommissionReason = "NamespaceDecommission"
+)
+
+// NamespaceDecommissioner drains the pods of the namespaces selected for decommission at a
+// controlled rate, a policy-driven alternative to ad-hoc cleanup scripts. The pods are evicted,
+// respecting their PodDisruptionBudgets, until the optional forceAfter deadline measured from
+// the start of the decommission, recorded in an annotation of the namespace. Past the deadline
+// the pods are deleted instead. Once no pod is left in a namespace, its decommission is
+// reported as complete through another annotation and the namespace is left alone.
+type NamespaceDecommissioner struct {
+	handle          frameworktypes.Handle
+	args            *NamespaceDecommissionerArgs
+	podFilter       podutil.FilterFunc
+	selector        labels.Selector
+	namespaceLister listersv1.NamespaceLister
+	podLister       listersv1.PodLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &NamespaceDecommissioner{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	decommissionerArgs, ok := args.(*NamespaceDecommissionerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type NamespaceDecommissionerArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if decommissionerArgs.Namespaces != nil {
+		includedNamespaces = sets.New(decommissionerArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(decommissionerArgs.Namespaces.Exclude...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(decommissionerArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(decommissionerArgs.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace selector from plugin arg: %v", err)
+	}
+
+	return &NamespaceDecommissioner{
+		handle:          handle,
+		args:            decommissionerArgs,
+		podFilter:       podFilter,
+		selector:        selector,
+		namespaceLister: handle.SharedInformerFactory().Core().V1().Namespaces().Lister(),
+		podLister:       handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *NamespaceDecommissioner) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *NamespaceDecommissioner) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	namespaces, err := d.namespaceLister.List(d.selector)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing namespaces: %v", err),
+		}
+	}
+	sort.Slice(namespaces, func(i, j int) bool {
+		return namespaces[i].Name < namespaces[j].Name
+	})
+
+	now := time.Now()
+	// force tells per namespace whether its pods are past the deadline
+	force := map[string]bool{}
+	for _, namespace := range namespaces {
+		if _, done := namespace.Annotations[DecommissionedAnnotationKey]; done {
+			continue
+		}
+		started, err := d.startedAt(ctx, namespace, now)
+		if err != nil {
+			logger.Error(err, "Unable to record the start of the decommission", "namespace", klog.KObj(namespace))
+			continue
+		}
+		force[namespace.Name] = d.args.ForceAfter != nil && now.Sub(started) >= d.args.ForceAfter.Duration
+
+		remaining, err := d.podLister.Pods(namespace.Name).List(labels.Everything())
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing the pods of namespace %s: %v", namespace.Name, err),
+			}
+		}
+		if len(remaining) > 0 {
+			logger.V(1).Info("Decommissioning namespace", "namespace", klog.KObj(namespace), "remainingPods", len(remaining), "started", started, "force", force[namespace.Name])
+			continue
+		}
+		if err := d.annotate(ctx, namespace.Name, DecommissionedAnnotationKey, now); err != nil {
+			logger.Error(err, "Unable to record the completion of the decommission", "namespace", klog.KObj(namespace))
+			continue
+		}
+		delete(force, namespace.Name)
+		logger.V(1).Info("Namespace decommissioned", "namespace", klog.KObj(namespace))
+		d.handle.EventRecorder().Eventf(namespace, nil, v1.EventTypeNormal, NamespaceDecommissionReason, "Decommissioned",
+			"all the pods of namespace %s were removed by sigs.k8s.io/descheduler", namespace.Name)
+	}
+	if len(force) == 0 {
+		return nil
+	}
+
+	pods, err := podutil.ListPodsOnNodes(nodes, d.handle.GetPodsAssignedToNodeFunc(), func(pod *v1.Pod) bool {
+		_, ok := force[pod.Namespace]
+		return ok && d.podFilter(pod)
+	})
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing all pods: %v", err),
+		}
+	}
+	// Namespaces in order, the lowest priority pods first
+	podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].Namespace < pods[j].Namespace
+	})
+
+	var removed uint
+loop:
+	for _, pod := range pods {
+		if removed >= *d.args.MaxEvictionsPerCycle {
+			break
+		}
+		if !d.handle.Evictor().PreEvictionFilter(pod) {
+			continue
+		}
+		if force[pod.Namespace] {
+			if d.forceDelete(ctx, pod) {
+				removed++
+			}
+			continue
+		}
+		err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+		if err == nil {
+			removed++
+			continue
+		}
+		switch err.(type) {
+		case *evictions.EvictionNodeLimitError:
+			continue
+		case *evictions.EvictionTotalLimitError:
+			break loop
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	return nil
+}
+
+// startedAt returns when the decommission of the namespace started, recording now as its start
+// when the namespace is seen for the first time
+func (d *NamespaceDecommissioner) startedAt(ctx context.Context, namespace *v1.Namespace, now time.Time) (time.Time, error) {
+	if value, ok := namespace.Annotations[DecommissionStartedAnnotationKey]; ok {
+		started, err := time.Parse(time.RFC3339, value)
+		if err == nil {
+			return started, nil
+		}
+		klog.FromContext(ctx).Info("Ignoring invalid decommission start", "namespace", klog.KObj(namespace), "value", value, "err", err)
+	}
+	if err := d.annotate(ctx, namespace.Name, DecommissionStartedAnnotationKey, now); err != nil {
+		return time.Time{}, err
+	}
+	return now, nil
+}
+
+// annotate sets the annotation of the namespace to the given time
+func (d *NamespaceDecommissioner) annotate(ctx context.Context, namespace, key string, at time.Time) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{key: at.UTC().Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = d.handle.ClientSet().CoreV1().Namespaces().Patch(ctx, namespace, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// forceDelete deletes the pod, bypassing its PodDisruptionBudgets, once the deadline of the
+// decommission of its namespace passed
+func (d *NamespaceDecommissioner) forceDelete(ctx context.Context, pod *v1.Pod) bool {
+	logger := klog.FromContext(ctx)
+	err := d.handle.ClientSet().CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{
+		Preconditions: metav1.NewUIDPreconditions(string(pod.UID)),
+	})
+	if err != nil {
+		logger.Error(err, "Unable to delete pod", "pod", klog.KObj(pod))
+		return false
+	}
+	logger.V(1).Info("Deleted pod past the decommission deadline of its namespace", "pod", klog.KObj(pod))
+	d.handle.EventRecorder().Eventf(pod, nil, v1.EventTypeWarning, NamespaceDecommissionReason, "ForceDeleted",
+		"pod deleted by sigs.k8s.io/descheduler as the decommission deadline of namespace %v passed", pod.Namespace)
+	return true
+}
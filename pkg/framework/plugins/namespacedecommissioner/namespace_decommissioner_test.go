@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacedecommissioner
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestNamespaceDecommissioner(t *testing.T) {
+	node := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	started := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+
+	buildNamespace := func(name string, decommission bool, annotations map[string]string) *v1.Namespace {
+		namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+		if decommission {
+			namespace.Labels = map[string]string{DecommissionLabelKey: "true"}
+		}
+		return namespace
+	}
+	buildPod := func(name, namespace string, priority int32) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node.Name, func(pod *v1.Pod) {
+			pod.Namespace = namespace
+			test.SetRSOwnerRef(pod)
+			test.SetPodPriority(pod, priority)
+		})
+	}
+
+	tests := []struct {
+		description          string
+		namespaces           []*v1.Namespace
+		pods                 []*v1.Pod
+		maxEvictionsPerCycle uint
+		forceAfter           *metav1.Duration
+		expectedEvicted      []string
+		expectedDeleted      []string
+		expectedAnnotations  map[string][]string
+	}{
+		{
+			description: "pods of the decommissioned namespaces are evicted, lowest priority first",
+			namespaces:  []*v1.Namespace{buildNamespace("a", true, nil), buildNamespace("b", false, nil)},
+			pods: []*v1.Pod{
+				buildPod("p1", "a", 100),
+				buildPod("p2", "a", 10),
+				buildPod("p3", "a", 50),
+				buildPod("p4", "b", 0),
+			},
+			maxEvictionsPerCycle: 2,
+			expectedEvicted:      []string{"p2", "p3"},
+			expectedAnnotations:  map[string][]string{"a": {DecommissionStartedAnnotationKey}},
+		},
+		{
+			description: "the namespaces are drained in order within the budget",
+			namespaces:  []*v1.Namespace{buildNamespace("b", true, nil), buildNamespace("a", true, nil)},
+			pods: []*v1.Pod{
+				buildPod("p1", "b", 0),
+				buildPod("p2", "a", 100),
+				buildPod("p3", "a", 100),
+			},
+			maxEvictionsPerCycle: 2,
+			expectedEvicted:      []string{"p2", "p3"},
+			expectedAnnotations: map[string][]string{
+				"a": {DecommissionStartedAnnotationKey},
+				"b": {DecommissionStartedAnnotationKey},
+			},
+		},
+		{
+			description: "pods are deleted past the deadline",
+			namespaces: []*v1.Namespace{
+				buildNamespace("a", true, map[string]string{DecommissionStartedAnnotationKey: started}),
+				buildNamespace("b", true, nil),
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", "a", 0),
+				buildPod("p2", "b", 0),
+			},
+			maxEvictionsPerCycle: 5,
+			forceAfter:           &metav1.Duration{Duration: time.Hour},
+			expectedEvicted:      []string{"p2"},
+			expectedDeleted:      []string{"p1"},
+			expectedAnnotations:  map[string][]string{"b": {DecommissionStartedAnnotationKey}},
+		},
+		{
+			description: "the completion is recorded once no pod is left",
+			namespaces: []*v1.Namespace{
+				buildNamespace("a", true, map[string]string{DecommissionStartedAnnotationKey: started}),
+				buildNamespace("b", true, map[string]string{DecommissionStartedAnnotationKey: started, DecommissionedAnnotationKey: started}),
+			},
+			pods:                 []*v1.Pod{buildPod("p1", "b", 0)},
+			maxEvictionsPerCycle: 5,
+			expectedEvicted:      nil,
+			expectedAnnotations:  map[string][]string{"a": {DecommissionedAnnotationKey}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var objects []runtime.Object
+			for _, namespace := range tc.namespaces {
+				objects = append(objects, namespace)
+			}
+			args := &NamespaceDecommissionerArgs{
+				MaxEvictionsPerCycle: utilptr.To(tc.maxEvictionsPerCycle),
+				ForceAfter:           tc.forceAfter,
+			}
+			SetDefaults_NamespaceDecommissionerArgs(args)
+			result := scenario.New().
+				WithNodes(node).
+				WithPods(tc.pods...).
+				WithObjects(objects...).
+				ExpectEvicted(tc.expectedEvicted...).
+				Run(t, New, args)
+
+			var deleted []string
+			annotations := map[string][]string{}
+			for _, action := range result.Client.Actions() {
+				switch {
+				case action.Matches("delete", "pods"):
+					deleted = append(deleted, action.(core.DeleteAction).GetName())
+				case action.Matches("patch", "namespaces"):
+					patch := action.(core.PatchAction)
+					var patched v1.Namespace
+					if err := json.Unmarshal(patch.GetPatch(), &patched); err != nil {
+						t.Fatalf("Unable to decode the patch of namespace %v: %v", patch.GetName(), err)
+					}
+					for key := range patched.Annotations {
+						annotations[patch.GetName()] = append(annotations[patch.GetName()], key)
+					}
+				}
+			}
+			for _, keys := range annotations {
+				sort.Strings(keys)
+			}
+			if diff := cmp.Diff(tc.expectedDeleted, deleted, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected deleted pods (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedAnnotations, annotations, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected annotated namespaces (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacedecommissioner
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NamespaceDecommissionerArgs holds arguments used to configure NamespaceDecommissioner plugin.
+type NamespaceDecommissionerArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// NamespaceSelector selects the namespaces being decommissioned
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// MaxEvictionsPerCycle caps the pods evicted in a descheduling cycle, across all the namespaces
+	MaxEvictionsPerCycle *uint `json:"maxEvictionsPerCycle,omitempty"`
+	// ForceAfter is how long after the start of the decommission the pods are deleted instead of
+	// evicted, bypassing their PodDisruptionBudgets. The pods are never force deleted when not set.
+	ForceAfter *metav1.Duration `json:"forceAfter,omitempty"`
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacedecommissioner
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateNamespaceDecommissionerArgs validates NamespaceDecommissioner arguments
+func ValidateNamespaceDecommissionerArgs(obj runtime.Object) error {
+	args := obj.(*NamespaceDecommissionerArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+	if args.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(args.NamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("failed to get namespace selector from plugin arg: %v", err)
+		}
+		if selector.Empty() {
+			return fmt.Errorf("namespaceSelector must not select all the namespaces")
+		}
+	}
+	if args.MaxEvictionsPerCycle != nil && *args.MaxEvictionsPerCycle == 0 {
+		return fmt.Errorf("maxEvictionsPerCycle must be greater than 0")
+	}
+	if args.ForceAfter != nil && args.ForceAfter.Duration < 0 {
+		return fmt.Errorf("forceAfter must not be negative, got %v", args.ForceAfter.Duration)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespacedecommissioner
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateNamespaceDecommissionerArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *NamespaceDecommissionerArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &NamespaceDecommissionerArgs{
+				NamespaceSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"lifecycle": "retired"}},
+				MaxEvictionsPerCycle: utilptr.To[uint](3),
+				ForceAfter:           &metav1.Duration{Duration: time.Hour},
+			},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &NamespaceDecommissionerArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "namespace selector selecting all the namespaces, expects error",
+			args: &NamespaceDecommissionerArgs{
+				NamespaceSelector: &metav1.LabelSelector{},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid namespace selector, expects error",
+			args: &NamespaceDecommissionerArgs{
+				NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "lifecycle", Operator: "Bogus"}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "no eviction per cycle, expects error",
+			args: &NamespaceDecommissionerArgs{
+				MaxEvictionsPerCycle: utilptr.To[uint](0),
+			},
+			expectError: true,
+		},
+		{
+			description: "negative forceAfter, expects error",
+			args: &NamespaceDecommissionerArgs{
+				ForceAfter: &metav1.Duration{Duration: -time.Hour},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateNamespaceDecommissionerArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
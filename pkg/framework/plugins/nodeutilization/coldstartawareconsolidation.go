@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const ColdStartAwareConsolidationPluginName = "ColdStartAwareConsolidation"
+
+// ColdStartAwareConsolidation evicts pods from under utilized nodes like HighNodeUtilization,
+// but it evicts first the pods that are cheap to restart and drains first the nodes whose pods
+// are cheap to restart. The restart cost of a pod is estimated from the size of its images, the
+// time its startup probes allow its containers to start and its number of init containers.
+type ColdStartAwareConsolidation struct {
+	handle    frameworktypes.Handle
+	args      *ColdStartAwareConsolidationArgs
+	podFilter func(pod *v1.Pod) bool
+}
+
+var _ frameworktypes.BalancePlugin = &ColdStartAwareConsolidation{}
+
+var _ frameworktypes.AllPodsRequirer = &ColdStartAwareConsolidationArgs{}
+
+// RequiresAllPods tells the utilization of the nodes is computed from all their pods
+func (a *ColdStartAwareConsolidationArgs) RequiresAllPods() bool {
+	return true
+}
+
+// NewColdStartAwareConsolidation builds plugin from its arguments while passing a handle
+func NewColdStartAwareConsolidation(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	consolidationArgs, ok := args.(*ColdStartAwareConsolidationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type ColdStartAwareConsolidationArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithLabelSelector(consolidationArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &ColdStartAwareConsolidation{
+		handle:    handle,
+		args:      consolidationArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (c *ColdStartAwareConsolidation) Name() string {
+	return ColdStartAwareConsolidationPluginName
+}
+
+// Balance extension point implementation for the plugin
+func (c *ColdStartAwareConsolidation) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	thresholds := c.args.Thresholds
+	targetThresholds := make(api.ResourceThresholds)
+
+	setDefaultForThresholds(thresholds, targetThresholds)
+	resourceNames := getResourceNames(targetThresholds)
+
+	sourceNodes, highNodes := classifyNodes(
+		logger,
+		getNodeUsage(ctx, nodes, resourceNames, c.handle.GetPodsAssignedToNodeFunc(), c.handle.UtilizationProvider()),
+		getNodeThresholds(ctx, nodes, thresholds, targetThresholds, resourceNames, c.handle.UtilizationProvider(), false),
+		func(node *v1.Node, usage NodeUsage, threshold NodeThresholds) bool {
+			return isNodeWithLowUtilization(usage, threshold.lowResourceThreshold)
+		},
+		func(node *v1.Node, usage NodeUsage, threshold NodeThresholds) bool {
+			if nodeutil.IsNodeUnschedulable(node) {
+				logger.V(2).Info("Node is unschedulable", "node", klog.KObj(node))
+				return false
+			}
+			return !isNodeWithLowUtilization(usage, threshold.lowResourceThreshold)
+		})
+
+	logger.V(1).Info("Number of underutilized nodes", "totalNumber", len(sourceNodes))
+
+	if len(sourceNodes) == 0 {
+		logger.V(1).Info("No node is underutilized, nothing to do here, you might tune your thresholds further")
+		return nil
+	}
+	if len(sourceNodes) <= c.args.NumberOfNodes {
+		logger.V(1).Info("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(sourceNodes), "numberOfNodes", c.args.NumberOfNodes)
+		return nil
+	}
+	if len(sourceNodes) == len(nodes) {
+		logger.V(1).Info("All nodes are underutilized, nothing to do here")
+		return nil
+	}
+	if len(highNodes) == 0 {
+		logger.V(1).Info("No node is available to schedule the pods, nothing to do here")
+		return nil
+	}
+
+	// stop if the total available usage has dropped to zero - no more pods can be scheduled
+	continueEvictionCond := func(nodeInfo NodeInfo, totalAvailableUsage map[v1.ResourceName]*resource.Quantity) bool {
+		for name := range totalAvailableUsage {
+			if totalAvailableUsage[name].CmpInt64(0) < 1 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	costModel := DefaultRestartCostModel
+	if c.args.CostModel != nil {
+		costModel = *c.args.CostModel
+	}
+	estimator := newRestartCostEstimator(nodes, costModel)
+	podFilter := func(pod *v1.Pod) bool {
+		if !c.podFilter(pod) {
+			return false
+		}
+		if c.args.MaxPodRestartCost != nil && estimator.podRestartCost(pod) > float64(*c.args.MaxPodRestartCost) {
+			logger.V(3).Info("Pod costs too much to restart", "pod", klog.KObj(pod), "restartCost", estimator.podRestartCost(pod))
+			return false
+		}
+		return true
+	}
+
+	// Drain first the nodes whose removable pods cost the least to restart,
+	// the least utilized nodes first when they cost the same
+	sortNodesByUsage(sourceNodes, true)
+	drainCosts := make(map[string]float64, len(sourceNodes))
+	for _, node := range sourceNodes {
+		for _, pod := range node.allPods {
+			if podFilter(pod) {
+				drainCosts[node.node.Name] += estimator.podRestartCost(pod)
+			}
+		}
+	}
+	sort.SliceStable(sourceNodes, func(i, j int) bool {
+		return drainCosts[sourceNodes[i].node.Name] < drainCosts[sourceNodes[j].node.Name]
+	})
+
+	evictPodsFromSourceNodes(
+		ctx,
+		c.args.EvictableNamespaces,
+		sourceNodes,
+		highNodes,
+		c.handle.Evictor(),
+		evictions.EvictOptions{StrategyName: ColdStartAwareConsolidationPluginName},
+		podFilter,
+		estimator.sortPods,
+		resourceNames,
+		continueEvictionCond)
+
+	return nil
+}
+
+// restartCostEstimator estimates how much restarting a pod on a new node costs according to a cost model.
+// Estimates are cached for the lifetime of the estimator.
+type restartCostEstimator struct {
+	costModel RestartCostModel
+	// images holds the size of the images present on each node by normalized image reference
+	images map[string]map[string]int64
+
+	pods map[types.UID]float64
+}
+
+func newRestartCostEstimator(nodes []*v1.Node, costModel RestartCostModel) *restartCostEstimator {
+	estimator := &restartCostEstimator{
+		costModel: costModel,
+		images:    make(map[string]map[string]int64, len(nodes)),
+		pods:      map[types.UID]float64{},
+	}
+	for _, node := range nodes {
+		estimator.images[node.Name] = utils.NodeImages(node)
+	}
+	return estimator
+}
+
+// sortPods sorts the pods by eviction cost, then by restart cost, then by priority and QoS
+func (e *restartCostEstimator) sortPods(pods []*v1.Pod) {
+	podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+	sort.SliceStable(pods, func(i, j int) bool {
+		return e.podRestartCost(pods[i]) < e.podRestartCost(pods[j])
+	})
+	podutil.SortPodsBasedOnEvictionCost(pods)
+}
+
+// podRestartCost returns the cost of pulling the images of the pod, waiting for its containers
+// to start and running its init containers
+func (e *restartCostEstimator) podRestartCost(pod *v1.Pod) float64 {
+	if cost, ok := e.pods[pod.UID]; ok {
+		return cost
+	}
+
+	var imageBytes int64
+	images := map[string]struct{}{}
+	var startupSeconds int32
+	for _, containers := range [][]v1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			image := utils.NormalizeImageReference(container.Image)
+			if _, ok := images[image]; !ok {
+				images[image] = struct{}{}
+				imageBytes += e.images[pod.Spec.NodeName][image]
+			}
+			// The containers start concurrently, the slowest one tells when the pod is started
+			if seconds := startupProbeSeconds(container.StartupProbe); seconds > startupSeconds {
+				startupSeconds = seconds
+			}
+		}
+	}
+
+	cost := float64(imageBytes)/(1<<30)*float64(e.costModel.ImagePerGiB) +
+		float64(startupSeconds)*float64(e.costModel.StartupProbePerSecond) +
+		float64(len(pod.Spec.InitContainers))*float64(e.costModel.InitContainer)
+	e.pods[pod.UID] = cost
+	return cost
+}
+
+// startupProbeSeconds returns how long the startup probe allows the container to take to start
+func startupProbeSeconds(probe *v1.Probe) int32 {
+	if probe == nil {
+		return 0
+	}
+	periodSeconds, failureThreshold := probe.PeriodSeconds, probe.FailureThreshold
+	// API server defaults
+	if periodSeconds == 0 {
+		periodSeconds = 10
+	}
+	if failureThreshold == 0 {
+		failureThreshold = 3
+	}
+	return probe.InitialDelaySeconds + periodSeconds*failureThreshold
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+const gib = 1 << 30
+
+func withImages(node *v1.Node) {
+	node.Status.Images = []v1.ContainerImage{
+		{Names: []string{"docker.io/library/nginx:latest", "docker.io/library/nginx@sha256:1234"}, SizeBytes: gib},
+		{Names: []string{"registry.k8s.io/big:v1"}, SizeBytes: 4 * gib},
+	}
+}
+
+func withContainerImage(image string) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Containers[0].Image = image
+	}
+}
+
+func TestRestartCostEstimator(t *testing.T) {
+	node := test.BuildTestNode("n1", 4000, 3000, 10, withImages)
+	estimator := newRestartCostEstimator([]*v1.Node{node}, DefaultRestartCostModel)
+
+	tests := []struct {
+		description string
+		apply       func(*v1.Pod)
+		expected    float64
+	}{
+		{
+			description: "image missing from the node status",
+			apply:       withContainerImage("unknown:v1"),
+			expected:    0,
+		},
+		{
+			description: "image referenced by its short name",
+			apply:       withContainerImage("nginx"),
+			expected:    10,
+		},
+		{
+			description: "init containers sharing the image of the container",
+			apply: func(pod *v1.Pod) {
+				withContainerImage("registry.k8s.io/big:v1")(pod)
+				pod.Spec.InitContainers = []v1.Container{
+					{Name: "init-1", Image: "registry.k8s.io/big:v1"},
+					{Name: "init-2", Image: "nginx:latest"},
+				}
+			},
+			expected: 40 + 10 + 2*5,
+		},
+		{
+			description: "slowest startup probe",
+			apply: func(pod *v1.Pod) {
+				withContainerImage("unknown:v1")(pod)
+				pod.Spec.Containers[0].StartupProbe = &v1.Probe{InitialDelaySeconds: 5}
+				pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{
+					Name:         "slow",
+					StartupProbe: &v1.Probe{PeriodSeconds: 10, FailureThreshold: 30},
+				})
+			},
+			expected: 300,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod(tc.description, 100, 0, node.Name, tc.apply)
+			if got := estimator.podRestartCost(pod); got != tc.expected {
+				t.Errorf("expected a restart cost of %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestColdStartAwareConsolidation(t *testing.T) {
+	testCases := []struct {
+		name              string
+		nodes             []*v1.Node
+		pods              []*v1.Pod
+		maxPodRestartCost *int64
+		evictedPods       []string
+	}{
+		{
+			name: "cheap to restart pod is evicted before expensive pods",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, withImages),
+				test.BuildTestNode("n2", 4000, 3000, 3, nil),
+			},
+			pods: []*v1.Pod{
+				// The expensive pod would be evicted first based on priority
+				test.BuildTestPod("expensive", 100, 0, "n1", func(pod *v1.Pod) {
+					withContainerImage("registry.k8s.io/big:v1")(pod)
+					pod.Spec.Priority = utilptr.To[int32](0)
+				}),
+				test.BuildTestPod("cheap", 100, 0, "n1", func(pod *v1.Pod) {
+					withContainerImage("nginx")(pod)
+					pod.Spec.Priority = utilptr.To[int32](100)
+				}),
+				// Room for a single pod on n2
+				test.BuildTestPod("p1", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 100, 0, "n2", test.SetRSOwnerRef),
+			},
+			evictedPods: []string{"cheap"},
+		},
+		{
+			name: "pods costing more than maxPodRestartCost are not evicted",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, withImages),
+				test.BuildTestNode("n2", 4000, 3000, 10, nil),
+			},
+			pods: []*v1.Pod{
+				test.BuildTestPod("expensive", 100, 0, "n1", withContainerImage("registry.k8s.io/big:v1")),
+				test.BuildTestPod("cheap", 100, 0, "n1", withContainerImage("nginx")),
+				test.BuildTestPod("p1", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p3", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p4", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p5", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p6", 100, 0, "n2", test.SetRSOwnerRef),
+			},
+			maxPodRestartCost: utilptr.To[int64](20),
+			evictedPods:       []string{"cheap"},
+		},
+		{
+			name: "nodes with cheap to restart pods are drained first",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, withImages),
+				test.BuildTestNode("n2", 4000, 3000, 10, withImages),
+				test.BuildTestNode("n3", 4000, 3000, 3, nil),
+			},
+			pods: []*v1.Pod{
+				test.BuildTestPod("expensive", 100, 0, "n1", withContainerImage("registry.k8s.io/big:v1")),
+				test.BuildTestPod("cheap", 100, 0, "n2", withContainerImage("nginx")),
+				// Room for a single pod on n3
+				test.BuildTestPod("p1", 100, 0, "n3", test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 100, 0, "n3", test.SetRSOwnerRef),
+			},
+			evictedPods: []string{"cheap"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range testCase.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range testCase.pods {
+				objs = append(objs, pod)
+			}
+			podsForEviction := make(map[string]struct{})
+			for _, pod := range testCase.evictedPods {
+				podsForEviction[pod] = struct{}{}
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{}, nil)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			evictionFailed := false
+			fakeClient.Fake.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				obj := action.(core.CreateAction).GetObject()
+				if eviction, ok := obj.(*policy.Eviction); ok {
+					if _, exists := podsForEviction[eviction.Name]; exists {
+						return true, obj, nil
+					}
+					evictionFailed = true
+					return true, nil, fmt.Errorf("pod %q was unexpectedly evicted", eviction.Name)
+				}
+				return false, nil, nil
+			})
+
+			plugin, err := NewColdStartAwareConsolidation(&ColdStartAwareConsolidationArgs{
+				Thresholds:        api.ResourceThresholds{v1.ResourcePods: 50},
+				MaxPodRestartCost: testCase.maxPodRestartCost,
+			}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, testCase.nodes)
+
+			if podsEvicted := podEvictor.TotalEvicted(); uint(len(testCase.evictedPods)) != podsEvicted {
+				t.Errorf("Expected %v pods to be evicted but %v got evicted", len(testCase.evictedPods), podsEvicted)
+			}
+			if evictionFailed {
+				t.Errorf("Pod evictions failed unexpectedly")
+			}
+		})
+	}
+}
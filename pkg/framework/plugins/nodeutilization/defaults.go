@@ -14,9 +14,26 @@ limitations under the License.
 package nodeutilization
 
 import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DefaultVolumeAttachTime is the attach time of the block volumes without attach time hint
+const DefaultVolumeAttachTime = 30 * time.Second
+
+// DefaultOffHoursTimeZone is the time zone of the off-hours windows of ScheduledDownscaleHelper
+const DefaultOffHoursTimeZone = "UTC"
+
+// DefaultRestartCostModel makes pulling a GiB of images cost as much as a startup probe allowing
+// 10 seconds to start, or 2 init containers
+var DefaultRestartCostModel = RestartCostModel{
+	ImagePerGiB:           10,
+	StartupProbePerSecond: 1,
+	InitContainer:         5,
+}
+
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
 	return RegisterDefaults(scheme)
 }
@@ -50,3 +67,49 @@ func SetDefaults_HighNodeUtilizationArgs(obj runtime.Object) {
 		args.NumberOfNodes = 0
 	}
 }
+
+// SetDefaults_VolumeAttachmentAwareConsolidationArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_VolumeAttachmentAwareConsolidationArgs(obj runtime.Object) {
+	args := obj.(*VolumeAttachmentAwareConsolidationArgs)
+	if args.Thresholds == nil {
+		args.Thresholds = nil
+	}
+	if args.NumberOfNodes == 0 {
+		args.NumberOfNodes = 0
+	}
+	if args.DefaultAttachTime == nil {
+		args.DefaultAttachTime = &metav1.Duration{Duration: DefaultVolumeAttachTime}
+	}
+}
+
+// SetDefaults_ColdStartAwareConsolidationArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_ColdStartAwareConsolidationArgs(obj runtime.Object) {
+	args := obj.(*ColdStartAwareConsolidationArgs)
+	if args.Thresholds == nil {
+		args.Thresholds = nil
+	}
+	if args.NumberOfNodes == 0 {
+		args.NumberOfNodes = 0
+	}
+	if args.CostModel == nil {
+		costModel := DefaultRestartCostModel
+		args.CostModel = &costModel
+	}
+}
+
+// SetDefaults_ScheduledDownscaleHelperArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_ScheduledDownscaleHelperArgs(obj runtime.Object) {
+	args := obj.(*ScheduledDownscaleHelperArgs)
+	if args.TimeZone == "" {
+		args.TimeZone = DefaultOffHoursTimeZone
+	}
+	if args.Thresholds == nil {
+		args.Thresholds = nil
+	}
+	if args.NumberOfNodes == 0 {
+		args.NumberOfNodes = 0
+	}
+}
@@ -15,9 +15,11 @@ package nodeutilization
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/descheduler/pkg/api"
@@ -122,3 +124,134 @@ func TestSetDefaults_HighNodeUtilizationArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDefaults_VolumeAttachmentAwareConsolidationArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "VolumeAttachmentAwareConsolidationArgs empty",
+			in:   &VolumeAttachmentAwareConsolidationArgs{},
+			want: &VolumeAttachmentAwareConsolidationArgs{
+				Thresholds:        nil,
+				NumberOfNodes:     0,
+				DefaultAttachTime: &metav1.Duration{Duration: DefaultVolumeAttachTime},
+			},
+		},
+		{
+			name: "VolumeAttachmentAwareConsolidationArgs with value",
+			in: &VolumeAttachmentAwareConsolidationArgs{
+				Thresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 20,
+				},
+				NumberOfNodes:     10,
+				DefaultAttachTime: &metav1.Duration{Duration: time.Minute},
+				MaxPodAttachTime:  &metav1.Duration{Duration: time.Minute},
+			},
+			want: &VolumeAttachmentAwareConsolidationArgs{
+				Thresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 20,
+				},
+				NumberOfNodes:     10,
+				DefaultAttachTime: &metav1.Duration{Duration: time.Minute},
+				MaxPodAttachTime:  &metav1.Duration{Duration: time.Minute},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_VolumeAttachmentAwareConsolidationArgs(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetDefaults_ColdStartAwareConsolidationArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "ColdStartAwareConsolidationArgs empty",
+			in:   &ColdStartAwareConsolidationArgs{},
+			want: &ColdStartAwareConsolidationArgs{
+				Thresholds:    nil,
+				NumberOfNodes: 0,
+				CostModel:     &RestartCostModel{ImagePerGiB: 10, StartupProbePerSecond: 1, InitContainer: 5},
+			},
+		},
+		{
+			name: "ColdStartAwareConsolidationArgs with value",
+			in: &ColdStartAwareConsolidationArgs{
+				Thresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 20,
+				},
+				NumberOfNodes: 10,
+				CostModel:     &RestartCostModel{ImagePerGiB: 1},
+			},
+			want: &ColdStartAwareConsolidationArgs{
+				Thresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 20,
+				},
+				NumberOfNodes: 10,
+				CostModel:     &RestartCostModel{ImagePerGiB: 1},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_ColdStartAwareConsolidationArgs(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetDefaults_ScheduledDownscaleHelperArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "ScheduledDownscaleHelperArgs empty",
+			in:   &ScheduledDownscaleHelperArgs{},
+			want: &ScheduledDownscaleHelperArgs{
+				TimeZone:      "UTC",
+				Thresholds:    nil,
+				NumberOfNodes: 0,
+			},
+		},
+		{
+			name: "ScheduledDownscaleHelperArgs with value",
+			in: &ScheduledDownscaleHelperArgs{
+				TimeZone: "Europe/Paris",
+				Thresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 20,
+				},
+				NumberOfNodes: 10,
+			},
+			want: &ScheduledDownscaleHelperArgs{
+				TimeZone: "Europe/Paris",
+				Thresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 20,
+				},
+				NumberOfNodes: 10,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_ScheduledDownscaleHelperArgs(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
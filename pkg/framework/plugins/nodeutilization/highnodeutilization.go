@@ -45,6 +45,13 @@ type HighNodeUtilization struct {
 
 var _ frameworktypes.BalancePlugin = &HighNodeUtilization{}
 
+var _ frameworktypes.AllPodsRequirer = &HighNodeUtilizationArgs{}
+
+// RequiresAllPods tells the utilization of the nodes is computed from all their pods
+func (a *HighNodeUtilizationArgs) RequiresAllPods() bool {
+	return true
+}
+
 // NewHighNodeUtilization builds plugin from its arguments while passing a handle
 func NewHighNodeUtilization(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
 	highNodeUtilizatioArgs, ok := args.(*HighNodeUtilizationArgs)
@@ -54,6 +61,7 @@ func NewHighNodeUtilization(args runtime.Object, handle frameworktypes.Handle) (
 
 	podFilter, err := podutil.NewOptions().
 		WithFilter(handle.Evictor().Filter).
+		WithLabelSelector(highNodeUtilizatioArgs.LabelSelector).
 		BuildFilterFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
@@ -73,21 +81,25 @@ func (h *HighNodeUtilization) Name() string {
 
 // Balance extension point implementation for the plugin
 func (h *HighNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
-	thresholds := h.args.Thresholds
+	logger := klog.FromContext(ctx)
+	thresholds := withFreeThresholds(h.args.Thresholds, h.args.FreeThresholds)
 	targetThresholds := make(api.ResourceThresholds)
 
 	setDefaultForThresholds(thresholds, targetThresholds)
 	resourceNames := getResourceNames(targetThresholds)
 
+	nodeThresholds := getNodeThresholds(ctx, nodes, thresholds, targetThresholds, resourceNames, h.handle.UtilizationProvider(), false)
+	applyFreeThresholds(nodes, nodeThresholds, h.args.FreeThresholds, nil)
 	sourceNodes, highNodes := classifyNodes(
-		getNodeUsage(nodes, resourceNames, h.handle.GetPodsAssignedToNodeFunc()),
-		getNodeThresholds(nodes, thresholds, targetThresholds, resourceNames, h.handle.GetPodsAssignedToNodeFunc(), false),
+		logger,
+		getNodeUsage(ctx, nodes, resourceNames, h.handle.GetPodsAssignedToNodeFunc(), h.handle.UtilizationProvider()),
+		nodeThresholds,
 		func(node *v1.Node, usage NodeUsage, threshold NodeThresholds) bool {
 			return isNodeWithLowUtilization(usage, threshold.lowResourceThreshold)
 		},
 		func(node *v1.Node, usage NodeUsage, threshold NodeThresholds) bool {
 			if nodeutil.IsNodeUnschedulable(node) {
-				klog.V(2).InfoS("Node is unschedulable", "node", klog.KObj(node))
+				logger.V(2).Info("Node is unschedulable", "node", klog.KObj(node))
 				return false
 			}
 			return !isNodeWithLowUtilization(usage, threshold.lowResourceThreshold)
@@ -104,24 +116,27 @@ func (h *HighNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *fr
 			keysAndValues = append(keysAndValues, string(name), int64(thresholds[name]))
 		}
 	}
+	if len(h.args.FreeThresholds) > 0 {
+		keysAndValues = append(keysAndValues, "freeThresholds", h.args.FreeThresholds)
+	}
 
-	klog.V(1).InfoS("Criteria for a node below target utilization", keysAndValues...)
-	klog.V(1).InfoS("Number of underutilized nodes", "totalNumber", len(sourceNodes))
+	logger.V(1).Info("Criteria for a node below target utilization", keysAndValues...)
+	logger.V(1).Info("Number of underutilized nodes", "totalNumber", len(sourceNodes))
 
 	if len(sourceNodes) == 0 {
-		klog.V(1).InfoS("No node is underutilized, nothing to do here, you might tune your thresholds further")
+		logger.V(1).Info("No node is underutilized, nothing to do here, you might tune your thresholds further")
 		return nil
 	}
 	if len(sourceNodes) <= h.args.NumberOfNodes {
-		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(sourceNodes), "numberOfNodes", h.args.NumberOfNodes)
+		logger.V(1).Info("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(sourceNodes), "numberOfNodes", h.args.NumberOfNodes)
 		return nil
 	}
 	if len(sourceNodes) == len(nodes) {
-		klog.V(1).InfoS("All nodes are underutilized, nothing to do here")
+		logger.V(1).Info("All nodes are underutilized, nothing to do here")
 		return nil
 	}
 	if len(highNodes) == 0 {
-		klog.V(1).InfoS("No node is available to schedule the pods, nothing to do here")
+		logger.V(1).Info("No node is available to schedule the pods, nothing to do here")
 		return nil
 	}
 
@@ -147,6 +162,7 @@ func (h *HighNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *fr
 		h.handle.Evictor(),
 		evictions.EvictOptions{StrategyName: HighNodeUtilizationPluginName},
 		h.podFilter,
+		sortPodsBasedOnPriority,
 		resourceNames,
 		continueEvictionCond)
 
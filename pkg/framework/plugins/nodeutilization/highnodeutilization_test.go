@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	utilptr "k8s.io/utils/ptr"
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
@@ -48,6 +49,7 @@ func TestHighNodeUtilization(t *testing.T) {
 	testCases := []struct {
 		name                string
 		thresholds          api.ResourceThresholds
+		freeThresholds      map[v1.ResourceName]resource.Quantity
 		nodes               []*v1.Node
 		pods                []*v1.Pod
 		expectedPodsEvicted uint
@@ -433,6 +435,28 @@ func TestHighNodeUtilization(t *testing.T) {
 			},
 			expectedPodsEvicted: 0,
 		},
+		{
+			name: "free thresholds on nodes of different sizes",
+			// Underutilized with more than 3 cpus free
+			freeThresholds: map[v1.ResourceName]resource.Quantity{
+				v1.ResourceCPU: resource.MustParse("3"),
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode(n1NodeName, 4000, 3000, 10, nil),
+				test.BuildTestNode(n2NodeName, 8000, 3000, 10, nil),
+				test.BuildTestNode(n3NodeName, 2000, 3000, 10, nil),
+			},
+			pods: []*v1.Pod{
+				// n1 has 3.6 cpus free
+				test.BuildTestPod("p1", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				// n2 uses 70% of its cpu, 2.4 cpus are free
+				test.BuildTestPod("p2", 5600, 0, n2NodeName, test.SetRSOwnerRef),
+				// n3 uses only 20% of its cpu, but 1.6 cpus are free
+				test.BuildTestPod("p3", 400, 0, n3NodeName, test.SetRSOwnerRef),
+			},
+			expectedPodsEvicted: 1,
+			evictedPods:         []string{"p1"},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -453,7 +477,7 @@ func TestHighNodeUtilization(t *testing.T) {
 			}
 			fakeClient := fake.NewSimpleClientset(objs...)
 
-			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{NodeFit: true}, nil)
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{NodeFit: utilptr.To(true)}, nil)
 			if err != nil {
 				t.Fatalf("Unable to initialize a framework handle: %v", err)
 			}
@@ -475,7 +499,8 @@ func TestHighNodeUtilization(t *testing.T) {
 			}
 
 			plugin, err := NewHighNodeUtilization(&HighNodeUtilizationArgs{
-				Thresholds: testCase.thresholds,
+				Thresholds:     testCase.thresholds,
+				FreeThresholds: testCase.freeThresholds,
 			},
 				handle)
 			if err != nil {
@@ -19,18 +19,29 @@ package nodeutilization
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
 
-const LowNodeUtilizationPluginName = "LowNodeUtilization"
+const (
+	LowNodeUtilizationPluginName = "LowNodeUtilization"
+
+	// DefaultPassSettleTimeout is how long to wait at most for the evicted pods before an extra balance pass
+	DefaultPassSettleTimeout = 30 * time.Second
+
+	// WarnTargetThresholdsReason is the reason of the events emitted on the nodes above the warn target thresholds
+	WarnTargetThresholdsReason = "NodeUtilizationWarnThreshold"
+	actionWarned               = "Warned"
+)
 
 // LowNodeUtilization evicts pods from overutilized nodes to underutilized nodes. Note that CPU/Memory requests are used
 // to calculate nodes' utilization and not the actual resource usage.
@@ -41,7 +52,14 @@ type LowNodeUtilization struct {
 	podFilter func(pod *v1.Pod) bool
 }
 
-var _ frameworktypes.BalancePlugin = &LowNodeUtilization{}
+var _ frameworktypes.MultiPassBalancePlugin = &LowNodeUtilization{}
+
+var _ frameworktypes.AllPodsRequirer = &LowNodeUtilizationArgs{}
+
+// RequiresAllPods tells the utilization of the nodes is computed from all their pods
+func (a *LowNodeUtilizationArgs) RequiresAllPods() bool {
+	return true
+}
 
 // NewLowNodeUtilization builds plugin from its arguments while passing a handle
 func NewLowNodeUtilization(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
@@ -52,6 +70,7 @@ func NewLowNodeUtilization(args runtime.Object, handle frameworktypes.Handle) (f
 
 	podFilter, err := podutil.NewOptions().
 		WithFilter(handle.Evictor().Filter).
+		WithLabelSelector(lowNodeUtilizationArgsArgs.LabelSelector).
 		BuildFilterFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
@@ -69,11 +88,22 @@ func (l *LowNodeUtilization) Name() string {
 	return LowNodeUtilizationPluginName
 }
 
+// BalancePasses returns the maximum number of passes per descheduling cycle and how long to wait for the
+// evicted pods before each extra pass
+func (l *LowNodeUtilization) BalancePasses() (int, time.Duration) {
+	settleTimeout := DefaultPassSettleTimeout
+	if l.args.PassSettleTimeout != nil {
+		settleTimeout = l.args.PassSettleTimeout.Duration
+	}
+	return max(1, int(l.args.MaxPasses)), settleTimeout
+}
+
 // Balance extension point implementation for the plugin
 func (l *LowNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
 	useDeviationThresholds := l.args.UseDeviationThresholds
-	thresholds := l.args.Thresholds
-	targetThresholds := l.args.TargetThresholds
+	thresholds := withFreeThresholds(l.args.Thresholds, l.args.FreeThresholds)
+	targetThresholds := withFreeThresholds(l.args.TargetThresholds, l.args.FreeTargetThresholds)
 
 	// check if Pods/CPU/Mem are set, if not, set them to 100
 	if _, ok := thresholds[v1.ResourcePods]; !ok {
@@ -105,19 +135,35 @@ func (l *LowNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *fra
 	}
 	resourceNames := getResourceNames(thresholds)
 
+	// The nodes are classified by the weighted average of the resource usage when weights are configured
+	isNodeAboveTarget := isNodeAboveTargetUtilization
+	isNodeWithLowUsage := isNodeWithLowUtilization
+	if weights := l.args.ResourceWeights; len(weights) > 0 {
+		isNodeAboveTarget = func(usage NodeUsage, threshold map[v1.ResourceName]*resource.Quantity) bool {
+			return isNodeAboveWeightedTargetUtilization(usage, threshold, weights)
+		}
+		isNodeWithLowUsage = func(usage NodeUsage, threshold map[v1.ResourceName]*resource.Quantity) bool {
+			return isNodeWithWeightedLowUtilization(usage, threshold, weights)
+		}
+	}
+
+	nodeUsage := getNodeUsage(ctx, nodes, resourceNames, l.handle.GetPodsAssignedToNodeFunc(), l.handle.UtilizationProvider())
+	nodeThresholds := getNodeThresholds(ctx, nodes, thresholds, targetThresholds, resourceNames, l.handle.UtilizationProvider(), useDeviationThresholds)
+	applyFreeThresholds(nodes, nodeThresholds, l.args.FreeThresholds, l.args.FreeTargetThresholds)
 	lowNodes, sourceNodes := classifyNodes(
-		getNodeUsage(nodes, resourceNames, l.handle.GetPodsAssignedToNodeFunc()),
-		getNodeThresholds(nodes, thresholds, targetThresholds, resourceNames, l.handle.GetPodsAssignedToNodeFunc(), useDeviationThresholds),
+		logger,
+		nodeUsage,
+		nodeThresholds,
 		// The node has to be schedulable (to be able to move workload there)
 		func(node *v1.Node, usage NodeUsage, threshold NodeThresholds) bool {
 			if nodeutil.IsNodeUnschedulable(node) {
-				klog.V(2).InfoS("Node is unschedulable, thus not considered as underutilized", "node", klog.KObj(node))
+				logger.V(2).Info("Node is unschedulable, thus not considered as underutilized", "node", klog.KObj(node))
 				return false
 			}
-			return isNodeWithLowUtilization(usage, threshold.lowResourceThreshold)
+			return isNodeWithLowUsage(usage, threshold.lowResourceThreshold)
 		},
 		func(node *v1.Node, usage NodeUsage, threshold NodeThresholds) bool {
-			return isNodeAboveTargetUtilization(usage, threshold.highResourceThreshold)
+			return isNodeAboveTarget(usage, threshold.highResourceThreshold)
 		},
 	)
 
@@ -132,8 +178,14 @@ func (l *LowNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *fra
 			underutilizationCriteria = append(underutilizationCriteria, string(name), int64(thresholds[name]))
 		}
 	}
-	klog.V(1).InfoS("Criteria for a node under utilization", underutilizationCriteria...)
-	klog.V(1).InfoS("Number of underutilized nodes", "totalNumber", len(lowNodes))
+	if len(l.args.FreeThresholds) > 0 {
+		underutilizationCriteria = append(underutilizationCriteria, "freeThresholds", l.args.FreeThresholds)
+	}
+	if len(l.args.ResourceWeights) > 0 {
+		underutilizationCriteria = append(underutilizationCriteria, "resourceWeights", l.args.ResourceWeights)
+	}
+	logger.V(1).Info("Criteria for a node under utilization", underutilizationCriteria...)
+	logger.V(1).Info("Number of underutilized nodes", "totalNumber", len(lowNodes))
 
 	// log message for over utilized nodes
 	overutilizationCriteria := []interface{}{
@@ -146,32 +198,60 @@ func (l *LowNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *fra
 			overutilizationCriteria = append(overutilizationCriteria, string(name), int64(targetThresholds[name]))
 		}
 	}
-	klog.V(1).InfoS("Criteria for a node above target utilization", overutilizationCriteria...)
-	klog.V(1).InfoS("Number of overutilized nodes", "totalNumber", len(sourceNodes))
+	if len(l.args.FreeTargetThresholds) > 0 {
+		overutilizationCriteria = append(overutilizationCriteria, "freeTargetThresholds", l.args.FreeTargetThresholds)
+	}
+	if len(l.args.ResourceWeights) > 0 {
+		overutilizationCriteria = append(overutilizationCriteria, "resourceWeights", l.args.ResourceWeights)
+	}
+	logger.V(1).Info("Criteria for a node above target utilization", overutilizationCriteria...)
+	logger.V(1).Info("Number of overutilized nodes", "totalNumber", len(sourceNodes))
+
+	if len(l.args.WarnTargetThresholds) > 0 {
+		warnTargetThresholds := l.args.WarnTargetThresholds
+		// The resources without warn threshold are never reported
+		for name, value := range targetThresholds {
+			if _, ok := warnTargetThresholds[name]; !ok {
+				warnTargetThresholds[name] = value
+			}
+		}
+		warnNodeThresholds := getNodeThresholds(ctx, nodes, thresholds, warnTargetThresholds, resourceNames, l.handle.UtilizationProvider(), useDeviationThresholds)
+		applyFreeThresholds(nodes, warnNodeThresholds, l.args.FreeThresholds, l.args.FreeTargetThresholds)
+		for _, usage := range nodeUsage {
+			if !isNodeAboveTarget(usage, warnNodeThresholds[usage.node.Name].highResourceThreshold) ||
+				isNodeAboveTarget(usage, nodeThresholds[usage.node.Name].highResourceThreshold) {
+				continue
+			}
+			logger.V(1).Info("Node is above the warn target utilization", "node", klog.KObj(usage.node), "usagePercentage", resourceUsagePercentages(usage))
+			l.handle.EventRecorder().Eventf(usage.node, nil, v1.EventTypeWarning, WarnTargetThresholdsReason, actionWarned,
+				"Node utilization %v is above the warn target thresholds", resourceUsagePercentages(usage))
+			metrics.ThresholdWarnings.With(map[string]string{"strategy": LowNodeUtilizationPluginName, "namespace": "", "node": usage.node.Name}).Inc()
+		}
+	}
 
 	if len(lowNodes) == 0 {
-		klog.V(1).InfoS("No node is underutilized, nothing to do here, you might tune your thresholds further")
+		logger.V(1).Info("No node is underutilized, nothing to do here, you might tune your thresholds further")
 		return nil
 	}
 
 	if len(lowNodes) <= l.args.NumberOfNodes {
-		klog.V(1).InfoS("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(lowNodes), "numberOfNodes", l.args.NumberOfNodes)
+		logger.V(1).Info("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(lowNodes), "numberOfNodes", l.args.NumberOfNodes)
 		return nil
 	}
 
 	if len(lowNodes) == len(nodes) {
-		klog.V(1).InfoS("All nodes are underutilized, nothing to do here")
+		logger.V(1).Info("All nodes are underutilized, nothing to do here")
 		return nil
 	}
 
 	if len(sourceNodes) == 0 {
-		klog.V(1).InfoS("All nodes are under target utilization, nothing to do here")
+		logger.V(1).Info("All nodes are under target utilization, nothing to do here")
 		return nil
 	}
 
 	// stop if node utilization drops below target threshold or any of required capacity (cpu, memory, pods) is moved
 	continueEvictionCond := func(nodeInfo NodeInfo, totalAvailableUsage map[v1.ResourceName]*resource.Quantity) bool {
-		if !isNodeAboveTargetUtilization(nodeInfo.NodeUsage, nodeInfo.thresholds.highResourceThreshold) {
+		if !isNodeAboveTarget(nodeInfo.NodeUsage, nodeInfo.thresholds.highResourceThreshold) {
 			return false
 		}
 		for name := range totalAvailableUsage {
@@ -194,6 +274,7 @@ func (l *LowNodeUtilization) Balance(ctx context.Context, nodes []*v1.Node) *fra
 		l.handle.Evictor(),
 		evictions.EvictOptions{StrategyName: LowNodeUtilizationPluginName},
 		l.podFilter,
+		sortPodsBasedOnPriority,
 		resourceNames,
 		continueEvictionCond)
 
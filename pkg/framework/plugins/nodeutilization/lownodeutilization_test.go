@@ -19,6 +19,7 @@ package nodeutilization
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"sigs.k8s.io/descheduler/pkg/api"
@@ -32,6 +33,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	"sigs.k8s.io/descheduler/pkg/utils"
@@ -56,6 +59,9 @@ func TestLowNodeUtilization(t *testing.T) {
 		expectedPodsEvicted          uint
 		evictedPods                  []string
 		evictableNamespaces          *api.Namespaces
+		resourceWeights              map[v1.ResourceName]int32
+		freeThresholds               map[v1.ResourceName]resource.Quantity
+		freeTargetThresholds         map[v1.ResourceName]resource.Quantity
 	}{
 		{
 			name: "no evictable pods",
@@ -850,6 +856,135 @@ func TestLowNodeUtilization(t *testing.T) {
 			expectedPodsEvicted: 2,
 			evictedPods:         []string{},
 		},
+		{
+			name: "resource weights, node is underutilized only by weighted usage",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceCPU:    30,
+				v1.ResourceMemory: 30,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceCPU:    50,
+				v1.ResourceMemory: 50,
+			},
+			resourceWeights: map[v1.ResourceName]int32{
+				v1.ResourceCPU:    3,
+				v1.ResourceMemory: 1,
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode(n1NodeName, 4000, 3000, 10, nil),
+				test.BuildTestNode(n2NodeName, 4000, 3000, 10, nil),
+			},
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 600, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 600, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p3", 600, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p4", 600, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p5", 600, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p6", 600, 0, n1NodeName, test.SetRSOwnerRef),
+				// Memory usage of 40% keeps n2 above the plain memory threshold,
+				// the weighted usage of (3*0 + 1*40) / 4 = 10% makes it underutilized.
+				test.BuildTestPod("p7", 0, 1200, n2NodeName, test.SetRSOwnerRef),
+			},
+			// n1 drops from a weighted usage of 67.5% to 45% after two evictions.
+			expectedPodsEvicted: 2,
+		},
+		{
+			name: "resource weights, node is overutilized only by unweighted usage",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceCPU:    30,
+				v1.ResourceMemory: 30,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceCPU:    50,
+				v1.ResourceMemory: 50,
+			},
+			resourceWeights: map[v1.ResourceName]int32{
+				v1.ResourceCPU:    1,
+				v1.ResourceMemory: 9,
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode(n1NodeName, 4000, 3000, 10, nil),
+				test.BuildTestNode(n2NodeName, 4000, 3000, 10, nil),
+			},
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1200, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 1200, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p3", 1200, 0, n1NodeName, test.SetRSOwnerRef),
+			},
+			// n1 has a cpu usage of 90% but a weighted usage of 9%.
+			expectedPodsEvicted: 0,
+		},
+		{
+			name: "with extended resource weighted above cpu",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceCPU:   30,
+				extendedResource: 30,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceCPU:   50,
+				extendedResource: 50,
+			},
+			resourceWeights: map[v1.ResourceName]int32{
+				v1.ResourceCPU:   1,
+				extendedResource: 3,
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode(n1NodeName, 4000, 3000, 10, func(node *v1.Node) {
+					test.SetNodeExtendedResource(node, extendedResource, 8)
+				}),
+				test.BuildTestNode(n2NodeName, 4000, 3000, 10, func(node *v1.Node) {
+					test.SetNodeExtendedResource(node, extendedResource, 8)
+				}),
+			},
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 0, 0, n1NodeName, func(pod *v1.Pod) {
+					test.SetRSOwnerRef(pod)
+					test.SetPodExtendedResourceRequest(pod, extendedResource, 2)
+				}),
+				test.BuildTestPod("p2", 0, 0, n1NodeName, func(pod *v1.Pod) {
+					test.SetRSOwnerRef(pod)
+					test.SetPodExtendedResourceRequest(pod, extendedResource, 2)
+				}),
+				test.BuildTestPod("p3", 0, 0, n1NodeName, func(pod *v1.Pod) {
+					test.SetRSOwnerRef(pod)
+					test.SetPodExtendedResourceRequest(pod, extendedResource, 2)
+				}),
+				// n2 uses 40% of its cpu, its weighted usage is (40 + 3*0) / 4 = 10%.
+				test.BuildTestPod("p4", 1600, 0, n2NodeName, test.SetRSOwnerRef),
+			},
+			// n1 drops from a weighted usage of 56.25% to 37.5% after one eviction.
+			expectedPodsEvicted: 1,
+		},
+		{
+			name: "free thresholds on nodes of different sizes",
+			// Underutilized with more than 3 cpus free, overutilized with less than 1 cpu free
+			freeThresholds: map[v1.ResourceName]resource.Quantity{
+				v1.ResourceCPU: resource.MustParse("3"),
+			},
+			freeTargetThresholds: map[v1.ResourceName]resource.Quantity{
+				v1.ResourceCPU: resource.MustParse("1"),
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode(n1NodeName, 2000, 3000, 10, nil),
+				test.BuildTestNode(n2NodeName, 8000, 3000, 10, nil),
+				test.BuildTestNode(n3NodeName, 4000, 3000, 10, nil),
+			},
+			pods: []*v1.Pod{
+				// n1 uses 60% of its cpu, 0.8 cpu is free
+				test.BuildTestPod("p1", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p3", 400, 0, n1NodeName, test.SetRSOwnerRef),
+				// n2 uses 10% of its cpu, 7.2 cpus are free
+				test.BuildTestPod("p4", 400, 0, n2NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p5", 400, 0, n2NodeName, test.SetRSOwnerRef),
+				// n3 uses 60% of its cpu too, but 1.6 cpus are free
+				test.BuildTestPod("p6", 1200, 0, n3NodeName, test.SetRSOwnerRef),
+				test.BuildTestPod("p7", 1200, 0, n3NodeName, test.SetRSOwnerRef),
+			},
+			// n1 has 1.2 cpus free after one eviction
+			expectedPodsEvicted: 1,
+			evictedPods:         []string{"p1", "p2", "p3"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -887,7 +1022,7 @@ func TestLowNodeUtilization(t *testing.T) {
 				})
 			}
 
-			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{NodeFit: true}, nil)
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{NodeFit: utilptr.To(true)}, nil)
 			if err != nil {
 				t.Fatalf("Unable to initialize a framework handle: %v", err)
 			}
@@ -897,6 +1032,9 @@ func TestLowNodeUtilization(t *testing.T) {
 				TargetThresholds:       tc.targetThresholds,
 				UseDeviationThresholds: tc.useDeviationThresholds,
 				EvictableNamespaces:    tc.evictableNamespaces,
+				ResourceWeights:        tc.resourceWeights,
+				FreeThresholds:         tc.freeThresholds,
+				FreeTargetThresholds:   tc.freeTargetThresholds,
 			},
 				handle)
 			if err != nil {
@@ -1040,7 +1178,7 @@ func TestLowNodeUtilizationWithTaints(t *testing.T) {
 				ctx,
 				fakeClient,
 				evictions.NewOptions().WithMaxPodsToEvictPerNode(&item.evictionsExpected),
-				defaultevictor.DefaultEvictorArgs{NodeFit: true},
+				defaultevictor.DefaultEvictorArgs{NodeFit: utilptr.To(true)},
 				nil,
 			)
 			if err != nil {
@@ -1067,3 +1205,140 @@ func TestLowNodeUtilizationWithTaints(t *testing.T) {
 		})
 	}
 }
+
+type fakeUtilizationProvider struct {
+	usage map[string]map[v1.ResourceName]*resource.Quantity
+}
+
+func (f *fakeUtilizationProvider) NodeUtilization(_ context.Context, node *v1.Node, _ []v1.ResourceName) (map[v1.ResourceName]*resource.Quantity, error) {
+	usage := map[v1.ResourceName]*resource.Quantity{}
+	for name, quantity := range f.usage[node.Name] {
+		copied := quantity.DeepCopy()
+		usage[name] = &copied
+	}
+	return usage, nil
+}
+
+func TestLowNodeUtilizationWithUtilizationProvider(t *testing.T) {
+	ctx := context.Background()
+
+	n1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 3000, 10, nil)
+	pods := []*v1.Pod{
+		test.BuildTestPod("p1", 200, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p2", 200, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p3", 200, 0, n1.Name, test.SetRSOwnerRef),
+		test.BuildTestPod("p4", 200, 0, n2.Name, test.SetRSOwnerRef),
+	}
+
+	testCases := []struct {
+		name                string
+		utilizationProvider frameworktypes.UtilizationProvider
+		evictionsExpected   uint
+	}{
+		{
+			name:              "requests based utilization, all nodes are underutilized",
+			evictionsExpected: 0,
+		},
+		{
+			name: "utilization reported by the provider, n1 is overutilized",
+			utilizationProvider: &fakeUtilizationProvider{
+				usage: map[string]map[v1.ResourceName]*resource.Quantity{
+					n1.Name: {
+						v1.ResourceCPU:    resource.NewMilliQuantity(3600, resource.DecimalSI),
+						v1.ResourceMemory: resource.NewQuantity(0, resource.BinarySI),
+						v1.ResourcePods:   resource.NewQuantity(3, resource.DecimalSI),
+					},
+					n2.Name: {
+						v1.ResourceCPU:    resource.NewMilliQuantity(400, resource.DecimalSI),
+						v1.ResourceMemory: resource.NewQuantity(0, resource.BinarySI),
+						v1.ResourcePods:   resource.NewQuantity(1, resource.DecimalSI),
+					},
+				},
+			},
+			evictionsExpected: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			objs := []runtime.Object{n1, n2}
+			for _, pod := range pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{}, nil)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+			if tc.utilizationProvider != nil {
+				handle.UtilizationProviderImpl = tc.utilizationProvider
+			}
+
+			plugin, err := NewLowNodeUtilization(&LowNodeUtilizationArgs{
+				Thresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 30,
+				},
+				TargetThresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 50,
+				},
+			},
+				handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, []*v1.Node{n1, n2})
+
+			if tc.evictionsExpected != podEvictor.TotalEvicted() {
+				t.Errorf("Expected %v evictions, got %v", tc.evictionsExpected, podEvictor.TotalEvicted())
+			}
+		})
+	}
+}
+
+func TestLowNodeUtilizationWarnTargetThresholds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{
+		test.BuildTestNode("n1", 1000, 3000, 20, nil),
+		test.BuildTestNode("n2", 1000, 3000, 20, nil),
+		test.BuildTestNode("n3", 1000, 3000, 20, nil),
+	}
+	objs := []runtime.Object{nodes[0], nodes[1], nodes[2]}
+	// n1 is underutilized, n2 above the warn target thresholds and n3 above the target thresholds
+	for node, count := range map[string]int{"n2": 6, "n3": 9} {
+		for i := 0; i < count; i++ {
+			objs = append(objs, test.BuildTestPod(fmt.Sprintf("%s-p%d", node, i), 100, 0, node, test.SetRSOwnerRef))
+		}
+	}
+	fakeClient := fake.NewSimpleClientset(objs...)
+
+	handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{}, nil)
+	if err != nil {
+		t.Fatalf("Unable to initialize a framework handle: %v", err)
+	}
+	eventRecorder := events.NewFakeRecorder(10)
+	handle.EventRecorderImpl = eventRecorder
+
+	plugin, err := NewLowNodeUtilization(&LowNodeUtilizationArgs{
+		Thresholds:           api.ResourceThresholds{v1.ResourceCPU: 20},
+		TargetThresholds:     api.ResourceThresholds{v1.ResourceCPU: 80},
+		WarnTargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50},
+	}, handle)
+	if err != nil {
+		t.Fatalf("Unable to initialize the plugin: %v", err)
+	}
+	plugin.(frameworktypes.BalancePlugin).Balance(ctx, nodes)
+
+	if podEvictor.NodeEvicted(nodes[1]) != 0 || podEvictor.NodeEvicted(nodes[2]) == 0 {
+		t.Errorf("Expected pods to be evicted from n3 only, got %v from n2 and %v from n3", podEvictor.NodeEvicted(nodes[1]), podEvictor.NodeEvicted(nodes[2]))
+	}
+	if len(eventRecorder.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %v", len(eventRecorder.Events))
+	}
+	if event := <-eventRecorder.Events; !strings.Contains(event, WarnTargetThresholdsReason) || !strings.Contains(event, "cpu:60") {
+		t.Errorf("Unexpected event: %v", event)
+	}
+}
@@ -64,6 +64,54 @@ const (
 	MaxResourcePercentage = 100
 )
 
+var (
+	_ api.EvictableNamespacesArgs = &LowNodeUtilizationArgs{}
+	_ api.EvictableNamespacesArgs = &HighNodeUtilizationArgs{}
+	_ api.EvictableNamespacesArgs = &VolumeAttachmentAwareConsolidationArgs{}
+	_ api.EvictableNamespacesArgs = &ColdStartAwareConsolidationArgs{}
+	_ api.EvictableNamespacesArgs = &ScheduledDownscaleHelperArgs{}
+)
+
+func (a *LowNodeUtilizationArgs) GetEvictableNamespaces() *api.Namespaces {
+	return a.EvictableNamespaces
+}
+
+func (a *LowNodeUtilizationArgs) SetEvictableNamespaces(namespaces *api.Namespaces) {
+	a.EvictableNamespaces = namespaces
+}
+
+func (a *HighNodeUtilizationArgs) GetEvictableNamespaces() *api.Namespaces {
+	return a.EvictableNamespaces
+}
+
+func (a *HighNodeUtilizationArgs) SetEvictableNamespaces(namespaces *api.Namespaces) {
+	a.EvictableNamespaces = namespaces
+}
+
+func (a *VolumeAttachmentAwareConsolidationArgs) GetEvictableNamespaces() *api.Namespaces {
+	return a.EvictableNamespaces
+}
+
+func (a *VolumeAttachmentAwareConsolidationArgs) SetEvictableNamespaces(namespaces *api.Namespaces) {
+	a.EvictableNamespaces = namespaces
+}
+
+func (a *ColdStartAwareConsolidationArgs) GetEvictableNamespaces() *api.Namespaces {
+	return a.EvictableNamespaces
+}
+
+func (a *ColdStartAwareConsolidationArgs) SetEvictableNamespaces(namespaces *api.Namespaces) {
+	a.EvictableNamespaces = namespaces
+}
+
+func (a *ScheduledDownscaleHelperArgs) GetEvictableNamespaces() *api.Namespaces {
+	return a.EvictableNamespaces
+}
+
+func (a *ScheduledDownscaleHelperArgs) SetEvictableNamespaces(namespaces *api.Namespaces) {
+	a.EvictableNamespaces = namespaces
+}
+
 func normalizePercentage(percent api.Percentage) api.Percentage {
 	if percent > MaxResourcePercentage {
 		return MaxResourcePercentage
@@ -75,17 +123,18 @@ func normalizePercentage(percent api.Percentage) api.Percentage {
 }
 
 func getNodeThresholds(
+	ctx context.Context,
 	nodes []*v1.Node,
 	lowThreshold, highThreshold api.ResourceThresholds,
 	resourceNames []v1.ResourceName,
-	getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc,
+	utilizationProvider frameworktypes.UtilizationProvider,
 	useDeviationThresholds bool,
 ) map[string]NodeThresholds {
 	nodeThresholdsMap := map[string]NodeThresholds{}
 
 	averageResourceUsagePercent := api.ResourceThresholds{}
 	if useDeviationThresholds {
-		averageResourceUsagePercent = averageNodeBasicresources(nodes, getPodsAssignedToNode, resourceNames)
+		averageResourceUsagePercent = averageNodeBasicresources(ctx, nodes, utilizationProvider, resourceNames)
 	}
 
 	for _, node := range nodes {
@@ -120,22 +169,31 @@ func getNodeThresholds(
 }
 
 func getNodeUsage(
+	ctx context.Context,
 	nodes []*v1.Node,
 	resourceNames []v1.ResourceName,
 	getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc,
+	utilizationProvider frameworktypes.UtilizationProvider,
 ) []NodeUsage {
+	logger := klog.FromContext(ctx)
 	var nodeUsageList []NodeUsage
 
 	for _, node := range nodes {
 		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, nil)
 		if err != nil {
-			klog.V(2).InfoS("Node will not be processed, error accessing its pods", "node", klog.KObj(node), "err", err)
+			logger.V(2).Info("Node will not be processed, error accessing its pods", "node", klog.KObj(node), "err", err)
+			continue
+		}
+
+		usage, err := utilizationProvider.NodeUtilization(ctx, node, resourceNames)
+		if err != nil {
+			logger.V(2).Info("Node will not be processed, error computing its utilization", "node", klog.KObj(node), "err", err)
 			continue
 		}
 
 		nodeUsageList = append(nodeUsageList, NodeUsage{
 			node:    node,
-			usage:   nodeutil.NodeUtilization(pods, resourceNames),
+			usage:   usage,
 			allPods: pods,
 		})
 	}
@@ -164,6 +222,59 @@ func resourceThreshold(nodeCapacity v1.ResourceList, resourceName v1.ResourceNam
 	return resource.NewQuantity(resourceCapacityFraction(resourceCapacityQuantity.Value()), defaultFormat)
 }
 
+// freeResourceThreshold returns the usage of the resource leaving the given quantity of the node capacity free
+func freeResourceThreshold(nodeCapacity v1.ResourceList, resourceName v1.ResourceName, free resource.Quantity) *resource.Quantity {
+	defaultFormat := resource.DecimalSI
+	if resourceName == v1.ResourceMemory {
+		defaultFormat = resource.BinarySI
+	}
+
+	threshold := nodeCapacity.Name(resourceName, defaultFormat).DeepCopy()
+	threshold.Sub(free)
+	if threshold.Sign() < 0 {
+		threshold.Set(0)
+	}
+	return &threshold
+}
+
+// withFreeThresholds returns a copy of the thresholds holding the resources of the free thresholds as well, so they
+// are neither defaulted nor left out of the resources the nodes are classified by. Their thresholds are placeholders
+// applyFreeThresholds replaces with the thresholds of each node.
+func withFreeThresholds(thresholds api.ResourceThresholds, freeThresholds map[v1.ResourceName]resource.Quantity) api.ResourceThresholds {
+	merged := make(api.ResourceThresholds, len(thresholds)+len(freeThresholds))
+	for name, percentage := range thresholds {
+		merged[name] = percentage
+	}
+	for name := range freeThresholds {
+		merged[name] = MaxResourcePercentage
+	}
+	return merged
+}
+
+// applyFreeThresholds sets the thresholds of the resources configured as quantities to leave free on the nodes.
+// The same quantity is a different share of the capacity of nodes of different sizes, unlike the percentages.
+func applyFreeThresholds(nodes []*v1.Node, nodeThresholds map[string]NodeThresholds, lowFreeThresholds, highFreeThresholds map[v1.ResourceName]resource.Quantity) {
+	if len(lowFreeThresholds) == 0 && len(highFreeThresholds) == 0 {
+		return
+	}
+	for _, node := range nodes {
+		thresholds, ok := nodeThresholds[node.Name]
+		if !ok {
+			continue
+		}
+		nodeCapacity := node.Status.Capacity
+		if len(node.Status.Allocatable) > 0 {
+			nodeCapacity = node.Status.Allocatable
+		}
+		for name, free := range lowFreeThresholds {
+			thresholds.lowResourceThreshold[name] = freeResourceThreshold(nodeCapacity, name, free)
+		}
+		for name, free := range highFreeThresholds {
+			thresholds.highResourceThreshold[name] = freeResourceThreshold(nodeCapacity, name, free)
+		}
+	}
+}
+
 func roundTo2Decimals(percentage float64) float64 {
 	return math.Round(percentage*100) / 100
 }
@@ -189,6 +300,7 @@ func resourceUsagePercentages(nodeUsage NodeUsage) map[v1.ResourceName]float64 {
 // classifyNodes classifies the nodes into low-utilization or high-utilization nodes. If a node lies between
 // low and high thresholds, it is simply ignored.
 func classifyNodes(
+	logger klog.Logger,
 	nodeUsages []NodeUsage,
 	nodeThresholds map[string]NodeThresholds,
 	lowThresholdFilter, highThresholdFilter func(node *v1.Node, usage NodeUsage, threshold NodeThresholds) bool,
@@ -201,13 +313,13 @@ func classifyNodes(
 			thresholds: nodeThresholds[nodeUsage.node.Name],
 		}
 		if lowThresholdFilter(nodeUsage.node, nodeUsage, nodeThresholds[nodeUsage.node.Name]) {
-			klog.InfoS("Node is underutilized", "node", klog.KObj(nodeUsage.node), "usage", nodeUsage.usage, "usagePercentage", resourceUsagePercentages(nodeUsage))
+			logger.Info("Node is underutilized", "node", klog.KObj(nodeUsage.node), "usage", nodeUsage.usage, "usagePercentage", resourceUsagePercentages(nodeUsage))
 			lowNodes = append(lowNodes, nodeInfo)
 		} else if highThresholdFilter(nodeUsage.node, nodeUsage, nodeThresholds[nodeUsage.node.Name]) {
-			klog.InfoS("Node is overutilized", "node", klog.KObj(nodeUsage.node), "usage", nodeUsage.usage, "usagePercentage", resourceUsagePercentages(nodeUsage))
+			logger.Info("Node is overutilized", "node", klog.KObj(nodeUsage.node), "usage", nodeUsage.usage, "usagePercentage", resourceUsagePercentages(nodeUsage))
 			highNodes = append(highNodes, nodeInfo)
 		} else {
-			klog.InfoS("Node is appropriately utilized", "node", klog.KObj(nodeUsage.node), "usage", nodeUsage.usage, "usagePercentage", resourceUsagePercentages(nodeUsage))
+			logger.Info("Node is appropriately utilized", "node", klog.KObj(nodeUsage.node), "usage", nodeUsage.usage, "usagePercentage", resourceUsagePercentages(nodeUsage))
 		}
 	}
 
@@ -224,9 +336,11 @@ func evictPodsFromSourceNodes(
 	podEvictor frameworktypes.Evictor,
 	evictOptions evictions.EvictOptions,
 	podFilter func(pod *v1.Pod) bool,
+	sortPods func(pods []*v1.Pod),
 	resourceNames []v1.ResourceName,
 	continueEviction continueEvictionCond,
 ) {
+	logger := klog.FromContext(ctx)
 	// upper bound on total number of pods/cpu/memory and optional extended resources to be moved
 	totalAvailableUsage := map[v1.ResourceName]*resource.Quantity{
 		v1.ResourcePods:   {},
@@ -258,22 +372,20 @@ func evictPodsFromSourceNodes(
 			keysAndValues = append(keysAndValues, string(name), totalAvailableUsage[name].Value())
 		}
 	}
-	klog.V(1).InfoS("Total capacity to be moved", keysAndValues...)
+	logger.V(1).Info("Total capacity to be moved", keysAndValues...)
 
 	for _, node := range sourceNodes {
-		klog.V(3).InfoS("Evicting pods from node", "node", klog.KObj(node.node), "usage", node.usage)
+		logger.V(3).Info("Evicting pods from node", "node", klog.KObj(node.node), "usage", node.usage)
 
 		nonRemovablePods, removablePods := classifyPods(node.allPods, podFilter)
-		klog.V(2).InfoS("Pods on node", "node", klog.KObj(node.node), "allPods", len(node.allPods), "nonRemovablePods", len(nonRemovablePods), "removablePods", len(removablePods))
+		logger.V(2).Info("Pods on node", "node", klog.KObj(node.node), "allPods", len(node.allPods), "nonRemovablePods", len(nonRemovablePods), "removablePods", len(removablePods))
 
 		if len(removablePods) == 0 {
-			klog.V(1).InfoS("No removable pods on node, try next node", "node", klog.KObj(node.node))
+			logger.V(1).Info("No removable pods on node, try next node", "node", klog.KObj(node.node))
 			continue
 		}
 
-		klog.V(1).InfoS("Evicting pods based on priority, if they have same priority, they'll be evicted based on QoS tiers")
-		// sort the evictable Pods based on priority. This also sorts them based on QoS. If there are multiple pods with same priority, they are sorted based on QoS tiers.
-		podutil.SortPodsBasedOnPriorityLowToHigh(removablePods)
+		sortPods(removablePods)
 		err := evictPods(ctx, evictableNamespaces, removablePods, node, totalAvailableUsage, taintsOfDestinationNodes, podEvictor, evictOptions, continueEviction)
 		if err != nil {
 			switch err.(type) {
@@ -296,6 +408,7 @@ func evictPods(
 	evictOptions evictions.EvictOptions,
 	continueEviction continueEvictionCond,
 ) error {
+	logger := klog.FromContext(ctx)
 	var excludedNamespaces sets.Set[string]
 	if evictableNamespaces != nil {
 		excludedNamespaces = sets.New(evictableNamespaces.Exclude...)
@@ -304,7 +417,7 @@ func evictPods(
 	if continueEviction(nodeInfo, totalAvailableUsage) {
 		for _, pod := range inputPods {
 			if !utils.PodToleratesTaints(pod, taintsOfLowNodes) {
-				klog.V(3).InfoS("Skipping eviction for pod, doesn't tolerate node taint", "pod", klog.KObj(pod))
+				logger.V(3).Info("Skipping eviction for pod, doesn't tolerate node taint", "pod", klog.KObj(pod))
 				continue
 			}
 
@@ -313,7 +426,7 @@ func evictPods(
 				WithoutNamespaces(excludedNamespaces).
 				BuildFilterFunc()
 			if err != nil {
-				klog.ErrorS(err, "could not build preEvictionFilter with namespace exclusion")
+				logger.Error(err, "could not build preEvictionFilter with namespace exclusion")
 				continue
 			}
 
@@ -322,7 +435,7 @@ func evictPods(
 			}
 			err = podEvictor.Evict(ctx, pod, evictOptions)
 			if err == nil {
-				klog.V(3).InfoS("Evicted pods", "pod", klog.KObj(pod))
+				logger.V(3).Info("Evicted pods", "pod", klog.KObj(pod))
 
 				for name := range totalAvailableUsage {
 					if name == v1.ResourcePods {
@@ -347,7 +460,7 @@ func evictPods(
 					}
 				}
 
-				klog.V(3).InfoS("Updated node usage", keysAndValues...)
+				logger.V(3).Info("Updated node usage", keysAndValues...)
 				// check if pods can be still evicted
 				if !continueEviction(nodeInfo, totalAvailableUsage) {
 					break
@@ -358,13 +471,20 @@ func evictPods(
 			case *evictions.EvictionNodeLimitError, *evictions.EvictionTotalLimitError:
 				return err
 			default:
-				klog.Errorf("eviction failed: %v", err)
+				logger.Error(err, "Eviction failed")
 			}
 		}
 	}
 	return nil
 }
 
+// sortPodsBasedOnPriority sorts the pods in the order LowNodeUtilization and HighNodeUtilization evict them
+func sortPodsBasedOnPriority(pods []*v1.Pod) {
+	klog.V(1).InfoS("Evicting pods based on priority, if they have same priority, they'll be evicted based on QoS tiers")
+	// sort the evictable Pods based on priority. This also sorts them based on QoS. If there are multiple pods with same priority, they are sorted based on QoS tiers.
+	podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+}
+
 // sortNodesByUsage sorts nodes based on usage according to the given plugin.
 func sortNodesByUsage(nodes []NodeInfo, ascending bool) {
 	sort.Slice(nodes, func(i, j int) bool {
@@ -414,6 +534,51 @@ func isNodeWithLowUtilization(usage NodeUsage, threshold map[v1.ResourceName]*re
 	return true
 }
 
+// weightedUsagePercentage returns the average of the given quantities, in percentage of the node capacity, weighted
+// by resource. The resources the node has no capacity for are not counted, false is returned when none is left.
+func weightedUsagePercentage(node *v1.Node, quantities map[v1.ResourceName]*resource.Quantity, weights map[v1.ResourceName]int32) (float64, bool) {
+	nodeCapacity := node.Status.Capacity
+	if len(node.Status.Allocatable) > 0 {
+		nodeCapacity = node.Status.Allocatable
+	}
+
+	var total, totalWeight float64
+	for name, weight := range weights {
+		capacity, quantity := nodeCapacity[name], quantities[name]
+		if capacity.IsZero() || quantity == nil {
+			continue
+		}
+		total += float64(weight) * 100 * float64(quantity.MilliValue()) / float64(capacity.MilliValue())
+		totalWeight += float64(weight)
+	}
+	if totalWeight == 0 {
+		return 0, false
+	}
+	return total / totalWeight, true
+}
+
+// isNodeAboveWeightedTargetUtilization checks if a node is overutilized
+// The weighted average of the resource usage has to be above the weighted average of the high thresholds
+func isNodeAboveWeightedTargetUtilization(usage NodeUsage, threshold map[v1.ResourceName]*resource.Quantity, weights map[v1.ResourceName]int32) bool {
+	usagePercentage, ok := weightedUsagePercentage(usage.node, usage.usage, weights)
+	if !ok {
+		return false
+	}
+	thresholdPercentage, _ := weightedUsagePercentage(usage.node, threshold, weights)
+	return usagePercentage > thresholdPercentage
+}
+
+// isNodeWithWeightedLowUtilization checks if a node is underutilized
+// The weighted average of the resource usage has to be below the weighted average of the low thresholds
+func isNodeWithWeightedLowUtilization(usage NodeUsage, threshold map[v1.ResourceName]*resource.Quantity, weights map[v1.ResourceName]int32) bool {
+	usagePercentage, ok := weightedUsagePercentage(usage.node, usage.usage, weights)
+	if !ok {
+		return false
+	}
+	thresholdPercentage, _ := weightedUsagePercentage(usage.node, threshold, weights)
+	return usagePercentage <= thresholdPercentage
+}
+
 // getResourceNames returns list of resource names in resource thresholds
 func getResourceNames(thresholds api.ResourceThresholds) []v1.ResourceName {
 	resourceNames := make([]v1.ResourceName, 0, len(thresholds))
@@ -437,17 +602,16 @@ func classifyPods(pods []*v1.Pod, filter func(pod *v1.Pod) bool) ([]*v1.Pod, []*
 	return nonRemovablePods, removablePods
 }
 
-func averageNodeBasicresources(nodes []*v1.Node, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, resourceNames []v1.ResourceName) api.ResourceThresholds {
+func averageNodeBasicresources(ctx context.Context, nodes []*v1.Node, utilizationProvider frameworktypes.UtilizationProvider, resourceNames []v1.ResourceName) api.ResourceThresholds {
 	total := api.ResourceThresholds{}
 	average := api.ResourceThresholds{}
 	numberOfNodes := len(nodes)
 	for _, node := range nodes {
-		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, nil)
+		usage, err := utilizationProvider.NodeUtilization(ctx, node, resourceNames)
 		if err != nil {
 			numberOfNodes--
 			continue
 		}
-		usage := nodeutil.NodeUtilization(pods, resourceNames)
 		nodeCapacity := node.Status.Capacity
 		if len(node.Status.Allocatable) > 0 {
 			nodeCapacity = node.Status.Allocatable
@@ -0,0 +1,367 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v2"
+	"k8s.io/klog/v2"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions/filters"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	ScheduledDownscaleHelperPluginName = "ScheduledDownscaleHelper"
+
+	// OffHoursMinReplicasAnnotationKey opts a HorizontalPodAutoscaler in lowering its minReplicas to the
+	// annotation value during the off-hours windows
+	OffHoursMinReplicasAnnotationKey = "descheduler.alpha.kubernetes.io/off-hours-min-replicas"
+	// BusinessHoursMinReplicasAnnotationKey keeps the minReplicas of a HorizontalPodAutoscaler to restore
+	// once the off-hours windows end
+	BusinessHoursMinReplicasAnnotationKey = "descheduler.alpha.kubernetes.io/business-hours-min-replicas"
+
+	// consolidatedStateKey is set from the first consolidation of an off-hours window until the pods are spread back
+	consolidatedStateKey = "consolidated"
+)
+
+// ScheduledDownscaleHelper consolidates the pods on a minimal set of nodes during off-hours windows like
+// HighNodeUtilization, so the cluster autoscaler can remove the drained nodes, and spreads them back across
+// the nodes once the windows end like LowNodeUtilization. Only the pods of a lower priority than the
+// priority threshold are moved when one is set.
+type ScheduledDownscaleHelper struct {
+	handle      frameworktypes.Handle
+	args        *ScheduledDownscaleHelperArgs
+	windows     []offHoursWindow
+	location    *time.Location
+	evictor     *downscaleEvictor
+	consolidate frameworktypes.BalancePlugin
+	spread      frameworktypes.BalancePlugin
+	now         func() time.Time
+	hpaLister   autoscalinglisters.HorizontalPodAutoscalerLister
+}
+
+var _ frameworktypes.BalancePlugin = &ScheduledDownscaleHelper{}
+
+var _ frameworktypes.AllPodsRequirer = &ScheduledDownscaleHelperArgs{}
+
+// RequiresAllPods tells the utilization of the nodes is computed from all their pods
+func (a *ScheduledDownscaleHelperArgs) RequiresAllPods() bool {
+	return true
+}
+
+// NewScheduledDownscaleHelper builds plugin from its arguments while passing a handle
+func NewScheduledDownscaleHelper(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	downscaleArgs, ok := args.(*ScheduledDownscaleHelperArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type ScheduledDownscaleHelperArgs, got %T", args)
+	}
+
+	windows := make([]offHoursWindow, 0, len(downscaleArgs.Windows))
+	for _, window := range downscaleArgs.Windows {
+		parsed, err := parseOffHoursWindow(window)
+		if err != nil {
+			return nil, fmt.Errorf("invalid off-hours window: %v", err)
+		}
+		windows = append(windows, parsed)
+	}
+	location, err := time.LoadLocation(downscaleArgs.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone: %v", err)
+	}
+
+	evictor := &downscaleEvictor{Evictor: handle.Evictor()}
+	if pt := downscaleArgs.PriorityThreshold; pt != nil && (pt.Value != nil || len(pt.Name) > 0) {
+		priority, err := utils.GetPriorityValueFromPriorityThreshold(context.TODO(), handle.ClientSet(), pt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get priority threshold: %v", err)
+		}
+		evictor.priorityThreshold = &priority
+	}
+	downscaleHandle := &downscaleHandle{Handle: handle, evictor: evictor}
+
+	consolidate, err := NewHighNodeUtilization(&HighNodeUtilizationArgs{
+		FilteringArgs:       downscaleArgs.FilteringArgs,
+		Thresholds:          downscaleArgs.Thresholds,
+		NumberOfNodes:       downscaleArgs.NumberOfNodes,
+		EvictableNamespaces: downscaleArgs.EvictableNamespaces,
+	}, downscaleHandle)
+	if err != nil {
+		return nil, err
+	}
+	var spread frameworktypes.Plugin
+	if len(downscaleArgs.SpreadThresholds) > 0 {
+		spread, err = NewLowNodeUtilization(&LowNodeUtilizationArgs{
+			FilteringArgs:       downscaleArgs.FilteringArgs,
+			Thresholds:          downscaleArgs.SpreadThresholds,
+			TargetThresholds:    downscaleArgs.SpreadTargetThresholds,
+			NumberOfNodes:       downscaleArgs.NumberOfNodes,
+			EvictableNamespaces: downscaleArgs.EvictableNamespaces,
+		}, downscaleHandle)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plugin := &ScheduledDownscaleHelper{
+		handle:      handle,
+		args:        downscaleArgs,
+		windows:     windows,
+		location:    location,
+		evictor:     evictor,
+		consolidate: consolidate.(frameworktypes.BalancePlugin),
+		now:         time.Now,
+	}
+	if spread != nil {
+		plugin.spread = spread.(frameworktypes.BalancePlugin)
+	}
+	if downscaleArgs.ScaleHorizontalPodAutoscalers {
+		// The HorizontalPodAutoscalers are read from the cluster, the cached client of the dry run mode holds none
+		plugin.hpaLister = handle.ClusterInformerFactory().Autoscaling().V2().HorizontalPodAutoscalers().Lister()
+	}
+	return plugin, nil
+}
+
+// Name retrieves the plugin name
+func (s *ScheduledDownscaleHelper) Name() string {
+	return ScheduledDownscaleHelperPluginName
+}
+
+// Balance extension point implementation for the plugin
+func (s *ScheduledDownscaleHelper) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	offHours := s.inOffHours(s.now())
+
+	if s.args.ScaleHorizontalPodAutoscalers {
+		if err := s.scaleHorizontalPodAutoscalers(ctx, offHours); err != nil {
+			logger.Error(err, "Unable to scale the HorizontalPodAutoscalers")
+		}
+	}
+
+	_, consolidated, err := s.handle.StateStore().Get(ctx, consolidatedStateKey)
+	if err != nil {
+		return &frameworktypes.Status{Err: fmt.Errorf("unable to read the consolidation state: %v", err)}
+	}
+
+	if offHours {
+		if !consolidated {
+			if err := s.handle.StateStore().Set(ctx, consolidatedStateKey, s.now().UTC().Format(time.RFC3339)); err != nil {
+				return &frameworktypes.Status{Err: fmt.Errorf("unable to record the consolidation state: %v", err)}
+			}
+		}
+		logger.V(1).Info("Consolidating the pods during the off-hours window")
+		return s.consolidate.Balance(ctx, nodes)
+	}
+
+	if !consolidated {
+		logger.V(1).Info("Outside of the off-hours windows, nothing to do here")
+		return nil
+	}
+	// The pods are spread back every cycle until the nodes are balanced, i.e. a spread evicts nothing
+	if s.spread != nil {
+		logger.V(1).Info("Spreading back the pods consolidated during the off-hours window")
+		s.evictor.evicted = 0
+		if status := s.spread.Balance(ctx, nodes); status != nil && status.Err != nil {
+			return status
+		}
+		if s.evictor.evicted > 0 {
+			return nil
+		}
+	}
+	if err := s.handle.StateStore().Delete(ctx, consolidatedStateKey); err != nil {
+		return &frameworktypes.Status{Err: fmt.Errorf("unable to clear the consolidation state: %v", err)}
+	}
+	return nil
+}
+
+// inOffHours tells whether the time is within any of the off-hours windows
+func (s *ScheduledDownscaleHelper) inOffHours(now time.Time) bool {
+	now = now.In(s.location)
+	for _, window := range s.windows {
+		if window.contains(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// scaleHorizontalPodAutoscalers lowers the minReplicas of the opted in HorizontalPodAutoscalers during
+// the off-hours windows, keeping the business hours value in an annotation, and restores it outside of them
+func (s *ScheduledDownscaleHelper) scaleHorizontalPodAutoscalers(ctx context.Context, offHours bool) error {
+	logger := klog.FromContext(ctx)
+	hpas, err := s.hpaLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	var excluded sets.Set[string]
+	if s.args.EvictableNamespaces != nil {
+		excluded = sets.New(s.args.EvictableNamespaces.Exclude...)
+	}
+
+	for _, hpa := range hpas {
+		offHoursValue, ok := hpa.Annotations[OffHoursMinReplicasAnnotationKey]
+		if !ok || excluded.Has(hpa.Namespace) {
+			continue
+		}
+		businessHoursValue, scaledDown := hpa.Annotations[BusinessHoursMinReplicasAnnotationKey]
+
+		var minReplicas *int32
+		annotations := map[string]interface{}{}
+		switch {
+		case offHours && !scaledDown:
+			offHoursMinReplicas, err := strconv.ParseInt(offHoursValue, 10, 32)
+			if err != nil || offHoursMinReplicas < 1 {
+				logger.Info("Ignoring invalid off-hours min replicas", "horizontalPodAutoscaler", klog.KObj(hpa), "value", offHoursValue)
+				continue
+			}
+			// minReplicas defaults to 1
+			businessHoursMinReplicas := int32(1)
+			if hpa.Spec.MinReplicas != nil {
+				businessHoursMinReplicas = *hpa.Spec.MinReplicas
+			}
+			if businessHoursMinReplicas <= int32(offHoursMinReplicas) {
+				continue
+			}
+			minReplicas = utilptr.To(int32(offHoursMinReplicas))
+			annotations[BusinessHoursMinReplicasAnnotationKey] = strconv.Itoa(int(businessHoursMinReplicas))
+		case !offHours && scaledDown:
+			businessHoursMinReplicas, err := strconv.ParseInt(businessHoursValue, 10, 32)
+			if err != nil || businessHoursMinReplicas < 1 {
+				logger.Info("Ignoring invalid business hours min replicas", "horizontalPodAutoscaler", klog.KObj(hpa), "value", businessHoursValue)
+				continue
+			}
+			minReplicas = utilptr.To(int32(businessHoursMinReplicas))
+			annotations[BusinessHoursMinReplicasAnnotationKey] = nil
+		default:
+			continue
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{"annotations": annotations},
+			"spec":     map[string]interface{}{"minReplicas": minReplicas},
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := s.handle.ClientSet().AutoscalingV2().HorizontalPodAutoscalers(hpa.Namespace).Patch(ctx, hpa.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			logger.Error(err, "Unable to scale the HorizontalPodAutoscaler", "horizontalPodAutoscaler", klog.KObj(hpa))
+			continue
+		}
+		logger.V(1).Info("Scaled the HorizontalPodAutoscaler", "horizontalPodAutoscaler", klog.KObj(hpa), "minReplicas", *minReplicas, "offHours", offHours)
+	}
+	return nil
+}
+
+// offHoursWindow is a parsed OffHoursWindow, the times are minutes since midnight
+type offHoursWindow struct {
+	days       sets.Set[time.Weekday]
+	start, end int
+}
+
+func parseOffHoursWindow(window OffHoursWindow) (offHoursWindow, error) {
+	parsed := offHoursWindow{days: sets.New[time.Weekday]()}
+	for _, day := range window.Days {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return offHoursWindow{}, fmt.Errorf("invalid day %q, expected Mon, Tue, Wed, Thu, Fri, Sat or Sun", day)
+		}
+		parsed.days.Insert(weekday)
+	}
+	start, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return offHoursWindow{}, fmt.Errorf("invalid start %q, expected HH:MM", window.Start)
+	}
+	end, err := time.Parse("15:04", window.End)
+	if err != nil {
+		return offHoursWindow{}, fmt.Errorf("invalid end %q, expected HH:MM", window.End)
+	}
+	parsed.start = start.Hour()*60 + start.Minute()
+	parsed.end = end.Hour()*60 + end.Minute()
+	return parsed, nil
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(day, weekday.String()) || strings.EqualFold(day, weekday.String()[:3]) {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// contains tells whether the time is within the window, the windows spanning midnight
+// belong to the day they start on
+func (w offHoursWindow) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	startsOn := func(day time.Weekday) bool {
+		return w.days.Len() == 0 || w.days.Has(day)
+	}
+	if w.start < w.end {
+		return startsOn(t.Weekday()) && minute >= w.start && minute < w.end
+	}
+	return (startsOn(t.Weekday()) && minute >= w.start) ||
+		(startsOn((t.Weekday()+6)%7) && minute < w.end)
+}
+
+// downscaleHandle hands the consolidation and the spread the evictor of the plugin
+type downscaleHandle struct {
+	frameworktypes.Handle
+	evictor *downscaleEvictor
+}
+
+func (h *downscaleHandle) Evictor() frameworktypes.Evictor {
+	return h.evictor
+}
+
+// downscaleEvictor only moves the pods below the priority threshold, reporting the evictions
+// as evictions of the plugin and counting them
+type downscaleEvictor struct {
+	frameworktypes.Evictor
+	priorityThreshold *int32
+	evicted           uint
+}
+
+func (e *downscaleEvictor) Filter(pod *v1.Pod) bool {
+	if e.priorityThreshold != nil && !filters.IsPodEvictableBasedOnPriority(pod, *e.priorityThreshold) {
+		return false
+	}
+	return e.Evictor.Filter(pod)
+}
+
+func (e *downscaleEvictor) Evict(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions) error {
+	opts.StrategyName = ScheduledDownscaleHelperPluginName
+	if err := e.Evictor.Evict(ctx, pod, opts); err != nil {
+		return err
+	}
+	e.evicted++
+	return nil
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	"sigs.k8s.io/descheduler/test"
+)
+
+// Friday 23:00 UTC
+var fridayNight = time.Date(2024, time.March, 1, 23, 0, 0, 0, time.UTC)
+
+func withPriority(priority int32) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Priority = utilptr.To(priority)
+	}
+}
+
+func TestOffHoursWindow(t *testing.T) {
+	tests := []struct {
+		description string
+		window      OffHoursWindow
+		now         time.Time
+		expected    bool
+	}{
+		{
+			description: "within a window of every day",
+			window:      OffHoursWindow{Start: "12:00", End: "14:00"},
+			now:         time.Date(2024, time.March, 5, 13, 0, 0, 0, time.UTC),
+			expected:    true,
+		},
+		{
+			description: "end of the window is excluded",
+			window:      OffHoursWindow{Start: "12:00", End: "14:00"},
+			now:         time.Date(2024, time.March, 5, 14, 0, 0, 0, time.UTC),
+			expected:    false,
+		},
+		{
+			description: "window starting on another day",
+			window:      OffHoursWindow{Days: []string{"Sat", "Sun"}, Start: "00:00", End: "23:59"},
+			now:         fridayNight,
+			expected:    false,
+		},
+		{
+			description: "window spanning midnight before midnight",
+			window:      OffHoursWindow{Days: []string{"Friday"}, Start: "20:00", End: "07:00"},
+			now:         fridayNight,
+			expected:    true,
+		},
+		{
+			description: "window spanning midnight after midnight",
+			window:      OffHoursWindow{Days: []string{"Fri"}, Start: "20:00", End: "07:00"},
+			now:         fridayNight.Add(6 * time.Hour),
+			expected:    true,
+		},
+		{
+			description: "window spanning midnight of the previous day",
+			window:      OffHoursWindow{Days: []string{"Sat"}, Start: "20:00", End: "07:00"},
+			now:         fridayNight.Add(6 * time.Hour),
+			expected:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			window, err := parseOffHoursWindow(tc.window)
+			if err != nil {
+				t.Fatalf("Unable to parse the window: %v", err)
+			}
+			if got := window.contains(tc.now); got != tc.expected {
+				t.Errorf("Expected the window to contain %v: %v, got %v", tc.now, tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestScheduledDownscaleHelper(t *testing.T) {
+	// Room for both pods of n1 on n2
+	consolidationNodes := []*v1.Node{
+		test.BuildTestNode("n1", 4000, 3000, 10, nil),
+		test.BuildTestNode("n2", 4000, 3000, 5, nil),
+	}
+	consolidationPods := []*v1.Pod{
+		test.BuildTestPod("low", 100, 0, "n1", withPriority(0)),
+		test.BuildTestPod("high", 100, 0, "n1", withPriority(2000)),
+		test.BuildTestPod("p1", 100, 0, "n2", withPriority(2000)),
+		test.BuildTestPod("p2", 100, 0, "n2", withPriority(2000)),
+		test.BuildTestPod("p3", 100, 0, "n2", withPriority(2000)),
+	}
+
+	testCases := []struct {
+		name               string
+		now                time.Time
+		consolidated       bool
+		nodes              []*v1.Node
+		pods               []*v1.Pod
+		evictedPods        []string
+		expectConsolidated bool
+	}{
+		{
+			name:               "pods below the priority threshold are consolidated during the window",
+			now:                fridayNight,
+			nodes:              consolidationNodes,
+			pods:               consolidationPods,
+			evictedPods:        []string{"low"},
+			expectConsolidated: true,
+		},
+		{
+			name:  "nothing is done outside of the windows",
+			now:   fridayNight.Add(-12 * time.Hour),
+			nodes: consolidationNodes,
+			pods:  consolidationPods,
+		},
+		{
+			name:         "pods below the priority threshold are spread back once the window ends",
+			now:          fridayNight.Add(10 * time.Hour),
+			consolidated: true,
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, nil),
+				test.BuildTestNode("n2", 4000, 3000, 10, nil),
+			},
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 100, 0, "n1", withPriority(2000)),
+				test.BuildTestPod("low", 100, 0, "n2", withPriority(0)),
+				test.BuildTestPod("p2", 100, 0, "n2", withPriority(2000)),
+				test.BuildTestPod("p3", 100, 0, "n2", withPriority(2000)),
+				test.BuildTestPod("p4", 100, 0, "n2", withPriority(2000)),
+				test.BuildTestPod("p5", 100, 0, "n2", withPriority(2000)),
+				test.BuildTestPod("p6", 100, 0, "n2", withPriority(2000)),
+			},
+			evictedPods:        []string{"low"},
+			expectConsolidated: true,
+		},
+		{
+			name:         "consolidation state is cleared once the pods are spread back",
+			now:          fridayNight.Add(10 * time.Hour),
+			consolidated: true,
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, nil),
+				test.BuildTestNode("n2", 4000, 3000, 10, nil),
+			},
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 100, 0, "n1", withPriority(0)),
+				test.BuildTestPod("p2", 100, 0, "n1", withPriority(0)),
+				test.BuildTestPod("p3", 100, 0, "n1", withPriority(0)),
+				test.BuildTestPod("p4", 100, 0, "n2", withPriority(0)),
+				test.BuildTestPod("p5", 100, 0, "n2", withPriority(0)),
+				test.BuildTestPod("p6", 100, 0, "n2", withPriority(0)),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range testCase.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range testCase.pods {
+				objs = append(objs, pod)
+			}
+			podsForEviction := make(map[string]struct{})
+			for _, pod := range testCase.evictedPods {
+				podsForEviction[pod] = struct{}{}
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{}, nil)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+			if testCase.consolidated {
+				if err := handle.StateStore().Set(ctx, consolidatedStateKey, "2024-03-01T20:00:00Z"); err != nil {
+					t.Fatalf("Unable to set the consolidation state: %v", err)
+				}
+			}
+
+			evictionFailed := false
+			fakeClient.Fake.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				obj := action.(core.CreateAction).GetObject()
+				if eviction, ok := obj.(*policy.Eviction); ok {
+					if _, exists := podsForEviction[eviction.Name]; exists {
+						return true, obj, nil
+					}
+					evictionFailed = true
+					return true, nil, fmt.Errorf("pod %q was unexpectedly evicted", eviction.Name)
+				}
+				return false, nil, nil
+			})
+
+			plugin, err := NewScheduledDownscaleHelper(&ScheduledDownscaleHelperArgs{
+				Windows:                []OffHoursWindow{{Days: []string{"Fri", "Sat"}, Start: "20:00", End: "07:00"}},
+				TimeZone:               "UTC",
+				PriorityThreshold:      &api.PriorityThreshold{Value: utilptr.To[int32](1000)},
+				Thresholds:             api.ResourceThresholds{v1.ResourcePods: 50},
+				SpreadThresholds:       api.ResourceThresholds{v1.ResourcePods: 20},
+				SpreadTargetThresholds: api.ResourceThresholds{v1.ResourcePods: 50},
+			}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			downscaleHelper := plugin.(*ScheduledDownscaleHelper)
+			downscaleHelper.now = func() time.Time { return testCase.now }
+			if status := downscaleHelper.Balance(ctx, testCase.nodes); status != nil && status.Err != nil {
+				t.Fatalf("Unexpected error: %v", status.Err)
+			}
+
+			if podsEvicted := podEvictor.TotalEvicted(); uint(len(testCase.evictedPods)) != podsEvicted {
+				t.Errorf("Expected %v pods to be evicted but %v got evicted", len(testCase.evictedPods), podsEvicted)
+			}
+			if evictionFailed {
+				t.Errorf("Pod evictions failed unexpectedly")
+			}
+			_, consolidated, err := handle.StateStore().Get(ctx, consolidatedStateKey)
+			if err != nil {
+				t.Fatalf("Unable to read the consolidation state: %v", err)
+			}
+			if consolidated != testCase.expectConsolidated {
+				t.Errorf("Expected the consolidation state to be set: %v, got %v", testCase.expectConsolidated, consolidated)
+			}
+		})
+	}
+}
+
+func TestScheduledDownscaleHelperHorizontalPodAutoscalers(t *testing.T) {
+	buildHPA := func(name string, minReplicas int32, annotations map[string]string) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+			Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: utilptr.To(minReplicas), MaxReplicas: 10},
+		}
+	}
+	hpas := []runtime.Object{
+		buildHPA("web", 5, map[string]string{OffHoursMinReplicasAnnotationKey: "2"}),
+		buildHPA("api", 2, map[string]string{OffHoursMinReplicasAnnotationKey: "2", BusinessHoursMinReplicasAnnotationKey: "4"}),
+		buildHPA("batch", 5, nil),
+	}
+
+	type scale struct {
+		minReplicas   int32
+		businessHours string
+	}
+	testCases := []struct {
+		name     string
+		now      time.Time
+		expected map[string]scale
+	}{
+		{
+			name: "min replicas are lowered during the window",
+			now:  fridayNight,
+			expected: map[string]scale{
+				"web":   {minReplicas: 2, businessHours: "5"},
+				"api":   {minReplicas: 2, businessHours: "4"},
+				"batch": {minReplicas: 5},
+			},
+		},
+		{
+			name: "min replicas are restored once the window ends",
+			now:  fridayNight.Add(10 * time.Hour),
+			expected: map[string]scale{
+				"web":   {minReplicas: 5},
+				"api":   {minReplicas: 4},
+				"batch": {minReplicas: 5},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			fakeClient := fake.NewSimpleClientset(hpas...)
+			handle, _, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{}, nil)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := NewScheduledDownscaleHelper(&ScheduledDownscaleHelperArgs{
+				Windows:                       []OffHoursWindow{{Days: []string{"Fri"}, Start: "20:00", End: "07:00"}},
+				TimeZone:                      "UTC",
+				Thresholds:                    api.ResourceThresholds{v1.ResourcePods: 50},
+				ScaleHorizontalPodAutoscalers: true,
+			}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			// Start the HorizontalPodAutoscaler informer requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			downscaleHelper := plugin.(*ScheduledDownscaleHelper)
+			downscaleHelper.now = func() time.Time { return testCase.now }
+			downscaleHelper.Balance(ctx, nil)
+
+			for name, expected := range testCase.expected {
+				hpa, err := fakeClient.AutoscalingV2().HorizontalPodAutoscalers("default").Get(ctx, name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("Unable to get the HorizontalPodAutoscaler %q: %v", name, err)
+				}
+				got := scale{minReplicas: *hpa.Spec.MinReplicas, businessHours: hpa.Annotations[BusinessHoursMinReplicasAnnotationKey]}
+				if got != expected {
+					t.Errorf("Expected HorizontalPodAutoscaler %q to be scaled to %+v, got %+v", name, expected, got)
+				}
+			}
+		})
+	}
+}
@@ -14,6 +14,8 @@ limitations under the License.
 package nodeutilization
 
 import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/descheduler/pkg/api"
 )
@@ -24,15 +26,43 @@ import (
 type LowNodeUtilizationArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
+	// Only the labelSelector and the nodeSelector are supported, the evictable
+	// namespaces are restricted with EvictableNamespaces
+	api.FilteringArgs `json:",inline"`
+
 	UseDeviationThresholds bool                   `json:"useDeviationThresholds"`
 	Thresholds             api.ResourceThresholds `json:"thresholds"`
 	TargetThresholds       api.ResourceThresholds `json:"targetThresholds"`
 	NumberOfNodes          int                    `json:"numberOfNodes"`
 
+	// FreeThresholds and FreeTargetThresholds set the thresholds of resources as the quantity left free on
+	// each node, e.g. memory: 4Gi, instead of a percentage of its capacity, so they mean the same on nodes
+	// of different sizes. A node is underutilized when more than FreeThresholds is free, and overutilized
+	// when less than FreeTargetThresholds is free. They can not be used with UseDeviationThresholds.
+	FreeThresholds       map[v1.ResourceName]resource.Quantity `json:"freeThresholds,omitempty"`
+	FreeTargetThresholds map[v1.ResourceName]resource.Quantity `json:"freeTargetThresholds,omitempty"`
+
 	// Naming this one differently since namespaces are still
 	// considered while considering resources used by pods
 	// but then filtered out before eviction
 	EvictableNamespaces *api.Namespaces `json:"evictableNamespaces"`
+
+	// WarnTargetThresholds reports the nodes above the given thresholds, without evicting
+	// pods from them until they are above TargetThresholds.
+	WarnTargetThresholds api.ResourceThresholds `json:"warnTargetThresholds,omitempty"`
+
+	// ResourceWeights classifies the nodes by the weighted average of the usage of the given resources
+	// instead of by each resource of the thresholds, e.g. to make the GPUs prevail over cpu and memory.
+	ResourceWeights map[v1.ResourceName]int32 `json:"resourceWeights,omitempty"`
+
+	// MaxPasses runs the plugin again within the descheduling cycle while its last pass evicted pods,
+	// up to the given number of passes, so the utilization converges without waiting for the next
+	// cycles. Zero or one runs a single pass.
+	MaxPasses int32 `json:"maxPasses,omitempty"`
+
+	// PassSettleTimeout bounds how long to wait before each extra pass for the evicted pods to leave
+	// the nodes, the remaining passes are skipped when they do not. Defaults to 30 seconds.
+	PassSettleTimeout *metav1.Duration `json:"passSettleTimeout,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -41,10 +71,133 @@ type LowNodeUtilizationArgs struct {
 type HighNodeUtilizationArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
+	// Only the labelSelector and the nodeSelector are supported, the evictable
+	// namespaces are restricted with EvictableNamespaces
+	api.FilteringArgs `json:",inline"`
+
+	Thresholds    api.ResourceThresholds `json:"thresholds"`
+	NumberOfNodes int                    `json:"numberOfNodes"`
+	// Naming this one differently since namespaces are still
+	// considered while considering resources used by pods
+	// but then filtered out before eviction
+	EvictableNamespaces *api.Namespaces `json:"evictableNamespaces"`
+
+	// FreeThresholds set the thresholds of resources as the quantity left free on each node, e.g. memory: 4Gi,
+	// instead of a percentage of its capacity. A node is underutilized when more than FreeThresholds is free.
+	FreeThresholds map[v1.ResourceName]resource.Quantity `json:"freeThresholds,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeAttachmentAwareConsolidationArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Only the labelSelector and the nodeSelector are supported, the evictable
+	// namespaces are restricted with EvictableNamespaces
+	api.FilteringArgs `json:",inline"`
+
+	Thresholds    api.ResourceThresholds `json:"thresholds"`
+	NumberOfNodes int                    `json:"numberOfNodes"`
+	// Naming this one differently since namespaces are still
+	// considered while considering resources used by pods
+	// but then filtered out before eviction
+	EvictableNamespaces *api.Namespaces `json:"evictableNamespaces"`
+
+	// AttachTimeHints tells how long the volumes of the given CSI drivers take to attach
+	AttachTimeHints []AttachTimeHint `json:"attachTimeHints"`
+	// DefaultAttachTime is the attach time of the block volumes without hint
+	DefaultAttachTime *metav1.Duration `json:"defaultAttachTime"`
+	// MaxPodAttachTime excludes the pods whose volumes take longer to attach from the consolidation
+	MaxPodAttachTime *metav1.Duration `json:"maxPodAttachTime"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// AttachTimeHint is the time the volumes of a CSI driver take to attach to a node
+type AttachTimeHint struct {
+	Driver     string          `json:"driver"`
+	AttachTime metav1.Duration `json:"attachTime"`
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ColdStartAwareConsolidationArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Only the labelSelector and the nodeSelector are supported, the evictable
+	// namespaces are restricted with EvictableNamespaces
+	api.FilteringArgs `json:",inline"`
+
 	Thresholds    api.ResourceThresholds `json:"thresholds"`
 	NumberOfNodes int                    `json:"numberOfNodes"`
 	// Naming this one differently since namespaces are still
 	// considered while considering resources used by pods
 	// but then filtered out before eviction
 	EvictableNamespaces *api.Namespaces `json:"evictableNamespaces"`
+
+	// CostModel weights the estimated restart cost of pods
+	CostModel *RestartCostModel `json:"costModel"`
+	// MaxPodRestartCost excludes the pods costing more to restart from the consolidation
+	MaxPodRestartCost *int64 `json:"maxPodRestartCost"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RestartCostModel tells how much each part of a pod start up costs
+type RestartCostModel struct {
+	// ImagePerGiB is the cost of each GiB of the images of the containers, the image sizes are
+	// read from the status of the node of the pod
+	ImagePerGiB int64 `json:"imagePerGiB"`
+	// StartupProbePerSecond is the cost of each second the startup probes allow the containers to start
+	StartupProbePerSecond int64 `json:"startupProbePerSecond"`
+	// InitContainer is the cost of each init container
+	InitContainer int64 `json:"initContainer"`
+}
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ScheduledDownscaleHelperArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Only the labelSelector and the nodeSelector are supported, the evictable
+	// namespaces are restricted with EvictableNamespaces
+	api.FilteringArgs `json:",inline"`
+
+	// Windows are the off-hours windows the pods are consolidated during
+	Windows []OffHoursWindow `json:"windows"`
+	// TimeZone is the IANA time zone of the windows, e.g. Europe/Paris. Defaults to UTC.
+	TimeZone string `json:"timeZone"`
+	// PriorityThreshold restricts the consolidated pods to the pods of a lower priority
+	PriorityThreshold *api.PriorityThreshold `json:"priorityThreshold"`
+
+	// Thresholds are the thresholds of the nodes drained during the windows, see HighNodeUtilization
+	Thresholds    api.ResourceThresholds `json:"thresholds"`
+	NumberOfNodes int                    `json:"numberOfNodes"`
+	// SpreadThresholds and SpreadTargetThresholds are the thresholds of the nodes the pods are spread
+	// back across once the windows end, see LowNodeUtilization. The pods are not spread back when unset.
+	SpreadThresholds       api.ResourceThresholds `json:"spreadThresholds"`
+	SpreadTargetThresholds api.ResourceThresholds `json:"spreadTargetThresholds"`
+	// Naming this one differently since namespaces are still
+	// considered while considering resources used by pods
+	// but then filtered out before eviction
+	EvictableNamespaces *api.Namespaces `json:"evictableNamespaces"`
+
+	// ScaleHorizontalPodAutoscalers lowers the minReplicas of the HorizontalPodAutoscalers annotated with
+	// descheduler.alpha.kubernetes.io/off-hours-min-replicas to the annotation value during the windows,
+	// and restores it once the windows end
+	ScaleHorizontalPodAutoscalers bool `json:"scaleHorizontalPodAutoscalers"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// OffHoursWindow is a daily time range, the range spans midnight when its end is not after its start
+type OffHoursWindow struct {
+	// Days are the days of the week the window starts on, e.g. Sat, every day when empty
+	Days []string `json:"days"`
+	// Start and End are times of the day, e.g. 20:00
+	Start string `json:"start"`
+	End   string `json:"end"`
 }
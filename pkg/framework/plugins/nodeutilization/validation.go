@@ -15,8 +15,14 @@ package nodeutilization
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/descheduler/pkg/api"
 )
 
@@ -26,6 +32,17 @@ func ValidateHighNodeUtilizationArgs(obj runtime.Object) error {
 	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 {
 		return fmt.Errorf("only Exclude namespaces can be set, inclusion is not supported")
 	}
+	if err := validateFilteringArgs(&args.FilteringArgs); err != nil {
+		return err
+	}
+	if len(args.FreeThresholds) > 0 {
+		if len(args.Thresholds) > 0 {
+			if err := validateThresholds(args.Thresholds); err != nil {
+				return err
+			}
+		}
+		return validateFreeThresholds(args.Thresholds, args.FreeThresholds)
+	}
 	err := validateThresholds(args.Thresholds)
 	if err != nil {
 		return err
@@ -34,16 +51,87 @@ func ValidateHighNodeUtilizationArgs(obj runtime.Object) error {
 	return nil
 }
 
+func ValidateVolumeAttachmentAwareConsolidationArgs(obj runtime.Object) error {
+	args := obj.(*VolumeAttachmentAwareConsolidationArgs)
+	// only exclude can be set, or not at all
+	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 {
+		return fmt.Errorf("only Exclude namespaces can be set, inclusion is not supported")
+	}
+	if err := validateFilteringArgs(&args.FilteringArgs); err != nil {
+		return err
+	}
+	if err := validateThresholds(args.Thresholds); err != nil {
+		return err
+	}
+
+	drivers := sets.New[string]()
+	for _, hint := range args.AttachTimeHints {
+		if hint.Driver == "" {
+			return fmt.Errorf("attach time hints must have a driver")
+		}
+		if drivers.Has(hint.Driver) {
+			return fmt.Errorf("attach time hint of driver %q is set more than once", hint.Driver)
+		}
+		drivers.Insert(hint.Driver)
+		if hint.AttachTime.Duration < 0 {
+			return fmt.Errorf("attach time of driver %q can not be negative", hint.Driver)
+		}
+	}
+	if args.DefaultAttachTime != nil && args.DefaultAttachTime.Duration < 0 {
+		return fmt.Errorf("defaultAttachTime can not be negative")
+	}
+	if args.MaxPodAttachTime != nil && args.MaxPodAttachTime.Duration <= 0 {
+		return fmt.Errorf("maxPodAttachTime must be greater than zero")
+	}
+
+	return nil
+}
+
 func ValidateLowNodeUtilizationArgs(obj runtime.Object) error {
 	args := obj.(*LowNodeUtilizationArgs)
 	// only exclude can be set, or not at all
 	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 {
 		return fmt.Errorf("only Exclude namespaces can be set, inclusion is not supported")
 	}
-	err := validateLowNodeUtilizationThresholds(args.Thresholds, args.TargetThresholds, args.UseDeviationThresholds)
+	if err := validateFilteringArgs(&args.FilteringArgs); err != nil {
+		return err
+	}
+	var err error
+	if len(args.FreeThresholds) > 0 || len(args.FreeTargetThresholds) > 0 {
+		err = validateLowNodeUtilizationFreeThresholds(args)
+	} else {
+		err = validateLowNodeUtilizationThresholds(args.Thresholds, args.TargetThresholds, args.UseDeviationThresholds)
+	}
 	if err != nil {
 		return err
 	}
+	if len(args.WarnTargetThresholds) > 0 {
+		if err := validateThresholds(args.WarnTargetThresholds); err != nil {
+			return fmt.Errorf("warnTargetThresholds config is not valid: %v", err)
+		}
+		for resourceName, value := range args.WarnTargetThresholds {
+			if targetValue, ok := args.TargetThresholds[resourceName]; !ok {
+				return fmt.Errorf("warnTargetThresholds configured a resource not in targetThresholds")
+			} else if value >= targetValue {
+				return fmt.Errorf("warnTargetThresholds' %v percentage is not lower than targetThresholds'", resourceName)
+			}
+		}
+	}
+	for resourceName, weight := range args.ResourceWeights {
+		_, ok := args.Thresholds[resourceName]
+		if _, free := args.FreeThresholds[resourceName]; !ok && !free {
+			return fmt.Errorf("resourceWeights configured resource %v not in thresholds", resourceName)
+		}
+		if weight <= 0 {
+			return fmt.Errorf("%v weight must be greater than zero", resourceName)
+		}
+	}
+	if args.MaxPasses < 0 {
+		return fmt.Errorf("maxPasses can not be negative")
+	}
+	if args.PassSettleTimeout != nil && args.PassSettleTimeout.Duration < 0 {
+		return fmt.Errorf("passSettleTimeout can not be negative")
+	}
 	return nil
 }
 
@@ -70,15 +158,167 @@ func validateLowNodeUtilizationThresholds(thresholds, targetThresholds api.Resou
 	return nil
 }
 
+// validateLowNodeUtilizationFreeThresholds validates the thresholds when some of them are quantities to leave free,
+// each resource has to be configured in both the thresholds and the target thresholds, as a percentage or a quantity
+func validateLowNodeUtilizationFreeThresholds(args *LowNodeUtilizationArgs) error {
+	if args.UseDeviationThresholds {
+		return fmt.Errorf("freeThresholds and freeTargetThresholds can not be used with useDeviationThresholds")
+	}
+	if len(args.Thresholds) > 0 {
+		if err := validateThresholds(args.Thresholds); err != nil {
+			return fmt.Errorf("thresholds config is not valid: %v", err)
+		}
+	}
+	if len(args.TargetThresholds) > 0 {
+		if err := validateThresholds(args.TargetThresholds); err != nil {
+			return fmt.Errorf("targetThresholds config is not valid: %v", err)
+		}
+	}
+	if err := validateFreeThresholds(args.Thresholds, args.FreeThresholds); err != nil {
+		return fmt.Errorf("thresholds config is not valid: %v", err)
+	}
+	if err := validateFreeThresholds(args.TargetThresholds, args.FreeTargetThresholds); err != nil {
+		return fmt.Errorf("targetThresholds config is not valid: %v", err)
+	}
+
+	resourceNames := sets.KeySet(args.Thresholds).Union(sets.KeySet(args.FreeThresholds))
+	targetResourceNames := sets.KeySet(args.TargetThresholds).Union(sets.KeySet(args.FreeTargetThresholds))
+	if !resourceNames.Equal(targetResourceNames) {
+		return fmt.Errorf("thresholds and targetThresholds configured different resources")
+	}
+	for resourceName, value := range args.Thresholds {
+		if targetValue, ok := args.TargetThresholds[resourceName]; ok && value > targetValue {
+			return fmt.Errorf("thresholds' %v percentage is greater than targetThresholds'", resourceName)
+		}
+	}
+	for resourceName, free := range args.FreeThresholds {
+		if targetFree, ok := args.FreeTargetThresholds[resourceName]; ok && free.Cmp(targetFree) < 0 {
+			return fmt.Errorf("freeThresholds' %v quantity is lower than freeTargetThresholds'", resourceName)
+		}
+	}
+	return nil
+}
+
+// validateFreeThresholds checks the quantities to leave free are of valid resources, not negative, and
+// that their resources are not configured with a percentage as well
+func validateFreeThresholds(thresholds api.ResourceThresholds, freeThresholds map[v1.ResourceName]resource.Quantity) error {
+	for name, free := range freeThresholds {
+		if err := validateResourceName(name); err != nil {
+			return err
+		}
+		if free.Sign() < 0 {
+			return fmt.Errorf("%v free quantity can not be negative", name)
+		}
+		if _, ok := thresholds[name]; ok {
+			return fmt.Errorf("%v is configured with both a percentage and a free quantity", name)
+		}
+	}
+	return nil
+}
+
+// validateFilteringArgs rejects the namespaces, the evictable namespaces are restricted with EvictableNamespaces
+func validateFilteringArgs(args *api.FilteringArgs) error {
+	if args.Namespaces != nil {
+		return fmt.Errorf("namespaces can not be set, use evictableNamespaces instead")
+	}
+	return args.Validate()
+}
+
 // validateThresholds checks if thresholds have valid resource name and resource percentage configured
 func validateThresholds(thresholds api.ResourceThresholds) error {
 	if len(thresholds) == 0 {
 		return fmt.Errorf("no resource threshold is configured")
 	}
 	for name, percent := range thresholds {
+		if err := validateResourceName(name); err != nil {
+			return err
+		}
 		if percent < MinResourcePercentage || percent > MaxResourcePercentage {
 			return fmt.Errorf("%v threshold not in [%v, %v] range", name, MinResourcePercentage, MaxResourcePercentage)
 		}
 	}
 	return nil
 }
+
+// validateResourceName checks the resource is a resource the nodes report a capacity for: cpu, memory,
+// pods, ephemeral storage, huge pages of a given size or an extended resource, e.g. nvidia.com/gpu
+func validateResourceName(name v1.ResourceName) error {
+	switch name {
+	case v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods, v1.ResourceEphemeralStorage:
+		return nil
+	}
+	if size, ok := strings.CutPrefix(string(name), v1.ResourceHugePagesPrefix); ok {
+		if _, err := resource.ParseQuantity(size); err != nil {
+			return fmt.Errorf("invalid huge pages resource %v: %v", name, err)
+		}
+		return nil
+	}
+	// Extended resources are fully qualified names outside of the kubernetes.io domain
+	domain, _, ok := strings.Cut(string(name), "/")
+	if !ok || domain == "kubernetes.io" || strings.HasSuffix(domain, ".kubernetes.io") || len(validation.IsQualifiedName(string(name))) > 0 {
+		return fmt.Errorf("unsupported resource %v, expected cpu, memory, pods, ephemeral-storage, hugepages-<size> or an extended resource", name)
+	}
+	return nil
+}
+
+func ValidateColdStartAwareConsolidationArgs(obj runtime.Object) error {
+	args := obj.(*ColdStartAwareConsolidationArgs)
+	// only exclude can be set, or not at all
+	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 {
+		return fmt.Errorf("only Exclude namespaces can be set, inclusion is not supported")
+	}
+	if err := validateFilteringArgs(&args.FilteringArgs); err != nil {
+		return err
+	}
+	if err := validateThresholds(args.Thresholds); err != nil {
+		return err
+	}
+
+	if args.CostModel != nil {
+		if args.CostModel.ImagePerGiB < 0 || args.CostModel.StartupProbePerSecond < 0 || args.CostModel.InitContainer < 0 {
+			return fmt.Errorf("costModel weights can not be negative")
+		}
+	}
+	if args.MaxPodRestartCost != nil && *args.MaxPodRestartCost <= 0 {
+		return fmt.Errorf("maxPodRestartCost must be greater than zero")
+	}
+
+	return nil
+}
+
+func ValidateScheduledDownscaleHelperArgs(obj runtime.Object) error {
+	args := obj.(*ScheduledDownscaleHelperArgs)
+	// only exclude can be set, or not at all
+	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 {
+		return fmt.Errorf("only Exclude namespaces can be set, inclusion is not supported")
+	}
+	if err := validateFilteringArgs(&args.FilteringArgs); err != nil {
+		return err
+	}
+
+	if len(args.Windows) == 0 {
+		return fmt.Errorf("no off-hours window is configured")
+	}
+	for i, window := range args.Windows {
+		if _, err := parseOffHoursWindow(window); err != nil {
+			return fmt.Errorf("invalid window %d: %v", i, err)
+		}
+	}
+	if _, err := time.LoadLocation(args.TimeZone); err != nil {
+		return fmt.Errorf("invalid timeZone %q: %v", args.TimeZone, err)
+	}
+	if args.PriorityThreshold != nil && args.PriorityThreshold.Value != nil && len(args.PriorityThreshold.Name) > 0 {
+		return fmt.Errorf("only one of priorityThreshold fields can be set")
+	}
+
+	if err := validateThresholds(args.Thresholds); err != nil {
+		return err
+	}
+	if len(args.SpreadThresholds) > 0 || len(args.SpreadTargetThresholds) > 0 {
+		if err := validateLowNodeUtilizationThresholds(args.SpreadThresholds, args.SpreadTargetThresholds, false); err != nil {
+			return fmt.Errorf("spread %v", err)
+		}
+	}
+
+	return nil
+}
@@ -19,8 +19,13 @@ package nodeutilization
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
 	"sigs.k8s.io/descheduler/pkg/api"
 )
 
@@ -162,6 +167,48 @@ func TestValidateLowNodeUtilizationPluginConfig(t *testing.T) {
 			},
 			errInfo: nil,
 		},
+		{
+			name: "passing valid plugin config with huge pages",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceCPU:                   20,
+				v1.ResourceName("hugepages-2Mi"): 20,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceCPU:                   80,
+				v1.ResourceName("hugepages-2Mi"): 80,
+			},
+			errInfo: nil,
+		},
+		{
+			name: "passing invalid huge pages size",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceName("hugepages-2Xi"): 20,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceName("hugepages-2Xi"): 80,
+			},
+			errInfo: fmt.Errorf("thresholds config is not valid: invalid huge pages resource hugepages-2Xi: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'"),
+		},
+		{
+			name: "passing unqualified resource name",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceName("gpu"): 20,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceName("gpu"): 80,
+			},
+			errInfo: fmt.Errorf("thresholds config is not valid: unsupported resource gpu, expected cpu, memory, pods, ephemeral-storage, hugepages-<size> or an extended resource"),
+		},
+		{
+			name: "passing resource name in the kubernetes.io domain",
+			thresholds: api.ResourceThresholds{
+				v1.ResourceName("kubernetes.io/foo"): 20,
+			},
+			targetThresholds: api.ResourceThresholds{
+				v1.ResourceName("kubernetes.io/foo"): 80,
+			},
+			errInfo: fmt.Errorf("thresholds config is not valid: unsupported resource kubernetes.io/foo, expected cpu, memory, pods, ephemeral-storage, hugepages-<size> or an extended resource"),
+		},
 	}
 
 	for _, testCase := range tests {
@@ -182,3 +229,520 @@ func TestValidateLowNodeUtilizationPluginConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateLowNodeUtilizationResourceWeights(t *testing.T) {
+	tests := []struct {
+		name            string
+		resourceWeights map[v1.ResourceName]int32
+		errInfo         error
+	}{
+		{
+			name: "valid weights",
+			resourceWeights: map[v1.ResourceName]int32{
+				v1.ResourceCPU:    3,
+				v1.ResourceMemory: 1,
+			},
+		},
+		{
+			name: "weight for a resource without a threshold",
+			resourceWeights: map[v1.ResourceName]int32{
+				v1.ResourcePods: 1,
+			},
+			errInfo: fmt.Errorf("resourceWeights configured resource pods not in thresholds"),
+		},
+		{
+			name: "zero weight",
+			resourceWeights: map[v1.ResourceName]int32{
+				v1.ResourceCPU: 0,
+			},
+			errInfo: fmt.Errorf("cpu weight must be greater than zero"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &LowNodeUtilizationArgs{
+				Thresholds: api.ResourceThresholds{
+					v1.ResourceCPU:    20,
+					v1.ResourceMemory: 20,
+				},
+				TargetThresholds: api.ResourceThresholds{
+					v1.ResourceCPU:    80,
+					v1.ResourceMemory: 80,
+				},
+				ResourceWeights: tc.resourceWeights,
+			}
+			validateErr := ValidateLowNodeUtilizationArgs(args)
+			if validateErr == nil || tc.errInfo == nil {
+				if validateErr != tc.errInfo {
+					t.Errorf("expected validity of resource weights %#v to be %v but got %v instead", tc.resourceWeights, tc.errInfo, validateErr)
+				}
+			} else if validateErr.Error() != tc.errInfo.Error() {
+				t.Errorf("expected validity of resource weights %#v to be %v but got %v instead", tc.resourceWeights, tc.errInfo, validateErr)
+			}
+		})
+	}
+}
+
+func TestValidateLowNodeUtilizationPasses(t *testing.T) {
+	tests := []struct {
+		name              string
+		maxPasses         int32
+		passSettleTimeout *metav1.Duration
+		errInfo           error
+	}{
+		{
+			name:              "valid passes",
+			maxPasses:         3,
+			passSettleTimeout: &metav1.Duration{Duration: time.Minute},
+		},
+		{
+			name:      "negative max passes",
+			maxPasses: -1,
+			errInfo:   fmt.Errorf("maxPasses can not be negative"),
+		},
+		{
+			name:              "negative pass settle timeout",
+			maxPasses:         3,
+			passSettleTimeout: &metav1.Duration{Duration: -time.Minute},
+			errInfo:           fmt.Errorf("passSettleTimeout can not be negative"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args := &LowNodeUtilizationArgs{
+				Thresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 20,
+				},
+				TargetThresholds: api.ResourceThresholds{
+					v1.ResourceCPU: 80,
+				},
+				MaxPasses:         tc.maxPasses,
+				PassSettleTimeout: tc.passSettleTimeout,
+			}
+			validateErr := ValidateLowNodeUtilizationArgs(args)
+			if validateErr == nil || tc.errInfo == nil {
+				if validateErr != tc.errInfo {
+					t.Errorf("expected validity of passes to be %v but got %v instead", tc.errInfo, validateErr)
+				}
+			} else if validateErr.Error() != tc.errInfo.Error() {
+				t.Errorf("expected validity of passes to be %v but got %v instead", tc.errInfo, validateErr)
+			}
+		})
+	}
+}
+
+func TestValidateVolumeAttachmentAwareConsolidationArgs(t *testing.T) {
+	thresholds := api.ResourceThresholds{v1.ResourceCPU: 20}
+	tests := []struct {
+		description string
+		args        *VolumeAttachmentAwareConsolidationArgs
+		expectError bool
+	}{
+		{
+			description: "valid args",
+			args: &VolumeAttachmentAwareConsolidationArgs{
+				Thresholds:       thresholds,
+				AttachTimeHints:  []AttachTimeHint{{Driver: "ebs.csi.aws.com", AttachTime: metav1.Duration{Duration: time.Minute}}},
+				MaxPodAttachTime: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+		{
+			description: "no threshold",
+			args:        &VolumeAttachmentAwareConsolidationArgs{},
+			expectError: true,
+		},
+		{
+			description: "included namespaces",
+			args: &VolumeAttachmentAwareConsolidationArgs{
+				Thresholds:          thresholds,
+				EvictableNamespaces: &api.Namespaces{Include: []string{"default"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "hint without driver",
+			args: &VolumeAttachmentAwareConsolidationArgs{
+				Thresholds:      thresholds,
+				AttachTimeHints: []AttachTimeHint{{AttachTime: metav1.Duration{Duration: time.Minute}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "duplicated hint",
+			args: &VolumeAttachmentAwareConsolidationArgs{
+				Thresholds: thresholds,
+				AttachTimeHints: []AttachTimeHint{
+					{Driver: "ebs.csi.aws.com", AttachTime: metav1.Duration{Duration: time.Minute}},
+					{Driver: "ebs.csi.aws.com", AttachTime: metav1.Duration{Duration: time.Second}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "negative attach time",
+			args: &VolumeAttachmentAwareConsolidationArgs{
+				Thresholds:      thresholds,
+				AttachTimeHints: []AttachTimeHint{{Driver: "ebs.csi.aws.com", AttachTime: metav1.Duration{Duration: -time.Minute}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "zero maxPodAttachTime",
+			args: &VolumeAttachmentAwareConsolidationArgs{
+				Thresholds:       thresholds,
+				MaxPodAttachTime: &metav1.Duration{},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateVolumeAttachmentAwareConsolidationArgs(tc.args)
+			if tc.expectError != (err != nil) {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
+
+func TestValidateColdStartAwareConsolidationArgs(t *testing.T) {
+	thresholds := api.ResourceThresholds{v1.ResourceCPU: 20}
+	tests := []struct {
+		description string
+		args        *ColdStartAwareConsolidationArgs
+		expectError bool
+	}{
+		{
+			description: "valid args",
+			args: &ColdStartAwareConsolidationArgs{
+				Thresholds:        thresholds,
+				CostModel:         &RestartCostModel{ImagePerGiB: 10},
+				MaxPodRestartCost: utilptr.To[int64](100),
+			},
+		},
+		{
+			description: "no threshold",
+			args:        &ColdStartAwareConsolidationArgs{},
+			expectError: true,
+		},
+		{
+			description: "included namespaces",
+			args: &ColdStartAwareConsolidationArgs{
+				Thresholds:          thresholds,
+				EvictableNamespaces: &api.Namespaces{Include: []string{"default"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "namespaces set instead of evictableNamespaces",
+			args: &ColdStartAwareConsolidationArgs{
+				Thresholds:    thresholds,
+				FilteringArgs: api.FilteringArgs{Namespaces: &api.Namespaces{Exclude: []string{"kube-system"}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "label and node selectors",
+			args: &ColdStartAwareConsolidationArgs{
+				Thresholds: thresholds,
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					NodeSelector:  "pool=workers",
+				},
+			},
+		},
+		{
+			description: "invalid node selector",
+			args: &ColdStartAwareConsolidationArgs{
+				Thresholds:    thresholds,
+				FilteringArgs: api.FilteringArgs{NodeSelector: "pool in (workers"},
+			},
+			expectError: true,
+		},
+		{
+			description: "negative cost model weight",
+			args: &ColdStartAwareConsolidationArgs{
+				Thresholds: thresholds,
+				CostModel:  &RestartCostModel{InitContainer: -1},
+			},
+			expectError: true,
+		},
+		{
+			description: "zero maxPodRestartCost",
+			args: &ColdStartAwareConsolidationArgs{
+				Thresholds:        thresholds,
+				MaxPodRestartCost: utilptr.To[int64](0),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateColdStartAwareConsolidationArgs(tc.args)
+			if tc.expectError != (err != nil) {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
+
+func TestValidateFreeThresholds(t *testing.T) {
+	free := func(quantity string) map[v1.ResourceName]resource.Quantity {
+		return map[v1.ResourceName]resource.Quantity{v1.ResourceMemory: resource.MustParse(quantity)}
+	}
+	tests := []struct {
+		description string
+		args        runtime.Object
+		expectError bool
+	}{
+		{
+			description: "low node utilization with free thresholds",
+			args: &LowNodeUtilizationArgs{
+				Thresholds:           api.ResourceThresholds{v1.ResourceCPU: 20},
+				TargetThresholds:     api.ResourceThresholds{v1.ResourceCPU: 60},
+				FreeThresholds:       free("8Gi"),
+				FreeTargetThresholds: free("2Gi"),
+				ResourceWeights:      map[v1.ResourceName]int32{v1.ResourceMemory: 2},
+			},
+		},
+		{
+			description: "low node utilization with a free threshold and a target percentage",
+			args: &LowNodeUtilizationArgs{
+				TargetThresholds: api.ResourceThresholds{v1.ResourceMemory: 80},
+				FreeThresholds:   free("8Gi"),
+			},
+		},
+		{
+			description: "low node utilization with a free threshold lower than the free target",
+			args: &LowNodeUtilizationArgs{
+				FreeThresholds:       free("2Gi"),
+				FreeTargetThresholds: free("8Gi"),
+			},
+			expectError: true,
+		},
+		{
+			description: "low node utilization with a free threshold without target",
+			args: &LowNodeUtilizationArgs{
+				Thresholds:           api.ResourceThresholds{v1.ResourceCPU: 20},
+				TargetThresholds:     api.ResourceThresholds{v1.ResourceCPU: 60},
+				FreeTargetThresholds: free("2Gi"),
+			},
+			expectError: true,
+		},
+		{
+			description: "low node utilization with both a percentage and a free threshold",
+			args: &LowNodeUtilizationArgs{
+				Thresholds:           api.ResourceThresholds{v1.ResourceMemory: 20},
+				TargetThresholds:     api.ResourceThresholds{v1.ResourceMemory: 60},
+				FreeThresholds:       free("8Gi"),
+				FreeTargetThresholds: free("2Gi"),
+			},
+			expectError: true,
+		},
+		{
+			description: "low node utilization with free thresholds and deviation thresholds",
+			args: &LowNodeUtilizationArgs{
+				UseDeviationThresholds: true,
+				FreeThresholds:         free("8Gi"),
+				FreeTargetThresholds:   free("2Gi"),
+			},
+			expectError: true,
+		},
+		{
+			description: "low node utilization with a negative free threshold",
+			args: &LowNodeUtilizationArgs{
+				FreeThresholds:       free("8Gi"),
+				FreeTargetThresholds: free("-1Gi"),
+			},
+			expectError: true,
+		},
+		{
+			description: "high node utilization with free thresholds only",
+			args: &HighNodeUtilizationArgs{
+				FreeThresholds: free("8Gi"),
+			},
+		},
+		{
+			description: "high node utilization with both a percentage and a free threshold",
+			args: &HighNodeUtilizationArgs{
+				Thresholds:     api.ResourceThresholds{v1.ResourceMemory: 20},
+				FreeThresholds: free("8Gi"),
+			},
+			expectError: true,
+		},
+		{
+			description: "high node utilization with a free threshold of an unsupported resource",
+			args: &HighNodeUtilizationArgs{
+				FreeThresholds: map[v1.ResourceName]resource.Quantity{"storage": resource.MustParse("10Gi")},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var err error
+			switch args := tc.args.(type) {
+			case *LowNodeUtilizationArgs:
+				err = ValidateLowNodeUtilizationArgs(args)
+			case *HighNodeUtilizationArgs:
+				err = ValidateHighNodeUtilizationArgs(args)
+			}
+			if tc.expectError != (err != nil) {
+				t.Errorf("unexpected arg validation behavior: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateScheduledDownscaleHelperArgs(t *testing.T) {
+	thresholds := api.ResourceThresholds{v1.ResourceCPU: 20}
+	windows := []OffHoursWindow{{Days: []string{"Fri", "Sat"}, Start: "20:00", End: "07:00"}}
+	tests := []struct {
+		description string
+		args        *ScheduledDownscaleHelperArgs
+		expectError bool
+	}{
+		{
+			description: "valid args",
+			args: &ScheduledDownscaleHelperArgs{
+				Windows:                windows,
+				TimeZone:               "UTC",
+				PriorityThreshold:      &api.PriorityThreshold{Value: utilptr.To[int32](1000)},
+				Thresholds:             thresholds,
+				SpreadThresholds:       api.ResourceThresholds{v1.ResourceCPU: 20},
+				SpreadTargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 60},
+			},
+		},
+		{
+			description: "no window",
+			args: &ScheduledDownscaleHelperArgs{
+				Thresholds: thresholds,
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid day",
+			args: &ScheduledDownscaleHelperArgs{
+				Windows:    []OffHoursWindow{{Days: []string{"Someday"}, Start: "20:00", End: "07:00"}},
+				Thresholds: thresholds,
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid start",
+			args: &ScheduledDownscaleHelperArgs{
+				Windows:    []OffHoursWindow{{Start: "8pm", End: "07:00"}},
+				Thresholds: thresholds,
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid time zone",
+			args: &ScheduledDownscaleHelperArgs{
+				Windows:    windows,
+				TimeZone:   "Nowhere/Nowhere",
+				Thresholds: thresholds,
+			},
+			expectError: true,
+		},
+		{
+			description: "both priority threshold fields",
+			args: &ScheduledDownscaleHelperArgs{
+				Windows:           windows,
+				PriorityThreshold: &api.PriorityThreshold{Name: "low", Value: utilptr.To[int32](1000)},
+				Thresholds:        thresholds,
+			},
+			expectError: true,
+		},
+		{
+			description: "no threshold",
+			args: &ScheduledDownscaleHelperArgs{
+				Windows: windows,
+			},
+			expectError: true,
+		},
+		{
+			description: "spread thresholds without spread target thresholds",
+			args: &ScheduledDownscaleHelperArgs{
+				Windows:          windows,
+				Thresholds:       thresholds,
+				SpreadThresholds: api.ResourceThresholds{v1.ResourceCPU: 20},
+			},
+			expectError: true,
+		},
+		{
+			description: "included namespaces",
+			args: &ScheduledDownscaleHelperArgs{
+				Windows:             windows,
+				Thresholds:          thresholds,
+				EvictableNamespaces: &api.Namespaces{Include: []string{"default"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "namespaces set instead of evictableNamespaces",
+			args: &ScheduledDownscaleHelperArgs{
+				Windows:       windows,
+				Thresholds:    thresholds,
+				FilteringArgs: api.FilteringArgs{Namespaces: &api.Namespaces{Exclude: []string{"kube-system"}}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateScheduledDownscaleHelperArgs(tc.args)
+			if tc.expectError != (err != nil) {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
+
+func TestValidateLowNodeUtilizationWarnTargetThresholds(t *testing.T) {
+	tests := []struct {
+		description          string
+		warnTargetThresholds api.ResourceThresholds
+		expectError          bool
+	}{
+		{
+			description:          "warn target thresholds lower than target thresholds, no errors",
+			warnTargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 50},
+			expectError:          false,
+		},
+		{
+			description:          "warn target thresholds not lower than target thresholds, expects errors",
+			warnTargetThresholds: api.ResourceThresholds{v1.ResourceCPU: 80},
+			expectError:          true,
+		},
+		{
+			description:          "resource not in target thresholds, expects errors",
+			warnTargetThresholds: api.ResourceThresholds{v1.ResourcePods: 50},
+			expectError:          true,
+		},
+		{
+			description:          "invalid warn target thresholds, expects errors",
+			warnTargetThresholds: api.ResourceThresholds{v1.ResourceCPU: -10},
+			expectError:          true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateLowNodeUtilizationArgs(&LowNodeUtilizationArgs{
+				Thresholds:           api.ResourceThresholds{v1.ResourceCPU: 20, v1.ResourceMemory: 20},
+				TargetThresholds:     api.ResourceThresholds{v1.ResourceCPU: 80, v1.ResourceMemory: 80},
+				WarnTargetThresholds: tc.warnTargetThresholds,
+			})
+			if hasError := err != nil; hasError != tc.expectError {
+				t.Errorf("unexpected arg validation behavior: %v", err)
+			}
+		})
+	}
+}
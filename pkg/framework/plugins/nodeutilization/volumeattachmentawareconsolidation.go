@@ -0,0 +1,289 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const VolumeAttachmentAwareConsolidationPluginName = "VolumeAttachmentAwareConsolidation"
+
+// VolumeAttachmentAwareConsolidation evicts pods from under utilized nodes like HighNodeUtilization,
+// but it evicts first the pods whose volumes attach quickly on their new node (no volume, ephemeral
+// or NFS volumes) and drains first the nodes whose pods attach quickly, so the consolidation does
+// not wait on slow block volume attachments. The attach time of CSI volumes is read from hints.
+type VolumeAttachmentAwareConsolidation struct {
+	handle    frameworktypes.Handle
+	args      *VolumeAttachmentAwareConsolidationArgs
+	podFilter func(pod *v1.Pod) bool
+	pvcLister listersv1.PersistentVolumeClaimLister
+	pvLister  listersv1.PersistentVolumeLister
+}
+
+var _ frameworktypes.BalancePlugin = &VolumeAttachmentAwareConsolidation{}
+
+var _ frameworktypes.AllPodsRequirer = &VolumeAttachmentAwareConsolidationArgs{}
+
+// RequiresAllPods tells the utilization of the nodes is computed from all their pods
+func (a *VolumeAttachmentAwareConsolidationArgs) RequiresAllPods() bool {
+	return true
+}
+
+// NewVolumeAttachmentAwareConsolidation builds plugin from its arguments while passing a handle
+func NewVolumeAttachmentAwareConsolidation(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	consolidationArgs, ok := args.(*VolumeAttachmentAwareConsolidationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type VolumeAttachmentAwareConsolidationArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithLabelSelector(consolidationArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &VolumeAttachmentAwareConsolidation{
+		handle:    handle,
+		args:      consolidationArgs,
+		podFilter: podFilter,
+		// The volumes and claims are read from the cluster, the cached client of the dry run mode holds none
+		pvcLister: handle.ClusterInformerFactory().Core().V1().PersistentVolumeClaims().Lister(),
+		pvLister:  handle.ClusterInformerFactory().Core().V1().PersistentVolumes().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (v *VolumeAttachmentAwareConsolidation) Name() string {
+	return VolumeAttachmentAwareConsolidationPluginName
+}
+
+// Balance extension point implementation for the plugin
+func (v *VolumeAttachmentAwareConsolidation) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	thresholds := v.args.Thresholds
+	targetThresholds := make(api.ResourceThresholds)
+
+	setDefaultForThresholds(thresholds, targetThresholds)
+	resourceNames := getResourceNames(targetThresholds)
+
+	sourceNodes, highNodes := classifyNodes(
+		logger,
+		getNodeUsage(ctx, nodes, resourceNames, v.handle.GetPodsAssignedToNodeFunc(), v.handle.UtilizationProvider()),
+		getNodeThresholds(ctx, nodes, thresholds, targetThresholds, resourceNames, v.handle.UtilizationProvider(), false),
+		func(node *v1.Node, usage NodeUsage, threshold NodeThresholds) bool {
+			return isNodeWithLowUtilization(usage, threshold.lowResourceThreshold)
+		},
+		func(node *v1.Node, usage NodeUsage, threshold NodeThresholds) bool {
+			if nodeutil.IsNodeUnschedulable(node) {
+				logger.V(2).Info("Node is unschedulable", "node", klog.KObj(node))
+				return false
+			}
+			return !isNodeWithLowUtilization(usage, threshold.lowResourceThreshold)
+		})
+
+	logger.V(1).Info("Number of underutilized nodes", "totalNumber", len(sourceNodes))
+
+	if len(sourceNodes) == 0 {
+		logger.V(1).Info("No node is underutilized, nothing to do here, you might tune your thresholds further")
+		return nil
+	}
+	if len(sourceNodes) <= v.args.NumberOfNodes {
+		logger.V(1).Info("Number of nodes underutilized is less or equal than NumberOfNodes, nothing to do here", "underutilizedNodes", len(sourceNodes), "numberOfNodes", v.args.NumberOfNodes)
+		return nil
+	}
+	if len(sourceNodes) == len(nodes) {
+		logger.V(1).Info("All nodes are underutilized, nothing to do here")
+		return nil
+	}
+	if len(highNodes) == 0 {
+		logger.V(1).Info("No node is available to schedule the pods, nothing to do here")
+		return nil
+	}
+
+	// stop if the total available usage has dropped to zero - no more pods can be scheduled
+	continueEvictionCond := func(nodeInfo NodeInfo, totalAvailableUsage map[v1.ResourceName]*resource.Quantity) bool {
+		for name := range totalAvailableUsage {
+			if totalAvailableUsage[name].CmpInt64(0) < 1 {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	estimator := newAttachTimeEstimator(ctx, v.pvcLister, v.pvLister, v.args)
+	podFilter := func(pod *v1.Pod) bool {
+		if !v.podFilter(pod) {
+			return false
+		}
+		if v.args.MaxPodAttachTime != nil && estimator.podAttachTime(pod) > v.args.MaxPodAttachTime.Duration {
+			logger.V(3).Info("Pod volumes take too long to attach", "pod", klog.KObj(pod), "attachTime", estimator.podAttachTime(pod))
+			return false
+		}
+		return true
+	}
+
+	// Drain first the nodes whose removable pods take the least time to attach their volumes,
+	// the least utilized nodes first when it takes the same time
+	sortNodesByUsage(sourceNodes, true)
+	drainTimes := make(map[string]time.Duration, len(sourceNodes))
+	for _, node := range sourceNodes {
+		for _, pod := range node.allPods {
+			if podFilter(pod) {
+				drainTimes[node.node.Name] += estimator.podAttachTime(pod)
+			}
+		}
+	}
+	sort.SliceStable(sourceNodes, func(i, j int) bool {
+		return drainTimes[sourceNodes[i].node.Name] < drainTimes[sourceNodes[j].node.Name]
+	})
+
+	evictPodsFromSourceNodes(
+		ctx,
+		v.args.EvictableNamespaces,
+		sourceNodes,
+		highNodes,
+		v.handle.Evictor(),
+		evictions.EvictOptions{StrategyName: VolumeAttachmentAwareConsolidationPluginName},
+		podFilter,
+		estimator.sortPods,
+		resourceNames,
+		continueEvictionCond)
+
+	return nil
+}
+
+// attachTimeEstimator estimates how long the volumes of a pod take to attach on a new node.
+// The volumes of a pod are expected to attach concurrently, so the attach time of a pod is
+// the one of its slowest volume. Estimates are cached for the lifetime of the estimator.
+type attachTimeEstimator struct {
+	ctx               context.Context
+	pvcLister         listersv1.PersistentVolumeClaimLister
+	pvLister          listersv1.PersistentVolumeLister
+	hints             map[string]time.Duration
+	defaultAttachTime time.Duration
+
+	pods map[types.UID]time.Duration
+}
+
+func newAttachTimeEstimator(ctx context.Context, pvcLister listersv1.PersistentVolumeClaimLister, pvLister listersv1.PersistentVolumeLister, args *VolumeAttachmentAwareConsolidationArgs) *attachTimeEstimator {
+	estimator := &attachTimeEstimator{
+		ctx:               ctx,
+		pvcLister:         pvcLister,
+		pvLister:          pvLister,
+		hints:             map[string]time.Duration{},
+		defaultAttachTime: DefaultVolumeAttachTime,
+		pods:              map[types.UID]time.Duration{},
+	}
+	if args.DefaultAttachTime != nil {
+		estimator.defaultAttachTime = args.DefaultAttachTime.Duration
+	}
+	for _, hint := range args.AttachTimeHints {
+		estimator.hints[hint.Driver] = hint.AttachTime.Duration
+	}
+	return estimator
+}
+
+// sortPods sorts the pods by eviction cost, then by attach time, then by priority and QoS
+func (e *attachTimeEstimator) sortPods(pods []*v1.Pod) {
+	podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+	sort.SliceStable(pods, func(i, j int) bool {
+		return e.podAttachTime(pods[i]) < e.podAttachTime(pods[j])
+	})
+	podutil.SortPodsBasedOnEvictionCost(pods)
+}
+
+// podAttachTime returns the time the slowest volume of the pod takes to attach
+func (e *attachTimeEstimator) podAttachTime(pod *v1.Pod) time.Duration {
+	if attachTime, ok := e.pods[pod.UID]; ok {
+		return attachTime
+	}
+	var attachTime time.Duration
+	for _, volume := range pod.Spec.Volumes {
+		if volumeAttachTime := e.volumeAttachTime(pod, volume); volumeAttachTime > attachTime {
+			attachTime = volumeAttachTime
+		}
+	}
+	e.pods[pod.UID] = attachTime
+	return attachTime
+}
+
+func (e *attachTimeEstimator) volumeAttachTime(pod *v1.Pod, volume v1.Volume) time.Duration {
+	var claimName string
+	switch {
+	case volume.CSI != nil:
+		return e.driverAttachTime(volume.CSI.Driver)
+	case volume.PersistentVolumeClaim != nil:
+		claimName = volume.PersistentVolumeClaim.ClaimName
+	case volume.Ephemeral != nil:
+		// Generic ephemeral volumes are provisioned through a claim named after the pod and the volume
+		claimName = pod.Name + "-" + volume.Name
+	case volume.NFS != nil, volume.CephFS != nil:
+		// Network file systems are mounted without being attached
+		return 0
+	case volume.ISCSI != nil, volume.RBD != nil, volume.FC != nil, volume.AWSElasticBlockStore != nil,
+		volume.GCEPersistentDisk != nil, volume.AzureDisk != nil, volume.Cinder != nil, volume.VsphereVolume != nil:
+		return e.defaultAttachTime
+	default:
+		// Volumes local to the node (emptyDir, configMap, secret, projected, hostPath...)
+		return 0
+	}
+
+	pvc, err := e.pvcLister.PersistentVolumeClaims(pod.Namespace).Get(claimName)
+	if err != nil || pvc.Spec.VolumeName == "" {
+		klog.FromContext(e.ctx).V(3).Info("Unable to get the volume of a persistent volume claim", "pod", klog.KObj(pod), "claim", claimName, "err", err)
+		return e.defaultAttachTime
+	}
+	pv, err := e.pvLister.Get(pvc.Spec.VolumeName)
+	if err != nil {
+		klog.FromContext(e.ctx).V(3).Info("Unable to get persistent volume", "pod", klog.KObj(pod), "volume", pvc.Spec.VolumeName, "err", err)
+		return e.defaultAttachTime
+	}
+	switch {
+	case pv.Spec.CSI != nil:
+		return e.driverAttachTime(pv.Spec.CSI.Driver)
+	case pv.Spec.NFS != nil, pv.Spec.CephFS != nil, pv.Spec.HostPath != nil, pv.Spec.Local != nil:
+		return 0
+	default:
+		return e.defaultAttachTime
+	}
+}
+
+func (e *attachTimeEstimator) driverAttachTime(driver string) time.Duration {
+	if attachTime, ok := e.hints[driver]; ok {
+		return attachTime
+	}
+	return e.defaultAttachTime
+}
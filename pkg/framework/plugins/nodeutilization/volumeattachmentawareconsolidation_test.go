@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+const slowDriver = "ebs.csi.aws.com"
+
+func withVolume(source v1.VolumeSource) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{Name: "data", VolumeSource: source})
+	}
+}
+
+var (
+	nfsVolume = v1.VolumeSource{NFS: &v1.NFSVolumeSource{Server: "nfs", Path: "/data"}}
+	pvcVolume = v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "slow"}}
+)
+
+func buildSlowVolume() (*v1.PersistentVolume, *v1.PersistentVolumeClaim) {
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-slow"},
+		Spec: v1.PersistentVolumeSpec{
+			PersistentVolumeSource: v1.PersistentVolumeSource{CSI: &v1.CSIPersistentVolumeSource{Driver: slowDriver, VolumeHandle: "vol-1"}},
+		},
+	}
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "slow", Namespace: "default"},
+		Spec:       v1.PersistentVolumeClaimSpec{VolumeName: pv.Name},
+	}
+	return pv, pvc
+}
+
+func TestAttachTimeEstimator(t *testing.T) {
+	pv, pvc := buildSlowVolume()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(pv, pvc), 0)
+	pvcLister := sharedInformerFactory.Core().V1().PersistentVolumeClaims().Lister()
+	pvLister := sharedInformerFactory.Core().V1().PersistentVolumes().Lister()
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	estimator := newAttachTimeEstimator(ctx, pvcLister, pvLister, &VolumeAttachmentAwareConsolidationArgs{
+		AttachTimeHints:   []AttachTimeHint{{Driver: slowDriver, AttachTime: metav1.Duration{Duration: time.Minute}}},
+		DefaultAttachTime: &metav1.Duration{Duration: 20 * time.Second},
+	})
+
+	tests := []struct {
+		description string
+		volumes     []v1.VolumeSource
+		expected    time.Duration
+	}{
+		{
+			description: "pod without volume",
+			expected:    0,
+		},
+		{
+			description: "local and network file system volumes",
+			volumes: []v1.VolumeSource{
+				{EmptyDir: &v1.EmptyDirVolumeSource{}},
+				{ConfigMap: &v1.ConfigMapVolumeSource{}},
+				nfsVolume,
+			},
+			expected: 0,
+		},
+		{
+			description: "claim of a CSI volume with a hint",
+			volumes:     []v1.VolumeSource{nfsVolume, pvcVolume},
+			expected:    time.Minute,
+		},
+		{
+			description: "inline CSI volume without hint",
+			volumes:     []v1.VolumeSource{{CSI: &v1.CSIVolumeSource{Driver: "other.csi.k8s.io"}}},
+			expected:    20 * time.Second,
+		},
+		{
+			description: "missing claim",
+			volumes:     []v1.VolumeSource{{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "missing"}}},
+			expected:    20 * time.Second,
+		},
+		{
+			description: "in-tree block volume",
+			volumes:     []v1.VolumeSource{{AWSElasticBlockStore: &v1.AWSElasticBlockStoreVolumeSource{VolumeID: "vol-2"}}},
+			expected:    20 * time.Second,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p", 100, 0, "n1", func(pod *v1.Pod) {
+				for _, source := range tc.volumes {
+					withVolume(source)(pod)
+				}
+			})
+			if got := estimator.podAttachTime(pod); got != tc.expected {
+				t.Errorf("expected an attach time of %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestVolumeAttachmentAwareConsolidation(t *testing.T) {
+	pv, pvc := buildSlowVolume()
+	hints := []AttachTimeHint{{Driver: slowDriver, AttachTime: metav1.Duration{Duration: time.Minute}}}
+
+	testCases := []struct {
+		name             string
+		nodes            []*v1.Node
+		pods             []*v1.Pod
+		maxPodAttachTime *metav1.Duration
+		evictedPods      []string
+	}{
+		{
+			name: "pod with fast volumes is evicted before pods with slow volumes",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, nil),
+				test.BuildTestNode("n2", 4000, 3000, 3, nil),
+			},
+			pods: []*v1.Pod{
+				// The pod with the slow volume would be evicted first based on priority
+				test.BuildTestPod("slow", 100, 0, "n1", func(pod *v1.Pod) {
+					withVolume(pvcVolume)(pod)
+					pod.Spec.Priority = utilptr.To[int32](0)
+				}),
+				test.BuildTestPod("fast", 100, 0, "n1", func(pod *v1.Pod) {
+					withVolume(nfsVolume)(pod)
+					pod.Spec.Priority = utilptr.To[int32](100)
+				}),
+				// Room for a single pod on n2
+				test.BuildTestPod("p1", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 100, 0, "n2", test.SetRSOwnerRef),
+			},
+			evictedPods: []string{"fast"},
+		},
+		{
+			name: "pods with volumes attaching slower than maxPodAttachTime are not evicted",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, nil),
+				test.BuildTestNode("n2", 4000, 3000, 10, nil),
+			},
+			pods: []*v1.Pod{
+				test.BuildTestPod("slow", 100, 0, "n1", withVolume(pvcVolume)),
+				test.BuildTestPod("fast", 100, 0, "n1", withVolume(nfsVolume)),
+				test.BuildTestPod("p1", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p3", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p4", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p5", 100, 0, "n2", test.SetRSOwnerRef),
+				test.BuildTestPod("p6", 100, 0, "n2", test.SetRSOwnerRef),
+			},
+			maxPodAttachTime: &metav1.Duration{Duration: 30 * time.Second},
+			evictedPods:      []string{"fast"},
+		},
+		{
+			name: "nodes with fast volumes are drained first",
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 4000, 3000, 10, nil),
+				test.BuildTestNode("n2", 4000, 3000, 10, nil),
+				test.BuildTestNode("n3", 4000, 3000, 3, nil),
+			},
+			pods: []*v1.Pod{
+				test.BuildTestPod("slow", 100, 0, "n1", withVolume(pvcVolume)),
+				test.BuildTestPod("fast", 100, 0, "n2", withVolume(nfsVolume)),
+				// Room for a single pod on n3
+				test.BuildTestPod("p1", 100, 0, "n3", test.SetRSOwnerRef),
+				test.BuildTestPod("p2", 100, 0, "n3", test.SetRSOwnerRef),
+			},
+			evictedPods: []string{"fast"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{pv, pvc}
+			for _, node := range testCase.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range testCase.pods {
+				objs = append(objs, pod)
+			}
+			podsForEviction := make(map[string]struct{})
+			for _, pod := range testCase.evictedPods {
+				podsForEviction[pod] = struct{}{}
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{}, nil)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			evictionFailed := false
+			fakeClient.Fake.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				obj := action.(core.CreateAction).GetObject()
+				if eviction, ok := obj.(*policy.Eviction); ok {
+					if _, exists := podsForEviction[eviction.Name]; exists {
+						return true, obj, nil
+					}
+					evictionFailed = true
+					return true, nil, fmt.Errorf("pod %q was unexpectedly evicted", eviction.Name)
+				}
+				return false, nil, nil
+			})
+
+			plugin, err := NewVolumeAttachmentAwareConsolidation(&VolumeAttachmentAwareConsolidationArgs{
+				Thresholds:       api.ResourceThresholds{v1.ResourcePods: 50},
+				AttachTimeHints:  hints,
+				MaxPodAttachTime: testCase.maxPodAttachTime,
+			}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			// Start the PersistentVolume and PersistentVolumeClaim informers requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, testCase.nodes)
+
+			if podsEvicted := podEvictor.TotalEvicted(); uint(len(testCase.evictedPods)) != podsEvicted {
+				t.Errorf("Expected %v pods to be evicted but %v got evicted", len(testCase.evictedPods), podsEvicted)
+			}
+			if evictionFailed {
+				t.Errorf("Pod evictions failed unexpectedly")
+			}
+		})
+	}
+}
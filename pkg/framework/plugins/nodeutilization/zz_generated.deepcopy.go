@@ -22,14 +22,83 @@ limitations under the License.
 package nodeutilization
 
 import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	api "sigs.k8s.io/descheduler/pkg/api"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttachTimeHint) DeepCopyInto(out *AttachTimeHint) {
+	*out = *in
+	out.AttachTime = in.AttachTime
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachTimeHint.
+func (in *AttachTimeHint) DeepCopy() *AttachTimeHint {
+	if in == nil {
+		return nil
+	}
+	out := new(AttachTimeHint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ColdStartAwareConsolidationArgs) DeepCopyInto(out *ColdStartAwareConsolidationArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.FilteringArgs.DeepCopyInto(&out.FilteringArgs)
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make(api.ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(api.Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CostModel != nil {
+		in, out := &in.CostModel, &out.CostModel
+		*out = new(RestartCostModel)
+		**out = **in
+	}
+	if in.MaxPodRestartCost != nil {
+		in, out := &in.MaxPodRestartCost, &out.MaxPodRestartCost
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ColdStartAwareConsolidationArgs.
+func (in *ColdStartAwareConsolidationArgs) DeepCopy() *ColdStartAwareConsolidationArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ColdStartAwareConsolidationArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ColdStartAwareConsolidationArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HighNodeUtilizationArgs) DeepCopyInto(out *HighNodeUtilizationArgs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	in.FilteringArgs.DeepCopyInto(&out.FilteringArgs)
 	if in.Thresholds != nil {
 		in, out := &in.Thresholds, &out.Thresholds
 		*out = make(api.ResourceThresholds, len(*in))
@@ -42,6 +111,13 @@ func (in *HighNodeUtilizationArgs) DeepCopyInto(out *HighNodeUtilizationArgs) {
 		*out = new(api.Namespaces)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FreeThresholds != nil {
+		in, out := &in.FreeThresholds, &out.FreeThresholds
+		*out = make(map[v1.ResourceName]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -67,6 +143,7 @@ func (in *HighNodeUtilizationArgs) DeepCopyObject() runtime.Object {
 func (in *LowNodeUtilizationArgs) DeepCopyInto(out *LowNodeUtilizationArgs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	in.FilteringArgs.DeepCopyInto(&out.FilteringArgs)
 	if in.Thresholds != nil {
 		in, out := &in.Thresholds, &out.Thresholds
 		*out = make(api.ResourceThresholds, len(*in))
@@ -81,11 +158,44 @@ func (in *LowNodeUtilizationArgs) DeepCopyInto(out *LowNodeUtilizationArgs) {
 			(*out)[key] = val
 		}
 	}
+	if in.FreeThresholds != nil {
+		in, out := &in.FreeThresholds, &out.FreeThresholds
+		*out = make(map[v1.ResourceName]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.FreeTargetThresholds != nil {
+		in, out := &in.FreeTargetThresholds, &out.FreeTargetThresholds
+		*out = make(map[v1.ResourceName]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	if in.EvictableNamespaces != nil {
 		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
 		*out = new(api.Namespaces)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WarnTargetThresholds != nil {
+		in, out := &in.WarnTargetThresholds, &out.WarnTargetThresholds
+		*out = make(api.ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceWeights != nil {
+		in, out := &in.ResourceWeights, &out.ResourceWeights
+		*out = make(map[v1.ResourceName]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PassSettleTimeout != nil {
+		in, out := &in.PassSettleTimeout, &out.PassSettleTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -106,3 +216,157 @@ func (in *LowNodeUtilizationArgs) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OffHoursWindow) DeepCopyInto(out *OffHoursWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OffHoursWindow.
+func (in *OffHoursWindow) DeepCopy() *OffHoursWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(OffHoursWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartCostModel) DeepCopyInto(out *RestartCostModel) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartCostModel.
+func (in *RestartCostModel) DeepCopy() *RestartCostModel {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartCostModel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledDownscaleHelperArgs) DeepCopyInto(out *ScheduledDownscaleHelperArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.FilteringArgs.DeepCopyInto(&out.FilteringArgs)
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]OffHoursWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PriorityThreshold != nil {
+		in, out := &in.PriorityThreshold, &out.PriorityThreshold
+		*out = new(api.PriorityThreshold)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make(api.ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SpreadThresholds != nil {
+		in, out := &in.SpreadThresholds, &out.SpreadThresholds
+		*out = make(api.ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SpreadTargetThresholds != nil {
+		in, out := &in.SpreadTargetThresholds, &out.SpreadTargetThresholds
+		*out = make(api.ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(api.Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledDownscaleHelperArgs.
+func (in *ScheduledDownscaleHelperArgs) DeepCopy() *ScheduledDownscaleHelperArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledDownscaleHelperArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledDownscaleHelperArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeAttachmentAwareConsolidationArgs) DeepCopyInto(out *VolumeAttachmentAwareConsolidationArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.FilteringArgs.DeepCopyInto(&out.FilteringArgs)
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make(api.ResourceThresholds, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(api.Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AttachTimeHints != nil {
+		in, out := &in.AttachTimeHints, &out.AttachTimeHints
+		*out = make([]AttachTimeHint, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultAttachTime != nil {
+		in, out := &in.DefaultAttachTime, &out.DefaultAttachTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxPodAttachTime != nil {
+		in, out := &in.MaxPodAttachTime, &out.MaxPodAttachTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAttachmentAwareConsolidationArgs.
+func (in *VolumeAttachmentAwareConsolidationArgs) DeepCopy() *VolumeAttachmentAwareConsolidationArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeAttachmentAwareConsolidationArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeAttachmentAwareConsolidationArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package numaawarerebalancer
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "NUMAAwareRebalancer"
+
+// NUMAAwareRebalancer evicts the latency sensitive pods, i.e. the guaranteed pods with exclusive cpus,
+// running on nodes whose topology manager admits pods without aligning their resources on a NUMA node,
+// when another node refusing misaligned pods has a NUMA node with enough resources available for them.
+// The topology of the nodes is read from the NodeResourceTopology objects of topology-aware scheduling.
+type NUMAAwareRebalancer struct {
+	handle    frameworktypes.Handle
+	args      *NUMAAwareRebalancerArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.BalancePlugin = &NUMAAwareRebalancer{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	numaArgs, ok := args.(*NUMAAwareRebalancerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type NUMAAwareRebalancerArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if numaArgs.Namespaces != nil {
+		includedNamespaces = sets.New(numaArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(numaArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(numaArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	podFilter = podutil.WrapFilterFuncs(podFilter, isLatencySensitive)
+
+	return &NUMAAwareRebalancer{
+		handle:    handle,
+		args:      numaArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *NUMAAwareRebalancer) Name() string {
+	return PluginName
+}
+
+// Balance extension point implementation for the plugin
+func (d *NUMAAwareRebalancer) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	topologies, err := listNodeResourceTopologies(ctx, d.handle.ClientSet())
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing node resource topologies: %v", err),
+		}
+	}
+
+	// The nodes without topology are left alone, their NUMA alignment being unknown
+	var sourceNodes, alignedNodes []*v1.Node
+	for _, node := range nodes {
+		topology, ok := topologies[node.Name]
+		if !ok {
+			continue
+		}
+		if topology.alignsResources() {
+			alignedNodes = append(alignedNodes, node)
+		} else {
+			sourceNodes = append(sourceNodes, node)
+		}
+	}
+	if len(sourceNodes) == 0 || len(alignedNodes) == 0 {
+		logger.V(1).Info("No node to move latency sensitive pods from or to", "sourceNodes", len(sourceNodes), "alignedNodes", len(alignedNodes))
+		return nil
+	}
+
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	for _, node := range sourceNodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+	loop:
+		for _, pod := range pods {
+			requests, _ := utils.PodRequestsAndLimits(pod)
+			target, targetZone := alignedPlacement(getPodsAssignedToNode, pod, requests, alignedNodes, topologies)
+			if target == nil {
+				logger.V(3).Info("No node can align the resources of the pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				continue
+			}
+			logger.V(2).Info("Evicting pod to align its resources on a NUMA node", "pod", klog.KObj(pod), "node", klog.KObj(node), "alignedNode", klog.KObj(target), "zone", targetZone.Name)
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				// The pod is expected to get scheduled onto the zone, the next pods can not count on its resources
+				targetZone.reserve(requests)
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// alignedPlacement returns a node refusing misaligned pods the pod fits on, along with the NUMA zone
+// of the node with enough resources available for the pod, or nil when there is none.
+func alignedPlacement(getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, requests v1.ResourceList, alignedNodes []*v1.Node, topologies map[string]*nodeResourceTopology) (*v1.Node, *zone) {
+	for _, node := range alignedNodes {
+		targetZone := topologies[node.Name].fittingZone(requests)
+		if targetZone == nil {
+			continue
+		}
+		if err := nodeutil.NodeFit(getPodsAssignedToNode, pod, node); err != nil {
+			continue
+		}
+		return node, targetZone
+	}
+	return nil, nil
+}
+
+// isLatencySensitive checks whether the pod is guaranteed and all its containers request whole cpus,
+// the pods the cpu manager assigns exclusive cpus to and the topology manager aligns.
+func isLatencySensitive(pod *v1.Pod) bool {
+	if utils.GetPodQOS(pod) != v1.PodQOSGuaranteed {
+		return false
+	}
+	for _, container := range pod.Spec.Containers {
+		cpu := container.Resources.Requests.Cpu()
+		if cpu.IsZero() || cpu.MilliValue()%1000 != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package numaawarerebalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildTopology(nodeName, policy string, availableCPUs ...string) nodeResourceTopology {
+	topology := nodeResourceTopology{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		Attributes: []attributeInfo{{Name: topologyManagerPolicyAttribute, Value: policy}},
+	}
+	for i, cpus := range availableCPUs {
+		topology.Zones = append(topology.Zones, zone{
+			Name: fmt.Sprintf("node-%d", i),
+			Type: numaZoneType,
+			Resources: []resourceInfo{
+				{Name: string(v1.ResourceCPU), Available: resource.MustParse(cpus)},
+				{Name: string(v1.ResourceMemory), Available: resource.MustParse("16Gi")},
+			},
+		})
+	}
+	return topology
+}
+
+func buildGuaranteedPod(name, nodeName string, milliCPU int64) *v1.Pod {
+	return test.BuildTestPod(name, milliCPU, 1000, nodeName, func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Containers[0].Resources.Limits = pod.Spec.Containers[0].Resources.Requests.DeepCopy()
+	})
+}
+
+func newTopologyServer(t *testing.T, topologies []nodeResourceTopology) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != nodeResourceTopologiesPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(nodeResourceTopologyList{Items: topologies}); err != nil {
+			t.Errorf("Unable to encode the node resource topologies: %v", err)
+		}
+	}))
+}
+
+func TestNUMAAwareRebalancer(t *testing.T) {
+	bestEffortNode := test.BuildTestNode("n1", 8000, 32000, 10, nil)
+	alignedNode := test.BuildTestNode("n2", 8000, 32000, 10, nil)
+	otherAlignedNode := test.BuildTestNode("n3", 8000, 32000, 10, nil)
+	taintedNode := test.BuildTestNode("n2", 8000, 32000, 10, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "db", Effect: v1.TaintEffectNoSchedule}}
+	})
+
+	burstablePod := test.BuildTestPod("burstable", 2000, 1000, "n1", test.SetRSOwnerRef)
+	legacyTopology := buildTopology("n2", "", "4")
+	legacyTopology.Attributes = nil
+	legacyTopology.TopologyPolicies = []string{"SingleNUMANodePodLevel"}
+
+	tests := []struct {
+		description          string
+		nodes                []*v1.Node
+		pods                 []*v1.Pod
+		topologies           []nodeResourceTopology
+		dryRun               bool
+		expectedEvictedCount uint
+	}{
+		{
+			description: "latency sensitive pod moved to a node aligning resources",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n1", 2000)},
+			topologies: []nodeResourceTopology{
+				buildTopology("n1", "best-effort", "4", "4"),
+				buildTopology("n2", "single-numa-node", "1", "4"),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "nodes are left alone in dry run mode",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n1", 2000)},
+			dryRun:      true,
+		},
+		{
+			description: "pods on nodes aligning resources are not evicted",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n2", 2000)},
+			topologies: []nodeResourceTopology{
+				buildTopology("n1", "none", "4", "4"),
+				buildTopology("n2", "restricted", "4", "4"),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "burstable pod not evicted",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode},
+			pods:        []*v1.Pod{burstablePod},
+			topologies: []nodeResourceTopology{
+				buildTopology("n1", "none", "4", "4"),
+				buildTopology("n2", "single-numa-node", "4"),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "guaranteed pod without exclusive cpus not evicted",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n1", 1500)},
+			topologies: []nodeResourceTopology{
+				buildTopology("n1", "none", "4", "4"),
+				buildTopology("n2", "single-numa-node", "4"),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "no NUMA node with enough cpus available",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n1", 3000)},
+			topologies: []nodeResourceTopology{
+				buildTopology("n1", "best-effort", "4", "4"),
+				buildTopology("n2", "single-numa-node", "2", "2"),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "NUMA node resources reserved for the pods already evicted",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n1", 2000), buildGuaranteedPod("p2", "n1", 2000)},
+			topologies: []nodeResourceTopology{
+				buildTopology("n1", "best-effort", "4", "4"),
+				buildTopology("n2", "single-numa-node", "3"),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "NUMA node resources of several aligned nodes",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode, otherAlignedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n1", 2000), buildGuaranteedPod("p2", "n1", 2000)},
+			topologies: []nodeResourceTopology{
+				buildTopology("n1", "best-effort", "4", "4"),
+				buildTopology("n2", "single-numa-node", "3"),
+				buildTopology("n3", "single-numa-node", "3"),
+			},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "nodes without topology left alone",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n1", 2000)},
+			topologies: []nodeResourceTopology{
+				buildTopology("n2", "single-numa-node", "4"),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "deprecated topology policies honored",
+			nodes:       []*v1.Node{bestEffortNode, alignedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n1", 2000)},
+			topologies: []nodeResourceTopology{
+				buildTopology("n1", "best-effort", "4", "4"),
+				legacyTopology,
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "pod not tolerating the taint of the aligned node",
+			nodes:       []*v1.Node{bestEffortNode, taintedNode},
+			pods:        []*v1.Pod{buildGuaranteedPod("p1", "n1", 2000)},
+			topologies: []nodeResourceTopology{
+				buildTopology("n1", "best-effort", "4", "4"),
+				buildTopology("n2", "single-numa-node", "4"),
+			},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			// The fake client does not serve the topology API, it is only used in dry run mode
+			if !tc.dryRun {
+				server := newTopologyServer(t, tc.topologies)
+				defer server.Close()
+				topologyClient, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+				if err != nil {
+					t.Fatalf("Unable to create a client: %v", err)
+				}
+				handle.ClientsetImpl = topologyClient
+			}
+
+			plugin, err := New(&NUMAAwareRebalancerArgs{}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			status := plugin.(frameworktypes.BalancePlugin).Balance(ctx, tc.nodes)
+			if status != nil && status.Err != nil {
+				t.Fatalf("Unexpected error: %v", status.Err)
+			}
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package numaawarerebalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// nodeResourceTopologiesPath lists the NodeResourceTopology objects published, one per node
+// and named after it, by the topology exporters of topology-aware scheduling (e.g. NFD or RTE)
+const nodeResourceTopologiesPath = "/apis/topology.node.k8s.io/v1alpha2/noderesourcetopologies"
+
+// topologyManagerPolicyAttribute is the attribute holding the topology manager policy of the kubelet
+const topologyManagerPolicyAttribute = "topologyManagerPolicy"
+
+// numaZoneType is the type of the zones standing for NUMA nodes
+const numaZoneType = "Node"
+
+// nodeResourceTopologyList is the part of the topology.node.k8s.io/v1alpha2 NodeResourceTopologyList read by the plugin
+type nodeResourceTopologyList struct {
+	Items []nodeResourceTopology `json:"items"`
+}
+
+// nodeResourceTopology is the part of the topology.node.k8s.io/v1alpha2 NodeResourceTopology read by the plugin
+type nodeResourceTopology struct {
+	metav1.ObjectMeta `json:"metadata"`
+	// TopologyPolicies is deprecated in favor of the topologyManagerPolicy attribute
+	TopologyPolicies []string        `json:"topologyPolicies,omitempty"`
+	Attributes       []attributeInfo `json:"attributes,omitempty"`
+	Zones            []zone          `json:"zones,omitempty"`
+}
+
+type attributeInfo struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type zone struct {
+	Name      string         `json:"name"`
+	Type      string         `json:"type"`
+	Resources []resourceInfo `json:"resources,omitempty"`
+}
+
+type resourceInfo struct {
+	Name      string            `json:"name"`
+	Available resource.Quantity `json:"available"`
+}
+
+// listNodeResourceTopologies returns the NodeResourceTopology objects by node name,
+// none when they can not be read (e.g. in dry run mode)
+func listNodeResourceTopologies(ctx context.Context, client clientset.Interface) (map[string]*nodeResourceTopology, error) {
+	if !utils.SupportsRawRequests(client) {
+		return nil, nil
+	}
+	body, err := client.CoreV1().RESTClient().Get().AbsPath(nodeResourceTopologiesPath).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the node resource topologies: %v", err)
+	}
+	var list nodeResourceTopologyList
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("unable to decode the node resource topologies: %v", err)
+	}
+	topologies := make(map[string]*nodeResourceTopology, len(list.Items))
+	for i := range list.Items {
+		topologies[list.Items[i].Name] = &list.Items[i]
+	}
+	return topologies, nil
+}
+
+// alignsResources checks whether the topology manager of the node refuses the pods it can not
+// align on NUMA nodes. Pods land on nodes with the none or best-effort policies even when misaligned.
+func (t *nodeResourceTopology) alignsResources() bool {
+	for _, attribute := range t.Attributes {
+		if attribute.Name == topologyManagerPolicyAttribute {
+			return attribute.Value == "restricted" || attribute.Value == "single-numa-node"
+		}
+	}
+	for _, policy := range t.TopologyPolicies {
+		switch policy {
+		case "Restricted", "SingleNUMANodeContainerLevel", "SingleNUMANodePodLevel":
+			return true
+		}
+	}
+	return false
+}
+
+// fittingZone returns the first NUMA zone with enough resources available for the requests, or nil.
+// The resources not reported by the zone (e.g. ephemeral storage) are not accounted per NUMA node.
+func (t *nodeResourceTopology) fittingZone(requests v1.ResourceList) *zone {
+	for i := range t.Zones {
+		if t.Zones[i].Type == numaZoneType && t.Zones[i].fits(requests) {
+			return &t.Zones[i]
+		}
+	}
+	return nil
+}
+
+func (z *zone) fits(requests v1.ResourceList) bool {
+	for _, info := range z.Resources {
+		if request, ok := requests[v1.ResourceName(info.Name)]; ok && request.Cmp(info.Available) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// reserve accounts the requests of a pod expected to be placed on the zone
+func (z *zone) reserve(requests v1.ResourceList) {
+	for i := range z.Resources {
+		if request, ok := requests[v1.ResourceName(z.Resources[i].Name)]; ok {
+			z.Resources[i].Available.Sub(request)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownerspreadacrosscontrolplaneupdates
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "OwnerSpreadAcrossControlPlaneUpdates"
+
+// OwnerSpreadAcrossControlPlaneUpdates moves the replicas of workloads from the nodes of an old
+// version onto the nodes of the target version while a node pool upgrade is in progress, so the
+// final drain of the old nodes does not disrupt all the replicas at once.
+//
+// The replicas of an owner are moved a few at a time: nothing is evicted for an owner until all
+// its pods are scheduled and ready, i.e. the replacements of the previously evicted pods surged.
+type OwnerSpreadAcrossControlPlaneUpdates struct {
+	handle    frameworktypes.Handle
+	args      *OwnerSpreadAcrossControlPlaneUpdatesArgs
+	podFilter podutil.FilterFunc
+	podLister listersv1.PodLister
+
+	maxEvictionsPerOwner uint
+}
+
+var _ frameworktypes.BalancePlugin = &OwnerSpreadAcrossControlPlaneUpdates{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	spreadArgs, ok := args.(*OwnerSpreadAcrossControlPlaneUpdatesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type OwnerSpreadAcrossControlPlaneUpdatesArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if spreadArgs.Namespaces != nil {
+		includedNamespaces = sets.New(spreadArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(spreadArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(spreadArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	maxEvictionsPerOwner := DefaultMaxEvictionsPerOwner
+	if spreadArgs.MaxEvictionsPerOwner != nil {
+		maxEvictionsPerOwner = *spreadArgs.MaxEvictionsPerOwner
+	}
+
+	return &OwnerSpreadAcrossControlPlaneUpdates{
+		handle:               handle,
+		args:                 spreadArgs,
+		podFilter:            podFilter,
+		podLister:            handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+		maxEvictionsPerOwner: maxEvictionsPerOwner,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *OwnerSpreadAcrossControlPlaneUpdates) Name() string {
+	return PluginName
+}
+
+// nodeVersion returns the version of the node pool the node belongs to
+func (d *OwnerSpreadAcrossControlPlaneUpdates) nodeVersion(node *v1.Node) string {
+	if d.args.NodeVersionKey == "" {
+		return node.Status.NodeInfo.KubeletVersion
+	}
+	if value, ok := node.Labels[d.args.NodeVersionKey]; ok {
+		return value
+	}
+	return node.Annotations[d.args.NodeVersionKey]
+}
+
+// splitNodes splits the nodes with a version into the nodes of the target version and the older ones.
+// Without a configured target version, the highest version found among the nodes is the target.
+func (d *OwnerSpreadAcrossControlPlaneUpdates) splitNodes(nodes []*v1.Node) (oldNodes, newNodes []*v1.Node, err error) {
+	if d.args.TargetVersion != "" {
+		for _, node := range nodes {
+			switch nodeVersion := d.nodeVersion(node); nodeVersion {
+			case "":
+			case d.args.TargetVersion:
+				newNodes = append(newNodes, node)
+			default:
+				oldNodes = append(oldNodes, node)
+			}
+		}
+		return oldNodes, newNodes, nil
+	}
+
+	versions := map[string]*version.Version{}
+	var target *version.Version
+	for _, node := range nodes {
+		nodeVersion := d.nodeVersion(node)
+		if nodeVersion == "" {
+			continue
+		}
+		parsed, err := version.ParseGeneric(nodeVersion)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse the version of node %q, set targetVersion instead: %v", node.Name, err)
+		}
+		versions[node.Name] = parsed
+		if target == nil || target.LessThan(parsed) {
+			target = parsed
+		}
+	}
+	for _, node := range nodes {
+		if parsed, ok := versions[node.Name]; ok {
+			if parsed.LessThan(target) {
+				oldNodes = append(oldNodes, node)
+			} else {
+				newNodes = append(newNodes, node)
+			}
+		}
+	}
+	return oldNodes, newNodes, nil
+}
+
+// settledOwners returns whether all the pods of each controller are scheduled, ready and not terminating.
+// An owner with an unsettled pod is still recovering from a previous eviction, or from a rollout.
+func (d *OwnerSpreadAcrossControlPlaneUpdates) settledOwners() (map[types.UID]bool, error) {
+	pods, err := d.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	settled := map[types.UID]bool{}
+	for _, pod := range pods {
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		if _, ok := settled[owner.UID]; !ok {
+			settled[owner.UID] = true
+		}
+		if pod.Spec.NodeName == "" || utils.IsPodTerminating(pod) || !utils.IsPodReady(pod) {
+			settled[owner.UID] = false
+		}
+	}
+	return settled, nil
+}
+
+// Balance extension point implementation for the plugin
+func (d *OwnerSpreadAcrossControlPlaneUpdates) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	oldNodes, newNodes, err := d.splitNodes(nodes)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: err,
+		}
+	}
+	if len(oldNodes) == 0 || len(newNodes) == 0 {
+		logger.V(1).Info("No node pool upgrade in progress", "oldNodes", len(oldNodes), "newNodes", len(newNodes))
+		return nil
+	}
+	logger.V(1).Info("Node pool upgrade in progress", "oldNodes", len(oldNodes), "newNodes", len(newNodes))
+
+	settled, err := d.settledOwners()
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods: %v", err),
+		}
+	}
+
+	var evicted uint
+	evictedPerOwner := map[types.UID]uint{}
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	for _, node := range oldNodes {
+		logger.V(1).Info("Processing node of an old version", "node", klog.KObj(node), "version", d.nodeVersion(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		// Move the least important pods first in case the limits are reached
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+	loop:
+		for _, pod := range pods {
+			owner := metav1.GetControllerOf(pod)
+			// DaemonSet pods follow their node, there is no replica to move
+			if owner == nil || owner.Kind == "DaemonSet" {
+				continue
+			}
+			if !settled[owner.UID] {
+				logger.V(2).Info("Waiting for the pods of the owner to be ready", "pod", klog.KObj(pod), "owner", owner.Name)
+				continue
+			}
+			if evictedPerOwner[owner.UID] >= d.maxEvictionsPerOwner {
+				continue
+			}
+			if !nodeutil.PodFitsAnyNode(getPodsAssignedToNode, pod, newNodes) {
+				logger.V(2).Info("Pod does not fit any node of the target version", "pod", klog.KObj(pod))
+				continue
+			}
+
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				evictedPerOwner[owner.UID]++
+				evicted++
+				if d.args.MaxEvictionsPerCycle != nil && evicted >= *d.args.MaxEvictionsPerCycle {
+					return nil
+				}
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
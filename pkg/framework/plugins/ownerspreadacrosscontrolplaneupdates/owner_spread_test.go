@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownerspreadacrosscontrolplaneupdates
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildNode(name, kubeletVersion string, apply func(*v1.Node)) *v1.Node {
+	return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+		node.Status.NodeInfo.KubeletVersion = kubeletVersion
+		if apply != nil {
+			apply(node)
+		}
+	})
+}
+
+func buildPod(name, nodeName, owner string, ready bool) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: owner, UID: types.UID(owner), Controller: utilptr.To(true)},
+		}
+		status := v1.ConditionTrue
+		if !ready {
+			status = v1.ConditionFalse
+		}
+		pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: status}}
+	})
+}
+
+func TestOwnerSpreadAcrossControlPlaneUpdates(t *testing.T) {
+	old1 := buildNode("old1", "v1.28.5", nil)
+	old2 := buildNode("old2", "v1.28.5", nil)
+	new1 := buildNode("new1", "v1.29.1", nil)
+	cordonedNew := buildNode("new1", "v1.29.1", func(node *v1.Node) {
+		node.Spec.Unschedulable = true
+	})
+	blue := buildNode("old1", "v1.28.5", func(node *v1.Node) {
+		node.Labels["example.com/node-pool-version"] = "blue"
+	})
+	green := buildNode("new1", "v1.28.5", func(node *v1.Node) {
+		node.Labels["example.com/node-pool-version"] = "green"
+	})
+
+	settledPods := []*v1.Pod{
+		buildPod("a1", "old1", "a", true),
+		buildPod("a2", "old1", "a", true),
+		buildPod("a3", "new1", "a", true),
+		buildPod("b1", "old2", "b", true),
+	}
+	pendingPod := buildPod("a4", "", "a", false)
+	pendingPod.Status.Phase = v1.PodPending
+
+	tests := []struct {
+		description             string
+		nodes                   []*v1.Node
+		pods                    []*v1.Pod
+		args                    OwnerSpreadAcrossControlPlaneUpdatesArgs
+		expectedEvictedPodCount uint
+		expectedErr             bool
+	}{
+		{
+			description:             "One replica per owner is moved off the nodes of the old version",
+			nodes:                   []*v1.Node{old1, old2, new1},
+			pods:                    settledPods,
+			args:                    OwnerSpreadAcrossControlPlaneUpdatesArgs{MaxEvictionsPerOwner: utilptr.To[uint](1)},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description:             "Several replicas per owner are moved",
+			nodes:                   []*v1.Node{old1, old2, new1},
+			pods:                    settledPods,
+			args:                    OwnerSpreadAcrossControlPlaneUpdatesArgs{MaxEvictionsPerOwner: utilptr.To[uint](2)},
+			expectedEvictedPodCount: 3,
+		},
+		{
+			description: "Evictions are limited per cycle",
+			nodes:       []*v1.Node{old1, old2, new1},
+			pods:        settledPods,
+			args: OwnerSpreadAcrossControlPlaneUpdatesArgs{
+				MaxEvictionsPerOwner: utilptr.To[uint](2),
+				MaxEvictionsPerCycle: utilptr.To[uint](1),
+			},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Owner with a pending replacement is not disrupted further",
+			nodes:                   []*v1.Node{old1, old2, new1},
+			pods:                    append([]*v1.Pod{pendingPod}, settledPods...),
+			args:                    OwnerSpreadAcrossControlPlaneUpdatesArgs{MaxEvictionsPerOwner: utilptr.To[uint](1)},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Owner with a replica not ready is not disrupted further",
+			nodes:       []*v1.Node{old1, old2, new1},
+			pods: []*v1.Pod{
+				buildPod("a1", "old1", "a", true),
+				buildPod("a2", "new1", "a", false),
+				buildPod("b1", "old2", "b", true),
+			},
+			args:                    OwnerSpreadAcrossControlPlaneUpdatesArgs{MaxEvictionsPerOwner: utilptr.To[uint](1)},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "No upgrade in progress",
+			nodes:                   []*v1.Node{old1, old2},
+			pods:                    settledPods,
+			args:                    OwnerSpreadAcrossControlPlaneUpdatesArgs{MaxEvictionsPerOwner: utilptr.To[uint](1)},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Pods not fitting any node of the target version are not evicted",
+			nodes:                   []*v1.Node{old1, old2, cordonedNew},
+			pods:                    settledPods,
+			args:                    OwnerSpreadAcrossControlPlaneUpdatesArgs{MaxEvictionsPerOwner: utilptr.To[uint](1)},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Configured target version lower than the other nodes",
+			nodes:       []*v1.Node{old1, old2, new1},
+			pods:        settledPods,
+			args: OwnerSpreadAcrossControlPlaneUpdatesArgs{
+				TargetVersion:        "v1.28.5",
+				MaxEvictionsPerOwner: utilptr.To[uint](1),
+			},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Node pool version read from a label",
+			nodes:       []*v1.Node{blue, green},
+			pods:        settledPods,
+			args: OwnerSpreadAcrossControlPlaneUpdatesArgs{
+				NodeVersionKey:       "example.com/node-pool-version",
+				TargetVersion:        "green",
+				MaxEvictionsPerOwner: utilptr.To[uint](2),
+			},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "Node pool versions not comparable without a target version",
+			nodes:       []*v1.Node{blue, green},
+			pods:        settledPods,
+			args: OwnerSpreadAcrossControlPlaneUpdatesArgs{
+				NodeVersionKey:       "example.com/node-pool-version",
+				MaxEvictionsPerOwner: utilptr.To[uint](1),
+			},
+			expectedEvictedPodCount: 0,
+			expectedErr:             true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			status := plugin.(frameworktypes.BalancePlugin).Balance(ctx, tc.nodes)
+			if hasErr := status != nil && status.Err != nil; hasErr != tc.expectedErr {
+				t.Errorf("Expected error %v, got status %v", tc.expectedErr, status)
+			}
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedPodCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedPodCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownerspreadacrosscontrolplaneupdates
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// OwnerSpreadAcrossControlPlaneUpdatesArgs holds arguments used to configure OwnerSpreadAcrossControlPlaneUpdates plugin.
+type OwnerSpreadAcrossControlPlaneUpdatesArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// NodeVersionKey is the node label, or annotation, holding the version of the node pool
+	// of the node. The kubelet version of the nodes is used when not set.
+	NodeVersionKey string `json:"nodeVersionKey,omitempty"`
+	// TargetVersion is the version the nodes are upgraded to.
+	// Defaults to the highest version found among the nodes.
+	TargetVersion string `json:"targetVersion,omitempty"`
+	// MaxEvictionsPerOwner limits the number of pods of the same owner evicted per descheduling cycle.
+	MaxEvictionsPerOwner *uint `json:"maxEvictionsPerOwner,omitempty"`
+	// MaxEvictionsPerCycle limits the number of pods evicted by the plugin per descheduling cycle.
+	MaxEvictionsPerCycle *uint `json:"maxEvictionsPerCycle,omitempty"`
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ownerspreadacrosscontrolplaneupdates
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateOwnerSpreadAcrossControlPlaneUpdatesArgs validates OwnerSpreadAcrossControlPlaneUpdates arguments
+func ValidateOwnerSpreadAcrossControlPlaneUpdatesArgs(obj runtime.Object) error {
+	args := obj.(*OwnerSpreadAcrossControlPlaneUpdatesArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.NodeVersionKey != "" {
+		if errs := validation.IsQualifiedName(args.NodeVersionKey); len(errs) > 0 {
+			return fmt.Errorf("invalid nodeVersionKey %q: %s", args.NodeVersionKey, strings.Join(errs, "; "))
+		}
+	}
+	if args.MaxEvictionsPerOwner != nil && *args.MaxEvictionsPerOwner == 0 {
+		return fmt.Errorf("maxEvictionsPerOwner must be greater than zero")
+	}
+	if args.MaxEvictionsPerCycle != nil && *args.MaxEvictionsPerCycle == 0 {
+		return fmt.Errorf("maxEvictionsPerCycle must be greater than zero")
+	}
+	return nil
+}
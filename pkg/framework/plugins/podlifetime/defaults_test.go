@@ -34,8 +34,10 @@ func TestSetDefaults_PodLifeTimeArgs(t *testing.T) {
 			name: "PodLifeTimeArgs empty",
 			in:   &PodLifeTimeArgs{},
 			want: &PodLifeTimeArgs{
-				Namespaces:            nil,
-				LabelSelector:         nil,
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    nil,
+					LabelSelector: nil,
+				},
 				MaxPodLifeTimeSeconds: nil,
 				States:                nil,
 			},
@@ -43,17 +45,21 @@ func TestSetDefaults_PodLifeTimeArgs(t *testing.T) {
 		{
 			name: "PodLifeTimeArgs with value",
 			in: &PodLifeTimeArgs{
-				Namespaces: &api.Namespaces{},
-				LabelSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{"foo": "bar"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					},
 				},
 				MaxPodLifeTimeSeconds: utilptr.To[uint](600),
 				States:                []string{"Pending"},
 			},
 			want: &PodLifeTimeArgs{
-				Namespaces: &api.Namespaces{},
-				LabelSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{"foo": "bar"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					},
 				},
 				MaxPodLifeTimeSeconds: utilptr.To[uint](600),
 				States:                []string{"Pending"},
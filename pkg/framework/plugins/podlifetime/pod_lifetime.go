@@ -26,13 +26,20 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/descheduler/metrics"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 )
 
-const PluginName = "PodLifeTime"
+const (
+	PluginName = "PodLifeTime"
+
+	// WarnThresholdReason is the reason of the events emitted on the pods above the warn threshold
+	WarnThresholdReason = "PodLifeTimeWarnThreshold"
+	actionWarned        = "Warned"
+)
 
 var _ frameworktypes.DeschedulePlugin = &PodLifeTime{}
 
@@ -67,9 +74,13 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
 	}
 
+	// The pods above the warn threshold are listed as well, to be reported only
+	minPodLifeTimeSeconds := *podLifeTimeArgs.MaxPodLifeTimeSeconds
+	if podLifeTimeArgs.WarnPodLifeTimeSeconds != nil {
+		minPodLifeTimeSeconds = min(minPodLifeTimeSeconds, *podLifeTimeArgs.WarnPodLifeTimeSeconds)
+	}
 	podFilter = podutil.WrapFilterFuncs(podFilter, func(pod *v1.Pod) bool {
-		podAgeSeconds := int(metav1.Now().Sub(pod.GetCreationTimestamp().Local()).Seconds())
-		return podAgeSeconds > int(*podLifeTimeArgs.MaxPodLifeTimeSeconds)
+		return podAgeSeconds(pod) > int(minPodLifeTimeSeconds)
 	})
 
 	if len(podLifeTimeArgs.States) > 0 {
@@ -128,11 +139,12 @@ func (d *PodLifeTime) Name() string {
 
 // Deschedule extension point implementation for the plugin
 func (d *PodLifeTime) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
 	podsToEvict := make([]*v1.Pod, 0)
 	nodeMap := make(map[string]*v1.Node, len(nodes))
 
 	for _, node := range nodes {
-		klog.V(2).InfoS("Processing node", "node", klog.KObj(node))
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
 		if err != nil {
 			// no pods evicted as error encountered retrieving evictable Pods
@@ -146,11 +158,20 @@ func (d *PodLifeTime) Deschedule(ctx context.Context, nodes []*v1.Node) *framewo
 	}
 
 	// Should sort Pods so that the oldest can be evicted first
-	// in the event that PDB or settings such maxNoOfPodsToEvictPer* prevent too much eviction
+	// in the event that PDB or settings such maxNoOfPodsToEvictPer* prevent too much eviction,
+	// the pods with the highest eviction cost being evicted last
 	podutil.SortPodsBasedOnAge(podsToEvict)
+	podutil.SortPodsBasedOnEvictionCost(podsToEvict)
 
 loop:
 	for _, pod := range podsToEvict {
+		if age := podAgeSeconds(pod); age <= int(*d.args.MaxPodLifeTimeSeconds) {
+			logger.V(2).Info("Pod lifetime is above the warn threshold", "pod", klog.KObj(pod), "lifeTimeSeconds", age)
+			d.handle.EventRecorder().Eventf(pod, nil, v1.EventTypeWarning, WarnThresholdReason, actionWarned,
+				"Pod lifetime of %ds is above the warn threshold of %ds", age, *d.args.WarnPodLifeTimeSeconds)
+			metrics.ThresholdWarnings.With(map[string]string{"strategy": PluginName, "namespace": pod.Namespace, "node": pod.Spec.NodeName}).Inc()
+			continue
+		}
 		err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
 		if err == nil {
 			continue
@@ -161,9 +182,14 @@ loop:
 		case *evictions.EvictionTotalLimitError:
 			return nil
 		default:
-			klog.Errorf("eviction failed: %v", err)
+			logger.Error(err, "Eviction failed")
 		}
 	}
 
 	return nil
 }
+
+// podAgeSeconds returns the number of seconds since the pod was created
+func podAgeSeconds(pod *v1.Pod) int {
+	return int(metav1.Now().Sub(pod.GetCreationTimestamp().Local()).Seconds())
+}
@@ -18,6 +18,9 @@ package podlifetime
 
 import (
 	"context"
+	"fmt"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
 	utilptr "k8s.io/utils/ptr"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
@@ -271,8 +275,10 @@ func TestPodLifeTime(t *testing.T) {
 			description: "No pod to evicted since all pod terminating",
 			args: &PodLifeTimeArgs{
 				MaxPodLifeTimeSeconds: &maxLifeTime,
-				LabelSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{"foo": "bar"},
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					},
 				},
 			},
 			pods:                    []*v1.Pod{p12, p13},
@@ -283,8 +289,10 @@ func TestPodLifeTime(t *testing.T) {
 			description: "No pod should be evicted since pod terminating",
 			args: &PodLifeTimeArgs{
 				MaxPodLifeTimeSeconds: &maxLifeTime,
-				LabelSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{"foo": "bar"},
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"foo": "bar"},
+					},
 				},
 			},
 			pods:                    []*v1.Pod{p14, p15},
@@ -656,3 +664,49 @@ func TestPodLifeTime(t *testing.T) {
 		})
 	}
 }
+
+func TestPodLifeTimeWarnThreshold(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	var objs []runtime.Object
+	for i, age := range []time.Duration{10 * time.Second, 1000 * time.Second, 5000 * time.Second} {
+		pod := test.BuildTestPod(fmt.Sprintf("p%d", i), 100, 0, node1.Name, test.SetRSOwnerRef)
+		pod.ObjectMeta.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		objs = append(objs, pod)
+	}
+	fakeClient := fake.NewSimpleClientset(append(objs, node1)...)
+
+	handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+		ctx,
+		fakeClient,
+		evictions.NewOptions(),
+		defaultevictor.DefaultEvictorArgs{},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Unable to initialize a framework handle: %v", err)
+	}
+	eventRecorder := events.NewFakeRecorder(10)
+	handle.EventRecorderImpl = eventRecorder
+
+	plugin, err := New(&PodLifeTimeArgs{
+		MaxPodLifeTimeSeconds:  utilptr.To[uint](3600),
+		WarnPodLifeTimeSeconds: utilptr.To[uint](600),
+	}, handle)
+	if err != nil {
+		t.Fatalf("Unable to initialize the plugin: %v", err)
+	}
+
+	plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node1})
+	if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != 1 {
+		t.Errorf("Expected 1 pod to be evicted, got %v", podsEvicted)
+	}
+	if len(eventRecorder.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %v", len(eventRecorder.Events))
+	}
+	if event := <-eventRecorder.Events; !strings.Contains(event, WarnThresholdReason) {
+		t.Errorf("Unexpected event: %v", event)
+	}
+}
@@ -25,10 +25,13 @@ import (
 type PodLifeTimeArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
-	Namespaces                   *api.Namespaces       `json:"namespaces"`
-	LabelSelector                *metav1.LabelSelector `json:"labelSelector"`
-	MaxPodLifeTimeSeconds        *uint                 `json:"maxPodLifeTimeSeconds"`
-	States                       []string              `json:"states"`
-	IncludingInitContainers      bool                  `json:"includingInitContainers"`
-	IncludingEphemeralContainers bool                  `json:"includingEphemeralContainers"`
+	api.FilteringArgs            `json:",inline"`
+	MaxPodLifeTimeSeconds        *uint    `json:"maxPodLifeTimeSeconds"`
+	States                       []string `json:"states"`
+	IncludingInitContainers      bool     `json:"includingInitContainers"`
+	IncludingEphemeralContainers bool     `json:"includingEphemeralContainers"`
+
+	// WarnPodLifeTimeSeconds reports the pods older than the given lifetime, without evicting
+	// them until they are older than MaxPodLifeTimeSeconds.
+	WarnPodLifeTimeSeconds *uint `json:"warnPodLifeTimeSeconds,omitempty"`
 }
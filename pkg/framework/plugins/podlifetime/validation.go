@@ -22,7 +22,6 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -32,16 +31,12 @@ func ValidatePodLifeTimeArgs(obj runtime.Object) error {
 	if args.MaxPodLifeTimeSeconds == nil {
 		return fmt.Errorf("MaxPodLifeTimeSeconds not set")
 	}
-
-	// At most one of include/exclude can be set
-	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
-		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	if args.WarnPodLifeTimeSeconds != nil && *args.WarnPodLifeTimeSeconds >= *args.MaxPodLifeTimeSeconds {
+		return fmt.Errorf("WarnPodLifeTimeSeconds must be lower than MaxPodLifeTimeSeconds")
 	}
 
-	if args.LabelSelector != nil {
-		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
-			return fmt.Errorf("failed to get label selectors from strategy's params: %+v", err)
-		}
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
 	}
 	podLifeTimeAllowedStates := sets.New(
 		// Pod Status Phase
@@ -44,6 +44,22 @@ func TestValidateRemovePodLifeTimeArgs(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			description: "warn threshold lower than max lifetime, no errors",
+			args: &PodLifeTimeArgs{
+				MaxPodLifeTimeSeconds:  func(i uint) *uint { return &i }(3600),
+				WarnPodLifeTimeSeconds: func(i uint) *uint { return &i }(600),
+			},
+			expectError: false,
+		},
+		{
+			description: "warn threshold not lower than max lifetime, expects errors",
+			args: &PodLifeTimeArgs{
+				MaxPodLifeTimeSeconds:  func(i uint) *uint { return &i }(600),
+				WarnPodLifeTimeSeconds: func(i uint) *uint { return &i }(600),
+			},
+			expectError: true,
+		},
 		{
 			description: "nil MaxPodLifeTimeSeconds arg, expects errors",
 			args: &PodLifeTimeArgs{
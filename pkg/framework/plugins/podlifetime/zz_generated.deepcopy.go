@@ -22,25 +22,14 @@ limitations under the License.
 package podlifetime
 
 import (
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
-	api "sigs.k8s.io/descheduler/pkg/api"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodLifeTimeArgs) DeepCopyInto(out *PodLifeTimeArgs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = new(api.Namespaces)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.LabelSelector != nil {
-		in, out := &in.LabelSelector, &out.LabelSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
+	in.FilteringArgs.DeepCopyInto(&out.FilteringArgs)
 	if in.MaxPodLifeTimeSeconds != nil {
 		in, out := &in.MaxPodLifeTimeSeconds, &out.MaxPodLifeTimeSeconds
 		*out = new(uint)
@@ -51,6 +40,11 @@ func (in *PodLifeTimeArgs) DeepCopyInto(out *PodLifeTimeArgs) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.WarnPodLifeTimeSeconds != nil {
+		in, out := &in.WarnPodLifeTimeSeconds, &out.WarnPodLifeTimeSeconds
+		*out = new(uint)
+		**out = **in
+	}
 	return
 }
 
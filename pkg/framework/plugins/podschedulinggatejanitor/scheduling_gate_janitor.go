@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podschedulinggatejanitor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	PluginName = "PodSchedulingGateJanitor"
+
+	// SchedulingGatedTooLongReason is the reason of the events emitted on pods gated for too long and their owners
+	SchedulingGatedTooLongReason = "SchedulingGatedTooLong"
+
+	actionReported = "Reported"
+	actionDeleted  = "Deleted"
+)
+
+// PodSchedulingGateJanitor looks for pods held by scheduling gates for longer than a
+// configured timeout, typically because the controller supposed to remove the gates
+// forgot about them. Gated pods are not running, so there is nothing to evict. Instead,
+// events are emitted on the pods, escalating to warnings on the pods and their owners
+// once the timeout got exceeded twice, or the pods get deleted so their owner recreates them.
+type PodSchedulingGateJanitor struct {
+	handle       frameworktypes.Handle
+	args         *PodSchedulingGateJanitorArgs
+	podFilter    podutil.FilterFunc
+	podLister    listersv1.PodLister
+	ignoredGates sets.Set[string]
+}
+
+var _ frameworktypes.DeschedulePlugin = &PodSchedulingGateJanitor{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	janitorArgs, ok := args.(*PodSchedulingGateJanitorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type PodSchedulingGateJanitorArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if janitorArgs.Namespaces != nil {
+		includedNamespaces = sets.New(janitorArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(janitorArgs.Namespaces.Exclude...)
+	}
+
+	// Gated pods are not assigned to any node, so PreEvictionFilter (e.g. node fit) does not apply here
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(janitorArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &PodSchedulingGateJanitor{
+		handle:       handle,
+		args:         janitorArgs,
+		podFilter:    podFilter,
+		podLister:    handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+		ignoredGates: sets.New(janitorArgs.IgnoredGates...),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *PodSchedulingGateJanitor) Name() string {
+	return PluginName
+}
+
+// pendingGates returns the scheduling gates of an unscheduled pod which are not ignored
+func (d *PodSchedulingGateJanitor) pendingGates(pod *v1.Pod) []string {
+	if pod.Spec.NodeName != "" || utils.IsPodTerminating(pod) {
+		return nil
+	}
+	var gates []string
+	for _, gate := range pod.Spec.SchedulingGates {
+		if !d.ignoredGates.Has(gate.Name) {
+			gates = append(gates, gate.Name)
+		}
+	}
+	return gates
+}
+
+// gatedSince returns when the pod got gated, i.e. the last transition of its
+// SchedulingGated condition, or its creation when the condition is not set.
+func gatedSince(pod *v1.Pod) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Reason == v1.PodReasonSchedulingGated && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return pod.CreationTimestamp.Time
+}
+
+// Deschedule extension point implementation for the plugin.
+// Gated pods are not bound to any node, so the list of nodes is not used.
+func (d *PodSchedulingGateJanitor) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	pods, err := d.podLister.List(labels.Everything())
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods: %v", err),
+		}
+	}
+
+	maxGated := time.Duration(*d.args.MaxGatedSeconds) * time.Second
+	for _, pod := range pods {
+		gates := d.pendingGates(pod)
+		if len(gates) == 0 {
+			continue
+		}
+		gated := time.Since(gatedSince(pod))
+		if gated <= maxGated || !d.podFilter(pod) {
+			continue
+		}
+		gated = gated.Truncate(time.Second)
+		logger.V(1).Info("Pod is held by scheduling gates for too long", "pod", klog.KObj(pod), "gates", gates, "gated", gated)
+
+		owner := metav1.GetControllerOf(pod)
+		// Without a controller nothing would recreate the pod, so such pods are only reported
+		if d.args.DeleteGatedPods && owner != nil {
+			if err := d.handle.ClientSet().CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+				logger.Error(err, "Unable to delete gated pod", "pod", klog.KObj(pod))
+				continue
+			}
+			logger.V(1).Info("Deleted gated pod", "pod", klog.KObj(pod))
+			d.handle.EventRecorder().Eventf(pod, nil, v1.EventTypeNormal, SchedulingGatedTooLongReason, actionDeleted,
+				"pod deleted after being gated by %s for %v", strings.Join(gates, ", "), gated)
+			continue
+		}
+
+		if gated <= 2*maxGated {
+			d.handle.EventRecorder().Eventf(pod, nil, v1.EventTypeNormal, SchedulingGatedTooLongReason, actionReported,
+				"pod gated by %s for %v", strings.Join(gates, ", "), gated)
+			continue
+		}
+		d.handle.EventRecorder().Eventf(pod, nil, v1.EventTypeWarning, SchedulingGatedTooLongReason, actionReported,
+			"pod gated by %s for %v", strings.Join(gates, ", "), gated)
+		if owner != nil {
+			ownerRef := &v1.ObjectReference{
+				APIVersion: owner.APIVersion,
+				Kind:       owner.Kind,
+				Namespace:  pod.Namespace,
+				Name:       owner.Name,
+				UID:        owner.UID,
+			}
+			d.handle.EventRecorder().Eventf(ownerRef, pod, v1.EventTypeWarning, SchedulingGatedTooLongReason, actionReported,
+				"pod %s gated by %s for %v", pod.Name, strings.Join(gates, ", "), gated)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podschedulinggatejanitor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildGatedPod(name, namespace, ownerName string, gated time.Duration, gates ...string) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, "", func(pod *v1.Pod) {
+		pod.Namespace = namespace
+		pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-gated - time.Minute))
+		if ownerName != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       ownerName,
+				UID:        types.UID(ownerName),
+				Controller: utilptr.To(true),
+			}}
+		}
+		for _, gate := range gates {
+			pod.Spec.SchedulingGates = append(pod.Spec.SchedulingGates, v1.PodSchedulingGate{Name: gate})
+		}
+		pod.Status.Phase = v1.PodPending
+		pod.Status.Conditions = []v1.PodCondition{{
+			Type:               v1.PodScheduled,
+			Status:             v1.ConditionFalse,
+			Reason:             v1.PodReasonSchedulingGated,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-gated)),
+		}}
+	})
+}
+
+func TestPodSchedulingGateJanitor(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+
+	// gated for longer than the timeout
+	p1 := buildGatedPod("p1", "default", "rs-1", 90*time.Minute, "example.com/gate")
+	// gated for more than twice the timeout
+	p2 := buildGatedPod("p2", "default", "rs-2", 3*time.Hour, "example.com/gate")
+	// gated, but not for long enough
+	p3 := buildGatedPod("p3", "default", "rs-3", time.Minute, "example.com/gate")
+	// gated, but only by an ignored gate
+	p4 := buildGatedPod("p4", "default", "rs-4", 3*time.Hour, "example.com/quota")
+	// pending without gates
+	p5 := buildGatedPod("p5", "default", "rs-5", 3*time.Hour)
+	p6 := buildGatedPod("p6", "kube-system", "rs-6", 90*time.Minute, "example.com/gate")
+	// running pod
+	p7 := test.BuildTestPod("p7", 100, 0, node1.Name, test.SetRSOwnerRef)
+
+	tests := []struct {
+		description       string
+		pods              []*v1.Pod
+		args              PodSchedulingGateJanitorArgs
+		expectedEvents    int
+		expectedWarnings  int
+		expectedDeletions int
+	}{
+		{
+			description: "Pods gated for too long are reported, escalating to their owner",
+			pods:        []*v1.Pod{p1, p2, p3, p4, p5, p7},
+			args: PodSchedulingGateJanitorArgs{
+				MaxGatedSeconds: utilptr.To[uint](3600),
+				IgnoredGates:    []string{"example.com/quota"},
+			},
+			expectedEvents:    3,
+			expectedWarnings:  2,
+			expectedDeletions: 0,
+		},
+		{
+			description: "Pods gated for too long are deleted when enabled",
+			pods:        []*v1.Pod{p1, p2, p3, p4, p5, p7},
+			args: PodSchedulingGateJanitorArgs{
+				MaxGatedSeconds: utilptr.To[uint](3600),
+				DeleteGatedPods: true,
+			},
+			expectedEvents:    3,
+			expectedWarnings:  0,
+			expectedDeletions: 3,
+		},
+		{
+			description: "Gated pods in excluded namespaces are ignored",
+			pods:        []*v1.Pod{p1, p6},
+			args: PodSchedulingGateJanitorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Exclude: []string{"kube-system"},
+					},
+				},
+				MaxGatedSeconds: utilptr.To[uint](3600),
+				DeleteGatedPods: true,
+			},
+			expectedEvents:    1,
+			expectedDeletions: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{node1}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, _, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+			eventRecorder := events.NewFakeRecorder(10)
+			handle.EventRecorderImpl = eventRecorder
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node1})
+
+			if len(eventRecorder.Events) != tc.expectedEvents {
+				t.Errorf("Expected %v events, got %v", tc.expectedEvents, len(eventRecorder.Events))
+			}
+			warnings := 0
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if !strings.Contains(event, SchedulingGatedTooLongReason) {
+					t.Errorf("Unexpected event: %v", event)
+				}
+				if strings.HasPrefix(event, v1.EventTypeWarning) {
+					warnings++
+				}
+			}
+			if warnings != tc.expectedWarnings {
+				t.Errorf("Expected %v warnings, got %v", tc.expectedWarnings, warnings)
+			}
+
+			pods, err := fakeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Unable to list pods: %v", err)
+			}
+			if deletions := len(tc.pods) - len(pods.Items); deletions != tc.expectedDeletions {
+				t.Errorf("Expected %v pods to be deleted, got %v", tc.expectedDeletions, deletions)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podschedulinggatejanitor
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodSchedulingGateJanitorArgs holds arguments used to configure PodSchedulingGateJanitor plugin.
+type PodSchedulingGateJanitorArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// MaxGatedSeconds is how long a pod may keep its scheduling gates before being reported or deleted
+	MaxGatedSeconds *uint `json:"maxGatedSeconds,omitempty"`
+	// DeleteGatedPods deletes the pods gated for too long so their owner recreates them
+	DeleteGatedPods bool `json:"deleteGatedPods,omitempty"`
+	// IgnoredGates lists scheduling gates which are expected to be held for a long time
+	IgnoredGates []string `json:"ignoredGates,omitempty"`
+}
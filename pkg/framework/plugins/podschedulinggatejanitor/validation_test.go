@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podschedulinggatejanitor
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidatePodSchedulingGateJanitorArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *PodSchedulingGateJanitorArgs
+		expectError bool
+	}{
+		{
+			description: "valid args, no errors",
+			args: &PodSchedulingGateJanitorArgs{
+				MaxGatedSeconds: utilptr.To[uint](3600),
+				IgnoredGates:    []string{"example.com/quota"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			description: "MaxGatedSeconds not set, expects error",
+			args:        &PodSchedulingGateJanitorArgs{},
+			expectError: true,
+		},
+		{
+			description: "MaxGatedSeconds set to zero, expects error",
+			args: &PodSchedulingGateJanitorArgs{
+				MaxGatedSeconds: utilptr.To[uint](0),
+			},
+			expectError: true,
+		},
+		{
+			description: "empty ignored gate, expects error",
+			args: &PodSchedulingGateJanitorArgs{
+				MaxGatedSeconds: utilptr.To[uint](3600),
+				IgnoredGates:    []string{""},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid namespaces args, expects error",
+			args: &PodSchedulingGateJanitorArgs{
+				MaxGatedSeconds: utilptr.To[uint](3600),
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+						Exclude: []string{"kube-system"},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector args, expects errors",
+			args: &PodSchedulingGateJanitorArgs{
+				MaxGatedSeconds: utilptr.To[uint](3600),
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Operator: metav1.LabelSelectorOpIn,
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidatePodSchedulingGateJanitorArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
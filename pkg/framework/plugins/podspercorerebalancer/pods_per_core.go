@@ -0,0 +1,238 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podspercorerebalancer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "PodsPerCoreRebalancer"
+
+// PodsPerCoreRebalancer evicts pods from the nodes running too many pods for their
+// number of allocatable cpu cores, so they get scheduled onto the nodes running few pods
+// per core. It balances the number of pods rather than their requests, for workloads
+// where the overhead of each pod (kubelet, networking...) matters more than its requests.
+type PodsPerCoreRebalancer struct {
+	handle    frameworktypes.Handle
+	args      *PodsPerCoreRebalancerArgs
+	podFilter podutil.FilterFunc
+}
+
+// nodeDensity is a node along with the number of pods it runs
+type nodeDensity struct {
+	node    *v1.Node
+	cores   float64
+	pods    int
+	maxPods int
+}
+
+func (n nodeDensity) density() float64 {
+	return float64(n.pods) / n.cores
+}
+
+var _ frameworktypes.BalancePlugin = &PodsPerCoreRebalancer{}
+
+var _ frameworktypes.AllPodsRequirer = &PodsPerCoreRebalancerArgs{}
+
+// RequiresAllPods tells the pods per core of the nodes are computed from all their pods
+func (a *PodsPerCoreRebalancerArgs) RequiresAllPods() bool {
+	return true
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	podsPerCoreArgs, ok := args.(*PodsPerCoreRebalancerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type PodsPerCoreRebalancerArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if podsPerCoreArgs.Namespaces != nil {
+		includedNamespaces = sets.New(podsPerCoreArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(podsPerCoreArgs.Namespaces.Exclude...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(podsPerCoreArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &PodsPerCoreRebalancer{
+		handle:    handle,
+		args:      podsPerCoreArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *PodsPerCoreRebalancer) Name() string {
+	return PluginName
+}
+
+// Balance extension point implementation for the plugin
+func (d *PodsPerCoreRebalancer) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	densities, err := nodeDensities(logger, nodes, getPodsAssignedToNode)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error computing the density of the nodes: %v", err),
+		}
+	}
+	if len(densities) == 0 {
+		return nil
+	}
+
+	low, high := d.args.TargetDensity.Low, d.args.TargetDensity.High
+	if d.args.UseDeviationThresholds {
+		average := averageDensity(densities)
+		low, high = math.Max(average-low, 0), average+high
+		logger.V(1).Info("Target density computed from the average density of the nodes", "average", average, "low", low, "high", high)
+	}
+
+	var sourceNodes []nodeDensity
+	var destinationNodes []*v1.Node
+	// Number of pods the destination nodes can receive before exceeding the high density
+	headroom := 0
+	for _, density := range densities {
+		switch {
+		case density.density() > high:
+			logger.V(2).Info("Node runs too many pods per core", "node", klog.KObj(density.node), "density", density.density(), "high", high)
+			sourceNodes = append(sourceNodes, density)
+		case density.density() < low:
+			if nodeutil.IsNodeUnschedulable(density.node) {
+				logger.V(2).Info("Node is unschedulable, thus not considered as a destination", "node", klog.KObj(density.node))
+				continue
+			}
+			nodeHeadroom := min(int(math.Floor(high*density.cores))-density.pods, density.maxPods-density.pods)
+			if nodeHeadroom <= 0 {
+				continue
+			}
+			logger.V(2).Info("Node runs few pods per core", "node", klog.KObj(density.node), "density", density.density(), "low", low, "headroom", nodeHeadroom)
+			destinationNodes = append(destinationNodes, density.node)
+			headroom += nodeHeadroom
+		}
+	}
+	if len(sourceNodes) == 0 || len(destinationNodes) == 0 {
+		logger.V(1).Info("No node to balance pods from or to", "sourceNodes", len(sourceNodes), "destinationNodes", len(destinationNodes))
+		return nil
+	}
+
+	// Densest nodes first
+	sort.SliceStable(sourceNodes, func(i, j int) bool {
+		return sourceNodes[i].density() > sourceNodes[j].density()
+	})
+
+	for _, source := range sourceNodes {
+		if headroom <= 0 {
+			logger.V(1).Info("Destination nodes can not receive more pods")
+			return nil
+		}
+		excess := source.pods - int(math.Floor(high*source.cores))
+		logger.V(1).Info("Evicting pods from node", "node", klog.KObj(source.node), "density", source.density(), "excess", excess)
+		pods, err := podutil.ListPodsOnANode(source.node.Name, getPodsAssignedToNode, d.podFilter)
+		if err != nil {
+			logger.Error(err, "Error listing pods on node", "node", klog.KObj(source.node))
+			continue
+		}
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+
+	loop:
+		for _, pod := range pods {
+			if excess <= 0 || headroom <= 0 {
+				break
+			}
+			if !nodeutil.PodFitsAnyNode(getPodsAssignedToNode, pod, destinationNodes) {
+				logger.V(3).Info("Pod does not fit any node running few pods per core", "pod", klog.KObj(pod))
+				continue
+			}
+			if !d.handle.Evictor().PreEvictionFilter(pod) {
+				continue
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				excess--
+				headroom--
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// nodeDensities counts the pods of the nodes. Nodes without allocatable cpu are left out.
+func nodeDensities(logger klog.Logger, nodes []*v1.Node, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) ([]nodeDensity, error) {
+	// Terminated pods do not cost anything to the node anymore
+	activePods := func(pod *v1.Pod) bool {
+		return pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed
+	}
+	densities := make([]nodeDensity, 0, len(nodes))
+	for _, node := range nodes {
+		cores := float64(node.Status.Allocatable.Cpu().MilliValue()) / 1000
+		if cores == 0 {
+			logger.V(2).Info("Node has no allocatable cpu, not considered", "node", klog.KObj(node))
+			continue
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, activePods)
+		if err != nil {
+			return nil, err
+		}
+		densities = append(densities, nodeDensity{
+			node:    node,
+			cores:   cores,
+			pods:    len(pods),
+			maxPods: int(node.Status.Allocatable.Pods().Value()),
+		})
+	}
+	return densities, nil
+}
+
+// averageDensity returns the number of pods per core across all the nodes
+func averageDensity(densities []nodeDensity) float64 {
+	pods, cores := 0, 0.0
+	for _, density := range densities {
+		pods += density.pods
+		cores += density.cores
+	}
+	return float64(pods) / cores
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podspercorerebalancer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+// buildPods returns the given number of pods assigned to the node
+func buildPods(node string, count int, apply func(*v1.Pod)) []runtime.Object {
+	var pods []runtime.Object
+	for i := 0; i < count; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("%s-p%d", node, i), 100, 0, node, apply))
+	}
+	return pods
+}
+
+func TestPodsPerCoreRebalancer(t *testing.T) {
+	setSucceeded := func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Status.Phase = v1.PodSucceeded
+	}
+
+	tests := []struct {
+		description          string
+		args                 PodsPerCoreRebalancerArgs
+		nodes                []*v1.Node
+		pods                 []runtime.Object
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pods are evicted from the node above the band until its density gets back to the high bound",
+			args:        PodsPerCoreRebalancerArgs{TargetDensity: DensityBand{Low: 1, High: 3}},
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 20, nil),
+				test.BuildTestNode("n2", 2000, 3000, 20, nil),
+			},
+			// 5 pods per core on n1, 0.5 on n2
+			pods:                 append(buildPods("n1", 10, test.SetRSOwnerRef), buildPods("n2", 1, test.SetRSOwnerRef)...),
+			expectedEvictedCount: 4,
+		},
+		{
+			description: "Terminated pods are not counted",
+			args:        PodsPerCoreRebalancerArgs{TargetDensity: DensityBand{Low: 1, High: 3}},
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 20, nil),
+				test.BuildTestNode("n2", 2000, 3000, 20, nil),
+			},
+			pods:                 append(buildPods("n1", 6, test.SetRSOwnerRef), buildPods("n2", 10, setSucceeded)...),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Destination nodes do not receive more pods than they can run",
+			args:        PodsPerCoreRebalancerArgs{TargetDensity: DensityBand{Low: 1, High: 3}},
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 20, nil),
+				test.BuildTestNode("n2", 2000, 3000, 3, nil),
+			},
+			pods:                 append(buildPods("n1", 10, test.SetRSOwnerRef), buildPods("n2", 1, test.SetRSOwnerRef)...),
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Unschedulable nodes do not receive pods",
+			args:        PodsPerCoreRebalancerArgs{TargetDensity: DensityBand{Low: 1, High: 3}},
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 20, nil),
+				test.BuildTestNode("n2", 2000, 3000, 20, test.SetNodeUnschedulable),
+			},
+			pods:                 append(buildPods("n1", 10, test.SetRSOwnerRef), buildPods("n2", 1, test.SetRSOwnerRef)...),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Nodes within the band are not balanced",
+			args:        PodsPerCoreRebalancerArgs{TargetDensity: DensityBand{Low: 1, High: 6}},
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 20, nil),
+				test.BuildTestNode("n2", 2000, 3000, 20, nil),
+			},
+			pods:                 append(buildPods("n1", 10, test.SetRSOwnerRef), buildPods("n2", 1, test.SetRSOwnerRef)...),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Bounds are relative to the average density with deviation thresholds",
+			args: PodsPerCoreRebalancerArgs{
+				TargetDensity:          DensityBand{Low: 1, High: 1},
+				UseDeviationThresholds: true,
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 20, nil),
+				test.BuildTestNode("n2", 2000, 3000, 20, nil),
+			},
+			// 2.75 pods per core on average: n1 goes down to 3.75 pods per core
+			pods:                 append(buildPods("n1", 10, test.SetRSOwnerRef), buildPods("n2", 1, test.SetRSOwnerRef)...),
+			expectedEvictedCount: 3,
+		},
+		{
+			description: "Pods of excluded namespaces are not evicted",
+			args: PodsPerCoreRebalancerArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"default"}},
+				},
+				TargetDensity: DensityBand{Low: 1, High: 3},
+			},
+			nodes: []*v1.Node{
+				test.BuildTestNode("n1", 2000, 3000, 20, nil),
+				test.BuildTestNode("n2", 2000, 3000, 20, nil),
+			},
+			pods:                 append(buildPods("n1", 10, test.SetRSOwnerRef), buildPods("n2", 1, test.SetRSOwnerRef)...),
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			objs = append(objs, tc.pods...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := tc.args
+			SetDefaults_PodsPerCoreRebalancerArgs(&args)
+			plugin, err := New(&args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
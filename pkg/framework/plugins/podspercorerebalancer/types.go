@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podspercorerebalancer
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodsPerCoreRebalancerArgs holds arguments used to configure PodsPerCoreRebalancer plugin.
+type PodsPerCoreRebalancerArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// TargetDensity is the band of pods per allocatable cpu core the density of the nodes is kept within
+	TargetDensity DensityBand `json:"targetDensity"`
+	// UseDeviationThresholds makes the bounds of the band relative to the average density of the nodes
+	UseDeviationThresholds bool `json:"useDeviationThresholds,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// DensityBand is a range of pods per allocatable cpu core.
+type DensityBand struct {
+	// Low is the density under which nodes receive the evicted pods
+	Low float64 `json:"low"`
+	// High is the density above which pods are evicted from nodes
+	High float64 `json:"high"`
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podspercorerebalancer
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidatePodsPerCoreRebalancerArgs validates PodsPerCoreRebalancer arguments
+func ValidatePodsPerCoreRebalancerArgs(obj runtime.Object) error {
+	args := obj.(*PodsPerCoreRebalancerArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.TargetDensity.Low < 0 || args.TargetDensity.High < 0 {
+		return fmt.Errorf("targetDensity bounds can not be negative")
+	}
+	if args.UseDeviationThresholds {
+		return nil
+	}
+	if args.TargetDensity.High == 0 {
+		return fmt.Errorf("targetDensity high can not be zero")
+	}
+	if args.TargetDensity.Low > args.TargetDensity.High {
+		return fmt.Errorf("targetDensity low can not be greater than high")
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podspercorerebalancer
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidatePodsPerCoreRebalancerArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *PodsPerCoreRebalancerArgs
+		expectError bool
+	}{
+		{
+			description: "valid args, no errors",
+			args:        &PodsPerCoreRebalancerArgs{TargetDensity: DensityBand{Low: 4, High: 8}},
+			expectError: false,
+		},
+		{
+			description: "empty band with deviation thresholds, no errors",
+			args:        &PodsPerCoreRebalancerArgs{UseDeviationThresholds: true},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &PodsPerCoreRebalancerArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+				TargetDensity: DensityBand{Low: 4, High: 8},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects error",
+			args: &PodsPerCoreRebalancerArgs{
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+					},
+				},
+				TargetDensity: DensityBand{Low: 4, High: 8},
+			},
+			expectError: true,
+		},
+		{
+			description: "negative bound, expects error",
+			args:        &PodsPerCoreRebalancerArgs{TargetDensity: DensityBand{Low: -1, High: 1}, UseDeviationThresholds: true},
+			expectError: true,
+		},
+		{
+			description: "zero high bound, expects error",
+			args:        &PodsPerCoreRebalancerArgs{TargetDensity: DensityBand{}},
+			expectError: true,
+		},
+		{
+			description: "low bound greater than high bound, expects error",
+			args:        &PodsPerCoreRebalancerArgs{TargetDensity: DensityBand{Low: 8, High: 4}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidatePodsPerCoreRebalancerArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
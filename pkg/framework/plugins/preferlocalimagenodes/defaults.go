@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preferlocalimagenodes
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+// DefaultMinImageSize is the minimum total size of the pulled images by default
+var DefaultMinImageSize = resource.MustParse("1Gi")
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_PreferLocalImageNodesArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_PreferLocalImageNodesArgs(obj runtime.Object) {
+	args := obj.(*PreferLocalImageNodesArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.MinImageSize == nil {
+		minImageSize := DefaultMinImageSize.DeepCopy()
+		args.MinImageSize = &minImageSize
+	}
+	if args.MaxEvictionsPerCycle == nil {
+		args.MaxEvictionsPerCycle = utilptr.To[uint](5)
+	}
+	if args.MaxEvictionsPerWorkload == nil {
+		args.MaxEvictionsPerWorkload = utilptr.To[uint](1)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preferlocalimagenodes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestSetDefaults_PreferLocalImageNodesArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "PreferLocalImageNodesArgs empty",
+			in:   &PreferLocalImageNodesArgs{},
+			want: &PreferLocalImageNodesArgs{
+				MinImageSize:            utilptr.To(resource.MustParse("1Gi")),
+				MaxEvictionsPerCycle:    utilptr.To[uint](5),
+				MaxEvictionsPerWorkload: utilptr.To[uint](1),
+			},
+		},
+		{
+			name: "PreferLocalImageNodesArgs with value",
+			in: &PreferLocalImageNodesArgs{
+				MinImageSize:            utilptr.To(resource.MustParse("500Mi")),
+				MaxEvictionsPerCycle:    utilptr.To[uint](10),
+				MaxEvictionsPerWorkload: utilptr.To[uint](2),
+			},
+			want: &PreferLocalImageNodesArgs{
+				MinImageSize:            utilptr.To(resource.MustParse("500Mi")),
+				MaxEvictionsPerCycle:    utilptr.To[uint](10),
+				MaxEvictionsPerWorkload: utilptr.To[uint](2),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_PreferLocalImageNodesArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preferlocalimagenodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "PreferLocalImageNodes"
+
+// PreferLocalImageNodes evicts pods still pulling very large images on a node lacking them in its
+// image cache while other nodes the pods fit on already have all of them cached, as reported in
+// the images of the node status. The replacement pods are expected to land on these nodes thanks
+// to the image locality scoring of the scheduler, saving registry bandwidth and startup time.
+// Only pods whose missing images add up to at least the minimum image size are considered, the
+// largest savings first, and the evictions are bounded per cycle and per workload.
+type PreferLocalImageNodes struct {
+	handle    frameworktypes.Handle
+	args      *PreferLocalImageNodesArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &PreferLocalImageNodes{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	imageArgs, ok := args.(*PreferLocalImageNodesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type PreferLocalImageNodesArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if imageArgs.Namespaces != nil {
+		includedNamespaces = sets.New(imageArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(imageArgs.Namespaces.Exclude...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(imageArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &PreferLocalImageNodes{
+		handle:    handle,
+		args:      imageArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *PreferLocalImageNodes) Name() string {
+	return PluginName
+}
+
+// normalizedImageName appends the latest tag to image names without tag nor digest, the same way
+// the image locality scoring of the scheduler does
+func normalizedImageName(name string) string {
+	if strings.LastIndex(name, ":") <= strings.LastIndex(name, "/") {
+		name = name + ":latest"
+	}
+	return name
+}
+
+// imageCache indexes the images cached on the nodes
+type imageCache struct {
+	sizes map[string]int64
+	nodes map[string]sets.Set[string]
+}
+
+func newImageCache(nodes []*v1.Node) *imageCache {
+	cache := &imageCache{
+		sizes: map[string]int64{},
+		nodes: map[string]sets.Set[string]{},
+	}
+	for _, node := range nodes {
+		images := sets.New[string]()
+		for _, image := range node.Status.Images {
+			for _, name := range image.Names {
+				name = normalizedImageName(name)
+				images.Insert(name)
+				if image.SizeBytes > cache.sizes[name] {
+					cache.sizes[name] = image.SizeBytes
+				}
+			}
+		}
+		cache.nodes[node.Name] = images
+	}
+	return cache
+}
+
+// candidate is a pod pulling images cached on other nodes
+type candidate struct {
+	pod         *v1.Pod
+	missingSize int64
+	nodes       []*v1.Node
+}
+
+// pullingImages returns the images the containers of the pod are still waiting for
+func pullingImages(pod *v1.Pod) sets.Set[string] {
+	images := sets.New[string]()
+	statuses := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting != nil && status.ImageID == "" {
+			images.Insert(normalizedImageName(status.Image))
+		}
+	}
+	return images
+}
+
+// candidateOf returns the pod as a candidate when the images it is pulling are cached on other nodes
+func (c *imageCache) candidateOf(pod *v1.Pod, nodes []*v1.Node) (candidate, bool) {
+	missing := pullingImages(pod).Difference(c.nodes[pod.Spec.NodeName])
+	var missingSize int64
+	for image := range missing {
+		size, ok := c.sizes[image]
+		if !ok {
+			// The image is cached nowhere, no node would start the pod faster
+			return candidate{}, false
+		}
+		missingSize += size
+	}
+	if missing.Len() == 0 {
+		return candidate{}, false
+	}
+
+	var cachingNodes []*v1.Node
+	for _, node := range nodes {
+		if node.Name != pod.Spec.NodeName && c.nodes[node.Name].IsSuperset(missing) {
+			cachingNodes = append(cachingNodes, node)
+		}
+	}
+	return candidate{pod: pod, missingSize: missingSize, nodes: cachingNodes}, len(cachingNodes) > 0
+}
+
+// workloadOf returns the uid of the controller of the pod, or the uid of the pod itself
+func workloadOf(pod *v1.Pod) types.UID {
+	if controllerRef := metav1.GetControllerOf(pod); controllerRef != nil {
+		return controllerRef.UID
+	}
+	return pod.UID
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *PreferLocalImageNodes) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	pods, err := podutil.ListPodsOnNodes(nodes, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing all pods: %v", err),
+		}
+	}
+
+	cache := newImageCache(nodes)
+	minImageSize := d.args.MinImageSize.Value()
+	var candidates []candidate
+	for _, pod := range pods {
+		c, ok := cache.candidateOf(pod, nodes)
+		if !ok || c.missingSize < minImageSize {
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+
+	// The largest savings first, in a stable order so all the pods make progress over the cycles
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].missingSize != candidates[j].missingSize {
+			return candidates[i].missingSize > candidates[j].missingSize
+		}
+		if candidates[i].pod.Namespace != candidates[j].pod.Namespace {
+			return candidates[i].pod.Namespace < candidates[j].pod.Namespace
+		}
+		return candidates[i].pod.Name < candidates[j].pod.Name
+	})
+
+	var evicted uint
+	evictedOfWorkload := map[types.UID]uint{}
+loop:
+	for _, c := range candidates {
+		if evicted >= *d.args.MaxEvictionsPerCycle {
+			break
+		}
+		workload := workloadOf(c.pod)
+		if evictedOfWorkload[workload] >= *d.args.MaxEvictionsPerWorkload {
+			continue
+		}
+		if !nodeutil.PodFitsAnyNode(d.handle.GetPodsAssignedToNodeFunc(), c.pod, c.nodes) {
+			logger.V(2).Info("Pod does not fit any node caching its images", "pod", klog.KObj(c.pod))
+			continue
+		}
+		if !d.handle.Evictor().PreEvictionFilter(c.pod) {
+			continue
+		}
+		logger.V(2).Info("Evicting pod pulling images cached on other nodes", "pod", klog.KObj(c.pod), "node", c.pod.Spec.NodeName, "missingImageBytes", c.missingSize)
+		err := d.handle.Evictor().Evict(ctx, c.pod, evictions.EvictOptions{StrategyName: PluginName})
+		if err == nil {
+			evicted++
+			evictedOfWorkload[workload]++
+			continue
+		}
+		switch err.(type) {
+		case *evictions.EvictionNodeLimitError:
+			continue
+		case *evictions.EvictionTotalLimitError:
+			break loop
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	return nil
+}
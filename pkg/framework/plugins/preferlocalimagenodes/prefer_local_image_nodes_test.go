@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preferlocalimagenodes
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	"sigs.k8s.io/descheduler/test"
+)
+
+const gib = int64(1 << 30)
+
+func TestPreferLocalImageNodes(t *testing.T) {
+	withImages := func(images ...v1.ContainerImage) func(*v1.Node) {
+		return func(node *v1.Node) {
+			node.Status.Images = images
+		}
+	}
+	bigImage := v1.ContainerImage{Names: []string{"registry.example.com/model@sha256:1234", "registry.example.com/model:v1"}, SizeBytes: 4 * gib}
+	hugeImage := v1.ContainerImage{Names: []string{"registry.example.com/dataset:latest"}, SizeBytes: 8 * gib}
+	smallImage := v1.ContainerImage{Names: []string{"registry.example.com/app:v1"}, SizeBytes: gib / 2}
+
+	bareNode := test.BuildTestNode("bare", 2000, 3000, 10, nil)
+	cachingNode := test.BuildTestNode("caching", 2000, 3000, 10, withImages(bigImage, hugeImage, smallImage))
+	fullNode := test.BuildTestNode("full", 100, 3000, 10, withImages(bigImage, hugeImage, smallImage))
+
+	buildPod := func(name, owner string, images ...string) *v1.Pod {
+		return test.BuildTestPod(name, 500, 0, "bare", func(pod *v1.Pod) {
+			pod.UID = types.UID(name)
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "v1", Name: owner, UID: types.UID(owner), Controller: utilptr.To(true)}}
+			pod.Status.Phase = v1.PodPending
+			for i, image := range images {
+				pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{
+					Name:  string(rune('a' + i)),
+					Image: image,
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+				})
+			}
+		})
+	}
+	pulled := func(pod *v1.Pod) *v1.Pod {
+		for i := range pod.Status.ContainerStatuses {
+			pod.Status.ContainerStatuses[i].ImageID = "registry.example.com/model@sha256:1234"
+			pod.Status.ContainerStatuses[i].State = v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+		}
+		pod.Status.Phase = v1.PodRunning
+		return pod
+	}
+
+	tests := []struct {
+		description             string
+		pods                    []*v1.Pod
+		nodes                   []*v1.Node
+		minImageSize            string
+		maxEvictionsPerCycle    uint
+		maxEvictionsPerWorkload uint
+		maxPodsToEvictTotal     *uint
+		expectedEvicted         []string
+	}{
+		{
+			description: "pods pulling large images cached on another node are evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", "registry.example.com/model:v1"),
+				buildPod("p2", "rs2", "registry.example.com/dataset"),
+			},
+			nodes:                   []*v1.Node{bareNode, cachingNode},
+			minImageSize:            "1Gi",
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 1,
+			expectedEvicted:         []string{"p1", "p2"},
+		},
+		{
+			description: "pods pulling images below the minimum size are not evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", "registry.example.com/app:v1"),
+				buildPod("p2", "rs2", "registry.example.com/model:v1", "registry.example.com/app:v1"),
+			},
+			nodes:                   []*v1.Node{bareNode, cachingNode},
+			minImageSize:            "4Gi",
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 1,
+			expectedEvicted:         []string{"p2"},
+		},
+		{
+			description: "pods whose images are pulled or cached nowhere are not evicted",
+			pods: []*v1.Pod{
+				pulled(buildPod("p1", "rs1", "registry.example.com/model:v1")),
+				buildPod("p2", "rs2", "registry.example.com/model:v1", "registry.example.com/unknown:v1"),
+			},
+			nodes:                   []*v1.Node{bareNode, cachingNode},
+			minImageSize:            "1Gi",
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 1,
+			expectedEvicted:         nil,
+		},
+		{
+			description: "pods not fitting the nodes caching their images are not evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", "registry.example.com/model:v1"),
+			},
+			nodes:                   []*v1.Node{bareNode, fullNode},
+			minImageSize:            "1Gi",
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 1,
+			expectedEvicted:         nil,
+		},
+		{
+			description: "evictions are limited per workload and per cycle, largest savings first",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", "registry.example.com/model:v1"),
+				buildPod("p2", "rs1", "registry.example.com/dataset"),
+				buildPod("p3", "rs2", "registry.example.com/model:v1", "registry.example.com/dataset"),
+				buildPod("p4", "rs3", "registry.example.com/model:v1"),
+				buildPod("p5", "rs4", "registry.example.com/model:v1"),
+			},
+			nodes:                   []*v1.Node{bareNode, cachingNode},
+			minImageSize:            "1Gi",
+			maxEvictionsPerCycle:    3,
+			maxEvictionsPerWorkload: 1,
+			expectedEvicted:         []string{"p2", "p3", "p4"},
+		},
+		{
+			description: "the total eviction limit stops the evictions",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", "registry.example.com/model:v1"),
+				buildPod("p2", "rs2", "registry.example.com/dataset"),
+			},
+			nodes:                   []*v1.Node{bareNode, cachingNode},
+			minImageSize:            "1Gi",
+			maxEvictionsPerCycle:    5,
+			maxEvictionsPerWorkload: 1,
+			maxPodsToEvictTotal:     utilptr.To[uint](1),
+			expectedEvicted:         []string{"p2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			minImageSize := resource.MustParse(tc.minImageSize)
+			scenario.New().
+				WithNodes(tc.nodes...).
+				WithPods(tc.pods...).
+				WithEvictionOptions(evictions.NewOptions().WithMaxPodsToEvictTotal(tc.maxPodsToEvictTotal)).
+				ExpectEvicted(tc.expectedEvicted...).
+				Run(t, New, &PreferLocalImageNodesArgs{
+					MinImageSize:            &minImageSize,
+					MaxEvictionsPerCycle:    &tc.maxEvictionsPerCycle,
+					MaxEvictionsPerWorkload: &tc.maxEvictionsPerWorkload,
+				})
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preferlocalimagenodes
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PreferLocalImageNodesArgs holds arguments used to configure PreferLocalImageNodes plugin.
+type PreferLocalImageNodesArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// MinImageSize is the minimum total size of the images a pod is still pulling for it to be evicted
+	MinImageSize *resource.Quantity `json:"minImageSize,omitempty"`
+	// MaxEvictionsPerCycle caps the pods evicted in a descheduling cycle
+	MaxEvictionsPerCycle *uint `json:"maxEvictionsPerCycle,omitempty"`
+	// MaxEvictionsPerWorkload caps the pods of a single workload evicted in a descheduling cycle
+	MaxEvictionsPerWorkload *uint `json:"maxEvictionsPerWorkload,omitempty"`
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preferlocalimagenodes
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidatePreferLocalImageNodesArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *PreferLocalImageNodesArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &PreferLocalImageNodesArgs{
+				MinImageSize: utilptr.To(resource.MustParse("2Gi")),
+			},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &PreferLocalImageNodesArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "negative minimum image size, expects error",
+			args: &PreferLocalImageNodesArgs{
+				MinImageSize: utilptr.To(resource.MustParse("-1Gi")),
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidatePreferLocalImageNodesArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rebalancedaemonsetsurge
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "RebalanceDaemonSetSurge"
+
+// RebalanceDaemonSetSurge cleans up after DaemonSet rolling updates. It evicts DaemonSet pods
+// left behind next to a ready up to date pod of the same DaemonSet on a node (stuck old generation
+// pods or duplicates, typically after a surge update), and evicts regular pods from nodes where
+// a DaemonSet pod can no longer be scheduled because of insufficient resources.
+//
+// Evicting DaemonSet pods requires the DefaultEvictor to be configured with evictDaemonSetPods.
+type RebalanceDaemonSetSurge struct {
+	handle          frameworktypes.Handle
+	args            *RebalanceDaemonSetSurgeArgs
+	podFilter       podutil.FilterFunc
+	podLister       listersv1.PodLister
+	daemonSetLister appslisters.DaemonSetLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &RebalanceDaemonSetSurge{}
+
+var _ frameworktypes.AllPodsRequirer = &RebalanceDaemonSetSurgeArgs{}
+
+// RequiresAllPods tells the DaemonSet pods, usually left out of a restricted pod cache, are the ones processed
+func (a *RebalanceDaemonSetSurgeArgs) RequiresAllPods() bool {
+	return true
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	surgeArgs, ok := args.(*RebalanceDaemonSetSurgeArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RebalanceDaemonSetSurgeArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if surgeArgs.Namespaces != nil {
+		includedNamespaces = sets.New(surgeArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(surgeArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(surgeArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RebalanceDaemonSetSurge{
+		handle:    handle,
+		args:      surgeArgs,
+		podFilter: podFilter,
+		podLister: handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+		// The DaemonSets are read from the cluster, the cached client of the dry run mode holds none
+		daemonSetLister: handle.ClusterInformerFactory().Apps().V1().DaemonSets().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RebalanceDaemonSetSurge) Name() string {
+	return PluginName
+}
+
+// daemonSetOwner returns the UID of the DaemonSet controlling the pod, if any
+func daemonSetOwner(pod *v1.Pod) (types.UID, bool) {
+	for _, ownerRef := range podutil.OwnerRef(pod) {
+		if ownerRef.Kind == "DaemonSet" {
+			return ownerRef.UID, true
+		}
+	}
+	return "", false
+}
+
+// isUpToDate returns true if the pod was created from the current template of the DaemonSet.
+// Pods or DaemonSets without a template generation are considered up to date.
+func isUpToDate(pod *v1.Pod, ds *appsv1.DaemonSet) bool {
+	generation, ok := ds.Annotations[appsv1.DeprecatedTemplateGeneration]
+	if !ok {
+		return true
+	}
+	podGeneration, ok := pod.Labels[extensionsv1beta1.DaemonSetTemplateGenerationKey]
+	return !ok || podGeneration == generation
+}
+
+// surplusDaemonSetPods returns the DaemonSet pods of a node which are no longer needed.
+// For every DaemonSet with more than one pod on the node, the oldest ready up to date pod
+// is kept and all the other pods are returned. Nothing is returned for a DaemonSet until
+// one of its up to date pods is ready, as the update is still in progress.
+func surplusDaemonSetPods(pods []*v1.Pod, daemonSets map[types.UID]*appsv1.DaemonSet) []*v1.Pod {
+	podsByOwner := map[types.UID][]*v1.Pod{}
+	for _, pod := range pods {
+		if utils.IsPodTerminating(pod) {
+			continue
+		}
+		if uid, ok := daemonSetOwner(pod); ok {
+			if _, exists := daemonSets[uid]; exists {
+				podsByOwner[uid] = append(podsByOwner[uid], pod)
+			}
+		}
+	}
+
+	var surplus []*v1.Pod
+	for uid, ownedPods := range podsByOwner {
+		if len(ownedPods) < 2 {
+			continue
+		}
+		podutil.SortPodsBasedOnAge(ownedPods)
+		var kept *v1.Pod
+		for _, pod := range ownedPods {
+			if utils.IsPodReady(pod) && isUpToDate(pod, daemonSets[uid]) {
+				kept = pod
+				break
+			}
+		}
+		if kept == nil {
+			continue
+		}
+		for _, pod := range ownedPods {
+			if pod != kept {
+				surplus = append(surplus, pod)
+			}
+		}
+	}
+	podutil.SortPodsBasedOnAge(surplus)
+	podutil.SortPodsBasedOnEvictionCost(surplus)
+	return surplus
+}
+
+// resourceShortage returns the amount of each resource missing on the node for the pod to fit.
+// Only resources with a positive shortage are returned.
+func resourceShortage(pod *v1.Pod, node *v1.Node, podsOnNode []*v1.Pod) map[v1.ResourceName]*resource.Quantity {
+	requests, _ := utils.PodRequestsAndLimits(pod)
+	requests[v1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+	resourceNames := make([]v1.ResourceName, 0, len(requests))
+	for name := range requests {
+		resourceNames = append(resourceNames, name)
+	}
+
+	used := nodeutil.NodeUtilization(podsOnNode, resourceNames)
+	shortage := map[v1.ResourceName]*resource.Quantity{}
+	for _, name := range resourceNames {
+		missing := requests[name].DeepCopy()
+		missing.Sub(node.Status.Allocatable[name])
+		missing.Add(*used[name])
+		if missing.Sign() > 0 {
+			shortage[name] = &missing
+		}
+	}
+	return shortage
+}
+
+// victimsToFit selects the pods to evict for the shortage to be covered, lowest priority first.
+// Nothing is returned when evicting all the candidates would not be enough.
+func victimsToFit(shortage map[v1.ResourceName]*resource.Quantity, candidates []*v1.Pod) []*v1.Pod {
+	podutil.SortPodsBasedOnPriorityLowToHigh(candidates)
+	var victims []*v1.Pod
+	for _, pod := range candidates {
+		if len(shortage) == 0 {
+			break
+		}
+		requests, _ := utils.PodRequestsAndLimits(pod)
+		requests[v1.ResourcePods] = *resource.NewQuantity(1, resource.DecimalSI)
+		helps := false
+		for name, missing := range shortage {
+			if request, ok := requests[name]; ok && !request.IsZero() {
+				helps = true
+				missing.Sub(request)
+				if missing.Sign() <= 0 {
+					delete(shortage, name)
+				}
+			}
+		}
+		if helps {
+			victims = append(victims, pod)
+		}
+	}
+	if len(shortage) > 0 {
+		return nil
+	}
+	return victims
+}
+
+// pendingDaemonSetPods returns the unschedulable pods of the given DaemonSets, grouped by the node they target
+func (d *RebalanceDaemonSetSurge) pendingDaemonSetPods(daemonSets map[types.UID]*appsv1.DaemonSet) (map[string][]*v1.Pod, error) {
+	pods, err := d.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	pending := map[string][]*v1.Pod{}
+	for _, pod := range pods {
+		uid, ok := daemonSetOwner(pod)
+		if !ok || daemonSets[uid] == nil || !utils.IsPodUnschedulable(pod) {
+			continue
+		}
+		if nodeName := utils.DaemonSetPodTargetNode(pod); nodeName != "" {
+			pending[nodeName] = append(pending[nodeName], pod)
+		}
+	}
+	return pending, nil
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RebalanceDaemonSetSurge) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	daemonSetList, err := d.daemonSetLister.List(labels.Everything())
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing daemon sets: %v", err),
+		}
+	}
+	if len(daemonSetList) == 0 {
+		return nil
+	}
+	daemonSets := map[types.UID]*appsv1.DaemonSet{}
+	for _, ds := range daemonSetList {
+		daemonSets[ds.UID] = ds
+	}
+
+	pendingPods, err := d.pendingDaemonSetPods(daemonSets)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pending pods: %v", err),
+		}
+	}
+
+	for _, node := range nodes {
+		logger.V(1).Info("Processing node", "node", klog.KObj(node))
+		podsOnNode, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), nil)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+
+		var surplus []*v1.Pod
+		for _, pod := range surplusDaemonSetPods(podsOnNode, daemonSets) {
+			if d.podFilter(pod) {
+				logger.V(2).Info("DaemonSet pod is superseded by an up to date pod on the node", "pod", klog.KObj(pod), "node", klog.KObj(node))
+				surplus = append(surplus, pod)
+			}
+		}
+		if !evictions.EvictPods(ctx, d.handle.Evictor().Evict, surplus, evictions.EvictOptions{StrategyName: PluginName}) {
+			return nil
+		}
+
+		for _, pending := range pendingPods[node.Name] {
+			if !d.makeRoomFor(ctx, pending, node) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// makeRoomFor evicts regular pods from the node a pending DaemonSet pod does not fit on anymore.
+// Only pods with a priority not higher than the one of the DaemonSet pod are evicted, pods with
+// a lower priority are expected to be preempted by the scheduler already. It returns false when
+// the total eviction limit got reached.
+func (d *RebalanceDaemonSetSurge) makeRoomFor(ctx context.Context, pending *v1.Pod, node *v1.Node) bool {
+	logger := klog.FromContext(ctx)
+	if ok, err := utils.PodMatchNodeSelector(pending, node); err != nil || !ok {
+		return true
+	}
+	if !utils.TolerationsTolerateTaintsWithFilter(pending.Spec.Tolerations, node.Spec.Taints, func(taint *v1.Taint) bool {
+		return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
+	}) {
+		return true
+	}
+
+	// Re-list the pods since surplus pods may have been evicted already
+	podsOnNode, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), func(pod *v1.Pod) bool {
+		return !utils.IsPodTerminating(pod)
+	})
+	if err != nil {
+		logger.Error(err, "Unable to list pods on a node", "node", klog.KObj(node))
+		return true
+	}
+	shortage := resourceShortage(pending, node, podsOnNode)
+	if len(shortage) == 0 {
+		return true
+	}
+
+	priority := utils.GetPodPriority(pending)
+	var candidates []*v1.Pod
+	for _, pod := range podsOnNode {
+		if utils.IsDaemonsetPod(podutil.OwnerRef(pod)) || utils.GetPodPriority(pod) > priority {
+			continue
+		}
+		if d.podFilter(pod) {
+			candidates = append(candidates, pod)
+		}
+	}
+	victims := victimsToFit(shortage, candidates)
+	if len(victims) == 0 {
+		logger.V(2).Info("Unable to make room for a DaemonSet pod on the node", "pod", klog.KObj(pending), "node", klog.KObj(node))
+		return true
+	}
+	logger.V(2).Info("Making room for a DaemonSet pod on the node", "pod", klog.KObj(pending), "node", klog.KObj(node), "victims", len(victims))
+	return evictions.EvictPods(ctx, d.handle.Evictor().Evict, victims, evictions.EvictOptions{StrategyName: PluginName})
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rebalancedaemonsetsurge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildDaemonSet(name string, generation string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			UID:         types.UID("uid-" + name),
+			Annotations: map[string]string{appsv1.DeprecatedTemplateGeneration: generation},
+		},
+	}
+}
+
+func buildDaemonSetPod(name, nodeName string, ds *appsv1.DaemonSet, generation string, ready bool, age time.Duration, apply func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{Kind: "DaemonSet", APIVersion: "apps/v1", Name: ds.Name, UID: ds.UID, Controller: utilptr.To(true)},
+		}
+		pod.Labels = map[string]string{extensionsv1beta1.DaemonSetTemplateGenerationKey: generation}
+		pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		status := v1.ConditionFalse
+		if ready {
+			status = v1.ConditionTrue
+		}
+		pod.Status.Phase = v1.PodRunning
+		pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: status}}
+		if apply != nil {
+			apply(pod)
+		}
+	})
+}
+
+func buildPendingDaemonSetPod(name, nodeName string, ds *appsv1.DaemonSet, cpu int64, priority int32) *v1.Pod {
+	return buildDaemonSetPod(name, "", ds, "1", false, time.Minute, func(pod *v1.Pod) {
+		pod.Spec.Containers[0].Resources.Requests[v1.ResourceCPU] = *resource.NewMilliQuantity(cpu, resource.DecimalSI)
+		pod.Spec.Priority = utilptr.To(priority)
+		pod.Spec.Affinity = &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{
+							MatchFields: []v1.NodeSelectorRequirement{
+								{Key: metav1.ObjectNameField, Operator: v1.NodeSelectorOpIn, Values: []string{nodeName}},
+							},
+						},
+					},
+				},
+			},
+		}
+		pod.Status.Phase = v1.PodPending
+		pod.Status.Conditions = []v1.PodCondition{
+			{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable},
+		}
+	})
+}
+
+func buildRegularPod(name, nodeName string, cpu int64, priority int32) *v1.Pod {
+	return test.BuildTestPod(name, cpu, 0, nodeName, func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Priority = utilptr.To(priority)
+		pod.Status.Phase = v1.PodRunning
+	})
+}
+
+func TestRebalanceDaemonSetSurge(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	ds := buildDaemonSet("ds", "2")
+
+	tests := []struct {
+		description          string
+		objects              []runtime.Object
+		nodes                []*v1.Node
+		evictDaemonSetPods   bool
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Old generation pod next to a ready up to date pod is evicted",
+			objects: []runtime.Object{
+				ds,
+				buildDaemonSetPod("old", "n1", ds, "1", true, time.Hour, nil),
+				buildDaemonSetPod("new", "n1", ds, "2", true, time.Minute, nil),
+			},
+			nodes:                []*v1.Node{node1},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Duplicated up to date pods, the oldest ready one is kept",
+			objects: []runtime.Object{
+				ds,
+				buildDaemonSetPod("new-1", "n1", ds, "2", true, time.Hour, nil),
+				buildDaemonSetPod("new-2", "n1", ds, "2", true, time.Minute, nil),
+				buildDaemonSetPod("new-3", "n1", ds, "2", false, time.Second, nil),
+			},
+			nodes:                []*v1.Node{node1},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Surge in progress, up to date pod not ready yet",
+			objects: []runtime.Object{
+				ds,
+				buildDaemonSetPod("old", "n1", ds, "1", true, time.Hour, nil),
+				buildDaemonSetPod("new", "n1", ds, "2", false, time.Minute, nil),
+			},
+			nodes:                []*v1.Node{node1},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Single pods per node are left alone",
+			objects: []runtime.Object{
+				ds,
+				buildDaemonSetPod("old", "n1", ds, "1", true, time.Hour, nil),
+				buildDaemonSetPod("new", "n2", ds, "2", true, time.Minute, nil),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "DaemonSet pods are not evicted unless the evictor allows it",
+			objects: []runtime.Object{
+				ds,
+				buildDaemonSetPod("old", "n1", ds, "1", true, time.Hour, nil),
+				buildDaemonSetPod("new", "n1", ds, "2", true, time.Minute, nil),
+			},
+			nodes:                []*v1.Node{node1},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Lowest priority pod is evicted to make room for a pending DaemonSet pod",
+			objects: []runtime.Object{
+				ds,
+				buildPendingDaemonSetPod("pending", "n1", ds, 500, 100),
+				buildRegularPod("p1", "n1", 1000, 100),
+				buildRegularPod("p2", "n1", 800, 50),
+			},
+			nodes:                []*v1.Node{node1},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Several pods are evicted until the pending DaemonSet pod fits",
+			objects: []runtime.Object{
+				ds,
+				buildPendingDaemonSetPod("pending", "n1", ds, 1000, 100),
+				buildRegularPod("p1", "n1", 600, 100),
+				buildRegularPod("p2", "n1", 600, 50),
+				buildRegularPod("p3", "n1", 600, 0),
+			},
+			nodes:                []*v1.Node{node1},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Pods with a higher priority than the pending DaemonSet pod are not evicted",
+			objects: []runtime.Object{
+				ds,
+				buildPendingDaemonSetPod("pending", "n1", ds, 500, 0),
+				buildRegularPod("p1", "n1", 1000, 100),
+				buildRegularPod("p2", "n1", 800, 50),
+			},
+			nodes:                []*v1.Node{node1},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Nothing is evicted when the pending DaemonSet pod would not fit anyway",
+			objects: []runtime.Object{
+				ds,
+				buildPendingDaemonSetPod("pending", "n1", ds, 3000, 100),
+				buildRegularPod("p1", "n1", 1000, 100),
+				buildRegularPod("p2", "n1", 800, 50),
+			},
+			nodes:                []*v1.Node{node1},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pending DaemonSet pod targeting a node not being processed",
+			objects: []runtime.Object{
+				ds,
+				buildPendingDaemonSetPod("pending", "n1", ds, 500, 100),
+				buildRegularPod("p1", "n1", 1000, 100),
+				buildRegularPod("p2", "n1", 800, 50),
+			},
+			nodes:                []*v1.Node{node2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of unknown DaemonSets are ignored",
+			objects: []runtime.Object{
+				buildDaemonSetPod("old", "n1", ds, "1", true, time.Hour, nil),
+				buildDaemonSetPod("new", "n1", ds, "2", true, time.Minute, nil),
+			},
+			nodes:                []*v1.Node{node1},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{node1, node2}
+			objs = append(objs, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{EvictDaemonSetPods: tc.evictDaemonSetPods},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&RebalanceDaemonSetSurgeArgs{}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			// Start the DaemonSet informer requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -32,18 +32,24 @@ func TestSetDefaults_RemoveDuplicatesArgs(t *testing.T) {
 			name: "RemoveDuplicatesArgs empty",
 			in:   &RemoveDuplicatesArgs{},
 			want: &RemoveDuplicatesArgs{
-				Namespaces:        nil,
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: nil,
+				},
 				ExcludeOwnerKinds: nil,
 			},
 		},
 		{
 			name: "RemoveDuplicatesArgs with value",
 			in: &RemoveDuplicatesArgs{
-				Namespaces:        &api.Namespaces{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{},
+				},
 				ExcludeOwnerKinds: []string{"ReplicaSet"},
 			},
 			want: &RemoveDuplicatesArgs{
-				Namespaces:        &api.Namespaces{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{},
+				},
 				ExcludeOwnerKinds: []string{"ReplicaSet"},
 			},
 		},
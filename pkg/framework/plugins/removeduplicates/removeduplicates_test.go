@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
 
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
 	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
@@ -294,7 +295,7 @@ func TestFindDuplicatePods(t *testing.T) {
 			}
 			fakeClient := fake.NewSimpleClientset(objs...)
 
-			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{NodeFit: testCase.nodefit}, nil)
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, fakeClient, nil, defaultevictor.DefaultEvictorArgs{NodeFit: utilptr.To(testCase.nodefit)}, nil)
 			if err != nil {
 				t.Fatalf("Unable to initialize a framework handle: %v", err)
 			}
@@ -502,7 +503,8 @@ func TestRemoveDuplicatesUniformly(t *testing.T) {
 				test.BuildTestPod("p18", 100, 0, "n2", setTwoRSOwnerRef),
 				test.BuildTestPod("p19", 100, 0, "n3", setTwoRSOwnerRef),
 			},
-			expectedEvictedPodCount: 4,
+			// Both replica sets select the same pods, which are evicted only once
+			expectedEvictedPodCount: 2,
 			nodes: []*v1.Node{
 				test.BuildTestNode("n1", 2000, 3000, 10, nil),
 				test.BuildTestNode("n2", 2000, 3000, 10, nil),
@@ -16,8 +16,10 @@ func TestValidateRemovePodsViolatingNodeTaintsArgs(t *testing.T) {
 			description: "valid namespace args, no errors",
 			args: &RemoveDuplicatesArgs{
 				ExcludeOwnerKinds: []string{"Job"},
-				Namespaces: &api.Namespaces{
-					Include: []string{"default"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+					},
 				},
 			},
 			expectError: false,
@@ -26,9 +28,11 @@ func TestValidateRemovePodsViolatingNodeTaintsArgs(t *testing.T) {
 			description: "invalid namespaces args, expects error",
 			args: &RemoveDuplicatesArgs{
 				ExcludeOwnerKinds: []string{"Job"},
-				Namespaces: &api.Namespaces{
-					Include: []string{"default"},
-					Exclude: []string{"kube-system"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+						Exclude: []string{"kube-system"},
+					},
 				},
 			},
 			expectError: true,
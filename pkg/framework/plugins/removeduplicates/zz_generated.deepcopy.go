@@ -23,18 +23,13 @@ package removeduplicates
 
 import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
-	api "sigs.k8s.io/descheduler/pkg/api"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoveDuplicatesArgs) DeepCopyInto(out *RemoveDuplicatesArgs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = new(api.Namespaces)
-		(*in).DeepCopyInto(*out)
-	}
+	in.FilteringArgs.DeepCopyInto(&out.FilteringArgs)
 	if in.ExcludeOwnerKinds != nil {
 		in, out := &in.ExcludeOwnerKinds, &out.ExcludeOwnerKinds
 		*out = make([]string, len(*in))
@@ -34,8 +34,10 @@ func TestSetDefaults_RemoveFailedPodsArgs(t *testing.T) {
 			name: "RemoveFailedPodsArgs empty",
 			in:   &RemoveFailedPodsArgs{},
 			want: &RemoveFailedPodsArgs{
-				Namespaces:              nil,
-				LabelSelector:           nil,
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    nil,
+					LabelSelector: nil,
+				},
 				ExcludeOwnerKinds:       nil,
 				MinPodLifetimeSeconds:   nil,
 				Reasons:                 nil,
@@ -45,16 +47,20 @@ func TestSetDefaults_RemoveFailedPodsArgs(t *testing.T) {
 		{
 			name: "RemoveFailedPodsArgs with value",
 			in: &RemoveFailedPodsArgs{
-				Namespaces:              &api.Namespaces{},
-				LabelSelector:           &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 				ExcludeOwnerKinds:       []string{"ReplicaSet"},
 				MinPodLifetimeSeconds:   utilptr.To[uint](0),
 				Reasons:                 []string{"reason"},
 				IncludingInitContainers: true,
 			},
 			want: &RemoveFailedPodsArgs{
-				Namespaces:              &api.Namespaces{},
-				LabelSelector:           &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 				ExcludeOwnerKinds:       []string{"ReplicaSet"},
 				MinPodLifetimeSeconds:   utilptr.To[uint](0),
 				Reasons:                 []string{"reason"},
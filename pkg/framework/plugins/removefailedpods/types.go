@@ -25,11 +25,10 @@ import (
 type RemoveFailedPodsArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
-	Namespaces              *api.Namespaces       `json:"namespaces"`
-	LabelSelector           *metav1.LabelSelector `json:"labelSelector"`
-	ExcludeOwnerKinds       []string              `json:"excludeOwnerKinds"`
-	MinPodLifetimeSeconds   *uint                 `json:"minPodLifetimeSeconds"`
-	Reasons                 []string              `json:"reasons"`
-	ExitCodes               []int32               `json:"exitCodes"`
-	IncludingInitContainers bool                  `json:"includingInitContainers"`
+	api.FilteringArgs       `json:",inline"`
+	ExcludeOwnerKinds       []string `json:"excludeOwnerKinds"`
+	MinPodLifetimeSeconds   *uint    `json:"minPodLifetimeSeconds"`
+	Reasons                 []string `json:"reasons"`
+	ExitCodes               []int32  `json:"exitCodes"`
+	IncludingInitContainers bool     `json:"includingInitContainers"`
 }
@@ -17,8 +17,10 @@ func TestValidateRemoveFailedPodsArgs(t *testing.T) {
 		{
 			description: "valid namespace args, no errors",
 			args: &RemoveFailedPodsArgs{
-				Namespaces: &api.Namespaces{
-					Include: []string{"default"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+					},
 				},
 				ExcludeOwnerKinds:     []string{"Job"},
 				Reasons:               []string{"ReasonDoesNotMatch"},
@@ -29,9 +31,11 @@ func TestValidateRemoveFailedPodsArgs(t *testing.T) {
 		{
 			description: "invalid namespaces args, expects error",
 			args: &RemoveFailedPodsArgs{
-				Namespaces: &api.Namespaces{
-					Include: []string{"default"},
-					Exclude: []string{"kube-system"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+						Exclude: []string{"kube-system"},
+					},
 				},
 			},
 			expectError: true,
@@ -39,8 +43,10 @@ func TestValidateRemoveFailedPodsArgs(t *testing.T) {
 		{
 			description: "valid label selector args, no errors",
 			args: &RemoveFailedPodsArgs{
-				LabelSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{"role.kubernetes.io/node": ""},
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"role.kubernetes.io/node": ""},
+					},
 				},
 			},
 			expectError: false,
@@ -48,16 +54,45 @@ func TestValidateRemoveFailedPodsArgs(t *testing.T) {
 		{
 			description: "invalid label selector args, expects errors",
 			args: &RemoveFailedPodsArgs{
-				LabelSelector: &metav1.LabelSelector{
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Operator: metav1.LabelSelectorOpIn,
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Operator: metav1.LabelSelectorOpIn,
+							},
 						},
 					},
 				},
 			},
 			expectError: true,
 		},
+		{
+			description: "valid node roles args, no errors",
+			args: &RemoveFailedPodsArgs{
+				FilteringArgs: api.FilteringArgs{
+					NodeRoles: &api.NodeRoles{Exclude: []string{"control-plane"}},
+				},
+			},
+			expectError: false,
+		},
+		{
+			description: "included and excluded node roles args, expects error",
+			args: &RemoveFailedPodsArgs{
+				FilteringArgs: api.FilteringArgs{
+					NodeRoles: &api.NodeRoles{Include: []string{"worker"}, Exclude: []string{"control-plane"}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid node role args, expects error",
+			args: &RemoveFailedPodsArgs{
+				FilteringArgs: api.FilteringArgs{
+					NodeRoles: &api.NodeRoles{Include: []string{"worker pool"}},
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -22,25 +22,14 @@ limitations under the License.
 package removefailedpods
 
 import (
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
-	api "sigs.k8s.io/descheduler/pkg/api"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoveFailedPodsArgs) DeepCopyInto(out *RemoveFailedPodsArgs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = new(api.Namespaces)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.LabelSelector != nil {
-		in, out := &in.LabelSelector, &out.LabelSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
+	in.FilteringArgs.DeepCopyInto(&out.FilteringArgs)
 	if in.ExcludeOwnerKinds != nil {
 		in, out := &in.ExcludeOwnerKinds, &out.ExcludeOwnerKinds
 		*out = make([]string, len(*in))
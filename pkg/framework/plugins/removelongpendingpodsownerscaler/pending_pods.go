@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removelongpendingpodsownerscaler
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	PluginName = "RemoveLongPendingPodsOwnerScaler"
+
+	// LongPendingPodsReason is the reason of the events emitted on the owners of long pending pods
+	LongPendingPodsReason = "LongPendingPods"
+
+	actionReported = "Reported"
+	actionDeleted  = "Deleted"
+)
+
+// RemoveLongPendingPodsOwnerScaler reports the owners of pods that could not be
+// scheduled for longer than a configured threshold. Such pods are not running,
+// so there is nothing to evict. Instead, an event is emitted on the owning workload
+// and, optionally, the pending pods get deleted so the owner recreates them
+// with a fresh scheduling attempt (e.g. after node labels changed).
+type RemoveLongPendingPodsOwnerScaler struct {
+	handle    frameworktypes.Handle
+	args      *RemoveLongPendingPodsOwnerScalerArgs
+	podFilter podutil.FilterFunc
+	podLister listersv1.PodLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemoveLongPendingPodsOwnerScaler{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	pendingPodsArgs, ok := args.(*RemoveLongPendingPodsOwnerScalerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemoveLongPendingPodsOwnerScalerArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if pendingPodsArgs.Namespaces != nil {
+		includedNamespaces = sets.New(pendingPodsArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(pendingPodsArgs.Namespaces.Exclude...)
+	}
+
+	// Pending pods are not assigned to any node, so PreEvictionFilter (e.g. node fit) does not apply here
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(pendingPodsArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	podFilter = podutil.WrapFilterFuncs(podFilter, func(pod *v1.Pod) bool {
+		podAgeSeconds := int(metav1.Now().Sub(pod.GetCreationTimestamp().Local()).Seconds())
+		return podAgeSeconds > int(*pendingPodsArgs.MinPendingSeconds)
+	})
+
+	return &RemoveLongPendingPodsOwnerScaler{
+		handle:    handle,
+		args:      pendingPodsArgs,
+		podFilter: podFilter,
+		podLister: handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemoveLongPendingPodsOwnerScaler) Name() string {
+	return PluginName
+}
+
+// schedulingMessage returns the message the scheduler left on the PodScheduled condition of a pod
+func schedulingMessage(pod *v1.Pod) string {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled {
+			return condition.Message
+		}
+	}
+	return ""
+}
+
+// Deschedule extension point implementation for the plugin.
+// Pending pods are not bound to any node, so the list of nodes is not used.
+func (d *RemoveLongPendingPodsOwnerScaler) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	pods, err := d.podLister.List(labels.Everything())
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pods: %v", err),
+		}
+	}
+
+	var pendingPods []*v1.Pod
+	for _, pod := range pods {
+		if utils.IsPodUnschedulable(pod) && d.podFilter(pod) {
+			pendingPods = append(pendingPods, pod)
+		}
+	}
+
+	// Without a controller nothing would recreate the pods, so pods without controller are ignored
+	for owner, ownedPods := range podutil.GroupByOwnerRef(pendingPods) {
+		logger.V(1).Info("Workload has long pending pods", "kind", owner.Kind, "owner", klog.KRef(owner.Namespace, owner.Name), "pendingPods", len(ownedPods))
+		action := actionReported
+		if d.args.DeletePendingPods {
+			action = actionDeleted
+			for _, pod := range ownedPods {
+				if err := d.handle.ClientSet().CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+					logger.Error(err, "Unable to delete pending pod", "pod", klog.KObj(pod))
+					continue
+				}
+				logger.V(1).Info("Deleted long pending pod", "pod", klog.KObj(pod))
+			}
+		}
+		d.handle.EventRecorder().Eventf(owner.ObjectReference(), ownedPods[0], v1.EventTypeWarning, LongPendingPodsReason, action,
+			"%d pod(s) pending for more than %vs: %s", len(ownedPods), *d.args.MinPendingSeconds, schedulingMessage(ownedPods[0]))
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removelongpendingpodsownerscaler
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildPendingPod(name, namespace, ownerName string, age time.Duration, apply func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, "", func(pod *v1.Pod) {
+		pod.Namespace = namespace
+		pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		if ownerName != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       ownerName,
+				UID:        types.UID(ownerName),
+				Controller: utilptr.To(true),
+			}}
+		}
+		pod.Status.Phase = v1.PodPending
+		pod.Status.Conditions = []v1.PodCondition{{
+			Type:    v1.PodScheduled,
+			Status:  v1.ConditionFalse,
+			Reason:  v1.PodReasonUnschedulable,
+			Message: "0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector.",
+		}}
+		if apply != nil {
+			apply(pod)
+		}
+	})
+}
+
+func TestRemoveLongPendingPodsOwnerScaler(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	p1 := buildPendingPod("p1", "default", "rs-1", 2*time.Hour, nil)
+	p2 := buildPendingPod("p2", "default", "rs-1", 2*time.Hour, nil)
+	p3 := buildPendingPod("p3", "default", "rs-2", 2*time.Hour, nil)
+	// pending, but not for long enough
+	p4 := buildPendingPod("p4", "default", "rs-3", time.Minute, nil)
+	// pending, but nothing would recreate it
+	p5 := buildPendingPod("p5", "default", "", 2*time.Hour, nil)
+	// pending, but waiting for its volumes rather than for a node
+	p6 := buildPendingPod("p6", "default", "rs-4", 2*time.Hour, func(pod *v1.Pod) {
+		pod.Status.Conditions = nil
+	})
+	p7 := buildPendingPod("p7", "kube-system", "rs-5", 2*time.Hour, nil)
+	// running pod
+	p8 := test.BuildTestPod("p8", 100, 0, node1.Name, test.SetRSOwnerRef)
+
+	tests := []struct {
+		description       string
+		pods              []*v1.Pod
+		args              RemoveLongPendingPodsOwnerScalerArgs
+		expectedEvents    int
+		expectedDeletions int
+	}{
+		{
+			description: "Owners of long pending pods are reported",
+			pods:        []*v1.Pod{p1, p2, p3, p4, p5, p6, p8},
+			args: RemoveLongPendingPodsOwnerScalerArgs{
+				MinPendingSeconds: utilptr.To[uint](3600),
+			},
+			expectedEvents:    2,
+			expectedDeletions: 0,
+		},
+		{
+			description: "Long pending pods are deleted when enabled",
+			pods:        []*v1.Pod{p1, p2, p3, p4, p5, p6, p8},
+			args: RemoveLongPendingPodsOwnerScalerArgs{
+				MinPendingSeconds: utilptr.To[uint](3600),
+				DeletePendingPods: true,
+			},
+			expectedEvents:    2,
+			expectedDeletions: 3,
+		},
+		{
+			description: "Pending pods in excluded namespaces are ignored",
+			pods:        []*v1.Pod{p1, p7},
+			args: RemoveLongPendingPodsOwnerScalerArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Exclude: []string{"kube-system"},
+					},
+				},
+				MinPendingSeconds: utilptr.To[uint](3600),
+				DeletePendingPods: true,
+			},
+			expectedEvents:    1,
+			expectedDeletions: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{node1, node2}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, _, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+			eventRecorder := events.NewFakeRecorder(10)
+			handle.EventRecorderImpl = eventRecorder
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node1, node2})
+
+			if len(eventRecorder.Events) != tc.expectedEvents {
+				t.Errorf("Expected %v events, got %v", tc.expectedEvents, len(eventRecorder.Events))
+			}
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if !strings.Contains(event, LongPendingPodsReason) {
+					t.Errorf("Unexpected event: %v", event)
+				}
+			}
+
+			pods, err := fakeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Unable to list pods: %v", err)
+			}
+			if deletions := len(tc.pods) - len(pods.Items); deletions != tc.expectedDeletions {
+				t.Errorf("Expected %v pods to be deleted, got %v", tc.expectedDeletions, deletions)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removemisscheduleddaemonsetpods
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "RemoveMisscheduledDaemonSetPods"
+
+// RemoveMisscheduledDaemonSetPods evicts DaemonSet pods running on nodes the DaemonSet
+// no longer targets, i.e. nodes not matching the node selector or required node affinity
+// of the DaemonSet template, or having NoSchedule/NoExecute taints the pod does not tolerate.
+//
+// Evicting DaemonSet pods requires the DefaultEvictor to be configured with evictDaemonSetPods.
+type RemoveMisscheduledDaemonSetPods struct {
+	handle            frameworktypes.Handle
+	args              *RemoveMisscheduledDaemonSetPodsArgs
+	podFilter         podutil.FilterFunc
+	daemonSetSelector labels.Selector
+	daemonSetLister   appslisters.DaemonSetLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemoveMisscheduledDaemonSetPods{}
+
+var _ frameworktypes.AllPodsRequirer = &RemoveMisscheduledDaemonSetPodsArgs{}
+
+// RequiresAllPods tells the DaemonSet pods, usually left out of a restricted pod cache, are the ones processed
+func (a *RemoveMisscheduledDaemonSetPodsArgs) RequiresAllPods() bool {
+	return true
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	misscheduledArgs, ok := args.(*RemoveMisscheduledDaemonSetPodsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemoveMisscheduledDaemonSetPodsArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if misscheduledArgs.Namespaces != nil {
+		includedNamespaces = sets.New(misscheduledArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(misscheduledArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(misscheduledArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	daemonSetSelector := labels.Everything()
+	if misscheduledArgs.DaemonSetSelector != nil {
+		daemonSetSelector, err = metav1.LabelSelectorAsSelector(misscheduledArgs.DaemonSetSelector)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing daemon set selector: %v", err)
+		}
+	}
+
+	return &RemoveMisscheduledDaemonSetPods{
+		handle:            handle,
+		args:              misscheduledArgs,
+		podFilter:         podFilter,
+		daemonSetSelector: daemonSetSelector,
+		// The DaemonSets are read from the cluster, the cached client of the dry run mode holds none
+		daemonSetLister: handle.ClusterInformerFactory().Apps().V1().DaemonSets().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemoveMisscheduledDaemonSetPods) Name() string {
+	return PluginName
+}
+
+// controllingDaemonSet returns the DaemonSet controlling the pod, if it is among the given ones
+func controllingDaemonSet(pod *v1.Pod, daemonSets map[types.UID]*appsv1.DaemonSet) *appsv1.DaemonSet {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != "DaemonSet" {
+		return nil
+	}
+	return daemonSets[owner.UID]
+}
+
+// isMisscheduled returns true if the node is no longer eligible for the pod of the DaemonSet.
+// Node selector and required node affinity are taken from the DaemonSet template since the
+// DaemonSet controller pins every pod to its node through its own node affinity. Taints are
+// checked against the pod tolerations, which include the ones added by the DaemonSet controller.
+func isMisscheduled(logger klog.Logger, pod *v1.Pod, ds *appsv1.DaemonSet, node *v1.Node) bool {
+	template := &v1.Pod{
+		Spec: v1.PodSpec{
+			NodeSelector: ds.Spec.Template.Spec.NodeSelector,
+			Affinity:     ds.Spec.Template.Spec.Affinity,
+		},
+	}
+	ok, err := utils.PodMatchNodeSelector(template, node)
+	if err != nil {
+		logger.Error(err, "Unable to match the DaemonSet node selector", "daemonset", klog.KObj(ds), "node", klog.KObj(node))
+		return false
+	}
+	if !ok {
+		return true
+	}
+	return !utils.TolerationsTolerateTaintsWithFilter(pod.Spec.Tolerations, node.Spec.Taints, func(taint *v1.Taint) bool {
+		return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
+	})
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemoveMisscheduledDaemonSetPods) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	daemonSetList, err := d.daemonSetLister.List(d.daemonSetSelector)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing daemon sets: %v", err),
+		}
+	}
+	if len(daemonSetList) == 0 {
+		return nil
+	}
+	daemonSets := map[types.UID]*appsv1.DaemonSet{}
+	for _, ds := range daemonSetList {
+		daemonSets[ds.UID] = ds
+	}
+
+loop:
+	for _, node := range nodes {
+		logger.V(1).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), func(pod *v1.Pod) bool {
+			ds := controllingDaemonSet(pod, daemonSets)
+			return ds != nil && !utils.IsPodTerminating(pod) && d.podFilter(pod) && isMisscheduled(logger, pod, ds, node)
+		})
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+
+		for _, pod := range pods {
+			logger.V(2).Info("DaemonSet pod is misscheduled on the node", "pod", klog.KObj(pod), "node", klog.KObj(node))
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				continue loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
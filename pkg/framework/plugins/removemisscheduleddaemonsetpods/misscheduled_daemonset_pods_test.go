@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removemisscheduleddaemonsetpods
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildDaemonSet(name string, labels map[string]string, apply func(*appsv1.DaemonSet)) *appsv1.DaemonSet {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID("uid-" + name),
+			Labels:    labels,
+		},
+	}
+	if apply != nil {
+		apply(ds)
+	}
+	return ds
+}
+
+func buildDaemonSetPod(name, nodeName string, ds *appsv1.DaemonSet, apply func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{Kind: "DaemonSet", APIVersion: "apps/v1", Name: ds.Name, UID: ds.UID, Controller: utilptr.To(true)},
+		}
+		pod.Status.Phase = v1.PodRunning
+		if apply != nil {
+			apply(pod)
+		}
+	})
+}
+
+func TestRemoveMisscheduledDaemonSetPods(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"role": "worker"}
+	})
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"role": "infra"}
+	})
+	node3 := test.BuildTestNode("n3", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"role": "worker"}
+		node.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "db", Effect: v1.TaintEffectNoSchedule}}
+	})
+
+	workerSelector := func(ds *appsv1.DaemonSet) {
+		ds.Spec.Template.Spec.NodeSelector = map[string]string{"role": "worker"}
+	}
+	workerAffinity := func(ds *appsv1.DaemonSet) {
+		ds.Spec.Template.Spec.Affinity = &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{
+							MatchExpressions: []v1.NodeSelectorRequirement{
+								{Key: "role", Operator: v1.NodeSelectorOpIn, Values: []string{"worker"}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	tolerateDedicated := func(pod *v1.Pod) {
+		pod.Spec.Tolerations = []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}}
+	}
+
+	tests := []struct {
+		description          string
+		objects              []runtime.Object
+		nodes                []*v1.Node
+		evictDaemonSetPods   bool
+		args                 *RemoveMisscheduledDaemonSetPodsArgs
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pod on a node no longer matching the node selector is evicted",
+			objects: []runtime.Object{
+				buildDaemonSet("ds", nil, workerSelector),
+				buildDaemonSetPod("p1", "n1", buildDaemonSet("ds", nil, nil), nil),
+				buildDaemonSetPod("p2", "n2", buildDaemonSet("ds", nil, nil), nil),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pod on a node no longer matching the required node affinity is evicted",
+			objects: []runtime.Object{
+				buildDaemonSet("ds", nil, workerAffinity),
+				buildDaemonSetPod("p1", "n1", buildDaemonSet("ds", nil, nil), nil),
+				buildDaemonSetPod("p2", "n2", buildDaemonSet("ds", nil, nil), nil),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pod on a node with an untolerated NoSchedule taint is evicted",
+			objects: []runtime.Object{
+				buildDaemonSet("ds", nil, nil),
+				buildDaemonSetPod("p1", "n1", buildDaemonSet("ds", nil, nil), nil),
+				buildDaemonSetPod("p3", "n3", buildDaemonSet("ds", nil, nil), nil),
+			},
+			nodes:                []*v1.Node{node1, node3},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pod tolerating the taint is kept",
+			objects: []runtime.Object{
+				buildDaemonSet("ds", nil, nil),
+				buildDaemonSetPod("p3", "n3", buildDaemonSet("ds", nil, nil), tolerateDedicated),
+			},
+			nodes:                []*v1.Node{node3},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "DaemonSet pods are not evicted without evictDaemonSetPods",
+			objects: []runtime.Object{
+				buildDaemonSet("ds", nil, workerSelector),
+				buildDaemonSetPod("p2", "n2", buildDaemonSet("ds", nil, nil), nil),
+			},
+			nodes:                []*v1.Node{node2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of DaemonSets not matching the daemon set selector are kept",
+			objects: []runtime.Object{
+				buildDaemonSet("ds", map[string]string{"app": "agent"}, workerSelector),
+				buildDaemonSet("other", map[string]string{"app": "other"}, workerSelector),
+				buildDaemonSetPod("p1", "n2", buildDaemonSet("ds", nil, nil), nil),
+				buildDaemonSetPod("p2", "n2", buildDaemonSet("other", nil, nil), nil),
+			},
+			nodes:              []*v1.Node{node2},
+			evictDaemonSetPods: true,
+			args: &RemoveMisscheduledDaemonSetPodsArgs{
+				DaemonSetSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods of unknown DaemonSets are ignored",
+			objects: []runtime.Object{
+				buildDaemonSetPod("p2", "n2", buildDaemonSet("ds", nil, workerSelector), nil),
+			},
+			nodes:                []*v1.Node{node2},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Regular pods are ignored",
+			objects: []runtime.Object{
+				buildDaemonSet("ds", nil, workerSelector),
+				test.BuildTestPod("p2", 100, 0, "n2", test.SetRSOwnerRef),
+			},
+			nodes:                []*v1.Node{node2},
+			evictDaemonSetPods:   true,
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{node1, node2, node3}
+			objs = append(objs, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{EvictDaemonSetPods: tc.evictDaemonSetPods},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := tc.args
+			if args == nil {
+				args = &RemoveMisscheduledDaemonSetPodsArgs{}
+			}
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			// Start the DaemonSet informer requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromdisconnectednodes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// WebhookCloudProvider is the name of the instance checker asking an HTTP endpoint
+const WebhookCloudProvider = "webhook"
+
+// InstanceChecker tells whether the cloud instance backing a node is terminated.
+// Implementations talk to a cloud provider (AWS, GCP, Azure...) or to anything
+// able to answer for it, like the generic webhook.
+type InstanceChecker interface {
+	// InstanceTerminated returns true only when the instance is known to be gone for good.
+	// A stopped or unknown instance is not terminated.
+	InstanceTerminated(ctx context.Context, node *v1.Node) (bool, error)
+}
+
+// InstanceCheckerBuilder builds an instance checker from the plugin configuration
+type InstanceCheckerBuilder func(config CloudProvider) (InstanceChecker, error)
+
+var (
+	instanceCheckersLock sync.RWMutex
+	instanceCheckers     = map[string]InstanceCheckerBuilder{
+		WebhookCloudProvider: newWebhookChecker,
+	}
+)
+
+// RegisterInstanceChecker makes an instance checker available under the given cloud provider name,
+// e.g. so builds of the descheduler vendoring a cloud provider SDK can query it directly.
+func RegisterInstanceChecker(name string, builder InstanceCheckerBuilder) {
+	instanceCheckersLock.Lock()
+	defer instanceCheckersLock.Unlock()
+	instanceCheckers[name] = builder
+}
+
+func isRegisteredInstanceChecker(name string) bool {
+	instanceCheckersLock.RLock()
+	defer instanceCheckersLock.RUnlock()
+	_, ok := instanceCheckers[name]
+	return ok
+}
+
+func newInstanceChecker(config CloudProvider) (InstanceChecker, error) {
+	instanceCheckersLock.RLock()
+	builder, ok := instanceCheckers[config.Name]
+	instanceCheckersLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown cloud provider %q", config.Name)
+	}
+	return builder(config)
+}
+
+// parseProviderID splits a node provider ID into the name of the provider and the ID of the instance,
+// e.g. aws:///us-east-1a/i-0123456789abcdef0 gives aws and i-0123456789abcdef0, and
+// gce://project/us-central1-a/instance-1 gives gce and instance-1.
+func parseProviderID(providerID string) (string, string, error) {
+	provider, path, found := strings.Cut(providerID, "://")
+	if !found || provider == "" {
+		return "", "", fmt.Errorf("invalid provider ID %q", providerID)
+	}
+	instance := path[strings.LastIndex(path, "/")+1:]
+	if instance == "" {
+		return "", "", fmt.Errorf("invalid provider ID %q", providerID)
+	}
+	return provider, instance, nil
+}
+
+// instanceRequest is the body of the requests sent to the webhook
+type instanceRequest struct {
+	NodeName   string `json:"nodeName"`
+	ProviderID string `json:"providerID"`
+	Provider   string `json:"provider"`
+	InstanceID string `json:"instanceID"`
+}
+
+// instanceResponse is the body of the responses expected from the webhook
+type instanceResponse struct {
+	Terminated bool `json:"terminated"`
+}
+
+// webhookChecker posts the provider ID of the nodes to an HTTP endpoint
+// which asks the cloud provider about the instance
+type webhookChecker struct {
+	httpClient      *http.Client
+	url             string
+	bearerTokenFile string
+}
+
+func newWebhookChecker(config CloudProvider) (InstanceChecker, error) {
+	if config.Webhook == nil {
+		return nil, fmt.Errorf("webhook is not configured")
+	}
+	return &webhookChecker{
+		httpClient:      utils.NewHTTPClient(config.Webhook.Timeout),
+		url:             config.Webhook.URL,
+		bearerTokenFile: config.Webhook.BearerTokenFile,
+	}, nil
+}
+
+func (c *webhookChecker) InstanceTerminated(ctx context.Context, node *v1.Node) (bool, error) {
+	provider, instance, err := parseProviderID(node.Spec.ProviderID)
+	if err != nil {
+		return false, err
+	}
+	body, err := json.Marshal(instanceRequest{
+		NodeName:   node.Name,
+		ProviderID: node.Spec.ProviderID,
+		Provider:   provider,
+		InstanceID: instance,
+	})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.bearerTokenFile != "" {
+		if err := utils.SetBearerToken(req, c.bearerTokenFile); err != nil {
+			return false, err
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var response instanceResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return false, fmt.Errorf("unable to decode the response: %v", err)
+	}
+	return response.Terminated, nil
+}
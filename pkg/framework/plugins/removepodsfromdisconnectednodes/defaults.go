@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromdisconnectednodes
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	DefaultNotReadyThreshold = time.Minute
+	DefaultWebhookTimeout    = 10 * time.Second
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_RemovePodsFromDisconnectedNodesArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_RemovePodsFromDisconnectedNodesArgs(obj runtime.Object) {
+	args := obj.(*RemovePodsFromDisconnectedNodesArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.NotReadyThreshold == nil {
+		args.NotReadyThreshold = &metav1.Duration{Duration: DefaultNotReadyThreshold}
+	}
+	if args.CloudProvider.Name == "" {
+		args.CloudProvider.Name = WebhookCloudProvider
+	}
+	if args.CloudProvider.Webhook != nil && args.CloudProvider.Webhook.Timeout == nil {
+		args.CloudProvider.Webhook.Timeout = &metav1.Duration{Duration: DefaultWebhookTimeout}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromdisconnectednodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetDefaults_RemovePodsFromDisconnectedNodesArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "RemovePodsFromDisconnectedNodesArgs empty",
+			in:   &RemovePodsFromDisconnectedNodesArgs{},
+			want: &RemovePodsFromDisconnectedNodesArgs{
+				NotReadyThreshold: &metav1.Duration{Duration: time.Minute},
+				CloudProvider:     CloudProvider{Name: "webhook"},
+			},
+		},
+		{
+			name: "RemovePodsFromDisconnectedNodesArgs with webhook",
+			in: &RemovePodsFromDisconnectedNodesArgs{
+				CloudProvider: CloudProvider{Webhook: &Webhook{URL: "http://instance-checker:8080"}},
+			},
+			want: &RemovePodsFromDisconnectedNodesArgs{
+				NotReadyThreshold: &metav1.Duration{Duration: time.Minute},
+				CloudProvider: CloudProvider{
+					Name:    "webhook",
+					Webhook: &Webhook{URL: "http://instance-checker:8080", Timeout: &metav1.Duration{Duration: 10 * time.Second}},
+				},
+			},
+		},
+		{
+			name: "RemovePodsFromDisconnectedNodesArgs with value",
+			in: &RemovePodsFromDisconnectedNodesArgs{
+				NotReadyThreshold: &metav1.Duration{Duration: 3 * time.Minute},
+				CloudProvider: CloudProvider{
+					Name:    "webhook",
+					Webhook: &Webhook{URL: "http://instance-checker:8080", Timeout: &metav1.Duration{Duration: time.Second}},
+				},
+			},
+			want: &RemovePodsFromDisconnectedNodesArgs{
+				NotReadyThreshold: &metav1.Duration{Duration: 3 * time.Minute},
+				CloudProvider: CloudProvider{
+					Name:    "webhook",
+					Webhook: &Webhook{URL: "http://instance-checker:8080", Timeout: &metav1.Duration{Duration: time.Second}},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RemovePodsFromDisconnectedNodesArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
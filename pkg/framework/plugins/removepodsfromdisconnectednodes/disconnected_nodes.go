@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromdisconnectednodes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilptr "k8s.io/utils/ptr"
+
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "RemovePodsFromDisconnectedNodes"
+
+	// NodeInstanceTerminatedReason is the reason of the events emitted on the force deleted pods
+	NodeInstanceTerminatedReason = "NodeInstanceTerminated"
+)
+
+// RemovePodsFromDisconnectedNodes force deletes the pods of the nodes which are not ready
+// and whose cloud instance is confirmed to be terminated. The pods of such nodes are stuck
+// until the node object is deleted or the taint based eviction kicks in, which keeps
+// StatefulSet pods from being recreated on another node for minutes.
+type RemovePodsFromDisconnectedNodes struct {
+	handle          frameworktypes.Handle
+	args            *RemovePodsFromDisconnectedNodesArgs
+	podFilter       podutil.FilterFunc
+	instanceChecker InstanceChecker
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsFromDisconnectedNodes{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	disconnectedNodesArgs, ok := args.(*RemovePodsFromDisconnectedNodesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsFromDisconnectedNodesArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if disconnectedNodesArgs.Namespaces != nil {
+		includedNamespaces = sets.New(disconnectedNodesArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(disconnectedNodesArgs.Namespaces.Exclude...)
+	}
+
+	// Pods already being deleted are kept: on a node which is gone they stay terminating forever
+	podFilter, err := podutil.NewOptions().
+		WithFilter(func(pod *v1.Pod) bool {
+			return pod.DeletionTimestamp != nil || handle.Evictor().Filter(pod)
+		}).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(disconnectedNodesArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	instanceChecker, err := newInstanceChecker(disconnectedNodesArgs.CloudProvider)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing the instance checker: %v", err)
+	}
+
+	return &RemovePodsFromDisconnectedNodes{
+		handle:          handle,
+		args:            disconnectedNodesArgs,
+		podFilter:       podFilter,
+		instanceChecker: instanceChecker,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsFromDisconnectedNodes) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin.
+// Only ready nodes are given to the plugins, so the not ready nodes are listed here.
+func (d *RemovePodsFromDisconnectedNodes) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	nodeList, err := d.handle.ClientSet().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing nodes: %v", err),
+		}
+	}
+
+	now := time.Now()
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		since := nodeutil.NotReadySince(node)
+		if since == nil || now.Sub(since.Time) < d.args.NotReadyThreshold.Duration {
+			continue
+		}
+		if node.Spec.ProviderID == "" {
+			logger.V(3).Info("Node has no provider ID, skipping", "node", klog.KObj(node))
+			continue
+		}
+
+		terminated, err := d.instanceChecker.InstanceTerminated(ctx, node)
+		if err != nil {
+			logger.Error(err, "Unable to check the instance of the node", "node", klog.KObj(node), "providerID", node.Spec.ProviderID)
+			continue
+		}
+		if !terminated {
+			logger.V(2).Info("Node is not ready but its instance is not terminated", "node", klog.KObj(node), "notReadySince", since.Time)
+			continue
+		}
+
+		logger.V(1).Info("Force deleting the pods of a node whose instance is terminated", "node", klog.KObj(node), "notReadySince", since.Time)
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			logger.Error(err, "Error listing pods on node", "node", klog.KObj(node))
+			continue
+		}
+		for _, pod := range pods {
+			d.forceDelete(ctx, pod, node)
+		}
+	}
+	return nil
+}
+
+// forceDelete deletes the pod without waiting for the kubelet to confirm its containers are stopped,
+// which never happens once the instance is terminated.
+func (d *RemovePodsFromDisconnectedNodes) forceDelete(ctx context.Context, pod *v1.Pod, node *v1.Node) {
+	logger := klog.FromContext(ctx)
+	err := d.handle.ClientSet().CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{
+		GracePeriodSeconds: utilptr.To[int64](0),
+		Preconditions:      metav1.NewUIDPreconditions(string(pod.UID)),
+	})
+	if err != nil {
+		logger.Error(err, "Unable to force delete pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+		return
+	}
+	logger.V(1).Info("Force deleted pod", "pod", klog.KObj(pod), "node", klog.KObj(node))
+	d.handle.EventRecorder().Eventf(pod, node, v1.EventTypeWarning, NodeInstanceTerminatedReason, "ForceDeleted",
+		"pod force deleted by sigs.k8s.io/descheduler as the instance of node %v is terminated", node.Name)
+}
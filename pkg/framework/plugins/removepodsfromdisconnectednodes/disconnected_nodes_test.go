@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromdisconnectednodes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+// newInstanceServer answers the instance lookups from the given instance states,
+// failing for the instances it does not know about
+func newInstanceServer(t *testing.T, terminated map[string]bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request instanceRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Unable to decode the request: %v", err)
+		}
+		state, ok := terminated[request.InstanceID]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(instanceResponse{Terminated: state})
+	}))
+}
+
+func buildNotReadyNode(name, providerID string, notReadyFor time.Duration) *v1.Node {
+	return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.ProviderID = providerID
+		node.Status.Conditions = []v1.NodeCondition{{
+			Type:               v1.NodeReady,
+			Status:             v1.ConditionUnknown,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-notReadyFor)),
+		}}
+	})
+}
+
+func TestRemovePodsFromDisconnectedNodes(t *testing.T) {
+	terminatedNode := buildNotReadyNode("n1", "aws:///us-east-1a/i-terminated", 5*time.Minute)
+	stoppedNode := buildNotReadyNode("n2", "aws:///us-east-1a/i-stopped", 5*time.Minute)
+	recentlyNotReadyNode := buildNotReadyNode("n3", "aws:///us-east-1a/i-recent", 10*time.Second)
+	unknownNode := buildNotReadyNode("n4", "aws:///us-east-1a/i-unknown", 5*time.Minute)
+	noProviderIDNode := buildNotReadyNode("n5", "", 5*time.Minute)
+	readyNode := test.BuildTestNode("n6", 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.ProviderID = "aws:///us-east-1a/i-ready"
+	})
+	instances := map[string]bool{
+		"i-terminated": true,
+		"i-stopped":    false,
+		"i-recent":     true,
+		"i-ready":      true,
+		"":             true,
+	}
+
+	statefulPod := test.BuildTestPod("stateful", 100, 0, "n1", test.SetSSOwnerRef)
+	terminatingPod := test.BuildTestPod("terminating", 100, 0, "n1", func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	})
+	daemonSetPod := test.BuildTestPod("daemonset", 100, 0, "n1", test.SetDSOwnerRef)
+	otherNamespacePod := test.BuildTestPod("other-namespace", 100, 0, "n1", func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Namespace = "kube-system"
+	})
+	var objs []runtime.Object
+	for _, node := range []*v1.Node{terminatedNode, stoppedNode, recentlyNotReadyNode, unknownNode, noProviderIDNode, readyNode} {
+		objs = append(objs, node)
+		objs = append(objs, test.BuildTestPod(node.Name+"-pod", 100, 0, node.Name, test.SetRSOwnerRef))
+	}
+	objs = append(objs, statefulPod, terminatingPod, daemonSetPod, otherNamespacePod)
+
+	tests := []struct {
+		description     string
+		args            RemovePodsFromDisconnectedNodesArgs
+		expectedDeleted []string
+	}{
+		{
+			description:     "Pods of the nodes whose instance is terminated are force deleted",
+			args:            RemovePodsFromDisconnectedNodesArgs{},
+			expectedDeleted: []string{"n1-pod", "other-namespace", "stateful", "terminating"},
+		},
+		{
+			description: "Pods of excluded namespaces are not deleted",
+			args: RemovePodsFromDisconnectedNodesArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"kube-system"}},
+				},
+			},
+			expectedDeleted: []string{"n1-pod", "stateful", "terminating"},
+		},
+		{
+			description: "Nodes not ready for less than the threshold are checked",
+			args: RemovePodsFromDisconnectedNodesArgs{
+				NotReadyThreshold: &metav1.Duration{Duration: time.Second},
+			},
+			expectedDeleted: []string{"n1-pod", "n3-pod", "other-namespace", "stateful", "terminating"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			server := newInstanceServer(t, instances)
+			defer server.Close()
+
+			fakeClient := fake.NewSimpleClientset(objs...)
+			var deleted []string
+			fakeClient.PrependReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				deleteAction := action.(core.DeleteAction)
+				if gracePeriod := deleteAction.GetDeleteOptions().GracePeriodSeconds; gracePeriod == nil || *gracePeriod != 0 {
+					t.Errorf("Expected pod %v to be deleted with a zero grace period", deleteAction.GetName())
+				}
+				deleted = append(deleted, deleteAction.GetName())
+				return true, nil, nil
+			})
+
+			handle, _, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := tc.args
+			args.CloudProvider.Webhook = &Webhook{URL: server.URL}
+			SetDefaults_RemovePodsFromDisconnectedNodesArgs(&args)
+			plugin, err := New(&args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			status := plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{readyNode})
+			if status != nil && status.Err != nil {
+				t.Fatalf("Unexpected error: %v", status.Err)
+			}
+			sort.Strings(deleted)
+			if diff := cmp.Diff(tc.expectedDeleted, deleted); diff != "" {
+				t.Errorf("Unexpected deleted pods (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseProviderID(t *testing.T) {
+	tests := []struct {
+		providerID       string
+		expectedProvider string
+		expectedInstance string
+		expectedError    bool
+	}{
+		{providerID: "aws:///us-east-1a/i-0123456789abcdef0", expectedProvider: "aws", expectedInstance: "i-0123456789abcdef0"},
+		{providerID: "gce://project/us-central1-a/instance-1", expectedProvider: "gce", expectedInstance: "instance-1"},
+		{providerID: "azure:///subscriptions/s/resourceGroups/g/providers/Microsoft.Compute/virtualMachines/vm-1", expectedProvider: "azure", expectedInstance: "vm-1"},
+		{providerID: "kind://docker/kind/kind-worker", expectedProvider: "kind", expectedInstance: "kind-worker"},
+		{providerID: "i-0123456789abcdef0", expectedError: true},
+		{providerID: "aws:///us-east-1a/", expectedError: true},
+	}
+	for _, tc := range tests {
+		provider, instance, err := parseProviderID(tc.providerID)
+		if hasError := err != nil; hasError != tc.expectedError {
+			t.Errorf("Expected error %v for %q, got %v", tc.expectedError, tc.providerID, err)
+		}
+		if provider != tc.expectedProvider || instance != tc.expectedInstance {
+			t.Errorf("Expected %q and %q for %q, got %q and %q", tc.expectedProvider, tc.expectedInstance, tc.providerID, provider, instance)
+		}
+	}
+}
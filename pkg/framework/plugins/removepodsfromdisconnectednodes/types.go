@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromdisconnectednodes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsFromDisconnectedNodesArgs holds arguments used to configure RemovePodsFromDisconnectedNodes plugin.
+type RemovePodsFromDisconnectedNodesArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// NotReadyThreshold is how long a node has to be not ready before the cloud provider is asked about its instance
+	NotReadyThreshold *metav1.Duration `json:"notReadyThreshold,omitempty"`
+	// CloudProvider tells how to check whether the instance of a node is terminated
+	CloudProvider CloudProvider `json:"cloudProvider"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// CloudProvider configures how the instances of the nodes are looked up.
+type CloudProvider struct {
+	// Name of the instance checker, "webhook" or a checker registered with RegisterInstanceChecker
+	Name string `json:"name"`
+	// Webhook configures the "webhook" instance checker
+	Webhook *Webhook `json:"webhook,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Webhook configures an HTTP endpoint telling whether the instance of a node is terminated.
+type Webhook struct {
+	// URL the instance lookups are posted to, e.g. http://instance-checker.kube-system.svc:8080/instances
+	URL string `json:"url"`
+	// BearerTokenFile is a file holding the token sent to authenticate against the endpoint
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// Timeout of each lookup
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
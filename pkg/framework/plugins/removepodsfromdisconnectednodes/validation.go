@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromdisconnectednodes
+
+import (
+	"fmt"
+	"net/url"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateRemovePodsFromDisconnectedNodesArgs validates RemovePodsFromDisconnectedNodes arguments
+func ValidateRemovePodsFromDisconnectedNodesArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsFromDisconnectedNodesArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.NotReadyThreshold != nil && args.NotReadyThreshold.Duration < 0 {
+		return fmt.Errorf("notReadyThreshold can not be negative")
+	}
+
+	if !isRegisteredInstanceChecker(args.CloudProvider.Name) {
+		return fmt.Errorf("unknown cloud provider %q", args.CloudProvider.Name)
+	}
+	if args.CloudProvider.Name == WebhookCloudProvider {
+		webhook := args.CloudProvider.Webhook
+		if webhook == nil || webhook.URL == "" {
+			return fmt.Errorf("webhook url can not be empty")
+		}
+		if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhook url %q is not a valid http(s) url", webhook.URL)
+		}
+		if webhook.Timeout != nil && webhook.Timeout.Duration <= 0 {
+			return fmt.Errorf("webhook timeout has to be positive")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromdisconnectednodes
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRemovePodsFromDisconnectedNodesArgs(t *testing.T) {
+	validArgs := func(mutate func(args *RemovePodsFromDisconnectedNodesArgs)) *RemovePodsFromDisconnectedNodesArgs {
+		args := &RemovePodsFromDisconnectedNodesArgs{
+			NotReadyThreshold: &metav1.Duration{Duration: time.Minute},
+			CloudProvider: CloudProvider{
+				Name:    WebhookCloudProvider,
+				Webhook: &Webhook{URL: "http://instance-checker.kube-system.svc:8080/instances"},
+			},
+		}
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *RemovePodsFromDisconnectedNodesArgs
+		expectError bool
+	}{
+		{
+			description: "valid args, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *RemovePodsFromDisconnectedNodesArgs) {
+				args.Namespaces = &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects error",
+			args: validArgs(func(args *RemovePodsFromDisconnectedNodesArgs) {
+				args.LabelSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+				}
+			}),
+			expectError: true,
+		},
+		{
+			description: "negative notReadyThreshold, expects error",
+			args: validArgs(func(args *RemovePodsFromDisconnectedNodesArgs) {
+				args.NotReadyThreshold = &metav1.Duration{Duration: -time.Minute}
+			}),
+			expectError: true,
+		},
+		{
+			description: "unknown cloud provider, expects error",
+			args:        validArgs(func(args *RemovePodsFromDisconnectedNodesArgs) { args.CloudProvider.Name = "aws" }),
+			expectError: true,
+		},
+		{
+			description: "missing webhook, expects error",
+			args:        validArgs(func(args *RemovePodsFromDisconnectedNodesArgs) { args.CloudProvider.Webhook = nil }),
+			expectError: true,
+		},
+		{
+			description: "invalid webhook url, expects error",
+			args: validArgs(func(args *RemovePodsFromDisconnectedNodesArgs) {
+				args.CloudProvider.Webhook.URL = "instance-checker:8080"
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero webhook timeout, expects error",
+			args: validArgs(func(args *RemovePodsFromDisconnectedNodesArgs) {
+				args.CloudProvider.Webhook.Timeout = &metav1.Duration{}
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemovePodsFromDisconnectedNodesArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodesexceedingpoddensitylimits
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestSetDefaults_RemovePodsFromNodesExceedingPodDensityLimitsArgs(t *testing.T) {
+	maxPods := intstr.FromInt32(90)
+	defaultMaxPods := intstr.FromString("80%")
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "RemovePodsFromNodesExceedingPodDensityLimitsArgs empty",
+			in:   &RemovePodsFromNodesExceedingPodDensityLimitsArgs{},
+			want: &RemovePodsFromNodesExceedingPodDensityLimitsArgs{MaxPodsPerNode: &defaultMaxPods},
+		},
+		{
+			name: "RemovePodsFromNodesExceedingPodDensityLimitsArgs with value",
+			in:   &RemovePodsFromNodesExceedingPodDensityLimitsArgs{MaxPodsPerNode: &maxPods},
+			want: &RemovePodsFromNodesExceedingPodDensityLimitsArgs{MaxPodsPerNode: &maxPods},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RemovePodsFromNodesExceedingPodDensityLimitsArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodesexceedingpoddensitylimits
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "RemovePodsFromNodesExceedingPodDensityLimits"
+
+// RemovePodsFromNodesExceedingPodDensityLimits evicts pods from the nodes running more pods than
+// allowed, a limit set below the one enforced by the kubelet, so the nodes keep room for the pods
+// of new DaemonSets and for bursts of critical pods. The newest pods of the lowest priority are
+// evicted first.
+type RemovePodsFromNodesExceedingPodDensityLimits struct {
+	handle    frameworktypes.Handle
+	args      *RemovePodsFromNodesExceedingPodDensityLimitsArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsFromNodesExceedingPodDensityLimits{}
+
+var _ frameworktypes.AllPodsRequirer = &RemovePodsFromNodesExceedingPodDensityLimitsArgs{}
+
+// RequiresAllPods tells the density of the nodes is computed from all their pods
+func (a *RemovePodsFromNodesExceedingPodDensityLimitsArgs) RequiresAllPods() bool {
+	return true
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	densityArgs, ok := args.(*RemovePodsFromNodesExceedingPodDensityLimitsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsFromNodesExceedingPodDensityLimitsArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if densityArgs.Namespaces != nil {
+		includedNamespaces = sets.New(densityArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(densityArgs.Namespaces.Exclude...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(densityArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsFromNodesExceedingPodDensityLimits{
+		handle:    handle,
+		args:      densityArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsFromNodesExceedingPodDensityLimits) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemovePodsFromNodesExceedingPodDensityLimits) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+loop:
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		limit, err := d.maxPodsPerNode(node)
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error computing the maximum number of pods of node %q: %v", node.Name, err),
+			}
+		}
+		if limit <= 0 {
+			continue
+		}
+
+		// All the pods the kubelet admitted count towards the limit, evictable or not
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), isActive)
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		excess := len(pods) - limit
+		if excess <= 0 {
+			continue
+		}
+		logger.V(1).Info("Node runs too many pods", "node", klog.KObj(node), "pods", len(pods), "maxPodsPerNode", limit)
+
+		var evictable []*v1.Pod
+		for _, pod := range pods {
+			if d.podFilter(pod) {
+				evictable = append(evictable, pod)
+			}
+		}
+		sortPodsByEvictionOrder(evictable)
+
+		for _, pod := range evictable {
+			if excess <= 0 {
+				break
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				excess--
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				continue loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// maxPodsPerNode returns the number of pods the node may run, zero when the node does
+// not report how many pods it can run and the limit is relative
+func (d *RemovePodsFromNodesExceedingPodDensityLimits) maxPodsPerNode(node *v1.Node) (int, error) {
+	allocatable := int(node.Status.Allocatable.Pods().Value())
+	return intstr.GetScaledValueFromIntOrPercent(d.args.MaxPodsPerNode, allocatable, false)
+}
+
+func isActive(pod *v1.Pod) bool {
+	return pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed
+}
+
+// sortPodsByEvictionOrder sorts the pods from the lowest to the highest priority, then from
+// the lowest to the highest eviction cost, the most recent pods first when both are the same
+func sortPodsByEvictionOrder(pods []*v1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		if pi, pj := utils.GetPodPriority(pods[i]), utils.GetPodPriority(pods[j]); pi != pj {
+			return pi < pj
+		}
+		if ci, cj := podutil.EvictionCost(pods[i]), podutil.EvictionCost(pods[j]); ci != cj {
+			return ci < cj
+		}
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+}
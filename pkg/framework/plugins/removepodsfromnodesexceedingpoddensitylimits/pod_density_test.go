@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodesexceedingpoddensitylimits
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+// buildPods returns the given number of pods assigned to the node, created a minute apart
+func buildPods(prefix, node string, count int, apply func(*v1.Pod)) []runtime.Object {
+	var pods []runtime.Object
+	for i := 0; i < count; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("%s-%s-p%d", prefix, node, i), 100, 0, node, func(pod *v1.Pod) {
+			pod.CreationTimestamp = metav1.NewTime(time.Date(2024, 1, 1, 0, i, 0, 0, time.UTC))
+			test.SetRSOwnerRef(pod)
+			if apply != nil {
+				apply(pod)
+			}
+		}))
+	}
+	return pods
+}
+
+func TestRemovePodsFromNodesExceedingPodDensityLimits(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 4000, 3000, 10, nil)
+	defaultMaxPods := DefaultMaxPodsPerNode
+	maxPods := intstr.FromInt32(3)
+	priority := func(value int32) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.Priority = &value
+		}
+	}
+	join := func(pods ...[]runtime.Object) []runtime.Object {
+		var objs []runtime.Object
+		for _, p := range pods {
+			objs = append(objs, p...)
+		}
+		return objs
+	}
+
+	tests := []struct {
+		description     string
+		args            RemovePodsFromNodesExceedingPodDensityLimitsArgs
+		pods            []runtime.Object
+		expectedEvicted []string
+	}{
+		{
+			description:     "Pods above the percentage of the allocatable pods are evicted",
+			args:            RemovePodsFromNodesExceedingPodDensityLimitsArgs{MaxPodsPerNode: &defaultMaxPods},
+			pods:            join(buildPods("a", "n1", 10, nil), buildPods("a", "n2", 8, nil)),
+			expectedEvicted: []string{"a-n1-p9", "a-n1-p8"},
+		},
+		{
+			description:     "Pods above the absolute limit are evicted",
+			args:            RemovePodsFromNodesExceedingPodDensityLimitsArgs{MaxPodsPerNode: &maxPods},
+			pods:            join(buildPods("a", "n1", 4, nil), buildPods("a", "n2", 3, nil)),
+			expectedEvicted: []string{"a-n1-p3"},
+		},
+		{
+			description: "Pods of the lowest priority are evicted first",
+			args:        RemovePodsFromNodesExceedingPodDensityLimitsArgs{MaxPodsPerNode: &maxPods},
+			pods: join(
+				buildPods("high", "n1", 2, priority(1000)),
+				buildPods("low", "n1", 3, priority(10)),
+			),
+			expectedEvicted: []string{"low-n1-p2", "low-n1-p1"},
+		},
+		{
+			description: "Pods which can not be evicted count towards the limit",
+			args:        RemovePodsFromNodesExceedingPodDensityLimitsArgs{MaxPodsPerNode: &maxPods},
+			pods: join(
+				buildPods("ds", "n1", 2, test.SetDSOwnerRef),
+				buildPods("a", "n1", 3, nil),
+			),
+			expectedEvicted: []string{"a-n1-p2", "a-n1-p1"},
+		},
+		{
+			description: "Terminated pods do not count towards the limit",
+			args:        RemovePodsFromNodesExceedingPodDensityLimitsArgs{MaxPodsPerNode: &maxPods},
+			pods: join(
+				buildPods("a", "n1", 3, nil),
+				buildPods("done", "n1", 3, func(pod *v1.Pod) {
+					pod.Status.Phase = v1.PodSucceeded
+				}),
+			),
+		},
+		{
+			description: "Pods of excluded namespaces are not evicted",
+			args: RemovePodsFromNodesExceedingPodDensityLimitsArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"kube-system"}},
+				},
+				MaxPodsPerNode: &maxPods,
+			},
+			pods: join(
+				buildPods("a", "n1", 3, nil),
+				buildPods("sys", "n1", 2, func(pod *v1.Pod) {
+					pod.Namespace = "kube-system"
+				}),
+			),
+			expectedEvicted: []string{"a-n1-p2", "a-n1-p1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{n1, n2}
+			objs := append([]runtime.Object{n1, n2}, tc.pods...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			evicted := sets.New[string]()
+			fakeClient.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() == "eviction" {
+					evicted.Insert(action.(core.CreateAction).GetObject().(*policyv1.Eviction).Name)
+				}
+				return false, nil, nil
+			})
+
+			handle, _, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, nodes)
+			if !evicted.Equal(sets.New(tc.expectedEvicted...)) {
+				t.Errorf("Expected evicted pods %v, got %v", sets.List(sets.New(tc.expectedEvicted...)), sets.List(evicted))
+			}
+		})
+	}
+}
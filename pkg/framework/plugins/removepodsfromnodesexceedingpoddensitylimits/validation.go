@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodesexceedingpoddensitylimits
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ValidateRemovePodsFromNodesExceedingPodDensityLimitsArgs validates RemovePodsFromNodesExceedingPodDensityLimits arguments
+func ValidateRemovePodsFromNodesExceedingPodDensityLimitsArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsFromNodesExceedingPodDensityLimitsArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.MaxPodsPerNode == nil {
+		return fmt.Errorf("maxPodsPerNode must be set")
+	}
+	switch args.MaxPodsPerNode.Type {
+	case intstr.Int:
+		if args.MaxPodsPerNode.IntVal < 1 {
+			return fmt.Errorf("maxPodsPerNode must be greater than zero, got %d", args.MaxPodsPerNode.IntVal)
+		}
+	case intstr.String:
+		value := args.MaxPodsPerNode.StrVal
+		if !strings.HasSuffix(value, "%") {
+			return fmt.Errorf("maxPodsPerNode must be an integer or a percentage, got %q", value)
+		}
+		percent, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil || percent < 1 || percent > 100 {
+			return fmt.Errorf("maxPodsPerNode percentage must be between 1%% and 100%%, got %q", value)
+		}
+	}
+
+	return nil
+}
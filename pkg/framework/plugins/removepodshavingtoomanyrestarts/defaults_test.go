@@ -34,8 +34,10 @@ func TestSetDefaults_RemovePodsHavingTooManyRestartsArgs(t *testing.T) {
 			name: "RemovePodsHavingTooManyRestartsArgs empty",
 			in:   &RemovePodsHavingTooManyRestartsArgs{},
 			want: &RemovePodsHavingTooManyRestartsArgs{
-				Namespaces:              nil,
-				LabelSelector:           nil,
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    nil,
+					LabelSelector: nil,
+				},
 				PodRestartThreshold:     0,
 				IncludingInitContainers: false,
 				States:                  nil,
@@ -44,15 +46,19 @@ func TestSetDefaults_RemovePodsHavingTooManyRestartsArgs(t *testing.T) {
 		{
 			name: "RemovePodsHavingTooManyRestartsArgs with value",
 			in: &RemovePodsHavingTooManyRestartsArgs{
-				Namespaces:              &api.Namespaces{},
-				LabelSelector:           &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 				PodRestartThreshold:     10,
 				IncludingInitContainers: true,
 				States:                  []string{string(v1.PodRunning)},
 			},
 			want: &RemovePodsHavingTooManyRestartsArgs{
-				Namespaces:              &api.Namespaces{},
-				LabelSelector:           &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 				PodRestartThreshold:     10,
 				IncludingInitContainers: true,
 				States:                  []string{string(v1.PodRunning)},
@@ -25,12 +25,19 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
 
-const PluginName = "RemovePodsHavingTooManyRestarts"
+const (
+	PluginName = "RemovePodsHavingTooManyRestarts"
+
+	// WarnThresholdReason is the reason of the events emitted on the pods above the warn threshold
+	WarnThresholdReason = "PodRestartsWarnThreshold"
+	actionWarned        = "Warned"
+)
 
 // RemovePodsHavingTooManyRestarts removes the pods that have too many restarts on node.
 // There are too many cases leading this issue: Volume mount failed, app error due to nodes' different settings.
@@ -112,8 +119,9 @@ func (d *RemovePodsHavingTooManyRestarts) Name() string {
 
 // Deschedule extension point implementation for the plugin
 func (d *RemovePodsHavingTooManyRestarts) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
 	for _, node := range nodes {
-		klog.V(2).InfoS("Processing node", "node", klog.KObj(node))
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
 		if err != nil {
 			// no pods evicted as error encountered retrieving evictable Pods
@@ -124,6 +132,13 @@ func (d *RemovePodsHavingTooManyRestarts) Deschedule(ctx context.Context, nodes
 		totalPods := len(pods)
 	loop:
 		for i := 0; i < totalPods; i++ {
+			if restarts := calcPodRestarts(pods[i], d.args); restarts < d.args.PodRestartThreshold {
+				logger.V(2).Info("Pod restarts are above the warn threshold", "pod", klog.KObj(pods[i]), "restarts", restarts)
+				d.handle.EventRecorder().Eventf(pods[i], nil, v1.EventTypeWarning, WarnThresholdReason, actionWarned,
+					"Pod restarted %d times, above the warn threshold of %d restarts", restarts, *d.args.WarnPodRestartThreshold)
+				metrics.ThresholdWarnings.With(map[string]string{"strategy": PluginName, "namespace": pods[i].Namespace, "node": node.Name}).Inc()
+				continue
+			}
 			err := d.handle.Evictor().Evict(ctx, pods[i], evictions.EvictOptions{StrategyName: PluginName})
 			if err == nil {
 				continue
@@ -134,7 +149,7 @@ func (d *RemovePodsHavingTooManyRestarts) Deschedule(ctx context.Context, nodes
 			case *evictions.EvictionTotalLimitError:
 				return nil
 			default:
-				klog.Errorf("eviction failed: %v", err)
+				logger.Error(err, "Eviction failed")
 			}
 		}
 	}
@@ -145,18 +160,29 @@ func (d *RemovePodsHavingTooManyRestarts) Deschedule(ctx context.Context, nodes
 func validateCanEvict(pod *v1.Pod, tooManyRestartsArgs *RemovePodsHavingTooManyRestartsArgs) error {
 	var err error
 
-	restarts := calcContainerRestartsFromStatuses(pod.Status.ContainerStatuses)
-	if tooManyRestartsArgs.IncludingInitContainers {
-		restarts += calcContainerRestartsFromStatuses(pod.Status.InitContainerStatuses)
-	}
+	restarts := calcPodRestarts(pod, tooManyRestartsArgs)
 
-	if restarts < tooManyRestartsArgs.PodRestartThreshold {
+	threshold := tooManyRestartsArgs.PodRestartThreshold
+	// The pods above the warn threshold are listed as well, to be reported only
+	if tooManyRestartsArgs.WarnPodRestartThreshold != nil {
+		threshold = min(threshold, *tooManyRestartsArgs.WarnPodRestartThreshold)
+	}
+	if restarts < threshold {
 		err = fmt.Errorf("number of container restarts (%v) not exceeding the threshold", restarts)
 	}
 
 	return err
 }
 
+// calcPodRestarts get the restarts of the containers of the pod considered by tooManyRestartsArgs.
+func calcPodRestarts(pod *v1.Pod, tooManyRestartsArgs *RemovePodsHavingTooManyRestartsArgs) int32 {
+	restarts := calcContainerRestartsFromStatuses(pod.Status.ContainerStatuses)
+	if tooManyRestartsArgs.IncludingInitContainers {
+		restarts += calcContainerRestartsFromStatuses(pod.Status.InitContainerStatuses)
+	}
+	return restarts
+}
+
 // calcContainerRestartsFromStatuses get container restarts from container statuses.
 func calcContainerRestartsFromStatuses(statuses []v1.ContainerStatus) int32 {
 	var restarts int32
@@ -19,12 +19,15 @@ package removepodshavingtoomanyrestarts
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
@@ -333,7 +336,7 @@ func TestRemovePodsHavingTooManyRestarts(t *testing.T) {
 				evictions.NewOptions().
 					WithMaxPodsToEvictPerNode(tc.maxPodsToEvictPerNode).
 					WithMaxPodsToEvictPerNamespace(tc.maxNoOfPodsToEvictPerNamespace),
-				defaultevictor.DefaultEvictorArgs{NodeFit: tc.nodeFit},
+				defaultevictor.DefaultEvictorArgs{NodeFit: utilptr.To(tc.nodeFit)},
 				nil,
 			)
 			if err != nil {
@@ -355,3 +358,50 @@ func TestRemovePodsHavingTooManyRestarts(t *testing.T) {
 		})
 	}
 }
+
+func TestRemovePodsHavingTooManyRestartsWarnThreshold(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node1 := test.BuildTestNode("node1", 2000, 3000, 10, nil)
+	objs := []runtime.Object{node1}
+	for _, pod := range initPods(node1) {
+		objs = append(objs, pod)
+	}
+	fakeClient := fake.NewSimpleClientset(objs...)
+
+	handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+		ctx,
+		fakeClient,
+		evictions.NewOptions(),
+		defaultevictor.DefaultEvictorArgs{},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Unable to initialize a framework handle: %v", err)
+	}
+	eventRecorder := events.NewFakeRecorder(10)
+	handle.EventRecorderImpl = eventRecorder
+
+	// pod-5 and pod-9 reach the threshold, pod-2 to pod-4 only the warn threshold
+	plugin, err := New(&RemovePodsHavingTooManyRestartsArgs{
+		PodRestartThreshold:     100,
+		WarnPodRestartThreshold: utilptr.To[int32](40),
+	}, handle)
+	if err != nil {
+		t.Fatalf("Unable to initialize the plugin: %v", err)
+	}
+
+	plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node1})
+	if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != 2 {
+		t.Errorf("Expected 2 pods to be evicted, got %v", podsEvicted)
+	}
+	if len(eventRecorder.Events) != 3 {
+		t.Errorf("Expected 3 events, got %v", len(eventRecorder.Events))
+	}
+	for len(eventRecorder.Events) > 0 {
+		if event := <-eventRecorder.Events; !strings.Contains(event, WarnThresholdReason) {
+			t.Errorf("Unexpected event: %v", event)
+		}
+	}
+}
@@ -25,9 +25,12 @@ import (
 type RemovePodsHavingTooManyRestartsArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
-	Namespaces              *api.Namespaces       `json:"namespaces"`
-	LabelSelector           *metav1.LabelSelector `json:"labelSelector"`
-	PodRestartThreshold     int32                 `json:"podRestartThreshold"`
-	IncludingInitContainers bool                  `json:"includingInitContainers"`
-	States                  []string              `json:"states"`
+	api.FilteringArgs       `json:",inline"`
+	PodRestartThreshold     int32    `json:"podRestartThreshold"`
+	IncludingInitContainers bool     `json:"includingInitContainers"`
+	States                  []string `json:"states"`
+
+	// WarnPodRestartThreshold reports the pods with at least the given number of restarts,
+	// without evicting them until they reach PodRestartThreshold.
+	WarnPodRestartThreshold *int32 `json:"warnPodRestartThreshold,omitempty"`
 }
@@ -17,7 +17,6 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -25,21 +24,18 @@ import (
 // ValidateRemovePodsHavingTooManyRestartsArgs validates RemovePodsHavingTooManyRestarts arguments
 func ValidateRemovePodsHavingTooManyRestartsArgs(obj runtime.Object) error {
 	args := obj.(*RemovePodsHavingTooManyRestartsArgs)
-	// At most one of include/exclude can be set
-	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
-		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
-	}
-
-	if args.LabelSelector != nil {
-		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
-			return fmt.Errorf("failed to get label selectors from strategy's params: %+v", err)
-		}
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
 	}
 
 	if args.PodRestartThreshold < 1 {
 		return fmt.Errorf("invalid PodsHavingTooManyRestarts threshold")
 	}
 
+	if args.WarnPodRestartThreshold != nil && (*args.WarnPodRestartThreshold < 1 || *args.WarnPodRestartThreshold >= args.PodRestartThreshold) {
+		return fmt.Errorf("warnPodRestartThreshold must be positive and lower than podRestartThreshold")
+	}
+
 	allowedStates := sets.New(
 		// Pod phases:
 		string(v1.PodRunning),
@@ -43,6 +43,22 @@ func TestValidateRemovePodsHavingTooManyRestartsArgs(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			description: "warn threshold lower than threshold, no errors",
+			args: &RemovePodsHavingTooManyRestartsArgs{
+				PodRestartThreshold:     10,
+				WarnPodRestartThreshold: func(i int32) *int32 { return &i }(5),
+			},
+			expectError: false,
+		},
+		{
+			description: "warn threshold not lower than threshold, expects errors",
+			args: &RemovePodsHavingTooManyRestartsArgs{
+				PodRestartThreshold:     10,
+				WarnPodRestartThreshold: func(i int32) *int32 { return &i }(10),
+			},
+			expectError: true,
+		},
 		{
 			description: "invalid States arg, expects errors",
 			args: &RemovePodsHavingTooManyRestartsArgs{
@@ -22,30 +22,24 @@ limitations under the License.
 package removepodshavingtoomanyrestarts
 
 import (
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
-	api "sigs.k8s.io/descheduler/pkg/api"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemovePodsHavingTooManyRestartsArgs) DeepCopyInto(out *RemovePodsHavingTooManyRestartsArgs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = new(api.Namespaces)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.LabelSelector != nil {
-		in, out := &in.LabelSelector, &out.LabelSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
+	in.FilteringArgs.DeepCopyInto(&out.FilteringArgs)
 	if in.States != nil {
 		in, out := &in.States, &out.States
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.WarnPodRestartThreshold != nil {
+		in, out := &in.WarnPodRestartThreshold, &out.WarnPodRestartThreshold
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsonnodespendingosupgrade
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_RemovePodsOnNodesPendingOSUpgradeArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_RemovePodsOnNodesPendingOSUpgradeArgs(obj runtime.Object) {
+	args := obj.(*RemovePodsOnNodesPendingOSUpgradeArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	// Without any configured signal fall back to the annotation kured puts on a node
+	// as soon as it found the node needs a reboot. The plan labels of the system-upgrade-controller
+	// can not be a default, they are only set once a node got upgraded and are kept afterwards.
+	if args.NodeAnnotations == nil && args.NodeLabels == nil {
+		args.NodeAnnotations = []string{KuredRebootInProgressAnnotation}
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsonnodespendingosupgrade
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "RemovePodsOnNodesPendingOSUpgrade"
+
+	// KuredRebootInProgressAnnotation is set by kured (https://kured.dev), when run with
+	// --annotate-nodes, on a node as soon as it finds the reboot sentinel of the node, so while
+	// the node waits for the reboot lock and before it gets drained. It is removed after the reboot.
+	KuredRebootInProgressAnnotation = "weave.works/kured-reboot-in-progress"
+)
+
+// RemovePodsOnNodesPendingOSUpgrade evicts pods from nodes carrying a signal
+// (annotation or label) that the node is about to be rebooted for an OS upgrade,
+// so the pods get moved ahead of the reboot window instead of all at once during the drain.
+type RemovePodsOnNodesPendingOSUpgrade struct {
+	handle          frameworktypes.Handle
+	args            *RemovePodsOnNodesPendingOSUpgradeArgs
+	podFilter       podutil.FilterFunc
+	nodeAnnotations []nodeSignal
+	nodeLabels      []nodeSignal
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsOnNodesPendingOSUpgrade{}
+
+// nodeSignal matches a node annotation or label by key, and optionally by value.
+type nodeSignal struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+func parseNodeSignals(signals []string) []nodeSignal {
+	parsed := make([]nodeSignal, 0, len(signals))
+	for _, signal := range signals {
+		key, value, hasValue := strings.Cut(signal, "=")
+		parsed = append(parsed, nodeSignal{key: key, value: value, hasValue: hasValue})
+	}
+	return parsed
+}
+
+func (s nodeSignal) matches(values map[string]string) bool {
+	value, ok := values[s.key]
+	if !ok {
+		return false
+	}
+	return !s.hasValue || value == s.value
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	pendingOSUpgradeArgs, ok := args.(*RemovePodsOnNodesPendingOSUpgradeArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsOnNodesPendingOSUpgradeArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if pendingOSUpgradeArgs.Namespaces != nil {
+		includedNamespaces = sets.New(pendingOSUpgradeArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(pendingOSUpgradeArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(pendingOSUpgradeArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsOnNodesPendingOSUpgrade{
+		handle:          handle,
+		args:            pendingOSUpgradeArgs,
+		podFilter:       podFilter,
+		nodeAnnotations: parseNodeSignals(pendingOSUpgradeArgs.NodeAnnotations),
+		nodeLabels:      parseNodeSignals(pendingOSUpgradeArgs.NodeLabels),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsOnNodesPendingOSUpgrade) Name() string {
+	return PluginName
+}
+
+// isPendingOSUpgrade checks whether any of the configured signals is present on the node
+func (d *RemovePodsOnNodesPendingOSUpgrade) isPendingOSUpgrade(node *v1.Node) bool {
+	for _, signal := range d.nodeAnnotations {
+		if signal.matches(node.Annotations) {
+			return true
+		}
+	}
+	for _, signal := range d.nodeLabels {
+		if signal.matches(node.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemovePodsOnNodesPendingOSUpgrade) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	for _, node := range nodes {
+		if !d.isPendingOSUpgrade(node) {
+			continue
+		}
+		logger.V(1).Info("Processing node pending an OS upgrade", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		// Move the least important pods first in case the node limit is reached
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+	loop:
+		for _, pod := range pods {
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
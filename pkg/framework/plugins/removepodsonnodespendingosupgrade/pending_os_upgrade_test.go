@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsonnodespendingosupgrade
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsOnNodesPendingOSUpgrade(t *testing.T) {
+	kuredNode := test.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+		node.Annotations = map[string]string{KuredRebootInProgressAnnotation: "2024-05-01T10:00:00Z"}
+	})
+	plainNode := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	labeledNode := test.BuildTestNode("n3", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels["example.com/os-upgrade"] = "pending"
+	})
+
+	buildPod := func(name, nodeName, namespace string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			pod.Namespace = namespace
+			pod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+		})
+	}
+
+	p1 := buildPod("p1", kuredNode.Name, "default")
+	p2 := buildPod("p2", kuredNode.Name, "default")
+	p3 := buildPod("p3", kuredNode.Name, "kube-system")
+	p4 := buildPod("p4", plainNode.Name, "default")
+	p5 := buildPod("p5", labeledNode.Name, "default")
+	// p6 is critical and protected by the default evictor
+	p6 := buildPod("p6", kuredNode.Name, "default")
+	priority := utils.SystemCriticalPriority
+	p6.Spec.Priority = &priority
+
+	tests := []struct {
+		description             string
+		pods                    []*v1.Pod
+		nodes                   []*v1.Node
+		args                    RemovePodsOnNodesPendingOSUpgradeArgs
+		maxPodsToEvictPerNode   *uint
+		expectedEvictedPodCount uint
+	}{
+		{
+			description: "Pods on nodes with the kured annotation are evicted",
+			pods:        []*v1.Pod{p1, p2, p4},
+			nodes:       []*v1.Node{kuredNode, plainNode},
+			args: RemovePodsOnNodesPendingOSUpgradeArgs{
+				NodeAnnotations: []string{KuredRebootInProgressAnnotation},
+			},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "Critical pods on nodes pending an upgrade are not evicted",
+			pods:        []*v1.Pod{p1, p6},
+			nodes:       []*v1.Node{kuredNode},
+			args: RemovePodsOnNodesPendingOSUpgradeArgs{
+				NodeAnnotations: []string{KuredRebootInProgressAnnotation},
+			},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Annotation value not matching, no pods evicted",
+			pods:        []*v1.Pod{p1, p2},
+			nodes:       []*v1.Node{kuredNode},
+			args: RemovePodsOnNodesPendingOSUpgradeArgs{
+				NodeAnnotations: []string{KuredRebootInProgressAnnotation + "=true"},
+			},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Pods on nodes with a matching label key and value are evicted",
+			pods:        []*v1.Pod{p1, p4, p5},
+			nodes:       []*v1.Node{kuredNode, plainNode, labeledNode},
+			args: RemovePodsOnNodesPendingOSUpgradeArgs{
+				NodeLabels: []string{"example.com/os-upgrade=pending"},
+			},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Excluded namespaces are skipped",
+			pods:        []*v1.Pod{p1, p3},
+			nodes:       []*v1.Node{kuredNode},
+			args: RemovePodsOnNodesPendingOSUpgradeArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Exclude: []string{"kube-system"},
+					},
+				},
+				NodeAnnotations: []string{KuredRebootInProgressAnnotation},
+			},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Max pods to evict per node is respected",
+			pods:        []*v1.Pod{p1, p2, p3},
+			nodes:       []*v1.Node{kuredNode},
+			args: RemovePodsOnNodesPendingOSUpgradeArgs{
+				NodeAnnotations: []string{KuredRebootInProgressAnnotation},
+			},
+			maxPodsToEvictPerNode:   utilptr.To[uint](1),
+			expectedEvictedPodCount: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions().WithMaxPodsToEvictPerNode(tc.maxPodsToEvictPerNode),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			actualEvictedPodCount := podEvictor.TotalEvicted()
+			if actualEvictedPodCount != tc.expectedEvictedPodCount {
+				t.Errorf("Test %#v failed, Unexpected no of pods evicted: pods evicted: %d, expected: %d", tc.description, actualEvictedPodCount, tc.expectedEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsonnodespendingosupgrade
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateRemovePodsOnNodesPendingOSUpgradeArgs validates RemovePodsOnNodesPendingOSUpgrade arguments
+func ValidateRemovePodsOnNodesPendingOSUpgradeArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsOnNodesPendingOSUpgradeArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if len(args.NodeAnnotations) == 0 && len(args.NodeLabels) == 0 {
+		return fmt.Errorf("at least one of nodeAnnotations or nodeLabels must be set")
+	}
+
+	if err := validateNodeSignals(args.NodeAnnotations); err != nil {
+		return err
+	}
+	return validateNodeSignals(args.NodeLabels)
+}
+
+// validateNodeSignals checks every signal is either a key or a key=value pair with a valid key
+func validateNodeSignals(signals []string) error {
+	for _, signal := range signals {
+		key, _, _ := strings.Cut(signal, "=")
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid node signal %q: %s", signal, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsonnodespendingosupgrade
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRemovePodsOnNodesPendingOSUpgradeArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *RemovePodsOnNodesPendingOSUpgradeArgs
+		expectError bool
+	}{
+		{
+			description: "valid namespace args, no errors",
+			args: &RemovePodsOnNodesPendingOSUpgradeArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+					},
+				},
+				NodeAnnotations: []string{KuredRebootInProgressAnnotation},
+			},
+			expectError: false,
+		},
+		{
+			description: "invalid namespaces args, expects error",
+			args: &RemovePodsOnNodesPendingOSUpgradeArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+						Exclude: []string{"kube-system"},
+					},
+				},
+				NodeAnnotations: []string{KuredRebootInProgressAnnotation},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector args, expects errors",
+			args: &RemovePodsOnNodesPendingOSUpgradeArgs{
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Operator: metav1.LabelSelectorOpIn,
+							},
+						},
+					},
+				},
+				NodeAnnotations: []string{KuredRebootInProgressAnnotation},
+			},
+			expectError: true,
+		},
+		{
+			description: "no node signals, expects error",
+			args:        &RemovePodsOnNodesPendingOSUpgradeArgs{},
+			expectError: true,
+		},
+		{
+			description: "valid node signals with and without value, no errors",
+			args: &RemovePodsOnNodesPendingOSUpgradeArgs{
+				NodeAnnotations: []string{"example.com/reboot-required=true"},
+				NodeLabels:      []string{"plan.upgrade.cattle.io/os-upgrade"},
+			},
+			expectError: false,
+		},
+		{
+			description: "invalid node annotation key, expects error",
+			args: &RemovePodsOnNodesPendingOSUpgradeArgs{
+				NodeAnnotations: []string{"=true"},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid node label key, expects error",
+			args: &RemovePodsOnNodesPendingOSUpgradeArgs{
+				NodeLabels: []string{"not a/valid/key"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemovePodsOnNodesPendingOSUpgradeArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingdedicatednodepolicy
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RemovePodsViolatingDedicatedNodePolicy"
+
+// RemovePodsViolatingDedicatedNodePolicy enforces dedicated nodes: the nodes labeled with the
+// name of a team, e.g. dedicated=team-a, only run the pods of that team. Pods of other teams which
+// slipped onto the nodes, e.g. before the nodes got tainted, are evicted. Nodes dedicated to a team
+// without dedication configured are left alone.
+type RemovePodsViolatingDedicatedNodePolicy struct {
+	handle          frameworktypes.Handle
+	args            *RemovePodsViolatingDedicatedNodePolicyArgs
+	podFilter       podutil.FilterFunc
+	dedications     map[string]*dedication
+	namespaceLister listersv1.NamespaceLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsViolatingDedicatedNodePolicy{}
+
+// dedication is a Dedication with its selectors parsed
+type dedication struct {
+	namespaces        sets.Set[string]
+	namespaceSelector labels.Selector
+	podSelector       labels.Selector
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	dedicatedArgs, ok := args.(*RemovePodsViolatingDedicatedNodePolicyArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsViolatingDedicatedNodePolicyArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if dedicatedArgs.Namespaces != nil {
+		includedNamespaces = sets.New(dedicatedArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(dedicatedArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(dedicatedArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	dedications := map[string]*dedication{}
+	for _, d := range dedicatedArgs.Dedications {
+		parsed := &dedication{namespaces: sets.New(d.Namespaces...)}
+		// A nil selector selects nothing
+		if parsed.namespaceSelector, err = metav1.LabelSelectorAsSelector(d.NamespaceSelector); err != nil {
+			return nil, fmt.Errorf("failed to get namespace selector of dedication %q: %v", d.Value, err)
+		}
+		if parsed.podSelector, err = metav1.LabelSelectorAsSelector(d.PodSelector); err != nil {
+			return nil, fmt.Errorf("failed to get pod selector of dedication %q: %v", d.Value, err)
+		}
+		dedications[d.Value] = parsed
+	}
+
+	return &RemovePodsViolatingDedicatedNodePolicy{
+		handle:          handle,
+		args:            dedicatedArgs,
+		podFilter:       podFilter,
+		dedications:     dedications,
+		namespaceLister: handle.SharedInformerFactory().Core().V1().Namespaces().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsViolatingDedicatedNodePolicy) Name() string {
+	return PluginName
+}
+
+// allows returns true if the pod belongs to the team of the dedication
+func (d *RemovePodsViolatingDedicatedNodePolicy) allows(logger klog.Logger, dedication *dedication, pod *v1.Pod) bool {
+	if dedication.namespaces.Has(pod.Namespace) || dedication.podSelector.Matches(labels.Set(pod.Labels)) {
+		return true
+	}
+	if dedication.namespaceSelector.Empty() {
+		return false
+	}
+	namespace, err := d.namespaceLister.Get(pod.Namespace)
+	if err != nil {
+		// The pod is given the benefit of the doubt
+		logger.V(4).Info("Unable to get namespace", "namespace", pod.Namespace, "err", err)
+		return true
+	}
+	return dedication.namespaceSelector.Matches(labels.Set(namespace.Labels))
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemovePodsViolatingDedicatedNodePolicy) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	for _, node := range nodes {
+		team, ok := node.Labels[d.args.NodeLabelKey]
+		if !ok {
+			continue
+		}
+		dedication, ok := d.dedications[team]
+		if !ok {
+			logger.V(4).Info("Node is dedicated to a team without dedication", "node", klog.KObj(node), "team", team)
+			continue
+		}
+		logger.V(2).Info("Processing node", "node", klog.KObj(node), "team", team)
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			if d.allows(logger, dedication, pod) {
+				continue
+			}
+			logger.V(2).Info("Pod trespasses on a node dedicated to another team", "pod", klog.KObj(pod), "node", klog.KObj(node), "team", team)
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
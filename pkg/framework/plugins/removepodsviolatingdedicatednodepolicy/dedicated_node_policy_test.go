@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingdedicatednodepolicy
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsViolatingDedicatedNodePolicy(t *testing.T) {
+	teamA := test.BuildTestNode("team-a", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"dedicated": "team-a"}
+	})
+	teamB := test.BuildTestNode("team-b", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"dedicated": "team-b"}
+	})
+	shared := test.BuildTestNode("shared", 2000, 3000, 10, nil)
+
+	buildNamespace := func(name string, labels map[string]string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	buildPod := func(name, namespace, node string, labels map[string]string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node, func(pod *v1.Pod) {
+			pod.Namespace = namespace
+			pod.Labels = labels
+			test.SetRSOwnerRef(pod)
+		})
+	}
+	objects := []runtime.Object{
+		buildNamespace("a1", nil),
+		buildNamespace("a2", map[string]string{"team": "a"}),
+		buildNamespace("b1", map[string]string{"team": "b"}),
+		buildNamespace("monitoring", nil),
+	}
+	dedications := []Dedication{
+		{
+			Value:             "team-a",
+			Namespaces:        []string{"a1"},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			PodSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "node-exporter"}},
+		},
+	}
+
+	tests := []struct {
+		description         string
+		args                RemovePodsViolatingDedicatedNodePolicyArgs
+		pods                []*v1.Pod
+		maxPodsToEvictTotal *uint
+		expectedEvicted     []string
+	}{
+		{
+			description: "pods of other teams are evicted from the dedicated nodes",
+			args:        RemovePodsViolatingDedicatedNodePolicyArgs{Dedications: dedications},
+			pods: []*v1.Pod{
+				buildPod("p1", "a1", teamA.Name, nil),
+				buildPod("p2", "a2", teamA.Name, nil),
+				buildPod("p3", "b1", teamA.Name, nil),
+				buildPod("p4", "monitoring", teamA.Name, map[string]string{"app": "node-exporter"}),
+				buildPod("p5", "monitoring", teamA.Name, map[string]string{"app": "grafana"}),
+			},
+			expectedEvicted: []string{"p3", "p5"},
+		},
+		{
+			description: "nodes without dedication or dedicated to an unknown team are left alone",
+			args:        RemovePodsViolatingDedicatedNodePolicyArgs{Dedications: dedications},
+			pods: []*v1.Pod{
+				buildPod("p1", "b1", shared.Name, nil),
+				buildPod("p2", "a1", teamB.Name, nil),
+			},
+			expectedEvicted: nil,
+		},
+		{
+			description: "custom node label key",
+			args: RemovePodsViolatingDedicatedNodePolicyArgs{
+				NodeLabelKey: "example.com/team",
+				Dedications:  dedications,
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", "b1", teamA.Name, nil),
+			},
+			expectedEvicted: nil,
+		},
+		{
+			description: "pods of excluded namespaces are not evicted",
+			args: RemovePodsViolatingDedicatedNodePolicyArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"monitoring"}},
+				},
+				Dedications: dedications,
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", "b1", teamA.Name, nil),
+				buildPod("p2", "monitoring", teamA.Name, nil),
+			},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "the total eviction limit stops the evictions",
+			args:        RemovePodsViolatingDedicatedNodePolicyArgs{Dedications: dedications},
+			pods: []*v1.Pod{
+				buildPod("p1", "b1", teamA.Name, nil),
+				buildPod("p2", "b1", teamA.Name, nil),
+			},
+			maxPodsToEvictTotal: utilptr.To[uint](1),
+			expectedEvicted:     nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			args := tc.args
+			SetDefaults_RemovePodsViolatingDedicatedNodePolicyArgs(&args)
+			s := scenario.New().
+				WithNodes(teamA, teamB, shared).
+				WithPods(tc.pods...).
+				WithObjects(objects...).
+				WithEvictionOptions(evictions.NewOptions().WithMaxPodsToEvictTotal(tc.maxPodsToEvictTotal))
+			if tc.maxPodsToEvictTotal != nil {
+				s.ExpectEvictedCount(*tc.maxPodsToEvictTotal)
+			} else {
+				s.ExpectEvicted(tc.expectedEvicted...)
+			}
+			s.Run(t, New, &args)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingdedicatednodepolicy
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsViolatingDedicatedNodePolicyArgs holds arguments used to configure RemovePodsViolatingDedicatedNodePolicy plugin.
+type RemovePodsViolatingDedicatedNodePolicyArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// NodeLabelKey is the key of the node label naming the team a node is dedicated to
+	NodeLabelKey string `json:"nodeLabelKey,omitempty"`
+	// Dedications maps the values of the node label to the pods allowed on the nodes
+	Dedications []Dedication `json:"dedications"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Dedication lists the pods allowed on the nodes dedicated to a team. A pod is allowed when it
+// matches any of the namespaces, the namespace selector or the pod selector.
+type Dedication struct {
+	// Value is the value of the node label of the nodes dedicated to the team
+	Value string `json:"value"`
+	// Namespaces lists the namespaces of the team
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces of the team through their labels
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// PodSelector selects the pods of the team through their labels, in any namespace
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
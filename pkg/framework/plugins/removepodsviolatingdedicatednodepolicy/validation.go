@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingdedicatednodepolicy
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateRemovePodsViolatingDedicatedNodePolicyArgs validates RemovePodsViolatingDedicatedNodePolicy arguments
+func ValidateRemovePodsViolatingDedicatedNodePolicyArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsViolatingDedicatedNodePolicyArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+	if errs := validation.IsQualifiedName(args.NodeLabelKey); len(errs) > 0 {
+		return fmt.Errorf("invalid node label key %q: %s", args.NodeLabelKey, strings.Join(errs, ", "))
+	}
+	if len(args.Dedications) == 0 {
+		return fmt.Errorf("at least one dedication is required")
+	}
+	values := sets.New[string]()
+	for _, dedication := range args.Dedications {
+		if dedication.Value == "" {
+			return fmt.Errorf("dedication value must not be empty")
+		}
+		if errs := validation.IsValidLabelValue(dedication.Value); len(errs) > 0 {
+			return fmt.Errorf("invalid dedication value %q: %s", dedication.Value, strings.Join(errs, ", "))
+		}
+		if values.Has(dedication.Value) {
+			return fmt.Errorf("dedication %q is declared more than once", dedication.Value)
+		}
+		values.Insert(dedication.Value)
+		if len(dedication.Namespaces) == 0 && dedication.NamespaceSelector == nil && dedication.PodSelector == nil {
+			return fmt.Errorf("dedication %q allows no pod, one of namespaces, namespaceSelector or podSelector is required", dedication.Value)
+		}
+		for _, selector := range []*metav1.LabelSelector{dedication.NamespaceSelector, dedication.PodSelector} {
+			if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+				return fmt.Errorf("invalid selector of dedication %q: %v", dedication.Value, err)
+			}
+		}
+	}
+	return nil
+}
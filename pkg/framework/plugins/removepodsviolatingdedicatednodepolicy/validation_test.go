@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingdedicatednodepolicy
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRemovePodsViolatingDedicatedNodePolicyArgs(t *testing.T) {
+	validArgs := func(mutate func(*RemovePodsViolatingDedicatedNodePolicyArgs)) *RemovePodsViolatingDedicatedNodePolicyArgs {
+		args := &RemovePodsViolatingDedicatedNodePolicyArgs{
+			Dedications: []Dedication{
+				{Value: "team-a", Namespaces: []string{"a1"}},
+				{Value: "team-b", PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}},
+			},
+		}
+		SetDefaults_RemovePodsViolatingDedicatedNodePolicyArgs(args)
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *RemovePodsViolatingDedicatedNodePolicyArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *RemovePodsViolatingDedicatedNodePolicyArgs) {
+				args.FilteringArgs = api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				}
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid node label key, expects error",
+			args: validArgs(func(args *RemovePodsViolatingDedicatedNodePolicyArgs) {
+				args.NodeLabelKey = "example.com/team/"
+			}),
+			expectError: true,
+		},
+		{
+			description: "no dedication, expects error",
+			args: validArgs(func(args *RemovePodsViolatingDedicatedNodePolicyArgs) {
+				args.Dedications = nil
+			}),
+			expectError: true,
+		},
+		{
+			description: "empty dedication value, expects error",
+			args: validArgs(func(args *RemovePodsViolatingDedicatedNodePolicyArgs) {
+				args.Dedications[0].Value = ""
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid dedication value, expects error",
+			args: validArgs(func(args *RemovePodsViolatingDedicatedNodePolicyArgs) {
+				args.Dedications[0].Value = "team a"
+			}),
+			expectError: true,
+		},
+		{
+			description: "duplicated dedication, expects error",
+			args: validArgs(func(args *RemovePodsViolatingDedicatedNodePolicyArgs) {
+				args.Dedications[1].Value = "team-a"
+			}),
+			expectError: true,
+		},
+		{
+			description: "dedication allowing no pod, expects error",
+			args: validArgs(func(args *RemovePodsViolatingDedicatedNodePolicyArgs) {
+				args.Dedications[0].Namespaces = nil
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid selector, expects error",
+			args: validArgs(func(args *RemovePodsViolatingDedicatedNodePolicyArgs) {
+				args.Dedications[1].PodSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Bogus"}}
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemovePodsViolatingDedicatedNodePolicyArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
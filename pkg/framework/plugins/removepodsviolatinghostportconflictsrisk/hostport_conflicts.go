@@ -0,0 +1,287 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatinghostportconflictsrisk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "RemovePodsViolatingHostPortConflictsRisk"
+
+// RemovePodsViolatingHostPortConflictsRisk resolves hostPort contention the scheduler can not
+// resolve on its own. It looks for pending pods requesting host ports (DaemonSet pods bound to
+// their node, or any other pod) which are blocked by pods already using the same host ports, and
+// evicts the conflicting pods when all of them can be moved to another node.
+//
+// DaemonSet pods are never evicted to make room, as they would be recreated on the same node.
+type RemovePodsViolatingHostPortConflictsRisk struct {
+	handle    frameworktypes.Handle
+	args      *RemovePodsViolatingHostPortConflictsRiskArgs
+	podFilter podutil.FilterFunc
+	podLister listersv1.PodLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsViolatingHostPortConflictsRisk{}
+
+var _ frameworktypes.AllPodsRequirer = &RemovePodsViolatingHostPortConflictsRiskArgs{}
+
+// RequiresAllPods tells the host ports in use on the nodes are read from all their pods,
+// including the DaemonSet pods usually left out of a restricted pod cache
+func (a *RemovePodsViolatingHostPortConflictsRiskArgs) RequiresAllPods() bool {
+	return true
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	hostPortArgs, ok := args.(*RemovePodsViolatingHostPortConflictsRiskArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsViolatingHostPortConflictsRiskArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if hostPortArgs.Namespaces != nil {
+		includedNamespaces = sets.New(hostPortArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(hostPortArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(hostPortArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsViolatingHostPortConflictsRisk{
+		handle:    handle,
+		args:      hostPortArgs,
+		podFilter: podFilter,
+		podLister: handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsViolatingHostPortConflictsRisk) Name() string {
+	return PluginName
+}
+
+// hostPort is a host port a pod binds to
+type hostPort struct {
+	ip       string
+	protocol v1.Protocol
+	port     int32
+}
+
+// conflicts returns true if both host ports can not be bound on the same node
+func (p hostPort) conflicts(other hostPort) bool {
+	if p.port != other.port || p.protocol != other.protocol {
+		return false
+	}
+	return p.ip == other.ip || p.ip == defaultBindAllHostIP || other.ip == defaultBindAllHostIP
+}
+
+const defaultBindAllHostIP = "0.0.0.0"
+
+// hostPorts returns the host ports requested by the containers and the sidecar containers of the pod
+func hostPorts(pod *v1.Pod) []hostPort {
+	var ports []hostPort
+	add := func(containers []v1.Container) {
+		for _, container := range containers {
+			for _, port := range container.Ports {
+				if port.HostPort <= 0 {
+					continue
+				}
+				p := hostPort{ip: port.HostIP, protocol: port.Protocol, port: port.HostPort}
+				if p.ip == "" {
+					p.ip = defaultBindAllHostIP
+				}
+				if p.protocol == "" {
+					p.protocol = v1.ProtocolTCP
+				}
+				ports = append(ports, p)
+			}
+		}
+	}
+	add(pod.Spec.Containers)
+	for _, container := range pod.Spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == v1.ContainerRestartPolicyAlways {
+			add([]v1.Container{container})
+		}
+	}
+	return ports
+}
+
+// conflictsWith returns true if one of the host ports of the pod conflicts with one of the given ports
+func conflictsWith(pod *v1.Pod, ports []hostPort) bool {
+	for _, podPort := range hostPorts(pod) {
+		for _, port := range ports {
+			if podPort.conflicts(port) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pendingHostPortPods returns the unschedulable pods requesting host ports, highest priority first
+func (d *RemovePodsViolatingHostPortConflictsRisk) pendingHostPortPods() ([]*v1.Pod, error) {
+	pods, err := d.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var pending []*v1.Pod
+	for _, pod := range pods {
+		if utils.IsPodUnschedulable(pod) && len(hostPorts(pod)) > 0 {
+			pending = append(pending, pod)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		if utils.GetPodPriority(pending[i]) != utils.GetPodPriority(pending[j]) {
+			return utils.GetPodPriority(pending[i]) > utils.GetPodPriority(pending[j])
+		}
+		return pending[i].CreationTimestamp.Before(&pending[j].CreationTimestamp)
+	})
+	return pending, nil
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemovePodsViolatingHostPortConflictsRisk) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	pending, err := d.pendingHostPortPods()
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pending pods: %v", err),
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	nodeSnapshot := d.handle.NodeSnapshot()
+	for _, pod := range pending {
+		candidates := nodes
+		if utils.IsDaemonsetPod(podutil.OwnerRef(pod)) {
+			node, ok := nodeSnapshot.Get(utils.DaemonSetPodTargetNode(pod))
+			if !ok {
+				continue
+			}
+			candidates = []*v1.Node{node}
+		}
+
+		node, victims := d.bestNode(logger, pod, candidates, nodes)
+		if node == nil {
+			logger.V(2).Info("Unable to resolve the host port conflicts of a pending pod", "pod", klog.KObj(pod))
+			continue
+		}
+		logger.V(2).Info("Evicting pods using the host ports of a pending pod", "pod", klog.KObj(pod), "node", klog.KObj(node), "victims", len(victims))
+		if !evictions.EvictPods(ctx, d.handle.Evictor().Evict, victims, evictions.EvictOptions{StrategyName: PluginName}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// bestNode returns the candidate node requiring the fewest evictions for the pending pod
+// to get its host ports, along with the pods to evict.
+func (d *RemovePodsViolatingHostPortConflictsRisk) bestNode(logger klog.Logger, pending *v1.Pod, candidates, nodes []*v1.Node) (*v1.Node, []*v1.Pod) {
+	var bestNode *v1.Node
+	var bestVictims []*v1.Pod
+	for _, node := range candidates {
+		victims, ok := d.conflictingPods(logger, pending, node, nodes)
+		if !ok || len(victims) == 0 {
+			continue
+		}
+		if bestNode == nil || len(victims) < len(bestVictims) {
+			bestNode, bestVictims = node, victims
+		}
+	}
+	return bestNode, bestVictims
+}
+
+// conflictingPods returns the pods of the node using the host ports of the pending pod. It
+// returns false when the pending pod can not be placed on the node or when one of the
+// conflicting pods can not be evicted, as evicting the other ones would not help.
+func (d *RemovePodsViolatingHostPortConflictsRisk) conflictingPods(logger klog.Logger, pending *v1.Pod, node *v1.Node, nodes []*v1.Node) ([]*v1.Pod, bool) {
+	if nodeutil.IsNodeUnschedulable(node) {
+		return nil, false
+	}
+	if ok, err := utils.PodMatchNodeSelector(pending, node); err != nil || !ok {
+		return nil, false
+	}
+	if !utils.TolerationsTolerateTaintsWithFilter(pending.Spec.Tolerations, node.Spec.Taints, func(taint *v1.Taint) bool {
+		return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
+	}) {
+		return nil, false
+	}
+
+	podsOnNode, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), func(pod *v1.Pod) bool {
+		return !utils.IsPodTerminating(pod)
+	})
+	if err != nil {
+		logger.Error(err, "Unable to list pods on a node", "node", klog.KObj(node))
+		return nil, false
+	}
+
+	ports := hostPorts(pending)
+	priority := utils.GetPodPriority(pending)
+	var victims []*v1.Pod
+	for _, pod := range podsOnNode {
+		if !conflictsWith(pod, ports) {
+			continue
+		}
+		if utils.IsDaemonsetPod(podutil.OwnerRef(pod)) || utils.GetPodPriority(pod) > priority || !d.podFilter(pod) || !d.fitsOtherNode(pod, nodes) {
+			return nil, false
+		}
+		victims = append(victims, pod)
+	}
+	return victims, true
+}
+
+// fitsOtherNode returns true if the pod fits another node where its host ports are free
+func (d *RemovePodsViolatingHostPortConflictsRisk) fitsOtherNode(pod *v1.Pod, nodes []*v1.Node) bool {
+	ports := hostPorts(pod)
+	for _, node := range nodes {
+		if node.Name == pod.Spec.NodeName || !nodeutil.PodFitsCurrentNode(d.handle.GetPodsAssignedToNodeFunc(), pod, node) {
+			continue
+		}
+		podsOnNode, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), func(p *v1.Pod) bool {
+			return !utils.IsPodTerminating(p) && conflictsWith(p, ports)
+		})
+		if err == nil && len(podsOnNode) == 0 {
+			return true
+		}
+	}
+	return false
+}
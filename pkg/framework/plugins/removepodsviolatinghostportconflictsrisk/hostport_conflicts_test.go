@@ -0,0 +1,280 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatinghostportconflictsrisk
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func withHostPort(port int32) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		pod.Spec.Containers[0].Ports = []v1.ContainerPort{{ContainerPort: port, HostPort: port}}
+	}
+}
+
+func buildPendingPod(name string, priority int32, apply ...func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, "", func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Priority = utilptr.To(priority)
+		pod.Status.Phase = v1.PodPending
+		pod.Status.Conditions = []v1.PodCondition{
+			{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable},
+		}
+		for _, f := range apply {
+			f(pod)
+		}
+	})
+}
+
+func buildPendingDaemonSetPod(name, nodeName string, priority int32, apply ...func(*v1.Pod)) *v1.Pod {
+	return buildPendingPod(name, priority, append([]func(*v1.Pod){func(pod *v1.Pod) {
+		test.SetDSOwnerRef(pod)
+		pod.Spec.Affinity = &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{
+							MatchFields: []v1.NodeSelectorRequirement{
+								{Key: metav1.ObjectNameField, Operator: v1.NodeSelectorOpIn, Values: []string{nodeName}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}}, apply...)...)
+}
+
+func buildRunningPod(name, nodeName string, cpu int64, priority int32, apply ...func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, cpu, 0, nodeName, func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Priority = utilptr.To(priority)
+		pod.Status.Phase = v1.PodRunning
+		for _, f := range apply {
+			f(pod)
+		}
+	})
+}
+
+func TestHostPort(t *testing.T) {
+	tests := []struct {
+		description string
+		a, b        hostPort
+		conflicts   bool
+	}{
+		{
+			description: "same port on all addresses",
+			a:           hostPort{ip: defaultBindAllHostIP, protocol: v1.ProtocolTCP, port: 80},
+			b:           hostPort{ip: defaultBindAllHostIP, protocol: v1.ProtocolTCP, port: 80},
+			conflicts:   true,
+		},
+		{
+			description: "same port on all addresses and on a given address",
+			a:           hostPort{ip: defaultBindAllHostIP, protocol: v1.ProtocolTCP, port: 80},
+			b:           hostPort{ip: "10.0.0.1", protocol: v1.ProtocolTCP, port: 80},
+			conflicts:   true,
+		},
+		{
+			description: "same port on different addresses",
+			a:           hostPort{ip: "10.0.0.2", protocol: v1.ProtocolTCP, port: 80},
+			b:           hostPort{ip: "10.0.0.1", protocol: v1.ProtocolTCP, port: 80},
+			conflicts:   false,
+		},
+		{
+			description: "same port with different protocols",
+			a:           hostPort{ip: defaultBindAllHostIP, protocol: v1.ProtocolUDP, port: 80},
+			b:           hostPort{ip: defaultBindAllHostIP, protocol: v1.ProtocolTCP, port: 80},
+			conflicts:   false,
+		},
+		{
+			description: "different ports",
+			a:           hostPort{ip: defaultBindAllHostIP, protocol: v1.ProtocolTCP, port: 80},
+			b:           hostPort{ip: defaultBindAllHostIP, protocol: v1.ProtocolTCP, port: 81},
+			conflicts:   false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := tc.a.conflicts(tc.b); got != tc.conflicts {
+				t.Errorf("expected conflicts to be %v, got %v", tc.conflicts, got)
+			}
+		})
+	}
+}
+
+func TestRemovePodsViolatingHostPortConflictsRisk(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	node3 := test.BuildTestNode("n3", 2000, 3000, 10, nil)
+
+	tests := []struct {
+		description          string
+		pods                 []*v1.Pod
+		nodes                []*v1.Node
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pod using the host port of a pending DaemonSet pod is evicted",
+			pods: []*v1.Pod{
+				buildPendingDaemonSetPod("pending", "n1", 100, withHostPort(9100)),
+				buildRunningPod("p1", "n1", 100, 0, withHostPort(9100)),
+				buildRunningPod("p2", "n1", 100, 0),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pending pod without host ports is ignored",
+			pods: []*v1.Pod{
+				buildPendingDaemonSetPod("pending", "n1", 100),
+				buildRunningPod("p1", "n1", 100, 0, withHostPort(9100)),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Conflicting pod not fitting another node is not evicted",
+			pods: []*v1.Pod{
+				buildPendingDaemonSetPod("pending", "n1", 100, withHostPort(9100)),
+				buildRunningPod("p1", "n1", 100, 0, withHostPort(9100)),
+				buildRunningPod("p2", "n2", 100, 0, withHostPort(9100)),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Conflicting pod with a higher priority is not evicted",
+			pods: []*v1.Pod{
+				buildPendingDaemonSetPod("pending", "n1", 0, withHostPort(9100)),
+				buildRunningPod("p1", "n1", 100, 100, withHostPort(9100)),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Conflicting DaemonSet pod is not evicted",
+			pods: []*v1.Pod{
+				buildPendingDaemonSetPod("pending", "n1", 100, withHostPort(9100)),
+				buildRunningPod("p1", "n1", 100, 0, withHostPort(9100), test.SetDSOwnerRef),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Nothing is evicted when one of the conflicting pods can not be evicted",
+			pods: []*v1.Pod{
+				buildPendingDaemonSetPod("pending", "n1", 100, func(pod *v1.Pod) {
+					pod.Spec.Containers[0].Ports = []v1.ContainerPort{{HostPort: 9100}, {HostPort: 9101}}
+				}),
+				buildRunningPod("p1", "n1", 100, 0, withHostPort(9100)),
+				buildRunningPod("p2", "n1", 100, 0, withHostPort(9101), func(pod *v1.Pod) {
+					// Bare pods are not evicted
+					pod.OwnerReferences = nil
+				}),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pending DaemonSet pod targeting a node not being processed",
+			pods: []*v1.Pod{
+				buildPendingDaemonSetPod("pending", "n3", 100, withHostPort(9100)),
+				buildRunningPod("p1", "n3", 100, 0, withHostPort(9100)),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pending regular pod gets the node requiring the fewest evictions",
+			pods: []*v1.Pod{
+				buildPendingPod("pending", 100, func(pod *v1.Pod) {
+					pod.Spec.Containers[0].Ports = []v1.ContainerPort{{HostPort: 9100}, {HostPort: 9101}}
+				}),
+				buildRunningPod("p1", "n1", 100, 0, withHostPort(9100)),
+				buildRunningPod("p2", "n1", 100, 0, withHostPort(9101)),
+				buildRunningPod("p3", "n2", 100, 0, func(pod *v1.Pod) {
+					pod.Spec.Containers[0].Ports = []v1.ContainerPort{{HostPort: 9100}, {HostPort: 9101}}
+				}),
+			},
+			nodes:                []*v1.Node{node1, node2, node3},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pending pod bound to another address is not blocked",
+			pods: []*v1.Pod{
+				buildPendingDaemonSetPod("pending", "n1", 100, func(pod *v1.Pod) {
+					pod.Spec.Containers[0].Ports = []v1.ContainerPort{{HostPort: 9100, HostIP: "10.0.0.1"}}
+				}),
+				buildRunningPod("p1", "n1", 100, 0, func(pod *v1.Pod) {
+					pod.Spec.Containers[0].Ports = []v1.ContainerPort{{HostPort: 9100, HostIP: "10.0.0.2"}}
+				}),
+			},
+			nodes:                []*v1.Node{node1, node2},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{node1, node2, node3}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+			// The nodes of the cycle are the ones being processed
+			handle.NodeSnapshotImpl = nodeutil.NewNodeSnapshot(0, tc.nodes)
+
+			plugin, err := New(&RemovePodsViolatingHostPortConflictsRiskArgs{}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
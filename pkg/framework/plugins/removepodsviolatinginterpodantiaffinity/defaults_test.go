@@ -33,19 +33,25 @@ func TestSetDefaults_RemovePodsViolatingInterPodAntiAffinityArgs(t *testing.T) {
 			name: "RemovePodsViolatingInterPodAntiAffinityArgs empty",
 			in:   &RemovePodsViolatingInterPodAntiAffinityArgs{},
 			want: &RemovePodsViolatingInterPodAntiAffinityArgs{
-				Namespaces:    nil,
-				LabelSelector: nil,
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    nil,
+					LabelSelector: nil,
+				},
 			},
 		},
 		{
 			name: "RemovePodsViolatingInterPodAntiAffinityArgs with value",
 			in: &RemovePodsViolatingInterPodAntiAffinityArgs{
-				Namespaces:    &api.Namespaces{},
-				LabelSelector: &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 			},
 			want: &RemovePodsViolatingInterPodAntiAffinityArgs{
-				Namespaces:    &api.Namespaces{},
-				LabelSelector: &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 			},
 		},
 	}
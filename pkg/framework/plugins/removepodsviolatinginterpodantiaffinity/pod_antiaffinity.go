@@ -42,6 +42,13 @@ type RemovePodsViolatingInterPodAntiAffinity struct {
 
 var _ frameworktypes.DeschedulePlugin = &RemovePodsViolatingInterPodAntiAffinity{}
 
+var _ frameworktypes.AllPodsRequirer = &RemovePodsViolatingInterPodAntiAffinityArgs{}
+
+// RequiresAllPods tells the anti-affinity of a pod is checked against all the pods of its node
+func (a *RemovePodsViolatingInterPodAntiAffinityArgs) RequiresAllPods() bool {
+	return true
+}
+
 // New builds plugin from its arguments while passing a handle
 func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
 	interPodAntiAffinityArgs, ok := args.(*RemovePodsViolatingInterPodAntiAffinityArgs)
@@ -77,6 +84,7 @@ func (d *RemovePodsViolatingInterPodAntiAffinity) Name() string {
 }
 
 func (d *RemovePodsViolatingInterPodAntiAffinity) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
 	pods, err := podutil.ListPodsOnNodes(nodes, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
 	if err != nil {
 		return &frameworktypes.Status{
@@ -86,11 +94,11 @@ func (d *RemovePodsViolatingInterPodAntiAffinity) Deschedule(ctx context.Context
 
 	podsInANamespace := podutil.GroupByNamespace(pods)
 	podsOnANode := podutil.GroupByNodeName(pods)
-	nodeMap := utils.CreateNodeMap(nodes)
+	nodeMap := d.handle.NodeSnapshot().NodeMap()
 
 loop:
 	for _, node := range nodes {
-		klog.V(2).InfoS("Processing node", "node", klog.KObj(node))
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
 		pods := podsOnANode[node.Name]
 		// sort the evict-able Pods based on priority, if there are multiple pods with same priority, they are sorted based on QoS tiers.
 		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
@@ -115,7 +123,7 @@ loop:
 					case *evictions.EvictionTotalLimitError:
 						return nil
 					default:
-						klog.Errorf("eviction failed: %v", err)
+						logger.Error(err, "Eviction failed")
 					}
 				}
 			}
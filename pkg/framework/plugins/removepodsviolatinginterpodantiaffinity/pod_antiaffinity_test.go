@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
@@ -232,7 +233,7 @@ func TestPodAntiAffinity(t *testing.T) {
 					WithMaxPodsToEvictPerNode(test.maxPodsToEvictPerNode).
 					WithMaxPodsToEvictPerNamespace(test.maxNoOfPodsToEvictPerNamespace).
 					WithMaxPodsToEvictTotal(test.maxNoOfPodsToEvictTotal),
-				defaultevictor.DefaultEvictorArgs{NodeFit: test.nodeFit},
+				defaultevictor.DefaultEvictorArgs{NodeFit: utilptr.To(test.nodeFit)},
 				nil,
 			)
 			if err != nil {
@@ -28,6 +28,5 @@ import (
 type RemovePodsViolatingInterPodAntiAffinityArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
-	Namespaces    *api.Namespaces       `json:"namespaces"`
-	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+	api.FilteringArgs `json:",inline"`
 }
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatinglimitranges
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "RemovePodsViolatingLimitRanges"
+
+// RemovePodsViolatingLimitRanges evicts pods which do not conform to the LimitRanges of their
+// namespace anymore, typically because a LimitRange got tightened after the pods were created.
+// LimitRanges are only enforced at admission, so such pods would otherwise keep running forever.
+// Once evicted, their owners recreate them and the misconfiguration surfaces at admission.
+type RemovePodsViolatingLimitRanges struct {
+	handle           frameworktypes.Handle
+	args             *RemovePodsViolatingLimitRangesArgs
+	podFilter        podutil.FilterFunc
+	limitRangeLister listersv1.LimitRangeLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsViolatingLimitRanges{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	limitRangesArgs, ok := args.(*RemovePodsViolatingLimitRangesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsViolatingLimitRangesArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if limitRangesArgs.Namespaces != nil {
+		includedNamespaces = sets.New(limitRangesArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(limitRangesArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(limitRangesArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsViolatingLimitRanges{
+		handle:    handle,
+		args:      limitRangesArgs,
+		podFilter: podFilter,
+		// The LimitRanges are read from the cluster, the cached client of the dry run mode holds none
+		limitRangeLister: handle.ClusterInformerFactory().Core().V1().LimitRanges().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsViolatingLimitRanges) Name() string {
+	return PluginName
+}
+
+// checkMinMax reports the resources of a list falling outside of the min/max bounds of a LimitRange item
+func checkMinMax(kind, name, kindOfValues string, values v1.ResourceList, item v1.LimitRangeItem) []string {
+	var violations []string
+	for resourceName, minimum := range item.Min {
+		if value, ok := values[resourceName]; ok && value.Cmp(minimum) < 0 {
+			violations = append(violations, fmt.Sprintf("%s %s %s %s of %s is below the minimum of %s", kind, name, resourceName, kindOfValues, value.String(), minimum.String()))
+		}
+	}
+	for resourceName, maximum := range item.Max {
+		if value, ok := values[resourceName]; ok && value.Cmp(maximum) > 0 {
+			violations = append(violations, fmt.Sprintf("%s %s %s %s of %s is above the maximum of %s", kind, name, resourceName, kindOfValues, value.String(), maximum.String()))
+		}
+	}
+	return violations
+}
+
+// checkLimitRequestRatio reports the resources whose limit to request ratio exceeds the one of a LimitRange item
+func checkLimitRequestRatio(kind, name string, requests, limits v1.ResourceList, item v1.LimitRangeItem) []string {
+	var violations []string
+	for resourceName, maxRatio := range item.MaxLimitRequestRatio {
+		request, hasRequest := requests[resourceName]
+		limit, hasLimit := limits[resourceName]
+		if !hasRequest || !hasLimit || request.IsZero() {
+			continue
+		}
+		ratio := float64(limit.MilliValue()) / float64(request.MilliValue())
+		if ratio > maxRatio.AsApproximateFloat64() {
+			violations = append(violations, fmt.Sprintf("%s %s %s limit to request ratio of %.2f is above the maximum of %s", kind, name, resourceName, ratio, maxRatio.String()))
+		}
+	}
+	return violations
+}
+
+// limitRangeViolations lists the constraints of the LimitRanges the pod does not conform to.
+// Only values set on the pod are checked: missing requests and limits get defaulted
+// by the LimitRanges once the pod is recreated.
+func limitRangeViolations(pod *v1.Pod, limitRanges []*v1.LimitRange) []string {
+	var violations []string
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			switch item.Type {
+			case v1.LimitTypeContainer:
+				for _, containers := range [][]v1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+					for _, container := range containers {
+						violations = append(violations, checkMinMax("container", container.Name, "request", container.Resources.Requests, item)...)
+						violations = append(violations, checkMinMax("container", container.Name, "limit", container.Resources.Limits, item)...)
+						violations = append(violations, checkLimitRequestRatio("container", container.Name, container.Resources.Requests, container.Resources.Limits, item)...)
+					}
+				}
+			case v1.LimitTypePod:
+				requests, limits := utils.PodRequestsAndLimits(pod)
+				violations = append(violations, checkMinMax("pod", pod.Name, "request", requests, item)...)
+				violations = append(violations, checkMinMax("pod", pod.Name, "limit", limits, item)...)
+				violations = append(violations, checkLimitRequestRatio("pod", pod.Name, requests, limits, item)...)
+			}
+		}
+	}
+	return violations
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemovePodsViolatingLimitRanges) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	limitRangeList, err := d.limitRangeLister.List(labels.Everything())
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing limit ranges: %v", err),
+		}
+	}
+	if len(limitRangeList) == 0 {
+		return nil
+	}
+	limitRanges := map[string][]*v1.LimitRange{}
+	for _, limitRange := range limitRangeList {
+		limitRanges[limitRange.Namespace] = append(limitRanges[limitRange.Namespace], limitRange)
+	}
+
+	podFilter := podutil.WrapFilterFuncs(func(pod *v1.Pod) bool {
+		return len(limitRanges[pod.Namespace]) > 0
+	}, d.podFilter)
+
+	for _, node := range nodes {
+		logger.V(1).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			violations := limitRangeViolations(pod, limitRanges[pod.Namespace])
+			if len(violations) == 0 {
+				continue
+			}
+			logger.V(2).Info("Pod does not conform to the limit ranges of its namespace", "pod", klog.KObj(pod), "violations", violations)
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
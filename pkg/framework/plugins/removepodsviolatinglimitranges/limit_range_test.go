@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatinglimitranges
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildLimitRange(name, namespace string, item v1.LimitRangeItem) *v1.LimitRange {
+	return &v1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1.LimitRangeSpec{
+			Limits: []v1.LimitRangeItem{item},
+		},
+	}
+}
+
+func buildPodWithResources(name, namespace, nodeName string, requests, limits v1.ResourceList) *v1.Pod {
+	return test.BuildTestPod(name, 0, 0, nodeName, func(pod *v1.Pod) {
+		pod.Namespace = namespace
+		test.SetRSOwnerRef(pod)
+		pod.Spec.Containers[0].Resources = v1.ResourceRequirements{Requests: requests, Limits: limits}
+	})
+}
+
+func TestLimitRangeViolations(t *testing.T) {
+	cpu := func(value string) v1.ResourceList {
+		return v1.ResourceList{v1.ResourceCPU: resource.MustParse(value)}
+	}
+
+	tests := []struct {
+		description        string
+		item               v1.LimitRangeItem
+		requests, limits   v1.ResourceList
+		expectedViolations int
+	}{
+		{
+			description: "container within bounds",
+			item:        v1.LimitRangeItem{Type: v1.LimitTypeContainer, Min: cpu("100m"), Max: cpu("1")},
+			requests:    cpu("200m"),
+			limits:      cpu("500m"),
+		},
+		{
+			description:        "container limit above maximum",
+			item:               v1.LimitRangeItem{Type: v1.LimitTypeContainer, Max: cpu("1")},
+			requests:           cpu("200m"),
+			limits:             cpu("2"),
+			expectedViolations: 1,
+		},
+		{
+			description:        "container request below minimum",
+			item:               v1.LimitRangeItem{Type: v1.LimitTypeContainer, Min: cpu("300m")},
+			requests:           cpu("200m"),
+			limits:             cpu("500m"),
+			expectedViolations: 1,
+		},
+		{
+			description:        "container limit to request ratio above maximum",
+			item:               v1.LimitRangeItem{Type: v1.LimitTypeContainer, MaxLimitRequestRatio: cpu("2")},
+			requests:           cpu("200m"),
+			limits:             cpu("500m"),
+			expectedViolations: 1,
+		},
+		{
+			description: "missing values are not checked",
+			item:        v1.LimitRangeItem{Type: v1.LimitTypeContainer, Min: cpu("300m"), Max: cpu("1"), MaxLimitRequestRatio: cpu("2")},
+		},
+		{
+			description:        "pod limit above maximum",
+			item:               v1.LimitRangeItem{Type: v1.LimitTypePod, Max: cpu("1")},
+			requests:           cpu("200m"),
+			limits:             cpu("2"),
+			expectedViolations: 1,
+		},
+		{
+			description: "persistent volume claim limits are ignored",
+			item: v1.LimitRangeItem{Type: v1.LimitTypePersistentVolumeClaim, Max: v1.ResourceList{
+				v1.ResourceStorage: resource.MustParse("1Gi"),
+			}},
+			requests: cpu("200m"),
+			limits:   cpu("2"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := buildPodWithResources("p1", "default", "n1", tc.requests, tc.limits)
+			violations := limitRangeViolations(pod, []*v1.LimitRange{buildLimitRange("lr", "default", tc.item)})
+			if len(violations) != tc.expectedViolations {
+				t.Errorf("Expected %v violations, got %v: %v", tc.expectedViolations, len(violations), violations)
+			}
+		})
+	}
+}
+
+func TestRemovePodsViolatingLimitRanges(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	memory := func(value string) v1.ResourceList {
+		return v1.ResourceList{v1.ResourceMemory: resource.MustParse(value)}
+	}
+
+	// the limit range got tightened to 512Mi after the pods were created
+	limitRange := buildLimitRange("lr", "team-a", v1.LimitRangeItem{Type: v1.LimitTypeContainer, Max: memory("512Mi")})
+
+	p1 := buildPodWithResources("p1", "team-a", node1.Name, memory("256Mi"), memory("1Gi"))
+	p2 := buildPodWithResources("p2", "team-a", node2.Name, memory("256Mi"), memory("2Gi"))
+	p3 := buildPodWithResources("p3", "team-a", node1.Name, memory("256Mi"), memory("512Mi"))
+	// no limit range in the namespace
+	p4 := buildPodWithResources("p4", "team-b", node1.Name, memory("256Mi"), memory("1Gi"))
+
+	tests := []struct {
+		description     string
+		objects         []runtime.Object
+		args            RemovePodsViolatingLimitRangesArgs
+		nodes           []*v1.Node
+		expectedEvicted uint
+	}{
+		{
+			description:     "Pods exceeding the limit range are evicted",
+			objects:         []runtime.Object{limitRange},
+			nodes:           []*v1.Node{node1, node2},
+			expectedEvicted: 2,
+		},
+		{
+			description:     "No limit ranges, no eviction",
+			nodes:           []*v1.Node{node1, node2},
+			expectedEvicted: 0,
+		},
+		{
+			description:     "Only pods on the given nodes are evicted",
+			objects:         []runtime.Object{limitRange},
+			nodes:           []*v1.Node{node1},
+			expectedEvicted: 1,
+		},
+		{
+			description: "Pods in excluded namespaces are ignored",
+			objects:     []runtime.Object{limitRange},
+			args: RemovePodsViolatingLimitRangesArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Exclude: []string{"team-a"},
+					},
+				},
+			},
+			nodes:           []*v1.Node{node1, node2},
+			expectedEvicted: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{node1, node2, p1, p2, p3, p4}
+			objs = append(objs, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			// Start the LimitRange informer requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvicted {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvicted, actualEvictedPodCount)
+			}
+		})
+	}
+}
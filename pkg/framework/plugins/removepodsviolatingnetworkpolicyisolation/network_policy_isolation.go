@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingnetworkpolicyisolation
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RemovePodsViolatingNetworkPolicyIsolation"
+
+// RemovePodsViolatingNetworkPolicyIsolation evicts pods running on nodes excluded by the node selector
+// declared on their namespace, e.g. because the PodNodeSelector admission plugin was not enabled
+// when the pods were created, or the namespace node selector was set afterwards.
+type RemovePodsViolatingNetworkPolicyIsolation struct {
+	handle          frameworktypes.Handle
+	args            *RemovePodsViolatingNetworkPolicyIsolationArgs
+	podFilter       podutil.FilterFunc
+	namespaceLister listersv1.NamespaceLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsViolatingNetworkPolicyIsolation{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	isolationArgs, ok := args.(*RemovePodsViolatingNetworkPolicyIsolationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsViolatingNetworkPolicyIsolationArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if isolationArgs.Namespaces != nil {
+		includedNamespaces = sets.New(isolationArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(isolationArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(isolationArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsViolatingNetworkPolicyIsolation{
+		handle:          handle,
+		args:            isolationArgs,
+		podFilter:       podFilter,
+		namespaceLister: handle.SharedInformerFactory().Core().V1().Namespaces().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsViolatingNetworkPolicyIsolation) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemovePodsViolatingNetworkPolicyIsolation) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	// The node selectors are parsed once per namespace and cycle, nil when the namespace does not declare any
+	selectors := map[string]labels.Selector{}
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			selector, ok := selectors[pod.Namespace]
+			if !ok {
+				selector = d.namespaceNodeSelector(logger, pod.Namespace)
+				selectors[pod.Namespace] = selector
+			}
+			if selector == nil || selector.Matches(labels.Set(node.Labels)) {
+				continue
+			}
+			logger.V(2).Info("Pod runs on a node excluded by the node selector of its namespace", "pod", klog.KObj(pod), "node", klog.KObj(node), "nodeSelector", selector.String())
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// namespaceNodeSelector returns the node selector declared on the namespace,
+// nil when the namespace does not declare any or it can not be parsed
+func (d *RemovePodsViolatingNetworkPolicyIsolation) namespaceNodeSelector(logger klog.Logger, name string) labels.Selector {
+	namespace, err := d.namespaceLister.Get(name)
+	if err != nil {
+		logger.V(4).Info("Unable to get namespace", "namespace", name, "err", err)
+		return nil
+	}
+	annotation, ok := namespace.Annotations[d.args.NamespaceAnnotation]
+	if !ok || annotation == "" {
+		return nil
+	}
+	// The annotation is a list of key=value pairs, as read by the PodNodeSelector admission plugin
+	set, err := labels.ConvertSelectorToLabelsMap(annotation)
+	if err != nil {
+		logger.Error(err, "Invalid node selector of namespace", "namespace", name, "annotation", d.args.NamespaceAnnotation)
+		return nil
+	}
+	return labels.SelectorFromSet(set)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingnetworkpolicyisolation
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildNamespace(name, annotation, nodeSelector string) *v1.Namespace {
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if annotation != "" {
+		namespace.Annotations = map[string]string{annotation: nodeSelector}
+	}
+	return namespace
+}
+
+func buildPod(name, namespace, node string) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, node, func(pod *v1.Pod) {
+		pod.Namespace = namespace
+		test.SetRSOwnerRef(pod)
+	})
+}
+
+func TestRemovePodsViolatingNetworkPolicyIsolation(t *testing.T) {
+	tenantA := test.BuildTestNode("tenant-a", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"tenant": "a", "zone": "z1"}
+	})
+	tenantB := test.BuildTestNode("tenant-b", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"tenant": "b", "zone": "z1"}
+	})
+	nodes := []*v1.Node{tenantA, tenantB}
+
+	tests := []struct {
+		description          string
+		args                 RemovePodsViolatingNetworkPolicyIsolationArgs
+		objects              []runtime.Object
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pods on nodes excluded by the namespace node selector are evicted",
+			args:        RemovePodsViolatingNetworkPolicyIsolationArgs{NamespaceAnnotation: DefaultNamespaceAnnotation},
+			objects: []runtime.Object{
+				buildNamespace("ns-a", DefaultNamespaceAnnotation, "tenant=a,zone=z1"),
+				buildPod("p1", "ns-a", tenantA.Name),
+				buildPod("p2", "ns-a", tenantB.Name),
+				buildPod("p3", "ns-a", tenantB.Name),
+			},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Pods of namespaces without node selector are not evicted",
+			args:        RemovePodsViolatingNetworkPolicyIsolationArgs{NamespaceAnnotation: DefaultNamespaceAnnotation},
+			objects: []runtime.Object{
+				buildNamespace("ns-a", "", ""),
+				buildNamespace("ns-b", DefaultNamespaceAnnotation, ""),
+				buildPod("p1", "ns-a", tenantB.Name),
+				buildPod("p2", "ns-b", tenantA.Name),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Invalid node selectors are ignored",
+			args:        RemovePodsViolatingNetworkPolicyIsolationArgs{NamespaceAnnotation: DefaultNamespaceAnnotation},
+			objects: []runtime.Object{
+				buildNamespace("ns-a", DefaultNamespaceAnnotation, "tenant in (a)"),
+				buildPod("p1", "ns-a", tenantB.Name),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Custom namespace annotation",
+			args:        RemovePodsViolatingNetworkPolicyIsolationArgs{NamespaceAnnotation: "example.com/node-selector"},
+			objects: []runtime.Object{
+				buildNamespace("ns-a", DefaultNamespaceAnnotation, "tenant=b"),
+				buildNamespace("ns-b", "example.com/node-selector", "tenant=b"),
+				buildPod("p1", "ns-a", tenantA.Name),
+				buildPod("p2", "ns-b", tenantA.Name),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods of excluded namespaces are not evicted",
+			args: RemovePodsViolatingNetworkPolicyIsolationArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"ns-a"}},
+				},
+				NamespaceAnnotation: DefaultNamespaceAnnotation,
+			},
+			objects: []runtime.Object{
+				buildNamespace("ns-a", DefaultNamespaceAnnotation, "tenant=a"),
+				buildPod("p1", "ns-a", tenantB.Name),
+			},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := append([]runtime.Object{tenantA, tenantB}, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			// Start the namespace informer requested by the plugin
+			handle.SharedInformerFactoryImpl.Start(ctx.Done())
+			handle.SharedInformerFactoryImpl.WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingnetworkpolicyisolation
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateRemovePodsViolatingNetworkPolicyIsolationArgs validates RemovePodsViolatingNetworkPolicyIsolation arguments
+func ValidateRemovePodsViolatingNetworkPolicyIsolationArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsViolatingNetworkPolicyIsolationArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if errs := validation.IsQualifiedName(args.NamespaceAnnotation); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace annotation %q: %s", args.NamespaceAnnotation, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
@@ -33,19 +33,25 @@ func TestSetDefaults_RemovePodsViolatingNodeAffinityArgs(t *testing.T) {
 			name: "RemovePodsViolatingNodeAffinityArgs empty",
 			in:   &RemovePodsViolatingNodeAffinityArgs{},
 			want: &RemovePodsViolatingNodeAffinityArgs{
-				Namespaces:    nil,
-				LabelSelector: nil,
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    nil,
+					LabelSelector: nil,
+				},
 			},
 		},
 		{
 			name: "RemovePodsViolatingNodeAffinityArgs with value",
 			in: &RemovePodsViolatingNodeAffinityArgs{
-				Namespaces:    &api.Namespaces{},
-				LabelSelector: &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 			},
 			want: &RemovePodsViolatingNodeAffinityArgs{
-				Namespaces:    &api.Namespaces{},
-				LabelSelector: &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 			},
 		},
 	}
@@ -77,8 +77,9 @@ func (d *RemovePodsViolatingNodeAffinity) Name() string {
 }
 
 func (d *RemovePodsViolatingNodeAffinity) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
 	for _, nodeAffinity := range d.args.NodeAffinityType {
-		klog.V(2).InfoS("Executing for nodeAffinityType", "nodeAffinity", nodeAffinity)
+		logger.V(2).Info("Executing for nodeAffinityType", "nodeAffinity", nodeAffinity)
 		var err *frameworktypes.Status = nil
 
 		// The pods that we'll evict must be evictable. For example, the current number of replicas
@@ -97,16 +98,16 @@ func (d *RemovePodsViolatingNodeAffinity) Deschedule(ctx context.Context, nodes
 			err = d.processNodes(ctx, nodes, filterFunc)
 		case "preferredDuringSchedulingIgnoredDuringExecution":
 			// In this specific case, the pod must have a better fit on another node than
-			// in the current one based on the preferred node affinity
+			// in the current one based on the preferred node affinity, by more than the
+			// configured improvement to avoid moving pods back and forth for little gain
 			filterFunc := func(pod *v1.Pod, node *v1.Node, nodes []*v1.Node) bool {
 				return utils.PodHasNodeAffinity(pod, utils.PreferredDuringSchedulingIgnoredDuringExecution) &&
 					d.handle.Evictor().Filter(pod) &&
-					nodeutil.PodFitsAnyNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes) &&
-					(nodeutil.GetBestNodeWeightGivenPodPreferredAffinity(pod, nodes) > nodeutil.GetNodeWeightGivenPodPreferredAffinity(pod, node))
+					d.hasBetterPreferredNode(pod, node, nodes)
 			}
 			err = d.processNodes(ctx, nodes, filterFunc)
 		default:
-			klog.ErrorS(nil, "Invalid nodeAffinityType", "nodeAffinity", nodeAffinity)
+			logger.Error(nil, "Invalid nodeAffinityType", "nodeAffinity", nodeAffinity)
 		}
 
 		if err != nil {
@@ -116,9 +117,26 @@ func (d *RemovePodsViolatingNodeAffinity) Deschedule(ctx context.Context, nodes
 	return nil
 }
 
+// hasBetterPreferredNode returns true if the pod fits on another node it gives a preferred node affinity
+// weight greater than the one of its current node by more than MinPreferredWeightImprovement
+func (d *RemovePodsViolatingNodeAffinity) hasBetterPreferredNode(pod *v1.Pod, node *v1.Node, nodes []*v1.Node) bool {
+	minWeight := nodeutil.GetNodeWeightGivenPodPreferredAffinity(pod, node) + d.args.MinPreferredWeightImprovement
+	if nodeutil.GetBestNodeWeightGivenPodPreferredAffinity(pod, nodes) <= minWeight {
+		return false
+	}
+	var candidates []*v1.Node
+	for _, candidate := range nodes {
+		if candidate.Name != node.Name && nodeutil.GetNodeWeightGivenPodPreferredAffinity(pod, candidate) > minWeight {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return nodeutil.PodFitsAnyNode(d.handle.GetPodsAssignedToNodeFunc(), pod, candidates)
+}
+
 func (d *RemovePodsViolatingNodeAffinity) processNodes(ctx context.Context, nodes []*v1.Node, filterFunc func(*v1.Pod, *v1.Node, []*v1.Node) bool) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
 	for _, node := range nodes {
-		klog.V(2).InfoS("Processing node", "node", klog.KObj(node))
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
 
 		// Potentially evictable pods
 		pods, err := podutil.ListPodsOnANode(
@@ -136,7 +154,7 @@ func (d *RemovePodsViolatingNodeAffinity) processNodes(ctx context.Context, node
 
 	loop:
 		for _, pod := range pods {
-			klog.V(1).InfoS("Evicting pod", "pod", klog.KObj(pod))
+			logger.V(1).Info("Evicting pod", "pod", klog.KObj(pod))
 			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
 			if err == nil {
 				continue
@@ -147,7 +165,7 @@ func (d *RemovePodsViolatingNodeAffinity) processNodes(ctx context.Context, node
 			case *evictions.EvictionTotalLimitError:
 				return nil
 			default:
-				klog.Errorf("eviction failed: %v", err)
+				logger.Error(err, "Eviction failed")
 			}
 		}
 	}
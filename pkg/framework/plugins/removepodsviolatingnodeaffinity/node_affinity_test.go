@@ -17,18 +17,15 @@ limitations under the License.
 package removepodsviolatingnodeaffinity
 
 import (
-	"context"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
-	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
-	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/test"
 )
 
@@ -39,6 +36,7 @@ func TestRemovePodsViolatingNodeAffinity(t *testing.T) {
 	nodeWithLabels.Labels[nodeLabelKey] = nodeLabelValue
 
 	nodeWithoutLabels := test.BuildTestNode("nodeWithoutLabels", 2000, 3000, 10, nil)
+	anotherNodeWithoutLabels := test.BuildTestNode("anotherNodeWithoutLabels", 2000, 3000, 10, nil)
 
 	unschedulableNodeWithLabels := test.BuildTestNode("unschedulableNodeWithLabels", 2000, 3000, 10, nil)
 	unschedulableNodeWithLabels.Labels[nodeLabelKey] = nodeLabelValue
@@ -338,51 +336,52 @@ func TestRemovePodsViolatingNodeAffinity(t *testing.T) {
 			maxPodsToEvictPerNode: &uint1,
 			nodefit:               true,
 		},
+		{
+			description:             "Pod is scheduled on node without matching labels, another node with a better fit is available, improvement below the minimum, should not evict [preferred affinity]",
+			expectedEvictedPodCount: 0,
+			args: RemovePodsViolatingNodeAffinityArgs{
+				NodeAffinityType:              []string{"preferredDuringSchedulingIgnoredDuringExecution"},
+				MinPreferredWeightImprovement: 10,
+			},
+			pods:  addPodsToNode(nodeWithoutLabels, nil, "preferredDuringSchedulingIgnoredDuringExecution"),
+			nodes: []*v1.Node{nodeWithLabels, nodeWithoutLabels},
+		},
+		{
+			description:             "Pod is scheduled on node without matching labels, another node with a better fit is available, improvement above the minimum, should be evicted [preferred affinity]",
+			expectedEvictedPodCount: 1,
+			args: RemovePodsViolatingNodeAffinityArgs{
+				NodeAffinityType:              []string{"preferredDuringSchedulingIgnoredDuringExecution"},
+				MinPreferredWeightImprovement: 9,
+			},
+			pods:  addPodsToNode(nodeWithoutLabels, nil, "preferredDuringSchedulingIgnoredDuringExecution"),
+			nodes: []*v1.Node{nodeWithLabels, nodeWithoutLabels},
+		},
+		{
+			description:             "Pod is scheduled on node without matching labels, the only node with a better fit is unschedulable, should not evict [preferred affinity]",
+			expectedEvictedPodCount: 0,
+			args: RemovePodsViolatingNodeAffinityArgs{
+				NodeAffinityType: []string{"preferredDuringSchedulingIgnoredDuringExecution"},
+			},
+			pods:  addPodsToNode(nodeWithoutLabels, nil, "preferredDuringSchedulingIgnoredDuringExecution"),
+			nodes: []*v1.Node{nodeWithoutLabels, anotherNodeWithoutLabels, unschedulableNodeWithLabels},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.description, func(t *testing.T) {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-
-			var objs []runtime.Object
-			for _, node := range tc.nodes {
-				objs = append(objs, node)
-			}
-			for _, pod := range tc.pods {
-				objs = append(objs, pod)
-			}
-			fakeClient := fake.NewSimpleClientset(objs...)
-
-			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
-				ctx,
-				fakeClient,
-				evictions.NewOptions().
+			scenario.New().
+				WithNodes(tc.nodes...).
+				WithPods(tc.pods...).
+				WithEvictionOptions(evictions.NewOptions().
 					WithMaxPodsToEvictPerNode(tc.maxPodsToEvictPerNode).
 					WithMaxPodsToEvictPerNamespace(tc.maxNoOfPodsToEvictPerNamespace).
-					WithMaxPodsToEvictTotal(tc.maxNoOfPodsToEvictTotal),
-				defaultevictor.DefaultEvictorArgs{NodeFit: tc.nodefit},
-				nil,
-			)
-			if err != nil {
-				t.Fatalf("Unable to initialize a framework handle: %v", err)
-			}
-
-			plugin, err := New(
-				&RemovePodsViolatingNodeAffinityArgs{
-					NodeAffinityType: tc.args.NodeAffinityType,
-				},
-				handle,
-			)
-			if err != nil {
-				t.Fatalf("Unable to initialize the plugin: %v", err)
-			}
-
-			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
-			actualEvictedPodCount := podEvictor.TotalEvicted()
-			if actualEvictedPodCount != tc.expectedEvictedPodCount {
-				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedPodCount, actualEvictedPodCount)
-			}
+					WithMaxPodsToEvictTotal(tc.maxNoOfPodsToEvictTotal)).
+				WithDefaultEvictorArgs(defaultevictor.DefaultEvictorArgs{NodeFit: utilptr.To(tc.nodefit)}).
+				ExpectEvictedCount(tc.expectedEvictedPodCount).
+				Run(t, New, &RemovePodsViolatingNodeAffinityArgs{
+					NodeAffinityType:              tc.args.NodeAffinityType,
+					MinPreferredWeightImprovement: tc.args.MinPreferredWeightImprovement,
+				})
 		})
 	}
 }
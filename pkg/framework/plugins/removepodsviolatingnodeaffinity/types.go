@@ -28,7 +28,10 @@ import (
 type RemovePodsViolatingNodeAffinityArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
-	Namespaces       *api.Namespaces       `json:"namespaces"`
-	LabelSelector    *metav1.LabelSelector `json:"labelSelector"`
-	NodeAffinityType []string              `json:"nodeAffinityType"`
+	api.FilteringArgs `json:",inline"`
+	NodeAffinityType  []string `json:"nodeAffinityType"`
+	// MinPreferredWeightImprovement is the minimum difference between the preferred node affinity weight
+	// of the best node a pod fits on and the one of its current node for the pod to be evicted,
+	// when handling preferredDuringSchedulingIgnoredDuringExecution. Any improvement is enough by default.
+	MinPreferredWeightImprovement int32 `json:"minPreferredWeightImprovement,omitempty"`
 }
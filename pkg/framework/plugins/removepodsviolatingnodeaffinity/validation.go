@@ -19,7 +19,6 @@ package removepodsviolatingnodeaffinity
 import (
 	"fmt"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -30,15 +29,12 @@ func ValidateRemovePodsViolatingNodeAffinityArgs(obj runtime.Object) error {
 		return fmt.Errorf("nodeAffinityType needs to be set")
 	}
 
-	// At most one of include/exclude can be set
-	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
-		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
 	}
 
-	if args.LabelSelector != nil {
-		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
-			return fmt.Errorf("failed to get label selectors from strategy's params: %+v", err)
-		}
+	if args.MinPreferredWeightImprovement < 0 {
+		return fmt.Errorf("minPreferredWeightImprovement can not be negative")
 	}
 
 	return nil
@@ -45,6 +45,14 @@ func TestValidateRemovePodsViolatingNodeAffinityArgs(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			description: "negative MinPreferredWeightImprovement args, expects errors",
+			args: &RemovePodsViolatingNodeAffinityArgs{
+				NodeAffinityType:              []string{"preferredDuringSchedulingIgnoredDuringExecution"},
+				MinPreferredWeightImprovement: -1,
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
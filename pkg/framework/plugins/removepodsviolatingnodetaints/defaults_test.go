@@ -33,8 +33,10 @@ func TestSetDefaults_RemovePodsViolatingNodeTaintsArgs(t *testing.T) {
 			name: "RemovePodsViolatingNodeTaintsArgs empty",
 			in:   &RemovePodsViolatingNodeTaintsArgs{},
 			want: &RemovePodsViolatingNodeTaintsArgs{
-				Namespaces:              nil,
-				LabelSelector:           nil,
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    nil,
+					LabelSelector: nil,
+				},
 				IncludePreferNoSchedule: false,
 				ExcludedTaints:          nil,
 				IncludedTaints:          nil,
@@ -43,15 +45,19 @@ func TestSetDefaults_RemovePodsViolatingNodeTaintsArgs(t *testing.T) {
 		{
 			name: "RemovePodsViolatingNodeTaintsArgs with value",
 			in: &RemovePodsViolatingNodeTaintsArgs{
-				Namespaces:              &api.Namespaces{},
-				LabelSelector:           &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 				IncludePreferNoSchedule: false,
 				ExcludedTaints:          []string{"ExcludedTaints"},
 				IncludedTaints:          []string{"IncludedTaints"},
 			},
 			want: &RemovePodsViolatingNodeTaintsArgs{
-				Namespaces:              &api.Namespaces{},
-				LabelSelector:           &metav1.LabelSelector{},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces:    &api.Namespaces{},
+					LabelSelector: &metav1.LabelSelector{},
+				},
 				IncludePreferNoSchedule: false,
 				ExcludedTaints:          []string{"ExcludedTaints"},
 				IncludedTaints:          []string{"IncludedTaints"},
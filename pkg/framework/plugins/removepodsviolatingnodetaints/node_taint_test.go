@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
@@ -417,7 +418,7 @@ func TestDeletePodsViolatingNodeTaints(t *testing.T) {
 				defaultevictor.DefaultEvictorArgs{
 					EvictLocalStoragePods:   tc.evictLocalStoragePods,
 					EvictSystemCriticalPods: tc.evictSystemCriticalPods,
-					NodeFit:                 tc.nodeFit,
+					NodeFit:                 utilptr.To(tc.nodeFit),
 				},
 				nil,
 			)
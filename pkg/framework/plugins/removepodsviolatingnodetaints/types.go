@@ -28,9 +28,8 @@ import (
 type RemovePodsViolatingNodeTaintsArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
-	Namespaces              *api.Namespaces       `json:"namespaces"`
-	LabelSelector           *metav1.LabelSelector `json:"labelSelector"`
-	IncludePreferNoSchedule bool                  `json:"includePreferNoSchedule"`
-	ExcludedTaints          []string              `json:"excludedTaints"`
-	IncludedTaints          []string              `json:"includedTaints"`
+	api.FilteringArgs       `json:",inline"`
+	IncludePreferNoSchedule bool     `json:"includePreferNoSchedule"`
+	ExcludedTaints          []string `json:"excludedTaints"`
+	IncludedTaints          []string `json:"includedTaints"`
 }
@@ -16,8 +16,10 @@ func TestValidateRemovePodsViolatingNodeTaintsArgs(t *testing.T) {
 		{
 			description: "valid namespace args, no errors",
 			args: &RemovePodsViolatingNodeTaintsArgs{
-				Namespaces: &api.Namespaces{
-					Include: []string{"default"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+					},
 				},
 			},
 			expectError: false,
@@ -25,9 +27,11 @@ func TestValidateRemovePodsViolatingNodeTaintsArgs(t *testing.T) {
 		{
 			description: "invalid namespaces args, expects error",
 			args: &RemovePodsViolatingNodeTaintsArgs{
-				Namespaces: &api.Namespaces{
-					Include: []string{"default"},
-					Exclude: []string{"kube-system"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+						Exclude: []string{"kube-system"},
+					},
 				},
 			},
 			expectError: true,
@@ -35,8 +39,10 @@ func TestValidateRemovePodsViolatingNodeTaintsArgs(t *testing.T) {
 		{
 			description: "valid label selector args, no errors",
 			args: &RemovePodsViolatingNodeTaintsArgs{
-				LabelSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{"role.kubernetes.io/node": ""},
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"role.kubernetes.io/node": ""},
+					},
 				},
 			},
 			expectError: false,
@@ -44,10 +50,12 @@ func TestValidateRemovePodsViolatingNodeTaintsArgs(t *testing.T) {
 		{
 			description: "invalid label selector args, expects errors",
 			args: &RemovePodsViolatingNodeTaintsArgs{
-				LabelSelector: &metav1.LabelSelector{
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Operator: metav1.LabelSelectorOpIn,
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Operator: metav1.LabelSelectorOpIn,
+							},
 						},
 					},
 				},
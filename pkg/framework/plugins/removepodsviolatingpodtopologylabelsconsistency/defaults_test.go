@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingpodtopologylabelsconsistency
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetDefaults_RemovePodsViolatingPodTopologyLabelsConsistencyArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "RemovePodsViolatingPodTopologyLabelsConsistencyArgs empty",
+			in:   &RemovePodsViolatingPodTopologyLabelsConsistencyArgs{},
+			want: &RemovePodsViolatingPodTopologyLabelsConsistencyArgs{
+				TopologyLabels: []string{v1.LabelTopologyZone, v1.LabelHostname},
+			},
+		},
+		{
+			name: "RemovePodsViolatingPodTopologyLabelsConsistencyArgs with value",
+			in: &RemovePodsViolatingPodTopologyLabelsConsistencyArgs{
+				TopologyEnv: map[string]string{"ZONE": v1.LabelTopologyZone},
+			},
+			want: &RemovePodsViolatingPodTopologyLabelsConsistencyArgs{
+				TopologyEnv: map[string]string{"ZONE": v1.LabelTopologyZone},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RemovePodsViolatingPodTopologyLabelsConsistencyArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
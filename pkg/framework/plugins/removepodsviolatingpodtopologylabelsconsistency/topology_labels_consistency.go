@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingpodtopologylabelsconsistency
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RemovePodsViolatingPodTopologyLabelsConsistency"
+
+var _ frameworktypes.PodFieldsRequirer = &RemovePodsViolatingPodTopologyLabelsConsistencyArgs{}
+
+// RequiredPodFields keeps the environment of the containers in the pod cache when topology
+// environment variables are checked
+func (d *RemovePodsViolatingPodTopologyLabelsConsistencyArgs) RequiredPodFields() podutil.PodFields {
+	return podutil.PodFields{ContainerEnv: len(d.TopologyEnv) > 0}
+}
+
+// RemovePodsViolatingPodTopologyLabelsConsistency evicts the pods whose topology labels or environment
+// variables, copied from the labels of their node at admission, no longer match the node they run on,
+// e.g. after their node got relabeled or their pod got bound to another node than the admitted one.
+// Such pods report a wrong topology to service meshes and topology aware routing.
+type RemovePodsViolatingPodTopologyLabelsConsistency struct {
+	handle    frameworktypes.Handle
+	args      *RemovePodsViolatingPodTopologyLabelsConsistencyArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsViolatingPodTopologyLabelsConsistency{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	consistencyArgs, ok := args.(*RemovePodsViolatingPodTopologyLabelsConsistencyArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsViolatingPodTopologyLabelsConsistencyArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if consistencyArgs.Namespaces != nil {
+		includedNamespaces = sets.New(consistencyArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(consistencyArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(consistencyArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsViolatingPodTopologyLabelsConsistency{
+		handle:    handle,
+		args:      consistencyArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsViolatingPodTopologyLabelsConsistency) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemovePodsViolatingPodTopologyLabelsConsistency) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			reason := d.inconsistency(pod, node)
+			if reason == "" {
+				continue
+			}
+			logger.V(2).Info("Pod topology does not match its node", "pod", klog.KObj(pod), "node", klog.KObj(node), "reason", reason)
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName, Reason: reason})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// inconsistency returns which topology label or environment variable of the pod does not match
+// the labels of its node, or an empty string when all match. The node labels which are not set
+// can not be compared and are skipped.
+func (d *RemovePodsViolatingPodTopologyLabelsConsistency) inconsistency(pod *v1.Pod, node *v1.Node) string {
+	for _, key := range d.args.TopologyLabels {
+		nodeValue, ok := node.Labels[key]
+		if !ok {
+			continue
+		}
+		if podValue, ok := pod.Labels[key]; ok && podValue != nodeValue {
+			return fmt.Sprintf("label %s is %q while the node has %q", key, podValue, nodeValue)
+		}
+	}
+	if len(d.args.TopologyEnv) == 0 {
+		return ""
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, env := range container.Env {
+			key, ok := d.args.TopologyEnv[env.Name]
+			// The variables read through the downward API at runtime always match
+			if !ok || env.ValueFrom != nil {
+				continue
+			}
+			nodeValue, ok := node.Labels[key]
+			if ok && env.Value != nodeValue {
+				return fmt.Sprintf("environment variable %s of container %s is %q while the node label %s has %q", env.Name, container.Name, env.Value, key, nodeValue)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingpodtopologylabelsconsistency
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildPod(name string, apply func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, "n1", func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		if apply != nil {
+			apply(pod)
+		}
+	})
+}
+
+func withLabel(key, value string) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Labels[key] = value
+	}
+}
+
+func withEnv(env v1.EnvVar) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, env)
+	}
+}
+
+func TestRemovePodsViolatingPodTopologyLabelsConsistency(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, func(node *v1.Node) {
+		node.Labels[v1.LabelTopologyZone] = "zone-a"
+		node.Labels[v1.LabelHostname] = "n1"
+	})
+	defaultArgs := RemovePodsViolatingPodTopologyLabelsConsistencyArgs{TopologyLabels: []string{v1.LabelTopologyZone, v1.LabelHostname}}
+
+	tests := []struct {
+		description          string
+		args                 RemovePodsViolatingPodTopologyLabelsConsistencyArgs
+		pods                 []*v1.Pod
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pods with a topology label differing from their node are evicted",
+			args:        defaultArgs,
+			pods: []*v1.Pod{
+				buildPod("p1", withLabel(v1.LabelTopologyZone, "zone-a")),
+				buildPod("p2", withLabel(v1.LabelTopologyZone, "zone-b")),
+				buildPod("p3", withLabel(v1.LabelHostname, "n2")),
+				buildPod("p4", nil),
+			},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "Labels missing from the node are not compared",
+			args:        RemovePodsViolatingPodTopologyLabelsConsistencyArgs{TopologyLabels: []string{v1.LabelTopologyRegion}},
+			pods: []*v1.Pod{
+				buildPod("p1", withLabel(v1.LabelTopologyRegion, "region-a")),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of excluded namespaces are not evicted",
+			args: RemovePodsViolatingPodTopologyLabelsConsistencyArgs{
+				FilteringArgs:  api.FilteringArgs{Namespaces: &api.Namespaces{Exclude: []string{"default"}}},
+				TopologyLabels: []string{v1.LabelTopologyZone},
+			},
+			pods: []*v1.Pod{
+				buildPod("p1", withLabel(v1.LabelTopologyZone, "zone-b")),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods with a topology environment variable differing from their node are evicted",
+			args:        RemovePodsViolatingPodTopologyLabelsConsistencyArgs{TopologyEnv: map[string]string{"ZONE": v1.LabelTopologyZone}},
+			pods: []*v1.Pod{
+				buildPod("p1", withEnv(v1.EnvVar{Name: "ZONE", Value: "zone-a"})),
+				buildPod("p2", withEnv(v1.EnvVar{Name: "ZONE", Value: "zone-b"})),
+				buildPod("p3", withEnv(v1.EnvVar{Name: "OTHER", Value: "zone-b"})),
+				buildPod("p4", withEnv(v1.EnvVar{Name: "ZONE", ValueFrom: &v1.EnvVarSource{
+					FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.labels['topology.kubernetes.io/zone']"},
+				}})),
+			},
+			expectedEvictedCount: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{n1}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{n1})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingpodtopologylabelsconsistency
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsViolatingPodTopologyLabelsConsistencyArgs holds arguments used to configure RemovePodsViolatingPodTopologyLabelsConsistency plugin.
+type RemovePodsViolatingPodTopologyLabelsConsistencyArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// TopologyLabels are the keys of the pod labels copied from the labels of the same key of their
+	// node at admission, e.g. topology.kubernetes.io/zone. Pods whose label differs from the label of
+	// their node are evicted.
+	TopologyLabels []string `json:"topologyLabels,omitempty"`
+	// TopologyEnv maps the names of the container environment variables set at admission to the keys
+	// of the node labels they are copied from. Pods with a container whose variable differs from the
+	// label of their node are evicted.
+	TopologyEnv map[string]string `json:"topologyEnv,omitempty"`
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingpodtopologylabelsconsistency
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateRemovePodsViolatingPodTopologyLabelsConsistencyArgs validates RemovePodsViolatingPodTopologyLabelsConsistency arguments
+func ValidateRemovePodsViolatingPodTopologyLabelsConsistencyArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsViolatingPodTopologyLabelsConsistencyArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if len(args.TopologyLabels) == 0 && len(args.TopologyEnv) == 0 {
+		return fmt.Errorf("at least one topology label or environment variable must be set")
+	}
+	for _, key := range args.TopologyLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid topology label %q: %s", key, strings.Join(errs, "; "))
+		}
+	}
+	for name, key := range args.TopologyEnv {
+		if errs := validation.IsEnvVarName(name); len(errs) > 0 {
+			return fmt.Errorf("invalid topology environment variable %q: %s", name, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid node label %q of topology environment variable %q: %s", key, name, strings.Join(errs, "; "))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatingpodtopologylabelsconsistency
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRemovePodsViolatingPodTopologyLabelsConsistencyArgs(t *testing.T) {
+	validArgs := func(mutate func(*RemovePodsViolatingPodTopologyLabelsConsistencyArgs)) *RemovePodsViolatingPodTopologyLabelsConsistencyArgs {
+		args := &RemovePodsViolatingPodTopologyLabelsConsistencyArgs{
+			TopologyLabels: []string{v1.LabelTopologyZone},
+			TopologyEnv:    map[string]string{"ZONE": v1.LabelTopologyZone},
+		}
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *RemovePodsViolatingPodTopologyLabelsConsistencyArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *RemovePodsViolatingPodTopologyLabelsConsistencyArgs) {
+				args.Namespaces = &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "nothing to check, expects error",
+			args: validArgs(func(args *RemovePodsViolatingPodTopologyLabelsConsistencyArgs) {
+				args.TopologyLabels = nil
+				args.TopologyEnv = nil
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid topology label, expects error",
+			args: validArgs(func(args *RemovePodsViolatingPodTopologyLabelsConsistencyArgs) {
+				args.TopologyLabels = append(args.TopologyLabels, "zone/")
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid environment variable name, expects error",
+			args: validArgs(func(args *RemovePodsViolatingPodTopologyLabelsConsistencyArgs) {
+				args.TopologyEnv["1ZONE"] = v1.LabelTopologyZone
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid node label of an environment variable, expects error",
+			args: validArgs(func(args *RemovePodsViolatingPodTopologyLabelsConsistencyArgs) {
+				args.TopologyEnv["REGION"] = "region/"
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemovePodsViolatingPodTopologyLabelsConsistencyArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
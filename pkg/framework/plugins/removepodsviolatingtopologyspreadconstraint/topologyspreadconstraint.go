@@ -56,6 +56,7 @@ type topology struct {
 // Fields are exported for structured logging.
 type topologySpreadConstraint struct {
 	MaxSkew            int32
+	MinDomains         int32
 	TopologyKey        string
 	Selector           labels.Selector
 	NodeAffinityPolicy v1.NodeInclusionPolicy
@@ -73,6 +74,13 @@ type RemovePodsViolatingTopologySpreadConstraint struct {
 
 var _ frameworktypes.BalancePlugin = &RemovePodsViolatingTopologySpreadConstraint{}
 
+var _ frameworktypes.AllPodsRequirer = &RemovePodsViolatingTopologySpreadConstraintArgs{}
+
+// RequiresAllPods tells the domains are balanced counting all the pods matching the constraints
+func (a *RemovePodsViolatingTopologySpreadConstraintArgs) RequiresAllPods() bool {
+	return true
+}
+
 // New builds plugin from its arguments while passing a handle
 func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
 	pluginArgs, ok := args.(*RemovePodsViolatingTopologySpreadConstraintArgs)
@@ -102,6 +110,7 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) Name() string {
 
 // nolint: gocyclo
 func (d *RemovePodsViolatingTopologySpreadConstraint) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
 	nodeMap := make(map[string]*v1.Node, len(nodes))
 	for _, node := range nodes {
 		nodeMap[node.Name] = node
@@ -119,7 +128,7 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) Balance(ctx context.Contex
 	// iterate through all topoPairs for this topologyKey and diff currentPods -minPods <=maxSkew
 	// if diff > maxSkew, add this pod in the current bucket for eviction
 
-	klog.V(1).Info("Processing namespaces for topology spread constraints")
+	logger.V(1).Info("Processing namespaces for topology spread constraints")
 	podsForEviction := make(map[*v1.Pod]struct{})
 	var includedNamespaces, excludedNamespaces sets.Set[string]
 	if d.args.Namespaces != nil {
@@ -140,7 +149,7 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) Balance(ctx context.Contex
 
 	// 1. for each namespace...
 	for namespace := range namespacedPods {
-		klog.V(4).InfoS("Processing namespace for topology spread constraints", "namespace", namespace)
+		logger.V(4).Info("Processing namespace for topology spread constraints", "namespace", namespace)
 
 		if (len(includedNamespaces) > 0 && !includedNamespaces.Has(namespace)) ||
 			(len(excludedNamespaces) > 0 && excludedNamespaces.Has(namespace)) {
@@ -158,7 +167,7 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) Balance(ctx context.Contex
 
 				namespaceTopologySpreadConstraint, err := newTopologySpreadConstraint(constraint, pod)
 				if err != nil {
-					klog.ErrorS(err, "cannot process topology spread constraint")
+					logger.Error(err, "cannot process topology spread constraint")
 					continue
 				}
 
@@ -211,6 +220,10 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) Balance(ctx context.Contex
 				if !ok {
 					continue
 				}
+				// the scheduler does not count pods on nodes excluded by the node inclusion policies
+				if !matchNodeInclusionPolicies(tsc, node) {
+					continue
+				}
 				// 6. create a topoPair with key as this TopologySpreadConstraint
 				topoPair := topologyPair{key: tsc.TopologyKey, value: nodeValue}
 				// 7. add the pod with key as this topoPair
@@ -218,10 +231,10 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) Balance(ctx context.Contex
 				sumPods++
 			}
 			if topologyIsBalanced(constraintTopologies, tsc) {
-				klog.V(2).InfoS("Skipping topology constraint because it is already balanced", "constraint", tsc)
+				logger.V(2).Info("Skipping topology constraint because it is already balanced", "constraint", tsc)
 				continue
 			}
-			d.balanceDomains(podsForEviction, tsc, constraintTopologies, sumPods, nodes)
+			d.balanceDomains(logger, podsForEviction, tsc, constraintTopologies, sumPods, nodes)
 		}
 	}
 
@@ -245,7 +258,7 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) Balance(ctx context.Contex
 			case *evictions.EvictionTotalLimitError:
 				return nil
 			default:
-				klog.Errorf("eviction failed: %v", err)
+				logger.Error(err, "Eviction failed")
 			}
 		}
 	}
@@ -265,9 +278,13 @@ func hasIdenticalConstraints(newConstraint topologySpreadConstraint, namespaceTo
 
 // topologyIsBalanced checks if any domains in the topology differ by more than the MaxSkew
 // this is called before any sorting or other calculations and is used to skip topologies that don't need to be balanced
+// When there are fewer domains than MinDomains, the global minimum is 0 as for the scheduler.
 func topologyIsBalanced(topology map[topologyPair][]*v1.Pod, tsc topologySpreadConstraint) bool {
 	minDomainSize := math.MaxInt32
 	maxDomainSize := math.MinInt32
+	if int32(len(topology)) < tsc.MinDomains {
+		minDomainSize = 0
+	}
 	for _, pods := range topology {
 		if len(pods) < minDomainSize {
 			minDomainSize = len(pods)
@@ -303,12 +320,18 @@ func topologyIsBalanced(topology map[topologyPair][]*v1.Pod, tsc topologySpreadC
 // [5, 5, 5, 5, 5, 5]
 // (assuming even distribution by the scheduler of the evicted pods)
 func (d *RemovePodsViolatingTopologySpreadConstraint) balanceDomains(
+	logger klog.Logger,
 	podsForEviction map[*v1.Pod]struct{},
 	tsc topologySpreadConstraint,
 	constraintTopologies map[topologyPair][]*v1.Pod,
 	sumPods float64,
 	nodes []*v1.Node,
 ) {
+	if int32(len(constraintTopologies)) < tsc.MinDomains {
+		d.balanceDomainsBelowMinDomains(logger, podsForEviction, tsc, constraintTopologies, nodes)
+		return
+	}
+
 	idealAvg := sumPods / float64(len(constraintTopologies))
 	isEvictable := d.handle.Evictor().Filter
 	sortedDomains := sortDomains(constraintTopologies, isEvictable)
@@ -370,7 +393,7 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) balanceDomains(
 			// So, a better selection heuristic could improve performance.
 
 			if topologyBalanceNodeFit && !node.PodFitsAnyOtherNode(getPodsAssignedToNode, aboveToEvict[k], nodesBelowIdealAvg) {
-				klog.V(2).InfoS("ignoring pod for eviction as it does not fit on any other node", "pod", klog.KObj(aboveToEvict[k]))
+				logger.V(2).Info("ignoring pod for eviction as it does not fit on any other node", "pod", klog.KObj(aboveToEvict[k]))
 				continue
 			}
 
@@ -381,6 +404,51 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) balanceDomains(
 	}
 }
 
+// balanceDomainsBelowMinDomains determines which pods should be evicted when there are fewer eligible domains
+// than the MinDomains of the constraint. The scheduler then treats the global minimum as 0, so no domain
+// may hold more than MaxSkew matching pods. Pods are moved from the domains above MaxSkew, largest first,
+// to the domains below MaxSkew, as long as those have room left. Pods are not evicted when there is no room
+// left anywhere since the scheduler would not be able to place them.
+func (d *RemovePodsViolatingTopologySpreadConstraint) balanceDomainsBelowMinDomains(
+	logger klog.Logger,
+	podsForEviction map[*v1.Pod]struct{},
+	tsc topologySpreadConstraint,
+	constraintTopologies map[topologyPair][]*v1.Pod,
+	nodes []*v1.Node,
+) {
+	isEvictable := d.handle.Evictor().Filter
+	sortedDomains := sortDomains(constraintTopologies, isEvictable)
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	topologyBalanceNodeFit := utilptr.Deref(d.args.TopologyBalanceNodeFit, true)
+	maxSkew := int(tsc.MaxSkew)
+
+	room := 0
+	for _, domain := range sortedDomains {
+		if len(domain.pods) < maxSkew {
+			room += maxSkew - len(domain.pods)
+		}
+	}
+	nodesWithRoom := filterNodesBelowIdealAvg(filterEligibleNodes(nodes, tsc), sortedDomains, tsc.TopologyKey, float64(maxSkew))
+
+	for j := len(sortedDomains) - 1; j >= 0 && room > 0; j-- {
+		excess := len(sortedDomains[j].pods) - maxSkew
+		if excess <= 0 {
+			break
+		}
+		movePods := int(math.Min(float64(excess), float64(room)))
+		room -= movePods
+
+		aboveToEvict := sortedDomains[j].pods[len(sortedDomains[j].pods)-movePods:]
+		for k := range aboveToEvict {
+			if topologyBalanceNodeFit && !node.PodFitsAnyOtherNode(getPodsAssignedToNode, aboveToEvict[k], nodesWithRoom) {
+				logger.V(2).Info("ignoring pod for eviction as it does not fit on any other node", "pod", klog.KObj(aboveToEvict[k]))
+				continue
+			}
+			podsForEviction[aboveToEvict[k]] = struct{}{}
+		}
+	}
+}
+
 // filterNodesBelowIdealAvg will return nodes that have fewer pods matching topology domain than the idealAvg count.
 // the desired behavior is to not consider nodes in a given topology domain that are already packed.
 func filterNodesBelowIdealAvg(nodes []*v1.Node, sortedDomains []topology, topologyKey string, idealAvg float64) []*v1.Node {
@@ -520,6 +588,7 @@ func newTopologySpreadConstraint(constraint v1.TopologySpreadConstraint, pod *v1
 
 	tsc := topologySpreadConstraint{
 		MaxSkew:            constraint.MaxSkew,
+		MinDomains:         1,
 		TopologyKey:        constraint.TopologyKey,
 		Selector:           selector,
 		NodeAffinityPolicy: v1.NodeInclusionPolicyHonor,  // If NodeAffinityPolicy is nil, we treat NodeAffinityPolicy as "Honor".
@@ -527,6 +596,10 @@ func newTopologySpreadConstraint(constraint v1.TopologySpreadConstraint, pod *v1
 		PodNodeAffinity:    nodeaffinity.GetRequiredNodeAffinity(pod),
 		PodTolerations:     pod.Spec.Tolerations,
 	}
+	// MinDomains is only honored by the scheduler for hard constraints
+	if constraint.MinDomains != nil && constraint.WhenUnsatisfiable == v1.DoNotSchedule {
+		tsc.MinDomains = *constraint.MinDomains
+	}
 	if constraint.NodeAffinityPolicy != nil {
 		tsc.NodeAffinityPolicy = *constraint.NodeAffinityPolicy
 	}
@@ -29,8 +29,7 @@ import (
 type RemovePodsViolatingTopologySpreadConstraintArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
-	Namespaces             *api.Namespaces                    `json:"namespaces"`
-	LabelSelector          *metav1.LabelSelector              `json:"labelSelector"`
+	api.FilteringArgs      `json:",inline"`
 	Constraints            []v1.UnsatisfiableConstraintAction `json:"constraints"`
 	TopologyBalanceNodeFit *bool                              `json:"topologyBalanceNodeFit"`
 }
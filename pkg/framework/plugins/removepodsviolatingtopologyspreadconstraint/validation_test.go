@@ -18,8 +18,10 @@ func TestValidateRemovePodsViolatingTopologySpreadConstraintArgs(t *testing.T) {
 		{
 			description: "valid namespace args, no errors",
 			args: &RemovePodsViolatingTopologySpreadConstraintArgs{
-				Namespaces: &api.Namespaces{
-					Include: []string{"default"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+					},
 				},
 			},
 			expectError: false,
@@ -27,9 +29,11 @@ func TestValidateRemovePodsViolatingTopologySpreadConstraintArgs(t *testing.T) {
 		{
 			description: "invalid namespaces args, expects error",
 			args: &RemovePodsViolatingTopologySpreadConstraintArgs{
-				Namespaces: &api.Namespaces{
-					Include: []string{"default"},
-					Exclude: []string{"kube-system"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+						Exclude: []string{"kube-system"},
+					},
 				},
 			},
 			expectError: true,
@@ -37,8 +41,10 @@ func TestValidateRemovePodsViolatingTopologySpreadConstraintArgs(t *testing.T) {
 		{
 			description: "valid label selector args, no errors",
 			args: &RemovePodsViolatingTopologySpreadConstraintArgs{
-				LabelSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{"role.kubernetes.io/node": ""},
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"role.kubernetes.io/node": ""},
+					},
 				},
 			},
 			expectError: false,
@@ -46,10 +52,12 @@ func TestValidateRemovePodsViolatingTopologySpreadConstraintArgs(t *testing.T) {
 		{
 			description: "invalid label selector args, expects errors",
 			args: &RemovePodsViolatingTopologySpreadConstraintArgs{
-				LabelSelector: &metav1.LabelSelector{
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Operator: metav1.LabelSelectorOpIn,
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Operator: metav1.LabelSelectorOpIn,
+							},
 						},
 					},
 				},
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithdeprecatedapisowners
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSetDefaults_RemovePodsWithDeprecatedAPIsOwnersArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "RemovePodsWithDeprecatedAPIsOwnersArgs empty",
+			in:   &RemovePodsWithDeprecatedAPIsOwnersArgs{},
+			want: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{"extensions/v1beta1", "apps/v1beta1", "apps/v1beta2"},
+			},
+		},
+		{
+			name: "RemovePodsWithDeprecatedAPIsOwnersArgs with value",
+			in: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{"example.com/v1alpha1"},
+				ReportOnly:            true,
+			},
+			want: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{"example.com/v1alpha1"},
+				ReportOnly:            true,
+			},
+		},
+		{
+			name: "RemovePodsWithDeprecatedAPIsOwnersArgs with empty deprecated API versions",
+			in: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{},
+			},
+			want: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RemovePodsWithDeprecatedAPIsOwnersArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
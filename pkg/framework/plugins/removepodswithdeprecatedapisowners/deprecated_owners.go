@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithdeprecatedapisowners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "RemovePodsWithDeprecatedAPIsOwners"
+
+	// DeprecatedAPIOwnerReason is the reason of the events emitted on the pods in report only mode
+	DeprecatedAPIOwnerReason = "DeprecatedAPIOwner"
+
+	actionReported = "Reported"
+)
+
+// RemovePodsWithDeprecatedAPIsOwners evicts, or only reports, pods owned through a deprecated API version
+// or through an API version or kind the cluster does not serve anymore, meaning the controller that
+// created them is gone. Evicting them ahead of a cluster upgrade forces their workloads to be migrated.
+type RemovePodsWithDeprecatedAPIsOwners struct {
+	handle                frameworktypes.Handle
+	args                  *RemovePodsWithDeprecatedAPIsOwnersArgs
+	podFilter             podutil.FilterFunc
+	deprecatedAPIVersions sets.Set[string]
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsWithDeprecatedAPIsOwners{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	deprecatedArgs, ok := args.(*RemovePodsWithDeprecatedAPIsOwnersArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsWithDeprecatedAPIsOwnersArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if deprecatedArgs.Namespaces != nil {
+		includedNamespaces = sets.New(deprecatedArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(deprecatedArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(deprecatedArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsWithDeprecatedAPIsOwners{
+		handle:                handle,
+		args:                  deprecatedArgs,
+		podFilter:             podFilter,
+		deprecatedAPIVersions: sets.New(deprecatedArgs.DeprecatedAPIVersions...),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsWithDeprecatedAPIsOwners) Name() string {
+	return PluginName
+}
+
+// servedAPIs holds the kinds served by the cluster for each API version
+type servedAPIs struct {
+	kinds map[string]sets.Set[string]
+	// unknown holds the API versions the discovery failed for
+	unknown sets.Set[string]
+}
+
+// discoverServedAPIs returns the kinds served by the cluster, nil when the discovery returned
+// nothing (e.g. the fake client used in dry run mode) so that only deprecated API versions are checked.
+func discoverServedAPIs(client discovery.DiscoveryInterface) (*servedAPIs, error) {
+	_, resourceLists, err := client.ServerGroupsAndResources()
+	served := &servedAPIs{kinds: map[string]sets.Set[string]{}, unknown: sets.New[string]()}
+	if err != nil {
+		var groupErr *discovery.ErrGroupDiscoveryFailed
+		if !errors.As(err, &groupErr) {
+			return nil, err
+		}
+		for groupVersion := range groupErr.Groups {
+			served.unknown.Insert(groupVersion.String())
+		}
+	}
+	if len(resourceLists) == 0 {
+		return nil, nil
+	}
+	for _, resourceList := range resourceLists {
+		if resourceList == nil {
+			continue
+		}
+		kinds := sets.New[string]()
+		for _, resource := range resourceList.APIResources {
+			kinds.Insert(resource.Kind)
+		}
+		served.kinds[resourceList.GroupVersion] = kinds
+	}
+	return served, nil
+}
+
+// isServed returns true if the cluster serves the kind of the owner reference in its API version
+func (s *servedAPIs) isServed(ownerRef metav1.OwnerReference) bool {
+	if s == nil || s.unknown.Has(ownerRef.APIVersion) {
+		return true
+	}
+	kinds, ok := s.kinds[ownerRef.APIVersion]
+	return ok && kinds.Has(ownerRef.Kind)
+}
+
+// deprecatedOwner returns the first owner reference of the pod using a deprecated or unserved API, nil if none
+func (d *RemovePodsWithDeprecatedAPIsOwners) deprecatedOwner(pod *v1.Pod, served *servedAPIs) (*metav1.OwnerReference, string) {
+	for i, ownerRef := range podutil.OwnerRef(pod) {
+		if d.deprecatedAPIVersions.Has(ownerRef.APIVersion) {
+			return &pod.OwnerReferences[i], "deprecated"
+		}
+		if !served.isServed(ownerRef) {
+			return &pod.OwnerReferences[i], "not served anymore"
+		}
+	}
+	return nil, ""
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemovePodsWithDeprecatedAPIsOwners) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	served, err := discoverServedAPIs(d.handle.ClientSet().Discovery())
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error discovering the APIs served by the cluster: %v", err),
+		}
+	}
+	if served == nil {
+		logger.V(1).Info("API discovery returned no resources, only owners using deprecated API versions are considered")
+	}
+
+	for _, node := range nodes {
+		logger.V(1).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			ownerRef, reason := d.deprecatedOwner(pod, served)
+			if ownerRef == nil {
+				continue
+			}
+			logger.V(2).Info("Pod is owned through an API which is "+reason, "pod", klog.KObj(pod), "apiVersion", ownerRef.APIVersion, "kind", ownerRef.Kind, "owner", ownerRef.Name)
+			if d.args.ReportOnly {
+				d.handle.EventRecorder().Eventf(pod, nil, v1.EventTypeWarning, DeprecatedAPIOwnerReason, actionReported,
+					"Owner %s %s uses API version %s which is %s", ownerRef.Kind, ownerRef.Name, ownerRef.APIVersion, reason)
+				continue
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
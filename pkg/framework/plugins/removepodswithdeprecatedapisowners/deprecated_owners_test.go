@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithdeprecatedapisowners
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsWithDeprecatedAPIsOwners(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+
+	ownedPod := func(name, apiVersion, kind string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name + "-owner"}}
+		})
+	}
+	pods := []runtime.Object{
+		ownedPod("p1", "apps/v1", "ReplicaSet"),
+		ownedPod("p2", "extensions/v1beta1", "ReplicaSet"),
+		ownedPod("p3", "example.com/v1alpha1", "Database"),
+		ownedPod("p4", "example.com/v1", "Database"),
+		ownedPod("p5", "example.com/v1", "Cache"),
+	}
+
+	servedResources := []*metav1.APIResourceList{
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{{Name: "replicasets", Kind: "ReplicaSet"}},
+		},
+		{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{{Name: "databases", Kind: "Database"}},
+		},
+	}
+
+	tests := []struct {
+		description          string
+		args                 RemovePodsWithDeprecatedAPIsOwnersArgs
+		servedResources      []*metav1.APIResourceList
+		expectedEvictedCount uint
+		expectedEvents       int
+	}{
+		{
+			description: "Pods owned through deprecated or unserved APIs are evicted",
+			args: RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{"extensions/v1beta1"},
+			},
+			servedResources: servedResources,
+			// p2 is deprecated, p3 uses a version and p5 a kind which are not served
+			expectedEvictedCount: 3,
+		},
+		{
+			description:          "Only unserved APIs without deprecated API versions",
+			args:                 RemovePodsWithDeprecatedAPIsOwnersArgs{DeprecatedAPIVersions: []string{}},
+			servedResources:      servedResources,
+			expectedEvictedCount: 3,
+		},
+		{
+			description: "Only deprecated API versions when the discovery returns nothing",
+			args: RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{"extensions/v1beta1"},
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods are only reported in report only mode",
+			args: RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{"extensions/v1beta1"},
+				ReportOnly:            true,
+			},
+			servedResources:      servedResources,
+			expectedEvictedCount: 0,
+			expectedEvents:       3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := append([]runtime.Object{node1}, pods...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+			fakeClient.Discovery().(*fakediscovery.FakeDiscovery).Resources = tc.servedResources
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+			eventRecorder := events.NewFakeRecorder(10)
+			handle.EventRecorderImpl = eventRecorder
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node1})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+			if len(eventRecorder.Events) != tc.expectedEvents {
+				t.Errorf("Expected %v events, got %v", tc.expectedEvents, len(eventRecorder.Events))
+			}
+			for len(eventRecorder.Events) > 0 {
+				event := <-eventRecorder.Events
+				if !strings.Contains(event, DeprecatedAPIOwnerReason) {
+					t.Errorf("Unexpected event: %v", event)
+				}
+			}
+		})
+	}
+}
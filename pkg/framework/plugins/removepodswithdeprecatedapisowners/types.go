@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithdeprecatedapisowners
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsWithDeprecatedAPIsOwnersArgs holds arguments used to configure RemovePodsWithDeprecatedAPIsOwners plugin.
+type RemovePodsWithDeprecatedAPIsOwnersArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// DeprecatedAPIVersions lists the API versions (e.g. extensions/v1beta1) owners of pods should not use anymore
+	DeprecatedAPIVersions []string `json:"deprecatedAPIVersions"`
+	// ReportOnly emits an event on the pods instead of evicting them
+	ReportOnly bool `json:"reportOnly"`
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithdeprecatedapisowners
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ValidateRemovePodsWithDeprecatedAPIsOwnersArgs validates RemovePodsWithDeprecatedAPIsOwners arguments
+func ValidateRemovePodsWithDeprecatedAPIsOwnersArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsWithDeprecatedAPIsOwnersArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	for _, apiVersion := range args.DeprecatedAPIVersions {
+		if apiVersion == "" {
+			return fmt.Errorf("deprecated API version can not be empty")
+		}
+		if _, err := schema.ParseGroupVersion(apiVersion); err != nil {
+			return fmt.Errorf("invalid deprecated API version %q: %v", apiVersion, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithdeprecatedapisowners
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRemovePodsWithDeprecatedAPIsOwnersArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *RemovePodsWithDeprecatedAPIsOwnersArgs
+		expectError bool
+	}{
+		{
+			description: "valid args, no errors",
+			args: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{"extensions/v1beta1", "v1beta1"},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+					},
+				},
+			},
+			expectError: false,
+		},
+		{
+			description: "empty deprecated API version, expects error",
+			args: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{""},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid deprecated API version, expects error",
+			args: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				DeprecatedAPIVersions: []string{"apps/v1/ReplicaSet"},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid namespaces args, expects error",
+			args: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{"default"},
+						Exclude: []string{"kube-system"},
+					},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector args, expects errors",
+			args: &RemovePodsWithDeprecatedAPIsOwnersArgs{
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Operator: metav1.LabelSelectorOpIn,
+							},
+						},
+					},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemovePodsWithDeprecatedAPIsOwnersArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithmissingserviceaccounts
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_RemovePodsWithMissingServiceAccountsArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_RemovePodsWithMissingServiceAccountsArgs(obj runtime.Object) {
+	args := obj.(*RemovePodsWithMissingServiceAccountsArgs)
+	// The system pods may run before their service accounts are created
+	if args.Namespaces == nil {
+		args.Namespaces = &api.Namespaces{Exclude: []string{metav1.NamespaceSystem}}
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithmissingserviceaccounts
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RemovePodsWithMissingServiceAccounts"
+
+// RemovePodsWithMissingServiceAccounts evicts the pods whose service account was deleted, or whose
+// projected service account tokens can no longer be used, as such pods keep running with credentials
+// about to expire and fail once they do.
+type RemovePodsWithMissingServiceAccounts struct {
+	handle               frameworktypes.Handle
+	args                 *RemovePodsWithMissingServiceAccountsArgs
+	podFilter            podutil.FilterFunc
+	audiences            sets.Set[string]
+	serviceAccountLister listersv1.ServiceAccountLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsWithMissingServiceAccounts{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	serviceAccountsArgs, ok := args.(*RemovePodsWithMissingServiceAccountsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsWithMissingServiceAccountsArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if serviceAccountsArgs.Namespaces != nil {
+		includedNamespaces = sets.New(serviceAccountsArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(serviceAccountsArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(serviceAccountsArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsWithMissingServiceAccounts{
+		handle:    handle,
+		args:      serviceAccountsArgs,
+		podFilter: podFilter,
+		audiences: sets.New(serviceAccountsArgs.Audiences...),
+		// The service accounts are read from the cluster, the cached client of the dry run mode holds none
+		serviceAccountLister: handle.ClusterInformerFactory().Core().V1().ServiceAccounts().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsWithMissingServiceAccounts) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RemovePodsWithMissingServiceAccounts) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	// The service accounts are listed once per namespace and cycle
+	serviceAccounts := map[string]sets.Set[string]{}
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			names, ok := serviceAccounts[pod.Namespace]
+			if !ok {
+				if names, err = d.listServiceAccounts(pod.Namespace); err != nil {
+					return &frameworktypes.Status{
+						Err: fmt.Errorf("error listing service accounts: %v", err),
+					}
+				}
+				serviceAccounts[pod.Namespace] = names
+			}
+
+			reason := d.brokenCredentials(pod, names)
+			if reason == "" {
+				continue
+			}
+			logger.V(2).Info("Pod has broken service account credentials", "pod", klog.KObj(pod), "reason", reason)
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName, Reason: reason})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// listServiceAccounts returns the names of the service accounts of the namespace
+func (d *RemovePodsWithMissingServiceAccounts) listServiceAccounts(namespace string) (sets.Set[string], error) {
+	list, err := d.serviceAccountLister.ServiceAccounts(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	names := sets.New[string]()
+	for _, serviceAccount := range list {
+		names.Insert(serviceAccount.Name)
+	}
+	return names, nil
+}
+
+// brokenCredentials returns why the service account credentials of the pod can no longer be used,
+// or an empty string when they still can.
+func (d *RemovePodsWithMissingServiceAccounts) brokenCredentials(pod *v1.Pod, serviceAccounts sets.Set[string]) string {
+	serviceAccountName := pod.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+	// Every namespace has at least the default service account: none are listed when the
+	// service accounts can not be read or are not created yet
+	if serviceAccounts.Len() > 0 && !serviceAccounts.Has(serviceAccountName) {
+		return fmt.Sprintf("service account %q not found", serviceAccountName)
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ServiceAccountToken == nil {
+				continue
+			}
+			// Tokens without audience are issued for the API server
+			if audience := source.ServiceAccountToken.Audience; audience != "" && d.audiences.Len() > 0 && !d.audiences.Has(audience) {
+				return fmt.Sprintf("service account token audience %q no longer issued", audience)
+			}
+			if d.args.IssuerChangeTime != nil && pod.Status.StartTime != nil && pod.Status.StartTime.Before(d.args.IssuerChangeTime) {
+				return "service account token issued before the issuer changed"
+			}
+		}
+	}
+	return ""
+}
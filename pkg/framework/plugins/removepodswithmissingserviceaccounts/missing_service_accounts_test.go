@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithmissingserviceaccounts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildServiceAccount(namespace, name string) *v1.ServiceAccount {
+	return &v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func buildPod(name, namespace, serviceAccount string, apply func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, "n1", func(pod *v1.Pod) {
+		pod.Namespace = namespace
+		pod.Spec.ServiceAccountName = serviceAccount
+		test.SetRSOwnerRef(pod)
+		if apply != nil {
+			apply(pod)
+		}
+	})
+}
+
+func withProjectedToken(audience string) func(*v1.Pod) {
+	return func(pod *v1.Pod) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: "token",
+			VolumeSource: v1.VolumeSource{
+				Projected: &v1.ProjectedVolumeSource{
+					Sources: []v1.VolumeProjection{
+						{ServiceAccountToken: &v1.ServiceAccountTokenProjection{Audience: audience, Path: "token"}},
+					},
+				},
+			},
+		})
+	}
+}
+
+func TestRemovePodsWithMissingServiceAccounts(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, nil)
+	issuerChange := metav1.NewTime(time.Now().Add(-time.Hour))
+	startedBefore := func(pod *v1.Pod) {
+		startTime := metav1.NewTime(issuerChange.Add(-time.Hour))
+		pod.Status.StartTime = &startTime
+		withProjectedToken("")(pod)
+	}
+	startedAfter := func(pod *v1.Pod) {
+		startTime := metav1.NewTime(issuerChange.Add(time.Minute))
+		pod.Status.StartTime = &startTime
+		withProjectedToken("")(pod)
+	}
+
+	tests := []struct {
+		description          string
+		args                 RemovePodsWithMissingServiceAccountsArgs
+		objects              []runtime.Object
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pods with a deleted service account are evicted",
+			objects: []runtime.Object{
+				buildServiceAccount("ns1", "default"),
+				buildServiceAccount("ns1", "sa1"),
+				buildPod("p1", "ns1", "sa1", nil),
+				buildPod("p2", "ns1", "", nil),
+				buildPod("p3", "ns1", "deleted", nil),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods of namespaces without service accounts are not evicted",
+			objects: []runtime.Object{
+				buildServiceAccount("ns1", "default"),
+				buildPod("p1", "ns2", "sa1", nil),
+				buildPod("p2", "ns2", "", nil),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of excluded namespaces are not evicted",
+			args:        RemovePodsWithMissingServiceAccountsArgs{FilteringArgs: api.FilteringArgs{Namespaces: &api.Namespaces{Exclude: []string{"ns1"}}}},
+			objects: []runtime.Object{
+				buildServiceAccount("ns1", "default"),
+				buildPod("p1", "ns1", "deleted", nil),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods with tokens for audiences no longer issued are evicted",
+			args:        RemovePodsWithMissingServiceAccountsArgs{Audiences: []string{"vault"}},
+			objects: []runtime.Object{
+				buildServiceAccount("ns1", "default"),
+				buildPod("p1", "ns1", "", withProjectedToken("vault")),
+				buildPod("p2", "ns1", "", withProjectedToken("deleted")),
+				buildPod("p3", "ns1", "", withProjectedToken("")),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Any audience is valid without audiences",
+			objects: []runtime.Object{
+				buildServiceAccount("ns1", "default"),
+				buildPod("p1", "ns1", "", withProjectedToken("deleted")),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods with tokens issued before the issuer changed are evicted",
+			args:        RemovePodsWithMissingServiceAccountsArgs{IssuerChangeTime: &issuerChange},
+			objects: []runtime.Object{
+				buildServiceAccount("ns1", "default"),
+				buildPod("p1", "ns1", "", startedBefore),
+				buildPod("p2", "ns1", "", startedAfter),
+				buildPod("p3", "ns1", "", func(pod *v1.Pod) {
+					startTime := metav1.NewTime(issuerChange.Add(-time.Hour))
+					pod.Status.StartTime = &startTime
+				}),
+			},
+			expectedEvictedCount: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := append([]runtime.Object{n1}, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			// Start the ServiceAccount informer requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{n1})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
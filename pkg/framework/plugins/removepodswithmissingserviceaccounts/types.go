@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithmissingserviceaccounts
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsWithMissingServiceAccountsArgs holds arguments used to configure RemovePodsWithMissingServiceAccounts plugin.
+type RemovePodsWithMissingServiceAccountsArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// Audiences are the audiences the API server still issues projected service account tokens for.
+	// Pods with projected tokens for other audiences are evicted. Any audience is valid when empty.
+	Audiences []string `json:"audiences,omitempty"`
+	// IssuerChangeTime is the time the service account token issuer changed. Pods with projected
+	// tokens started before it are evicted.
+	IssuerChangeTime *metav1.Time `json:"issuerChangeTime,omitempty"`
+}
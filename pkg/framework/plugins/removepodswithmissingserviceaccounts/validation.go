@@ -0,0 +1,39 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodswithmissingserviceaccounts
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateRemovePodsWithMissingServiceAccountsArgs validates RemovePodsWithMissingServiceAccounts arguments
+func ValidateRemovePodsWithMissingServiceAccountsArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsWithMissingServiceAccountsArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	for _, audience := range args.Audiences {
+		if audience == "" {
+			return fmt.Errorf("audiences can not be empty")
+		}
+	}
+
+	return nil
+}
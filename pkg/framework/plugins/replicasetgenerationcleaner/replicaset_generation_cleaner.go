@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicasetgenerationcleaner
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "ReplicaSetGenerationCleaner"
+
+// RevisionAnnotation is the revision the deployment controller sets on a Deployment and its ReplicaSets
+const RevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// ReplicaSetGenerationCleaner evicts the pods left behind by old ReplicaSets of a Deployment
+// once its rollout completed, e.g. surge pods orphaned by a paused and resumed rollout.
+type ReplicaSetGenerationCleaner struct {
+	handle           frameworktypes.Handle
+	args             *ReplicaSetGenerationCleanerArgs
+	podFilter        podutil.FilterFunc
+	replicaSetLister appslisters.ReplicaSetLister
+	deploymentLister appslisters.DeploymentLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &ReplicaSetGenerationCleaner{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	cleanerArgs, ok := args.(*ReplicaSetGenerationCleanerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type ReplicaSetGenerationCleanerArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if cleanerArgs.Namespaces != nil {
+		includedNamespaces = sets.New(cleanerArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(cleanerArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(cleanerArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &ReplicaSetGenerationCleaner{
+		handle:    handle,
+		args:      cleanerArgs,
+		podFilter: podFilter,
+		// The ReplicaSets and Deployments are read from the cluster, the cached client of the dry run mode holds none
+		replicaSetLister: handle.ClusterInformerFactory().Apps().V1().ReplicaSets().Lister(),
+		deploymentLister: handle.ClusterInformerFactory().Apps().V1().Deployments().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *ReplicaSetGenerationCleaner) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *ReplicaSetGenerationCleaner) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	evicted := map[types.NamespacedName]uint{}
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			deployment, err := d.outdatedDeployment(pod)
+			if err != nil {
+				return &frameworktypes.Status{
+					Err: fmt.Errorf("error getting owners of pod %s/%s: %v", pod.Namespace, pod.Name, err),
+				}
+			}
+			if deployment == nil {
+				continue
+			}
+			key := types.NamespacedName{Namespace: deployment.Namespace, Name: deployment.Name}
+			if evicted[key] >= *d.args.MaxPodsToEvictPerDeployment {
+				continue
+			}
+			logger.V(2).Info("Pod belongs to an old ReplicaSet of a completed rollout", "pod", klog.KObj(pod), "deployment", klog.KObj(deployment))
+			err = d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				evicted[key]++
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// outdatedDeployment returns the Deployment of the pod when the pod belongs to one of its
+// old ReplicaSets and the rollout of the Deployment completed, nil otherwise.
+func (d *ReplicaSetGenerationCleaner) outdatedDeployment(pod *v1.Pod) (*appsv1.Deployment, error) {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil || ownerRef.Kind != "ReplicaSet" {
+		return nil, nil
+	}
+	replicaSet, err := d.replicaSetLister.ReplicaSets(pod.Namespace).Get(ownerRef.Name)
+	if err != nil {
+		return nil, ignoreNotFound(err)
+	}
+	ownerRef = metav1.GetControllerOf(replicaSet)
+	if ownerRef == nil || ownerRef.Kind != "Deployment" {
+		return nil, nil
+	}
+	deployment, err := d.deploymentLister.Deployments(pod.Namespace).Get(ownerRef.Name)
+	if err != nil {
+		return nil, ignoreNotFound(err)
+	}
+
+	revision, ok := replicaSet.Annotations[RevisionAnnotation]
+	if !ok || revision == deployment.Annotations[RevisionAnnotation] || !rolloutComplete(deployment) {
+		return nil, nil
+	}
+	return deployment, nil
+}
+
+// ignoreNotFound drops the errors of owners already deleted, their pods are garbage collected
+func ignoreNotFound(err error) error {
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// rolloutComplete checks all the replicas of the Deployment are updated and available
+func rolloutComplete(deployment *appsv1.Deployment) bool {
+	if deployment.Spec.Paused || deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.UpdatedReplicas >= replicas && deployment.Status.AvailableReplicas >= replicas
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicasetgenerationcleaner
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildDeployment(name, revision string, apply func(*appsv1.Deployment)) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: map[string]string{RevisionAnnotation: revision},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: utilptr.To[int32](2)},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas:   2,
+			AvailableReplicas: 2,
+		},
+	}
+	if apply != nil {
+		apply(deployment)
+	}
+	return deployment
+}
+
+func buildReplicaSet(name, revision string, deployment *appsv1.Deployment) *appsv1.ReplicaSet {
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: map[string]string{RevisionAnnotation: revision},
+		},
+	}
+	if deployment != nil {
+		replicaSet.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       deployment.Name,
+			Controller: utilptr.To(true),
+		}}
+	}
+	return replicaSet
+}
+
+func buildPods(replicaSet *appsv1.ReplicaSet, count int) []runtime.Object {
+	var pods []runtime.Object
+	for i := 0; i < count; i++ {
+		pods = append(pods, test.BuildTestPod(fmt.Sprintf("%s-p%d", replicaSet.Name, i), 100, 0, "n1", func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       replicaSet.Name,
+				Controller: utilptr.To(true),
+			}}
+		}))
+	}
+	return pods
+}
+
+func TestReplicaSetGenerationCleaner(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, nil)
+	completed := buildDeployment("completed", "3", nil)
+	paused := buildDeployment("paused", "3", func(deployment *appsv1.Deployment) {
+		deployment.Spec.Paused = true
+	})
+	progressing := buildDeployment("progressing", "3", func(deployment *appsv1.Deployment) {
+		deployment.Status.AvailableReplicas = 1
+	})
+	outdated := buildDeployment("outdated", "3", func(deployment *appsv1.Deployment) {
+		deployment.Generation = 2
+		deployment.Status.ObservedGeneration = 1
+	})
+	join := func(objs ...[]runtime.Object) []runtime.Object {
+		var all []runtime.Object
+		for _, o := range objs {
+			all = append(all, o...)
+		}
+		return all
+	}
+	replicaSets := func(deployment *appsv1.Deployment, oldPods int) []runtime.Object {
+		current := buildReplicaSet(deployment.Name+"-new", "3", deployment)
+		old := buildReplicaSet(deployment.Name+"-old", "2", deployment)
+		return join([]runtime.Object{deployment, current, old}, buildPods(current, 2), buildPods(old, oldPods))
+	}
+
+	tests := []struct {
+		description          string
+		args                 ReplicaSetGenerationCleanerArgs
+		objects              []runtime.Object
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "Pods of old ReplicaSets of a completed rollout are evicted",
+			args:                 ReplicaSetGenerationCleanerArgs{MaxPodsToEvictPerDeployment: utilptr.To[uint](5)},
+			objects:              replicaSets(completed, 2),
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Evictions are bounded per Deployment",
+			args:                 ReplicaSetGenerationCleanerArgs{MaxPodsToEvictPerDeployment: utilptr.To[uint](1)},
+			objects:              join(replicaSets(completed, 3), replicaSets(buildDeployment("other", "3", nil), 3)),
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Pods of paused rollouts are not evicted",
+			args:                 ReplicaSetGenerationCleanerArgs{MaxPodsToEvictPerDeployment: utilptr.To[uint](5)},
+			objects:              replicaSets(paused, 2),
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Pods of rollouts in progress are not evicted",
+			args:                 ReplicaSetGenerationCleanerArgs{MaxPodsToEvictPerDeployment: utilptr.To[uint](5)},
+			objects:              join(replicaSets(progressing, 2), replicaSets(outdated, 2)),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of ReplicaSets without Deployment are not evicted",
+			args:        ReplicaSetGenerationCleanerArgs{MaxPodsToEvictPerDeployment: utilptr.To[uint](5)},
+			objects: func() []runtime.Object {
+				replicaSet := buildReplicaSet("standalone", "1", nil)
+				return append(buildPods(replicaSet, 2), replicaSet)
+			}(),
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of excluded namespaces are not evicted",
+			args: ReplicaSetGenerationCleanerArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Exclude: []string{"default"}},
+				},
+				MaxPodsToEvictPerDeployment: utilptr.To[uint](5),
+			},
+			objects:              replicaSets(completed, 2),
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := append([]runtime.Object{n1}, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			// Start the ReplicaSet and Deployment informers requested by the plugin
+			handle.ClusterInformerFactory().Start(ctx.Done())
+			handle.ClusterInformerFactory().WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{n1})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
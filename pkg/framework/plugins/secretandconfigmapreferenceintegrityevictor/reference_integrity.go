@@ -0,0 +1,272 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretandconfigmapreferenceintegrityevictor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "SecretAndConfigMapReferenceIntegrityEvictor"
+
+// SecretAndConfigMapReferenceIntegrityEvictor evicts the running pods referencing secrets or config maps deleted after
+// they started. Such pods keep running, but fail to start their containers on the next restart,
+// or to be recreated on another node, so they are evicted while their owner can still react.
+type SecretAndConfigMapReferenceIntegrityEvictor struct {
+	handle          frameworktypes.Handle
+	args            *SecretAndConfigMapReferenceIntegrityEvictorArgs
+	podFilter       podutil.FilterFunc
+	secretLister    listersv1.SecretLister
+	configMapLister listersv1.ConfigMapLister
+	// secretsSynced and configMapsSynced tell whether the objects of the kind could be listed,
+	// their informers do not sync without the permission to list them
+	secretsSynced    cache.InformerSynced
+	configMapsSynced cache.InformerSynced
+}
+
+var _ frameworktypes.DeschedulePlugin = &SecretAndConfigMapReferenceIntegrityEvictor{}
+
+var _ frameworktypes.PodFieldsRequirer = &SecretAndConfigMapReferenceIntegrityEvictorArgs{}
+
+// RequiredPodFields keeps the environment of the containers in the pod cache, as it references
+// secrets and config maps through env and envFrom
+func (a *SecretAndConfigMapReferenceIntegrityEvictorArgs) RequiredPodFields() podutil.PodFields {
+	return podutil.PodFields{ContainerEnv: true}
+}
+
+// reference is a secret or config map a pod requires to start
+type reference struct {
+	kind string
+	name string
+}
+
+const (
+	secretKind    = "secret"
+	configMapKind = "config map"
+)
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	integrityArgs, ok := args.(*SecretAndConfigMapReferenceIntegrityEvictorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type SecretAndConfigMapReferenceIntegrityEvictorArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if integrityArgs.Namespaces != nil {
+		includedNamespaces = sets.New(integrityArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(integrityArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(integrityArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+	var minPodAge time.Duration
+	if integrityArgs.MinPodAge != nil {
+		minPodAge = integrityArgs.MinPodAge.Duration
+	}
+	// The pods which are not running yet may be waiting for their secrets and config maps,
+	// the most recent ones may be created along with them
+	podFilter = podutil.WrapFilterFuncs(podFilter, func(pod *v1.Pod) bool {
+		return pod.Status.Phase == v1.PodRunning && time.Since(pod.CreationTimestamp.Time) >= minPodAge
+	})
+
+	// The secrets and config maps are read from the cluster, the cached client of the dry run mode holds none
+	secretInformer := handle.ClusterInformerFactory().Core().V1().Secrets()
+	configMapInformer := handle.ClusterInformerFactory().Core().V1().ConfigMaps()
+
+	return &SecretAndConfigMapReferenceIntegrityEvictor{
+		handle:           handle,
+		args:             integrityArgs,
+		podFilter:        podFilter,
+		secretLister:     secretInformer.Lister(),
+		configMapLister:  configMapInformer.Lister(),
+		secretsSynced:    secretInformer.Informer().HasSynced,
+		configMapsSynced: configMapInformer.Informer().HasSynced,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *SecretAndConfigMapReferenceIntegrityEvictor) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *SecretAndConfigMapReferenceIntegrityEvictor) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	readable := map[string]bool{secretKind: d.secretsSynced(), configMapKind: d.configMapsSynced()}
+	if !readable[secretKind] && !readable[configMapKind] {
+		logger.V(1).Info("Unable to read the secrets and config maps, skipping")
+		return nil
+	}
+	// The names of the secrets and config maps are listed once per namespace and cycle
+	existing := map[string]map[string]sets.Set[string]{}
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			names, ok := existing[pod.Namespace]
+			if !ok {
+				if names, err = d.listNames(pod.Namespace, readable); err != nil {
+					return &frameworktypes.Status{
+						Err: fmt.Errorf("error listing secrets and config maps: %v", err),
+					}
+				}
+				existing[pod.Namespace] = names
+			}
+
+			reason := missingReference(pod, names)
+			if reason == "" {
+				continue
+			}
+			logger.V(2).Info("Pod references a deleted object", "pod", klog.KObj(pod), "reason", reason)
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName, Reason: reason})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// listNames returns the names of the secrets and of the config maps of the namespace, by kind.
+// The kinds which can not be read are left out.
+func (d *SecretAndConfigMapReferenceIntegrityEvictor) listNames(namespace string, readable map[string]bool) (map[string]sets.Set[string], error) {
+	names := map[string]sets.Set[string]{}
+	if readable[secretKind] {
+		secrets, err := d.secretLister.Secrets(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		names[secretKind] = sets.New[string]()
+		for _, secret := range secrets {
+			names[secretKind].Insert(secret.Name)
+		}
+	}
+	if readable[configMapKind] {
+		configMaps, err := d.configMapLister.ConfigMaps(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		names[configMapKind] = sets.New[string]()
+		for _, configMap := range configMaps {
+			names[configMapKind].Insert(configMap.Name)
+		}
+	}
+	return names, nil
+}
+
+// missingReference returns why the pod can not start its containers anymore,
+// or an empty string when all the objects it requires exist.
+func missingReference(pod *v1.Pod, names map[string]sets.Set[string]) string {
+	for _, ref := range podReferences(pod) {
+		// The references to the objects which can not be read are not checked
+		existing, ok := names[ref.kind]
+		if !ok {
+			continue
+		}
+		if !existing.Has(ref.name) {
+			return fmt.Sprintf("%s %q not found", ref.kind, ref.name)
+		}
+	}
+	return ""
+}
+
+// podReferences returns the secrets and config maps the pod can not start without,
+// the optional ones are left out
+func podReferences(pod *v1.Pod) []reference {
+	var refs []reference
+	add := func(kind, name string, optional *bool) {
+		if name != "" && (optional == nil || !*optional) {
+			refs = append(refs, reference{kind: kind, name: name})
+		}
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.Secret != nil:
+			add(secretKind, volume.Secret.SecretName, volume.Secret.Optional)
+		case volume.ConfigMap != nil:
+			add(configMapKind, volume.ConfigMap.Name, volume.ConfigMap.Optional)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					add(secretKind, source.Secret.Name, source.Secret.Optional)
+				}
+				if source.ConfigMap != nil {
+					add(configMapKind, source.ConfigMap.Name, source.ConfigMap.Optional)
+				}
+			}
+		}
+	}
+
+	containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				add(secretKind, envFrom.SecretRef.Name, envFrom.SecretRef.Optional)
+			}
+			if envFrom.ConfigMapRef != nil {
+				add(configMapKind, envFrom.ConfigMapRef.Name, envFrom.ConfigMapRef.Optional)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				add(secretKind, ref.Name, ref.Optional)
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				add(configMapKind, ref.Name, ref.Optional)
+			}
+		}
+	}
+	return refs
+}
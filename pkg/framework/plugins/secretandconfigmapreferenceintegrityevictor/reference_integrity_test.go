@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretandconfigmapreferenceintegrityevictor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestSecretAndConfigMapReferenceIntegrityEvictor(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, nil)
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "default"}}
+	configMap := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}}
+
+	buildPod := func(name string, apply func(*v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, n1.Name, func(pod *v1.Pod) {
+			pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+			pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+			pod.Status.Phase = v1.PodRunning
+			apply(pod)
+		})
+	}
+	secretVolume := func(name string, optional *bool) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+				Name:         name,
+				VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: name, Optional: optional}},
+			})
+		}
+	}
+	configMapEnv := func(name string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.Containers[0].EnvFrom = append(pod.Spec.Containers[0].EnvFrom, v1.EnvFromSource{
+				ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: name}},
+			})
+		}
+	}
+	projectedSecretKey := func(name string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+				Name: "projected",
+				VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{Sources: []v1.VolumeProjection{
+					{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: name}}},
+				}}},
+			})
+		}
+	}
+	then := func(applies ...func(*v1.Pod)) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			for _, apply := range applies {
+				apply(pod)
+			}
+		}
+	}
+
+	tests := []struct {
+		description          string
+		args                 SecretAndConfigMapReferenceIntegrityEvictorArgs
+		objects              []runtime.Object
+		forbidden            []string
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pods referencing deleted secrets and config maps are evicted",
+			objects: []runtime.Object{
+				secret, configMap,
+				buildPod("p1", secretVolume("deleted", nil)),
+				buildPod("p2", configMapEnv("deleted")),
+				buildPod("p3", projectedSecretKey("deleted")),
+				buildPod("p4", then(secretVolume("credentials", nil), configMapEnv("settings"))),
+			},
+			expectedEvictedCount: 3,
+		},
+		{
+			description: "Optional references are ignored",
+			objects: []runtime.Object{
+				secret, configMap,
+				buildPod("p1", secretVolume("deleted", ptr.To(true))),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods younger than the grace window are not evicted",
+			args:        SecretAndConfigMapReferenceIntegrityEvictorArgs{MinPodAge: &metav1.Duration{Duration: 2 * time.Hour}},
+			objects: []runtime.Object{
+				secret, configMap,
+				buildPod("p1", secretVolume("deleted", nil)),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods which are not running are not evicted",
+			objects: []runtime.Object{
+				secret, configMap,
+				buildPod("p1", then(secretVolume("deleted", nil), func(pod *v1.Pod) {
+					pod.Status.Phase = v1.PodPending
+				})),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of namespaces whose only secret and config map got deleted are evicted",
+			objects: []runtime.Object{
+				buildPod("p1", secretVolume("deleted", nil)),
+				buildPod("p2", configMapEnv("deleted")),
+			},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "References to the objects which can not be read are not checked",
+			objects: []runtime.Object{
+				configMap,
+				buildPod("p1", secretVolume("deleted", nil)),
+				buildPod("p2", configMapEnv("deleted")),
+			},
+			forbidden:            []string{"secrets"},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "No pod is evicted when no object can be read",
+			objects: []runtime.Object{
+				buildPod("p1", secretVolume("deleted", nil)),
+				buildPod("p2", configMapEnv("deleted")),
+			},
+			forbidden:            []string{"secrets", "configmaps"},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods of namespaces not opted in are not evicted",
+			args: SecretAndConfigMapReferenceIntegrityEvictorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"opted-in"}},
+				},
+			},
+			objects: []runtime.Object{
+				secret, configMap,
+				buildPod("p1", secretVolume("deleted", nil)),
+			},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := append([]runtime.Object{n1}, tc.objects...)
+			fakeClient := fake.NewSimpleClientset(objs...)
+			for _, resource := range tc.forbidden {
+				fakeClient.PrependReactor("list", resource, func(action core.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: action.GetResource().Resource}, "", nil)
+				})
+			}
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			SetDefaults_SecretAndConfigMapReferenceIntegrityEvictorArgs(&tc.args)
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			// Start the secret and config map informers requested by the plugin,
+			// the ones not allowed to list their objects never sync
+			handle.SharedInformerFactoryImpl.Start(ctx.Done())
+			syncCtx, syncCancel := context.WithTimeout(ctx, time.Second)
+			defer syncCancel()
+			handle.SharedInformerFactoryImpl.WaitForCacheSync(syncCtx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{n1})
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecardriftevictor
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+const (
+	DefaultContainerName        = "istio-proxy"
+	DefaultMaxEvictionsPerCycle = 1
+	DefaultMaxEvictionsPerOwner = 1
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_SidecarDriftEvictorArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_SidecarDriftEvictorArgs(obj runtime.Object) {
+	args := obj.(*SidecarDriftEvictorArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.ContainerName == "" && args.ImageName == "" {
+		args.ContainerName = DefaultContainerName
+	}
+	if args.MaxEvictionsPerCycle == nil {
+		args.MaxEvictionsPerCycle = utilptr.To[uint](DefaultMaxEvictionsPerCycle)
+	}
+	if args.MaxEvictionsPerOwner == nil {
+		args.MaxEvictionsPerOwner = utilptr.To[uint](DefaultMaxEvictionsPerOwner)
+	}
+}
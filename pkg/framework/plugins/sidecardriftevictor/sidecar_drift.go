@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecardriftevictor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "SidecarDriftEvictor"
+
+// SidecarDriftEvictor evicts the pods whose injected sidecar, e.g. the proxy of a service mesh, runs a
+// version older than the required one, so they get recreated with the sidecar the mesh now injects. The
+// required version is configured, or read from a custom resource of the mesh every descheduling cycle.
+// The pods running the oldest sidecars are evicted first, within budgets of evictions per cycle and per
+// owner, rolling the pods onto the new sidecar at a controlled rate.
+type SidecarDriftEvictor struct {
+	handle          frameworktypes.Handle
+	args            *SidecarDriftEvictorArgs
+	podFilter       podutil.FilterFunc
+	requiredVersion *version.Version
+}
+
+var _ frameworktypes.DeschedulePlugin = &SidecarDriftEvictor{}
+
+// driftedPod is a pod whose sidecar runs a version older than the required one
+type driftedPod struct {
+	pod     *v1.Pod
+	version *version.Version
+	image   string
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	driftArgs, ok := args.(*SidecarDriftEvictorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type SidecarDriftEvictorArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if driftArgs.Namespaces != nil {
+		includedNamespaces = sets.New(driftArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(driftArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(driftArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	var requiredVersion *version.Version
+	if driftArgs.RequiredVersion != "" {
+		requiredVersion, err = parseVersion(driftArgs.RequiredVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid required version: %v", err)
+		}
+	}
+
+	return &SidecarDriftEvictor{
+		handle:          handle,
+		args:            driftArgs,
+		podFilter:       podFilter,
+		requiredVersion: requiredVersion,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *SidecarDriftEvictor) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *SidecarDriftEvictor) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	requiredVersion := d.requiredVersion
+	if requiredVersion == nil {
+		var err error
+		requiredVersion, err = meshVersion(ctx, d.handle.ClientSet(), d.args.RequiredVersionFrom)
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error reading the required sidecar version: %v", err),
+			}
+		}
+	}
+
+	var drifted []driftedPod
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		for _, pod := range pods {
+			sidecarVersion, image, ok := d.oldestSidecar(ctx, pod)
+			if !ok || !sidecarVersion.LessThan(requiredVersion) {
+				continue
+			}
+			drifted = append(drifted, driftedPod{pod: pod, version: sidecarVersion, image: image})
+		}
+	}
+
+	// The pods running the oldest sidecars are rolled first
+	sort.SliceStable(drifted, func(i, j int) bool {
+		return drifted[i].version.LessThan(drifted[j].version)
+	})
+	var evicted uint
+	evictedByOwner := map[types.UID]uint{}
+	for _, candidate := range drifted {
+		owner := podutil.OwnerUID(candidate.pod)
+		if evictedByOwner[owner] >= *d.args.MaxEvictionsPerOwner {
+			continue
+		}
+		reason := fmt.Sprintf("sidecar image %s is older than the required version %s", candidate.image, requiredVersion)
+		logger.V(2).Info("Pod runs a drifted sidecar", "pod", klog.KObj(candidate.pod), "image", candidate.image, "requiredVersion", requiredVersion)
+		err := d.handle.Evictor().Evict(ctx, candidate.pod, evictions.EvictOptions{StrategyName: PluginName, Reason: reason})
+		if err == nil {
+			evicted++
+			evictedByOwner[owner]++
+			if evicted >= *d.args.MaxEvictionsPerCycle {
+				return nil
+			}
+			continue
+		}
+		switch err.(type) {
+		case *evictions.EvictionNodeLimitError:
+			continue
+		case *evictions.EvictionTotalLimitError:
+			return nil
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	return nil
+}
+
+// oldestSidecar returns the version of the oldest sidecar of the pod with its image, false when the
+// pod has no sidecar or the versions of its sidecars are unknown
+func (d *SidecarDriftEvictor) oldestSidecar(ctx context.Context, pod *v1.Pod) (*version.Version, string, bool) {
+	containers := append([]v1.Container{}, pod.Spec.Containers...)
+	for _, container := range pod.Spec.InitContainers {
+		// Native sidecars are restartable init containers, the other init containers have completed
+		if container.RestartPolicy != nil && *container.RestartPolicy == v1.ContainerRestartPolicyAlways {
+			containers = append(containers, container)
+		}
+	}
+
+	var oldest *version.Version
+	var oldestImage string
+	for _, container := range containers {
+		if !d.sidecar(container) {
+			continue
+		}
+		_, tag := imageRepositoryAndTag(container.Image)
+		v, err := parseVersion(tag)
+		if err != nil {
+			klog.FromContext(ctx).V(3).Info("Unable to read the version of the sidecar image", "pod", klog.KObj(pod), "container", container.Name, "image", container.Image)
+			continue
+		}
+		if oldest == nil || v.LessThan(oldest) {
+			oldest, oldestImage = v, container.Image
+		}
+	}
+	return oldest, oldestImage, oldest != nil
+}
+
+// sidecar checks whether the container is the sidecar by its name and the repository of its image
+func (d *SidecarDriftEvictor) sidecar(container v1.Container) bool {
+	if d.args.ContainerName != "" && container.Name != d.args.ContainerName {
+		return false
+	}
+	if d.args.ImageName == "" {
+		return true
+	}
+	repository, _ := imageRepositoryAndTag(container.Image)
+	return repository == d.args.ImageName || strings.HasSuffix(repository, "/"+d.args.ImageName)
+}
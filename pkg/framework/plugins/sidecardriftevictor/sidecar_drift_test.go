@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecardriftevictor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestSidecarDriftEvictor(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 4000, 3000, 20, nil)
+
+	buildPod := func(name, owner string, apply func(pod *v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, n1.Name, func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "v1", Name: owner, UID: types.UID(owner)}}
+			pod.Spec.Containers[0].Name = "app"
+			pod.Spec.Containers[0].Image = "registry.example.com/team/app:1.0.0"
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	withSidecar := func(name, image string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: name, Image: image})
+		}
+	}
+	withNativeSidecar := func(name, image string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.InitContainers = append(pod.Spec.InitContainers, v1.Container{
+				Name:          name,
+				Image:         image,
+				RestartPolicy: utilptr.To(v1.ContainerRestartPolicyAlways),
+			})
+		}
+	}
+
+	tests := []struct {
+		description     string
+		pods            []*v1.Pod
+		args            SidecarDriftEvictorArgs
+		expectedEvicted []string
+	}{
+		{
+			description: "Pod whose sidecar is older than the required version is evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withSidecar("istio-proxy", "docker.io/istio/proxyv2:1.21.2")),
+				buildPod("p2", "rs2", withSidecar("istio-proxy", "docker.io/istio/proxyv2:1.22.3-distroless")),
+				buildPod("p3", "rs3", withSidecar("istio-proxy", "docker.io/istio/proxyv2@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")),
+				buildPod("p4", "rs4", nil),
+			},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Pod whose native sidecar is older than the required version is evicted",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withNativeSidecar("istio-proxy", "docker.io/istio/proxyv2:1.21.2")),
+				buildPod("p2", "rs2", func(pod *v1.Pod) {
+					// An init container which completed is no sidecar
+					pod.Spec.InitContainers = []v1.Container{{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.21.2"}}
+				}),
+			},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Sidecar detected by the repository of its image",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withSidecar("linkerd-proxy", "cr.l5d.io/linkerd/proxy:stable-2.13.7")),
+				buildPod("p2", "rs2", withSidecar("linkerd-proxy", "cr.l5d.io/linkerd/proxy:stable-2.14.10")),
+				buildPod("p3", "rs3", withSidecar("linkerd-proxy", "cr.l5d.io/other/proxy:stable-2.13.7")),
+			},
+			args:            SidecarDriftEvictorArgs{ImageName: "linkerd/proxy", RequiredVersion: "stable-2.14.10"},
+			expectedEvicted: []string{"p1"},
+		},
+		{
+			description: "Pods running the oldest sidecars are evicted first, once per owner",
+			pods: []*v1.Pod{
+				buildPod("p1", "rs1", withSidecar("istio-proxy", "docker.io/istio/proxyv2:1.20.0")),
+				buildPod("p2", "rs1", withSidecar("istio-proxy", "docker.io/istio/proxyv2:1.19.0")),
+				buildPod("p3", "rs2", withSidecar("istio-proxy", "docker.io/istio/proxyv2:1.21.0")),
+				buildPod("p4", "rs3", withSidecar("istio-proxy", "docker.io/istio/proxyv2:1.22.0")),
+			},
+			args:            SidecarDriftEvictorArgs{MaxEvictionsPerCycle: utilptr.To[uint](2)},
+			expectedEvicted: []string{"p2", "p3"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			args := tc.args
+			if args.RequiredVersion == "" {
+				args.RequiredVersion = "1.22.3"
+			}
+			SetDefaults_SidecarDriftEvictorArgs(&args)
+
+			scenario.New().
+				WithNodes(n1).
+				WithPods(tc.pods...).
+				ExpectEvicted(tc.expectedEvicted...).
+				Run(t, New, &args)
+		})
+	}
+}
+
+func TestMeshVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apis/sailoperator.io/v1/istios/default":
+			w.Write([]byte(`{"apiVersion":"sailoperator.io/v1","kind":"Istio","spec":{"version":"v1.22.3"}}`))
+		case "/apis/example.com/v1/namespaces/mesh/meshes/default":
+			w.Write([]byte(`{"spec":{"version":1}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("Unable to create a client: %v", err)
+	}
+
+	tests := []struct {
+		description     string
+		source          VersionSource
+		expectedVersion string
+		expectError     bool
+	}{
+		{
+			description:     "version read from the field of a cluster scoped resource",
+			source:          VersionSource{APIVersion: "sailoperator.io/v1", Resource: "istios", Name: "default", FieldPath: "spec.version"},
+			expectedVersion: "1.22.3",
+		},
+		{
+			description: "field not set",
+			source:      VersionSource{APIVersion: "sailoperator.io/v1", Resource: "istios", Name: "default", FieldPath: "status.version"},
+			expectError: true,
+		},
+		{
+			description: "field not holding a string",
+			source:      VersionSource{APIVersion: "example.com/v1", Resource: "meshes", Namespace: "mesh", Name: "default", FieldPath: "spec.version"},
+			expectError: true,
+		},
+		{
+			description: "resource not found",
+			source:      VersionSource{APIVersion: "sailoperator.io/v1", Resource: "istios", Name: "other", FieldPath: "spec.version"},
+			expectError: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			v, err := meshVersion(context.Background(), client, &tc.source)
+			if hasError := err != nil; hasError != tc.expectError {
+				t.Fatalf("Expected error %v, got %v", tc.expectError, err)
+			}
+			if err == nil && v.String() != tc.expectedVersion {
+				t.Errorf("Expected version %v, got %v", tc.expectedVersion, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecardriftevictor
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SidecarDriftEvictorArgs holds arguments used to configure SidecarDriftEvictor plugin.
+type SidecarDriftEvictorArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// ContainerName is the name of the sidecar container. Defaults to istio-proxy when ImageName is not set.
+	ContainerName string `json:"containerName,omitempty"`
+	// ImageName detects the sidecar by the repository of its image, e.g. istio/proxyv2, matching the
+	// trailing path segments of the repository
+	ImageName string `json:"imageName,omitempty"`
+	// RequiredVersion is the version the sidecars older than get evicted, e.g. 1.22.3
+	RequiredVersion string `json:"requiredVersion,omitempty"`
+	// RequiredVersionFrom reads the required version from a field of a custom resource of the mesh
+	// every descheduling cycle, instead of RequiredVersion
+	RequiredVersionFrom *VersionSource `json:"requiredVersionFrom,omitempty"`
+	// MaxEvictionsPerCycle limits the pods evicted by the plugin in a descheduling cycle. Defaults to 1.
+	MaxEvictionsPerCycle *uint `json:"maxEvictionsPerCycle,omitempty"`
+	// MaxEvictionsPerOwner limits the pods of the same owner evicted by the plugin in a descheduling cycle.
+	// Defaults to 1.
+	MaxEvictionsPerOwner *uint `json:"maxEvictionsPerOwner,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// VersionSource is a field of a custom resource holding the version of the mesh.
+type VersionSource struct {
+	// APIVersion of the resource, e.g. sailoperator.io/v1
+	APIVersion string `json:"apiVersion"`
+	// Resource is the plural name of the resource, e.g. istios
+	Resource string `json:"resource"`
+	// Namespace of the resource, empty for a cluster scoped resource
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the resource
+	Name string `json:"name"`
+	// FieldPath is the dot separated path of the field holding the version, e.g. spec.version
+	FieldPath string `json:"fieldPath"`
+}
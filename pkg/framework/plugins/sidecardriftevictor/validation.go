@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecardriftevictor
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateSidecarDriftEvictorArgs validates SidecarDriftEvictor arguments
+func ValidateSidecarDriftEvictorArgs(obj runtime.Object) error {
+	args := obj.(*SidecarDriftEvictorArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.ContainerName == "" && args.ImageName == "" {
+		return fmt.Errorf("one of containerName and imageName has to be set")
+	}
+	if (args.RequiredVersion == "") == (args.RequiredVersionFrom == nil) {
+		return fmt.Errorf("exactly one of requiredVersion and requiredVersionFrom has to be set")
+	}
+	if args.RequiredVersion != "" {
+		if _, err := parseVersion(args.RequiredVersion); err != nil {
+			return fmt.Errorf("invalid requiredVersion: %v", err)
+		}
+	}
+	if source := args.RequiredVersionFrom; source != nil {
+		if source.APIVersion == "" || source.Resource == "" || source.Name == "" {
+			return fmt.Errorf("requiredVersionFrom needs an apiVersion, a resource and a name")
+		}
+		if source.FieldPath == "" || strings.HasPrefix(source.FieldPath, ".") || strings.HasSuffix(source.FieldPath, ".") || strings.Contains(source.FieldPath, "..") {
+			return fmt.Errorf("requiredVersionFrom fieldPath %q is not a valid dot separated path", source.FieldPath)
+		}
+	}
+	if args.MaxEvictionsPerCycle != nil && *args.MaxEvictionsPerCycle == 0 {
+		return fmt.Errorf("maxEvictionsPerCycle has to be positive")
+	}
+	if args.MaxEvictionsPerOwner != nil && *args.MaxEvictionsPerOwner == 0 {
+		return fmt.Errorf("maxEvictionsPerOwner has to be positive")
+	}
+
+	return nil
+}
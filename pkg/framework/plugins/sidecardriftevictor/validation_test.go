@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecardriftevictor
+
+import (
+	"testing"
+
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateSidecarDriftEvictorArgs(t *testing.T) {
+	meshSource := &VersionSource{APIVersion: "sailoperator.io/v1", Resource: "istios", Name: "default", FieldPath: "spec.version"}
+
+	testCases := []struct {
+		description string
+		args        *SidecarDriftEvictorArgs
+		expectError bool
+	}{
+		{
+			description: "valid args with a required version, no errors",
+			args:        &SidecarDriftEvictorArgs{ContainerName: "istio-proxy", RequiredVersion: "1.22.3"},
+			expectError: false,
+		},
+		{
+			description: "valid args with a mesh resource, no errors",
+			args:        &SidecarDriftEvictorArgs{ImageName: "istio/proxyv2", RequiredVersionFrom: meshSource},
+			expectError: false,
+		},
+		{
+			description: "invalid namespaces args, expects error",
+			args: &SidecarDriftEvictorArgs{
+				FilteringArgs:   api.FilteringArgs{Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}},
+				ContainerName:   "istio-proxy",
+				RequiredVersion: "1.22.3",
+			},
+			expectError: true,
+		},
+		{
+			description: "no sidecar detection, expects error",
+			args:        &SidecarDriftEvictorArgs{RequiredVersion: "1.22.3"},
+			expectError: true,
+		},
+		{
+			description: "no required version, expects error",
+			args:        &SidecarDriftEvictorArgs{ContainerName: "istio-proxy"},
+			expectError: true,
+		},
+		{
+			description: "both a required version and a mesh resource, expects error",
+			args:        &SidecarDriftEvictorArgs{ContainerName: "istio-proxy", RequiredVersion: "1.22.3", RequiredVersionFrom: meshSource},
+			expectError: true,
+		},
+		{
+			description: "invalid required version, expects error",
+			args:        &SidecarDriftEvictorArgs{ContainerName: "istio-proxy", RequiredVersion: "latest"},
+			expectError: true,
+		},
+		{
+			description: "mesh resource without a name, expects error",
+			args: &SidecarDriftEvictorArgs{
+				ContainerName:       "istio-proxy",
+				RequiredVersionFrom: &VersionSource{APIVersion: "sailoperator.io/v1", Resource: "istios", FieldPath: "spec.version"},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid mesh resource field path, expects error",
+			args: &SidecarDriftEvictorArgs{
+				ContainerName:       "istio-proxy",
+				RequiredVersionFrom: &VersionSource{APIVersion: "sailoperator.io/v1", Resource: "istios", Name: "default", FieldPath: "spec..version"},
+			},
+			expectError: true,
+		},
+		{
+			description: "zero maxEvictionsPerCycle, expects error",
+			args:        &SidecarDriftEvictorArgs{ContainerName: "istio-proxy", RequiredVersion: "1.22.3", MaxEvictionsPerCycle: utilptr.To[uint](0)},
+			expectError: true,
+		},
+		{
+			description: "zero maxEvictionsPerOwner, expects error",
+			args:        &SidecarDriftEvictorArgs{ContainerName: "istio-proxy", RequiredVersion: "1.22.3", MaxEvictionsPerOwner: utilptr.To[uint](0)},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateSidecarDriftEvictorArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
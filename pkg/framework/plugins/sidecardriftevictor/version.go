@@ -0,0 +1,86 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecardriftevictor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/version"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// parseVersion parses the version of a sidecar image tag or of a mesh, ignoring a leading non numeric
+// prefix, e.g. the v of v1.22.3 or the stable- of stable-2.14.10, and a trailing suffix, e.g. the
+// -distroless of 1.22.3-distroless
+func parseVersion(s string) (*version.Version, error) {
+	return version.ParseGeneric(strings.TrimLeftFunc(s, func(r rune) bool { return !unicode.IsDigit(r) }))
+}
+
+// imageRepositoryAndTag returns the repository of the image, e.g. docker.io/istio/proxyv2, and its tag,
+// empty when the image is pinned by digest
+func imageRepositoryAndTag(image string) (string, string) {
+	name := utils.NormalizeImageReference(image)
+	if at := strings.Index(name, "@"); at >= 0 {
+		name = name[:at]
+		if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+			name = name[:colon]
+		}
+		return name, ""
+	}
+	colon := strings.LastIndex(name, ":")
+	return name[:colon], name[colon+1:]
+}
+
+// resourcePath returns the API path of the resource holding the version of the mesh
+func (s *VersionSource) resourcePath() string {
+	path := "/api/" + s.APIVersion
+	if strings.Contains(s.APIVersion, "/") {
+		path = "/apis/" + s.APIVersion
+	}
+	if s.Namespace != "" {
+		path += "/namespaces/" + s.Namespace
+	}
+	return path + "/" + s.Resource + "/" + s.Name
+}
+
+// meshVersion reads the version of the mesh from the field of its custom resource
+func meshVersion(ctx context.Context, client clientset.Interface, source *VersionSource) (*version.Version, error) {
+	body, err := client.CoreV1().RESTClient().Get().AbsPath(source.resourcePath()).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get %s: %v", source.resourcePath(), err)
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %v", source.resourcePath(), err)
+	}
+	value, found, err := unstructured.NestedString(object, strings.Split(source.FieldPath, ".")...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read field %s of %s: %v", source.FieldPath, source.resourcePath(), err)
+	}
+	if !found {
+		return nil, fmt.Errorf("field %s of %s is not set", source.FieldPath, source.resourcePath())
+	}
+	v, err := parseVersion(value)
+	if err != nil {
+		return nil, fmt.Errorf("field %s of %s does not hold a version: %v", source.FieldPath, source.resourcePath(), err)
+	}
+	return v, nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stalenodeleaseevictor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	coordinationinformers "k8s.io/client-go/informers/coordination/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	coordinationlisters "k8s.io/client-go/listers/coordination/v1"
+	"k8s.io/client-go/tools/cache"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "StaleNodeLeaseEvictor"
+
+	// NodeLeaseStaleReason is the reason of the events reported on the nodes whose lease is stale
+	NodeLeaseStaleReason = "NodeLeaseStale"
+
+	actionReported = "Reported"
+)
+
+// StaleNodeLeaseEvictor evicts the pods of the nodes still reported ready whose kubelet stopped renewing
+// the lease of the node, before the node controller marks them not ready and taints them, to shave the
+// grace period of the node controller and the toleration of the taints off the failover of the pods.
+// Nothing is evicted when the leases of too many nodes are stale, which rather tells the descheduler or
+// the control plane is cut off from the nodes.
+type StaleNodeLeaseEvictor struct {
+	handle      frameworktypes.Handle
+	args        *StaleNodeLeaseEvictorArgs
+	podFilter   podutil.FilterFunc
+	leaseLister coordinationlisters.LeaseNamespaceLister
+}
+
+var _ frameworktypes.DeschedulePlugin = &StaleNodeLeaseEvictor{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	staleLeaseArgs, ok := args.(*StaleNodeLeaseEvictorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type StaleNodeLeaseEvictorArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if staleLeaseArgs.Namespaces != nil {
+		includedNamespaces = sets.New(staleLeaseArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(staleLeaseArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(staleLeaseArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	leaseInformer := handle.SharedInformerFactory().InformerFor(&coordinationv1.Lease{}, newNodeLeaseInformer)
+	return &StaleNodeLeaseEvictor{
+		handle:      handle,
+		args:        staleLeaseArgs,
+		podFilter:   podFilter,
+		leaseLister: coordinationlisters.NewLeaseLister(leaseInformer.GetIndexer()).Leases(v1.NamespaceNodeLease),
+	}, nil
+}
+
+// newNodeLeaseInformer builds an informer caching the leases of the nodes only, instead of all the
+// leases of the cluster.
+func newNodeLeaseInformer(client clientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return coordinationinformers.NewLeaseInformer(client, v1.NamespaceNodeLease, resyncPeriod,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+}
+
+// Name retrieves the plugin name
+func (d *StaleNodeLeaseEvictor) Name() string {
+	return PluginName
+}
+
+// leaseStaleSince returns when the lease of the node was last renewed if it is stale, nil when the lease
+// is fresh or the node has no lease to tell.
+func (d *StaleNodeLeaseEvictor) leaseStaleSince(node *v1.Node, now time.Time) *metav1.MicroTime {
+	lease, err := d.leaseLister.Get(node.Name)
+	if err != nil || lease.Spec.RenewTime == nil {
+		return nil
+	}
+	if now.Sub(lease.Spec.RenewTime.Time) < d.args.StaleThreshold.Duration {
+		return nil
+	}
+	return lease.Spec.RenewTime
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *StaleNodeLeaseEvictor) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	now := time.Now()
+	var staleNodes, freshNodes []*v1.Node
+	renewTimes := map[string]*metav1.MicroTime{}
+	for _, node := range nodes {
+		renewTime := d.leaseStaleSince(node, now)
+		if renewTime == nil {
+			freshNodes = append(freshNodes, node)
+			continue
+		}
+		staleNodes = append(staleNodes, node)
+		renewTimes[node.Name] = renewTime
+	}
+	if len(staleNodes) == 0 {
+		return nil
+	}
+	maxStaleNodes := int(math.Max(1, math.Floor(float64(len(nodes))**d.args.MaxStaleNodesPercentage/100)))
+	if len(staleNodes) > maxStaleNodes {
+		logger.Info("Too many nodes with a stale lease, skipping", "staleNodes", len(staleNodes), "nodes", len(nodes), "maxStaleNodes", maxStaleNodes)
+		return nil
+	}
+
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	for _, node := range staleNodes {
+		logger.V(1).Info("Processing node with a stale lease", "node", klog.KObj(node), "renewTime", renewTimes[node.Name].Time)
+		d.handle.EventRecorder().Eventf(node, nil, v1.EventTypeWarning, NodeLeaseStaleReason, actionReported,
+			"Node lease not renewed since %s while the node is still reported ready", renewTimes[node.Name].Time.Format(time.RFC3339))
+
+		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		// The most important pods fail over first in case the node limit is reached
+		sort.SliceStable(pods, func(i, j int) bool {
+			return corev1helpers.PodPriority(pods[i]) > corev1helpers.PodPriority(pods[j])
+		})
+	loop:
+		for _, pod := range pods {
+			// Moving the pod to another node whose lease is stale would not help it
+			if !nodeutil.PodFitsAnyNode(getPodsAssignedToNode, pod, freshNodes) {
+				logger.V(2).Info("Pod does not fit any node with a fresh lease", "pod", klog.KObj(pod))
+				continue
+			}
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
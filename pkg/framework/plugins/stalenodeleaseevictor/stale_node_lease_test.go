@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stalenodeleaseevictor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildLease(nodeName string, renewedAgo time.Duration) *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: v1.NamespaceNodeLease, Name: nodeName},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity: ptr.To(nodeName),
+			RenewTime:      &metav1.MicroTime{Time: time.Now().Add(-renewedAgo)},
+		},
+	}
+}
+
+func buildPod(name, nodeName string, apply func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		test.SetRSOwnerRef(pod)
+		if apply != nil {
+			apply(pod)
+		}
+	})
+}
+
+func TestStaleNodeLeaseEvictor(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	n3 := test.BuildTestNode("n3", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1, n2, n3}
+	pods := []*v1.Pod{
+		buildPod("p1", "n1", nil),
+		buildPod("p2", "n1", nil),
+		buildPod("p3", "n2", nil),
+		buildPod("p4", "n3", nil),
+	}
+
+	tests := []struct {
+		description          string
+		args                 StaleNodeLeaseEvictorArgs
+		leases               []*coordinationv1.Lease
+		pods                 []*v1.Pod
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "Pods of a ready node with a stale lease are evicted",
+			leases:               []*coordinationv1.Lease{buildLease("n1", time.Minute), buildLease("n2", time.Second), buildLease("n3", time.Second)},
+			pods:                 pods,
+			expectedEvictedCount: 2,
+		},
+		{
+			description:          "Fresh leases, no eviction",
+			leases:               []*coordinationv1.Lease{buildLease("n1", 10*time.Second), buildLease("n2", time.Second), buildLease("n3", time.Second)},
+			pods:                 pods,
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Nodes without lease are not evicted from",
+			leases:               []*coordinationv1.Lease{buildLease("n2", time.Second), buildLease("n3", time.Second)},
+			pods:                 pods,
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Too many nodes with a stale lease, no eviction",
+			leases:               []*coordinationv1.Lease{buildLease("n1", time.Minute), buildLease("n2", time.Minute), buildLease("n3", time.Second)},
+			pods:                 pods,
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "Several nodes with a stale lease allowed",
+			args:                 StaleNodeLeaseEvictorArgs{MaxStaleNodesPercentage: ptr.To(70.0)},
+			leases:               []*coordinationv1.Lease{buildLease("n1", time.Minute), buildLease("n2", time.Minute), buildLease("n3", time.Second)},
+			pods:                 pods,
+			expectedEvictedCount: 3,
+		},
+		{
+			description: "Pods fitting no node with a fresh lease are not evicted",
+			leases:      []*coordinationv1.Lease{buildLease("n1", time.Minute), buildLease("n2", time.Second), buildLease("n3", time.Second)},
+			pods: []*v1.Pod{
+				buildPod("p1", "n1", nil),
+				buildPod("p2", "n1", func(pod *v1.Pod) {
+					pod.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+				}),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description:          "Longer stale threshold, no eviction",
+			args:                 StaleNodeLeaseEvictorArgs{StaleThreshold: &metav1.Duration{Duration: 2 * time.Minute}},
+			leases:               []*coordinationv1.Lease{buildLease("n1", time.Minute), buildLease("n2", time.Second), buildLease("n3", time.Second)},
+			pods:                 pods,
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, lease := range tc.leases {
+				objs = append(objs, lease)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			args := tc.args.DeepCopy()
+			SetDefaults_StaleNodeLeaseEvictorArgs(args)
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			handle.SharedInformerFactoryImpl.Start(ctx.Done())
+			handle.SharedInformerFactoryImpl.WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stalenodeleaseevictor
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StaleNodeLeaseEvictorArgs holds arguments used to configure StaleNodeLeaseEvictor plugin.
+type StaleNodeLeaseEvictorArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// StaleThreshold is how long after its last renewal the lease of a node is considered stale.
+	// Defaults to 30 seconds, below the grace period of the node controller.
+	StaleThreshold *metav1.Duration `json:"staleThreshold,omitempty"`
+	// MaxStaleNodesPercentage is the percentage of the nodes above which the stale leases are blamed
+	// on the connectivity of the descheduler or on the control plane, and nothing is evicted. Pods
+	// may always be evicted from a single node. Defaults to 10.
+	MaxStaleNodesPercentage *float64 `json:"maxStaleNodesPercentage,omitempty"`
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stalenodeleaseevictor
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateStaleNodeLeaseEvictorArgs validates StaleNodeLeaseEvictor arguments
+func ValidateStaleNodeLeaseEvictorArgs(obj runtime.Object) error {
+	args := obj.(*StaleNodeLeaseEvictorArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.StaleThreshold != nil && args.StaleThreshold.Duration <= 0 {
+		return fmt.Errorf("staleThreshold must be greater than zero")
+	}
+	if args.MaxStaleNodesPercentage != nil && (*args.MaxStaleNodesPercentage <= 0 || *args.MaxStaleNodesPercentage > 100) {
+		return fmt.Errorf("maxStaleNodesPercentage must be greater than 0 and at most 100, got %v", *args.MaxStaleNodesPercentage)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stalenodeleaseevictor
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateStaleNodeLeaseEvictorArgs(t *testing.T) {
+	validArgs := func(mutate func(*StaleNodeLeaseEvictorArgs)) *StaleNodeLeaseEvictorArgs {
+		args := &StaleNodeLeaseEvictorArgs{}
+		SetDefaults_StaleNodeLeaseEvictorArgs(args)
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *StaleNodeLeaseEvictorArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *StaleNodeLeaseEvictorArgs) {
+				args.Namespaces = &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero stale threshold, expects error",
+			args: validArgs(func(args *StaleNodeLeaseEvictorArgs) {
+				args.StaleThreshold = &metav1.Duration{}
+			}),
+			expectError: true,
+		},
+		{
+			description: "zero max stale nodes percentage, expects error",
+			args: validArgs(func(args *StaleNodeLeaseEvictorArgs) {
+				args.MaxStaleNodesPercentage = ptr.To(0.0)
+			}),
+			expectError: true,
+		},
+		{
+			description: "max stale nodes percentage above 100, expects error",
+			args: validArgs(func(args *StaleNodeLeaseEvictorArgs) {
+				args.MaxStaleNodesPercentage = ptr.To(150.0)
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateStaleNodeLeaseEvictorArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
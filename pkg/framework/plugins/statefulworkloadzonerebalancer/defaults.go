@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulworkloadzonerebalancer
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
+)
+
+const (
+	// DefaultMaxSkew is the difference between the replicas of the zones tolerated by default
+	DefaultMaxSkew = 1
+	// DefaultMaxMovesPerStatefulSet is the number of replicas of a StatefulSet moved per cycle by default
+	DefaultMaxMovesPerStatefulSet = 1
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_StatefulWorkloadZoneRebalancerArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_StatefulWorkloadZoneRebalancerArgs(obj runtime.Object) {
+	args := obj.(*StatefulWorkloadZoneRebalancerArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.TopologyKey == "" {
+		args.TopologyKey = v1.LabelTopologyZone
+	}
+	if args.MaxSkew == 0 {
+		args.MaxSkew = DefaultMaxSkew
+	}
+	if args.MaxMovesPerStatefulSet == nil {
+		args.MaxMovesPerStatefulSet = utilptr.To[uint](DefaultMaxMovesPerStatefulSet)
+	}
+}
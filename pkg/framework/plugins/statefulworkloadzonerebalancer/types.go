@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulworkloadzonerebalancer
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StatefulWorkloadZoneRebalancerArgs holds arguments used to configure StatefulWorkloadZoneRebalancer plugin.
+type StatefulWorkloadZoneRebalancerArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// TopologyKey is the node label whose value identifies the zone of the nodes
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// MaxSkew is the difference between the replicas of a StatefulSet in its most and least
+	// populated zones tolerated before moving a replica
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+	// CrossZoneStorageClassNames lists the storage classes whose volumes can be attached in any
+	// zone, e.g. regional disks or network file systems. The claims of these classes are kept.
+	CrossZoneStorageClassNames []string `json:"crossZoneStorageClassNames,omitempty"`
+	// DeletableData opts in moving the replicas whose claims use a WaitForFirstConsumer storage
+	// class, deleting their claims so they get provisioned again in the new zone. Only the claims
+	// of the StatefulSets whose claim retention policy deletes the claims of the scaled down
+	// replicas are deleted.
+	DeletableData bool `json:"deletableData,omitempty"`
+	// MaxMovesPerStatefulSet bounds the replicas of each StatefulSet moved per cycle
+	MaxMovesPerStatefulSet *uint `json:"maxMovesPerStatefulSet,omitempty"`
+}
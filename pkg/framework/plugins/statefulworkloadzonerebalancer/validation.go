@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulworkloadzonerebalancer
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateStatefulWorkloadZoneRebalancerArgs validates StatefulWorkloadZoneRebalancer arguments
+func ValidateStatefulWorkloadZoneRebalancerArgs(obj runtime.Object) error {
+	args := obj.(*StatefulWorkloadZoneRebalancerArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if args.TopologyKey == "" {
+		return fmt.Errorf("TopologyKey not set")
+	}
+	if errs := validation.IsQualifiedName(args.TopologyKey); len(errs) > 0 {
+		return fmt.Errorf("invalid TopologyKey %q: %s", args.TopologyKey, strings.Join(errs, "; "))
+	}
+
+	if args.MaxSkew < 1 {
+		return fmt.Errorf("MaxSkew must be greater than zero, got %d", args.MaxSkew)
+	}
+
+	for _, name := range args.CrossZoneStorageClassNames {
+		if name == "" {
+			return fmt.Errorf("CrossZoneStorageClassNames can not contain an empty name")
+		}
+	}
+
+	if args.MaxMovesPerStatefulSet != nil && *args.MaxMovesPerStatefulSet == 0 {
+		return fmt.Errorf("MaxMovesPerStatefulSet must be greater than zero")
+	}
+
+	return nil
+}
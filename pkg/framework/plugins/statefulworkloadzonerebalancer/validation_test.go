@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulworkloadzonerebalancer
+
+import (
+	"testing"
+
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateStatefulWorkloadZoneRebalancerArgs(t *testing.T) {
+	validArgs := func(mutate func(*StatefulWorkloadZoneRebalancerArgs)) *StatefulWorkloadZoneRebalancerArgs {
+		args := &StatefulWorkloadZoneRebalancerArgs{
+			CrossZoneStorageClassNames: []string{"regional"},
+		}
+		SetDefaults_StatefulWorkloadZoneRebalancerArgs(args)
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *StatefulWorkloadZoneRebalancerArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: validArgs(func(args *StatefulWorkloadZoneRebalancerArgs) {
+				args.FilteringArgs = api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				}
+			}),
+			expectError: true,
+		},
+		{
+			description: "empty topology key, expects error",
+			args: validArgs(func(args *StatefulWorkloadZoneRebalancerArgs) {
+				args.TopologyKey = ""
+			}),
+			expectError: true,
+		},
+		{
+			description: "invalid topology key, expects error",
+			args: validArgs(func(args *StatefulWorkloadZoneRebalancerArgs) {
+				args.TopologyKey = "example.com/zone/"
+			}),
+			expectError: true,
+		},
+		{
+			description: "negative max skew, expects error",
+			args: validArgs(func(args *StatefulWorkloadZoneRebalancerArgs) {
+				args.MaxSkew = -1
+			}),
+			expectError: true,
+		},
+		{
+			description: "empty storage class name, expects error",
+			args: validArgs(func(args *StatefulWorkloadZoneRebalancerArgs) {
+				args.CrossZoneStorageClassNames = []string{""}
+			}),
+			expectError: true,
+		},
+		{
+			description: "no move per StatefulSet, expects error",
+			args: validArgs(func(args *StatefulWorkloadZoneRebalancerArgs) {
+				args.MaxMovesPerStatefulSet = utilptr.To[uint](0)
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateStatefulWorkloadZoneRebalancerArgs(tc.args)
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
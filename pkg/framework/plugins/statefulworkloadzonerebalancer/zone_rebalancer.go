@@ -0,0 +1,337 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulworkloadzonerebalancer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	storagelisters "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "StatefulWorkloadZoneRebalancer"
+
+// StatefulWorkloadZoneRebalancer evicts the replicas of a StatefulSet from its most populated zone
+// while it runs more than maxSkew replicas more than its least populated zone, so they get
+// scheduled again in the latter. A replica is only moved when its storage can follow it: its
+// claims either use a storage class whose volumes can be attached in any zone, or, once opted in
+// through deletableData, use a WaitForFirstConsumer storage class and belong to a StatefulSet
+// deleting the claims of its scaled down replicas. The latter claims are deleted along with the
+// eviction, so the StatefulSet controller provisions them again in the zone of the new replica.
+type StatefulWorkloadZoneRebalancer struct {
+	handle                  frameworktypes.Handle
+	args                    *StatefulWorkloadZoneRebalancerArgs
+	podFilter               podutil.FilterFunc
+	crossZoneStorageClasses sets.Set[string]
+	pvcLister               listersv1.PersistentVolumeClaimLister
+	storageClassLister      storagelisters.StorageClassLister
+	statefulSetLister       appslisters.StatefulSetLister
+}
+
+var _ frameworktypes.BalancePlugin = &StatefulWorkloadZoneRebalancer{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	rebalancerArgs, ok := args.(*StatefulWorkloadZoneRebalancerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type StatefulWorkloadZoneRebalancerArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if rebalancerArgs.Namespaces != nil {
+		includedNamespaces = sets.New(rebalancerArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(rebalancerArgs.Namespaces.Exclude...)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(rebalancerArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &StatefulWorkloadZoneRebalancer{
+		handle:                  handle,
+		args:                    rebalancerArgs,
+		podFilter:               podFilter,
+		crossZoneStorageClasses: sets.New(rebalancerArgs.CrossZoneStorageClassNames...),
+		// The claims, storage classes and StatefulSets are read from the cluster,
+		// the cached client of the dry run mode holds none
+		pvcLister:          handle.ClusterInformerFactory().Core().V1().PersistentVolumeClaims().Lister(),
+		storageClassLister: handle.ClusterInformerFactory().Storage().V1().StorageClasses().Lister(),
+		statefulSetLister:  handle.ClusterInformerFactory().Apps().V1().StatefulSets().Lister(),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *StatefulWorkloadZoneRebalancer) Name() string {
+	return PluginName
+}
+
+// statefulSetReplicas holds the replicas of a StatefulSet running in each zone
+type statefulSetReplicas struct {
+	namespace string
+	name      string
+	zones     map[string][]*v1.Pod
+}
+
+// mostAndLeastPopulated returns the zones running the most and the least replicas, the first
+// zone in alphabetical order on ties
+func (r *statefulSetReplicas) mostAndLeastPopulated(zones []string) (string, string) {
+	most, least := zones[0], zones[0]
+	for _, zone := range zones[1:] {
+		if len(r.zones[zone]) > len(r.zones[most]) {
+			most = zone
+		}
+		if len(r.zones[zone]) < len(r.zones[least]) {
+			least = zone
+		}
+	}
+	return most, least
+}
+
+// move records the move of the pod from a zone to another
+func (r *statefulSetReplicas) move(pod *v1.Pod, from, to string) {
+	for i := range r.zones[from] {
+		if r.zones[from][i] == pod {
+			r.zones[from] = append(r.zones[from][:i], r.zones[from][i+1:]...)
+			break
+		}
+	}
+	r.zones[to] = append(r.zones[to], pod)
+}
+
+// Balance extension point implementation for the plugin
+func (d *StatefulWorkloadZoneRebalancer) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	nodesOfZone := map[string][]*v1.Node{}
+	zoneOfNode := map[string]string{}
+	for _, node := range nodes {
+		zone, ok := node.Labels[d.args.TopologyKey]
+		if !ok {
+			continue
+		}
+		nodesOfZone[zone] = append(nodesOfZone[zone], node)
+		zoneOfNode[node.Name] = zone
+	}
+	if len(nodesOfZone) < 2 {
+		logger.V(1).Info("Less than two zones, nothing to rebalance", "topologyKey", d.args.TopologyKey)
+		return nil
+	}
+	zones := sets.List(sets.KeySet(nodesOfZone))
+
+	// All the replicas count, the ones the evictor does not evict included
+	pods, err := podutil.ListPodsOnNodes(nodes, d.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing all pods: %v", err),
+		}
+	}
+	statefulSets := map[types.UID]*statefulSetReplicas{}
+	for _, pod := range pods {
+		ownerRef := metav1.GetControllerOf(pod)
+		zone, ok := zoneOfNode[pod.Spec.NodeName]
+		if ownerRef == nil || ownerRef.Kind != "StatefulSet" || !ok {
+			continue
+		}
+		replicas, ok := statefulSets[ownerRef.UID]
+		if !ok {
+			replicas = &statefulSetReplicas{namespace: pod.Namespace, name: ownerRef.Name, zones: map[string][]*v1.Pod{}}
+			statefulSets[ownerRef.UID] = replicas
+		}
+		replicas.zones[zone] = append(replicas.zones[zone], pod)
+	}
+
+	ordered := make([]*statefulSetReplicas, 0, len(statefulSets))
+	for _, replicas := range statefulSets {
+		ordered = append(ordered, replicas)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].namespace != ordered[j].namespace {
+			return ordered[i].namespace < ordered[j].namespace
+		}
+		return ordered[i].name < ordered[j].name
+	})
+
+	for _, replicas := range ordered {
+		for moves := uint(0); moves < *d.args.MaxMovesPerStatefulSet; moves++ {
+			from, to := replicas.mostAndLeastPopulated(zones)
+			if int32(len(replicas.zones[from])-len(replicas.zones[to])) <= d.args.MaxSkew {
+				break
+			}
+			logger.V(1).Info("Rebalancing StatefulSet", "statefulSet", klog.KRef(replicas.namespace, replicas.name), "from", from, "to", to, "replicasFrom", len(replicas.zones[from]), "replicasTo", len(replicas.zones[to]))
+			moved, err := d.moveReplica(ctx, replicas, from, to, nodesOfZone[to])
+			if err != nil {
+				// The total eviction limit got reached
+				return nil
+			}
+			if !moved {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// moveReplica evicts a replica of the StatefulSet whose storage can follow it from a zone to
+// another, the highest ordinal first, and deletes the claims to provision again
+func (d *StatefulWorkloadZoneRebalancer) moveReplica(ctx context.Context, replicas *statefulSetReplicas, from, to string, nodes []*v1.Node) (bool, error) {
+	logger := klog.FromContext(ctx)
+	var candidates []*v1.Pod
+	for _, pod := range replicas.zones[from] {
+		if d.podFilter(pod) {
+			candidates = append(candidates, pod)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i].Name) != len(candidates[j].Name) {
+			return len(candidates[i].Name) > len(candidates[j].Name)
+		}
+		return candidates[i].Name > candidates[j].Name
+	})
+
+	for _, pod := range candidates {
+		claims, movable := d.claimsToProvisionAgain(ctx, pod, replicas)
+		if !movable {
+			continue
+		}
+		if !nodeutil.PodFitsAnyNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes) {
+			logger.V(2).Info("Replica does not fit any node of the zone", "pod", klog.KObj(pod), "zone", to)
+			continue
+		}
+		if !d.handle.Evictor().PreEvictionFilter(pod) {
+			continue
+		}
+		err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+		if err == nil {
+			d.deleteClaims(ctx, pod, claims)
+			replicas.move(pod, from, to)
+			return true, nil
+		}
+		switch err.(type) {
+		case *evictions.EvictionNodeLimitError:
+			continue
+		case *evictions.EvictionTotalLimitError:
+			return false, err
+		default:
+			logger.Error(err, "Eviction failed")
+		}
+	}
+	return false, nil
+}
+
+// claimsToProvisionAgain tells whether the storage of the replica can follow it to another zone,
+// returning the claims to delete so they get provisioned again in the new zone.
+func (d *StatefulWorkloadZoneRebalancer) claimsToProvisionAgain(ctx context.Context, pod *v1.Pod, replicas *statefulSetReplicas) ([]*v1.PersistentVolumeClaim, bool) {
+	logger := klog.FromContext(ctx)
+	var claims []*v1.PersistentVolumeClaim
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		claimName := volume.PersistentVolumeClaim.ClaimName
+		pvc, err := d.pvcLister.PersistentVolumeClaims(pod.Namespace).Get(claimName)
+		if err != nil {
+			logger.V(3).Info("Unable to get persistent volume claim", "pod", klog.KObj(pod), "claim", claimName, "err", err)
+			return nil, false
+		}
+		if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+			logger.V(3).Info("Claim without storage class, its volume can not follow the replica", "pod", klog.KObj(pod), "claim", claimName)
+			return nil, false
+		}
+		if d.crossZoneStorageClasses.Has(*pvc.Spec.StorageClassName) {
+			continue
+		}
+		if !d.args.DeletableData {
+			logger.V(3).Info("Claim bound to its zone", "pod", klog.KObj(pod), "claim", claimName, "storageClass", *pvc.Spec.StorageClassName)
+			return nil, false
+		}
+		storageClass, err := d.storageClassLister.Get(*pvc.Spec.StorageClassName)
+		if err != nil {
+			logger.V(3).Info("Unable to get storage class", "pod", klog.KObj(pod), "storageClass", *pvc.Spec.StorageClassName, "err", err)
+			return nil, false
+		}
+		if storageClass.VolumeBindingMode == nil || *storageClass.VolumeBindingMode != storagev1.VolumeBindingWaitForFirstConsumer {
+			logger.V(3).Info("Claim provisioned regardless of the zone of its replica", "pod", klog.KObj(pod), "claim", claimName, "storageClass", storageClass.Name)
+			return nil, false
+		}
+		statefulSet, err := d.statefulSetLister.StatefulSets(replicas.namespace).Get(replicas.name)
+		if err != nil {
+			logger.V(3).Info("Unable to get StatefulSet", "statefulSet", klog.KRef(replicas.namespace, replicas.name), "err", err)
+			return nil, false
+		}
+		if !deletesScaledDownClaims(statefulSet) || !isClaimOfTemplate(statefulSet, pod, claimName) {
+			logger.V(3).Info("Claim retained by the StatefulSet", "pod", klog.KObj(pod), "claim", claimName)
+			return nil, false
+		}
+		claims = append(claims, pvc)
+	}
+	return claims, true
+}
+
+// deletesScaledDownClaims checks whether the owners of the StatefulSet let the claims of the
+// scaled down replicas get deleted, i.e. consider the data of a replica disposable
+func deletesScaledDownClaims(statefulSet *appsv1.StatefulSet) bool {
+	policy := statefulSet.Spec.PersistentVolumeClaimRetentionPolicy
+	return policy != nil && policy.WhenScaled == appsv1.DeletePersistentVolumeClaimRetentionPolicyType
+}
+
+// isClaimOfTemplate checks whether the claim got created by the StatefulSet for the replica out
+// of one of its volume claim templates
+func isClaimOfTemplate(statefulSet *appsv1.StatefulSet, pod *v1.Pod, claimName string) bool {
+	for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+		if template.Name+"-"+pod.Name == claimName {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteClaims deletes the claims of the evicted replica. They are kept until the replica is
+// gone, then the StatefulSet controller provisions them again along with the new replica.
+func (d *StatefulWorkloadZoneRebalancer) deleteClaims(ctx context.Context, pod *v1.Pod, claims []*v1.PersistentVolumeClaim) {
+	logger := klog.FromContext(ctx)
+	for _, pvc := range claims {
+		logger.V(1).Info("Deleting the claim of the moved replica", "pod", klog.KObj(pod), "claim", klog.KObj(pvc))
+		err := d.handle.ClientSet().CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{UID: &pvc.UID},
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "Unable to delete the claim of the moved replica", "pod", klog.KObj(pod), "claim", klog.KObj(pvc))
+		}
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statefulworkloadzonerebalancer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/framework/fake/scenario"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildZoneNode(name, zone string, millicpu int64) *v1.Node {
+	return test.BuildTestNode(name, millicpu, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{v1.LabelTopologyZone: zone}
+	})
+}
+
+func buildStatefulSet(retention appsv1.PersistentVolumeClaimRetentionPolicyType) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "web-uid"},
+		Spec: appsv1.StatefulSetSpec{
+			VolumeClaimTemplates: []v1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}},
+			PersistentVolumeClaimRetentionPolicy: &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  retention,
+			},
+		},
+	}
+}
+
+func buildStorageClass(name string, bindingMode storagev1.VolumeBindingMode) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta:        metav1.ObjectMeta{Name: name},
+		Provisioner:       "example.com/disk",
+		VolumeBindingMode: &bindingMode,
+	}
+}
+
+// buildReplicas builds the replicas of the web StatefulSet from the given ordinal on the node,
+// with a data claim of the storage class when set
+func buildReplicas(node string, from, count int, storageClass string) []runtime.Object {
+	var objects []runtime.Object
+	for ordinal := from; ordinal < from+count; ordinal++ {
+		name := fmt.Sprintf("web-%d", ordinal)
+		pod := test.BuildTestPod(name, 100, 0, node, func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "StatefulSet",
+				Name:       "web",
+				UID:        "web-uid",
+				Controller: utilptr.To(true),
+			}}
+		})
+		objects = append(objects, pod)
+		if storageClass == "" {
+			continue
+		}
+		claimName := "data-" + name
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name: "data",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			},
+		})
+		objects = append(objects, &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: pod.Namespace, UID: types.UID("uid-" + claimName)},
+			Spec:       v1.PersistentVolumeClaimSpec{StorageClassName: utilptr.To(storageClass)},
+		})
+	}
+	return objects
+}
+
+func TestStatefulWorkloadZoneRebalancer(t *testing.T) {
+	storageClasses := []runtime.Object{
+		buildStorageClass("regional", storagev1.VolumeBindingImmediate),
+		buildStorageClass("zonal-wffc", storagev1.VolumeBindingWaitForFirstConsumer),
+		buildStorageClass("zonal-immediate", storagev1.VolumeBindingImmediate),
+	}
+
+	tests := []struct {
+		description           string
+		args                  StatefulWorkloadZoneRebalancerArgs
+		targetNodeCPU         int64
+		statefulSet           *appsv1.StatefulSet
+		replicas              []runtime.Object
+		expectedEvicted       []string
+		expectedDeletedClaims []string
+	}{
+		{
+			description:     "replica without claims is moved, the highest ordinal first",
+			replicas:        buildReplicas("a1", 0, 3, ""),
+			expectedEvicted: []string{"web-2"},
+		},
+		{
+			description: "replica with claims of a cross-zone storage class is moved, its claims are kept",
+			args: StatefulWorkloadZoneRebalancerArgs{
+				CrossZoneStorageClassNames: []string{"regional"},
+			},
+			replicas:        buildReplicas("a1", 0, 3, "regional"),
+			expectedEvicted: []string{"web-2"},
+		},
+		{
+			description:     "replica with zonal claims is not moved without deletable data",
+			replicas:        buildReplicas("a1", 0, 3, "zonal-wffc"),
+			statefulSet:     buildStatefulSet(appsv1.DeletePersistentVolumeClaimRetentionPolicyType),
+			expectedEvicted: nil,
+		},
+		{
+			description: "replica with deletable data is moved, its claims are deleted",
+			args: StatefulWorkloadZoneRebalancerArgs{
+				DeletableData: true,
+			},
+			replicas:              buildReplicas("a1", 0, 3, "zonal-wffc"),
+			statefulSet:           buildStatefulSet(appsv1.DeletePersistentVolumeClaimRetentionPolicyType),
+			expectedEvicted:       []string{"web-2"},
+			expectedDeletedClaims: []string{"data-web-2"},
+		},
+		{
+			description: "replica with claims retained by the StatefulSet is not moved",
+			args: StatefulWorkloadZoneRebalancerArgs{
+				DeletableData: true,
+			},
+			replicas:        buildReplicas("a1", 0, 3, "zonal-wffc"),
+			statefulSet:     buildStatefulSet(appsv1.RetainPersistentVolumeClaimRetentionPolicyType),
+			expectedEvicted: nil,
+		},
+		{
+			description: "replica with claims provisioned immediately is not moved",
+			args: StatefulWorkloadZoneRebalancerArgs{
+				DeletableData: true,
+			},
+			replicas:        buildReplicas("a1", 0, 3, "zonal-immediate"),
+			statefulSet:     buildStatefulSet(appsv1.DeletePersistentVolumeClaimRetentionPolicyType),
+			expectedEvicted: nil,
+		},
+		{
+			description:     "skew within the max skew",
+			replicas:        append(buildReplicas("a1", 0, 2, ""), buildReplicas("b1", 2, 1, "")...),
+			expectedEvicted: nil,
+		},
+		{
+			description: "several replicas moved per cycle",
+			args: StatefulWorkloadZoneRebalancerArgs{
+				MaxMovesPerStatefulSet: utilptr.To[uint](3),
+			},
+			replicas:        buildReplicas("a1", 0, 4, ""),
+			expectedEvicted: []string{"web-3", "web-2"},
+		},
+		{
+			description: "larger max skew",
+			args: StatefulWorkloadZoneRebalancerArgs{
+				MaxSkew:                3,
+				MaxMovesPerStatefulSet: utilptr.To[uint](3),
+			},
+			replicas:        buildReplicas("a1", 0, 4, ""),
+			expectedEvicted: []string{"web-3"},
+		},
+		{
+			description:     "replica not fitting the least populated zone is not moved",
+			targetNodeCPU:   50,
+			replicas:        buildReplicas("a1", 0, 3, ""),
+			expectedEvicted: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			args := tc.args
+			SetDefaults_StatefulWorkloadZoneRebalancerArgs(&args)
+			targetNodeCPU := tc.targetNodeCPU
+			if targetNodeCPU == 0 {
+				targetNodeCPU = 2000
+			}
+
+			objects := append([]runtime.Object{}, storageClasses...)
+			if tc.statefulSet != nil {
+				objects = append(objects, tc.statefulSet)
+			}
+			var pods []*v1.Pod
+			for _, object := range tc.replicas {
+				if pod, ok := object.(*v1.Pod); ok {
+					pods = append(pods, pod)
+					continue
+				}
+				objects = append(objects, object)
+			}
+
+			result := scenario.New().
+				WithNodes(buildZoneNode("a1", "zone-a", 2000), buildZoneNode("b1", "zone-b", targetNodeCPU)).
+				WithPods(pods...).
+				WithObjects(objects...).
+				ExpectEvicted(tc.expectedEvicted...).
+				Run(t, New, &args)
+
+			claims, err := result.Client.CoreV1().PersistentVolumeClaims("default").List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Unable to list the claims: %v", err)
+			}
+			remaining := sets.New[string]()
+			for _, claim := range claims.Items {
+				remaining.Insert(claim.Name)
+			}
+			for _, object := range tc.replicas {
+				claim, ok := object.(*v1.PersistentVolumeClaim)
+				if !ok {
+					continue
+				}
+				deleted := sets.New(tc.expectedDeletedClaims...).Has(claim.Name)
+				if remaining.Has(claim.Name) == deleted {
+					t.Errorf("Expected claim %s to be deleted: %v", claim.Name, deleted)
+				}
+			}
+		})
+	}
+}
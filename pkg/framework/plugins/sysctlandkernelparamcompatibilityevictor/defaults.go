@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sysctlandkernelparamcompatibilityevictor
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// DefaultSysctlLabelPrefix prefixes the labels nodes advertise their unsafe sysctls with
+	DefaultSysctlLabelPrefix = "sysctl.descheduler.alpha.kubernetes.io/"
+	// DefaultKernelModuleLabelPrefix prefixes the labels nodes advertise their kernel modules with
+	DefaultKernelModuleLabelPrefix = "kernel-module.descheduler.alpha.kubernetes.io/"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_SysctlAndKernelParamCompatibilityEvictorArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_SysctlAndKernelParamCompatibilityEvictorArgs(obj runtime.Object) {
+	args := obj.(*SysctlAndKernelParamCompatibilityEvictorArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.SysctlLabelPrefix == "" {
+		args.SysctlLabelPrefix = DefaultSysctlLabelPrefix
+	}
+	if args.KernelModuleLabelPrefix == "" {
+		args.KernelModuleLabelPrefix = DefaultKernelModuleLabelPrefix
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sysctlandkernelparamcompatibilityevictor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	PluginName = "SysctlAndKernelParamCompatibilityEvictor"
+
+	// RequiredSysctlsAnnotation lists, comma separated, the sysctls a pod requires the node to allow
+	// on top of the ones set in its security context, e.g. the ones set by an init container.
+	RequiredSysctlsAnnotation = "descheduler.alpha.kubernetes.io/required-sysctls"
+	// RequiredKernelModulesAnnotation lists, comma separated, the kernel modules a pod requires the node to load.
+	RequiredKernelModulesAnnotation = "descheduler.alpha.kubernetes.io/required-kernel-modules"
+)
+
+// safeSysctls are the namespaced sysctls the kubelet allows on every node,
+// see https://kubernetes.io/docs/tasks/administer-cluster/sysctl-cluster/#safe-and-unsafe-sysctls
+var safeSysctls = sets.New(
+	"kernel.shm_rmid_forced",
+	"net.ipv4.ip_local_port_range",
+	"net.ipv4.tcp_syncookies",
+	"net.ipv4.ping_group_range",
+	"net.ipv4.ip_unprivileged_port_start",
+	"net.ipv4.ip_local_reserved_ports",
+	"net.ipv4.tcp_keepalive_time",
+	"net.ipv4.tcp_fin_timeout",
+	"net.ipv4.tcp_keepalive_intvl",
+	"net.ipv4.tcp_keepalive_probes",
+)
+
+// SysctlAndKernelParamCompatibilityEvictor evicts the pods requiring unsafe sysctls or kernel modules their node
+// stopped advertising, e.g. after a node image update dropped them from the kubelet allowlist. Nodes advertise
+// the sysctls and kernel modules they support through labels. A pod is only evicted when another node
+// supports all its requirements and fits it, so nothing is evicted from clusters where nodes advertise nothing.
+type SysctlAndKernelParamCompatibilityEvictor struct {
+	handle    frameworktypes.Handle
+	args      *SysctlAndKernelParamCompatibilityEvictorArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &SysctlAndKernelParamCompatibilityEvictor{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	compatibilityArgs, ok := args.(*SysctlAndKernelParamCompatibilityEvictorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type SysctlAndKernelParamCompatibilityEvictorArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if compatibilityArgs.Namespaces != nil {
+		includedNamespaces = sets.New(compatibilityArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(compatibilityArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(compatibilityArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &SysctlAndKernelParamCompatibilityEvictor{
+		handle:    handle,
+		args:      compatibilityArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *SysctlAndKernelParamCompatibilityEvictor) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *SysctlAndKernelParamCompatibilityEvictor) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			labels := d.requiredLabels(pod)
+			missing := missingLabels(node, labels)
+			if len(missing) == 0 {
+				continue
+			}
+
+			var compatibleNodes []*v1.Node
+			for _, candidate := range nodes {
+				if len(missingLabels(candidate, labels)) == 0 {
+					compatibleNodes = append(compatibleNodes, candidate)
+				}
+			}
+			if !nodeutil.PodFitsAnyOtherNode(d.handle.GetPodsAssignedToNodeFunc(), pod, compatibleNodes) {
+				logger.V(2).Info("Pod requires sysctls or kernel modules its node does not support, but no other node supports them and fits it", "pod", klog.KObj(pod), "node", klog.KObj(node), "missing", missing)
+				continue
+			}
+
+			reason := fmt.Sprintf("node does not advertise %s", strings.Join(missing, ", "))
+			logger.V(2).Info("Pod requires sysctls or kernel modules its node does not support", "pod", klog.KObj(pod), "node", klog.KObj(node), "missing", missing)
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName, Reason: reason})
+			if err == nil {
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// requiredLabels returns the labels a node advertises the unsafe sysctls and the kernel modules
+// the pod requires with
+func (d *SysctlAndKernelParamCompatibilityEvictor) requiredLabels(pod *v1.Pod) []string {
+	required := sets.New[string]()
+	for _, sysctl := range podSysctls(pod) {
+		if !safeSysctls.Has(sysctl) {
+			required.Insert(d.args.SysctlLabelPrefix + sysctl)
+		}
+	}
+	for _, module := range splitAnnotation(pod.Annotations[RequiredKernelModulesAnnotation]) {
+		required.Insert(d.args.KernelModuleLabelPrefix + module)
+	}
+	return sets.List(required)
+}
+
+// podSysctls returns the sysctls set in the security context of the pod and the ones
+// listed in its annotation, with their components separated by dots
+func podSysctls(pod *v1.Pod) []string {
+	var sysctls []string
+	if pod.Spec.SecurityContext != nil {
+		for _, sysctl := range pod.Spec.SecurityContext.Sysctls {
+			sysctls = append(sysctls, sysctl.Name)
+		}
+	}
+	sysctls = append(sysctls, splitAnnotation(pod.Annotations[RequiredSysctlsAnnotation])...)
+	for i := range sysctls {
+		sysctls[i] = strings.ReplaceAll(sysctls[i], "/", ".")
+	}
+	return sysctls
+}
+
+func splitAnnotation(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// missingLabels returns the labels the node does not have
+func missingLabels(node *v1.Node, labels []string) []string {
+	var missing []string
+	for _, label := range labels {
+		if _, ok := node.Labels[label]; !ok {
+			missing = append(missing, label)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sysctlandkernelparamcompatibilityevictor
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestSysctlAndKernelParamCompatibilityEvictor(t *testing.T) {
+	somaxconn := DefaultSysctlLabelPrefix + "net.core.somaxconn"
+	ipVS := DefaultKernelModuleLabelPrefix + "ip_vs"
+
+	buildNode := func(name string, labels ...string) *v1.Node {
+		return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+			node.Labels = map[string]string{}
+			for _, label := range labels {
+				node.Labels[label] = "true"
+			}
+		})
+	}
+	buildPod := func(name string, cpu int64, apply func(*v1.Pod)) *v1.Pod {
+		return test.BuildTestPod(name, cpu, 0, "n1", func(pod *v1.Pod) {
+			pod.ObjectMeta.OwnerReferences = test.GetReplicaSetOwnerRefList()
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	withSysctls := func(names ...string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Spec.SecurityContext = &v1.PodSecurityContext{}
+			for _, name := range names {
+				pod.Spec.SecurityContext.Sysctls = append(pod.Spec.SecurityContext.Sysctls, v1.Sysctl{Name: name, Value: "1"})
+			}
+		}
+	}
+	withAnnotation := func(key, value string) func(*v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Annotations = map[string]string{key: value}
+		}
+	}
+
+	tests := []struct {
+		description          string
+		args                 SysctlAndKernelParamCompatibilityEvictorArgs
+		nodes                []*v1.Node
+		pods                 []*v1.Pod
+		expectedEvictedCount uint
+	}{
+		{
+			description: "Pods requiring unsafe sysctls their node stopped advertising are evicted",
+			nodes:       []*v1.Node{buildNode("n1"), buildNode("n2", somaxconn)},
+			pods: []*v1.Pod{
+				buildPod("p1", 100, withSysctls("net.core.somaxconn")),
+				buildPod("p2", 100, withSysctls("net/core/somaxconn")),
+				buildPod("p3", 100, withAnnotation(RequiredSysctlsAnnotation, "net.core.somaxconn")),
+				buildPod("p4", 100, nil),
+			},
+			expectedEvictedCount: 3,
+		},
+		{
+			description: "Safe sysctls do not require the node to advertise them",
+			nodes:       []*v1.Node{buildNode("n1"), buildNode("n2")},
+			pods: []*v1.Pod{
+				buildPod("p1", 100, withSysctls("net.ipv4.ip_local_port_range", "kernel.shm_rmid_forced")),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods requiring kernel modules their node stopped advertising are evicted",
+			nodes:       []*v1.Node{buildNode("n1", somaxconn), buildNode("n2", somaxconn, ipVS)},
+			pods: []*v1.Pod{
+				buildPod("p1", 100, withAnnotation(RequiredKernelModulesAnnotation, "ip_vs")),
+				buildPod("p2", 100, withSysctls("net.core.somaxconn")),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods are not evicted when no other node advertises all their requirements",
+			nodes:       []*v1.Node{buildNode("n1"), buildNode("n2", somaxconn), buildNode("n3", ipVS)},
+			pods: []*v1.Pod{
+				buildPod("p1", 100, func(pod *v1.Pod) {
+					withSysctls("net.core.somaxconn")(pod)
+					withAnnotation(RequiredKernelModulesAnnotation, "ip_vs")(pod)
+				}),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Pods are not evicted when they do not fit the compatible nodes",
+			nodes:       []*v1.Node{buildNode("n1"), buildNode("n2", somaxconn)},
+			pods: []*v1.Pod{
+				buildPod("p1", 3000, withSysctls("net.core.somaxconn")),
+			},
+			expectedEvictedCount: 0,
+		},
+		{
+			description: "Custom label prefixes",
+			args: SysctlAndKernelParamCompatibilityEvictorArgs{
+				SysctlLabelPrefix: "example.com/sysctl.",
+			},
+			nodes: []*v1.Node{buildNode("n1", somaxconn), buildNode("n2", "example.com/sysctl.net.core.somaxconn")},
+			pods: []*v1.Pod{
+				buildPod("p1", 100, withSysctls("net.core.somaxconn")),
+			},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "Pods of namespaces not opted in are not evicted",
+			args: SysctlAndKernelParamCompatibilityEvictorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"opted-in"}},
+				},
+			},
+			nodes: []*v1.Node{buildNode("n1"), buildNode("n2", somaxconn)},
+			pods: []*v1.Pod{
+				buildPod("p1", 100, withSysctls("net.core.somaxconn")),
+			},
+			expectedEvictedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			SetDefaults_SysctlAndKernelParamCompatibilityEvictorArgs(&tc.args)
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sysctlandkernelparamcompatibilityevictor
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SysctlAndKernelParamCompatibilityEvictorArgs holds arguments used to configure SysctlAndKernelParamCompatibilityEvictor plugin.
+type SysctlAndKernelParamCompatibilityEvictorArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	api.FilteringArgs `json:",inline"`
+	// SysctlLabelPrefix is prepended to the name of an unsafe sysctl to build the label nodes
+	// advertise their support for it with. Defaults to "sysctl.descheduler.alpha.kubernetes.io/".
+	SysctlLabelPrefix string `json:"sysctlLabelPrefix,omitempty"`
+	// KernelModuleLabelPrefix is prepended to the name of a kernel module to build the label nodes
+	// advertise their support for it with. Defaults to "kernel-module.descheduler.alpha.kubernetes.io/".
+	KernelModuleLabelPrefix string `json:"kernelModuleLabelPrefix,omitempty"`
+}
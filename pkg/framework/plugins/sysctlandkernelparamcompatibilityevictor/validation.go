@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sysctlandkernelparamcompatibilityevictor
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidateSysctlAndKernelParamCompatibilityEvictorArgs validates SysctlAndKernelParamCompatibilityEvictor arguments
+func ValidateSysctlAndKernelParamCompatibilityEvictorArgs(obj runtime.Object) error {
+	args := obj.(*SysctlAndKernelParamCompatibilityEvictorArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	if err := validateLabelPrefix("sysctlLabelPrefix", args.SysctlLabelPrefix); err != nil {
+		return err
+	}
+	return validateLabelPrefix("kernelModuleLabelPrefix", args.KernelModuleLabelPrefix)
+}
+
+// validateLabelPrefix checks the prefix makes a valid label key once followed by a name
+func validateLabelPrefix(name, prefix string) error {
+	if errs := validation.IsQualifiedName(prefix + "name"); len(errs) > 0 {
+		return fmt.Errorf("invalid %s %q: %s", name, prefix, strings.Join(errs, ", "))
+	}
+	return nil
+}
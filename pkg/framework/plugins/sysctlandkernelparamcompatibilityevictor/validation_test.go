@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sysctlandkernelparamcompatibilityevictor
+
+import (
+	"testing"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateSysctlAndKernelParamCompatibilityEvictorArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *SysctlAndKernelParamCompatibilityEvictorArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &SysctlAndKernelParamCompatibilityEvictorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}},
+				},
+				SysctlLabelPrefix:       DefaultSysctlLabelPrefix,
+				KernelModuleLabelPrefix: "feature.example.com/kernel-module.",
+			},
+			expectError: false,
+		},
+		{
+			description: "namespaces include and exclude, expects error",
+			args: &SysctlAndKernelParamCompatibilityEvictorArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"default"}, Exclude: []string{"kube-system"}},
+				},
+				SysctlLabelPrefix:       DefaultSysctlLabelPrefix,
+				KernelModuleLabelPrefix: DefaultKernelModuleLabelPrefix,
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid sysctl label prefix, expects error",
+			args: &SysctlAndKernelParamCompatibilityEvictorArgs{
+				SysctlLabelPrefix:       "example.com/sysctls/",
+				KernelModuleLabelPrefix: DefaultKernelModuleLabelPrefix,
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid kernel module label prefix, expects error",
+			args: &SysctlAndKernelParamCompatibilityEvictorArgs{
+				SysctlLabelPrefix:       DefaultSysctlLabelPrefix,
+				KernelModuleLabelPrefix: "kernel module ",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateSysctlAndKernelParamCompatibilityEvictorArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrightsizingnudger
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestSetDefaults_WorkloadRightSizingNudgerArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "WorkloadRightSizingNudgerArgs empty",
+			in:   &WorkloadRightSizingNudgerArgs{},
+			want: &WorkloadRightSizingNudgerArgs{
+				Factor:                  ptr.To(2.0),
+				UpdateModes:             []string{UpdateModeInitial},
+				MaxEvictionsPerWorkload: ptr.To[uint](1),
+			},
+		},
+		{
+			name: "WorkloadRightSizingNudgerArgs with value",
+			in: &WorkloadRightSizingNudgerArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"team-a"}},
+				},
+				Factor:                  ptr.To(1.5),
+				UpdateModes:             []string{UpdateModeInitial, UpdateModeOff},
+				MaxEvictionsPerWorkload: ptr.To[uint](3),
+			},
+			want: &WorkloadRightSizingNudgerArgs{
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{Include: []string{"team-a"}},
+				},
+				Factor:                  ptr.To(1.5),
+				UpdateModes:             []string{UpdateModeInitial, UpdateModeOff},
+				MaxEvictionsPerWorkload: ptr.To[uint](3),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_WorkloadRightSizingNudgerArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
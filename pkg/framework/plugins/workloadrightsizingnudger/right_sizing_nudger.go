@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrightsizingnudger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const PluginName = "WorkloadRightSizingNudger"
+
+// WorkloadRightSizingNudger evicts the pods whose requests deviate too much from the recommendations of
+// the VPA of their workload, when the VPA only applies its recommendations to new pods. The pods
+// are recreated with the recommended requests, a few pods of each workload per cycle.
+type WorkloadRightSizingNudger struct {
+	handle      frameworktypes.Handle
+	args        *WorkloadRightSizingNudgerArgs
+	podFilter   podutil.FilterFunc
+	updateModes sets.Set[string]
+}
+
+var _ frameworktypes.DeschedulePlugin = &WorkloadRightSizingNudger{}
+
+// workload identifies the controller of pods a VPA targets
+type workload struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	nudgerArgs, ok := args.(*WorkloadRightSizingNudgerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type WorkloadRightSizingNudgerArgs, got %T", args)
+	}
+
+	var includedNamespaces, excludedNamespaces sets.Set[string]
+	if nudgerArgs.Namespaces != nil {
+		includedNamespaces = sets.New(nudgerArgs.Namespaces.Include...)
+		excludedNamespaces = sets.New(nudgerArgs.Namespaces.Exclude...)
+	}
+
+	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
+	podFilter, err := podutil.NewOptions().
+		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
+		WithNamespaces(includedNamespaces).
+		WithoutNamespaces(excludedNamespaces).
+		WithLabelSelector(nudgerArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &WorkloadRightSizingNudger{
+		handle:      handle,
+		args:        nudgerArgs,
+		podFilter:   podFilter,
+		updateModes: sets.New(nudgerArgs.UpdateModes...),
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *WorkloadRightSizingNudger) Name() string {
+	return PluginName
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *WorkloadRightSizingNudger) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	// The recommendations can not be read in dry run mode
+	if !utils.SupportsRawRequests(d.handle.ClientSet()) {
+		logger.V(2).Info("Unable to read the vertical pod autoscalers, skipping")
+		return nil
+	}
+	vpas, err := listVerticalPodAutoscalers(ctx, d.handle.ClientSet())
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing vertical pod autoscalers: %v", err),
+		}
+	}
+
+	targets := map[workload]map[string]v1.ResourceList{}
+	for i := range vpas {
+		vpa := &vpas[i]
+		if vpa.Spec.TargetRef == nil || !d.updateModes.Has(vpa.updateMode()) {
+			continue
+		}
+		if recommended := vpa.targets(); len(recommended) > 0 {
+			targets[workload{namespace: vpa.Namespace, kind: vpa.Spec.TargetRef.Kind, name: vpa.Spec.TargetRef.Name}] = recommended
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	evicted := map[workload]uint{}
+	for _, node := range nodes {
+		logger.V(2).Info("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			// no pods evicted as error encountered retrieving evictable Pods
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+	loop:
+		for _, pod := range pods {
+			owner, ok := podWorkload(pod)
+			if !ok {
+				continue
+			}
+			recommended, ok := targets[owner]
+			if !ok || evicted[owner] >= *d.args.MaxEvictionsPerWorkload {
+				continue
+			}
+			reason := d.deviation(pod, recommended)
+			if reason == "" {
+				continue
+			}
+			logger.V(2).Info("Pod requests deviate from the recommendation", "pod", klog.KObj(pod), "reason", reason)
+			err := d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName, Reason: reason})
+			if err == nil {
+				evicted[owner]++
+				continue
+			}
+			switch err.(type) {
+			case *evictions.EvictionNodeLimitError:
+				break loop
+			case *evictions.EvictionTotalLimitError:
+				return nil
+			default:
+				logger.Error(err, "Eviction failed")
+			}
+		}
+	}
+	return nil
+}
+
+// deviation returns how the requests of a container of the pod deviate from the recommendation,
+// or an empty string when all of them are close enough
+func (d *WorkloadRightSizingNudger) deviation(pod *v1.Pod, recommended map[string]v1.ResourceList) string {
+	factor := *d.args.Factor
+	for _, container := range pod.Spec.Containers {
+		for _, resourceName := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			target, ok := recommended[container.Name][resourceName]
+			if !ok || target.IsZero() {
+				continue
+			}
+			request := container.Resources.Requests[resourceName]
+			ratio := float64(request.MilliValue()) / float64(target.MilliValue())
+			if ratio >= factor || ratio <= 1/factor {
+				return fmt.Sprintf("container %q requests %s of %s, %s recommended", container.Name, request.String(), resourceName, target.String())
+			}
+		}
+	}
+	return ""
+}
+
+// podWorkload returns the workload controlling the pod, the pods of a ReplicaSet created by a
+// Deployment being attributed to the Deployment
+func podWorkload(pod *v1.Pod) (workload, bool) {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil {
+		return workload{}, false
+	}
+	kind, name := controller.Kind, controller.Name
+	if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; kind == "ReplicaSet" && hash != "" && strings.HasSuffix(name, "-"+hash) {
+		kind, name = "Deployment", strings.TrimSuffix(name, "-"+hash)
+	}
+	return workload{namespace: pod.Namespace, kind: kind, name: name}, true
+}
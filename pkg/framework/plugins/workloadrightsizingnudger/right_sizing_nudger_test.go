@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrightsizingnudger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildVPA(namespace, kind, name, mode, cpu, memory string) verticalPodAutoscaler {
+	vpa := verticalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: vpaSpec{
+			TargetRef:    &targetRef{Kind: kind, Name: name},
+			UpdatePolicy: &updatePolicy{UpdateMode: ptr.To(mode)},
+		},
+	}
+	vpa.Status.Recommendation = &recommendation{ContainerRecommendations: []containerRecommendation{{
+		ContainerName: "app",
+		Target: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse(cpu),
+			v1.ResourceMemory: resource.MustParse(memory),
+		},
+	}}}
+	return vpa
+}
+
+// buildDeploymentPod returns a pod of the web deployment requesting the given cpu and 1000 bytes of memory
+func buildDeploymentPod(name, namespace string, milliCPU int64) *v1.Pod {
+	return test.BuildTestPod(name, milliCPU, 1000, "n1", func(pod *v1.Pod) {
+		pod.Namespace = namespace
+		pod.Labels = map[string]string{"pod-template-hash": "5d8f7c"}
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: "web-5d8f7c", Controller: ptr.To(true)}}
+		pod.Spec.Containers[0].Name = "app"
+	})
+}
+
+func newVPAServer(t *testing.T, vpas []verticalPodAutoscaler) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != verticalPodAutoscalersPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(verticalPodAutoscalerList{Items: vpas}); err != nil {
+			t.Errorf("Unable to encode the vertical pod autoscalers: %v", err)
+		}
+	}))
+}
+
+func TestWorkloadRightSizingNudger(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 8000, 32000, 10, nil)
+	optedIn := api.FilteringArgs{Namespaces: &api.Namespaces{Include: []string{"team-a"}}}
+
+	tests := []struct {
+		description          string
+		args                 WorkloadRightSizingNudgerArgs
+		pods                 []*v1.Pod
+		vpas                 []verticalPodAutoscaler
+		dryRun               bool
+		expectedEvictedCount uint
+	}{
+		{
+			description:          "pod requesting far more than recommended is evicted",
+			args:                 WorkloadRightSizingNudgerArgs{FilteringArgs: optedIn},
+			pods:                 []*v1.Pod{buildDeploymentPod("p1", "team-a", 2000)},
+			vpas:                 []verticalPodAutoscaler{buildVPA("team-a", "Deployment", "web", UpdateModeInitial, "500m", "1k")},
+			expectedEvictedCount: 1,
+		},
+		{
+			description:          "pod requesting far less than recommended is evicted",
+			args:                 WorkloadRightSizingNudgerArgs{FilteringArgs: optedIn},
+			pods:                 []*v1.Pod{buildDeploymentPod("p1", "team-a", 100)},
+			vpas:                 []verticalPodAutoscaler{buildVPA("team-a", "Deployment", "web", UpdateModeInitial, "500m", "1k")},
+			expectedEvictedCount: 1,
+		},
+		{
+			description:          "pod requesting close to the recommendation is not evicted",
+			args:                 WorkloadRightSizingNudgerArgs{FilteringArgs: optedIn},
+			pods:                 []*v1.Pod{buildDeploymentPod("p1", "team-a", 600)},
+			vpas:                 []verticalPodAutoscaler{buildVPA("team-a", "Deployment", "web", UpdateModeInitial, "500m", "1k")},
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "pods of VPAs updating the pods themselves are not evicted",
+			args:                 WorkloadRightSizingNudgerArgs{FilteringArgs: optedIn},
+			pods:                 []*v1.Pod{buildDeploymentPod("p1", "team-a", 2000)},
+			vpas:                 []verticalPodAutoscaler{buildVPA("team-a", "Deployment", "web", "Auto", "500m", "1k")},
+			expectedEvictedCount: 0,
+		},
+		{
+			description:          "pods of VPAs in Off mode are evicted when configured",
+			args:                 WorkloadRightSizingNudgerArgs{FilteringArgs: optedIn, UpdateModes: []string{UpdateModeOff}},
+			pods:                 []*v1.Pod{buildDeploymentPod("p1", "team-a", 2000)},
+			vpas:                 []verticalPodAutoscaler{buildVPA("team-a", "Deployment", "web", UpdateModeOff, "500m", "1k")},
+			expectedEvictedCount: 1,
+		},
+		{
+			description: "pods of namespaces not opted in are not evicted",
+			args:        WorkloadRightSizingNudgerArgs{FilteringArgs: optedIn},
+			pods:        []*v1.Pod{buildDeploymentPod("p1", "team-b", 2000)},
+			vpas:        []verticalPodAutoscaler{buildVPA("team-b", "Deployment", "web", UpdateModeInitial, "500m", "1k")},
+		},
+		{
+			description: "evictions are bounded per workload",
+			args:        WorkloadRightSizingNudgerArgs{FilteringArgs: optedIn, MaxEvictionsPerWorkload: ptr.To[uint](2)},
+			pods: []*v1.Pod{
+				buildDeploymentPod("p1", "team-a", 2000),
+				buildDeploymentPod("p2", "team-a", 2000),
+				buildDeploymentPod("p3", "team-a", 2000),
+			},
+			vpas:                 []verticalPodAutoscaler{buildVPA("team-a", "Deployment", "web", UpdateModeInitial, "500m", "1k")},
+			expectedEvictedCount: 2,
+		},
+		{
+			description: "pods are left alone in dry run mode",
+			args:        WorkloadRightSizingNudgerArgs{FilteringArgs: optedIn},
+			pods:        []*v1.Pod{buildDeploymentPod("p1", "team-a", 2000)},
+			dryRun:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{n1}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(
+				ctx,
+				fakeClient,
+				evictions.NewOptions(),
+				defaultevictor.DefaultEvictorArgs{},
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			// The fake client does not serve the VPA API, it is only used in dry run mode
+			if !tc.dryRun {
+				server := newVPAServer(t, tc.vpas)
+				defer server.Close()
+				vpaClient, err := kubernetes.NewForConfig(&restclient.Config{Host: server.URL})
+				if err != nil {
+					t.Fatalf("Unable to create a client: %v", err)
+				}
+				handle.ClientsetImpl = vpaClient
+			}
+
+			SetDefaults_WorkloadRightSizingNudgerArgs(&tc.args)
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			status := plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{n1})
+			if status != nil && status.Err != nil {
+				t.Fatalf("Unexpected error: %v", status.Err)
+			}
+			if actualEvictedPodCount := podEvictor.TotalEvicted(); actualEvictedPodCount != tc.expectedEvictedCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrightsizingnudger
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadRightSizingNudgerArgs holds arguments used to configure WorkloadRightSizingNudger plugin.
+type WorkloadRightSizingNudgerArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Namespaces.Include lists the namespaces opted in, it is required
+	api.FilteringArgs `json:",inline"`
+	// Factor is how far the requests of a container may deviate from the target recommended
+	// by its VPA: pods are evicted when a container requests at least Factor times the target,
+	// or at most the target divided by Factor. Defaults to 2.
+	Factor *float64 `json:"factor,omitempty"`
+	// UpdateModes are the update modes of the VPAs whose pods are evicted, among Initial and Off.
+	// Defaults to Initial, the only mode the VPA admission controller applies the recommendations in.
+	UpdateModes []string `json:"updateModes,omitempty"`
+	// MaxEvictionsPerWorkload is the number of pods of a workload evicted per descheduling cycle.
+	// Defaults to 1.
+	MaxEvictionsPerWorkload *uint `json:"maxEvictionsPerWorkload,omitempty"`
+}
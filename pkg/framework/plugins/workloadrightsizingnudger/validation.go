@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrightsizingnudger
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateWorkloadRightSizingNudgerArgs validates WorkloadRightSizingNudger arguments
+func ValidateWorkloadRightSizingNudgerArgs(obj runtime.Object) error {
+	args := obj.(*WorkloadRightSizingNudgerArgs)
+	if err := args.FilteringArgs.Validate(); err != nil {
+		return err
+	}
+
+	// Restarting pods is only acceptable in the namespaces opted in
+	if args.Namespaces == nil || len(args.Namespaces.Include) == 0 {
+		return fmt.Errorf("namespaces.include must list the namespaces opted in")
+	}
+	if args.Factor != nil && *args.Factor <= 1 {
+		return fmt.Errorf("factor must be greater than 1, got %v", *args.Factor)
+	}
+	for _, mode := range args.UpdateModes {
+		if mode != UpdateModeInitial && mode != UpdateModeOff {
+			return fmt.Errorf("update mode %q not supported, must be one of: %s, %s", mode, UpdateModeInitial, UpdateModeOff)
+		}
+	}
+	if args.MaxEvictionsPerWorkload != nil && *args.MaxEvictionsPerWorkload == 0 {
+		return fmt.Errorf("maxEvictionsPerWorkload must be greater than zero")
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrightsizingnudger
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateWorkloadRightSizingNudgerArgs(t *testing.T) {
+	validArgs := func(mutate func(*WorkloadRightSizingNudgerArgs)) *WorkloadRightSizingNudgerArgs {
+		args := &WorkloadRightSizingNudgerArgs{
+			FilteringArgs: api.FilteringArgs{
+				Namespaces: &api.Namespaces{Include: []string{"team-a"}},
+			},
+		}
+		SetDefaults_WorkloadRightSizingNudgerArgs(args)
+		if mutate != nil {
+			mutate(args)
+		}
+		return args
+	}
+
+	testCases := []struct {
+		description string
+		args        *WorkloadRightSizingNudgerArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args:        validArgs(nil),
+			expectError: false,
+		},
+		{
+			description: "no namespace opted in, expects error",
+			args: validArgs(func(args *WorkloadRightSizingNudgerArgs) {
+				args.Namespaces = nil
+			}),
+			expectError: true,
+		},
+		{
+			description: "excluded namespaces only, expects error",
+			args: validArgs(func(args *WorkloadRightSizingNudgerArgs) {
+				args.Namespaces = &api.Namespaces{Exclude: []string{"kube-system"}}
+			}),
+			expectError: true,
+		},
+		{
+			description: "factor not greater than 1, expects error",
+			args: validArgs(func(args *WorkloadRightSizingNudgerArgs) {
+				args.Factor = ptr.To(1.0)
+			}),
+			expectError: true,
+		},
+		{
+			description: "unsupported update mode, expects error",
+			args: validArgs(func(args *WorkloadRightSizingNudgerArgs) {
+				args.UpdateModes = []string{"Recreate"}
+			}),
+			expectError: true,
+		},
+		{
+			description: "no eviction per workload, expects error",
+			args: validArgs(func(args *WorkloadRightSizingNudgerArgs) {
+				args.MaxEvictionsPerWorkload = ptr.To[uint](0)
+			}),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateWorkloadRightSizingNudgerArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Error("unexpected arg validation behavior")
+			}
+		})
+	}
+}
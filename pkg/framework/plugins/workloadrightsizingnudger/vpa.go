@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadrightsizingnudger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+// verticalPodAutoscalersPath lists the VerticalPodAutoscaler objects of all the namespaces
+const verticalPodAutoscalersPath = "/apis/autoscaling.k8s.io/v1/verticalpodautoscalers"
+
+const (
+	// UpdateModeInitial VPAs set the requests of the pods when they are created only
+	UpdateModeInitial = "Initial"
+	// UpdateModeOff VPAs only recommend requests, applied by other means (e.g. GitOps)
+	UpdateModeOff = "Off"
+)
+
+// verticalPodAutoscalerList is the part of the autoscaling.k8s.io/v1 VerticalPodAutoscalerList read by the plugin
+type verticalPodAutoscalerList struct {
+	Items []verticalPodAutoscaler `json:"items"`
+}
+
+// verticalPodAutoscaler is the part of the autoscaling.k8s.io/v1 VerticalPodAutoscaler read by the plugin
+type verticalPodAutoscaler struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              vpaSpec   `json:"spec"`
+	Status            vpaStatus `json:"status"`
+}
+
+type vpaSpec struct {
+	TargetRef    *targetRef    `json:"targetRef,omitempty"`
+	UpdatePolicy *updatePolicy `json:"updatePolicy,omitempty"`
+}
+
+type targetRef struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type updatePolicy struct {
+	UpdateMode *string `json:"updateMode,omitempty"`
+}
+
+type vpaStatus struct {
+	Recommendation *recommendation `json:"recommendation,omitempty"`
+}
+
+type recommendation struct {
+	ContainerRecommendations []containerRecommendation `json:"containerRecommendations,omitempty"`
+}
+
+type containerRecommendation struct {
+	ContainerName string          `json:"containerName"`
+	Target        v1.ResourceList `json:"target"`
+}
+
+// updateMode returns the update mode of the VPA, Auto when not set
+func (vpa *verticalPodAutoscaler) updateMode() string {
+	if vpa.Spec.UpdatePolicy == nil || vpa.Spec.UpdatePolicy.UpdateMode == nil {
+		return "Auto"
+	}
+	return *vpa.Spec.UpdatePolicy.UpdateMode
+}
+
+// targets returns the recommended requests of the containers, by container name
+func (vpa *verticalPodAutoscaler) targets() map[string]v1.ResourceList {
+	targets := map[string]v1.ResourceList{}
+	if vpa.Status.Recommendation == nil {
+		return targets
+	}
+	for _, recommendation := range vpa.Status.Recommendation.ContainerRecommendations {
+		targets[recommendation.ContainerName] = recommendation.Target
+	}
+	return targets
+}
+
+// listVerticalPodAutoscalers returns the VerticalPodAutoscaler objects of all the namespaces
+func listVerticalPodAutoscalers(ctx context.Context, client clientset.Interface) ([]verticalPodAutoscaler, error) {
+	body, err := client.CoreV1().RESTClient().Get().AbsPath(verticalPodAutoscalersPath).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the vertical pod autoscalers: %v", err)
+	}
+	var list verticalPodAutoscalerList
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("unable to decode the vertical pod autoscalers: %v", err)
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/metrics"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	// DefaultPluginFailureThreshold is the number of consecutive failed runs tripping the circuit breaker of a plugin
+	DefaultPluginFailureThreshold = 3
+	// DefaultPluginCooldownCycles is the number of cycles a plugin is disabled for once its circuit breaker trips
+	DefaultPluginCooldownCycles = 5
+
+	// PluginDisabledReason is the reason of the events reporting a plugin disabled by its circuit breaker
+	PluginDisabledReason = "PluginDisabled"
+
+	failureReasonPanic   = "panic"
+	failureReasonTimeout = "timeout"
+	// failureReasonCycleDeadline is not held against the plugin, the cycle overran because of all the plugins
+	failureReasonCycleDeadline = "cycleDeadline"
+)
+
+// ErrCycleDeadlineExceeded is the cause of the cancellation of the context of the plugins once the
+// descheduling cycle overruns its deadline
+var ErrCycleDeadlineExceeded = errors.New("descheduling cycle deadline exceeded")
+
+// cycleOverrun checks whether the descheduling cycle of the context overran its deadline
+func cycleOverrun(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrCycleDeadlineExceeded)
+}
+
+// PluginGuard runs the plugins under a recover wrapper and an optional timeout, and disables for a
+// number of cycles the plugins whose runs repeatedly panic or time out, so one misbehaving plugin
+// does not stall the descheduling cycles. It also tracks the plugins skipped once a cycle overran
+// its deadline, to run them first the next cycle. Its state outlives the profiles, rebuilt every cycle.
+type PluginGuard struct {
+	timeout          time.Duration
+	pluginTimeouts   map[string]time.Duration
+	failureThreshold int
+	cooldownCycles   int
+	eventRecorder    events.EventRecorder
+	regarding        runtime.Object
+
+	mu sync.Mutex
+	// breakers are keyed by the profile, the plugin and the extension point
+	breakers map[string]*circuitBreaker
+	// runSkippedFirst runs the plugins skipped at the deadline of a cycle first the next cycle
+	runSkippedFirst bool
+	// skipped are the plugins skipped at the deadline of the current cycle and prioritized the ones
+	// running first in the current cycle, keyed like the breakers
+	skipped     sets.Set[string]
+	prioritized sets.Set[string]
+}
+
+type circuitBreaker struct {
+	// failures is the number of consecutive failed runs
+	failures int
+	// cooldown is the number of runs left to skip
+	cooldown int
+}
+
+// NewPluginGuard returns a guard bounding the runs of the plugins by the timeout, zero for no bound, or
+// their own timeout when listed, and disabling the plugins failing failureThreshold consecutive runs for
+// cooldownCycles cycles.
+func NewPluginGuard(timeout time.Duration, pluginTimeouts map[string]time.Duration, failureThreshold, cooldownCycles int) *PluginGuard {
+	return &PluginGuard{
+		timeout:          timeout,
+		pluginTimeouts:   pluginTimeouts,
+		failureThreshold: failureThreshold,
+		cooldownCycles:   cooldownCycles,
+		breakers:         map[string]*circuitBreaker{},
+		skipped:          sets.New[string](),
+		prioritized:      sets.New[string](),
+	}
+}
+
+// WithSkippedPluginsFirst runs the plugins skipped at the deadline of a cycle first the next cycle
+func (g *PluginGuard) WithSkippedPluginsFirst(runSkippedFirst bool) *PluginGuard {
+	g.runSkippedFirst = runSkippedFirst
+	return g
+}
+
+// WithEvents reports the plugins disabled by their circuit breaker through events regarding the given object
+func (g *PluginGuard) WithEvents(eventRecorder events.EventRecorder, regarding runtime.Object) *PluginGuard {
+	g.eventRecorder = eventRecorder
+	g.regarding = regarding
+	return g
+}
+
+func breakerKey(profileName, pluginName string, extensionPoint frameworktypes.ExtensionPoint) string {
+	return profileName + "/" + pluginName + "/" + string(extensionPoint)
+}
+
+// StartCycle starts a descheduling cycle, the plugins skipped at the deadline of the previous cycle
+// running first when asked to
+func (g *PluginGuard) StartCycle() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.prioritized = sets.New[string]()
+	if g.runSkippedFirst {
+		g.prioritized = g.skipped
+	}
+	g.skipped = sets.New[string]()
+}
+
+// Prioritized checks whether some plugins of the profile run first at the extension point in the
+// current cycle, having been skipped at the deadline of the previous cycle
+func (g *PluginGuard) Prioritized(profileName string, extensionPoint frameworktypes.ExtensionPoint) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for key := range g.prioritized {
+		if strings.HasPrefix(key, profileName+"/") && strings.HasSuffix(key, "/"+string(extensionPoint)) {
+			return true
+		}
+	}
+	return false
+}
+
+// skip records the plugin got skipped at the extension point as the cycle overran its deadline
+func (g *PluginGuard) skip(profileName, pluginName string, extensionPoint frameworktypes.ExtensionPoint) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.skipped.Insert(breakerKey(profileName, pluginName, extensionPoint))
+	metrics.PluginsSkippedAtCycleDeadline.With(map[string]string{"strategy": pluginName, "profile": profileName}).Inc()
+}
+
+// prioritize orders first the plugins of the profile skipped at the extension point at the deadline
+// of the previous cycle, the order of the plugins being kept otherwise
+func prioritize[T frameworktypes.Plugin](g *PluginGuard, profileName string, extensionPoint frameworktypes.ExtensionPoint, plugins []T) []T {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.prioritized.Len() == 0 {
+		return plugins
+	}
+	ordered := make([]T, 0, len(plugins))
+	var others []T
+	for _, pl := range plugins {
+		if g.prioritized.Has(breakerKey(profileName, pl.Name(), extensionPoint)) {
+			ordered = append(ordered, pl)
+		} else {
+			others = append(others, pl)
+		}
+	}
+	return append(ordered, others...)
+}
+
+// allow checks whether the plugin may run at the extension point, counting down the cycles left
+// before a plugin disabled by its circuit breaker runs again.
+func (g *PluginGuard) allow(profileName, pluginName string, extensionPoint frameworktypes.ExtensionPoint) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	breaker, ok := g.breakers[breakerKey(profileName, pluginName, extensionPoint)]
+	if !ok || breaker.cooldown == 0 {
+		return true
+	}
+	breaker.cooldown--
+	return false
+}
+
+// run runs the plugin, turning a panic or a timeout into an error status and recording the outcome
+// in the circuit breaker of the plugin.
+func (g *PluginGuard) run(ctx context.Context, profileName, pluginName string, extensionPoint frameworktypes.ExtensionPoint, fnc func(ctx context.Context) *frameworktypes.Status) *frameworktypes.Status {
+	status, failureReason := g.execute(ctx, pluginName, fnc)
+	g.record(profileName, pluginName, extensionPoint, failureReason)
+	return status
+}
+
+func (g *PluginGuard) execute(ctx context.Context, pluginName string, fnc func(ctx context.Context) *frameworktypes.Status) (*frameworktypes.Status, string) {
+	timeout := g.timeout
+	if pluginTimeout, ok := g.pluginTimeouts[pluginName]; ok {
+		timeout = pluginTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	type result struct {
+		status        *frameworktypes.Status
+		failureReason string
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				klog.FromContext(ctx).Error(nil, "Plugin panicked", "panic", r, "stack", string(debug.Stack()))
+				done <- result{status: &frameworktypes.Status{Err: fmt.Errorf("plugin panicked: %v", r)}, failureReason: failureReasonPanic}
+			}
+		}()
+		done <- result{status: fnc(ctx)}
+	}()
+
+	select {
+	case r := <-done:
+		return r.status, r.failureReason
+	case <-ctx.Done():
+		if cycleOverrun(ctx) {
+			// The plugin keeps running in the background until it honors the cancellation of its context
+			return &frameworktypes.Status{Err: fmt.Errorf("plugin cancelled: %w", ErrCycleDeadlineExceeded)}, failureReasonCycleDeadline
+		}
+		if ctx.Err() != context.DeadlineExceeded {
+			// The descheduler is shutting down, the plugin is expected to return shortly
+			r := <-done
+			return r.status, r.failureReason
+		}
+		// The plugin keeps running in the background until it honors the cancellation of its context
+		return &frameworktypes.Status{Err: fmt.Errorf("plugin timed out after %v", timeout)}, failureReasonTimeout
+	}
+}
+
+func (g *PluginGuard) record(profileName, pluginName string, extensionPoint frameworktypes.ExtensionPoint, failureReason string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	key := breakerKey(profileName, pluginName, extensionPoint)
+	breaker, ok := g.breakers[key]
+	if !ok {
+		breaker = &circuitBreaker{}
+		g.breakers[key] = breaker
+	}
+	if failureReason == "" {
+		breaker.failures = 0
+		return
+	}
+	if failureReason == failureReasonCycleDeadline {
+		return
+	}
+
+	metrics.PluginFailures.With(map[string]string{"strategy": pluginName, "profile": profileName, "reason": failureReason}).Inc()
+	breaker.failures++
+	if breaker.failures < g.failureThreshold {
+		return
+	}
+	breaker.failures = 0
+	breaker.cooldown = g.cooldownCycles
+	metrics.PluginCircuitBreakerTrips.With(map[string]string{"strategy": pluginName, "profile": profileName}).Inc()
+	klog.InfoS("Plugin disabled after repeatedly panicking or timing out", "plugin", pluginName, "profile", profileName, "extension point", extensionPoint, "failures", g.failureThreshold, "cycles", g.cooldownCycles)
+	if g.eventRecorder != nil {
+		g.eventRecorder.Eventf(g.regarding, nil, v1.EventTypeWarning, PluginDisabledReason, "Disabled",
+			"Plugin %s of profile %s disabled at the %s extension point for %d cycles after %d consecutive runs panicking or timing out",
+			pluginName, profileName, extensionPoint, g.cooldownCycles, g.failureThreshold)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestPluginGuardRecoversPanics(t *testing.T) {
+	recorder := events.NewFakeRecorder(10)
+	guard := NewPluginGuard(0, nil, 2, 3).WithEvents(recorder, &v1.ObjectReference{Kind: "Lease", Namespace: "kube-system", Name: "descheduler"})
+	panicking := func(ctx context.Context) *frameworktypes.Status {
+		panic("boom")
+	}
+	succeeding := func(ctx context.Context) *frameworktypes.Status {
+		return nil
+	}
+	ctx := context.Background()
+
+	status := guard.run(ctx, "profile", "plugin", frameworktypes.DescheduleExtensionPoint, panicking)
+	if status == nil || status.Err == nil || !strings.Contains(status.Err.Error(), "boom") {
+		t.Fatalf("Expected the panic to be turned into an error status, got %v", status)
+	}
+	// A successful run resets the consecutive failures
+	guard.run(ctx, "profile", "plugin", frameworktypes.DescheduleExtensionPoint, succeeding)
+	guard.run(ctx, "profile", "plugin", frameworktypes.DescheduleExtensionPoint, panicking)
+	if !guard.allow("profile", "plugin", frameworktypes.DescheduleExtensionPoint) {
+		t.Fatalf("Expected the plugin to be allowed before reaching the failure threshold")
+	}
+	if len(recorder.Events) != 0 {
+		t.Fatalf("Expected no event before the circuit breaker trips, got %v", <-recorder.Events)
+	}
+
+	guard.run(ctx, "profile", "plugin", frameworktypes.DescheduleExtensionPoint, panicking)
+	for cycle := 0; cycle < 3; cycle++ {
+		if guard.allow("profile", "plugin", frameworktypes.DescheduleExtensionPoint) {
+			t.Fatalf("Expected the plugin to be disabled at cycle %d", cycle)
+		}
+	}
+	if !guard.allow("profile", "plugin", frameworktypes.DescheduleExtensionPoint) {
+		t.Fatalf("Expected the plugin to be enabled again after the cooldown")
+	}
+	if !guard.allow("profile", "plugin", frameworktypes.BalanceExtensionPoint) || !guard.allow("other", "plugin", frameworktypes.DescheduleExtensionPoint) {
+		t.Fatalf("Expected the other extension points and profiles of the plugin to be left enabled")
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, PluginDisabledReason) {
+			t.Errorf("Expected a %s event, got %q", PluginDisabledReason, event)
+		}
+	default:
+		t.Errorf("Expected an event reporting the plugin disabled")
+	}
+}
+
+func TestPluginGuardTimeout(t *testing.T) {
+	guard := NewPluginGuard(time.Hour, map[string]time.Duration{"slow": 10 * time.Millisecond}, 1, 1)
+	released := make(chan struct{})
+	blocking := func(ctx context.Context) *frameworktypes.Status {
+		<-ctx.Done()
+		close(released)
+		return nil
+	}
+
+	start := time.Now()
+	status := guard.run(context.Background(), "profile", "slow", frameworktypes.BalanceExtensionPoint, blocking)
+	if status == nil || status.Err == nil || !strings.Contains(status.Err.Error(), "timed out") {
+		t.Fatalf("Expected a timeout error status, got %v", status)
+	}
+	if elapsed := time.Since(start); elapsed > time.Minute {
+		t.Errorf("Expected the plugin timeout to take precedence over the default timeout, took %v", elapsed)
+	}
+	select {
+	case <-released:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Errorf("Expected the context of the plugin to be cancelled")
+	}
+	if guard.allow("profile", "slow", frameworktypes.BalanceExtensionPoint) {
+		t.Errorf("Expected the plugin to be disabled after timing out")
+	}
+}
+
+func TestPluginGuardTimedOutPluginCannotEvict(t *testing.T) {
+	pod := test.BuildTestPod("p1", 100, 0, "n1", nil)
+	podEvictor := evictions.NewPodEvictor(fake.NewSimpleClientset(pod), events.NewFakeRecorder(10), nil)
+	guard := NewPluginGuard(10*time.Millisecond, nil, 1, 1)
+	evictErr := make(chan error, 1)
+	// The plugin ignores the cancellation of its context and keeps evicting after timing out
+	stubborn := func(ctx context.Context) *frameworktypes.Status {
+		<-ctx.Done()
+		evictErr <- podEvictor.EvictPod(ctx, pod, evictions.EvictOptions{})
+		return nil
+	}
+
+	status := guard.run(context.Background(), "profile", "stubborn", frameworktypes.BalanceExtensionPoint, stubborn)
+	if status == nil || status.Err == nil || !strings.Contains(status.Err.Error(), "timed out") {
+		t.Fatalf("Expected a timeout error status, got %v", status)
+	}
+	select {
+	case err := <-evictErr:
+		if _, ok := err.(*evictions.EvictionCancelledError); !ok {
+			t.Errorf("Expected the eviction to be refused with an EvictionCancelledError, got %v", err)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("Expected the plugin to try evicting after timing out")
+	}
+	if evicted := podEvictor.TotalEvicted(); evicted != 0 {
+		t.Errorf("Expected no eviction, got %d", evicted)
+	}
+}
+
+func TestPluginGuardCancelledContext(t *testing.T) {
+	guard := NewPluginGuard(time.Hour, nil, 1, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	status := guard.run(ctx, "profile", "plugin", frameworktypes.DescheduleExtensionPoint, func(ctx context.Context) *frameworktypes.Status {
+		<-ctx.Done()
+		return &frameworktypes.Status{Err: ctx.Err()}
+	})
+	if status == nil || status.Err != context.Canceled {
+		t.Fatalf("Expected the status of the plugin, got %v", status)
+	}
+	if !guard.allow("profile", "plugin", frameworktypes.DescheduleExtensionPoint) {
+		t.Errorf("Expected a cancelled run not to count as a failure")
+	}
+}
+
+func TestPluginGuardCycleDeadline(t *testing.T) {
+	guard := NewPluginGuard(0, nil, 1, 1)
+	ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Millisecond, ErrCycleDeadlineExceeded)
+	defer cancel()
+	status := guard.run(ctx, "profile", "slow", frameworktypes.DescheduleExtensionPoint, func(ctx context.Context) *frameworktypes.Status {
+		<-ctx.Done()
+		return nil
+	})
+	if status == nil || !errors.Is(status.Err, ErrCycleDeadlineExceeded) {
+		t.Fatalf("Expected the plugin to be cancelled at the cycle deadline, got %v", status)
+	}
+	if !guard.allow("profile", "slow", frameworktypes.DescheduleExtensionPoint) {
+		t.Errorf("Expected a run cancelled at the cycle deadline not to count as a failure")
+	}
+}
+
+// namedPlugin is a plugin doing nothing
+type namedPlugin string
+
+func (p namedPlugin) Name() string {
+	return string(p)
+}
+
+func TestPluginGuardRunsSkippedPluginsFirst(t *testing.T) {
+	plugins := []namedPlugin{"first", "second", "third"}
+	pluginNames := func(plugins []namedPlugin) string {
+		var names []string
+		for _, pl := range plugins {
+			names = append(names, pl.Name())
+		}
+		return strings.Join(names, ",")
+	}
+
+	for _, runSkippedFirst := range []bool{false, true} {
+		guard := NewPluginGuard(0, nil, 1, 1).WithSkippedPluginsFirst(runSkippedFirst)
+		guard.StartCycle()
+		guard.skip("profile", "third", frameworktypes.BalanceExtensionPoint)
+		guard.skip("profile", "second", frameworktypes.DescheduleExtensionPoint)
+
+		guard.StartCycle()
+		want := "first,second,third"
+		if runSkippedFirst {
+			want = "second,first,third"
+		}
+		if got := pluginNames(prioritize(guard, "profile", frameworktypes.DescheduleExtensionPoint, plugins)); got != want {
+			t.Errorf("Expected the deschedule plugins to run in the %s order, got %s", want, got)
+		}
+		if got := guard.Prioritized("profile", frameworktypes.BalanceExtensionPoint); got != runSkippedFirst {
+			t.Errorf("Expected the balance plugins of the profile to be prioritized: %v, got %v", runSkippedFirst, got)
+		}
+		if guard.Prioritized("other", frameworktypes.DescheduleExtensionPoint) {
+			t.Errorf("Expected the plugins of the other profile not to be prioritized")
+		}
+
+		// The plugins only run first the cycle following the one they got skipped in
+		guard.StartCycle()
+		if got := pluginNames(prioritize(guard, "profile", frameworktypes.DescheduleExtensionPoint, plugins)); got != "first,second,third" {
+			t.Errorf("Expected the deschedule plugins to run in their order, got %s", got)
+		}
+	}
+}
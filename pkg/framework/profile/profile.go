@@ -23,16 +23,23 @@ import (
 	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/framework/logging"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/statestore"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/pkg/tracing"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 
 	"k8s.io/klog/v2"
 )
@@ -41,27 +48,65 @@ import (
 // can evict a pod without importing a specific pod evictor
 type evictorImpl struct {
 	profileName       string
+	profileLabels     map[string]string
 	podEvictor        *evictions.PodEvictor
 	filter            podutil.FilterFunc
 	preEvictionFilter podutil.FilterFunc
+	collector         *summaryCollector
 }
 
 var _ frameworktypes.Evictor = &evictorImpl{}
 
 // Filter checks if a pod can be evicted
 func (ei *evictorImpl) Filter(pod *v1.Pod) bool {
-	return ei.filter(pod)
+	// Pods evicted by a previous plugin are skipped without running the filter plugins
+	if ei.podEvictor.ProcessedPods().Has(pod) {
+		ei.collector.observeFilter(pod, SkippedAlreadyProcessed)
+		return false
+	}
+	if !ei.filter(pod) {
+		ei.collector.observeFilter(pod, SkippedByFilter)
+		return false
+	}
+	ei.collector.observeFilter(pod, "")
+	return true
 }
 
 // PreEvictionFilter checks if pod can be evicted right before eviction
 func (ei *evictorImpl) PreEvictionFilter(pod *v1.Pod) bool {
-	return ei.preEvictionFilter(pod)
+	if ei.podEvictor.ProcessedPods().Has(pod) {
+		ei.collector.observeFilter(pod, SkippedAlreadyProcessed)
+		return false
+	}
+	if !ei.preEvictionFilter(pod) {
+		ei.collector.observeFilter(pod, SkippedByPreEvictionFilter)
+		return false
+	}
+	return true
 }
 
 // Evict evicts a pod (no pre-check performed)
 func (ei *evictorImpl) Evict(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions) error {
 	opts.ProfileName = ei.profileName
-	return ei.podEvictor.EvictPod(ctx, pod, opts)
+	opts.ProfileLabels = ei.profileLabels
+	err := ei.podEvictor.EvictPod(ctx, pod, opts)
+	// The summary of the plugin was closed already when the plugin keeps running after being cancelled
+	if ctx.Err() == nil {
+		ei.collector.observeEviction(pod, err)
+	}
+	return err
+}
+
+// labeledRecorder appends the labels of the profile to the notes of the events emitted by its plugins
+type labeledRecorder struct {
+	recorder events.EventRecorder
+	labels   string
+}
+
+var _ events.EventRecorder = &labeledRecorder{}
+
+func (r *labeledRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...interface{}) {
+	r.recorder.Eventf(regarding, related, eventtype, reason, action, note+" (%s)", append(args, r.labels)...)
 }
 
 // handleImpl implements the framework handle which gets passed to plugins
@@ -69,7 +114,12 @@ type handleImpl struct {
 	clientSet                 clientset.Interface
 	getPodsAssignedToNodeFunc podutil.GetPodsAssignedToNodeFunc
 	sharedInformerFactory     informers.SharedInformerFactory
+	clusterInformerFactory    informers.SharedInformerFactory
 	evictor                   *evictorImpl
+	utilizationProvider       frameworktypes.UtilizationProvider
+	eventRecorder             events.EventRecorder
+	stateStore                frameworktypes.StateStore
+	nodeSnapshot              *nodeutil.NodeSnapshot
 }
 
 var _ frameworktypes.Handle = &handleImpl{}
@@ -89,11 +139,54 @@ func (hi *handleImpl) SharedInformerFactory() informers.SharedInformerFactory {
 	return hi.sharedInformerFactory
 }
 
+// ClusterInformerFactory retrieves the informer factory reading the cluster, even in dry run mode
+func (hi *handleImpl) ClusterInformerFactory() informers.SharedInformerFactory {
+	return hi.clusterInformerFactory
+}
+
 // Evictor retrieves evictor so plugins can filter and evict pods
 func (hi *handleImpl) Evictor() frameworktypes.Evictor {
 	return hi.evictor
 }
 
+// UtilizationProvider retrieves the provider of nodes utilization
+func (hi *handleImpl) UtilizationProvider() frameworktypes.UtilizationProvider {
+	return hi.utilizationProvider
+}
+
+// EventRecorder retrieves the event recorder
+func (hi *handleImpl) EventRecorder() events.EventRecorder {
+	return hi.eventRecorder
+}
+
+// ProcessedPods retrieves the pods evicted in the current descheduling cycle
+func (hi *handleImpl) ProcessedPods() *evictions.ProcessedPods {
+	return hi.evictor.podEvictor.ProcessedPods()
+}
+
+// ReadyNodes retrieves the ready nodes matching the selector
+func (hi *handleImpl) ReadyNodes(selector labels.Selector) ([]*v1.Node, error) {
+	return nodeutil.ReadyNodesFromLister(hi.sharedInformerFactory.Core().V1().Nodes().Lister(), selector)
+}
+
+// StateStore retrieves the store of the plugin state
+func (hi *handleImpl) StateStore() frameworktypes.StateStore {
+	return hi.stateStore
+}
+
+// NodeSnapshot retrieves the snapshot of the nodes of the descheduling cycle, a snapshot of the
+// ready nodes when none is set
+func (hi *handleImpl) NodeSnapshot() *nodeutil.NodeSnapshot {
+	if hi.nodeSnapshot == nil {
+		nodes, err := hi.ReadyNodes(labels.Everything())
+		if err != nil {
+			klog.ErrorS(err, "Unable to list the ready nodes")
+		}
+		hi.nodeSnapshot = nodeutil.NewNodeSnapshot(0, nodes)
+	}
+	return hi.nodeSnapshot
+}
+
 type filterPlugin interface {
 	frameworktypes.Plugin
 	Filter(pod *v1.Pod) bool
@@ -107,11 +200,17 @@ type preEvictionFilterPlugin interface {
 type profileImpl struct {
 	profileName string
 	podEvictor  *evictions.PodEvictor
+	collector   *summaryCollector
+	guard       *PluginGuard
+	handle      *handleImpl
 
 	deschedulePlugins        []frameworktypes.DeschedulePlugin
 	balancePlugins           []frameworktypes.BalancePlugin
 	filterPlugins            []filterPlugin
 	preEvictionFilterPlugins []preEvictionFilterPlugin
+	// nodeMatchers restricts the nodes processed by the plugins whose arguments carry a node selector
+	// or node roles
+	nodeMatchers map[string]api.NodeMatcher
 
 	// Each extension point with a list of plugins implementing the extension point.
 	deschedule        sets.Set[string]
@@ -126,8 +225,15 @@ type Option func(*handleImplOpts)
 type handleImplOpts struct {
 	clientSet                 clientset.Interface
 	sharedInformerFactory     informers.SharedInformerFactory
+	clusterInformerFactory    informers.SharedInformerFactory
 	getPodsAssignedToNodeFunc podutil.GetPodsAssignedToNodeFunc
 	podEvictor                *evictions.PodEvictor
+	utilizationProvider       frameworktypes.UtilizationProvider
+	eventRecorder             events.EventRecorder
+	predictDestinations       bool
+	stateStore                frameworktypes.StateStore
+	pluginGuard               *PluginGuard
+	nodeSnapshot              *nodeutil.NodeSnapshot
 }
 
 // WithClientSet sets clientSet for the scheduling frameworkImpl.
@@ -143,6 +249,14 @@ func WithSharedInformerFactory(sharedInformerFactory informers.SharedInformerFac
 	}
 }
 
+// WithClusterInformerFactory sets the informer factory reading the cluster, when the shared informer
+// factory reads the cached client of the dry run mode. The shared informer factory is used otherwise.
+func WithClusterInformerFactory(clusterInformerFactory informers.SharedInformerFactory) Option {
+	return func(o *handleImplOpts) {
+		o.clusterInformerFactory = clusterInformerFactory
+	}
+}
+
 func WithPodEvictor(podEvictor *evictions.PodEvictor) Option {
 	return func(o *handleImplOpts) {
 		o.podEvictor = podEvictor
@@ -155,6 +269,55 @@ func WithGetPodsAssignedToNodeFnc(getPodsAssignedToNodeFunc podutil.GetPodsAssig
 	}
 }
 
+// WithUtilizationProvider sets the provider of nodes utilization.
+// The utilization is computed from pod requests when not set.
+func WithUtilizationProvider(utilizationProvider frameworktypes.UtilizationProvider) Option {
+	return func(o *handleImplOpts) {
+		o.utilizationProvider = utilizationProvider
+	}
+}
+
+// WithEventRecorder sets the recorder plugins emit events with.
+// Events are dropped when not set.
+func WithEventRecorder(eventRecorder events.EventRecorder) Option {
+	return func(o *handleImplOpts) {
+		o.eventRecorder = eventRecorder
+	}
+}
+
+// WithDestinationPrediction predicts, through a fit simulation, the nodes the pods the plugins
+// try to evict may get scheduled onto, and lists them in the summaries of the plugins.
+func WithDestinationPrediction(predictDestinations bool) Option {
+	return func(o *handleImplOpts) {
+		o.predictDestinations = predictDestinations
+	}
+}
+
+// WithStateStore sets the store plugins persist their state in, scoped to each plugin.
+// The state is kept in memory for the lifetime of the profile when not set.
+func WithStateStore(stateStore frameworktypes.StateStore) Option {
+	return func(o *handleImplOpts) {
+		o.stateStore = stateStore
+	}
+}
+
+// WithPluginGuard sets the guard bounding the runs of the plugins and disabling the plugins repeatedly
+// failing, shared by the profiles across the cycles. The panics are recovered and the plugins are disabled
+// with the default thresholds for the lifetime of the profile when not set.
+func WithPluginGuard(pluginGuard *PluginGuard) Option {
+	return func(o *handleImplOpts) {
+		o.pluginGuard = pluginGuard
+	}
+}
+
+// WithNodeSnapshot sets the snapshot of the nodes the plugins run over in the descheduling cycle.
+// A snapshot of the ready nodes is taken when first requested when not set.
+func WithNodeSnapshot(nodeSnapshot *nodeutil.NodeSnapshot) Option {
+	return func(o *handleImplOpts) {
+		o.nodeSnapshot = nodeSnapshot
+	}
+}
+
 func getPluginConfig(pluginName string, pluginConfigs []api.PluginConfig) (*api.PluginConfig, int) {
 	for idx, pluginConfig := range pluginConfigs {
 		if pluginConfig.Name == pluginName {
@@ -176,7 +339,19 @@ func buildPlugin(config api.DeschedulerProfile, pluginName string, handle *handl
 		klog.ErrorS(fmt.Errorf("unable to find plugin in the pluginsMap"), "skipping plugin", "plugin", pluginName)
 		return nil, fmt.Errorf("unable to find %q plugin in the pluginsMap", pluginName)
 	}
-	pg, err := registryPlugin.PluginBuilder(pc.Args, handle)
+	pluginHandle := *handle
+	pluginHandle.stateStore = statestore.Scoped(handle.stateStore, config.Name, pluginName)
+	if handle.nodeSnapshot != nil {
+		// The plugins restricted to some nodes look up those only
+		matcher, err := pluginNodeMatcher(config, pluginName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize %q plugin: %v", pluginName, err)
+		}
+		if matcher != nil {
+			pluginHandle.nodeSnapshot = handle.nodeSnapshot.Select(matcher)
+		}
+	}
+	pg, err := registryPlugin.PluginBuilder(pc.Args, &pluginHandle)
 	if err != nil {
 		klog.ErrorS(err, "unable to initialize a plugin", "pluginName", pluginName)
 		return nil, fmt.Errorf("unable to initialize %q plugin: %v", pluginName, err)
@@ -184,6 +359,35 @@ func buildPlugin(config api.DeschedulerProfile, pluginName string, handle *handl
 	return pg, nil
 }
 
+// pluginNodeMatcher returns the node matcher of the plugin arguments embedding api.FilteringArgs,
+// nil when the plugin processes all the nodes
+func pluginNodeMatcher(config api.DeschedulerProfile, pluginName string) (api.NodeMatcher, error) {
+	pc, _ := getPluginConfig(pluginName, config.PluginConfigs)
+	if pc == nil {
+		return nil, nil
+	}
+	args, ok := pc.Args.(api.FilteringArgsGetter)
+	if !ok {
+		return nil, nil
+	}
+	return args.GetFilteringArgs().NodeMatcher()
+}
+
+// pluginNodes returns the nodes matching the node selector and the node roles of the plugin
+func (d profileImpl) pluginNodes(pluginName string, nodes []*v1.Node) []*v1.Node {
+	matcher, ok := d.nodeMatchers[pluginName]
+	if !ok {
+		return nodes
+	}
+	var pluginNodes []*v1.Node
+	for _, node := range nodes {
+		if matcher.Matches(labels.Set(node.Labels)) {
+			pluginNodes = append(pluginNodes, node)
+		}
+	}
+	return pluginNodes
+}
+
 func (p *profileImpl) registryToExtensionPoints(registry pluginregistry.Registry) {
 	p.deschedule = sets.New[string]()
 	p.balance = sets.New[string]()
@@ -217,18 +421,44 @@ func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts
 	if hOpts.sharedInformerFactory == nil {
 		return nil, fmt.Errorf("sharedInformerFactory missing")
 	}
+	if hOpts.clusterInformerFactory == nil {
+		hOpts.clusterInformerFactory = hOpts.sharedInformerFactory
+	}
 
 	if hOpts.podEvictor == nil {
 		return nil, fmt.Errorf("podEvictor missing")
 	}
 
+	if hOpts.utilizationProvider == nil {
+		hOpts.utilizationProvider = nodeutil.NewRequestsUtilizationProvider(hOpts.getPodsAssignedToNodeFunc)
+	}
+
+	if hOpts.eventRecorder == nil {
+		hOpts.eventRecorder = &events.FakeRecorder{}
+	}
+	if hOpts.stateStore == nil {
+		hOpts.stateStore = statestore.NewMemoryStore()
+	}
+	if hOpts.pluginGuard == nil {
+		hOpts.pluginGuard = NewPluginGuard(0, nil, DefaultPluginFailureThreshold, DefaultPluginCooldownCycles)
+	}
+	if len(config.Labels) > 0 {
+		hOpts.eventRecorder = &labeledRecorder{recorder: hOpts.eventRecorder, labels: labels.Set(config.Labels).String()}
+	}
+
 	pi := &profileImpl{
 		profileName:              config.Name,
 		podEvictor:               hOpts.podEvictor,
+		collector:                &summaryCollector{profileLabels: config.Labels},
+		guard:                    hOpts.pluginGuard,
 		deschedulePlugins:        []frameworktypes.DeschedulePlugin{},
 		balancePlugins:           []frameworktypes.BalancePlugin{},
 		filterPlugins:            []filterPlugin{},
 		preEvictionFilterPlugins: []preEvictionFilterPlugin{},
+		nodeMatchers:             map[string]api.NodeMatcher{},
+	}
+	if hOpts.predictDestinations {
+		pi.collector.getPodsAssignedToNode = hOpts.getPodsAssignedToNodeFunc
 	}
 	pi.registryToExtensionPoints(reg)
 
@@ -249,11 +479,19 @@ func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts
 		clientSet:                 hOpts.clientSet,
 		getPodsAssignedToNodeFunc: hOpts.getPodsAssignedToNodeFunc,
 		sharedInformerFactory:     hOpts.sharedInformerFactory,
+		clusterInformerFactory:    hOpts.clusterInformerFactory,
+		utilizationProvider:       hOpts.utilizationProvider,
+		eventRecorder:             hOpts.eventRecorder,
+		stateStore:                hOpts.stateStore,
+		nodeSnapshot:              hOpts.nodeSnapshot,
 		evictor: &evictorImpl{
-			profileName: config.Name,
-			podEvictor:  hOpts.podEvictor,
+			profileName:   config.Name,
+			profileLabels: config.Labels,
+			podEvictor:    hOpts.podEvictor,
+			collector:     pi.collector,
 		},
 	}
+	pi.handle = handle
 
 	pluginNames := append(config.Plugins.Deschedule.Enabled, config.Plugins.Balance.Enabled...)
 	pluginNames = append(pluginNames, config.Plugins.Filter.Enabled...)
@@ -269,6 +507,14 @@ func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts
 			return nil, fmt.Errorf("got empty %v plugin build", plugin)
 		}
 		plugins[plugin] = pg
+
+		matcher, err := pluginNodeMatcher(config, plugin)
+		if err != nil {
+			return nil, fmt.Errorf("unable to build %v plugin: %v", plugin, err)
+		}
+		if matcher != nil {
+			pi.nodeMatchers[plugin] = matcher
+		}
 	}
 
 	// Later, when a default list of plugins and their extension points is established,
@@ -299,16 +545,43 @@ func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts
 	return pi, nil
 }
 
+// summaryDetails returns the details the plugin reports about its last run, if any
+func summaryDetails(pl frameworktypes.Plugin) map[string]string {
+	if reporter, ok := pl.(frameworktypes.SummaryReporter); ok {
+		return reporter.SummaryDetails()
+	}
+	return nil
+}
+
 func (d profileImpl) RunDeschedulePlugins(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
 	errs := []error{}
-	for _, pl := range d.deschedulePlugins {
+	d.collector.setNodes(nodes)
+	for _, pl := range prioritize(d.guard, d.profileName, frameworktypes.DescheduleExtensionPoint, d.deschedulePlugins) {
+		if cycleOverrun(ctx) {
+			klog.V(1).InfoS("Skipping plugin as the cycle overran its deadline", "plugin", pl.Name(), "profile", d.profileName, "extension point", "Deschedule")
+			d.guard.skip(d.profileName, pl.Name(), frameworktypes.DescheduleExtensionPoint)
+			d.collector.skip(d.profileName, pl.Name(), frameworktypes.DescheduleExtensionPoint)
+			continue
+		}
+		if !d.guard.allow(d.profileName, pl.Name(), frameworktypes.DescheduleExtensionPoint) {
+			klog.V(1).InfoS("Skipping plugin disabled by its circuit breaker", "plugin", pl.Name(), "profile", d.profileName, "extension point", "Deschedule")
+			continue
+		}
 		var span trace.Span
 		ctx, span = tracing.Tracer().Start(ctx, pl.Name(), trace.WithAttributes(attribute.String("plugin", pl.Name()), attribute.String("profile", d.profileName), attribute.String("operation", tracing.DescheduleOperation)))
 		defer span.End()
 		evicted := d.podEvictor.TotalEvicted()
+		pluginNodes := d.pluginNodes(pl.Name(), nodes)
+		d.collector.start(d.profileName, pl.Name(), frameworktypes.DescheduleExtensionPoint, pluginNodes)
 		strategyStart := time.Now()
-		status := pl.Deschedule(ctx, nodes)
-		metrics.DeschedulerStrategyDuration.With(map[string]string{"strategy": pl.Name(), "profile": d.profileName}).Observe(time.Since(strategyStart).Seconds())
+		// The plugins log through a logger named after them which honors their verbosity override
+		pluginCtx := klog.NewContext(ctx, logging.DefaultLevels.PluginLogger(klog.FromContext(ctx), pl.Name()))
+		status := d.guard.run(pluginCtx, d.profileName, pl.Name(), frameworktypes.DescheduleExtensionPoint, func(ctx context.Context) *frameworktypes.Status {
+			return pl.Deschedule(ctx, pluginNodes)
+		})
+		strategyDuration := time.Since(strategyStart)
+		metrics.DeschedulerStrategyDuration.With(map[string]string{"strategy": pl.Name(), "profile": d.profileName}).Observe(strategyDuration.Seconds())
+		d.collector.finish(d.podEvictor.TotalEvicted()-evicted, strategyDuration, status, summaryDetails(pl))
 
 		if status != nil && status.Err != nil {
 			span.AddEvent("Plugin Execution Failed", trace.WithAttributes(attribute.String("err", status.Err.Error())))
@@ -329,14 +602,32 @@ func (d profileImpl) RunDeschedulePlugins(ctx context.Context, nodes []*v1.Node)
 
 func (d profileImpl) RunBalancePlugins(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
 	errs := []error{}
-	for _, pl := range d.balancePlugins {
+	d.collector.setNodes(nodes)
+	for _, pl := range prioritize(d.guard, d.profileName, frameworktypes.BalanceExtensionPoint, d.balancePlugins) {
+		if cycleOverrun(ctx) {
+			klog.V(1).InfoS("Skipping plugin as the cycle overran its deadline", "plugin", pl.Name(), "profile", d.profileName, "extension point", "Balance")
+			d.guard.skip(d.profileName, pl.Name(), frameworktypes.BalanceExtensionPoint)
+			d.collector.skip(d.profileName, pl.Name(), frameworktypes.BalanceExtensionPoint)
+			continue
+		}
+		if !d.guard.allow(d.profileName, pl.Name(), frameworktypes.BalanceExtensionPoint) {
+			klog.V(1).InfoS("Skipping plugin disabled by its circuit breaker", "plugin", pl.Name(), "profile", d.profileName, "extension point", "Balance")
+			continue
+		}
 		var span trace.Span
 		ctx, span = tracing.Tracer().Start(ctx, pl.Name(), trace.WithAttributes(attribute.String("plugin", pl.Name()), attribute.String("profile", d.profileName), attribute.String("operation", tracing.BalanceOperation)))
 		defer span.End()
 		evicted := d.podEvictor.TotalEvicted()
+		pluginNodes := d.pluginNodes(pl.Name(), nodes)
+		d.collector.start(d.profileName, pl.Name(), frameworktypes.BalanceExtensionPoint, pluginNodes)
 		strategyStart := time.Now()
-		status := pl.Balance(ctx, nodes)
-		metrics.DeschedulerStrategyDuration.With(map[string]string{"strategy": pl.Name(), "profile": d.profileName}).Observe(time.Since(strategyStart).Seconds())
+		pluginCtx := klog.NewContext(ctx, logging.DefaultLevels.PluginLogger(klog.FromContext(ctx), pl.Name()))
+		status := d.guard.run(pluginCtx, d.profileName, pl.Name(), frameworktypes.BalanceExtensionPoint, func(ctx context.Context) *frameworktypes.Status {
+			return d.runBalancePasses(ctx, pl, pluginNodes)
+		})
+		strategyDuration := time.Since(strategyStart)
+		metrics.DeschedulerStrategyDuration.With(map[string]string{"strategy": pl.Name(), "profile": d.profileName}).Observe(strategyDuration.Seconds())
+		d.collector.finish(d.podEvictor.TotalEvicted()-evicted, strategyDuration, status, summaryDetails(pl))
 
 		if status != nil && status.Err != nil {
 			span.AddEvent("Plugin Execution Failed", trace.WithAttributes(attribute.String("err", status.Err.Error())))
@@ -354,3 +645,82 @@ func (d profileImpl) RunBalancePlugins(ctx context.Context, nodes []*v1.Node) *f
 		Err: fmt.Errorf("%v", aggrErr.Error()),
 	}
 }
+
+// balancePassPollInterval is how often the nodes are checked for the evicted pods before an extra balance pass
+const balancePassPollInterval = time.Second
+
+// runBalancePasses runs the plugin once, or again while its last pass evicted pods when it converges over
+// several passes. Each extra pass waits for the pods evicted so far to leave the nodes, so their usage is not
+// counted anymore, and runs on the nodes refreshed from the cache.
+func (d profileImpl) runBalancePasses(ctx context.Context, pl frameworktypes.BalancePlugin, nodes []*v1.Node) *frameworktypes.Status {
+	logger := klog.FromContext(ctx)
+	maxPasses, settleTimeout := 1, time.Duration(0)
+	if multiPass, ok := pl.(frameworktypes.MultiPassBalancePlugin); ok {
+		maxPasses, settleTimeout = multiPass.BalancePasses()
+	}
+	if maxPasses > 1 && d.podEvictor.DefersEvictions() {
+		// The evicted pods would not leave their nodes before the passes to come
+		logger.V(1).Info("Evictions are paced or simulated, running a single balance pass", "maxPasses", maxPasses)
+		maxPasses = 1
+	}
+	for pass := 1; ; pass++ {
+		evictedBefore := d.podEvictor.EvictedPods().Clone()
+		status := pl.Balance(ctx, nodes)
+		if status != nil && status.Err != nil || pass >= maxPasses {
+			return status
+		}
+		// Only the evictions of this pass are waited for, the pods queued, held back or simulated
+		// in a dry run namespace do not leave their nodes in the meantime
+		evictedInPass := func(pod *v1.Pod) bool {
+			return d.podEvictor.EvictedPods().Has(pod) && !evictedBefore.Has(pod)
+		}
+		if d.podEvictor.EvictedPods().Len() == evictedBefore.Len() {
+			logger.V(1).Info("Balance passes converged", "passes", pass)
+			return status
+		}
+		if !d.waitForEvictedPods(ctx, nodes, settleTimeout, evictedInPass) {
+			logger.V(1).Info("Evicted pods did not leave the nodes in time, skipping the remaining balance passes", "passes", pass, "settleTimeout", settleTimeout)
+			return status
+		}
+		nodes = d.refreshNodes(nodes)
+		logger.V(1).Info("Running another balance pass", "pass", pass+1, "maxPasses", maxPasses)
+	}
+}
+
+// waitForEvictedPods waits up to the timeout for the evicted pods to leave the nodes, it returns whether they did
+func (d profileImpl) waitForEvictedPods(ctx context.Context, nodes []*v1.Node, timeout time.Duration, evicted podutil.FilterFunc) bool {
+	evictedPodsLeft := func(ctx context.Context) (bool, error) {
+		for _, node := range nodes {
+			pods, err := podutil.ListPodsOnANode(node.Name, d.handle.getPodsAssignedToNodeFunc, evicted)
+			if err != nil || len(pods) > 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	if timeout <= 0 {
+		left, _ := evictedPodsLeft(ctx)
+		return left
+	}
+	return wait.PollUntilContextTimeout(ctx, balancePassPollInterval, timeout, true, evictedPodsLeft) == nil
+}
+
+// refreshNodes returns the nodes as currently cached, without the nodes which are not ready anymore
+func (d profileImpl) refreshNodes(nodes []*v1.Node) []*v1.Node {
+	readyNodes, err := d.handle.ReadyNodes(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Unable to refresh the nodes, the balance pass runs on the previous ones")
+		return nodes
+	}
+	cached := make(map[string]*v1.Node, len(readyNodes))
+	for _, node := range readyNodes {
+		cached[node.Name] = node
+	}
+	refreshed := make([]*v1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node, ok := cached[node.Name]; ok {
+			refreshed = append(refreshed, node)
+		}
+	}
+	return refreshed
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -21,6 +22,7 @@ import (
 	fakeplugin "sigs.k8s.io/descheduler/pkg/framework/fake/plugin"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
 	frameworktesting "sigs.k8s.io/descheduler/pkg/framework/testing"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	testutils "sigs.k8s.io/descheduler/test"
@@ -280,6 +282,267 @@ func TestProfileDescheduleBalanceExtensionPointsEviction(t *testing.T) {
 	}
 }
 
+func TestProfileSkipsProcessedPods(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1}
+
+	p1 := testutils.BuildTestPod(fmt.Sprintf("pod_1_%s", n1.Name), 200, 0, n1.Name, nil)
+	p1.ObjectMeta.OwnerReferences = []metav1.OwnerReference{{}}
+
+	var balanceFiltered, balanceProcessed bool
+	fakePlugin := fakeplugin.FakePlugin{}
+	fakePlugin.AddReactor(string(frameworktypes.DescheduleExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		if dAction, ok := action.(fakeplugin.DescheduleAction); ok {
+			if !dAction.Handle().Evictor().Filter(p1) {
+				return true, false, fmt.Errorf("pod filtered out before its eviction")
+			}
+			if err := dAction.Handle().Evictor().Evict(ctx, p1, evictions.EvictOptions{StrategyName: fakePlugin.PluginName}); err != nil {
+				return true, false, fmt.Errorf("pod not evicted: %v", err)
+			}
+			return true, false, nil
+		}
+		return false, false, nil
+	})
+	fakePlugin.AddReactor(string(frameworktypes.BalanceExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		if bAction, ok := action.(fakeplugin.BalanceAction); ok {
+			balanceFiltered = bAction.Handle().Evictor().Filter(p1) || bAction.Handle().Evictor().PreEvictionFilter(p1)
+			balanceProcessed = bAction.Handle().ProcessedPods().Has(p1)
+			return true, false, nil
+		}
+		return false, false, nil
+	})
+
+	pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+	pluginregistry.Register(
+		"FakePlugin",
+		fakeplugin.NewPluginFncFromFake(&fakePlugin),
+		&fakeplugin.FakePlugin{},
+		&fakeplugin.FakePluginArgs{},
+		fakeplugin.ValidateFakePluginArgs,
+		fakeplugin.SetDefaults_FakePluginArgs,
+		pluginregistry.PluginRegistry,
+	)
+	pluginregistry.Register(
+		defaultevictor.PluginName,
+		defaultevictor.New,
+		&defaultevictor.DefaultEvictor{},
+		&defaultevictor.DefaultEvictorArgs{},
+		defaultevictor.ValidateDefaultEvictorArgs,
+		defaultevictor.SetDefaults_DefaultEvictorArgs,
+		pluginregistry.PluginRegistry,
+	)
+
+	client := fakeclientset.NewSimpleClientset(n1, p1)
+	var evictedPods []string
+	client.PrependReactor("create", "pods", podEvictionReactionFuc(&evictedPods))
+
+	handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, client, nil, defaultevictor.DefaultEvictorArgs{}, nil)
+	if err != nil {
+		t.Fatalf("Unable to initialize a framework handle: %v", err)
+	}
+
+	config := api.DeschedulerProfile{
+		Name: "strategy-test-profile-processed-pods",
+		PluginConfigs: []api.PluginConfig{
+			{
+				Name: defaultevictor.PluginName,
+				Args: &defaultevictor.DefaultEvictorArgs{},
+			},
+			{
+				Name: "FakePlugin",
+				Args: &fakeplugin.FakePluginArgs{},
+			},
+		},
+		Plugins: api.Plugins{
+			Deschedule:        api.PluginSet{Enabled: []string{"FakePlugin"}},
+			Balance:           api.PluginSet{Enabled: []string{"FakePlugin"}},
+			Filter:            api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+			PreEvictionFilter: api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+		},
+	}
+	prfl, err := NewProfile(
+		config,
+		pluginregistry.PluginRegistry,
+		WithClientSet(client),
+		WithSharedInformerFactory(handle.SharedInformerFactoryImpl),
+		WithPodEvictor(podEvictor),
+		WithGetPodsAssignedToNodeFnc(handle.GetPodsAssignedToNodeFuncImpl),
+	)
+	if err != nil {
+		t.Fatalf("unable to create %q profile: %v", config.Name, err)
+	}
+
+	if status := prfl.RunDeschedulePlugins(ctx, nodes); status.Err != nil {
+		t.Fatalf("Expected nil error in status, got %q instead", status.Err)
+	}
+	if status := prfl.RunBalancePlugins(ctx, nodes); status.Err != nil {
+		t.Fatalf("Expected nil error in status, got %q instead", status.Err)
+	}
+
+	if len(evictedPods) != 1 {
+		t.Errorf("Expected 1 eviction, got %v", evictedPods)
+	}
+	if balanceFiltered {
+		t.Errorf("Expected the evicted pod to be filtered out by the next plugin")
+	}
+	if !balanceProcessed {
+		t.Errorf("Expected the evicted pod to be reported as processed to the next plugin")
+	}
+}
+
+func TestProfilePluginNodeSelector(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"pool": "a", "node-role.kubernetes.io/worker": ""}
+	})
+	n2 := testutils.BuildTestNode("n2", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"pool": "b", "kubernetes.io/role": "master"}
+	})
+	n3 := testutils.BuildTestNode("n3", 2000, 3000, 10, func(node *v1.Node) {
+		node.Labels = map[string]string{"pool": "a", "node-role.kubernetes.io/control-plane": ""}
+	})
+	nodes := []*v1.Node{n1, n2, n3}
+
+	nodeNames := func(nodes []*v1.Node) []string {
+		var names []string
+		for _, node := range nodes {
+			names = append(names, node.Name)
+		}
+		return names
+	}
+	var descheduleNodes, balanceNodes []string
+	fakePlugin := fakeplugin.FakePlugin{PluginName: "FakePlugin"}
+	fakePlugin.AddReactor(string(frameworktypes.DescheduleExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		if dAction, ok := action.(fakeplugin.DescheduleAction); ok {
+			descheduleNodes = nodeNames(dAction.Nodes())
+			return true, false, nil
+		}
+		return false, false, nil
+	})
+	fakePlugin.AddReactor(string(frameworktypes.BalanceExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		if bAction, ok := action.(fakeplugin.BalanceAction); ok {
+			balanceNodes = nodeNames(bAction.Nodes())
+			return true, false, nil
+		}
+		return false, false, nil
+	})
+
+	// The arguments of any plugin embedding the filtering arguments carry a node selector
+	pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+	pluginregistry.Register(
+		"FakePlugin",
+		func(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+			return &fakePlugin, nil
+		},
+		&fakeplugin.FakePlugin{},
+		&removefailedpods.RemoveFailedPodsArgs{},
+		removefailedpods.ValidateRemoveFailedPodsArgs,
+		removefailedpods.SetDefaults_RemoveFailedPodsArgs,
+		pluginregistry.PluginRegistry,
+	)
+	pluginregistry.Register(
+		defaultevictor.PluginName,
+		defaultevictor.New,
+		&defaultevictor.DefaultEvictor{},
+		&defaultevictor.DefaultEvictorArgs{},
+		defaultevictor.ValidateDefaultEvictorArgs,
+		defaultevictor.SetDefaults_DefaultEvictorArgs,
+		pluginregistry.PluginRegistry,
+	)
+
+	client := fakeclientset.NewSimpleClientset(n1, n2, n3)
+	handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, client, nil, defaultevictor.DefaultEvictorArgs{}, nil)
+	if err != nil {
+		t.Fatalf("Unable to initialize a framework handle: %v", err)
+	}
+
+	newProfile := func(filteringArgs api.FilteringArgs) (*profileImpl, error) {
+		return NewProfile(
+			api.DeschedulerProfile{
+				Name: "strategy-test-profile-node-selector",
+				PluginConfigs: []api.PluginConfig{
+					{
+						Name: defaultevictor.PluginName,
+						Args: &defaultevictor.DefaultEvictorArgs{},
+					},
+					{
+						Name: "FakePlugin",
+						Args: &removefailedpods.RemoveFailedPodsArgs{FilteringArgs: filteringArgs},
+					},
+				},
+				Plugins: api.Plugins{
+					Deschedule:        api.PluginSet{Enabled: []string{"FakePlugin"}},
+					Balance:           api.PluginSet{Enabled: []string{"FakePlugin"}},
+					Filter:            api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+					PreEvictionFilter: api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+				},
+			},
+			pluginregistry.PluginRegistry,
+			WithClientSet(client),
+			WithSharedInformerFactory(handle.SharedInformerFactoryImpl),
+			WithPodEvictor(podEvictor),
+			WithGetPodsAssignedToNodeFnc(handle.GetPodsAssignedToNodeFuncImpl),
+		)
+	}
+
+	if _, err := newProfile(api.FilteringArgs{NodeSelector: "pool in (a"}); err == nil {
+		t.Errorf("Expected an invalid node selector to fail the profile creation")
+	}
+
+	tests := []struct {
+		description   string
+		filteringArgs api.FilteringArgs
+		expectedNodes []string
+	}{
+		{
+			description:   "node selector",
+			filteringArgs: api.FilteringArgs{NodeSelector: "pool=a"},
+			expectedNodes: []string{"n1", "n3"},
+		},
+		{
+			description:   "excluded node roles",
+			filteringArgs: api.FilteringArgs{NodeRoles: &api.NodeRoles{Exclude: []string{"control-plane", "master"}}},
+			expectedNodes: []string{"n1"},
+		},
+		{
+			description:   "included node roles",
+			filteringArgs: api.FilteringArgs{NodeRoles: &api.NodeRoles{Include: []string{"control-plane", "master"}}},
+			expectedNodes: []string{"n2", "n3"},
+		},
+		{
+			description:   "node selector and node roles",
+			filteringArgs: api.FilteringArgs{NodeSelector: "pool=a", NodeRoles: &api.NodeRoles{Include: []string{"control-plane", "master"}}},
+			expectedNodes: []string{"n3"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			descheduleNodes, balanceNodes = nil, nil
+			prfl, err := newProfile(tc.filteringArgs)
+			if err != nil {
+				t.Fatalf("unable to create the profile: %v", err)
+			}
+			if status := prfl.RunDeschedulePlugins(ctx, nodes); status.Err != nil {
+				t.Fatalf("Expected nil error in status, got %q instead", status.Err)
+			}
+			if status := prfl.RunBalancePlugins(ctx, nodes); status.Err != nil {
+				t.Fatalf("Expected nil error in status, got %q instead", status.Err)
+			}
+			if diff := cmp.Diff(tc.expectedNodes, descheduleNodes); diff != "" {
+				t.Errorf("Unexpected nodes passed to the deschedule extension point (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedNodes, balanceNodes); diff != "" {
+				t.Errorf("Unexpected nodes passed to the balance extension point (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func podEvictionReactionFuc(evictedPods *[]string) func(action core.Action) (bool, runtime.Object, error) {
 	return func(action core.Action) (bool, runtime.Object, error) {
 		if action.GetSubresource() == "eviction" {
@@ -677,3 +940,189 @@ func TestProfileExtensionPointOrdering(t *testing.T) {
 		t.Errorf("check for balance invocation order failed. Results are not deep equal. mismatch (-want +got):\n%s", diff)
 	}
 }
+
+// multiPassFakePlugin converges over several balance passes
+type multiPassFakePlugin struct {
+	*fakeplugin.FakePlugin
+	maxPasses     int
+	settleTimeout time.Duration
+}
+
+func (p *multiPassFakePlugin) BalancePasses() (int, time.Duration) {
+	return p.maxPasses, p.settleTimeout
+}
+
+func TestProfileBalancePasses(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxPasses       int
+		deleteEvicted   bool
+		pacingPeriod    time.Duration
+		expectedPasses  int
+		expectedEvicted int
+	}{
+		{
+			name:            "single pass",
+			maxPasses:       1,
+			deleteEvicted:   true,
+			expectedPasses:  1,
+			expectedEvicted: 1,
+		},
+		{
+			name:            "passes until a pass evicts no pod",
+			maxPasses:       5,
+			deleteEvicted:   true,
+			expectedPasses:  4,
+			expectedEvicted: 3,
+		},
+		{
+			name:            "passes up to the maximum number of passes",
+			maxPasses:       2,
+			deleteEvicted:   true,
+			expectedPasses:  2,
+			expectedEvicted: 2,
+		},
+		{
+			name:            "no other pass when the evicted pods do not leave the node",
+			maxPasses:       5,
+			expectedPasses:  1,
+			expectedEvicted: 1,
+		},
+		{
+			name:            "single pass without waiting when the evictions are paced",
+			maxPasses:       5,
+			deleteEvicted:   true,
+			pacingPeriod:    time.Minute,
+			expectedPasses:  1,
+			expectedEvicted: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+
+			n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+			objs := []runtime.Object{n1}
+			for i := 1; i <= 3; i++ {
+				pod := testutils.BuildTestPod(fmt.Sprintf("pod_%d", i), 200, 0, n1.Name, testutils.SetRSOwnerRef)
+				objs = append(objs, pod)
+			}
+
+			passes := 0
+			fakePlugin := fakeplugin.FakePlugin{}
+			fakePlugin.AddReactor(string(frameworktypes.BalanceExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+				bAction, ok := action.(fakeplugin.BalanceAction)
+				if !ok {
+					return false, false, nil
+				}
+				passes++
+				// Evict a single pod per pass
+				pods, err := bAction.Handle().GetPodsAssignedToNodeFunc()(n1.Name, bAction.Handle().Evictor().Filter)
+				if err != nil || len(pods) == 0 {
+					return true, false, err
+				}
+				sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+				return true, false, bAction.Handle().Evictor().Evict(ctx, pods[0], evictions.EvictOptions{StrategyName: fakePlugin.PluginName})
+			})
+
+			pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+			pluginregistry.Register(
+				"FakePlugin",
+				func(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+					plugin, err := fakeplugin.NewPluginFncFromFake(&fakePlugin)(args, handle)
+					if err != nil {
+						return nil, err
+					}
+					return &multiPassFakePlugin{FakePlugin: plugin.(*fakeplugin.FakePlugin), maxPasses: tc.maxPasses, settleTimeout: 2 * time.Second}, nil
+				},
+				&multiPassFakePlugin{FakePlugin: &fakeplugin.FakePlugin{}},
+				&fakeplugin.FakePluginArgs{},
+				fakeplugin.ValidateFakePluginArgs,
+				fakeplugin.SetDefaults_FakePluginArgs,
+				pluginregistry.PluginRegistry,
+			)
+			pluginregistry.Register(
+				defaultevictor.PluginName,
+				defaultevictor.New,
+				&defaultevictor.DefaultEvictor{},
+				&defaultevictor.DefaultEvictorArgs{},
+				defaultevictor.ValidateDefaultEvictorArgs,
+				defaultevictor.SetDefaults_DefaultEvictorArgs,
+				pluginregistry.PluginRegistry,
+			)
+
+			client := fakeclientset.NewSimpleClientset(objs...)
+			var evictedPods []string
+			client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				eviction := action.(core.CreateActionImpl).Object.(*policy.Eviction)
+				evictedPods = append(evictedPods, eviction.Name)
+				if tc.deleteEvicted {
+					return true, nil, client.Tracker().Delete(v1.SchemeGroupVersion.WithResource("pods"), eviction.Namespace, eviction.Name)
+				}
+				return true, nil, nil
+			})
+
+			var evictionOptions *evictions.Options
+			if tc.pacingPeriod > 0 {
+				evictionOptions = evictions.NewOptions().WithPacingPeriod(tc.pacingPeriod)
+			}
+			handle, podEvictor, err := frameworktesting.InitFrameworkHandle(ctx, client, evictionOptions, defaultevictor.DefaultEvictorArgs{}, nil)
+			if err != nil {
+				t.Fatalf("Unable to initialize a framework handle: %v", err)
+			}
+
+			config := api.DeschedulerProfile{
+				Name: "strategy-test-profile-balance-passes",
+				PluginConfigs: []api.PluginConfig{
+					{
+						Name: defaultevictor.PluginName,
+						Args: &defaultevictor.DefaultEvictorArgs{},
+					},
+					{
+						Name: "FakePlugin",
+						Args: &fakeplugin.FakePluginArgs{},
+					},
+				},
+				Plugins: api.Plugins{
+					Balance:           api.PluginSet{Enabled: []string{"FakePlugin"}},
+					Filter:            api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+					PreEvictionFilter: api.PluginSet{Enabled: []string{defaultevictor.PluginName}},
+				},
+			}
+			prfl, err := NewProfile(
+				config,
+				pluginregistry.PluginRegistry,
+				WithClientSet(client),
+				WithSharedInformerFactory(handle.SharedInformerFactoryImpl),
+				WithPodEvictor(podEvictor),
+				WithGetPodsAssignedToNodeFnc(handle.GetPodsAssignedToNodeFuncImpl),
+			)
+			if err != nil {
+				t.Fatalf("unable to create %q profile: %v", config.Name, err)
+			}
+			handle.SharedInformerFactoryImpl.Core().V1().Nodes().Informer()
+			handle.SharedInformerFactoryImpl.Start(ctx.Done())
+			handle.SharedInformerFactoryImpl.WaitForCacheSync(ctx.Done())
+
+			start := time.Now()
+			if status := prfl.RunBalancePlugins(ctx, []*v1.Node{n1}); status.Err != nil {
+				t.Fatalf("Expected nil error in status, got %q instead", status.Err)
+			}
+			// The queued pods never leave the node, waiting for them would only run into the settle timeout
+			if elapsed := time.Since(start); tc.pacingPeriod > 0 && elapsed >= 2*time.Second {
+				t.Errorf("Expected no wait for the queued evictions, took %v", elapsed)
+			}
+			if passes != tc.expectedPasses {
+				t.Errorf("Expected %d balance passes, got %d", tc.expectedPasses, passes)
+			}
+			if len(evictedPods) != tc.expectedEvicted {
+				t.Errorf("Expected %d evictions, got %v", tc.expectedEvicted, evictedPods)
+			}
+		})
+	}
+}
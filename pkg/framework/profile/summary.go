@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+// Reasons pods were skipped for, as reported by PluginSummary
+const (
+	SkippedByFilter            = "filter"
+	SkippedByPreEvictionFilter = "preEvictionFilter"
+	SkippedByNodeLimit         = "nodeLimit"
+	SkippedByNamespaceLimit    = "namespaceLimit"
+	SkippedByTotalLimit        = "totalLimit"
+	SkippedByEvictionError     = "evictionError"
+	SkippedAlreadyProcessed    = "alreadyProcessed"
+	SkippedByNamespaceMode     = "namespaceMode"
+)
+
+// Overruns of the plugins as reported by PluginSummary, once the cycle overran its deadline
+const (
+	// OverrunCancelled reports the plugin running at the deadline, whose context got cancelled
+	OverrunCancelled = "cancelled"
+	// OverrunSkipped reports the plugins left to run at the deadline
+	OverrunSkipped = "skipped"
+)
+
+// EvictionResultEvicted is the result of the evictions requested successfully, as reported by EvictionSummary
+const EvictionResultEvicted = "evicted"
+
+// maxPredictedDestinations caps the number of destinations listed by EvictionSummary
+const maxPredictedDestinations = 5
+
+// PluginSummary reports what a plugin did while running an extension point of a profile
+type PluginSummary struct {
+	Profile string `json:"profile"`
+	// ProfileLabels are the labels attributing the evictions of the profile
+	ProfileLabels  map[string]string             `json:"profileLabels,omitempty"`
+	Plugin         string                        `json:"plugin"`
+	ExtensionPoint frameworktypes.ExtensionPoint `json:"extensionPoint"`
+	// Nodes is the number of nodes the plugin processed
+	Nodes int `json:"nodes"`
+	// Candidates is the number of pods accepted by the evictor filter
+	Candidates int `json:"candidates"`
+	// Evicted is the number of pods the plugin evicted
+	Evicted uint `json:"evicted"`
+	// Skipped is the number of pods the plugin did not evict, by reason
+	Skipped  map[string]int  `json:"skipped,omitempty"`
+	Duration metav1.Duration `json:"duration"`
+	Error    string          `json:"error,omitempty"`
+	// Evictions lists the pods the plugin tried to evict, only when predicting their destinations
+	Evictions []EvictionSummary `json:"evictions,omitempty"`
+	// Details are reported by the plugins implementing frameworktypes.SummaryReporter
+	Details map[string]string `json:"details,omitempty"`
+	// Overrun is set when the cycle overran its deadline before the plugin completed, see OverrunCancelled
+	Overrun string `json:"overrun,omitempty"`
+}
+
+// EvictionSummary reports a pod a plugin tried to evict, along with the nodes it is predicted
+// to get scheduled onto next. The prediction is a best effort fit simulation over the nodes of
+// the cycle, the scheduler may decide otherwise, e.g. because of other pods or scoring.
+type EvictionSummary struct {
+	// Pod is the namespace/name of the pod
+	Pod  string `json:"pod"`
+	Node string `json:"node"`
+	// Result is either evicted or the reason the eviction was skipped for
+	Result string `json:"result"`
+	// Destinations are some of the other nodes the pod fits on, at most 5
+	Destinations []string `json:"destinations,omitempty"`
+	// FittingNodes is the number of other nodes the pod fits on, zero meaning the pod
+	// is expected to stay pending or to get back to its node
+	FittingNodes int `json:"fittingNodes"`
+}
+
+// summaryCollector gathers the summaries of the plugins of a profile.
+// The evictor reports the pods it filters and evicts to the plugin currently running.
+type summaryCollector struct {
+	mu        sync.Mutex
+	current   *PluginSummary
+	filtered  map[string]sets.Set[string]
+	summaries []PluginSummary
+
+	// profileLabels are reported in the summaries of the profile plugins
+	profileLabels map[string]string
+	// getPodsAssignedToNode enables the prediction of the destinations of the evicted pods when set
+	getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc
+	// nodes the destinations are predicted among
+	nodes []*v1.Node
+}
+
+// setNodes sets the nodes the destinations of the evicted pods are predicted among
+func (c *summaryCollector) setNodes(nodes []*v1.Node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nodes = nodes
+}
+
+func (c *summaryCollector) start(profile, plugin string, extensionPoint frameworktypes.ExtensionPoint, nodes []*v1.Node) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.current = &PluginSummary{
+		Profile:        profile,
+		ProfileLabels:  c.profileLabels,
+		Plugin:         plugin,
+		ExtensionPoint: extensionPoint,
+		Nodes:          len(nodes),
+		Skipped:        map[string]int{},
+	}
+	c.filtered = map[string]sets.Set[string]{}
+}
+
+func (c *summaryCollector) finish(evicted uint, duration time.Duration, status *frameworktypes.Status, details map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current == nil {
+		return
+	}
+	summary := c.current
+	summary.Evicted = evicted
+	summary.Duration = metav1.Duration{Duration: duration}
+	if len(details) > 0 {
+		summary.Details = details
+	}
+	if status != nil && status.Err != nil {
+		summary.Error = status.Err.Error()
+		if errors.Is(status.Err, ErrCycleDeadlineExceeded) {
+			summary.Overrun = OverrunCancelled
+		}
+	}
+	if len(summary.Skipped) == 0 {
+		summary.Skipped = nil
+	}
+	c.summaries = append(c.summaries, *summary)
+	c.current = nil
+	c.filtered = nil
+}
+
+// skip records the plugin got skipped as the cycle overran its deadline
+func (c *summaryCollector) skip(profile, plugin string, extensionPoint frameworktypes.ExtensionPoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.summaries = append(c.summaries, PluginSummary{
+		Profile:        profile,
+		ProfileLabels:  c.profileLabels,
+		Plugin:         plugin,
+		ExtensionPoint: extensionPoint,
+		Overrun:        OverrunSkipped,
+	})
+}
+
+// observeFilter records the result of a filter, each pod being counted once per result
+func (c *summaryCollector) observeFilter(pod *v1.Pod, result string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current == nil {
+		return
+	}
+	if c.filtered[result] == nil {
+		c.filtered[result] = sets.New[string]()
+	}
+	key := pod.Namespace + "/" + pod.Name
+	if c.filtered[result].Has(key) {
+		return
+	}
+	c.filtered[result].Insert(key)
+	if result == "" {
+		c.current.Candidates++
+	} else {
+		c.current.Skipped[result]++
+	}
+}
+
+func (c *summaryCollector) observeEviction(pod *v1.Pod, err error) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current == nil {
+		return
+	}
+	result := EvictionResultEvicted
+	if err != nil {
+		result = evictionSkipReason(err)
+		c.current.Skipped[result]++
+	}
+	if c.getPodsAssignedToNode != nil {
+		c.current.Evictions = append(c.current.Evictions, c.predictEviction(pod, result))
+	}
+}
+
+func evictionSkipReason(err error) string {
+	switch err.(type) {
+	case *evictions.EvictionNodeLimitError:
+		return SkippedByNodeLimit
+	case *evictions.EvictionNamespaceLimitError:
+		return SkippedByNamespaceLimit
+	case *evictions.EvictionTotalLimitError:
+		return SkippedByTotalLimit
+	case *evictions.EvictionAlreadyProcessedError:
+		return SkippedAlreadyProcessed
+	case *evictions.EvictionNamespaceDisabledError:
+		return SkippedByNamespaceMode
+	default:
+		return SkippedByEvictionError
+	}
+}
+
+// predictEviction simulates where the pod may get scheduled onto, among the nodes other than its own
+func (c *summaryCollector) predictEviction(pod *v1.Pod, result string) EvictionSummary {
+	eviction := EvictionSummary{
+		Pod:    pod.Namespace + "/" + pod.Name,
+		Node:   pod.Spec.NodeName,
+		Result: result,
+	}
+	for _, node := range c.nodes {
+		if node.Name == pod.Spec.NodeName || nodeutil.NodeFit(c.getPodsAssignedToNode, pod, node) != nil {
+			continue
+		}
+		eviction.FittingNodes++
+		if len(eviction.Destinations) < maxPredictedDestinations {
+			eviction.Destinations = append(eviction.Destinations, node.Name)
+		}
+	}
+	return eviction
+}
+
+// Summaries returns the summaries of the plugins the profile ran so far, in the order they ran
+func (d profileImpl) Summaries() []PluginSummary {
+	d.collector.mu.Lock()
+	defer d.collector.mu.Unlock()
+	return append([]PluginSummary(nil), d.collector.summaries...)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	testutils "sigs.k8s.io/descheduler/test"
+)
+
+func TestSummaryCollector(t *testing.T) {
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := testutils.BuildTestNode("n2", 2000, 3000, 10, nil)
+	p1 := testutils.BuildTestPod("p1", 100, 0, n1.Name, nil)
+	p2 := testutils.BuildTestPod("p2", 100, 0, n1.Name, nil)
+	p3 := testutils.BuildTestPod("p3", 100, 0, n2.Name, nil)
+
+	collector := &summaryCollector{}
+	prfl := profileImpl{collector: collector}
+
+	collector.start("profile", "FirstPlugin", frameworktypes.DescheduleExtensionPoint, []*v1.Node{n1, n2})
+	// pods filtered several times are counted once
+	collector.observeFilter(p1, "")
+	collector.observeFilter(p1, "")
+	collector.observeFilter(p2, "")
+	collector.observeFilter(p3, SkippedByFilter)
+	collector.observeFilter(p3, SkippedByFilter)
+	collector.observeFilter(p2, SkippedByPreEvictionFilter)
+	collector.observeEviction(p1, nil)
+	collector.observeEviction(p2, evictions.NewEvictionNodeLimitError(n1.Name))
+	collector.finish(1, 1500*time.Millisecond, nil, map[string]string{"savings": "1.50/h"})
+
+	collector.start("profile", "SecondPlugin", frameworktypes.BalanceExtensionPoint, []*v1.Node{n1})
+	collector.finish(0, time.Second, &frameworktypes.Status{Err: fmt.Errorf("plugin failed")}, map[string]string{})
+
+	// observations outside of a plugin run are ignored
+	collector.observeFilter(p1, "")
+	collector.observeEviction(p1, evictions.NewEvictionTotalLimitError())
+
+	expected := []PluginSummary{
+		{
+			Profile:        "profile",
+			Plugin:         "FirstPlugin",
+			ExtensionPoint: frameworktypes.DescheduleExtensionPoint,
+			Nodes:          2,
+			Candidates:     2,
+			Evicted:        1,
+			Skipped: map[string]int{
+				SkippedByFilter:            1,
+				SkippedByPreEvictionFilter: 1,
+				SkippedByNodeLimit:         1,
+			},
+			Duration: metav1.Duration{Duration: 1500 * time.Millisecond},
+			Details:  map[string]string{"savings": "1.50/h"},
+		},
+		{
+			Profile:        "profile",
+			Plugin:         "SecondPlugin",
+			ExtensionPoint: frameworktypes.BalanceExtensionPoint,
+			Nodes:          1,
+			Duration:       metav1.Duration{Duration: time.Second},
+			Error:          "plugin failed",
+		},
+	}
+	if diff := cmp.Diff(expected, prfl.Summaries()); diff != "" {
+		t.Errorf("Unexpected summaries (-want,+got):\n%s", diff)
+	}
+}
+
+func TestSummaryCollectorPredictions(t *testing.T) {
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := testutils.BuildTestNode("n2", 2000, 3000, 10, nil)
+	n3 := testutils.BuildTestNode("n3", 2000, 3000, 10, func(node *v1.Node) {
+		node.Spec.Taints = []v1.Taint{{Key: "dedicated", Effect: v1.TaintEffectNoSchedule}}
+	})
+	n4 := testutils.BuildTestNode("n4", 500, 3000, 10, nil)
+	p1 := testutils.BuildTestPod("p1", 1000, 0, n1.Name, nil)
+	p2 := testutils.BuildTestPod("p2", 100, 0, n1.Name, nil)
+	p3 := testutils.BuildTestPod("p3", 1500, 0, n2.Name, nil)
+
+	podsByNode := map[string][]*v1.Pod{n1.Name: {p1, p2}, n2.Name: {p3}}
+	collector := &summaryCollector{
+		getPodsAssignedToNode: func(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+			var pods []*v1.Pod
+			for _, pod := range podsByNode[nodeName] {
+				if filter == nil || filter(pod) {
+					pods = append(pods, pod)
+				}
+			}
+			return pods, nil
+		},
+	}
+	prfl := profileImpl{collector: collector}
+
+	collector.setNodes([]*v1.Node{n1, n2, n3, n4})
+	collector.start("profile", "Plugin", frameworktypes.DescheduleExtensionPoint, []*v1.Node{n1})
+	collector.observeEviction(p1, nil)
+	collector.observeEviction(p2, evictions.NewEvictionNodeLimitError(n1.Name))
+	collector.finish(1, time.Second, nil, nil)
+
+	expected := []EvictionSummary{
+		// n2 lacks cpu, n3 is tainted and n4 too small
+		{Pod: "default/p1", Node: n1.Name, Result: EvictionResultEvicted},
+		{Pod: "default/p2", Node: n1.Name, Result: SkippedByNodeLimit, Destinations: []string{n2.Name, n4.Name}, FittingNodes: 2},
+	}
+	if diff := cmp.Diff(expected, prfl.Summaries()[0].Evictions); diff != "" {
+		t.Errorf("Unexpected evictions (-want,+got):\n%s", diff)
+	}
+}
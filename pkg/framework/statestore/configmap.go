@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statestore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+// configMapStore persists the state in the data of a ConfigMap, created on the first write.
+// The data is read once and then served from memory, the descheduler being the only writer.
+type configMapStore struct {
+	client    clientset.Interface
+	namespace string
+	name      string
+	// readOnly keeps the writes in memory, e.g. in dry run mode
+	readOnly bool
+
+	mu   sync.Mutex
+	data map[string]string
+}
+
+var _ frameworktypes.StateStore = &configMapStore{}
+
+// NewConfigMapStore returns a store persisting the state in the given ConfigMap
+func NewConfigMapStore(client clientset.Interface, namespace, name string, readOnly bool) frameworktypes.StateStore {
+	return &configMapStore{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+		readOnly:  readOnly,
+	}
+}
+
+// load reads the data of the ConfigMap unless already read. The caller is expected to hold s.mu.
+func (s *configMapStore) load(ctx context.Context) error {
+	if s.data != nil {
+		return nil
+	}
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to read the state from ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	s.data = map[string]string{}
+	if err == nil {
+		for key, value := range cm.Data {
+			s.data[key] = value
+		}
+	}
+	return nil
+}
+
+func (s *configMapStore) Get(ctx context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(ctx); err != nil {
+		return "", false, err
+	}
+	value, ok := s.data[key]
+	return value, ok, nil
+}
+
+func (s *configMapStore) Set(ctx context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(ctx); err != nil {
+		return err
+	}
+	if current, ok := s.data[key]; ok && current == value {
+		return nil
+	}
+	if err := s.write(ctx, func(data map[string]string) { data[key] = value }); err != nil {
+		return err
+	}
+	s.data[key] = value
+	return nil
+}
+
+func (s *configMapStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(ctx); err != nil {
+		return err
+	}
+	if _, ok := s.data[key]; !ok {
+		return nil
+	}
+	if err := s.write(ctx, func(data map[string]string) { delete(data, key) }); err != nil {
+		return err
+	}
+	delete(s.data, key)
+	return nil
+}
+
+// write applies the mutation to the data of the ConfigMap, creating it when missing.
+// The caller is expected to hold s.mu.
+func (s *configMapStore) write(ctx context.Context, mutate func(map[string]string)) error {
+	if s.readOnly {
+		return nil
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			cm = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name},
+				Data:       map[string]string{},
+			}
+			mutate(cm.Data)
+			_, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(ctx, cm, metav1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				return apierrors.NewConflict(v1.Resource("configmaps"), s.name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		mutate(cm.Data)
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write the state to ConfigMap %s/%s: %v", s.namespace, s.name, err)
+	}
+	return nil
+}
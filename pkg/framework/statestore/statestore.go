@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statestore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+// memoryStore keeps the state in memory, it is lost when the descheduler restarts
+type memoryStore struct {
+	mu   sync.RWMutex
+	data map[string]string
+}
+
+var _ frameworktypes.StateStore = &memoryStore{}
+
+// NewMemoryStore returns a store keeping the state in memory only
+func NewMemoryStore() frameworktypes.StateStore {
+	return &memoryStore{data: map[string]string{}}
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (string, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.data[key]
+	return value, ok, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+// scopedStore prefixes the keys so plugins sharing a store do not overwrite
+// the state of each other
+type scopedStore struct {
+	store  frameworktypes.StateStore
+	prefix string
+}
+
+var _ frameworktypes.StateStore = &scopedStore{}
+
+// Scoped returns a view of the store whose keys are prefixed with the scopes,
+// e.g. the profile and plugin names, joined by dots
+func Scoped(store frameworktypes.StateStore, scopes ...string) frameworktypes.StateStore {
+	return &scopedStore{store: store, prefix: strings.Join(scopes, ".") + "."}
+}
+
+func (s *scopedStore) key(key string) (string, error) {
+	scopedKey := s.prefix + key
+	if errs := validation.IsConfigMapKey(scopedKey); len(errs) > 0 {
+		return "", fmt.Errorf("invalid state key %q: %s", scopedKey, strings.Join(errs, ", "))
+	}
+	return scopedKey, nil
+}
+
+func (s *scopedStore) Get(ctx context.Context, key string) (string, bool, error) {
+	scopedKey, err := s.key(key)
+	if err != nil {
+		return "", false, err
+	}
+	return s.store.Get(ctx, scopedKey)
+}
+
+func (s *scopedStore) Set(ctx context.Context, key, value string) error {
+	scopedKey, err := s.key(key)
+	if err != nil {
+		return err
+	}
+	return s.store.Set(ctx, scopedKey, value)
+}
+
+func (s *scopedStore) Delete(ctx context.Context, key string) error {
+	scopedKey, err := s.key(key)
+	if err != nil {
+		return err
+	}
+	return s.store.Delete(ctx, scopedKey)
+}
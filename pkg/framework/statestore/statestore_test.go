@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statestore
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestScopedStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	first := Scoped(store, "profile", "PluginA")
+	second := Scoped(store, "profile", "PluginB")
+
+	if err := first.Set(ctx, "cooldown", "1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok, _ := second.Get(ctx, "cooldown"); ok {
+		t.Errorf("Expected the key of a plugin not to be visible to another plugin")
+	}
+	if value, ok, _ := store.Get(ctx, "profile.PluginA.cooldown"); !ok || value != "1" {
+		t.Errorf("Expected the key to be prefixed with the profile and plugin names, got %q", value)
+	}
+	if err := first.Set(ctx, "node/worker-1", "1"); err == nil {
+		t.Errorf("Expected an error for a key which is not a valid ConfigMap key")
+	}
+	if err := first.Delete(ctx, "cooldown"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok, _ := first.Get(ctx, "cooldown"); ok {
+		t.Errorf("Expected the key to be deleted")
+	}
+}
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset()
+
+	store := NewConfigMapStore(client, "kube-system", "descheduler-state", false)
+	if _, ok, err := store.Get(ctx, "key"); err != nil || ok {
+		t.Fatalf("Expected no value from a missing ConfigMap, got ok=%v err=%v", ok, err)
+	}
+	if err := store.Set(ctx, "key", "value"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := store.Set(ctx, "other", "value"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := store.Delete(ctx, "other"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cm, err := client.CoreV1().ConfigMaps("kube-system").Get(ctx, "descheduler-state", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the ConfigMap to be created: %v", err)
+	}
+	if len(cm.Data) != 1 || cm.Data["key"] != "value" {
+		t.Errorf("Unexpected ConfigMap data: %v", cm.Data)
+	}
+
+	// A new store, e.g. after a restart, reads the persisted state back
+	restarted := NewConfigMapStore(client, "kube-system", "descheduler-state", false)
+	if value, ok, err := restarted.Get(ctx, "key"); err != nil || !ok || value != "value" {
+		t.Errorf("Expected the persisted value, got %q ok=%v err=%v", value, ok, err)
+	}
+}
+
+func TestConfigMapStoreReadOnly(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "descheduler-state"},
+		Data:       map[string]string{"key": "persisted"},
+	})
+
+	store := NewConfigMapStore(client, "kube-system", "descheduler-state", true)
+	if err := store.Set(ctx, "key", "local"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value, _, _ := store.Get(ctx, "key"); value != "local" {
+		t.Errorf("Expected the local value, got %q", value)
+	}
+	cm, err := client.CoreV1().ConfigMaps("kube-system").Get(ctx, "descheduler-state", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cm.Data["key"] != "persisted" {
+		t.Errorf("Expected a read-only store not to write the ConfigMap, got %q", cm.Data["key"])
+	}
+}
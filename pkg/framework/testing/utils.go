@@ -10,9 +10,11 @@ import (
 
 	clientset "k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/statestore"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
 
@@ -25,6 +27,8 @@ func InitFrameworkHandle(
 ) (*frameworkfake.HandleImpl, *evictions.PodEvictor, error) {
 	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
 	podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+	// The node snapshot of the handle is built out of the node lister
+	sharedInformerFactory.Core().V1().Nodes().Informer()
 	podsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Build get pods assigned to node function error: %v", err)
@@ -62,5 +66,8 @@ func InitFrameworkHandle(
 		PodEvictorImpl:                podEvictor,
 		EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
 		SharedInformerFactoryImpl:     sharedInformerFactory,
+		UtilizationProviderImpl:       nodeutil.NewRequestsUtilizationProvider(getPodsAssignedToNode),
+		EventRecorderImpl:             eventRecorder,
+		StateStoreImpl:                statestore.NewMemoryStore(),
 	}, podEvictor, nil
 }
@@ -18,12 +18,17 @@ package types
 
 import (
 	"context"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 )
 
@@ -36,6 +41,51 @@ type Handle interface {
 	Evictor() Evictor
 	GetPodsAssignedToNodeFunc() podutil.GetPodsAssignedToNodeFunc
 	SharedInformerFactory() informers.SharedInformerFactory
+	// ClusterInformerFactory returns the informer factory reading the cluster. It differs from the
+	// SharedInformerFactory in dry run mode only, where the latter reads the cached client holding
+	// the pods, nodes and namespaces the evictions are simulated on. Plugins read the objects the
+	// descheduler does not change, e.g. secrets or workloads, from it to decide as they would for real.
+	ClusterInformerFactory() informers.SharedInformerFactory
+	UtilizationProvider() UtilizationProvider
+	// EventRecorder returns a recorder for plugins to report what they did
+	// on objects they do not evict (e.g. workloads owning the pods).
+	EventRecorder() events.EventRecorder
+	// ProcessedPods returns the pods already evicted, or queued for eviction,
+	// by any plugin in the current descheduling cycle. The evictor filters
+	// skip them so plugins do not need to check it before evicting.
+	ProcessedPods() *evictions.ProcessedPods
+	// ReadyNodes returns the ready nodes matching the selector among the nodes
+	// the descheduler operates over, so plugins working on a subset of the nodes
+	// do not need to filter the whole node list they are given.
+	ReadyNodes(selector labels.Selector) ([]*v1.Node, error)
+	// NodeSnapshot returns the snapshot of the nodes the plugins run over in the
+	// current descheduling cycle, shared by all the plugins so they look the
+	// nodes up without building their own index and see the same node data.
+	NodeSnapshot() *nodeutil.NodeSnapshot
+	// StateStore returns a store for plugins to keep small state, e.g. cooldowns or
+	// flap history, across descheduling cycles and restarts of the descheduler.
+	// The keys are scoped to the plugin and its profile.
+	StateStore() StateStore
+}
+
+// StateStore keeps string values by key. Keys must be valid ConfigMap keys,
+// i.e. made of alphanumeric characters, '-', '_' or '.'.
+type StateStore interface {
+	// Get returns the value of the key, and whether the key is set.
+	Get(ctx context.Context, key string) (string, bool, error)
+	// Set sets the value of the key.
+	Set(ctx context.Context, key, value string) error
+	// Delete removes the key, it is not an error when the key is not set.
+	Delete(ctx context.Context, key string) error
+}
+
+// UtilizationProvider computes how much of a node's resources is in use,
+// so balance plugins can share the same view of the cluster utilization
+// regardless of where the data comes from (pod requests, metrics, ...).
+type UtilizationProvider interface {
+	// NodeUtilization returns the usage of the given resources on a node.
+	// The usage of v1.ResourcePods is the number of pods assigned to the node.
+	NodeUtilization(ctx context.Context, node *v1.Node, resourceNames []v1.ResourceName) (map[v1.ResourceName]*resource.Quantity, error)
 }
 
 // Evictor defines an interface for filtering and evicting pods
@@ -71,6 +121,17 @@ type BalancePlugin interface {
 	Balance(ctx context.Context, nodes []*v1.Node) *Status
 }
 
+// MultiPassBalancePlugin is implemented by balance plugins converging over several passes, e.g. because
+// each pass evicts pods picked from the utilization the nodes had before the pass. The framework runs
+// their Balance extension point again within the descheduling cycle while the last pass evicted pods,
+// once the evicted pods left the nodes and on the nodes refreshed from the cache.
+type MultiPassBalancePlugin interface {
+	BalancePlugin
+	// BalancePasses returns the maximum number of passes per descheduling cycle, and how long to wait at
+	// most before each extra pass for the evicted pods to leave the nodes. The passes stop when they do not.
+	BalancePasses() (maxPasses int, settleTimeout time.Duration)
+}
+
 // EvictorPlugin defines extension points for a general evictor behavior
 // Even though we name this plugin interface EvictorPlugin, it does not actually evict anything,
 // This plugin is only meant to customize other actions (extension points) of the evictor,
@@ -81,6 +142,25 @@ type EvictorPlugin interface {
 	PreEvictionFilter(pod *v1.Pod) bool
 }
 
+// PodFieldsRequirer is implemented by the arguments of plugins which read pod fields
+// the pod cache can be configured to drop, so the cache keeps them.
+type PodFieldsRequirer interface {
+	RequiredPodFields() podutil.PodFields
+}
+
+// AllPodsRequirer is implemented by the arguments of plugins which need to see every pod of
+// the nodes, e.g. to compute their utilization, so a pod cache restricted with selectors is
+// rejected when such a plugin is configured.
+type AllPodsRequirer interface {
+	RequiresAllPods() bool
+}
+
+// SummaryReporter is implemented by plugins reporting details about their last run, e.g. the savings
+// their evictions are estimated to bring, which are added to their summary of the descheduling cycle.
+type SummaryReporter interface {
+	SummaryDetails() map[string]string
+}
+
 type ExtensionPoint string
 
 const (
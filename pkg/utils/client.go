@@ -0,0 +1,29 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// SupportsRawRequests checks whether raw requests, e.g. to read custom resources, can be sent
+// through the client. The fake client the plugins run with in dry run mode can not send them.
+func SupportsRawRequests(client clientset.Interface) bool {
+	restClient, ok := client.CoreV1().RESTClient().(*rest.RESTClient)
+	return !ok || restClient != nil
+}
@@ -2,14 +2,143 @@ package utils
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/events"
 )
 
-func GetRecorderAndBroadcaster(ctx context.Context, clientset clientset.Interface) (events.EventBroadcasterAdapter, events.EventRecorder) {
-	eventBroadcaster := events.NewEventBroadcasterAdapter(clientset)
+func GetRecorderAndBroadcaster(ctx context.Context, clientset clientset.Interface) (events.EventBroadcaster, events.EventRecorder) {
+	eventBroadcaster := events.NewBroadcaster(&events.EventSinkImpl{Interface: clientset.EventsV1()})
 	eventBroadcaster.StartRecordingToSink(ctx.Done())
-	eventRecorder := eventBroadcaster.NewRecorder("sigs.k8s.io.descheduler")
+	eventRecorder := eventBroadcaster.NewRecorder(scheme.Scheme, "sigs.k8s.io.descheduler")
 	return eventBroadcaster, eventRecorder
 }
+
+// EventVerbosity tells which events are emitted
+type EventVerbosity string
+
+const (
+	// EventVerbosityPerEviction emits all the events, e.g. one per evicted pod
+	EventVerbosityPerEviction EventVerbosity = "per-eviction"
+	// EventVerbosityPerCycle emits the warnings and sums the other events up in one event per namespace and descheduling cycle
+	EventVerbosityPerCycle EventVerbosity = "per-cycle"
+	// EventVerbosityErrorsOnly emits the warnings only, e.g. failed evictions
+	EventVerbosityErrorsOnly EventVerbosity = "errors-only"
+	// EventVerbosityNone emits no event
+	EventVerbosityNone EventVerbosity = "none"
+)
+
+// CycleSummaryEventReason is the reason of the events summing up a descheduling cycle
+const CycleSummaryEventReason = "DeschedulingCycle"
+
+// ValidateEventVerbosity returns an error if the verbosity is not one of the known verbosities
+func ValidateEventVerbosity(verbosity EventVerbosity) error {
+	switch verbosity {
+	case EventVerbosityPerEviction, EventVerbosityPerCycle, EventVerbosityErrorsOnly, EventVerbosityNone:
+		return nil
+	}
+	return fmt.Errorf("unsupported event verbosity %q, must be one of: %s, %s, %s, %s", verbosity, EventVerbosityPerEviction, EventVerbosityPerCycle, EventVerbosityErrorsOnly, EventVerbosityNone)
+}
+
+// VerbosityRecorder filters the events sent to a recorder by the verbosity configured for the
+// namespace of the object they are about, falling back to a default verbosity. Events of
+// namespaces with the per-cycle verbosity are counted and sent once Flush is called.
+type VerbosityRecorder struct {
+	recorder             events.EventRecorder
+	verbosity            EventVerbosity
+	namespaceVerbosities map[string]EventVerbosity
+
+	mu sync.Mutex
+	// cycleEvents counts the events of the current cycle by namespace and reason
+	cycleEvents map[string]map[string]int
+}
+
+var _ events.EventRecorder = &VerbosityRecorder{}
+
+// NewVerbosityRecorder returns a recorder emitting events through the given recorder with the given verbosities
+func NewVerbosityRecorder(recorder events.EventRecorder, verbosity EventVerbosity, namespaceVerbosities map[string]EventVerbosity) *VerbosityRecorder {
+	return &VerbosityRecorder{
+		recorder:             recorder,
+		verbosity:            verbosity,
+		namespaceVerbosities: namespaceVerbosities,
+		cycleEvents:          map[string]map[string]int{},
+	}
+}
+
+// Eventf sends, counts or drops the event depending on the verbosity of the namespace of the regarding object
+func (r *VerbosityRecorder) Eventf(regarding runtime.Object, related runtime.Object, eventtype, reason, action, note string, args ...interface{}) {
+	namespace := objectNamespace(regarding)
+	verbosity, ok := r.namespaceVerbosities[namespace]
+	if !ok {
+		verbosity = r.verbosity
+	}
+
+	switch verbosity {
+	case EventVerbosityNone:
+		return
+	case EventVerbosityErrorsOnly:
+		if eventtype != v1.EventTypeWarning {
+			return
+		}
+	case EventVerbosityPerCycle:
+		// Events of cluster scoped objects have no namespace to be summed up in
+		if eventtype != v1.EventTypeWarning && namespace != "" {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if r.cycleEvents[namespace] == nil {
+				r.cycleEvents[namespace] = map[string]int{}
+			}
+			r.cycleEvents[namespace][reason]++
+			return
+		}
+	}
+	r.recorder.Eventf(regarding, related, eventtype, reason, action, note, args...)
+}
+
+// Flush emits one event per namespace summing up the events counted since the last flush.
+// The summaries are about the Namespace objects.
+func (r *VerbosityRecorder) Flush() {
+	r.mu.Lock()
+	cycleEvents := r.cycleEvents
+	r.cycleEvents = map[string]map[string]int{}
+	r.mu.Unlock()
+
+	for namespace, reasons := range cycleEvents {
+		total := 0
+		counts := make([]string, 0, len(reasons))
+		for reason, count := range reasons {
+			total += count
+			counts = append(counts, fmt.Sprintf("%s: %d", reason, count))
+		}
+		sort.Strings(counts)
+		r.recorder.Eventf(
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+			nil,
+			v1.EventTypeNormal,
+			CycleSummaryEventReason,
+			"Descheduled",
+			"%d events in the descheduling cycle by sigs.k8s.io/descheduler (%s)",
+			total,
+			strings.Join(counts, ", "),
+		)
+	}
+}
+
+func objectNamespace(obj runtime.Object) string {
+	if ref, ok := obj.(*v1.ObjectReference); ok {
+		return ref.Namespace
+	}
+	if accessor, err := meta.Accessor(obj); err == nil {
+		return accessor.GetNamespace()
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+)
+
+func TestVerbosityRecorder(t *testing.T) {
+	pod := func(namespace string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: namespace}}
+	}
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}}
+
+	testCases := []struct {
+		name                 string
+		verbosity            EventVerbosity
+		namespaceVerbosities map[string]EventVerbosity
+		expectedEvents       []string
+	}{
+		{
+			name:      "per-eviction emits all the events",
+			verbosity: EventVerbosityPerEviction,
+			expectedEvents: []string{
+				"Normal Evicted evicted",
+				"Normal Evicted evicted",
+				"Warning EvictionFailed failed",
+				"Normal Cordoned cordoned",
+			},
+		},
+		{
+			name:      "errors-only emits the warnings",
+			verbosity: EventVerbosityErrorsOnly,
+			expectedEvents: []string{
+				"Warning EvictionFailed failed",
+			},
+		},
+		{
+			name:           "none emits no event",
+			verbosity:      EventVerbosityNone,
+			expectedEvents: nil,
+		},
+		{
+			name:      "per-cycle sums the namespaced events up on flush",
+			verbosity: EventVerbosityPerCycle,
+			expectedEvents: []string{
+				"Warning EvictionFailed failed",
+				"Normal Cordoned cordoned",
+				"Normal DeschedulingCycle 1 events in the descheduling cycle by sigs.k8s.io/descheduler (Evicted: 1)",
+				"Normal DeschedulingCycle 1 events in the descheduling cycle by sigs.k8s.io/descheduler (Evicted: 1)",
+			},
+		},
+		{
+			name:      "namespace verbosity overrides the default one",
+			verbosity: EventVerbosityPerEviction,
+			namespaceVerbosities: map[string]EventVerbosity{
+				"ns1": EventVerbosityNone,
+				"":    EventVerbosityNone,
+			},
+			expectedEvents: []string{
+				"Normal Evicted evicted",
+				"Warning EvictionFailed failed",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeRecorder := events.NewFakeRecorder(10)
+			recorder := NewVerbosityRecorder(fakeRecorder, tc.verbosity, tc.namespaceVerbosities)
+
+			recorder.Eventf(pod("ns1"), nil, v1.EventTypeNormal, "Evicted", "Descheduled", "evicted")
+			recorder.Eventf(pod("ns2"), nil, v1.EventTypeNormal, "Evicted", "Descheduled", "evicted")
+			recorder.Eventf(pod("ns2"), nil, v1.EventTypeWarning, "EvictionFailed", "Descheduled", "failed")
+			recorder.Eventf(node, nil, v1.EventTypeNormal, "Cordoned", "Descheduled", "cordoned")
+			recorder.Flush()
+			close(fakeRecorder.Events)
+
+			var got []string
+			for event := range fakeRecorder.Events {
+				got = append(got, event)
+			}
+			// The summaries of the namespaces are emitted in no particular order
+			if diff := cmp.Diff(tc.expectedEvents, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Got unexpected events (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
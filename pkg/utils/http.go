@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultHTTPTimeout bounds the requests of the HTTP clients configured without a timeout
+const DefaultHTTPTimeout = 10 * time.Second
+
+// NewHTTPClient returns an HTTP client giving up on the requests after the timeout,
+// DefaultHTTPTimeout when not set, so an unresponsive endpoint can not stall a descheduling cycle.
+func NewHTTPClient(timeout *metav1.Duration) *http.Client {
+	client := &http.Client{Timeout: DefaultHTTPTimeout}
+	if timeout != nil {
+		client.Timeout = timeout.Duration
+	}
+	return client
+}
+
+// SetBearerToken authorizes the request with the token read from the file.
+// The file is read on every request so rotated tokens get picked up.
+func SetBearerToken(req *http.Request, tokenFile string) error {
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return fmt.Errorf("unable to read the bearer token: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	return nil
+}
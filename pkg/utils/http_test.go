@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	if timeout := NewHTTPClient(nil).Timeout; timeout != DefaultHTTPTimeout {
+		t.Errorf("Expected the default timeout, got %v", timeout)
+	}
+	if timeout := NewHTTPClient(&metav1.Duration{Duration: time.Second}).Timeout; timeout != time.Second {
+		t.Errorf("Expected a timeout of 1s, got %v", timeout)
+	}
+}
+
+func TestSetBearerToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetBearerToken(req, tokenFile); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if header := req.Header.Get("Authorization"); header != "Bearer secret" {
+		t.Errorf("Expected the token to be set, got %q", header)
+	}
+	if err := SetBearerToken(req, filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("Expected an error for a missing token file")
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// NormalizeImageReference expands an image reference the way the container runtimes do,
+// e.g. nginx becomes docker.io/library/nginx:latest, so it matches the names reported by the nodes
+func NormalizeImageReference(image string) string {
+	name := image
+	if slash := strings.Index(image, "/"); slash < 0 {
+		name = "docker.io/library/" + image
+	} else if domain := image[:slash]; !strings.ContainsAny(domain, ".:") && domain != "localhost" {
+		name = "docker.io/" + image
+	}
+	if !strings.Contains(name, "@") && !strings.Contains(name[strings.LastIndex(name, "/")+1:], ":") {
+		name += ":latest"
+	}
+	return name
+}
+
+// NodeImages returns the normalized names of the images cached by the node with their size
+func NodeImages(node *v1.Node) map[string]int64 {
+	images := map[string]int64{}
+	for _, image := range node.Status.Images {
+		for _, name := range image.Names {
+			images[NormalizeImageReference(name)] = image.SizeBytes
+		}
+	}
+	return images
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import "testing"
+
+func TestNormalizeImageReference(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                         "docker.io/library/nginx:latest",
+		"nginx:1.25":                    "docker.io/library/nginx:1.25",
+		"bitnami/redis":                 "docker.io/bitnami/redis:latest",
+		"registry.k8s.io/pause:3.9":     "registry.k8s.io/pause:3.9",
+		"localhost:5000/app":            "localhost:5000/app:latest",
+		"localhost/app@sha256:1234":     "localhost/app@sha256:1234",
+		"docker.io/library/nginx:1.25":  "docker.io/library/nginx:1.25",
+		"quay.io/prometheus/prometheus": "quay.io/prometheus/prometheus:latest",
+	}
+	for image, expected := range tests {
+		if got := NormalizeImageReference(image); got != expected {
+			t.Errorf("expected %q to be normalized to %q, got %q", image, expected, got)
+		}
+	}
+}
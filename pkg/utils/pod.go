@@ -2,10 +2,13 @@ package utils
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 )
 
@@ -37,8 +40,8 @@ func GetResourceRequestQuantity(pod *v1.Pod, resourceName v1.ResourceName) resou
 		requestQuantity = resource.Quantity{Format: resource.DecimalSI}
 	}
 
-	for _, container := range pod.Spec.Containers {
-		if rQuantity, ok := container.Resources.Requests[resourceName]; ok {
+	for i := range pod.Spec.Containers {
+		if rQuantity, ok := containerRequests(pod, &pod.Spec.Containers[i])[resourceName]; ok {
 			requestQuantity.Add(rQuantity)
 		}
 	}
@@ -62,6 +65,38 @@ func GetResourceRequestQuantity(pod *v1.Pod, resourceName v1.ResourceName) resou
 	return requestQuantity
 }
 
+// inPlacePodVerticalScaling tells whether the requests of containers account for in-place resizes
+var inPlacePodVerticalScaling atomic.Bool
+
+// SetInPlacePodVerticalScaling enables the support for in-place pod resizes (InPlacePodVerticalScaling
+// feature). Once enabled, the requests of containers take into account the resources allocated to them
+// by the kubelet, which differ from the requests of their spec until a resize is completed.
+func SetInPlacePodVerticalScaling(enabled bool) {
+	inPlacePodVerticalScaling.Store(enabled)
+}
+
+// containerRequests returns the requests of a container of the pod. As done by the scheduler when
+// in-place pod resizes are supported, the greater of the requests of the spec and the allocated
+// resources is used while a resize is pending, and the allocated resources once it is infeasible.
+func containerRequests(pod *v1.Pod, container *v1.Container) v1.ResourceList {
+	if !inPlacePodVerticalScaling.Load() {
+		return container.Resources.Requests
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != container.Name || status.AllocatedResources == nil {
+			continue
+		}
+		if pod.Status.Resize == v1.PodResizeStatusInfeasible {
+			return status.AllocatedResources
+		}
+		requests := v1.ResourceList{}
+		addResourceList(requests, container.Resources.Requests)
+		maxResourceList(requests, status.AllocatedResources)
+		return requests
+	}
+	return container.Resources.Requests
+}
+
 // IsMirrorPod returns true if the pod is a Mirror Pod.
 func IsMirrorPod(pod *v1.Pod) bool {
 	_, ok := pod.Annotations[v1.MirrorPodAnnotationKey]
@@ -73,6 +108,43 @@ func IsPodTerminating(pod *v1.Pod) bool {
 	return pod.DeletionTimestamp != nil
 }
 
+// GetPodCondition returns the condition of the pod with the given type, nil when not set.
+func GetPodCondition(pod *v1.Pod, conditionType v1.PodConditionType) *v1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsPodReady returns true if the pod has the Ready condition set to true.
+func IsPodReady(pod *v1.Pod) bool {
+	condition := GetPodCondition(pod, v1.PodReady)
+	return condition != nil && condition.Status == v1.ConditionTrue
+}
+
+// IsPodUnschedulable returns true if the scheduler failed to find a node for the pod.
+func IsPodUnschedulable(pod *v1.Pod) bool {
+	if pod.Spec.NodeName != "" || pod.Status.Phase != v1.PodPending {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse && condition.Reason == v1.PodReasonUnschedulable {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPodPriority returns the priority of the pod, defaulting to 0 when not set.
+func GetPodPriority(pod *v1.Pod) int32 {
+	if pod.Spec.Priority == nil {
+		return 0
+	}
+	return *pod.Spec.Priority
+}
+
 // IsStaticPod returns true if the pod is a static pod.
 func IsStaticPod(pod *v1.Pod) bool {
 	source, err := GetPodSource(pod)
@@ -94,6 +166,32 @@ func IsDaemonsetPod(ownerRefList []metav1.OwnerReference) bool {
 	return false
 }
 
+// DaemonSetPodTargetNode returns the node a DaemonSet pod is bound to through the node affinity
+// set by the DaemonSet controller, or an empty string.
+func DaemonSetPodTargetNode(pod *v1.Pod) string {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil || pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, field := range term.MatchFields {
+			if field.Key == metav1.ObjectNameField && field.Operator == v1.NodeSelectorOpIn && len(field.Values) == 1 {
+				return field.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// IsStatefulSetPod returns true if the pod is owned by a StatefulSet.
+func IsStatefulSetPod(ownerRefList []metav1.OwnerReference) bool {
+	for _, ownerRef := range ownerRefList {
+		if ownerRef.Kind == "StatefulSet" {
+			return true
+		}
+	}
+	return false
+}
+
 // IsPodWithLocalStorage returns true if the pod has local storage.
 func IsPodWithLocalStorage(pod *v1.Pod) bool {
 	for _, volume := range pod.Spec.Volumes {
@@ -131,9 +229,9 @@ func GetPodSource(pod *v1.Pod) (string, error) {
 // non-zero quantity.
 func PodRequestsAndLimits(pod *v1.Pod) (reqs, limits v1.ResourceList) {
 	reqs, limits = v1.ResourceList{}, v1.ResourceList{}
-	for _, container := range pod.Spec.Containers {
-		addResourceList(reqs, container.Resources.Requests)
-		addResourceList(limits, container.Resources.Limits)
+	for i := range pod.Spec.Containers {
+		addResourceList(reqs, containerRequests(pod, &pod.Spec.Containers[i]))
+		addResourceList(limits, pod.Spec.Containers[i].Resources.Limits)
 	}
 	// init containers define the minimum of any resource
 	for _, container := range pod.Spec.InitContainers {
@@ -238,3 +336,17 @@ const (
 	RequiredDuringSchedulingIgnoredDuringExecution  NodeAffinityType = "requiredDuringSchedulingIgnoredDuringExecution"
 	PreferredDuringSchedulingIgnoredDuringExecution NodeAffinityType = "preferredDuringSchedulingIgnoredDuringExecution"
 )
+
+// PodDisruptionBudgetsSelecting returns the PodDisruptionBudgets selecting the pod among the budgets of its namespace.
+// A nil selector selects no pod while an empty one selects all the pods of the namespace.
+func PodDisruptionBudgetsSelecting(pod *v1.Pod, pdbs []*policy.PodDisruptionBudget) []*policy.PodDisruptionBudget {
+	var selecting []*policy.PodDisruptionBudget
+	for _, pdb := range pdbs {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		selecting = append(selecting, pdb)
+	}
+	return selecting
+}
@@ -0,0 +1,235 @@
+package utils
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodRequestsWithInPlacePodVerticalScaling(t *testing.T) {
+	buildPod := func(request, allocated string, resize v1.PodResizeStatus) *v1.Pod {
+		pod := &v1.Pod{
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name: "app",
+						Resources: v1.ResourceRequirements{
+							Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(request)},
+						},
+					},
+				},
+			},
+			Status: v1.PodStatus{
+				Resize: resize,
+			},
+		}
+		if allocated != "" {
+			pod.Status.ContainerStatuses = []v1.ContainerStatus{
+				{
+					Name:               "app",
+					AllocatedResources: v1.ResourceList{v1.ResourceCPU: resource.MustParse(allocated)},
+				},
+			}
+		}
+		return pod
+	}
+
+	tests := []struct {
+		description string
+		pod         *v1.Pod
+		enabled     bool
+		expectedCPU string
+	}{
+		{
+			description: "disabled, requests of the spec are used",
+			pod:         buildPod("500m", "200m", v1.PodResizeStatusInProgress),
+			expectedCPU: "500m",
+		},
+		{
+			description: "no allocated resources reported",
+			pod:         buildPod("500m", "", ""),
+			enabled:     true,
+			expectedCPU: "500m",
+		},
+		{
+			description: "resize completed",
+			pod:         buildPod("500m", "500m", ""),
+			enabled:     true,
+			expectedCPU: "500m",
+		},
+		{
+			description: "scale up in progress, the requests of the spec are greater",
+			pod:         buildPod("500m", "200m", v1.PodResizeStatusInProgress),
+			enabled:     true,
+			expectedCPU: "500m",
+		},
+		{
+			description: "scale down in progress, the allocated resources are greater",
+			pod:         buildPod("200m", "500m", v1.PodResizeStatusInProgress),
+			enabled:     true,
+			expectedCPU: "500m",
+		},
+		{
+			description: "infeasible resize, the allocated resources are used",
+			pod:         buildPod("2", "500m", v1.PodResizeStatusInfeasible),
+			enabled:     true,
+			expectedCPU: "500m",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			SetInPlacePodVerticalScaling(tc.enabled)
+			defer SetInPlacePodVerticalScaling(false)
+
+			expected := resource.MustParse(tc.expectedCPU)
+			requests, _ := PodRequestsAndLimits(tc.pod)
+			if cpu := requests[v1.ResourceCPU]; cpu.Cmp(expected) != 0 {
+				t.Errorf("PodRequestsAndLimits: expected %v cpu, got %v", expected.String(), cpu.String())
+			}
+			if cpu := GetResourceRequestQuantity(tc.pod, v1.ResourceCPU); cpu.Cmp(expected) != 0 {
+				t.Errorf("GetResourceRequestQuantity: expected %v cpu, got %v", expected.String(), cpu.String())
+			}
+		})
+	}
+}
+
+func TestPodDisruptionBudgetsSelecting(t *testing.T) {
+	buildPDB := func(name string, selector *metav1.LabelSelector) *policy.PodDisruptionBudget {
+		return &policy.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       policy.PodDisruptionBudgetSpec{Selector: selector},
+		}
+	}
+	pdbs := []*policy.PodDisruptionBudget{
+		buildPDB("web", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}),
+		buildPDB("db", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}),
+		buildPDB("all", &metav1.LabelSelector{}),
+		buildPDB("none", nil),
+		buildPDB("invalid", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}}}),
+	}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "default", Labels: map[string]string{"app": "web"}}}
+
+	var names []string
+	for _, pdb := range PodDisruptionBudgetsSelecting(pod, pdbs) {
+		names = append(names, pdb.Name)
+	}
+	if len(names) != 2 || names[0] != "web" || names[1] != "all" {
+		t.Errorf("Expected the web and all budgets to select the pod, got %v", names)
+	}
+}
+
+func TestPodStatusHelpers(t *testing.T) {
+	priority := int32(1000)
+	tests := []struct {
+		description           string
+		pod                   *v1.Pod
+		expectedReady         bool
+		expectedUnschedulable bool
+		expectedPriority      int32
+	}{
+		{
+			description: "ready pod",
+			pod: &v1.Pod{
+				Spec:   v1.PodSpec{NodeName: "n1", Priority: &priority},
+				Status: v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}},
+			},
+			expectedReady:    true,
+			expectedPriority: 1000,
+		},
+		{
+			description: "running pod not ready",
+			pod: &v1.Pod{
+				Spec:   v1.PodSpec{NodeName: "n1"},
+				Status: v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}}},
+			},
+		},
+		{
+			description: "pending pod the scheduler found no node for",
+			pod: &v1.Pod{
+				Status: v1.PodStatus{Phase: v1.PodPending, Conditions: []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable}}},
+			},
+			expectedUnschedulable: true,
+		},
+		{
+			description: "pending pod bound to a node",
+			pod: &v1.Pod{
+				Spec:   v1.PodSpec{NodeName: "n1"},
+				Status: v1.PodStatus{Phase: v1.PodPending, Conditions: []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionFalse, Reason: v1.PodReasonUnschedulable}}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if ready := IsPodReady(tc.pod); ready != tc.expectedReady {
+				t.Errorf("Expected ready %v, got %v", tc.expectedReady, ready)
+			}
+			if unschedulable := IsPodUnschedulable(tc.pod); unschedulable != tc.expectedUnschedulable {
+				t.Errorf("Expected unschedulable %v, got %v", tc.expectedUnschedulable, unschedulable)
+			}
+			if priority := GetPodPriority(tc.pod); priority != tc.expectedPriority {
+				t.Errorf("Expected priority %d, got %d", tc.expectedPriority, priority)
+			}
+		})
+	}
+}
+
+func TestDaemonSetPodTargetNode(t *testing.T) {
+	withAffinity := func(terms ...v1.NodeSelectorTerm) *v1.Pod {
+		return &v1.Pod{Spec: v1.PodSpec{Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{NodeSelectorTerms: terms},
+		}}}}
+	}
+	tests := []struct {
+		description string
+		pod         *v1.Pod
+		expected    string
+	}{
+		{
+			description: "pod without affinity",
+			pod:         &v1.Pod{},
+		},
+		{
+			description: "pod bound to its node by the DaemonSet controller",
+			pod: withAffinity(v1.NodeSelectorTerm{MatchFields: []v1.NodeSelectorRequirement{
+				{Key: metav1.ObjectNameField, Operator: v1.NodeSelectorOpIn, Values: []string{"n1"}},
+			}}),
+			expected: "n1",
+		},
+		{
+			description: "pod with a node affinity on labels",
+			pod: withAffinity(v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"a"}},
+			}}),
+		},
+		{
+			description: "pod allowed on several nodes",
+			pod: withAffinity(v1.NodeSelectorTerm{MatchFields: []v1.NodeSelectorRequirement{
+				{Key: metav1.ObjectNameField, Operator: v1.NodeSelectorOpIn, Values: []string{"n1", "n2"}},
+			}}),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if node := DaemonSetPodTargetNode(tc.pod); node != tc.expected {
+				t.Errorf("Expected node %q, got %q", tc.expected, node)
+			}
+		})
+	}
+}
+
+func TestGetPodCondition(t *testing.T) {
+	pod := &v1.Pod{Status: v1.PodStatus{Conditions: []v1.PodCondition{
+		{Type: v1.PodScheduled, Status: v1.ConditionTrue},
+		{Type: v1.PodReady, Status: v1.ConditionFalse, Reason: "ContainersNotReady"},
+	}}}
+	if condition := GetPodCondition(pod, v1.PodReady); condition == nil || condition.Reason != "ContainersNotReady" {
+		t.Errorf("Expected the Ready condition of the pod, got %v", condition)
+	}
+	if condition := GetPodCondition(pod, v1.DisruptionTarget); condition != nil {
+		t.Errorf("Expected no DisruptionTarget condition, got %v", condition)
+	}
+}
@@ -325,14 +325,6 @@ func GetNodeWeightGivenPodPreferredAffinity(pod *v1.Pod, node *v1.Node) (int32,
 	return sumWeights, nil
 }
 
-func CreateNodeMap(nodes []*v1.Node) map[string]*v1.Node {
-	m := make(map[string]*v1.Node, len(nodes))
-	for _, node := range nodes {
-		m[node.GetName()] = node
-	}
-	return m
-}
-
 // CheckPodsWithAntiAffinityExist checks if there are other pods on the node that the current candidate pod cannot tolerate.
 func CheckPodsWithAntiAffinityExist(candidatePod *v1.Pod, assignedPods map[string][]*v1.Pod, nodeMap map[string]*v1.Node) bool {
 	nodeHavingCandidatePod, ok := nodeMap[candidatePod.Spec.NodeName]
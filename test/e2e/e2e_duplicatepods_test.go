@@ -188,8 +188,10 @@ func TestRemoveDuplicates(t *testing.T) {
 			}
 
 			plugin, err := removeduplicates.New(&removeduplicates.RemoveDuplicatesArgs{
-				Namespaces: &api.Namespaces{
-					Include: []string{testNamespace.Name},
+				FilteringArgs: api.FilteringArgs{
+					Namespaces: &api.Namespaces{
+						Include: []string{testNamespace.Name},
+					},
 				},
 			},
 				handle,
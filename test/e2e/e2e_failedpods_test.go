@@ -3,6 +3,7 @@ package e2e
 import (
 	"context"
 	"os"
+	"sigs.k8s.io/descheduler/pkg/api"
 	"strings"
 	"testing"
 	"time"
@@ -112,8 +113,10 @@ func TestFailedPods(t *testing.T) {
 				MinPodLifetimeSeconds:   tc.args.MinPodLifetimeSeconds,
 				IncludingInitContainers: tc.args.IncludingInitContainers,
 				ExcludeOwnerKinds:       tc.args.ExcludeOwnerKinds,
-				LabelSelector:           tc.args.LabelSelector,
-				Namespaces:              tc.args.Namespaces,
+				FilteringArgs: api.FilteringArgs{
+					LabelSelector: tc.args.LabelSelector,
+					Namespaces:    tc.args.Namespaces,
+				},
 			},
 				handle,
 			)
@@ -435,8 +435,10 @@ func runPodLifetimePlugin(
 
 	plugin, err := podlifetime.New(&podlifetime.PodLifeTimeArgs{
 		MaxPodLifeTimeSeconds: &maxPodLifeTimeSeconds,
-		LabelSelector:         labelSelector,
-		Namespaces:            namespaces,
+		FilteringArgs: deschedulerapi.FilteringArgs{
+			LabelSelector: labelSelector,
+			Namespaces:    namespaces,
+		},
 	}, handle)
 	if err != nil {
 		t.Fatalf("Unable to initialize the plugin: %v", err)
@@ -56,8 +56,10 @@ func tooManyRestartsPolicy(targetNamespace string, podRestartThresholds int32, i
 							Object: &removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestartsArgs{
 								PodRestartThreshold:     podRestartThresholds,
 								IncludingInitContainers: includingInitContainers,
-								Namespaces: &api.Namespaces{
-									Include: []string{targetNamespace},
+								FilteringArgs: api.FilteringArgs{
+									Namespaces: &api.Namespaces{
+										Include: []string{targetNamespace},
+									},
 								},
 							},
 						},